@@ -171,3 +171,32 @@ func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, e
 	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchTaskEvents returns a watcher that reports the events in the
+// life of a task: changes of its status, and the messages and output
+// it logs. The result strings are json formatted core.actions.TaskEvent
+// objects.
+func (c *Client) WatchTaskEvents(taskID string) (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("watching task events")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewActionTag(taskID).String()},
+		},
+	}
+	err := c.facade.FacadeCall("WatchTaskEvents", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
@@ -188,6 +188,59 @@ func (s *actionSuite) TestWatchActionProgressArity(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "expected 1 result, got 2")
 }
 
+func (s *actionSuite) TestWatchTaskEvents(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "WatchTaskEvents")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{
+						Tag: "action-666",
+					}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+				*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+					Results: []params.StringsWatchResult{{
+						Error: &params.Error{Message: "FAIL"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := action.NewClient(apiCaller)
+	w, err := client.WatchTaskEvents("666")
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *actionSuite) TestWatchTaskEventsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchTaskEvents("666")
+	c.Assert(err, gc.ErrorMatches, "watching task events not supported")
+}
+
 func (s *actionSuite) TestListOperations(c *gc.C) {
 	offset := 100
 	limit := 200
@@ -0,0 +1,89 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedule
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client is the api client for the ActionSchedule facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates an action schedule api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ActionSchedule")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddSchedule adds a schedule on which an action is run.
+func (c *Client) AddSchedule(schedule params.ActionSchedule) error {
+	args := params.AddActionSchedulesArgs{
+		Schedules: []params.ActionSchedule{schedule},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddSchedules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListSchedules returns all the action schedules in the model.
+func (c *Client) ListSchedules() ([]params.ActionSchedule, error) {
+	var result params.ActionSchedulesResult
+	if err := c.facade.FacadeCall("ListSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Schedules, nil
+}
+
+// Schedule returns the named action schedule, including
+// its recent runs.
+func (c *Client) Schedule(name string) (params.ActionSchedule, error) {
+	args := params.ActionScheduleNames{Names: []string{name}}
+	var results params.ActionScheduleResults
+	if err := c.facade.FacadeCall("Schedules", args, &results); err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ActionSchedule{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ActionSchedule{}, result.Error
+	}
+	return *result.Schedule, nil
+}
+
+// Pause pauses runs of the named action schedule.
+func (c *Client) Pause(name string) error {
+	return c.call("Pause", name)
+}
+
+// Resume resumes runs of the named action schedule.
+func (c *Client) Resume(name string) error {
+	return c.call("Resume", name)
+}
+
+// Remove removes the named action schedule.
+func (c *Client) Remove(name string) error {
+	return c.call("Remove", name)
+}
+
+func (c *Client) call(method, name string) error {
+	args := params.ActionScheduleNames{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedule_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionschedule"
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func newClient(c *gc.C, method string, expectArgs interface{}, result interface{}) *actionschedule.Client {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, response interface{}) error {
+		c.Check(objType, gc.Equals, "ActionSchedule")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, method)
+		c.Check(arg, jc.DeepEquals, expectArgs)
+		switch r := response.(type) {
+		case *params.ErrorResults:
+			*r = result.(params.ErrorResults)
+		case *params.ActionSchedulesResult:
+			*r = result.(params.ActionSchedulesResult)
+		case *params.ActionScheduleResults:
+			*r = result.(params.ActionScheduleResults)
+		default:
+			c.Fatalf("unexpected response type %T", response)
+		}
+		return nil
+	})
+	return actionschedule.NewClient(apiCaller)
+}
+
+func (s *clientSuite) TestAddSchedule(c *gc.C) {
+	schedule := params.ActionSchedule{
+		Name:       "nightly-backup",
+		Target:     "mysql/leader",
+		Action:     "backup",
+		Parameters: map[string]interface{}{"outfile": "out.tar.gz"},
+		Cron:       "0 3 * * *",
+	}
+	client := newClient(c, "AddSchedules",
+		params.AddActionSchedulesArgs{Schedules: []params.ActionSchedule{schedule}},
+		params.ErrorResults{Results: []params.ErrorResult{{
+			Error: &params.Error{Message: "boom"},
+		}}},
+	)
+	err := client.AddSchedule(schedule)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestListSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{
+		Name:    "nightly-backup",
+		Target:  "mysql",
+		Action:  "backup",
+		Cron:    "@daily",
+		Created: time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+	}}
+	client := newClient(c, "ListSchedules", nil, params.ActionSchedulesResult{
+		Schedules: schedules,
+	})
+	result, err := client.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedules)
+}
+
+func (s *clientSuite) TestSchedule(c *gc.C) {
+	schedule := params.ActionSchedule{
+		Name:   "nightly-backup",
+		Target: "mysql",
+		Action: "backup",
+		Cron:   "@daily",
+		Runs: []params.ActionScheduleRun{{
+			Scheduled:    time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC),
+			Enqueued:     time.Date(2021, 6, 2, 0, 0, 1, 0, time.UTC),
+			OperationTag: "operation-1",
+		}},
+	}
+	client := newClient(c, "Schedules",
+		params.ActionScheduleNames{Names: []string{"nightly-backup"}},
+		params.ActionScheduleResults{Results: []params.ActionScheduleResult{{
+			Schedule: &schedule,
+		}}},
+	)
+	result, err := client.Schedule("nightly-backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedule)
+}
+
+func (s *clientSuite) TestScheduleNotFound(c *gc.C) {
+	client := newClient(c, "Schedules",
+		params.ActionScheduleNames{Names: []string{"weekly"}},
+		params.ActionScheduleResults{Results: []params.ActionScheduleResult{{
+			Error: &params.Error{Code: params.CodeNotFound, Message: `action schedule "weekly" not found`},
+		}}},
+	)
+	_, err := client.Schedule("weekly")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *clientSuite) TestPauseResumeRemove(c *gc.C) {
+	for _, method := range []string{"Pause", "Resume", "Remove"} {
+		client := newClient(c, method,
+			params.ActionScheduleNames{Names: []string{"nightly-backup"}},
+			params.ErrorResults{Results: []params.ErrorResult{{}}},
+		)
+		var err error
+		switch method {
+		case "Pause":
+			err = client.Pause("nightly-backup")
+		case "Resume":
+			err = client.Resume("nightly-backup")
+		case "Remove":
+			err = client.Remove("nightly-backup")
+		}
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const actionSchedulerFacade = "ActionScheduler"
+
+// API provides access to the ActionScheduler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ActionScheduler facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, actionSchedulerFacade)}
+}
+
+// WatchActionSchedules returns a NotifyWatcher which fires when
+// any action schedule in the model is added, changed or removed.
+func (api *API) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (api *API) ActionSchedules() ([]params.ActionSchedule, error) {
+	var result params.ActionSchedulesResult
+	if err := api.facade.FacadeCall("ActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Schedules, nil
+}
+
+// RunActionSchedules enqueues the specified runs of action schedules,
+// returning a result for each in the same order.
+func (api *API) RunActionSchedules(runs []params.RunActionScheduleArg) ([]params.ActionScheduleRunResult, error) {
+	args := params.RunActionSchedulesArgs{Runs: runs}
+	var results params.ActionScheduleRunResults
+	if err := api.facade.FacadeCall("RunActionSchedules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(runs) {
+		return nil, errors.Errorf("expected %d results, got %d", len(runs), n)
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type actionSchedulerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+func (s *actionSchedulerSuite) TestWatchActionSchedulesError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "WatchActionSchedules",
+		Results: params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		},
+	})
+	_, err := actionscheduler.NewAPI(caller).WatchActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *actionSchedulerSuite) TestActionSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{
+		Name:   "nightly-backup",
+		Target: "mysql",
+		Action: "backup",
+		Cron:   "@daily",
+	}}
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "ActionSchedules",
+		Results:       params.ActionSchedulesResult{Schedules: schedules},
+	})
+	result, err := actionscheduler.NewAPI(caller).ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedules)
+}
+
+func (s *actionSchedulerSuite) TestActionSchedulesCallError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "ActionSchedules",
+		Error:         errors.New("boom"),
+	})
+	_, err := actionscheduler.NewAPI(caller).ActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *actionSchedulerSuite) TestRunActionSchedules(c *gc.C) {
+	due := time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC)
+	runs := []params.RunActionScheduleArg{{Name: "nightly-backup", Scheduled: due}}
+	results := []params.ActionScheduleRunResult{{
+		Run: &params.ActionScheduleRun{
+			Scheduled:    due,
+			Enqueued:     due,
+			OperationTag: "operation-1",
+		},
+	}}
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "RunActionSchedules",
+		Args:          params.RunActionSchedulesArgs{Runs: runs},
+		Results:       params.ActionScheduleRunResults{Results: results},
+	})
+	result, err := actionscheduler.NewAPI(caller).RunActionSchedules(runs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, results)
+}
+
+func (s *actionSchedulerSuite) TestRunActionSchedulesWrongCount(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "RunActionSchedules",
+		Args:          params.RunActionSchedulesArgs{Runs: []params.RunActionScheduleArg{{Name: "a"}}},
+		Results:       params.ActionScheduleRunResults{},
+	})
+	_, err := actionscheduler.NewAPI(caller).RunActionSchedules([]params.RunActionScheduleArg{{Name: "a"}})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/charmstore"
 
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/trace"
 	jujuproxy "github.com/juju/juju/proxy"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
@@ -66,6 +67,7 @@ var logger = loggo.GetLogger("juju.api")
 
 type rpcConnection interface {
 	Call(req rpc.Request, params, response interface{}) error
+	CallContext(ctx context.Context, req rpc.Request, params, response interface{}) error
 	Dead() <-chan struct{}
 	Close() error
 }
@@ -1236,8 +1238,32 @@ var apiCallRetryStrategy = retry.LimitTime(10*time.Second,
 // object id, and the specific RPC method. It marshalls the Arguments, and will
 // unmarshall the result into the response object that is supplied.
 func (s *state) APICall(facade string, vers int, id, method string, args, response interface{}) error {
+	return s.APICallContext(context.Background(), facade, vers, id, method, args, response)
+}
+
+// APICallContext is like APICall, but the call is recorded as part of
+// the trace of the span held by ctx, if any, and is abandoned if ctx
+// is cancelled.
+func (s *state) APICallContext(ctx context.Context, facade string, vers int, id, method string, args, response interface{}) error {
+	// Calls on a specific object (in practice watchers) block
+	// for long periods, so we don't trace them.
+	if id == "" {
+		var span trace.Span
+		ctx, span = trace.Start(ctx, facade+"."+method,
+			trace.StringAttr("rpc.facade", facade),
+			trace.IntAttr("rpc.version", vers),
+		)
+		defer span.End()
+		err := s.apiCall(ctx, facade, vers, id, method, args, response)
+		span.RecordError(err)
+		return err
+	}
+	return s.apiCall(ctx, facade, vers, id, method, args, response)
+}
+
+func (s *state) apiCall(ctx context.Context, facade string, vers int, id, method string, args, response interface{}) error {
 	for a := retry.Start(apiCallRetryStrategy, s.clock); a.Next(); {
-		err := s.client.Call(rpc.Request{
+		err := s.client.CallContext(ctx, rpc.Request{
 			Type:    facade,
 			Version: vers,
 			Id:      id,
@@ -33,6 +33,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/trace"
 	jjtesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
@@ -1105,6 +1106,67 @@ func (s *apiclientSuite) TestAPICallError(c *gc.C) {
 	c.Check(clock.waits, gc.HasLen, 0)
 }
 
+func (s *apiclientSuite) TestAPICallTraced(c *gc.C) {
+	tracer := trace.NewBufferedTracer(clock.WallClock, 10)
+	trace.SetDefaultTracer(tracer)
+	defer trace.SetDefaultTracer(nil)
+
+	rpcConn := newRPCConnection(errors.BadRequestf("boom"))
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         &fakeClock{},
+	})
+
+	err := conn.APICall("facade", 1, "", "method", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	spans, _ := tracer.Drain()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].Name, gc.Equals, "facade.method")
+	c.Check(spans[0].Error, gc.Equals, "boom")
+	c.Check(rpcConn.spanContext, gc.Equals, spans[0].SpanContext)
+}
+
+func (s *apiclientSuite) TestAPICallContextTracedInContextSpan(c *gc.C) {
+	tracer := trace.NewBufferedTracer(clock.WallClock, 10)
+	trace.SetDefaultTracer(tracer)
+	defer trace.SetDefaultTracer(nil)
+
+	rpcConn := newRPCConnection()
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         &fakeClock{},
+	})
+
+	ctx, parent := trace.Start(context.Background(), "worker.Operation")
+	err := conn.APICallContext(ctx, "facade", 1, "", "method", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	parent.End()
+
+	spans, _ := tracer.Drain()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans[0].Name, gc.Equals, "facade.method")
+	c.Check(spans[0].SpanContext.TraceID, gc.Equals, parent.SpanContext().TraceID)
+	c.Check(spans[0].ParentSpanID, gc.Equals, parent.SpanContext().SpanID)
+	c.Check(rpcConn.spanContext, gc.Equals, spans[0].SpanContext)
+}
+
+func (s *apiclientSuite) TestAPICallWithIdNotTraced(c *gc.C) {
+	tracer := trace.NewBufferedTracer(clock.WallClock, 10)
+	trace.SetDefaultTracer(tracer)
+	defer trace.SetDefaultTracer(nil)
+
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: newRPCConnection(),
+		Clock:         &fakeClock{},
+	})
+
+	err := conn.APICall("facade", 1, "id", "method", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	spans, _ := tracer.Drain()
+	c.Assert(spans, gc.HasLen, 0)
+}
+
 func (s *apiclientSuite) TestPing(c *gc.C) {
 	clock := &fakeClock{}
 	rpcConn := newRPCConnection()
@@ -1266,8 +1328,9 @@ func newRPCConnection(errs ...error) *fakeRPCConnection {
 }
 
 type fakeRPCConnection struct {
-	stub     testing.Stub
-	response interface{}
+	stub        testing.Stub
+	response    interface{}
+	spanContext trace.SpanContext
 }
 
 func (f *fakeRPCConnection) Dead() <-chan struct{} {
@@ -1279,7 +1342,12 @@ func (f *fakeRPCConnection) Close() error {
 }
 
 func (f *fakeRPCConnection) Call(req rpc.Request, params, response interface{}) error {
+	return f.CallContext(context.Background(), req, params, response)
+}
+
+func (f *fakeRPCConnection) CallContext(ctx context.Context, req rpc.Request, params, response interface{}) error {
 	f.stub.AddCall(req.Type+"."+req.Action, req.Version, params)
+	f.spanContext = trace.SpanContextFromContext(ctx)
 	if f.response != nil {
 		rv := reflect.ValueOf(response)
 		target := reflect.Indirect(rv)
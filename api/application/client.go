@@ -1276,3 +1276,57 @@ func unitInfoFromParams(in params.UnitInfoResult) UnitInfo {
 	}
 	return info
 }
+
+// UnitEvent describes an event reported by the cloud
+// for the workload of a unit.
+type UnitEvent struct {
+	Type    string
+	Reason  string
+	Message string
+	Count   int
+	Time    time.Time
+}
+
+// UnitEventsResult holds the events for a unit, or an error.
+type UnitEventsResult struct {
+	Events []UnitEvent
+	Error  error
+}
+
+// UnitsEvents retrieves the most recent cloud events
+// for the workloads of the specified units.
+func (c *Client) UnitsEvents(units []names.UnitTag) ([]UnitEventsResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return nil, errors.NotSupportedf("UnitsEvents for Application facade v%v", apiVersion)
+	}
+	all := make([]params.Entity, len(units))
+	for i, one := range units {
+		all[i] = params.Entity{Tag: one.String()}
+	}
+	in := params.Entities{Entities: all}
+	var out params.UnitEventsResults
+	err := c.facade.FacadeCall("UnitsEvents", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), resultsLen)
+	}
+	results := make([]UnitEventsResult, len(out.Results))
+	for i, r := range out.Results {
+		if r.Error != nil {
+			results[i].Error = stderrors.New(r.Error.Error())
+			continue
+		}
+		for _, e := range r.Events {
+			results[i].Events = append(results[i].Events, UnitEvent{
+				Type:    e.Type,
+				Reason:  e.Reason,
+				Message: e.Message,
+				Count:   e.Count,
+				Time:    e.Time,
+			})
+		}
+	}
+	return results, nil
+}
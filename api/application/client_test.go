@@ -1831,6 +1831,72 @@ func (s *applicationSuite) TestUnitsInfoResultMismatch(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
 
+func (s *applicationSuite) TestUnitsEventsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{
+		BestVersion:   13,
+		APICallerFunc: apiCaller,
+	})
+	_, err := client.UnitsEvents(nil)
+	c.Assert(err, gc.ErrorMatches, "UnitsEvents for Application facade v13 not supported")
+}
+
+func (s *applicationSuite) TestUnitsEvents(c *gc.C) {
+	now := time.Now()
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "UnitsEvents")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{
+					{Tag: "unit-foo-0"},
+					{Tag: "unit-bar-1"},
+				}})
+
+			result, ok := response.(*params.UnitEventsResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.UnitEventsResult{
+				{Error: &params.Error{Message: "boom"}},
+				{Events: []params.UnitEvent{{
+					Type:    "Warning",
+					Reason:  "FailedScheduling",
+					Message: "0/1 nodes are available: 1 Insufficient memory.",
+					Count:   2,
+					Time:    now,
+				}}},
+			}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{
+		BestVersion:   14,
+		APICallerFunc: apiCaller,
+	})
+	results, err := client.UnitsEvents([]names.UnitTag{
+		names.NewUnitTag("foo/0"),
+		names.NewUnitTag("bar/1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(results[1], jc.DeepEquals, application.UnitEventsResult{
+		Events: []application.UnitEvent{{
+			Type:    "Warning",
+			Reason:  "FailedScheduling",
+			Message: "0/1 nodes are available: 1 Insufficient memory.",
+			Count:   2,
+			Time:    now,
+		}},
+	})
+}
+
 func (s *applicationSuite) TestExposeVersionChecks(c *gc.C) {
 	specs := []struct {
 		descr            string
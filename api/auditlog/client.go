@@ -0,0 +1,33 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client is the api client for the AuditLog facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates an audit log api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit log entries matching the specified
+// criteria, most recent first.
+func (c *Client) Query(args params.AuditLogQueryArgs) ([]params.AuditLogEntry, error) {
+	var result params.AuditLogQueryResults
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
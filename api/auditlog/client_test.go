@@ -0,0 +1,71 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestQuery(c *gc.C) {
+	after := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	args := params.AuditLogQueryArgs{
+		User:    "fred",
+		Facade:  "Application",
+		Method:  "Deploy",
+		After:   &after,
+		Outcome: "failure",
+		Limit:   10,
+	}
+	entries := []params.AuditLogEntry{{
+		ConversationID: "c1",
+		ConnectionID:   "1A",
+		User:           "fred",
+		RequestID:      2,
+		Time:           after.Add(time.Minute),
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        13,
+		Outcome:        "failure",
+		Errors:         []params.AuditLogError{{Message: "boom"}},
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AuditLog")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Query")
+		c.Check(arg, jc.DeepEquals, args)
+		c.Assert(result, gc.FitsTypeOf, &params.AuditLogQueryResults{})
+		*(result.(*params.AuditLogQueryResults)) = params.AuditLogQueryResults{
+			Results: entries,
+		}
+		return nil
+	})
+	client := auditlog.NewClient(apiCaller)
+	result, err := client.Query(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+}
+
+func (s *clientSuite) TestQueryError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
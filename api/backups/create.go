@@ -11,13 +11,18 @@ import (
 
 // Create sends a request to create a backup of juju's state.  It
 // returns the metadata associated with the resulting backup and a
-// filename for download.
-func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+// filename for download. If an armored OpenPGP encryption key is
+// given, the backup archive is encrypted to it.
+func (c *Client) Create(notes string, keepCopy, noDownload bool, encryptionKey string) (*params.BackupsMetadataResult, error) {
+	if encryptionKey != "" && c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("encrypted backups on this controller")
+	}
 	var result params.BackupsMetadataResult
 	args := params.BackupsCreateArgs{
-		Notes:      notes,
-		KeepCopy:   keepCopy,
-		NoDownload: noDownload,
+		Notes:         notes,
+		KeepCopy:      keepCopy,
+		NoDownload:    noDownload,
+		EncryptionKey: encryptionKey,
 	}
 
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
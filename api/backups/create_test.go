@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -41,9 +42,44 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important", false, false)
+	result, err := s.client.Create("important", false, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Log(result)
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 4,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.EncryptionKey, gc.Equals, "<public key>")
+
+			result := resp.(*params.BackupsMetadataResult)
+			*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
+			result.EncryptedTo = []string{"FINGERPRINT"}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.Create("", false, false, "<public key>")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.EncryptedTo, jc.DeepEquals, []string{"FINGERPRINT"})
+}
+
+func (s *createSuite) TestCreateEncryptedNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 3,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Create("", false, false, "<public key>")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "encrypted backups on this controller not supported")
+}
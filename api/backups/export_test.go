@@ -17,8 +17,14 @@ func ExposeFacade(c *Client) base.FacadeCaller {
 // PatchClientFacadeCall is a cleanup function that returns the client to its
 // original state.
 func PatchClientFacadeCall(c *Client, mockCall func(request string, params interface{}, response interface{}) error) func() {
+	return PatchClientFacadeCallVersion(c, 0, mockCall)
+}
+
+// PatchClientFacadeCallVersion is like PatchClientFacadeCall, but the
+// patched FacadeCaller reports the given facade version.
+func PatchClientFacadeCallVersion(c *Client, version int, mockCall func(request string, params interface{}, response interface{}) error) func() {
 	orig := c.facade
-	c.facade = &resultCaller{mockCall}
+	c.facade = &resultCaller{mockCall, version}
 	return func() {
 		c.facade = orig
 	}
@@ -26,6 +32,7 @@ func PatchClientFacadeCall(c *Client, mockCall func(request string, params inter
 
 type resultCaller struct {
 	mockCall func(request string, params interface{}, response interface{}) error
+	version  int
 }
 
 func (f *resultCaller) FacadeCall(request string, params, response interface{}) error {
@@ -37,7 +44,7 @@ func (f *resultCaller) Name() string {
 }
 
 func (f *resultCaller) BestAPIVersion() int {
-	return 0
+	return f.version
 }
 
 func (f *resultCaller) RawAPICaller() base.APICaller {
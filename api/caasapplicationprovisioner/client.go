@@ -14,6 +14,7 @@ import (
 	charmscommon "github.com/juju/juju/api/common/charms"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/life"
@@ -119,6 +120,9 @@ type ProvisioningInfo struct {
 	ImageRepo            string
 	CharmModifiedVersion int
 	CharmURL             *charm.URL
+	Scale                int
+	Autoscaling          *caas.AutoscalingPolicy
+	NetworkPolicy        *caas.NetworkPolicy
 }
 
 // ProvisioningInfo returns the info needed to provision an operator for an application.
@@ -148,6 +152,22 @@ func (c *Client) ProvisioningInfo(applicationName string) (ProvisioningInfo, err
 		Series:               r.Series,
 		ImageRepo:            r.ImageRepo,
 		CharmModifiedVersion: r.CharmModifiedVersion,
+		Scale:                r.Scale,
+	}
+	if a := r.Autoscaling; a != nil {
+		info.Autoscaling = &caas.AutoscalingPolicy{
+			MinReplicas:             a.MinReplicas,
+			MaxReplicas:             a.MaxReplicas,
+			TargetCPUUtilization:    a.TargetCPUUtilization,
+			TargetMemoryUtilization: a.TargetMemoryUtilization,
+			Metrics:                 a.Metrics,
+		}
+	}
+	if np := r.NetworkPolicy; np != nil {
+		info.NetworkPolicy = &caas.NetworkPolicy{
+			Applications: np.Applications,
+			CIDRs:        np.CIDRs,
+		}
 	}
 
 	for _, fs := range r.Filesystems {
@@ -193,6 +213,7 @@ func filesystemFromParams(in params.KubernetesFilesystemParams) (*storage.Kubern
 		Attributes:   in.Attributes,
 		ResourceTags: in.Tags,
 		Attachment:   attachment,
+		Shared:       in.Shared,
 	}, nil
 }
 
@@ -352,3 +373,22 @@ func (c *Client) UpdateUnits(arg params.UpdateApplicationUnits) (*params.UpdateA
 func (c *Client) WatchApplication(appName string) (watcher.NotifyWatcher, error) {
 	return common.Watch(c.facade, "Watch", names.NewApplicationTag(appName))
 }
+
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the application config of the specified application.
+func (c *Client) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("WatchApplicationConfig on CAASApplicationProvisioner v1")
+	}
+	return common.Watch(c.facade, "WatchApplicationConfig", names.NewApplicationTag(appName))
+}
+
+// WatchNetworkPolicy returns a NotifyWatcher that notifies of changes
+// to the relations of the specified application and to the model config,
+// which determine the network policy of the application.
+func (c *Client) WatchNetworkPolicy(appName string) (watcher.NotifyWatcher, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("WatchNetworkPolicy on CAASApplicationProvisioner v%d", c.facade.BestAPIVersion())
+	}
+	return common.Watch(c.facade, "WatchNetworkPolicy", names.NewApplicationTag(appName))
+}
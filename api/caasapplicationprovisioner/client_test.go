@@ -15,6 +15,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/caasapplicationprovisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
@@ -154,6 +155,17 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 				ImageRepo:            "jujuqa",
 				CharmModifiedVersion: 1,
 				CharmURL:             "cs:~test/charm-1",
+				Scale:                3,
+				Autoscaling: &params.KubernetesAutoscalingPolicy{
+					MinReplicas:          2,
+					MaxReplicas:          5,
+					TargetCPUUtilization: 70,
+					Metrics:              map[string]string{"requests_per_second": "100"},
+				},
+				NetworkPolicy: &params.KubernetesNetworkPolicy{
+					Applications: []string{"postgresql"},
+					CIDRs:        []string{"10.0.0.0/8"},
+				},
 			}}}
 		return nil
 	})
@@ -168,6 +180,17 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 		ImageRepo:            "jujuqa",
 		CharmModifiedVersion: 1,
 		CharmURL:             &charm.URL{Schema: "cs", User: "test", Name: "charm", Revision: 1},
+		Scale:                3,
+		Autoscaling: &caas.AutoscalingPolicy{
+			MinReplicas:          2,
+			MaxReplicas:          5,
+			TargetCPUUtilization: 70,
+			Metrics:              map[string]string{"requests_per_second": "100"},
+		},
+		NetworkPolicy: &caas.NetworkPolicy{
+			Applications: []string{"postgresql"},
+			CIDRs:        []string{"10.0.0.0/8"},
+		},
 	})
 }
 
@@ -403,3 +426,78 @@ func (s *provisionerSuite) TestWatchApplication(c *gc.C) {
 	c.Assert(watcher, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
+
+func (s *provisionerSuite) TestWatchApplicationConfig(c *gc.C) {
+	client := caasapplicationprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchApplicationConfig")
+			c.Assert(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{
+					Tag: "application-gitlab",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+			*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+				Results: []params.NotifyWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	})
+	watcher, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestWatchApplicationConfigNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *provisionerSuite) TestWatchNetworkPolicy(c *gc.C) {
+	client := caasapplicationprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchNetworkPolicy")
+			c.Assert(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{
+					Tag: "application-gitlab",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+			*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+				Results: []params.NotifyWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	})
+	watcher, err := client.WatchNetworkPolicy("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestWatchNetworkPolicyNotSupported(c *gc.C) {
+	client := caasapplicationprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	})
+	_, err := client.WatchNetworkPolicy("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
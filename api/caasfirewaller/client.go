@@ -204,6 +204,32 @@ func (c *Client) IsExposed(appName string) (bool, error) {
 	return results.Results[0].Result, nil
 }
 
+// ExposedEndpoints returns the names of the exposed endpoints of the
+// specified CAAS application. An empty name means that all of the
+// application's endpoints are exposed.
+func (c *Client) ExposedEndpoints(appName string) ([]string, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("ExposedEndpoints on %s v%d", c.facade.Name(), c.facade.BestAPIVersion())
+	}
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("ExposedEndpoints", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
 // maybeNotFound returns an error satisfying errors.IsNotFound
 // if the supplied error has a CodeNotFound error.
 func maybeNotFound(err *params.Error) error {
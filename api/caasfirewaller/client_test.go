@@ -31,6 +31,7 @@ type clientCommmon interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	WatchApplication(string) (watcher.NotifyWatcher, error)
 	IsExposed(string) (bool, error)
+	ExposedEndpoints(string) ([]string, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
 	Life(string) (life.Value, error)
 }
@@ -153,6 +154,49 @@ func (s *firewallerBaseSuite) TestIsExposed(c *gc.C) {
 	c.Assert(exposed, jc.IsTrue)
 }
 
+func (s *firewallerBaseSuite) TestExposedEndpoints(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, s.objType)
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ExposedEndpoints")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{
+					Tag: "application-gitlab",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+			*(result.(*params.StringsResults)) = params.StringsResults{
+				Results: []params.StringsResult{{
+					Result: []string{"api", "website"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := s.newFunc(apiCaller)
+	endpoints, err := client.ExposedEndpoints("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, jc.DeepEquals, []string{"api", "website"})
+}
+
+func (s *firewallerBaseSuite) TestExposedEndpointsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+
+	client := s.newFunc(apiCaller)
+	_, err := client.ExposedEndpoints("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *firewallerBaseSuite) TestIsExposedError(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		*(result.(*params.BoolResults)) = params.BoolResults{
@@ -250,6 +250,7 @@ func filesystemFromParams(in params.KubernetesFilesystemParams) (*storage.Kubern
 		Attributes:   in.Attributes,
 		ResourceTags: in.Tags,
 		Attachment:   attachment,
+		Shared:       in.Shared,
 	}, nil
 }
 
@@ -53,6 +53,22 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time on or before
+	// EndTime will be returned. Once EndTime has passed, the server
+	// closes the connection rather than waiting for new records.
+	EndTime time.Time
+	// MessageRegex, if set, is a regular expression which the messages
+	// of returned records must match. It must be valid RE2 syntax, and
+	// is run by the database as PCRE, so should stick to the syntax
+	// common to both.
+	MessageRegex string
+	// IncludeLabel lists labels to include in the response. Records with
+	// any of the labels are included. If none are set, then all records
+	// are considered included.
+	IncludeLabel []string
+	// ExcludeLabel lists labels to exclude from the response. Records
+	// with any of the labels are excluded.
+	ExcludeLabel []string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -62,6 +78,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
 	}
+	if len(args.IncludeLabel) > 0 {
+		attrs["includeLabel"] = args.IncludeLabel
+	}
+	if len(args.ExcludeLabel) > 0 {
+		attrs["excludeLabel"] = args.ExcludeLabel
+	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
 	}
@@ -80,17 +102,25 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
+	}
 	return attrs
 }
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ModelUUID string
 	Entity    string
 	Timestamp time.Time
 	Severity  string
 	Module    string
 	Location  string
 	Message   string
+	Labels    []string
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -122,12 +152,14 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				return
 			}
 			messages <- LogMessage{
+				ModelUUID: msg.ModelUUID,
 				Entity:    msg.Entity,
 				Timestamp: msg.Timestamp,
 				Severity:  msg.Severity,
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+				Labels:    msg.Labels,
 			}
 		}
 	}()
@@ -0,0 +1,21 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// BackupStatus returns the schedule and the outcome of the
+// controller's scheduled backups.
+func (c *Client) BackupStatus() (params.ControllerBackupStatus, error) {
+	var result params.ControllerBackupStatus
+	if c.BestAPIVersion() < 10 {
+		return result, errors.NotSupportedf("BackupStatus not supported by this version of Juju")
+	}
+	err := c.facade.FacadeCall("BackupStatus", nil, &result)
+	return result, errors.Trace(err)
+}
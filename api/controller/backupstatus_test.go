@@ -0,0 +1,72 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestBackupStatusPriorV10(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestBackupStatus(c *gc.C) {
+	success := time.Date(2021, 5, 24, 3, 0, 0, 0, time.UTC)
+	expected := params.ControllerBackupStatus{
+		Schedule:     "0 3 * * *",
+		LastAttempt:  &success,
+		LastSuccess:  &success,
+		LastBackupID: "backup-id",
+		MaxAge:       48 * time.Hour,
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "BackupStatus")
+			c.Check(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ControllerBackupStatus{})
+			*(result.(*params.ControllerBackupStatus)) = expected
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	status, err := client.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, expected)
+}
+
+func (s *Suite) TestBackupStatusCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
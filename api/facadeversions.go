@@ -11,25 +11,28 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       7,
+	"Action":                       8,
 	"ActionPruner":                 1,
+	"ActionSchedule":               1,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  13,
+	"Application":                  14,
 	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
-	"Backups":                      3,
+	"AuditLog":                     1,
+	"Backups":                      4,
 	"Block":                        2,
 	"Bundle":                       4,
 	"CAASAgent":                    1,
 	"CAASAdmission":                1,
 	"CAASApplication":              1,
-	"CAASApplicationProvisioner":   1,
-	"CAASFirewaller":               1,
-	"CAASFirewallerEmbedded":       1,
+	"CAASApplicationProvisioner":   3,
+	"CAASFirewaller":               2,
+	"CAASFirewallerEmbedded":       2,
 	"CAASModelOperator":            1,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
@@ -41,7 +44,7 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        7,
-	"Controller":                   9,
+	"Controller":                   10,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
@@ -60,7 +63,7 @@ var facadeVersions = map[string]int{
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              2,
-	"InstancePoller":               4,
+	"InstancePoller":               5,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -68,7 +71,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               6,
+	"MachineManager":               8,
 	"MachineUndertaker":            1,
 	"Machiner":                     4,
 	"MeterStatus":                  2,
@@ -101,17 +104,19 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
+	"SecretsManager":               1,
 	"Singular":                     2,
 	"Spaces":                       6,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      6,
-	"StorageProvisioner":           4,
+	"Storage":                      8,
+	"StorageProvisioner":           6,
 	"StringsWatcher":               1,
 	"Subnets":                      4,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       17,
+	"Uniter":                       18,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
@@ -152,6 +152,20 @@ func (m *Machine) SetInstanceStatus(status status.Status, message string, data m
 	return result.OneError()
 }
 
+// SetAvailabilityZone records the availability zone that the machine's
+// instance is running in.
+func (m *Machine) SetAvailabilityZone(zone string) error {
+	var result params.ErrorResults
+	args := params.SetAvailabilityZones{Zones: []params.EntityString{
+		{Tag: m.tag.String(), Value: zone},
+	}}
+	err := m.facade.FacadeCall("SetAvailabilityZones", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // SetProviderNetworkConfig updates the provider addresses for this machine.
 func (m *Machine) SetProviderNetworkConfig(ifList network.InterfaceInfos) (network.ProviderAddresses, bool, error) {
 	var results params.SetProviderNetworkConfigResults
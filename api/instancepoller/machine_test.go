@@ -100,6 +100,12 @@ var machineErrorTests = []struct {
 		return m.SetInstanceStatus("", "", nil)
 	},
 	resultsRef: params.ErrorResults{},
+}, {
+	method: "SetAvailabilityZone",
+	wrapper: func(m *instancepoller.Machine) error {
+		return m.SetAvailabilityZone("")
+	},
+	resultsRef: params.ErrorResults{},
 }, {
 	method: "SetProviderNetworkConfig",
 	wrapper: func(m *instancepoller.Machine) error {
@@ -225,6 +231,22 @@ func (s *MachineSuite) TestSetInstanceStatusSuccess(c *gc.C) {
 	c.Check(apiCaller.CallCount, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestSetAvailabilityZoneSuccess(c *gc.C) {
+	expectArgs := params.SetAvailabilityZones{
+		Zones: []params.EntityString{{
+			Tag:   "machine-42",
+			Value: "node02",
+		}}}
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	}
+	apiCaller := successAPICaller(c, "SetAvailabilityZones", expectArgs, results)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, life.Alive)
+	err := machine.SetAvailabilityZone("node02")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
 func (s *MachineSuite) TestSetProviderNetworkConfigSuccess(c *gc.C) {
 	cfg := network.InterfaceInfos{{
 		DeviceIndex: 0,
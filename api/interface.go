@@ -275,6 +275,10 @@ type Connection interface {
 	// This should not be used outside the api/* packages or tests.
 	base.APICaller
 
+	// APICallContext makes an API call in the same way as APICall,
+	// recording it as part of the trace of the span held by ctx.
+	APICallContext(ctx context.Context, objType string, version int, id, request string, params, response interface{}) error
+
 	// ControllerTag returns the tag of the controller.
 	// This could be defined on base.APICaller.
 	ControllerTag() names.ControllerTag
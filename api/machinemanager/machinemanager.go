@@ -265,3 +265,45 @@ func (client *Client) GetUpgradeSeriesMessages(machineName, watcherId string) ([
 
 	return result.Result, nil
 }
+
+// EvacuateClusterMember moves the instances of the model's machines off
+// the named member of the cloud's cluster. If target is non-empty, the
+// instances are moved to that member; otherwise they are spread over the
+// remaining members. The outcome of each move is returned.
+func (client *Client) EvacuateClusterMember(member, target string) ([]params.EvacuatedMachine, error) {
+	if client.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("evacuating cluster members")
+	}
+	args := params.EvacuateClusterMemberArg{
+		Member: member,
+		Target: target,
+	}
+	var result params.EvacuateClusterMemberResult
+	if err := client.facade.FacadeCall("EvacuateClusterMember", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, apiservererrors.RestoreError(result.Error)
+	}
+	return result.Machines, nil
+}
+
+// MigrateMachine moves the instance of the specified machine to another
+// availability zone of the cloud, and returns the outcome of the move.
+func (client *Client) MigrateMachine(machineId, zone string) (params.MigrateMachineResult, error) {
+	if client.BestAPIVersion() < 8 {
+		return params.MigrateMachineResult{}, errors.NotSupportedf("moving machines")
+	}
+	args := params.MigrateMachineArg{
+		MachineTag: names.NewMachineTag(machineId).String(),
+		Zone:       zone,
+	}
+	var result params.MigrateMachineResult
+	if err := client.facade.FacadeCall("MigrateMachine", args, &result); err != nil {
+		return params.MigrateMachineResult{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.MigrateMachineResult{}, apiservererrors.RestoreError(result.Error)
+	}
+	return result, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -248,3 +249,119 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParamsNilWait(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expected)
 }
+
+func (s *MachinemanagerSuite) TestEvacuateClusterMember(c *gc.C) {
+	expected := []params.EvacuatedMachine{{
+		MachineTag: "machine-0",
+		InstanceId: "juju-0",
+		Zone:       "node2",
+		Live:       true,
+	}}
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(objType, gc.Equals, "MachineManager")
+				c.Check(request, gc.Equals, "EvacuateClusterMember")
+				c.Check(a, jc.DeepEquals, params.EvacuateClusterMemberArg{
+					Member: "node1",
+					Target: "node2",
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.EvacuateClusterMemberResult{})
+				*(response.(*params.EvacuateClusterMemberResult)) = params.EvacuateClusterMemberResult{
+					Machines: expected,
+				}
+				return nil
+			}),
+		})
+	machines, err := client.EvacuateClusterMember("node1", "node2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, expected)
+}
+
+func (s *MachinemanagerSuite) TestEvacuateClusterMemberError(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				*(response.(*params.EvacuateClusterMemberResult)) = params.EvacuateClusterMemberResult{
+					Error: &params.Error{Message: `cluster member "node9" not found`, Code: params.CodeNotFound},
+				}
+				return nil
+			}),
+		})
+	_, err := client.EvacuateClusterMember("node9", "")
+	c.Assert(err, gc.ErrorMatches, `cluster member "node9" not found`)
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotFound)
+}
+
+func (s *MachinemanagerSuite) TestEvacuateClusterMemberNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 6,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call %q", request)
+				return nil
+			}),
+		})
+	_, err := client.EvacuateClusterMember("node1", "")
+	c.Assert(err, gc.ErrorMatches, "evacuating cluster members not supported")
+}
+
+func (s *MachinemanagerSuite) TestMigrateMachine(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(objType, gc.Equals, "MachineManager")
+				c.Check(request, gc.Equals, "MigrateMachine")
+				c.Check(a, jc.DeepEquals, params.MigrateMachineArg{
+					MachineTag: "machine-0",
+					Zone:       "node2",
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.MigrateMachineResult{})
+				*(response.(*params.MigrateMachineResult)) = params.MigrateMachineResult{
+					InstanceId: "juju-0",
+					Zone:       "node2",
+					Live:       true,
+				}
+				return nil
+			}),
+		})
+	result, err := client.MigrateMachine("0", "node2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateMachineResult{
+		InstanceId: "juju-0",
+		Zone:       "node2",
+		Live:       true,
+	})
+}
+
+func (s *MachinemanagerSuite) TestMigrateMachineError(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				*(response.(*params.MigrateMachineResult)) = params.MigrateMachineResult{
+					Error: &params.Error{Message: "moving machines on this cloud not supported", Code: params.CodeNotSupported},
+				}
+				return nil
+			}),
+		})
+	_, err := client.MigrateMachine("0", "node2")
+	c.Assert(err, gc.ErrorMatches, "moving machines on this cloud not supported")
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *MachinemanagerSuite) TestMigrateMachineNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call %q", request)
+				return nil
+			}),
+		})
+	_, err := client.MigrateMachine("0", "node2")
+	c.Assert(err, gc.ErrorMatches, "moving machines not supported")
+}
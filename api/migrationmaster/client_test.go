@@ -296,6 +296,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 					},
 				},
 			}},
+			Secrets: []byte("bar"),
 		}
 		return nil
 	})
@@ -364,6 +365,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 				},
 			},
 		}},
+		Secrets: []byte("bar"),
 	})
 }
 
@@ -55,8 +55,11 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 
 // Import takes a serialized model and imports it into the target
 // controller.
-func (c *Client) Import(bytes []byte) error {
-	serialized := params.SerializedModel{Bytes: bytes}
+func (c *Client) Import(model coremigration.SerializedModel) error {
+	serialized := params.SerializedModel{
+		Bytes:   model.Bytes,
+		Secrets: model.Secrets,
+	}
 	return errors.Trace(c.caller.FacadeCall("Import", serialized, nil))
 }
 
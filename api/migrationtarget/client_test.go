@@ -81,9 +81,12 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
-	err := client.Import([]byte("foo"))
+	err := client.Import(coremigration.SerializedModel{
+		Bytes:   []byte("foo"),
+		Secrets: []byte("bar"),
+	})
 
-	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	expectedArg := params.SerializedModel{Bytes: []byte("foo"), Secrets: []byte("bar")}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Import", []interface{}{"", expectedArg}},
 	})
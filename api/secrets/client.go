@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+)
+
+// Client is the api client for the Secrets facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a secrets api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// SecretDetails holds a secret metadata and value.
+type SecretDetails struct {
+	Metadata secrets.SecretMetadata
+	Value    secrets.SecretValue
+	Error    string
+}
+
+// ListSecrets lists the available secrets.
+func (api *Client) ListSecrets(showSecrets bool) ([]SecretDetails, error) {
+	arg := params.ListSecretsArgs{
+		ShowSecrets: showSecrets,
+	}
+	var response params.ListSecretResults
+	err := api.facade.FacadeCall("ListSecrets", arg, &response)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make([]SecretDetails, len(response.Results))
+	for i, r := range response.Results {
+		uri, err := secrets.ParseURI(r.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		details := SecretDetails{
+			Metadata: secrets.SecretMetadata{
+				URI:            uri,
+				OwnerTag:       r.OwnerTag,
+				Description:    r.Description,
+				RotateInterval: r.RotateInterval,
+				NextRotateTime: r.NextRotateTime,
+				LatestRevision: r.LatestRevision,
+				CreateTime:     r.CreateTime,
+				UpdateTime:     r.UpdateTime,
+			},
+		}
+		if r.Value != nil {
+			if r.Value.Error == nil {
+				details.Value = secrets.NewSecretValue(r.Value.Data)
+			} else {
+				details.Error = r.Value.Error.Error()
+			}
+		}
+		result[i] = details
+	}
+	return result, nil
+}
+
+// SecretBackend returns the type of backend used
+// to store the content of secrets in the model.
+func (api *Client) SecretBackend() (string, error) {
+	var result params.SecretBackendResult
+	if err := api.facade.FacadeCall("SecretBackend", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	return result.BackendType, nil
+}
+
+// ChangeSecretBackend changes the type of backend used to store the
+// content of secrets in the model, moving any existing content to it.
+// If config is nil, any existing config for the backend type is used.
+// It returns the number of secret revisions which were moved.
+func (api *Client) ChangeSecretBackend(backendType string, config map[string]interface{}) (int, error) {
+	arg := params.ChangeSecretBackendArg{
+		BackendType: backendType,
+		Config:      config,
+	}
+	var result params.ChangeSecretBackendResult
+	if err := api.facade.FacadeCall("ChangeSecretBackend", arg, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Migrated, result.Error
+	}
+	return result.Migrated, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&SecretsSuite{})
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *SecretsSuite) TestNewClient(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{testing.APICallerFunc(nil), 1}
+	client := apisecrets.NewClient(apiCaller)
+	c.Assert(client, gc.NotNil)
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	now := time.Now()
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, gc.DeepEquals, params.ListSecretsArgs{
+			ShowSecrets: true,
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ListSecretResults{})
+		*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+			Results: []params.ListSecretResult{{
+				URI:            uri.String(),
+				OwnerTag:       "application-mysql",
+				Description:    "shhh",
+				RotateInterval: time.Hour,
+				NextRotateTime: &now,
+				LatestRevision: 2,
+				CreateTime:     now,
+				UpdateTime:     now,
+				Value: &params.SecretValueResult{
+					Data: map[string]string{"foo": "bar"},
+				},
+			}},
+		}
+		return nil
+	})
+	client := apisecrets.NewClient(apiCaller)
+	result, err := client.ListSecrets(true)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []apisecrets.SecretDetails{{
+		Metadata: secrets.SecretMetadata{
+			URI:            uri,
+			OwnerTag:       "application-mysql",
+			Description:    "shhh",
+			RotateInterval: time.Hour,
+			NextRotateTime: &now,
+			LatestRevision: 2,
+			CreateTime:     now,
+			UpdateTime:     now,
+		},
+		Value: secrets.NewSecretValue(map[string]string{"foo": "bar"}),
+	}})
+}
+
+func (s *SecretsSuite) TestListSecretsError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+			Results: []params.ListSecretResult{{
+				URI:      secrets.NewURI(coretesting.ModelTag.Id()).String(),
+				OwnerTag: "application-mysql",
+				Value: &params.SecretValueResult{
+					Error: &params.Error{Message: "boom"},
+				},
+			}},
+		}
+		return nil
+	})
+	client := apisecrets.NewClient(apiCaller)
+	result, err := client.ListSecrets(true)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Assert(result[0].Error, gc.Equals, "boom")
+	c.Assert(result[0].Value, gc.IsNil)
+}
+
+func (s *SecretsSuite) TestSecretBackend(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "SecretBackend")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.SecretBackendResult{})
+		*(result.(*params.SecretBackendResult)) = params.SecretBackendResult{BackendType: "vault"}
+		return nil
+	})
+	client := apisecrets.NewClient(apiCaller)
+	result, err := client.SecretBackend()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "vault")
+}
+
+func (s *SecretsSuite) TestChangeSecretBackend(c *gc.C) {
+	cfg := map[string]interface{}{"token": "foo"}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "ChangeSecretBackend")
+		c.Check(arg, jc.DeepEquals, params.ChangeSecretBackendArg{
+			BackendType: "vault",
+			Config:      cfg,
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ChangeSecretBackendResult{})
+		*(result.(*params.ChangeSecretBackendResult)) = params.ChangeSecretBackendResult{Migrated: 3}
+		return nil
+	})
+	client := apisecrets.NewClient(apiCaller)
+	migrated, err := client.ChangeSecretBackend("vault", cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(migrated, gc.Equals, 3)
+}
+
+func (s *SecretsSuite) TestChangeSecretBackendError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ChangeSecretBackendResult)) = params.ChangeSecretBackendResult{
+			Migrated: 1,
+			Error:    &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := apisecrets.NewClient(apiCaller)
+	migrated, err := client.ChangeSecretBackend("vault", nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(migrated, gc.Equals, 1)
+}
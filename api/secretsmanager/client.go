@@ -0,0 +1,216 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Client is the api client for the SecretsManager facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a secrets api client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "SecretsManager")}
+}
+
+// Create creates a new secret owned by the specified application.
+func (c *Client) Create(ownerTag names.ApplicationTag, cfg *secrets.SecretConfig, value secrets.SecretValue) (string, error) {
+	if value == nil || value.IsEmpty() {
+		return "", errors.NotValidf("empty secret value")
+	}
+	arg := params.CreateSecretArg{
+		OwnerTag: ownerTag.String(),
+		Data:     value.EncodedValues(),
+	}
+	if cfg != nil {
+		arg.RotateInterval = cfg.RotateInterval
+		arg.Description = cfg.Description
+	}
+	var results params.StringResults
+	err := c.facade.FacadeCall("CreateSecrets", params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// Update updates an existing secret value and/or config like rotate interval.
+func (c *Client) Update(uri string, cfg *secrets.SecretConfig, value secrets.SecretValue) error {
+	arg := params.UpdateSecretArg{
+		URI: uri,
+	}
+	if cfg != nil {
+		arg.RotateInterval = cfg.RotateInterval
+		arg.Description = cfg.Description
+	}
+	if value != nil && !value.IsEmpty() {
+		arg.Data = value.EncodedValues()
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("UpdateSecrets", params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GetValue returns the value of a secret. A revision
+// of zero means the latest revision.
+func (c *Client) GetValue(uri string, revision int) (secrets.SecretValue, error) {
+	var results params.SecretValueResults
+	err := c.facade.FacadeCall("GetSecretValues", params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{URI: uri, Revision: revision}},
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewSecretValue(results.Results[0].Data), nil
+}
+
+// GetSecretMetadata returns metadata for the secrets owned by the
+// caller's application.
+func (c *Client) GetSecretMetadata() ([]secrets.SecretMetadata, error) {
+	var results params.ListSecretResults
+	err := c.facade.FacadeCall("GetSecretMetadata", nil, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]secrets.SecretMetadata, len(results.Results))
+	for i, r := range results.Results {
+		uri, err := secrets.ParseURI(r.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = secrets.SecretMetadata{
+			URI:            uri,
+			OwnerTag:       r.OwnerTag,
+			Description:    r.Description,
+			RotateInterval: r.RotateInterval,
+			NextRotateTime: r.NextRotateTime,
+			LatestRevision: r.LatestRevision,
+			CreateTime:     r.CreateTime,
+			UpdateTime:     r.UpdateTime,
+		}
+	}
+	return result, nil
+}
+
+// SecretRevokeGrantArgs holds the args used to grant or revoke access to a secret.
+type SecretRevokeGrantArgs struct {
+	// ScopeTag is the entity whose lifetime bounds the access.
+	ScopeTag names.Tag
+
+	// ApplicationName, if set, is the application being granted
+	// or losing access.
+	ApplicationName *string
+
+	// UnitName, if set, is the unit being granted or losing access.
+	UnitName *string
+
+	// Role is the access role being granted.
+	Role secrets.SecretRole
+}
+
+func (args *SecretRevokeGrantArgs) subjectTags() []string {
+	var tags []string
+	if args.ApplicationName != nil {
+		tags = append(tags, names.NewApplicationTag(*args.ApplicationName).String())
+	}
+	if args.UnitName != nil {
+		tags = append(tags, names.NewUnitTag(*args.UnitName).String())
+	}
+	return tags
+}
+
+// Grant grants access to the specified secret.
+func (c *Client) Grant(uri string, p *SecretRevokeGrantArgs) error {
+	return c.grantRevoke("GrantSecret", uri, p)
+}
+
+// Revoke revokes access to the specified secret.
+func (c *Client) Revoke(uri string, p *SecretRevokeGrantArgs) error {
+	return c.grantRevoke("RevokeSecret", uri, p)
+}
+
+func (c *Client) grantRevoke(method, uri string, p *SecretRevokeGrantArgs) error {
+	arg := params.GrantRevokeSecretArg{
+		URI:         uri,
+		SubjectTags: p.subjectTags(),
+		Role:        string(p.Role),
+	}
+	if p.ScopeTag != nil {
+		arg.ScopeTag = p.ScopeTag.String()
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall(method, params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchSecretsRotationChanges returns a watcher which reports the URIs
+// of secrets owned by the specified application whose rotation
+// schedule has changed.
+func (c *Client) WatchSecretsRotationChanges(ownerTag names.ApplicationTag) (watcher.StringsWatcher, error) {
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: ownerTag.String()}},
+	}
+	err := c.facade.FacadeCall("WatchSecretsRotationChanges", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// SecretRotated records when a secret was last rotated.
+func (c *Client) SecretRotated(uri string, when time.Time) error {
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("SecretsRotated", params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{URI: uri, When: when}},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,248 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secretsmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&SecretsSuite{})
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *SecretsSuite) TestCreateSecret(c *gc.C) {
+	data := map[string]string{"foo": "bar"}
+	value := secrets.NewSecretValue(data)
+	interval := time.Hour
+	description := "my secret"
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "CreateSecrets")
+		c.Check(arg, jc.DeepEquals, params.CreateSecretArgs{
+			Args: []params.CreateSecretArg{{
+				OwnerTag:       "application-mariadb",
+				RotateInterval: &interval,
+				Description:    &description,
+				Data:           data,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+		*(result.(*params.StringResults)) = params.StringResults{
+			[]params.StringResult{{
+				Result: "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo",
+			}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	cfg := &secrets.SecretConfig{
+		RotateInterval: &interval,
+		Description:    &description,
+	}
+	result, err := client.Create(names.NewApplicationTag("mariadb"), cfg, value)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo")
+}
+
+func (s *SecretsSuite) TestCreateSecretsEmptyValue(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call")
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	_, err := client.Create(names.NewApplicationTag("mariadb"), nil, secrets.NewSecretValue(nil))
+	c.Assert(err, gc.ErrorMatches, "empty secret value not valid")
+}
+
+func (s *SecretsSuite) TestCreateSecretsError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringResults)) = params.StringResults{
+			[]params.StringResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	value := secrets.NewSecretValue(map[string]string{"foo": "bar"})
+	result, err := client.Create(names.NewApplicationTag("mariadb"), nil, value)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(result, gc.Equals, "")
+}
+
+func (s *SecretsSuite) TestUpdateSecret(c *gc.C) {
+	data := map[string]string{"foo": "bar"}
+	value := secrets.NewSecretValue(data)
+	interval := time.Hour
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "UpdateSecrets")
+		c.Check(arg, jc.DeepEquals, params.UpdateSecretArgs{
+			Args: []params.UpdateSecretArg{{
+				URI:            "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo",
+				RotateInterval: &interval,
+				Data:           data,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			[]params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	cfg := &secrets.SecretConfig{RotateInterval: &interval}
+	err := client.Update("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo", cfg, value)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) TestGetValue(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "GetSecretValues")
+		c.Check(arg, jc.DeepEquals, params.GetSecretValueArgs{
+			Args: []params.GetSecretValueArg{{
+				URI:      "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo",
+				Revision: 2,
+			}},
+		})
+		*(result.(*params.SecretValueResults)) = params.SecretValueResults{
+			[]params.SecretValueResult{{
+				Data: map[string]string{"foo": "bar"},
+			}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	result, err := client.GetValue("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, secrets.NewSecretValue(map[string]string{"foo": "bar"}))
+}
+
+func (s *SecretsSuite) TestGetSecretMetadata(c *gc.C) {
+	now := time.Now()
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "GetSecretMetadata")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+			[]params.ListSecretResult{{
+				URI:            "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo",
+				OwnerTag:       "application-mariadb",
+				RotateInterval: time.Hour,
+				NextRotateTime: &now,
+				LatestRevision: 1,
+			}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	result, err := client.GetSecretMetadata()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []secrets.SecretMetadata{{
+		URI:            &secrets.URI{ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d", ID: "foo"},
+		OwnerTag:       "application-mariadb",
+		RotateInterval: time.Hour,
+		NextRotateTime: &now,
+		LatestRevision: 1,
+	}})
+}
+
+func (s *SecretsSuite) TestGrant(c *gc.C) {
+	s.assertGrantRevoke(c, "GrantSecret")
+}
+
+func (s *SecretsSuite) TestRevoke(c *gc.C) {
+	s.assertGrantRevoke(c, "RevokeSecret")
+}
+
+func (s *SecretsSuite) assertGrantRevoke(c *gc.C, method string) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, method)
+		c.Check(arg, jc.DeepEquals, params.GrantRevokeSecretArgs{
+			Args: []params.GrantRevokeSecretArg{{
+				URI:         "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo",
+				ScopeTag:    "relation-wordpress.db#mariadb.server",
+				SubjectTags: []string{"application-wordpress", "unit-wordpress-0"},
+				Role:        "view",
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			[]params.ErrorResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	app := "wordpress"
+	unit := "wordpress/0"
+	args := &secretsmanager.SecretRevokeGrantArgs{
+		ScopeTag:        names.NewRelationTag("wordpress:db mariadb:server"),
+		ApplicationName: &app,
+		UnitName:        &unit,
+		Role:            secrets.RoleView,
+	}
+	var err error
+	if method == "GrantSecret" {
+		err = client.Grant("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo", args)
+	} else {
+		err = client.Revoke("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo", args)
+	}
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SecretsSuite) TestWatchSecretsRotationChanges(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "WatchSecretsRotationChanges")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mariadb"}},
+		})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			[]params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	_, err := client.WatchSecretsRotationChanges(names.NewApplicationTag("mariadb"))
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *SecretsSuite) TestSecretRotated(c *gc.C) {
+	now := time.Now()
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "SecretsRotated")
+		c.Check(arg, jc.DeepEquals, params.SecretRotatedArgs{
+			Args: []params.SecretRotatedArg{{
+				URI:  "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo",
+				When: now,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			[]params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	err := client.SecretRotated("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo", now)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -198,6 +198,13 @@ func (c *Client) ListFilesystems(machines []string) ([]params.FilesystemDetailsL
 // NOTE(axw) for old controllers, the results will only
 // contain errors.
 func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+	if c.BestAPIVersion() < 7 {
+		for _, s := range storages {
+			if s.FromSnapshot != "" {
+				return nil, errors.NotSupportedf("adding storage from a snapshot with this version of Juju")
+			}
+		}
+	}
 	out := params.AddStorageResults{}
 	in := params.StoragesAddParams{Storages: storages}
 	err := c.facade.FacadeCall("AddToUnit", in, &out)
@@ -355,3 +362,82 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// CreateSnapshots creates snapshots of the volumes backing the
+// specified storage instances.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("snapshotting storage with this version of Juju")
+	}
+	args, err := storageEntities(storageIds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.VolumeSnapshotResults
+	if err := c.facade.FacadeCall("CreateSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots lists the snapshots of the volumes backing the
+// specified storage instances.
+func (c *Client) ListSnapshots(storageIds []string) ([]params.VolumeSnapshotListResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("listing storage snapshots with this version of Juju")
+	}
+	args, err := storageEntities(storageIds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.VolumeSnapshotListResults
+	if err := c.facade.FacadeCall("ListSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ResizeStorage requests that the volume or filesystem backing the
+// specified storage instance be grown to the given size, in MiB.
+func (c *Client) ResizeStorage(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("resizing storage with this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.StorageResizeParams{
+		Storage: []params.StorageResize{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+func storageEntities(storageIds []string) (params.Entities, error) {
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return params.Entities{}, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	return params.Entities{Entities: entities}, nil
+}
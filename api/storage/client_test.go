@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -1077,3 +1078,169 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+					{Tag: "storage-bar-1"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotResults{})
+				results := result.(*params.VolumeSnapshotResults)
+				results.Results = []params.VolumeSnapshotResult{
+					{Result: &params.VolumeSnapshotDetails{
+						SnapshotId: "snap-0",
+						StorageTag: "storage-foo-0",
+						VolumeTag:  "volume-0",
+						VolumeId:   "vol-0",
+						Size:       1024,
+						Created:    created,
+					}},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"foo/0", "bar/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotResult{
+		{Result: &params.VolumeSnapshotDetails{
+			SnapshotId: "snap-0",
+			StorageTag: "storage-foo-0",
+			VolumeTag:  "volume-0",
+			VolumeId:   "vol-0",
+			Size:       1024,
+			Created:    created,
+		}},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotListResults{})
+				results := result.(*params.VolumeSnapshotListResults)
+				results.Results = []params.VolumeSnapshotListResult{{
+					Result: []params.VolumeSnapshotDetails{{
+						SnapshotId: "snap-0",
+						StorageTag: "storage-foo-0",
+					}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.ListSnapshots([]string{"foo/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotListResult{{
+		Result: []params.VolumeSnapshotDetails{{
+			SnapshotId: "snap-0",
+			StorageTag: "storage-foo-0",
+		}},
+	}})
+}
+
+func (s *storageMockSuite) TestListSnapshotsInvalidStorageId(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 7})
+	_, err := client.ListSnapshots([]string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestAddToUnitFromSnapshotNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.AddToUnit([]params.StorageAddParams{{
+		UnitTag:      "unit-foo-0",
+		StorageName:  "data",
+		FromSnapshot: "snap-0",
+	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ResizeStorage")
+				c.Check(a, jc.DeepEquals, params.StorageResizeParams{[]params.StorageResize{
+					{StorageTag: "storage-foo-0", Size: 2048},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{Error: &params.Error{Message: "baz"}}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("foo/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestResizeStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("foo/0", 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
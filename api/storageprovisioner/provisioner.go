@@ -94,6 +94,28 @@ func (st *State) WatchFilesystems(scope names.Tag) (watcher.StringsWatcher, erro
 	return st.watchStorageEntities("WatchFilesystems", scope)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the specified tag, so that pending resizes may be
+// identified. If the controller does not support resizing volumes,
+// an error satisfying errors.IsNotSupported is returned.
+func (st *State) WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("resizing volumes")
+	}
+	return st.watchStorageEntities("WatchVolumeResizes", scope)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the specified tag, so that pending resizes may be
+// identified. If the controller does not support resizing filesystems,
+// an error satisfying errors.IsNotSupported is returned.
+func (st *State) WatchFilesystemResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("resizing filesystems")
+	}
+	return st.watchStorageEntities("WatchFilesystemResizes", scope)
+}
+
 func (st *State) watchStorageEntities(method string, scope names.Tag) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -301,6 +323,27 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// ResizeVolumeParams returns the parameters for resizing the volumes
+// with the specified tags. Volumes without a pending resize will have
+// an error satisfying params.IsCodeNotFound.
+func (st *State) ResizeVolumeParams(tags []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeVolumeParamsResults
+	err := st.facade.FacadeCall("ResizeVolumeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -321,6 +364,27 @@ func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.Filesyst
 	return results.Results, nil
 }
 
+// ResizeFilesystemParams returns the parameters for resizing the
+// filesystems with the specified tags. Filesystems without a pending
+// resize will have an error satisfying params.IsCodeNotFound.
+func (st *State) ResizeFilesystemParams(tags []names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeFilesystemParamsResults
+	err := st.facade.FacadeCall("ResizeFilesystemParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // RemoveFilesystemParams returns the parameters for destroying or releasing
 // the filesystems with the specified tags.
 func (st *State) RemoveFilesystemParams(tags []names.FilesystemTag) ([]params.RemoveFilesystemParamsResult, error) {
@@ -453,6 +517,26 @@ func (st *State) SetFilesystemAttachmentInfo(filesystemAttachments []params.File
 	return results.Results, nil
 }
 
+// SetFilesystemUsage records the space and inode usage of filesystems,
+// as observed on the machines that they are attached to. If the controller
+// does not support reporting filesystem usage, an error satisfying
+// errors.IsNotSupported is returned.
+func (st *State) SetFilesystemUsage(usage []params.FilesystemUsageArg) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("reporting filesystem usage")
+	}
+	args := params.FilesystemUsageArgs{Args: usage}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetFilesystemUsage", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(usage) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(usage), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // Life requests the life cycle of the entities with the specified tags.
 func (st *State) Life(tags []names.Tag) ([]params.LifeResult, error) {
 	var results params.LifeResults
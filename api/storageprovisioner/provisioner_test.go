@@ -4,8 +4,7 @@
 package storageprovisioner_test
 
 import (
-	"errors"
-
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -1301,3 +1300,160 @@ func (s *provisionerSuite) TestLifeServerError(c *gc.C) {
 	c.Assert(results, gc.HasLen, 1)
 	c.Check(results[0].Error, gc.ErrorMatches, "MSG")
 }
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 5)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchVolumeResizes")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-123"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		},
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizesNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes(names.NewMachineTag("123"))
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = st.WatchFilesystemResizes(names.NewMachineTag("123"))
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *provisionerSuite) TestSetFilesystemUsage(c *gc.C) {
+	usage := []params.FilesystemUsageArg{{
+		FilesystemTag: "filesystem-100",
+		MachineTag:    "machine-123",
+		Usage: params.FilesystemUsage{
+			BytesUsed:  768,
+			BytesFree:  256,
+			InodesUsed: 10,
+			InodesFree: 90,
+		},
+	}}
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 6)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetFilesystemUsage")
+			c.Check(arg, jc.DeepEquals, params.FilesystemUsageArgs{Args: usage})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+			}
+			callCount++
+			return nil
+		},
+		BestVersion: 6,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.SetFilesystemUsage(usage)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestSetFilesystemUsageNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 5,
+	}
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.SetFilesystemUsage(nil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *provisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 5)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeVolumeParams")
+			c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ResizeVolumeParamsResults{})
+			*(result.(*params.ResizeVolumeParamsResults)) = params.ResizeVolumeParamsResults{
+				Results: []params.ResizeVolumeParamsResult{{
+					Result: params.ResizeVolumeParams{
+						VolumeTag: "volume-100",
+						Provider:  "foo",
+						VolumeId:  "bar",
+						Size:      2048,
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.ResizeVolumeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(resizeParams, jc.DeepEquals, []params.ResizeVolumeParamsResult{{
+		Result: params.ResizeVolumeParams{
+			VolumeTag: "volume-100",
+			Provider:  "foo",
+			VolumeId:  "bar",
+			Size:      2048,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestResizeFilesystemParams(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "ResizeFilesystemParams")
+			c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"filesystem-100"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ResizeFilesystemParamsResults{})
+			*(result.(*params.ResizeFilesystemParamsResults)) = params.ResizeFilesystemParamsResults{
+				Results: []params.ResizeFilesystemParamsResult{{
+					Error: &params.Error{Code: params.CodeNotFound, Message: "pending resize for filesystem 100 not found"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.ResizeFilesystemParams([]names.FilesystemTag{names.NewFilesystemTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -148,6 +148,40 @@ func (s *actionSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "biff")
 }
 
+func (s *actionSuite) TestLogActionOutput(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "LogActionsOutput")
+		c.Assert(arg, gc.DeepEquals, params.ActionOutputParams{
+			Output: []params.ActionOutput{{Tag: "action-666", Stream: "stdout", Output: "hello\n"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.LogActionOutput(names.NewActionTag("666"), "stdout", "hello\n")
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *actionSuite) TestLogActionOutputNotImplemented(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 17}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.LogActionOutput(names.NewActionTag("666"), "stdout", "hello\n")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *actionSuite) TestWatchActionNotifications(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		if objType == "StringsWatcher" {
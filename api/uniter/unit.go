@@ -660,6 +660,24 @@ func (u *Unit) LogActionMessage(tag names.ActionTag, message string) error {
 	return result.OneError()
 }
 
+// LogActionOutput logs output written by the specified action
+// to stdout or stderr.
+func (u *Unit) LogActionOutput(tag names.ActionTag, stream, output string) error {
+	if u.st.facade.BestAPIVersion() < 18 {
+		return errors.NotImplementedf("LogActionOutput() (need V18+)")
+	}
+
+	var result params.ErrorResults
+	args := params.ActionOutputParams{
+		Output: []params.ActionOutput{{Tag: tag.String(), Stream: stream, Output: output}},
+	}
+	err := u.st.facade.FacadeCall("LogActionsOutput", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // UpgradeSeriesStatus returns the upgrade series status of a unit from remote state
 func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	res, err := u.st.UpgradeSeriesUnitStatus()
@@ -30,7 +30,7 @@ func (s *unitStorageSuite) TestAddUnitStorage(c *gc.C) {
 
 	expected := params.StoragesAddParams{
 		Storages: []params.StorageAddParams{
-			{"unit-mysql-0", "data", params.StorageConstraints{Count: &count}},
+			{UnitTag: "unit-mysql-0", StorageName: "data", Constraints: params.StorageConstraints{Count: &count}},
 		},
 	}
 
@@ -64,7 +64,7 @@ func (s *unitStorageSuite) TestAddUnitStorageError(c *gc.C) {
 
 	expected := params.StoragesAddParams{
 		Storages: []params.StorageAddParams{
-			{"unit-mysql-0", "data", params.StorageConstraints{Count: &count}},
+			{UnitTag: "unit-mysql-0", StorageName: "data", Constraints: params.StorageConstraints{Count: &count}},
 		},
 	}
 
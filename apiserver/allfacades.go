@@ -37,6 +37,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/reboot"
 	"github.com/juju/juju/apiserver/facades/agent/resourceshookcontext"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
+	"github.com/juju/juju/apiserver/facades/agent/secretsmanager"
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
 	"github.com/juju/juju/apiserver/facades/agent/unitassigner"
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
@@ -44,9 +45,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/upgradeseries"
 	"github.com/juju/juju/apiserver/facades/agent/upgradesteps"
 	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/facades/client/actionschedule"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
@@ -68,12 +71,14 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
 	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/facades/client/secrets"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
@@ -134,7 +139,10 @@ func AllFacades() *facade.Registry {
 	}
 
 	reg("Action", 7, action.NewActionAPIV7)
+	reg("Action", 8, action.NewActionAPIV8)
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionSchedule", 1, actionschedule.NewFacade)
+	reg("ActionScheduler", 1, actionscheduler.NewFacade)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -155,12 +163,15 @@ func AllFacades() *facade.Registry {
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
 	reg("Application", 13, application.NewFacadeV13) // Adds CharmOrigin to Deploy
+	reg("Application", 14, application.NewFacadeV14) // Adds UnitsEvents.
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
 	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPIV3) // Add user to consume offers details  args.
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("AuditLog", 1, auditlog.NewFacade)
 	reg("Backups", 3, backups.NewFacadeV3)
+	reg("Backups", 4, backups.NewFacadeV4)
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacadeV1)
 	reg("Bundle", 2, bundle.NewFacadeV2)
@@ -184,8 +195,10 @@ func AllFacades() *facade.Registry {
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
-	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeLegacy)
-	reg("CAASFirewallerEmbedded", 1, caasfirewaller.NewStateFacadeEmbedded)
+	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeLegacyV1)
+	reg("CAASFirewaller", 2, caasfirewaller.NewStateFacadeLegacy) // Adds ExposedEndpoints.
+	reg("CAASFirewallerEmbedded", 1, caasfirewaller.NewStateFacadeEmbeddedV1)
+	reg("CAASFirewallerEmbedded", 2, caasfirewaller.NewStateFacadeEmbedded) // Adds ExposedEndpoints.
 	reg("CAASOperator", 1, caasoperator.NewStateFacade)
 	reg("CAASAdmission", 1, caasadmission.NewStateFacade)
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
@@ -194,7 +207,9 @@ func AllFacades() *facade.Registry {
 	reg("CAASOperatorUpgrader", 1, caasoperatorupgrader.NewStateCAASOperatorUpgraderAPI)
 	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
 	reg("CAASApplication", 1, caasapplication.NewStateFacade)
-	reg("CAASApplicationProvisioner", 1, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPI)
+	reg("CAASApplicationProvisioner", 1, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPIV1)
+	reg("CAASApplicationProvisioner", 2, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPIV2) // Adds WatchApplicationConfig.
+	reg("CAASApplicationProvisioner", 3, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPI)   // Adds WatchNetworkPolicy.
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
@@ -203,6 +218,7 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
 	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Controller", 10, controller.NewControllerAPIv10) // Adds BackupStatus
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
 	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
@@ -230,7 +246,8 @@ func AllFacades() *facade.Registry {
 	reg("InstanceMutater", 2, instancemutater.NewFacadeV2)
 
 	reg("InstancePoller", 3, instancepoller.NewFacadeV3)
-	reg("InstancePoller", 4, instancepoller.NewFacade)
+	reg("InstancePoller", 4, instancepoller.NewFacadeV4)
+	reg("InstancePoller", 5, instancepoller.NewFacade) // Adds SetAvailabilityZones.
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
@@ -246,6 +263,8 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Adds DestroyMachineWithParams.
 	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
 	reg("MachineManager", 6, machinemanager.NewFacadeV6) // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 7, machinemanager.NewFacadeV7) // Adds EvacuateClusterMember.
+	reg("MachineManager", 8, machinemanager.NewFacadeV8) // Adds MigrateMachine.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPIV1)
@@ -311,6 +330,8 @@ func AllFacades() *facade.Registry {
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("Secrets", 1, secrets.NewSecretsAPI)
+	reg("SecretsManager", 1, secretsmanager.NewSecretManagerAPI)
 	reg("Singular", 2, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -327,10 +348,14 @@ func AllFacades() *facade.Registry {
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
 	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
-	reg("Storage", 6, storage.NewStorageAPI)   // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 6, storage.NewStorageAPIV6) // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 7, storage.NewStorageAPIV7) // Adds CreateSnapshots and ListSnapshots, and AddToUnit from a snapshot.
+	reg("Storage", 8, storage.NewStorageAPI)   // Adds ResizeStorage.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5) // Adds WatchVolumeResizes, WatchFilesystemResizes, ResizeVolumeParams and ResizeFilesystemParams.
+	reg("StorageProvisioner", 6, storageprovisioner.NewFacadeV6) // Adds SetFilesystemUsage.
 	reg("Subnets", 2, subnets.NewAPIv2)
 	reg("Subnets", 3, subnets.NewAPIv3)
 	reg("Subnets", 4, subnets.NewAPI) // Adds SubnetsByCIDR; removes AllSpaces.
@@ -350,7 +375,8 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
 	reg("Uniter", 15, uniter.NewUniterAPIV15)
 	reg("Uniter", 16, uniter.NewUniterAPIV16)
-	reg("Uniter", 17, uniter.NewUniterAPI)
+	reg("Uniter", 17, uniter.NewUniterAPIV17)
+	reg("Uniter", 18, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ActionSchedule describes a schedule on which an action is run,
+// as implemented by *state.ActionSchedule.
+type ActionSchedule interface {
+	Name() string
+	Target() string
+	Action() string
+	Parameters() map[string]interface{}
+	Cron() string
+	Paused() bool
+	Owner() string
+	Created() time.Time
+	Runs() []state.ActionScheduleRun
+}
+
+// ActionScheduleToParams returns the API representation
+// of an action schedule.
+func ActionScheduleToParams(s ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Name:       s.Name(),
+		Target:     s.Target(),
+		Action:     s.Action(),
+		Parameters: s.Parameters(),
+		Cron:       s.Cron(),
+		Paused:     s.Paused(),
+		Owner:      s.Owner(),
+		Created:    s.Created(),
+	}
+	for _, run := range s.Runs() {
+		result.Runs = append(result.Runs, ActionScheduleRunToParams(run))
+	}
+	return result
+}
+
+// ActionScheduleRunToParams returns the API representation
+// of a run of an action schedule.
+func ActionScheduleRunToParams(run state.ActionScheduleRun) params.ActionScheduleRun {
+	result := params.ActionScheduleRun{
+		Scheduled: run.Scheduled,
+		Enqueued:  run.Enqueued,
+		Error:     run.Error,
+	}
+	if run.OperationID != "" {
+		result.OperationTag = names.NewOperationTag(run.OperationID).String()
+	}
+	return result
+}
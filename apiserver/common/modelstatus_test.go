@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/provider/dummy"
+	secretsprovider "github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
@@ -328,3 +329,7 @@ func (statePolicy) StorageProviderRegistry() (storage.ProviderRegistry, error) {
 func (statePolicy) ProviderConfigSchemaSource(cloudName string) (config.ConfigSchemaSource, error) {
 	return nil, errors.NotImplementedf("ConfigSchemaSource")
 }
+
+func (statePolicy) SecretsStore(backendType string) (secretsprovider.SecretsStore, error) {
+	return nil, errors.NotImplementedf("SecretsStore")
+}
@@ -1,7 +1,7 @@
 // Copyright 2020 Canonical Ltd.
 // Licensed under the AGPLv3, see LICENCE file for details.
 
-package instancepoller
+package networkingcommon
 
 import (
 	"strings"
@@ -11,7 +11,6 @@ import (
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2/txn"
 
-	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
 )
@@ -20,7 +19,7 @@ import (
 // provider-sourced network configuration with existing data for a single
 // machine/host/container.
 type mergeMachineLinkLayerOp struct {
-	*networkingcommon.MachineLinkLayerOp
+	*MachineLinkLayerOp
 
 	// namelessHWAddrs stores the hardware addresses of
 	// incoming devices that have no accompanying name.
@@ -32,11 +31,13 @@ type mergeMachineLinkLayerOp struct {
 	providerIDs map[network.Id]string
 }
 
-func newMergeMachineLinkLayerOp(
-	machine networkingcommon.LinkLayerMachine, incoming network.InterfaceInfos,
-) *mergeMachineLinkLayerOp {
+// NewMergeMachineLinkLayerOp returns a model operation that merges the
+// input provider-sourced link-layer data with that known for the machine.
+func NewMergeMachineLinkLayerOp(
+	machine LinkLayerMachine, incoming network.InterfaceInfos,
+) state.ModelOperation {
 	return &mergeMachineLinkLayerOp{
-		MachineLinkLayerOp: networkingcommon.NewMachineLinkLayerOp(machine, incoming),
+		MachineLinkLayerOp: NewMachineLinkLayerOp(machine, incoming),
 		namelessHWAddrs:    set.NewStrings(),
 	}
 }
@@ -106,7 +107,7 @@ func (o *mergeMachineLinkLayerOp) normaliseIncoming() {
 	}
 
 	// First get the best device per hardware address.
-	devByHWAddr := make(map[string]networkingcommon.LinkLayerDevice)
+	devByHWAddr := make(map[string]LinkLayerDevice)
 	for _, dev := range o.ExistingDevices() {
 		hwAddr := dev.MACAddress()
 
@@ -139,7 +140,7 @@ func (o *mergeMachineLinkLayerOp) normaliseIncoming() {
 	}
 }
 
-func (o *mergeMachineLinkLayerOp) processExistingDevice(dev networkingcommon.LinkLayerDevice) ([]txn.Op, error) {
+func (o *mergeMachineLinkLayerOp) processExistingDevice(dev LinkLayerDevice) ([]txn.Op, error) {
 	incomingDev := o.MatchingIncoming(dev)
 
 	var ops []txn.Op
@@ -221,7 +222,7 @@ func (o *mergeMachineLinkLayerOp) processExistingDevice(dev networkingcommon.Lin
 // ensure that a device has no provider ID and that the origin for all
 // addresses on the device is relinquished to the machine.
 func (o *mergeMachineLinkLayerOp) opsForDeviceOriginRelinquishment(
-	dev networkingcommon.LinkLayerDevice,
+	dev LinkLayerDevice,
 ) ([]txn.Op, error) {
 	ops, err := dev.SetProviderIDOps("")
 	if err != nil {
@@ -236,8 +237,8 @@ func (o *mergeMachineLinkLayerOp) opsForDeviceOriginRelinquishment(
 }
 
 func (o *mergeMachineLinkLayerOp) processExistingDeviceAddress(
-	dev networkingcommon.LinkLayerDevice,
-	addr networkingcommon.LinkLayerAddress,
+	dev LinkLayerDevice,
+	addr LinkLayerAddress,
 	incomingAddrs []state.LinkLayerDeviceAddress,
 ) ([]txn.Op, error) {
 	addrValue := addr.Value()
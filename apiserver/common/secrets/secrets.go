@@ -0,0 +1,165 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// Stores provides access to the secret content stores of a model.
+type Stores interface {
+	// BackendType returns the type of backend the model
+	// is configured to store new secret content in.
+	BackendType() (string, error)
+
+	// Store returns the store for the specified
+	// external secret backend type.
+	Store(backendType string) (provider.SecretsStore, error)
+}
+
+// Model defines the model methods needed to access secret stores.
+type Model interface {
+	stateenvirons.SecretsModel
+}
+
+type modelStores struct {
+	model Model
+}
+
+// NewStores returns the secret content stores of the specified model.
+func NewStores(model Model) Stores {
+	return &modelStores{model: model}
+}
+
+// BackendType implements Stores.
+func (s *modelStores) BackendType() (string, error) {
+	cfg, err := s.model.Config()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if backendType := cfg.SecretBackend(); backendType != "" {
+		return backendType, nil
+	}
+	return provider.Internal, nil
+}
+
+// Store implements Stores.
+func (s *modelStores) Store(backendType string) (provider.SecretsStore, error) {
+	if backendType == provider.Internal {
+		return nil, errors.NotValidf("getting store for %q secret backend", backendType)
+	}
+	return stateenvirons.NewSecretsStoreForModel(
+		s.model, backendType, stateenvirons.GetNewCAASBrokerFunc(caas.New),
+	)
+}
+
+// SaveContent saves new content for the specified secret in the
+// backend the model is configured to use. It returns the data, if
+// the content is stored in the controller, or a reference to the
+// content if it is stored in an external backend.
+func SaveContent(stores Stores, uri *secrets.URI, data secrets.SecretData) (secrets.SecretData, *secrets.ValueRef, error) {
+	backendType, err := stores.BackendType()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if backendType == provider.Internal {
+		return data, nil, nil
+	}
+	store, err := stores.Store(backendType)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	revisionID, err := store.SaveContent(uri, secrets.NewSecretValue(data))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return nil, &secrets.ValueRef{
+		BackendType: backendType,
+		RevisionID:  revisionID,
+	}, nil
+}
+
+// GetContent returns the secret content held in state, or if ref
+// is not nil, the content it refers to in an external backend.
+func GetContent(stores Stores, value secrets.SecretValue, ref *secrets.ValueRef) (secrets.SecretValue, error) {
+	if ref == nil {
+		return value, nil
+	}
+	store, err := stores.Store(ref.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	val, err := store.GetContent(ref.RevisionID)
+	return val, errors.Trace(err)
+}
+
+// DeleteContent removes the content referred to by ref
+// from its external backend. It is a no-op if ref is nil.
+func DeleteContent(stores Stores, ref *secrets.ValueRef) error {
+	if ref == nil {
+		return nil
+	}
+	store, err := stores.Store(ref.BackendType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(store.DeleteContent(ref.RevisionID))
+}
+
+// ValidateBackend returns an error if the specified
+// backend type, config and credential are not valid.
+func ValidateBackend(backendType string, cfg map[string]interface{}, credential map[string]string) error {
+	if backendType == provider.Internal {
+		if len(cfg) > 0 || len(credential) > 0 {
+			return errors.NotValidf("%q secret backend config", backendType)
+		}
+		return nil
+	}
+	p, err := provider.Provider(backendType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.ValidateConfig(cfg, credential))
+}
+
+// SplitCredential separates the attributes of the specified
+// backend type's config which are held in its credential.
+func SplitCredential(backendType string, attrs map[string]interface{}) (map[string]interface{}, map[string]string, error) {
+	if backendType == provider.Internal {
+		return attrs, nil, nil
+	}
+	p, err := provider.Provider(backendType)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	credentialAttrs := set.NewStrings(p.CredentialAttributes()...)
+	var (
+		cfg        map[string]interface{}
+		credential map[string]string
+	)
+	for k, v := range attrs {
+		if !credentialAttrs.Contains(k) {
+			if cfg == nil {
+				cfg = make(map[string]interface{})
+			}
+			cfg[k] = v
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, errors.NotValidf("%q secret backend %q attribute", backendType, k)
+		}
+		if credential == nil {
+			credential = make(map[string]string)
+		}
+		credential[k] = s
+	}
+	return cfg, credential, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	secretscommon "github.com/juju/juju/apiserver/common/secrets"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+
+	stores *mockStores
+	uri    *coresecrets.URI
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.stores = &mockStores{backendType: provider.Internal, store: &mockStore{}}
+	s.uri = coresecrets.NewURI(coretesting.ModelTag.Id())
+}
+
+func (s *SecretsSuite) TestSaveContentInternal(c *gc.C) {
+	data := coresecrets.SecretData{"foo": "YmFy"}
+	saved, ref, err := secretscommon.SaveContent(s.stores, s.uri, data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(saved, jc.DeepEquals, data)
+	c.Assert(ref, gc.IsNil)
+	s.stores.CheckCallNames(c, "BackendType")
+}
+
+func (s *SecretsSuite) TestSaveContentExternal(c *gc.C) {
+	s.stores.backendType = "vault"
+	data := coresecrets.SecretData{"foo": "YmFy"}
+	saved, ref, err := secretscommon.SaveContent(s.stores, s.uri, data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(saved, gc.IsNil)
+	c.Assert(ref, jc.DeepEquals, &coresecrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"})
+	s.stores.CheckCall(c, 1, "Store", "vault")
+	s.stores.store.CheckCall(c, 0, "SaveContent", s.uri, coresecrets.NewSecretValue(data))
+}
+
+func (s *SecretsSuite) TestGetContent(c *gc.C) {
+	value := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	val, err := secretscommon.GetContent(s.stores, value, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val, jc.DeepEquals, value)
+	s.stores.CheckNoCalls(c)
+
+	ref := &coresecrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"}
+	val, err = secretscommon.GetContent(s.stores, nil, ref)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val, jc.DeepEquals, coresecrets.NewSecretValue(map[string]string{"foo": "YmF6"}))
+	s.stores.CheckCall(c, 0, "Store", "vault")
+	s.stores.store.CheckCall(c, 0, "GetContent", "rev-id")
+}
+
+func (s *SecretsSuite) TestDeleteContent(c *gc.C) {
+	err := secretscommon.DeleteContent(s.stores, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stores.CheckNoCalls(c)
+
+	s.stores.store.SetErrors(errors.New("boom"))
+	err = secretscommon.DeleteContent(s.stores, &coresecrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.stores.store.CheckCall(c, 0, "DeleteContent", "rev-id")
+}
+
+func (s *SecretsSuite) TestValidateBackend(c *gc.C) {
+	err := secretscommon.ValidateBackend(provider.Internal, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = secretscommon.ValidateBackend(provider.Internal, map[string]interface{}{"foo": "bar"}, nil)
+	c.Assert(err, gc.ErrorMatches, `"internal" secret backend config not valid`)
+	err = secretscommon.ValidateBackend("vault", map[string]interface{}{
+		"endpoint": "http://vault:8200",
+	}, map[string]string{"token": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = secretscommon.ValidateBackend("unknown", nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSplitCredential(c *gc.C) {
+	cfg, credential, err := secretscommon.SplitCredential("vault", map[string]interface{}{
+		"endpoint": "http://vault:8200",
+		"token":    "foo",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, map[string]interface{}{"endpoint": "http://vault:8200"})
+	c.Assert(credential, jc.DeepEquals, map[string]string{"token": "foo"})
+
+	_, _, err = secretscommon.SplitCredential("vault", map[string]interface{}{"token": 42})
+	c.Assert(err, gc.ErrorMatches, `"vault" secret backend "token" attribute not valid`)
+	_, _, err = secretscommon.SplitCredential("unknown", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type mockStores struct {
+	testing.Stub
+
+	backendType string
+	store       *mockStore
+}
+
+func (m *mockStores) BackendType() (string, error) {
+	m.MethodCall(m, "BackendType")
+	return m.backendType, m.NextErr()
+}
+
+func (m *mockStores) Store(backendType string) (provider.SecretsStore, error) {
+	m.MethodCall(m, "Store", backendType)
+	return m.store, m.NextErr()
+}
+
+type mockStore struct {
+	testing.Stub
+}
+
+func (m *mockStore) SaveContent(uri *coresecrets.URI, value coresecrets.SecretValue) (string, error) {
+	m.MethodCall(m, "SaveContent", uri, value)
+	return "rev-id", m.NextErr()
+}
+
+func (m *mockStore) GetContent(revisionID string) (coresecrets.SecretValue, error) {
+	m.MethodCall(m, "GetContent", revisionID)
+	return coresecrets.NewSecretValue(map[string]string{"foo": "YmF6"}), m.NextErr()
+}
+
+func (m *mockStore) DeleteContent(revisionID string) error {
+	m.MethodCall(m, "DeleteContent", revisionID)
+	return m.NextErr()
+}
@@ -26,11 +26,12 @@ func VolumeParams(
 	registry storage.ProviderRegistry,
 ) (params.VolumeParams, error) {
 
-	var pool string
+	var pool, snapshotId string
 	var size uint64
 	if stateVolumeParams, ok := v.Params(); ok {
 		pool = stateVolumeParams.Pool
 		size = stateVolumeParams.Size
+		snapshotId = stateVolumeParams.Snapshot
 	} else {
 		volumeInfo, err := v.Info()
 		if err != nil {
@@ -56,6 +57,7 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		snapshotId,
 	}, nil
 }
 
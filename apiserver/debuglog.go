@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -181,6 +182,7 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
 	startTime     time.Time
+	endTime       time.Time
 	maxLines      uint
 	fromTheStart  bool
 	noTail        bool
@@ -190,6 +192,9 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+	includeLabel  []string
+	excludeLabel  []string
+	messageRegex  string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -245,10 +250,30 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before start time", value)
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Annotatef(err, "message regex %q is not valid", value)
+		}
+		params.messageRegex = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeLabel = queryMap["includeLabel"]
+	params.excludeLabel = queryMap["excludeLabel"]
 
 	return params, nil
 }
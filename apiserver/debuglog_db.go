@@ -32,6 +32,16 @@ func handleDebugLogDBRequest(
 	stop <-chan struct{},
 ) error {
 	params := makeLogTailerParams(reqParams)
+	// There's no point waiting for new logs if they
+	// would all be after the requested end time.
+	if !reqParams.endTime.IsZero() {
+		untilEnd := reqParams.endTime.Sub(clock.Now())
+		if untilEnd <= 0 {
+			params.NoTail = true
+		} else if untilEnd < maxDuration {
+			maxDuration = untilEnd
+		}
+	}
 	tailer, err := newLogTailer(st, params)
 	if err != nil {
 		return errors.Trace(err)
@@ -72,11 +82,15 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
 		InitialLines:  int(reqParams.backlog),
 		IncludeEntity: reqParams.includeEntity,
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+		IncludeLabel:  reqParams.includeLabel,
+		ExcludeLabel:  reqParams.excludeLabel,
+		MessageRegex:  reqParams.messageRegex,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -86,12 +100,14 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 
 func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 	return &params.LogMessage{
+		ModelUUID: r.ModelUUID,
 		Entity:    r.Entity,
 		Timestamp: r.Time,
 		Severity:  r.Level.String(),
 		Module:    r.Module,
 		Location:  r.Location,
 		Message:   r.Message,
+		Labels:    r.Labels,
 	}
 }
 
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -46,6 +47,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		includeModule: []string{"bar"},
 		excludeEntity: []string{"baz"},
 		excludeModule: []string{"qux"},
+		includeLabel:  []string{"http"},
+		excludeLabel:  []string{"debug"},
+		messageRegex:  "^hook",
 	}
 
 	called := false
@@ -62,6 +66,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeLabel, jc.DeepEquals, []string{"http"})
+		c.Assert(params.ExcludeLabel, jc.DeepEquals, []string{"debug"})
+		c.Assert(params.MessageRegex, gc.Equals, "^hook")
 
 		return newFakeLogTailer(), nil
 	})
@@ -96,6 +103,88 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionEndTimePassed(c *gc.C) {
+	endTime := s.clock.Now().Add(-time.Minute)
+	reqParams := debugLogParams{
+		endTime: endTime,
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+		c.Assert(params.EndTime, gc.Equals, endTime)
+		// No logs will be written before the end time,
+		// so there's no need to wait for them.
+		c.Assert(params.NoTail, jc.IsTrue)
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(s.clock, s.timeout, nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestStopsAtEndTime(c *gc.C) {
+	tailer := newFakeLogTailer()
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		c.Assert(params.NoTail, jc.IsFalse)
+		return tailer, nil
+	})
+
+	done := s.runRequest(debugLogParams{
+		endTime: s.clock.Now().Add(time.Second),
+	}, nil)
+	s.assertOutput(c, []string{"ok"})
+	s.assertRunning(c, done, tailer)
+
+	// The request stops at the end time rather than
+	// waiting for the maximum duration.
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStops(c, done, tailer)
+}
+
+func (s *debugLogDBIntSuite) TestReadParams(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime":    {"2016-11-30T10:51:00Z"},
+		"endTime":      {"2016-11-30T11:51:00Z"},
+		"messageRegex": {"^hook .* failed$"},
+		"includeLabel": {"http", "db"},
+		"excludeLabel": {"debug"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.startTime, gc.Equals, time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC))
+	c.Assert(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC))
+	c.Assert(params.messageRegex, gc.Equals, "^hook .* failed$")
+	c.Assert(params.includeLabel, jc.DeepEquals, []string{"http", "db"})
+	c.Assert(params.excludeLabel, jc.DeepEquals, []string{"debug"})
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsErrors(c *gc.C) {
+	for i, test := range []struct {
+		query url.Values
+		err   string
+	}{{
+		query: url.Values{"endTime": {"yesterday"}},
+		err:   `end time "yesterday" is not a valid time in RFC3339 format`,
+	}, {
+		query: url.Values{
+			"startTime": {"2016-11-30T11:51:00Z"},
+			"endTime":   {"2016-11-30T10:51:00Z"},
+		},
+		err: `end time "2016-11-30T10:51:00Z" is before start time`,
+	}, {
+		query: url.Values{"messageRegex": {"("}},
+		err:   `message regex "\(" is not valid: .*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := readDebugLogParams(test.query)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
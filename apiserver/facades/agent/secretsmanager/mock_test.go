@@ -0,0 +1,180 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockSecretsBackend struct {
+	testing.Stub
+
+	owner    string
+	access   map[string]secrets.SecretRole
+	valueRef *secrets.ValueRef
+	watcher  *mockStringsWatcher
+}
+
+func (m *mockSecretsBackend) CreateSecret(uri *secrets.URI, p state.CreateSecretParams) (*secrets.SecretMetadata, error) {
+	m.MethodCall(m, "CreateSecret", uri, p)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &secrets.SecretMetadata{URI: uri, OwnerTag: p.Owner.String(), LatestRevision: 1}, nil
+}
+
+func (m *mockSecretsBackend) UpdateSecret(uri *secrets.URI, p state.UpdateSecretParams) (*secrets.SecretMetadata, error) {
+	m.MethodCall(m, "UpdateSecret", uri, p)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &secrets.SecretMetadata{URI: uri, OwnerTag: m.owner, LatestRevision: 2}, nil
+}
+
+func (m *mockSecretsBackend) GetSecret(uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	m.MethodCall(m, "GetSecret", uri)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &secrets.SecretMetadata{URI: uri, OwnerTag: m.owner, LatestRevision: 2}, nil
+}
+
+func (m *mockSecretsBackend) GetSecretValue(uri *secrets.URI, revision int) (secrets.SecretValue, *secrets.ValueRef, error) {
+	m.MethodCall(m, "GetSecretValue", uri, revision)
+	if err := m.NextErr(); err != nil {
+		return nil, nil, err
+	}
+	if m.valueRef != nil {
+		return secrets.NewSecretValue(nil), m.valueRef, nil
+	}
+	return secrets.NewSecretValue(map[string]string{"foo": "YmFy"}), nil, nil
+}
+
+func (m *mockSecretsBackend) ListSecrets(filter state.SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	m.MethodCall(m, "ListSecrets", filter)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*secrets.SecretMetadata{{
+		URI:            &secrets.URI{ModelUUID: coretesting.ModelTag.Id(), ID: "9m4e2mr0ui3e8a215n4g"},
+		OwnerTag:       m.owner,
+		Description:    "my secret",
+		RotateInterval: time.Hour,
+		LatestRevision: 2,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}}, nil
+}
+
+func (m *mockSecretsBackend) GrantSecretAccess(uri *secrets.URI, p state.SecretAccessParams) error {
+	m.MethodCall(m, "GrantSecretAccess", uri, p)
+	return m.NextErr()
+}
+
+func (m *mockSecretsBackend) RevokeSecretAccess(uri *secrets.URI, p state.SecretAccessParams) error {
+	m.MethodCall(m, "RevokeSecretAccess", uri, p)
+	return m.NextErr()
+}
+
+func (m *mockSecretsBackend) SecretAccess(uri *secrets.URI, subject names.Tag) (secrets.SecretRole, error) {
+	m.MethodCall(m, "SecretAccess", uri, subject)
+	if err := m.NextErr(); err != nil {
+		return secrets.RoleNone, err
+	}
+	return m.access[subject.String()], nil
+}
+
+func (m *mockSecretsBackend) SecretRotated(uri *secrets.URI, when time.Time) error {
+	m.MethodCall(m, "SecretRotated", uri, when)
+	return m.NextErr()
+}
+
+func (m *mockSecretsBackend) WatchSecretsRotationChanges(owner names.Tag) state.StringsWatcher {
+	m.MethodCall(m, "WatchSecretsRotationChanges", owner)
+	return m.watcher
+}
+
+type mockStringsWatcher struct {
+	state.StringsWatcher
+	changes chan []string
+}
+
+func (w *mockStringsWatcher) Changes() <-chan []string {
+	return w.changes
+}
+
+func (w *mockStringsWatcher) Stop() error {
+	return nil
+}
+
+func (w *mockStringsWatcher) Kill() {}
+
+func (w *mockStringsWatcher) Wait() error {
+	return nil
+}
+
+func (w *mockStringsWatcher) Err() error {
+	return nil
+}
+
+type mockLeadershipChecker struct {
+	testing.Stub
+}
+
+func (m *mockLeadershipChecker) LeadershipCheck(applicationId, unitId string) leadership.Token {
+	m.MethodCall(m, "LeadershipCheck", applicationId, unitId)
+	return &mockToken{}
+}
+
+type mockToken struct{}
+
+func (t *mockToken) Check(int, interface{}) error {
+	return nil
+}
+
+type mockStores struct {
+	testing.Stub
+
+	backendType string
+	store       *mockStore
+}
+
+func (m *mockStores) BackendType() (string, error) {
+	m.MethodCall(m, "BackendType")
+	return m.backendType, m.NextErr()
+}
+
+func (m *mockStores) Store(backendType string) (provider.SecretsStore, error) {
+	m.MethodCall(m, "Store", backendType)
+	return m.store, m.NextErr()
+}
+
+type mockStore struct {
+	testing.Stub
+}
+
+func (m *mockStore) SaveContent(uri *secrets.URI, value secrets.SecretValue) (string, error) {
+	m.MethodCall(m, "SaveContent", uri, value)
+	return "rev-id", m.NextErr()
+}
+
+func (m *mockStore) GetContent(revisionID string) (secrets.SecretValue, error) {
+	m.MethodCall(m, "GetContent", revisionID)
+	return secrets.NewSecretValue(map[string]string{"foo": "YmF6"}), m.NextErr()
+}
+
+func (m *mockStore) DeleteContent(revisionID string) error {
+	m.MethodCall(m, "DeleteContent", revisionID)
+	return m.NextErr()
+}
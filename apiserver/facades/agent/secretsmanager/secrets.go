@@ -0,0 +1,402 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+
+	secretscommon "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.secretsmanager")
+
+// SecretsManagerAPI is the implementation for the SecretsManager facade.
+type SecretsManagerAPI struct {
+	modelUUID         string
+	authTag           names.UnitTag
+	leadershipChecker leadership.Checker
+	backend           SecretsBackend
+	stores            secretscommon.Stores
+	resources         facade.Resources
+	clock             clock.Clock
+}
+
+// NewSecretManagerAPI creates a SecretsManagerAPI.
+func NewSecretManagerAPI(context facade.Context) (*SecretsManagerAPI, error) {
+	leadershipChecker, err := context.LeadershipChecker()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := context.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewSecretsManager(
+		context.State().ModelUUID(),
+		context.Auth(),
+		leadershipChecker,
+		NewStateBackend(context.State()),
+		secretscommon.NewStores(model),
+		context.Resources(),
+		context.StatePool().Clock(),
+	)
+}
+
+// NewSecretsManager returns a new SecretsManagerAPI using the
+// specified dependencies.
+func NewSecretsManager(
+	modelUUID string,
+	authorizer facade.Authorizer,
+	leadershipChecker leadership.Checker,
+	backend SecretsBackend,
+	stores secretscommon.Stores,
+	resources facade.Resources,
+	clock clock.Clock,
+) (*SecretsManagerAPI, error) {
+	authTag, ok := authorizer.GetAuthTag().(names.UnitTag)
+	if !ok || !authorizer.AuthUnitAgent() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &SecretsManagerAPI{
+		modelUUID:         modelUUID,
+		authTag:           authTag,
+		leadershipChecker: leadershipChecker,
+		backend:           backend,
+		stores:            stores,
+		resources:         resources,
+		clock:             clock,
+	}, nil
+}
+
+func (s *SecretsManagerAPI) appTag() names.ApplicationTag {
+	appName, _ := names.UnitApplication(s.authTag.Id())
+	return names.NewApplicationTag(appName)
+}
+
+func (s *SecretsManagerAPI) leaderToken() leadership.Token {
+	return s.leadershipChecker.LeadershipCheck(s.appTag().Id(), s.authTag.Id())
+}
+
+// access returns the role the calling unit has on the specified secret,
+// and the leadership token to use if the secret is owned by the unit's
+// application.
+func (s *SecretsManagerAPI) access(uri *secrets.URI) (secrets.SecretRole, leadership.Token, error) {
+	md, err := s.backend.GetSecret(uri)
+	if err != nil {
+		return secrets.RoleNone, nil, errors.Trace(err)
+	}
+	if md.OwnerTag == s.appTag().String() {
+		return secrets.RoleManage, s.leaderToken(), nil
+	}
+	role, err := s.backend.SecretAccess(uri, s.authTag)
+	if err != nil {
+		return secrets.RoleNone, nil, errors.Trace(err)
+	}
+	if role.Allowed(secrets.RoleManage) {
+		return role, nil, nil
+	}
+	appRole, err := s.backend.SecretAccess(uri, s.appTag())
+	if err != nil {
+		return secrets.RoleNone, nil, errors.Trace(err)
+	}
+	if appRole.Allowed(secrets.RoleView) {
+		role = appRole
+	}
+	return role, nil, nil
+}
+
+func (s *SecretsManagerAPI) parseURI(str string) (*secrets.URI, error) {
+	uri, err := secrets.ParseURI(str)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if uri.ModelUUID != s.modelUUID {
+		return nil, errors.NotFoundf("secret %q", str)
+	}
+	return uri, nil
+}
+
+// CreateSecrets creates new secrets owned by the calling unit's application.
+func (s *SecretsManagerAPI) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		uri, err := s.createSecret(arg)
+		result.Results[i].Result = uri
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) createSecret(arg params.CreateSecretArg) (string, error) {
+	if arg.OwnerTag != s.appTag().String() {
+		return "", apiservererrors.ErrPerm
+	}
+	if len(arg.Data) == 0 {
+		return "", errors.NotValidf("empty secret value")
+	}
+	uri := secrets.NewURI(s.modelUUID)
+	data, ref, err := secretscommon.SaveContent(s.stores, uri, arg.Data)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	md, err := s.backend.CreateSecret(uri, state.CreateSecretParams{
+		Owner: s.appTag(),
+		UpdateSecretParams: state.UpdateSecretParams{
+			LeaderToken:    s.leaderToken(),
+			RotateInterval: arg.RotateInterval,
+			Description:    arg.Description,
+			Data:           data,
+			ValueRef:       ref,
+		},
+	})
+	if err != nil {
+		s.deleteContent(uri, ref)
+		return "", errors.Trace(err)
+	}
+	return md.URI.String(), nil
+}
+
+// deleteContent removes orphaned content from an external
+// secret backend after a failure to record it in state.
+func (s *SecretsManagerAPI) deleteContent(uri *secrets.URI, ref *secrets.ValueRef) {
+	if err := secretscommon.DeleteContent(s.stores, ref); err != nil {
+		logger.Warningf("cannot delete orphaned content for secret %q: %v", uri, err)
+	}
+}
+
+// UpdateSecrets updates the specified secrets.
+func (s *SecretsManagerAPI) UpdateSecrets(args params.UpdateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := s.updateSecret(arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) updateSecret(arg params.UpdateSecretArg) error {
+	uri, err := s.parseURI(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.RotateInterval == nil && arg.Description == nil && len(arg.Data) == 0 {
+		return errors.New("at least one attribute to update must be specified")
+	}
+	role, token, err := s.access(uri)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !role.Allowed(secrets.RoleManage) {
+		return apiservererrors.ErrPerm
+	}
+	p := state.UpdateSecretParams{
+		LeaderToken:    token,
+		RotateInterval: arg.RotateInterval,
+		Description:    arg.Description,
+	}
+	if len(arg.Data) > 0 {
+		if p.Data, p.ValueRef, err = secretscommon.SaveContent(s.stores, uri, arg.Data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if _, err = s.backend.UpdateSecret(uri, p); err != nil {
+		s.deleteContent(uri, p.ValueRef)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// GetSecretMetadata returns metadata for the caller's secrets.
+func (s *SecretsManagerAPI) GetSecretMetadata() (params.ListSecretResults, error) {
+	var result params.ListSecretResults
+	owner := names.Tag(s.appTag())
+	mds, err := s.backend.ListSecrets(state.SecretsFilter{OwnerTag: &owner})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, md := range mds {
+		result.Results = append(result.Results, params.ListSecretResult{
+			URI:            md.URI.String(),
+			OwnerTag:       md.OwnerTag,
+			Description:    md.Description,
+			RotateInterval: md.RotateInterval,
+			NextRotateTime: md.NextRotateTime,
+			LatestRevision: md.LatestRevision,
+			CreateTime:     md.CreateTime,
+			UpdateTime:     md.UpdateTime,
+		})
+	}
+	return result, nil
+}
+
+// GetSecretValues returns the secret values for the specified secrets.
+func (s *SecretsManagerAPI) GetSecretValues(args params.GetSecretValueArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		data, err := s.getSecretValue(arg)
+		result.Results[i].Data = data
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) getSecretValue(arg params.GetSecretValueArg) (map[string]string, error) {
+	uri, err := s.parseURI(arg.URI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	role, _, err := s.access(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !role.Allowed(secrets.RoleView) {
+		return nil, apiservererrors.ErrPerm
+	}
+	val, ref, err := s.backend.GetSecretValue(uri, arg.Revision)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if val, err = secretscommon.GetContent(s.stores, val, ref); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return val.EncodedValues(), nil
+}
+
+// GrantSecret grants access to the specified secrets.
+func (s *SecretsManagerAPI) GrantSecret(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.secretsGrantRevoke(args, s.backend.GrantSecretAccess)
+}
+
+// RevokeSecret revokes access to the specified secrets.
+func (s *SecretsManagerAPI) RevokeSecret(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.secretsGrantRevoke(args, s.backend.RevokeSecretAccess)
+}
+
+type grantRevokeFunc func(*secrets.URI, state.SecretAccessParams) error
+
+func (s *SecretsManagerAPI) secretsGrantRevoke(args params.GrantRevokeSecretArgs, op grantRevokeFunc) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := s.grantRevokeSecret(arg, op)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) grantRevokeSecret(arg params.GrantRevokeSecretArg, op grantRevokeFunc) error {
+	uri, err := s.parseURI(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	role, token, err := s.access(uri)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !role.Allowed(secrets.RoleManage) {
+		return apiservererrors.ErrPerm
+	}
+	var scopeTag names.Tag
+	if arg.ScopeTag != "" {
+		if scopeTag, err = names.ParseTag(arg.ScopeTag); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	grantRole := secrets.SecretRole(arg.Role)
+	if grantRole == secrets.RoleNone {
+		grantRole = secrets.RoleView
+	}
+	for _, tagStr := range arg.SubjectTags {
+		subjectTag, err := names.ParseTag(tagStr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := op(uri, state.SecretAccessParams{
+			LeaderToken: token,
+			Scope:       scopeTag,
+			Subject:     subjectTag,
+			Role:        grantRole,
+		}); err != nil {
+			return errors.Annotatef(err, "cannot change access to %q for %q", uri, tagStr)
+		}
+	}
+	return nil
+}
+
+// WatchSecretsRotationChanges sets up a watcher to notify of changes to secret
+// rotation config for secrets owned by the specified applications.
+func (s *SecretsManagerAPI) WatchSecretsRotationChanges(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		ownerTag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if ownerTag != s.appTag() {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		w := s.backend.WatchSecretsRotationChanges(ownerTag)
+		if changes, ok := <-w.Changes(); ok {
+			result.Results[i].StringsWatcherId = s.resources.Register(w)
+			result.Results[i].Changes = changes
+		} else {
+			err = watcher.EnsureErr(w)
+			result.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// SecretsRotated records when secrets were last rotated.
+func (s *SecretsManagerAPI) SecretsRotated(args params.SecretRotatedArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := s.secretRotated(arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) secretRotated(arg params.SecretRotatedArg) error {
+	uri, err := s.parseURI(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	role, _, err := s.access(uri)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !role.Allowed(secrets.RoleManage) {
+		return apiservererrors.ErrPerm
+	}
+	when := arg.When
+	if when.IsZero() {
+		when = s.clock.Now()
+	}
+	logger.Debugf("secret %q rotated at %v", uri, when)
+	return errors.Trace(s.backend.SecretRotated(uri, when))
+}
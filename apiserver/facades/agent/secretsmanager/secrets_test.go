@@ -0,0 +1,350 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/secretsmanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsManagerSuite struct {
+	coretesting.BaseSuite
+
+	authorizer        apiservertesting.FakeAuthorizer
+	resources         *common.Resources
+	backend           *mockSecretsBackend
+	stores            *mockStores
+	leadershipChecker *mockLeadershipChecker
+	clock             *testclock.Clock
+
+	facade *secretsmanager.SecretsManagerAPI
+}
+
+var _ = gc.Suite(&SecretsManagerSuite{})
+
+func (s *SecretsManagerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mariadb/0"),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+
+	s.backend = &mockSecretsBackend{
+		owner:  "application-mariadb",
+		access: make(map[string]secrets.SecretRole),
+		watcher: &mockStringsWatcher{
+			changes: make(chan []string, 1),
+		},
+	}
+	s.stores = &mockStores{
+		backendType: provider.Internal,
+		store:       &mockStore{},
+	}
+	s.leadershipChecker = &mockLeadershipChecker{}
+	s.clock = testclock.NewClock(time.Now())
+
+	var err error
+	s.facade, err = secretsmanager.NewSecretsManager(
+		coretesting.ModelTag.Id(), s.authorizer, s.leadershipChecker, s.backend, s.stores, s.resources, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsManagerSuite) TestNewSecretsManagerRequiresUnitAgent(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	_, err := secretsmanager.NewSecretsManager(
+		coretesting.ModelTag.Id(), authorizer, s.leadershipChecker, s.backend, s.stores, s.resources, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsManagerSuite) TestCreateSecrets(c *gc.C) {
+	description := "my secret"
+	results, err := s.facade.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			OwnerTag:    "application-mariadb",
+			Description: &description,
+			Data:        map[string]string{"foo": "YmFy"},
+		}, {
+			OwnerTag: "application-mysql",
+			Data:     map[string]string{"foo": "YmFy"},
+		}, {
+			OwnerTag: "application-mariadb",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	uri, err := secrets.ParseURI(results.Results[0].Result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uri.ModelUUID, gc.Equals, coretesting.ModelTag.Id())
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "empty secret value not valid")
+
+	s.leadershipChecker.CheckCall(c, 0, "LeadershipCheck", "mariadb", "mariadb/0")
+	s.backend.CheckCallNames(c, "CreateSecret")
+	p := s.backend.Calls()[0].Args[1].(state.CreateSecretParams)
+	c.Assert(p.Owner, gc.Equals, names.NewApplicationTag("mariadb"))
+	c.Assert(p.LeaderToken, gc.NotNil)
+	c.Assert(*p.Description, gc.Equals, "my secret")
+	c.Assert(p.Data, jc.DeepEquals, secrets.SecretData{"foo": "YmFy"})
+}
+
+func (s *SecretsManagerSuite) TestCreateSecretsExternalBackend(c *gc.C) {
+	s.stores.backendType = "vault"
+	results, err := s.facade.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			OwnerTag: "application-mariadb",
+			Data:     map[string]string{"foo": "YmFy"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	s.stores.CheckCalls(c, []testing.StubCall{
+		{"BackendType", nil},
+		{"Store", []interface{}{"vault"}},
+	})
+	s.stores.store.CheckCallNames(c, "SaveContent")
+	p := s.backend.Calls()[0].Args[1].(state.CreateSecretParams)
+	c.Assert(p.Data, gc.HasLen, 0)
+	c.Assert(p.ValueRef, jc.DeepEquals, &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"})
+}
+
+func (s *SecretsManagerSuite) TestCreateSecretsExternalBackendFailureDeletesContent(c *gc.C) {
+	s.stores.backendType = "vault"
+	s.backend.SetErrors(errors.New("boom"))
+	results, err := s.facade.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			OwnerTag: "application-mariadb",
+			Data:     map[string]string{"foo": "YmFy"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+	s.stores.store.CheckCallNames(c, "SaveContent", "DeleteContent")
+	s.stores.store.CheckCall(c, 1, "DeleteContent", "rev-id")
+}
+
+func (s *SecretsManagerSuite) TestUpdateSecrets(c *gc.C) {
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			URI:  uri.String(),
+			Data: map[string]string{"foo": "YmFy"},
+		}, {
+			URI: uri.String(),
+		}, {
+			URI:  "secret://deadbeef-0bad-400d-8000-4b1d0d06f00e/foo",
+			Data: map[string]string{"foo": "YmFy"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "at least one attribute to update must be specified")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `secret ".*" not found`)
+
+	s.backend.CheckCallNames(c, "GetSecret", "UpdateSecret")
+	p := s.backend.Calls()[1].Args[1].(state.UpdateSecretParams)
+	c.Assert(p.LeaderToken, gc.NotNil)
+	c.Assert(p.Data, jc.DeepEquals, secrets.SecretData{"foo": "YmFy"})
+}
+
+func (s *SecretsManagerSuite) TestUpdateSecretsNotOwner(c *gc.C) {
+	s.backend.owner = "application-mysql"
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			URI:  uri.String(),
+			Data: map[string]string{"foo": "YmFy"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesOwner(c *gc.C) {
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.GetSecretValues(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			URI:      uri.String(),
+			Revision: 1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{{
+			Data: map[string]string{"foo": "YmFy"},
+		}},
+	})
+	s.backend.CheckCallNames(c, "GetSecret", "GetSecretValue")
+	s.backend.CheckCall(c, 1, "GetSecretValue", uri, 1)
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesExternalBackend(c *gc.C) {
+	s.backend.valueRef = &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"}
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.GetSecretValues(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			URI: uri.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Data, jc.DeepEquals, map[string]string{"foo": "YmF6"})
+	s.stores.CheckCall(c, 0, "Store", "vault")
+	s.stores.store.CheckCall(c, 0, "GetContent", "rev-id")
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesGranted(c *gc.C) {
+	s.backend.owner = "application-mysql"
+	s.backend.access["application-mariadb"] = secrets.RoleView
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.GetSecretValues(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			URI: uri.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Data, jc.DeepEquals, map[string]string{"foo": "YmFy"})
+	s.backend.CheckCallNames(c, "GetSecret", "SecretAccess", "SecretAccess", "GetSecretValue")
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesDenied(c *gc.C) {
+	s.backend.owner = "application-mysql"
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.GetSecretValues(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			URI: uri.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "GetSecret", "SecretAccess", "SecretAccess")
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesNotFound(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("secret"))
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.GetSecretValues(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			URI: uri.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *SecretsManagerSuite) TestGetSecretMetadata(c *gc.C) {
+	results, err := s.facade.GetSecretMetadata()
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Assert(results, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.ListSecretResult{{
+			URI:            "secret://" + coretesting.ModelTag.Id() + "/9m4e2mr0ui3e8a215n4g",
+			OwnerTag:       "application-mariadb",
+			Description:    "my secret",
+			RotateInterval: time.Hour,
+			LatestRevision: 2,
+			CreateTime:     now,
+			UpdateTime:     now,
+		}},
+	})
+	owner := names.Tag(names.NewApplicationTag("mariadb"))
+	s.backend.CheckCall(c, 0, "ListSecrets", state.SecretsFilter{OwnerTag: &owner})
+}
+
+func (s *SecretsManagerSuite) TestGrantSecret(c *gc.C) {
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.GrantSecret(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			URI:         uri.String(),
+			ScopeTag:    "relation-wordpress.db#mariadb.server",
+			SubjectTags: []string{"application-wordpress", "unit-wordpress-0"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "GetSecret", "GrantSecretAccess", "GrantSecretAccess")
+	p := s.backend.Calls()[1].Args[1].(state.SecretAccessParams)
+	c.Assert(p.Scope, gc.Equals, names.NewRelationTag("wordpress:db mariadb:server"))
+	c.Assert(p.Subject, gc.Equals, names.NewApplicationTag("wordpress"))
+	c.Assert(p.Role, gc.Equals, secrets.RoleView)
+	c.Assert(p.LeaderToken, gc.NotNil)
+	p = s.backend.Calls()[2].Args[1].(state.SecretAccessParams)
+	c.Assert(p.Subject, gc.Equals, names.NewUnitTag("wordpress/0"))
+}
+
+func (s *SecretsManagerSuite) TestRevokeSecret(c *gc.C) {
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	results, err := s.facade.RevokeSecret(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			URI:         uri.String(),
+			SubjectTags: []string{"application-wordpress"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "GetSecret", "RevokeSecretAccess")
+	p := s.backend.Calls()[1].Args[1].(state.SecretAccessParams)
+	c.Assert(p.Subject, gc.Equals, names.NewApplicationTag("wordpress"))
+}
+
+func (s *SecretsManagerSuite) TestWatchSecretsRotationChanges(c *gc.C) {
+	s.backend.watcher.changes <- []string{"secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo"}
+	results, err := s.facade.WatchSecretsRotationChanges(params.Entities{
+		Entities: []params.Entity{{
+			Tag: "application-mariadb",
+		}, {
+			Tag: "application-mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{{
+			StringsWatcherId: "1",
+			Changes:          []string{"secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/foo"},
+		}, {
+			Error: &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"},
+		}},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.watcher)
+}
+
+func (s *SecretsManagerSuite) TestSecretsRotated(c *gc.C) {
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	when := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	results, err := s.facade.SecretsRotated(params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{
+			URI:  uri.String(),
+			When: when,
+		}, {
+			URI: uri.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Combine(), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "GetSecret", "SecretRotated", "GetSecret", "SecretRotated")
+	s.backend.CheckCall(c, 1, "SecretRotated", uri, when)
+	s.backend.CheckCall(c, 3, "SecretRotated", uri, s.clock.Now())
+}
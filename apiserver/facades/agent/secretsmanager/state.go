@@ -0,0 +1,42 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+// SecretsBackend defines the state functionality required by the
+// secrets manager facade.
+type SecretsBackend interface {
+	CreateSecret(*secrets.URI, state.CreateSecretParams) (*secrets.SecretMetadata, error)
+	UpdateSecret(*secrets.URI, state.UpdateSecretParams) (*secrets.SecretMetadata, error)
+	GetSecret(*secrets.URI) (*secrets.SecretMetadata, error)
+	GetSecretValue(*secrets.URI, int) (secrets.SecretValue, *secrets.ValueRef, error)
+	ListSecrets(state.SecretsFilter) ([]*secrets.SecretMetadata, error)
+
+	GrantSecretAccess(*secrets.URI, state.SecretAccessParams) error
+	RevokeSecretAccess(*secrets.URI, state.SecretAccessParams) error
+	SecretAccess(*secrets.URI, names.Tag) (secrets.SecretRole, error)
+	SecretRotated(*secrets.URI, time.Time) error
+	WatchSecretsRotationChanges(names.Tag) state.StringsWatcher
+}
+
+type stateShim struct {
+	*state.State
+	state.SecretsStore
+}
+
+// NewStateBackend converts a state.State into a SecretsBackend.
+func NewStateBackend(st *state.State) SecretsBackend {
+	return &stateShim{
+		State:        st,
+		SecretsStore: state.NewSecrets(st),
+	}
+}
@@ -21,4 +21,5 @@ type Backend interface {
 	WatchModelFilesystems() state.StringsWatcher
 	WatchModelFilesystemAttachments() state.StringsWatcher
 	WatchModelVolumeAttachments() state.StringsWatcher
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
 }
@@ -4,6 +4,8 @@
 package filesystemwatcher_test
 
 import (
+	"sync"
+
 	"github.com/juju/errors"
 	"github.com/juju/juju/state"
 	"github.com/juju/names/v4"
@@ -19,6 +21,8 @@ type mockBackend struct {
 	modelFilesystemsW             *watchertest.StringsWatcher
 	modelFilesystemAttachmentsW   *watchertest.StringsWatcher
 	modelVolumeAttachmentsW       *watchertest.StringsWatcher
+	filesystemW                   *watchertest.NotifyWatcher
+	filesystemChanges             chan struct{}
 
 	filesystems               map[string]*mockFilesystem
 	volumeAttachments         map[string]*mockVolumeAttachment
@@ -82,13 +86,41 @@ func (b *mockBackend) WatchModelVolumeAttachments() state.StringsWatcher {
 	return b.modelVolumeAttachmentsW
 }
 
+func (b *mockBackend) WatchFilesystem(tag names.FilesystemTag) state.NotifyWatcher {
+	return b.filesystemW
+}
+
 func newStringsWatcher() *watchertest.StringsWatcher {
 	return watchertest.NewStringsWatcher(make(chan []string, 1))
 }
 
 type mockFilesystem struct {
 	state.Filesystem
-	volume names.VolumeTag
+	tag          names.FilesystemTag
+	volume       names.VolumeTag
+	hostAttached bool
+
+	mu          sync.Mutex
+	provisioned bool
+}
+
+func (f *mockFilesystem) FilesystemTag() names.FilesystemTag {
+	return f.tag
+}
+
+func (f *mockFilesystem) setProvisioned() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.provisioned = true
+}
+
+func (f *mockFilesystem) Info() (state.FilesystemInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.provisioned {
+		return state.FilesystemInfo{}, errors.NotProvisionedf("filesystem %s", f.tag.Id())
+	}
+	return state.FilesystemInfo{FilesystemId: "fs-" + f.tag.Id()}, nil
 }
 
 func (f *mockFilesystem) Volume() (names.VolumeTag, error) {
@@ -19,8 +19,26 @@ import (
 // model-scoped filesystems that have no backing volume. The host-level worker
 // watches both host-scoped filesystems, and model-scoped filesystems whose
 // backing volumes are attached to the host.
+//
+// Attachments of model-scoped filesystems that have no backing volume are
+// watched by the model-level worker, unless the filesystems are attached by
+// the host, e.g. network filesystems that each machine mounts; those are
+// watched by the host-level worker once the filesystem is provisioned.
 type Watchers struct {
 	Backend Backend
+
+	// HostAttached, if non-nil, reports whether the specified
+	// model-scoped filesystem is attached by the host it is
+	// attached to.
+	HostAttached func(state.Filesystem) (bool, error)
+}
+
+func (fw Watchers) hostAttached(f state.Filesystem) (bool, error) {
+	if fw.HostAttached == nil {
+		return false, nil
+	}
+	hostAttached, err := fw.HostAttached(f)
+	return hostAttached, errors.Annotate(err, "checking if filesystem is attached by its host")
 }
 
 // WatchModelManagedFilesystems returns a strings watcher that reports
@@ -232,8 +250,8 @@ func (w *hostFilesystemsWatcher) modelVolumeAttachmentChanged(hostTag names.Tag,
 
 // WatchModelManagedFilesystemAttachments returns a strings watcher that
 // reports lifecycle changes to attachments of model-scoped filesystem that
-// have no backing volume. Volume-backed filesystems are always managed by
-// the host to which they are attached.
+// have no backing volume, and are not attached by their hosts. Volume-backed
+// filesystems are always managed by the host to which they are attached.
 func (fw Watchers) WatchModelManagedFilesystemAttachments() state.StringsWatcher {
 	return newFilteredStringsWatcher(fw.Backend.WatchModelFilesystemAttachments(), func(id string) (bool, error) {
 		_, filesystemTag, err := state.ParseFilesystemAttachmentId(id)
@@ -246,15 +264,21 @@ func (fw Watchers) WatchModelManagedFilesystemAttachments() state.StringsWatcher
 		} else if err != nil {
 			return false, errors.Trace(err)
 		}
-		_, err = f.Volume()
-		return err == state.ErrNoBackingVolume, nil
+		if _, err := f.Volume(); err != state.ErrNoBackingVolume {
+			return false, nil
+		}
+		hostAttached, err := fw.hostAttached(f)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return !hostAttached, nil
 	})
 }
 
 // WatchMachineManagedFilesystemAttachments returns a strings watcher that
 // reports lifecycle changes for attachments to both machine-scoped filesystems,
-// and model-scoped, volume-backed filesystems that are attached to the
-// specified machine.
+// and model-scoped, volume-backed or host-attached filesystems that are
+// attached to the specified machine.
 func (fw Watchers) WatchMachineManagedFilesystemAttachments(m names.MachineTag) state.StringsWatcher {
 	w := &hostFilesystemAttachmentsWatcher{
 		stringsWatcherBase:               stringsWatcherBase{out: make(chan []string)},
@@ -265,6 +289,9 @@ func (fw Watchers) WatchMachineManagedFilesystemAttachments(m names.MachineTag)
 		modelVolumeAttachments:           fw.Backend.WatchModelVolumeAttachments(),
 		modelVolumesAttached:             names.NewSet(),
 		modelVolumeFilesystemAttachments: make(map[names.VolumeTag]string),
+		hostAttached:                     fw.hostAttached,
+		unprovisionedFilesystems:         make(map[names.FilesystemTag]set.Strings),
+		filesystemProvisioned:            make(chan names.FilesystemTag),
 		hostMatch: func(tag names.Tag) (bool, error) {
 			return tag == m, nil
 		},
@@ -293,6 +320,9 @@ func (fw Watchers) WatchUnitManagedFilesystemAttachments(app names.ApplicationTa
 		modelVolumeAttachments:           fw.Backend.WatchModelVolumeAttachments(),
 		modelVolumesAttached:             names.NewSet(),
 		modelVolumeFilesystemAttachments: make(map[names.VolumeTag]string),
+		hostAttached:                     fw.hostAttached,
+		unprovisionedFilesystems:         make(map[names.FilesystemTag]set.Strings),
+		filesystemProvisioned:            make(chan names.FilesystemTag),
 		hostMatch: func(tag names.Tag) (bool, error) {
 			unitApp, err := names.UnitApplication(tag.Id())
 			if err != nil {
@@ -313,8 +343,8 @@ func (fw Watchers) WatchUnitManagedFilesystemAttachments(app names.ApplicationTa
 
 // hostFilesystemAttachmentsWatcher is a strings watcher that reports
 // lifechcle changes for attachments to both host-scoped filesystems,
-// and model-scoped, volume-backed filesystems that are attached to the
-// specified host.
+// and model-scoped, volume-backed or host-attached filesystems that are
+// attached to the specified host.
 //
 // NOTE(axw) we use the existence of the *volume* attachment rather than
 // filesystem attachment because the filesystem attachment can be destroyed
@@ -329,6 +359,13 @@ type hostFilesystemAttachmentsWatcher struct {
 	modelVolumesAttached             names.Set
 	modelVolumeFilesystemAttachments map[names.VolumeTag]string
 	hostMatch                        func(names.Tag) (bool, error)
+	hostAttached                     func(state.Filesystem) (bool, error)
+
+	// unprovisionedFilesystems holds the IDs of the attachments of
+	// host-attached filesystems that are yet to be provisioned; the
+	// host needs the filesystem ID to attach them.
+	unprovisionedFilesystems map[names.FilesystemTag]set.Strings
+	filesystemProvisioned    chan names.FilesystemTag
 }
 
 func (w *hostFilesystemAttachmentsWatcher) loop() error {
@@ -392,6 +429,11 @@ func (w *hostFilesystemAttachmentsWatcher) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case filesystemTag := <-w.filesystemProvisioned:
+			for _, id := range w.unprovisionedFilesystems[filesystemTag].Values() {
+				w.changes.Add(id)
+			}
+			delete(w.unprovisionedFilesystems, filesystemTag)
 		case out <- w.changes.SortedValues():
 			w.changes = set.NewStrings()
 			out = nil
@@ -423,8 +465,7 @@ func (w *hostFilesystemAttachmentsWatcher) modelFilesystemAttachmentChanged(
 	}
 	volumeTag, err := filesystem.Volume()
 	if err == state.ErrNoBackingVolume {
-		// Filesystem has no backing volume: nothing more to do.
-		return nil
+		return w.hostAttachedFilesystemAttachmentChanged(filesystemAttachmentId, filesystem)
 	} else if err != nil {
 		return errors.Annotate(err, "getting filesystem volume")
 	}
@@ -435,6 +476,74 @@ func (w *hostFilesystemAttachmentsWatcher) modelFilesystemAttachmentChanged(
 	return nil
 }
 
+// hostAttachedFilesystemAttachmentChanged is called for attachments of
+// model-scoped filesystems that have no backing volume. The attachment
+// is reported if the filesystem is attached by the host, as soon as the
+// filesystem has been provisioned.
+func (w *hostFilesystemAttachmentsWatcher) hostAttachedFilesystemAttachmentChanged(
+	filesystemAttachmentId string,
+	filesystem state.Filesystem,
+) error {
+	hostAttached, err := w.hostAttached(filesystem)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !hostAttached {
+		// The filesystem is attached by the model: nothing more to do.
+		return nil
+	}
+	if _, err := filesystem.Info(); err == nil {
+		w.changes.Add(filesystemAttachmentId)
+		return nil
+	} else if !errors.IsNotProvisioned(err) {
+		return errors.Annotate(err, "getting filesystem info")
+	}
+	filesystemTag := filesystem.FilesystemTag()
+	ids, ok := w.unprovisionedFilesystems[filesystemTag]
+	if !ok {
+		ids = set.NewStrings()
+		w.unprovisionedFilesystems[filesystemTag] = ids
+		w.watchFilesystemProvisioned(filesystemTag)
+	}
+	ids.Add(filesystemAttachmentId)
+	return nil
+}
+
+// watchFilesystemProvisioned starts a goroutine that watches the
+// specified filesystem, and sends its tag on filesystemProvisioned
+// once it has been provisioned, or removed.
+func (w *hostFilesystemAttachmentsWatcher) watchFilesystemProvisioned(filesystemTag names.FilesystemTag) {
+	fw := w.backend.WatchFilesystem(filesystemTag)
+	w.tomb.Go(func() error {
+		defer watcher.Stop(fw, &w.tomb)
+		for {
+			select {
+			case <-w.tomb.Dying():
+				return tomb.ErrDying
+			case _, ok := <-fw.Changes():
+				if !ok {
+					return watcher.EnsureErr(fw)
+				}
+			}
+			filesystem, err := w.backend.Filesystem(filesystemTag)
+			if err == nil {
+				_, err = filesystem.Info()
+			}
+			if errors.IsNotProvisioned(err) {
+				continue
+			} else if err != nil && !errors.IsNotFound(err) {
+				return errors.Annotate(err, "getting filesystem info")
+			}
+			select {
+			case <-w.tomb.Dying():
+				return tomb.ErrDying
+			case w.filesystemProvisioned <- filesystemTag:
+				return nil
+			}
+		}
+	})
+}
+
 func (w *hostFilesystemAttachmentsWatcher) modelVolumeAttachmentChanged(hostTag names.Tag, volumeTag names.VolumeTag) error {
 	va, err := w.backend.VolumeAttachment(hostTag, volumeTag)
 	if err != nil && !errors.IsNotFound(err) {
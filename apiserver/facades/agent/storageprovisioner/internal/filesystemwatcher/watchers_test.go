@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner/internal/filesystemwatcher"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/state/watcher/watchertest"
 )
 
 var _ = gc.Suite(&WatchersSuite{})
@@ -33,6 +34,7 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 		modelFilesystemsW:             newStringsWatcher(),
 		modelFilesystemAttachmentsW:   newStringsWatcher(),
 		modelVolumeAttachmentsW:       newStringsWatcher(),
+		filesystemChanges:             make(chan struct{}, 1),
 		filesystems: map[string]*mockFilesystem{
 			// filesystem 0 has no backing volume.
 			"0": {},
@@ -40,6 +42,10 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 			"1": {volume: names.NewVolumeTag("1")},
 			// filesystem 2 is backed by volume 2.
 			"2": {volume: names.NewVolumeTag("2")},
+			// filesystem 3 is attached by its hosts, and provisioned.
+			"3": {tag: names.NewFilesystemTag("3"), hostAttached: true, provisioned: true},
+			// filesystem 4 is attached by its hosts, and not yet provisioned.
+			"4": {tag: names.NewFilesystemTag("4"), hostAttached: true},
 		},
 		volumeAttachments: map[string]*mockVolumeAttachment{
 			"1": {life: state.Alive},
@@ -47,6 +53,7 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 		},
 		volumeAttachmentRequested: make(chan names.VolumeTag, 10),
 	}
+	s.backend.filesystemW = watchertest.NewNotifyWatcher(s.backend.filesystemChanges)
 	s.AddCleanup(func(*gc.C) {
 		s.backend.machineFilesystemsW.Stop()
 		s.backend.machineFilesystemAttachmentsW.Stop()
@@ -55,6 +62,9 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 		s.backend.modelVolumeAttachmentsW.Stop()
 	})
 	s.watchers.Backend = s.backend
+	s.watchers.HostAttached = func(f state.Filesystem) (bool, error) {
+		return f.(*mockFilesystem).hostAttached, nil
+	}
 }
 
 func (s *WatchersSuite) TestWatchModelManagedFilesystems(c *gc.C) {
@@ -77,9 +87,10 @@ func (s *WatchersSuite) TestWatchModelManagedFilesystemsWatcherErrorsPropagate(c
 func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachments(c *gc.C) {
 	w := s.watchers.WatchModelManagedFilesystemAttachments()
 	defer statetesting.AssertKillAndWait(c, w)
-	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:0", "0:1"}
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:0", "0:1", "0:3"}
 
-	// Filesystem 1 has a backing volume, so should not be reported.
+	// Filesystem 1 has a backing volume, and filesystem 3 is
+	// attached by its hosts, so neither should be reported.
 	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
 	wc.AssertChangeInSingleEvent("0:0")
 	wc.AssertNoChange()
@@ -181,6 +192,30 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachments(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachmentsHostAttached(c *gc.C) {
+	w := s.watchers.WatchMachineManagedFilesystemAttachments(names.NewMachineTag("0"))
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:0", "0:3", "0:4", "1:3"}
+	s.backend.machineFilesystemAttachmentsW.C <- []string{}
+	s.backend.modelVolumeAttachmentsW.C <- []string{}
+
+	// Filesystem 0 is attached by the model, and filesystem 4 is
+	// not yet provisioned, so only filesystem 3 should be reported.
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0:3")
+	wc.AssertNoChange()
+
+	// The initial event from the filesystem watcher reports
+	// that filesystem 4 is still not provisioned.
+	s.backend.filesystemChanges <- struct{}{}
+	wc.AssertNoChange()
+
+	s.backend.filesystems["4"].setProvisioned()
+	s.backend.filesystemChanges <- struct{}{}
+	wc.AssertChangeInSingleEvent("0:4")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachmentsErrorsPropagate(c *gc.C) {
 	w := s.watchers.WatchMachineManagedFilesystemAttachments(names.NewMachineTag("0"))
 	s.backend.modelFilesystemAttachmentsW.T.Kill(errors.New("rah"))
@@ -56,6 +56,24 @@ func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.
 	return NewStorageProvisionerAPIv4(v3), nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv5, error) {
+	v4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv5(v4), nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration.
+func NewFacadeV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv6, error) {
+	v5, err := NewFacadeV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv6(v5), nil
+}
+
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
@@ -84,6 +102,11 @@ type StorageBackend interface {
 	WatchUnitVolumeAttachments(tag names.ApplicationTag) state.StringsWatcher
 	WatchVolumeAttachment(names.Tag, names.VolumeTag) state.NotifyWatcher
 	WatchMachineAttachmentsPlans(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 	AllStorageInstances() ([]state.StorageInstance, error)
@@ -94,6 +117,7 @@ type StorageBackend interface {
 
 	Filesystem(names.FilesystemTag) (state.Filesystem, error)
 	FilesystemAttachment(names.Tag, names.FilesystemTag) (state.FilesystemAttachment, error)
+	FilesystemAttachments(names.FilesystemTag) ([]state.FilesystemAttachment, error)
 
 	Volume(names.VolumeTag) (state.Volume, error)
 	VolumeAttachment(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
@@ -114,6 +138,7 @@ type StorageBackend interface {
 	SetFilesystemAttachmentInfo(names.Tag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.Tag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetFilesystemUsage(names.FilesystemTag, state.FilesystemUsage) error
 
 	CreateVolumeAttachmentPlan(names.Tag, names.VolumeTag, state.VolumeAttachmentPlanInfo) error
 	RemoveVolumeAttachmentPlan(names.Tag, names.VolumeTag, bool) error
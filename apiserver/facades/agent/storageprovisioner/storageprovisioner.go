@@ -25,6 +25,16 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv6 provides the StorageProvisioner API v6 facade.
+type StorageProvisionerAPIv6 struct {
+	*StorageProvisionerAPIv5
+}
+
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -50,6 +60,16 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.Tag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv6 creates a new server-side StorageProvisioner v6 facade.
+func NewStorageProvisionerAPIv6(v5 *StorageProvisionerAPIv5) *StorageProvisionerAPIv6 {
+	return &StorageProvisionerAPIv6{v5}
+}
+
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -137,6 +157,20 @@ func NewStorageProvisionerAPIv3(
 				}
 			} else if err != state.ErrNoBackingVolume {
 				return false
+			} else if hostAttached, err := hostAttachesFilesystem(f, poolManager, registry); err != nil {
+				return false
+			} else if hostAttached {
+				// The filesystem is attached by the machines
+				// it is attached to, which may access it.
+				filesystemAttachments, err := sb.FilesystemAttachments(tag)
+				if err != nil {
+					return false
+				}
+				for _, a := range filesystemAttachments {
+					if canAccessStorageMachine(a.Host(), false) {
+						return true
+					}
+				}
 			}
 			return authorizer.AuthController()
 		case names.MachineTag:
@@ -325,13 +359,65 @@ func (s *StorageProvisionerAPIv3) WatchVolumes(args params.Entities) (params.Str
 // WatchFilesystems watches for changes to filesystems scoped
 // to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPIv3) WatchFilesystems(args params.Entities) (params.StringsWatchResults, error) {
-	w := filesystemwatcher.Watchers{s.sb}
+	w := s.filesystemWatchers()
 	return s.watchStorageEntities(args,
 		w.WatchModelManagedFilesystems,
 		w.WatchMachineManagedFilesystems,
 		w.WatchUnitManagedFilesystems)
 }
 
+func (s *StorageProvisionerAPIv3) filesystemWatchers() filesystemwatcher.Watchers {
+	return filesystemwatcher.Watchers{
+		Backend: s.sb,
+		HostAttached: func(f state.Filesystem) (bool, error) {
+			return hostAttachesFilesystem(f, s.poolManager, s.registry)
+		},
+	}
+}
+
+// hostAttachesFilesystem reports whether the filesystem is attached by
+// the storage provisioners of the machines it is attached to, though
+// it is managed by the model's storage provisioner.
+func hostAttachesFilesystem(
+	f state.Filesystem,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (bool, error) {
+	var pool string
+	if filesystemParams, ok := f.Params(); ok {
+		pool = filesystemParams.Pool
+	} else {
+		filesystemInfo, err := f.Info()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		pool = filesystemInfo.Pool
+	}
+	providerType, _, err := storagecommon.StoragePoolConfig(pool, poolManager, registry)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	provider, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return storage.HostAttachesFilesystems(provider), nil
+}
+
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that pending resizes
+// may be carried out.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeResizes, s.sb.WatchMachineVolumeResizes, nil)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the tag passed to NewState, so that pending resizes
+// may be carried out.
+func (s *StorageProvisionerAPIv5) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelFilesystemResizes, s.sb.WatchMachineFilesystemResizes, nil)
+}
+
 func (s *StorageProvisionerAPIv3) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -357,6 +443,9 @@ func (s *StorageProvisionerAPIv3) watchStorageEntities(
 		case names.ModelTag:
 			w = watchEnvironStorage()
 		case names.ApplicationTag:
+			if watchApplicationStorage == nil {
+				return "", nil, apiservererrors.ServerError(errors.NotSupportedf("watching storage for %v", tag))
+			}
 			w = watchApplicationStorage(tag)
 		default:
 			return "", nil, apiservererrors.ServerError(errors.NotSupportedf("watching storage for %v", tag))
@@ -396,7 +485,7 @@ func (s *StorageProvisionerAPIv3) WatchVolumeAttachments(args params.Entities) (
 // WatchFilesystemAttachments watches for changes to filesystem attachments
 // scoped to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPIv3) WatchFilesystemAttachments(args params.Entities) (params.MachineStorageIdsWatchResults, error) {
-	w := filesystemwatcher.Watchers{s.sb}
+	w := s.filesystemWatchers()
 	return s.watchAttachments(
 		args,
 		w.WatchModelManagedFilesystemAttachments,
@@ -990,6 +1079,123 @@ func (s *StorageProvisionerAPIv4) RemoveFilesystemParams(args params.Entities) (
 	return results, nil
 }
 
+// ResizeVolumeParams returns the parameters for resizing the volumes
+// with the specified tags. An error satisfying params.IsCodeNotFound
+// is returned for volumes that have no pending resize.
+func (s *StorageProvisionerAPIv5) ResizeVolumeParams(args params.Entities) (params.ResizeVolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeVolumeParamsResults{}, err
+	}
+	results := params.ResizeVolumeParamsResults{
+		Results: make([]params.ResizeVolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeVolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeVolumeParams{}, apiservererrors.ErrPerm
+		}
+		volume, err := s.sb.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeVolumeParams{}, apiservererrors.ErrPerm
+		} else if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok || volume.Life() != state.Alive {
+			return params.ResizeVolumeParams{}, errors.NotFoundf(
+				"pending resize for %s", names.ReadableString(tag),
+			)
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		return params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			Provider:  string(provider),
+			VolumeId:  volumeInfo.VolumeId,
+			Size:      size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeVolumeParamsResult
+		volumeParams, err := one(arg)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		} else {
+			result.Result = volumeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// ResizeFilesystemParams returns the parameters for resizing the
+// filesystems with the specified tags. An error satisfying
+// params.IsCodeNotFound is returned for filesystems that have
+// no pending resize.
+func (s *StorageProvisionerAPIv5) ResizeFilesystemParams(args params.Entities) (params.ResizeFilesystemParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeFilesystemParamsResults{}, err
+	}
+	results := params.ResizeFilesystemParamsResults{
+		Results: make([]params.ResizeFilesystemParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeFilesystemParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeFilesystemParams{}, apiservererrors.ErrPerm
+		}
+		filesystem, err := s.sb.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeFilesystemParams{}, apiservererrors.ErrPerm
+		} else if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		size, ok := filesystem.RequestedSize()
+		if !ok || filesystem.Life() != state.Alive {
+			return params.ResizeFilesystemParams{}, errors.NotFoundf(
+				"pending resize for %s", names.ReadableString(tag),
+			)
+		}
+		filesystemInfo, err := filesystem.Info()
+		if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			filesystemInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		return params.ResizeFilesystemParams{
+			FilesystemTag: tag.String(),
+			Provider:      string(provider),
+			FilesystemId:  filesystemInfo.FilesystemId,
+			Size:          size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeFilesystemParamsResult
+		filesystemParams, err := one(arg)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		} else {
+			result.Result = filesystemParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // VolumeAttachmentParams returns the parameters for creating the volume
 // attachments with the specified IDs.
 func (s *StorageProvisionerAPIv3) VolumeAttachmentParams(
@@ -1485,6 +1691,53 @@ func (s *StorageProvisionerAPIv3) SetFilesystemAttachmentInfo(
 	return results, nil
 }
 
+// SetFilesystemUsage records the space and inode usage of filesystems,
+// as observed on the machines that they are attached to.
+func (s *StorageProvisionerAPIv6) SetFilesystemUsage(args params.FilesystemUsageArgs) (params.ErrorResults, error) {
+	canAccess, err := s.getAttachmentAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	one := func(arg params.FilesystemUsageArg) error {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filesystemTag, err := names.ParseFilesystemTag(arg.FilesystemTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !canAccess(machineTag, filesystemTag) {
+			return apiservererrors.ErrPerm
+		}
+		// Only the machine that the filesystem is
+		// attached to may report its usage.
+		if _, err := s.sb.FilesystemAttachment(machineTag, filesystemTag); errors.IsNotFound(err) {
+			return apiservererrors.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		err = s.sb.SetFilesystemUsage(filesystemTag, state.FilesystemUsage{
+			BytesUsed:  arg.Usage.BytesUsed,
+			BytesFree:  arg.Usage.BytesFree,
+			InodesUsed: arg.Usage.InodesUsed,
+			InodesFree: arg.Usage.InodesFree,
+		})
+		if errors.IsNotFound(err) {
+			return apiservererrors.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := one(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
 // AttachmentLife returns the lifecycle state of each specified machine
 // storage attachment.
 func (s *StorageProvisionerAPIv3) AttachmentLife(args params.MachineStorageIds) (params.LifeResults, error) {
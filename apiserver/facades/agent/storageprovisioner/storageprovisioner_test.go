@@ -58,7 +58,7 @@ type provisionerSuite struct {
 
 	resources      *common.Resources
 	authorizer     *apiservertesting.FakeAuthorizer
-	api            *storageprovisioner.StorageProvisionerAPIv4
+	api            *storageprovisioner.StorageProvisionerAPIv6
 	storageBackend storageprovisioner.StorageBackend
 }
 
@@ -89,7 +89,9 @@ func (s *iaasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
@@ -122,7 +124,9 @@ func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -633,6 +637,77 @@ func (s *iaasProvisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{{
+			Result: params.ResizeVolumeParams{
+				VolumeTag: "volume-2",
+				Provider:  "modelscoped",
+				VolumeId:  "def",
+				Size:      8192,
+			},
+		}, {
+			Error: &params.Error{Message: `pending resize for volume 0/0 not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.Model.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	wc0 := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc0.AssertNoChange()
+	wc1 := statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc1.AssertNoChange()
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	wc0.AssertNoChange()
+	wc1.AssertChangeInSingleEvent("2")
+}
+
 func (s *iaasProvisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -989,6 +1064,42 @@ func (s *iaasProvisionerSuite) TestSetFilesystemAttachmentInfo(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestSetFilesystemUsage(c *gc.C) {
+	s.setupFilesystems(c)
+
+	results, err := s.api.SetFilesystemUsage(params.FilesystemUsageArgs{
+		Args: []params.FilesystemUsageArg{{
+			MachineTag:    "machine-0",
+			FilesystemTag: "filesystem-0-0",
+			Usage: params.FilesystemUsage{
+				BytesUsed:  768,
+				BytesFree:  256,
+				InodesUsed: 10,
+				InodesFree: 90,
+			},
+		}, {
+			MachineTag:    "machine-0",
+			FilesystemTag: "filesystem-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err := sb.FilesystemUsage(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.BytesUsed, gc.Equals, uint64(768))
+	c.Assert(usage.BytesFree, gc.Equals, uint64(256))
+	c.Assert(usage.InodesUsed, gc.Equals, uint64(10))
+	c.Assert(usage.InodesFree, gc.Equals, uint64(90))
+}
+
 func (s *caasProvisionerSuite) TestWatchApplications(c *gc.C) {
 	ch := s.Factory.MakeCharm(c, &factory.CharmParams{
 		Name:   "storage-filesystem",
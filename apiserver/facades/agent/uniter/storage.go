@@ -207,6 +207,7 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		params.StorageKind(stateStorageInstance.Kind()),
 		info.Location,
 		life.Value(stateStorageAttachment.Life().String()),
+		info.Size,
 	}, nil
 }
 
@@ -473,11 +474,13 @@ func watchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage volume")
 		}
-		// We need to watch both the volume attachment, and the
-		// machine's block devices. A volume attachment's block
-		// device could change (most likely, become present).
+		// We need to watch the volume attachment, the volume (for
+		// changes in size), and the machine's block devices. A volume
+		// attachment's block device could change (most likely, become
+		// present).
 		watchers = []state.NotifyWatcher{
 			stVolume.WatchVolumeAttachment(hostTag, volume.VolumeTag()),
+			stVolume.WatchVolume(volume.VolumeTag()),
 		}
 
 		// TODO(caas) - we currently only support block devices on machines.
@@ -501,6 +504,7 @@ func watchStorageAttachment(
 		}
 		watchers = []state.NotifyWatcher{
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
+			stFile.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
@@ -81,6 +81,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	volumeWatcher.changes <- struct{}{}
+	volumeSizeWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	volumeSizeWatcher.changes <- struct{}{}
 	blockDevicesWatcher := &mockNotifyWatcher{
 		changes: make(chan struct{}, 1),
 	}
@@ -110,6 +114,11 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(v, gc.DeepEquals, volumeTag)
 			return volumeWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeSizeWatcher
+		},
 		watchBlockDevices: func(m names.MachineTag) state.NotifyWatcher {
 			calls = append(calls, "WatchBlockDevices")
 			c.Assert(m, gc.DeepEquals, machineTag)
@@ -135,6 +144,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		"StorageInstance",
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
+		"WatchVolume",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
 	})
@@ -173,6 +183,10 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		changes: make(chan struct{}, 1),
 	}
 	filesystemWatcher.changes <- struct{}{}
+	filesystemSizeWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemSizeWatcher.changes <- struct{}{}
 	var calls []string
 	st := &mockStorageState{
 		assignedMachine: assignedMachine,
@@ -198,6 +212,11 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 			c.Assert(f, gc.DeepEquals, filesystemTag)
 			return filesystemWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemSizeWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(st, st, resources, getCanAccess)
@@ -218,6 +237,7 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"WatchFilesystem",
 		"WatchStorageAttachment",
 	})
 }
@@ -490,6 +510,8 @@ type mockStorageState struct {
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.Tag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.Tag, names.VolumeTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorageOperation       func(u names.UnitTag, name string, cons state.StorageConstraints) error
 }
@@ -544,6 +566,14 @@ func (m *mockStorageState) WatchVolumeAttachment(hostTag names.Tag, v names.Volu
 	return m.watchVolumeAttachment(hostTag, v)
 }
 
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
 func (m *mockStorageState) WatchBlockDevices(mtag names.MachineTag) state.NotifyWatcher {
 	return m.watchBlockDevices(mtag)
 }
@@ -626,6 +656,7 @@ type watchStorageAttachmentSuite struct {
 	storageInstance          *fakeStorageInstance
 	volume                   *fakeVolume
 	volumeAttachmentWatcher  *apiservertesting.FakeNotifyWatcher
+	volumeWatcher            *apiservertesting.FakeNotifyWatcher
 	blockDevicesWatcher      *apiservertesting.FakeNotifyWatcher
 	storageAttachmentWatcher *apiservertesting.FakeNotifyWatcher
 }
@@ -643,6 +674,7 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	}
 	s.volume = &fakeVolume{tag: names.NewVolumeTag("0")}
 	s.volumeAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
+	s.volumeWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.blockDevicesWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.storageAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.st = &fakeStorage{
@@ -655,6 +687,9 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		watchVolumeAttachment: func(names.Tag, names.VolumeTag) state.NotifyWatcher {
 			return s.volumeAttachmentWatcher
 		},
+		watchVolume: func(names.VolumeTag) state.NotifyWatcher {
+			return s.volumeWatcher
+		},
 		watchBlockDevices: func(names.MachineTag) state.NotifyWatcher {
 			return s.blockDevicesWatcher
 		},
@@ -670,6 +705,12 @@ func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeAttachment
 	})
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeChanges(c *gc.C) {
+	s.testWatchBlockStorageAttachment(c, func() {
+		s.volumeWatcher.C <- struct{}{}
+	})
+}
+
 func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentStorageAttachmentChanges(c *gc.C) {
 	s.testWatchBlockStorageAttachment(c, func() {
 		s.storageAttachmentWatcher.C <- struct{}{}
@@ -688,6 +729,7 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 		"StorageInstance",
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
+		"WatchVolume",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
 	)
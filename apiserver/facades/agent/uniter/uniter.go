@@ -42,9 +42,8 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 // TODO (manadart 2020-10-21): Remove the ModelUUID method
 // from the next version of this facade.
 
-// UniterAPI implements the latest version (v17) of the Uniter API, which
-// augments the payload of the CommitHookChanges API call and introduces
-// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+// UniterAPI implements the latest version (v18) of the Uniter API,
+// which adds LogActionsOutput.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -83,10 +82,17 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV17 implements version (v17) of the Uniter API, which
+// augments the payload of the CommitHookChanges API call and introduces
+// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+type UniterAPIV17 struct {
+	UniterAPI
+}
+
 // UniterAPIV16 implements version (v16) of the Uniter API, which adds
 // LXDProfileAPIV2.
 type UniterAPIV16 struct {
-	UniterAPI
+	UniterAPIV17
 }
 
 // UniterAPIV15 implements version (v15) of the Uniter API, which adds
@@ -262,14 +268,25 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV16 creates an instance of the V16 uniter API.
 func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV17(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV16{
-		UniterAPI: *uniterAPI,
+		UniterAPIV17: *uniterAPI,
 	}, nil
 }
 
@@ -1441,6 +1458,39 @@ func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.
 	return result, nil
 }
 
+// LogActionsOutput records the output written by the specified
+// actions to stdout or stderr.
+func (u *UniterAPI) LogActionsOutput(args params.ActionOutputParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+
+	oneActionOutput := func(output params.ActionOutput) error {
+		action, err := actionFn(output.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return action.LogOutput(output.Stream, output.Output)
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Output)),
+	}
+	for i, output := range args.Output {
+		result.Results[i].Error = apiservererrors.ServerError(oneActionOutput(output))
+	}
+	return result, nil
+}
+
+// LogActionsOutput isn't on the v17 API.
+func (u *UniterAPIV17) LogActionsOutput(_, _ struct{}) {}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -3790,6 +3840,9 @@ func (u *UniterAPI) commitHookChangesForOneUnit(unitTag names.UnitTag, changes p
 // WatchInstanceData isn't on the v15 API.
 func (u *UniterAPIV15) WatchInstanceData(_ struct{}) {}
 
+// LogActionsOutput isn't on the v15 API.
+func (u *UniterAPIV15) LogActionsOutput(_, _ struct{}) {}
+
 // WatchInstanceData is a shim to call the LXDProfileAPIv2 version of this method.
 func (u *UniterAPI) WatchInstanceData(args params.Entities) (params.NotifyWatchResults, error) {
 	return u.lxdProfileAPI.WatchInstanceData(args)
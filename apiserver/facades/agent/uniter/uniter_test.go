@@ -1191,6 +1191,42 @@ func (s *uniterSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Timestamp(), gc.NotNil)
 }
 
+func (s *uniterSuite) TestLogActionOutput(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	wrongAction, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionOutputParams{Output: []params.ActionOutput{
+		{Tag: anAction.Tag().String(), Stream: "stdout", Output: "hello\n"},
+		{Tag: anAction.Tag().String(), Stream: "stdin", Output: "hello\n"},
+		{Tag: wrongAction.Tag().String(), Stream: "stdout", Output: "world\n"},
+		{Tag: "foo-42", Stream: "stdout", Output: "mars\n"},
+	}}
+	result, err := s.uniter.LogActionsOutput(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservererrors.ServerError(errors.NotValidf(`output stream "stdin"`))},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `"foo-42" is not a valid tag`}},
+		},
+	})
+	anAction, err = s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	output := anAction.Output()
+	c.Assert(output, gc.HasLen, 1)
+	c.Assert(output[0].Stream(), gc.Equals, "stdout")
+	c.Assert(output[0].Message(), gc.Equals, "hello\n")
+	c.Assert(anAction.Messages(), gc.HasLen, 0)
+}
+
 func (s *uniterSuite) TestLogActionMessageAborting(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
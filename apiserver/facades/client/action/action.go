@@ -27,18 +27,32 @@ type ActionAPI struct {
 
 // APIv7 provides the Action API facade for version 7.
 type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Action API facade for version 8.
+type APIv8 struct {
 	*ActionAPI
 }
 
 // NewActionAPIV7 returns an initialized ActionAPI for version 7.
 func NewActionAPIV7(ctx facade.Context) (*APIv7, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV8(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv7{api}, nil
 }
 
+// NewActionAPIV8 returns an initialized ActionAPI for version 8.
+func NewActionAPIV8(ctx facade.Context) (*APIv8, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
@@ -243,3 +257,41 @@ func (api *ActionAPI) WatchActionsProgress(actions params.Entities) (params.Stri
 	}
 	return results, nil
 }
+
+// WatchTaskEvents creates a watcher that reports the events in the
+// life of each task: changes of its status, and the messages and
+// output it logs. The changes are json encoded core/actions.TaskEvents.
+func (api *ActionAPI) WatchTaskEvents(tasks params.Entities) (params.StringsWatchResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(tasks.Entities)),
+	}
+	for i, arg := range tasks.Entities {
+		actionTag, err := names.ParseActionTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if _, err := api.model.ActionByTag(actionTag); err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+
+		w := api.state.WatchTaskEvents(actionTag.Id())
+		// Consume the initial event.
+		changes, ok := <-w.Changes()
+		if !ok {
+			results.Results[i].Error = apiservererrors.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+
+		results.Results[i].Changes = changes
+		results.Results[i].StringsWatcherId = api.resources.Register(w)
+	}
+	return results, nil
+}
+
+// WatchTaskEvents isn't on the v7 API.
+func (*APIv7) WatchTaskEvents(_, _ struct{}) {}
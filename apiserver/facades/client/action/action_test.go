@@ -591,3 +591,64 @@ func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
 	wc.AssertChange(string(expected))
 	wc.AssertNoChange()
 }
+
+func (s *actionSuite) TestWatchTaskEvents(c *gc.C) {
+	unit, err := s.State.Unit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertReadyToTest(c, unit)
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	added, err := unit.AddAction(operationID, "fakeaction", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.action.WatchTaskEvents(params.Entities{Entities: []params.Entity{
+		{Tag: "action-2"},
+		{Tag: "action-666"},
+		{Tag: "foo-42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Results, gc.HasLen, 3)
+	c.Assert(w.Results[0].Error, gc.IsNil)
+	c.Assert(w.Results[0].Changes, gc.HasLen, 1)
+	c.Assert(w.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(w.Results[2].Error, gc.ErrorMatches, `"foo-42" is not a valid action tag`)
+
+	var event actions.TaskEvent
+	err = json.Unmarshal([]byte(w.Results[0].Changes[0]), &event)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(event.Status, gc.Equals, "pending")
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	// Run the task and check its output is reported.
+	added, err = added.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = added.LogOutput("stdout", "hello\n")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.Model.Action("2")
+	c.Assert(err, jc.ErrorIsNil)
+	output := a.Output()
+	c.Assert(output, gc.HasLen, 1)
+	expectedStatus, err := json.Marshal(actions.TaskEvent{
+		Timestamp: a.Started().UTC(),
+		Status:    "running",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput, err := json.Marshal(actions.TaskEvent{
+		Timestamp: output[0].Timestamp(),
+		Message:   "hello\n",
+		Stream:    "stdout",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(string(expectedStatus), string(expectedOutput))
+	wc.AssertNoChange()
+}
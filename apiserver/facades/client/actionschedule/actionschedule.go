@@ -0,0 +1,203 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionschedule provides the facade used by clients to
+// manage the schedules on which actions are run in a model.
+package actionschedule
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// API is the backend for the ActionSchedule facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// BlockChecker checks whether changes to the model are blocked.
+type BlockChecker interface {
+	ChangeAllowed() error
+	RemoveAllowed() error
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(stateShim{st: st, model: model}, common.NewBlockChecker(st), ctx.Auth())
+}
+
+// NewAPI returns a new action schedule API facade.
+func NewAPI(backend Backend, check BlockChecker, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      check,
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// AddSchedules adds schedules on which actions are run. The action
+// must be defined by the target application's charm, and the
+// parameters must satisfy the action's schema.
+func (api *API) AddSchedules(args params.AddActionSchedulesArgs) (params.ErrorResults, error) {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	owner := api.authorizer.GetAuthTag().Id()
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		err := api.addSchedule(state.AddActionScheduleArgs{
+			Name:       arg.Name,
+			Target:     arg.Target,
+			Action:     arg.Action,
+			Parameters: arg.Parameters,
+			Cron:       arg.Cron,
+			Owner:      owner,
+		})
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) addSchedule(args state.AddActionScheduleArgs) error {
+	if err := args.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	appName := state.ActionScheduleTargetApplication(args.Target)
+	specs, err := api.backend.ApplicationActionSpecs(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if names.IsValidUnit(args.Target) {
+		if err := api.backend.CheckUnitExists(args.Target); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	spec, ok := actions.PredefinedActionsSpec[args.Action]
+	if !ok {
+		if spec, ok = specs[args.Action]; !ok {
+			return errors.NotFoundf("action %q for application %q", args.Action, appName)
+		}
+	}
+	if err := spec.ValidateParams(args.Parameters); err != nil {
+		return errors.Annotatef(err, "action %q", args.Action)
+	}
+	return errors.Trace(api.backend.AddActionSchedule(args))
+}
+
+// ListSchedules returns all the action schedules in the model.
+func (api *API) ListSchedules() (params.ActionSchedulesResult, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.ActionSchedulesResult{}, errors.Trace(err)
+	}
+	schedules, err := api.backend.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedulesResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	result := params.ActionSchedulesResult{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = common.ActionScheduleToParams(schedule)
+	}
+	return result, nil
+}
+
+// Schedules returns the named action schedules, along
+// with the history of their recent runs.
+func (api *API) Schedules(args params.ActionScheduleNames) (params.ActionScheduleResults, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		schedule, err := api.backend.ActionSchedule(name)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result := common.ActionScheduleToParams(schedule)
+		results.Results[i].Schedule = &result
+	}
+	return results, nil
+}
+
+// Pause stops the named action schedules from running
+// until they're resumed.
+func (api *API) Pause(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return api.update(args, api.check.ChangeAllowed, func(s ActionSchedule) error {
+		return s.SetPaused(true)
+	})
+}
+
+// Resume resumes runs of the named action schedules.
+func (api *API) Resume(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return api.update(args, api.check.ChangeAllowed, func(s ActionSchedule) error {
+		return s.SetPaused(false)
+	})
+}
+
+// Remove removes the named action schedules. Operations already
+// enqueued by the schedules are not affected.
+func (api *API) Remove(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	return api.update(args, api.check.RemoveAllowed, ActionSchedule.Remove)
+}
+
+func (api *API) update(
+	args params.ActionScheduleNames,
+	allowed func() error,
+	update func(ActionSchedule) error,
+) (params.ErrorResults, error) {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := allowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		schedule, err := api.backend.ActionSchedule(name)
+		if err == nil {
+			err = update(schedule)
+		}
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
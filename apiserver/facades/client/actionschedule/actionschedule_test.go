@@ -0,0 +1,360 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedule_test
+
+import (
+	"time"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/actionschedule"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionScheduleSuite struct {
+	coretesting.BaseSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	blocks     *mockBlockChecker
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{
+		specs: map[string]charm.ActionSpec{
+			"backup": {
+				Params: map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"outfile": map[string]interface{}{"type": "string"},
+					},
+					"additionalProperties": false,
+				},
+			},
+		},
+		schedules: make(map[string]*mockSchedule),
+	}
+	s.blocks = &mockBlockChecker{}
+}
+
+func (s *ActionScheduleSuite) newAPI(c *gc.C) *actionschedule.API {
+	api, err := actionschedule.NewAPI(s.backend, s.blocks, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *ActionScheduleSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := actionschedule.NewAPI(s.backend, s.blocks, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ActionScheduleSuite) TestAddSchedules(c *gc.C) {
+	api := s.newAPI(c)
+
+	results, err := api.AddSchedules(params.AddActionSchedulesArgs{
+		Schedules: []params.ActionSchedule{{
+			Name:       "nightly-backup",
+			Target:     "mysql/leader",
+			Action:     "backup",
+			Parameters: map[string]interface{}{"outfile": "out.tar.gz"},
+			Cron:       "0 3 * * *",
+		}, {
+			Name:   "unit-backup",
+			Target: "mysql/1",
+			Action: "backup",
+			Cron:   "@daily",
+		}, {
+			Name:   "bad-action",
+			Target: "mysql",
+			Action: "explode",
+			Cron:   "@daily",
+		}, {
+			Name:       "bad-params",
+			Target:     "mysql",
+			Action:     "backup",
+			Parameters: map[string]interface{}{"compression": "gzip"},
+			Cron:       "@daily",
+		}, {
+			Name:   "bad-cron",
+			Target: "mysql",
+			Action: "backup",
+			Cron:   "every day",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `action "explode" for application "mysql" not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `action "backup": .*additional property "compression" is not allowed.*`)
+	c.Check(results.Results[4].Error, gc.ErrorMatches, `cron expression "every day": expected 5 fields, got 2 not valid`)
+
+	s.backend.CheckCall(c, 0, "ModelTag")
+	c.Check(s.backend.added, jc.DeepEquals, []state.AddActionScheduleArgs{{
+		Name:       "nightly-backup",
+		Target:     "mysql/leader",
+		Action:     "backup",
+		Parameters: map[string]interface{}{"outfile": "out.tar.gz"},
+		Cron:       "0 3 * * *",
+		Owner:      "admin",
+	}, {
+		Name:   "unit-backup",
+		Target: "mysql/1",
+		Action: "backup",
+		Cron:   "@daily",
+		Owner:  "admin",
+	}})
+}
+
+func (s *ActionScheduleSuite) TestAddSchedulesUnknownUnit(c *gc.C) {
+	api := s.newAPI(c)
+	s.backend.SetErrors(nil, nil, errors.NotFoundf(`unit "mysql/5"`))
+
+	results, err := api.AddSchedules(params.AddActionSchedulesArgs{
+		Schedules: []params.ActionSchedule{{
+			Name:   "unit-backup",
+			Target: "mysql/5",
+			Action: "backup",
+			Cron:   "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `unit "mysql/5" not found`)
+	c.Check(s.backend.added, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestAddSchedulesRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	api := s.newAPI(c)
+
+	_, err := api.AddSchedules(params.AddActionSchedulesArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ActionScheduleSuite) TestAddSchedulesBlocked(c *gc.C) {
+	api := s.newAPI(c)
+	s.blocks.SetErrors(errors.New("change blocked"))
+
+	_, err := api.AddSchedules(params.AddActionSchedulesArgs{})
+	c.Assert(err, gc.ErrorMatches, "change blocked")
+}
+
+func (s *ActionScheduleSuite) TestListSchedules(c *gc.C) {
+	created := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.schedules["nightly-backup"] = &mockSchedule{
+		name:    "nightly-backup",
+		target:  "mysql/leader",
+		action:  "backup",
+		cron:    "0 3 * * *",
+		owner:   "admin",
+		created: created,
+		runs: []state.ActionScheduleRun{{
+			Scheduled:   created.Add(17 * time.Hour),
+			Enqueued:    created.Add(17*time.Hour + time.Second),
+			OperationID: "7",
+		}, {
+			Scheduled: created.Add(41 * time.Hour),
+			Enqueued:  created.Add(41 * time.Hour),
+			Error:     "application \"mysql\" has no units",
+		}},
+	}
+	api := s.newAPI(c)
+
+	result, err := api.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedulesResult{
+		Schedules: []params.ActionSchedule{{
+			Name:    "nightly-backup",
+			Target:  "mysql/leader",
+			Action:  "backup",
+			Cron:    "0 3 * * *",
+			Owner:   "admin",
+			Created: created,
+			Runs: []params.ActionScheduleRun{{
+				Scheduled:    created.Add(17 * time.Hour),
+				Enqueued:     created.Add(17*time.Hour + time.Second),
+				OperationTag: "operation-7",
+			}, {
+				Scheduled: created.Add(41 * time.Hour),
+				Enqueued:  created.Add(41 * time.Hour),
+				Error:     "application \"mysql\" has no units",
+			}},
+		}},
+	})
+}
+
+func (s *ActionScheduleSuite) TestSchedules(c *gc.C) {
+	s.backend.schedules["nightly-backup"] = &mockSchedule{
+		name:   "nightly-backup",
+		target: "mysql",
+		action: "backup",
+		cron:   "@daily",
+		paused: true,
+	}
+	api := s.newAPI(c)
+
+	results, err := api.Schedules(params.ActionScheduleNames{
+		Names: []string{"nightly-backup", "weekly"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Schedule, jc.DeepEquals, &params.ActionSchedule{
+		Name:   "nightly-backup",
+		Target: "mysql",
+		Action: "backup",
+		Cron:   "@daily",
+		Paused: true,
+	})
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ActionScheduleSuite) TestPauseResume(c *gc.C) {
+	schedule := &mockSchedule{name: "nightly-backup"}
+	s.backend.schedules["nightly-backup"] = schedule
+	api := s.newAPI(c)
+
+	results, err := api.Pause(params.ActionScheduleNames{
+		Names: []string{"nightly-backup", "weekly"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(schedule.paused, jc.IsTrue)
+
+	results, err = api.Resume(params.ActionScheduleNames{
+		Names: []string{"nightly-backup"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(schedule.paused, jc.IsFalse)
+}
+
+func (s *ActionScheduleSuite) TestRemove(c *gc.C) {
+	schedule := &mockSchedule{name: "nightly-backup"}
+	s.backend.schedules["nightly-backup"] = schedule
+	api := s.newAPI(c)
+
+	results, err := api.Remove(params.ActionScheduleNames{
+		Names: []string{"nightly-backup"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(schedule.removed, jc.IsTrue)
+	s.blocks.CheckCallNames(c, "RemoveAllowed")
+}
+
+func (s *ActionScheduleSuite) TestRemoveBlocked(c *gc.C) {
+	api := s.newAPI(c)
+	s.blocks.SetErrors(errors.New("remove blocked"))
+
+	_, err := api.Remove(params.ActionScheduleNames{Names: []string{"nightly-backup"}})
+	c.Assert(err, gc.ErrorMatches, "remove blocked")
+}
+
+type mockBackend struct {
+	testing.Stub
+	specs     map[string]charm.ActionSpec
+	schedules map[string]*mockSchedule
+	added     []state.AddActionScheduleArgs
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) AddActionSchedule(args state.AddActionScheduleArgs) error {
+	m.MethodCall(m, "AddActionSchedule", args)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.added = append(m.added, args)
+	return nil
+}
+
+func (m *mockBackend) ActionSchedule(name string) (actionschedule.ActionSchedule, error) {
+	m.MethodCall(m, "ActionSchedule", name)
+	schedule, ok := m.schedules[name]
+	if !ok {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	}
+	return schedule, nil
+}
+
+func (m *mockBackend) AllActionSchedules() ([]actionschedule.ActionSchedule, error) {
+	m.MethodCall(m, "AllActionSchedules")
+	var result []actionschedule.ActionSchedule
+	for _, schedule := range m.schedules {
+		result = append(result, schedule)
+	}
+	return result, m.NextErr()
+}
+
+func (m *mockBackend) ApplicationActionSpecs(name string) (map[string]charm.ActionSpec, error) {
+	m.MethodCall(m, "ApplicationActionSpecs", name)
+	return m.specs, m.NextErr()
+}
+
+func (m *mockBackend) CheckUnitExists(name string) error {
+	m.MethodCall(m, "CheckUnitExists", name)
+	return m.NextErr()
+}
+
+type mockSchedule struct {
+	name, target, action, cron, owner string
+	parameters                        map[string]interface{}
+	paused, removed                   bool
+	created                           time.Time
+	runs                              []state.ActionScheduleRun
+}
+
+func (m *mockSchedule) Name() string                       { return m.name }
+func (m *mockSchedule) Target() string                     { return m.target }
+func (m *mockSchedule) Action() string                     { return m.action }
+func (m *mockSchedule) Parameters() map[string]interface{} { return m.parameters }
+func (m *mockSchedule) Cron() string                       { return m.cron }
+func (m *mockSchedule) Paused() bool                       { return m.paused }
+func (m *mockSchedule) Owner() string                      { return m.owner }
+func (m *mockSchedule) Created() time.Time                 { return m.created }
+func (m *mockSchedule) Runs() []state.ActionScheduleRun    { return m.runs }
+
+func (m *mockSchedule) SetPaused(paused bool) error {
+	m.paused = paused
+	return nil
+}
+
+func (m *mockSchedule) Remove() error {
+	m.removed = true
+	return nil
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (m *mockBlockChecker) ChangeAllowed() error {
+	m.MethodCall(m, "ChangeAllowed")
+	return m.NextErr()
+}
+
+func (m *mockBlockChecker) RemoveAllowed() error {
+	m.MethodCall(m, "RemoveAllowed")
+	return m.NextErr()
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedule
+
+import (
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// action schedule facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AddActionSchedule(state.AddActionScheduleArgs) error
+	ActionSchedule(name string) (ActionSchedule, error)
+	AllActionSchedules() ([]ActionSchedule, error)
+
+	// ApplicationActionSpecs returns the actions defined
+	// by the named application's charm.
+	ApplicationActionSpecs(name string) (map[string]charm.ActionSpec, error)
+
+	// CheckUnitExists returns an error satisfying errors.IsNotFound
+	// if the named unit doesn't exist.
+	CheckUnitExists(name string) error
+}
+
+// ActionSchedule describes a schedule on which an
+// action is run, and allows it to be updated.
+type ActionSchedule interface {
+	common.ActionSchedule
+	SetPaused(bool) error
+	Remove() error
+}
+
+type stateShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return s.model.ModelTag()
+}
+
+func (s stateShim) AddActionSchedule(args state.AddActionScheduleArgs) error {
+	_, err := s.model.AddActionSchedule(args)
+	return err
+}
+
+func (s stateShim) ActionSchedule(name string) (ActionSchedule, error) {
+	return s.model.ActionSchedule(name)
+}
+
+func (s stateShim) AllActionSchedules() ([]ActionSchedule, error) {
+	schedules, err := s.model.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = schedule
+	}
+	return result, nil
+}
+
+func (s stateShim) ApplicationActionSpecs(name string) (map[string]charm.ActionSpec, error) {
+	app, err := s.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ch.Actions() == nil {
+		return nil, nil
+	}
+	return ch.Actions().ActionSpecs, nil
+}
+
+func (s stateShim) CheckUnitExists(name string) error {
+	_, err := s.st.Unit(name)
+	return errors.Trace(err)
+}
@@ -111,6 +111,12 @@ type APIv12 struct {
 // It adds CharmOrigin. The ApplicationsInfo call populates the exposed
 // endpoints field in its response entries.
 type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds the UnitsEvents method.
+type APIv14 struct {
 	*APIBase
 }
 
@@ -226,16 +232,25 @@ func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
 }
 
 func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV14(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv13{api}, nil
 }
 
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
+	UnitEvents(providerId string) ([]caas.UnitEvent, error)
 }
 
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
@@ -1031,6 +1046,31 @@ func (api *APIv12) Update(args params.ApplicationUpdate) error {
 	return nil
 }
 
+// validateAutoscalingConfig checks that the autoscaling policy resulting
+// from applying the config changes to the application is valid.
+func validateAutoscalingConfig(app Application, changes map[string]interface{}) error {
+	var changed bool
+	for k := range changes {
+		changed = changed || k8s.IsAutoscalingConfigKey(k)
+	}
+	if !changed {
+		return nil
+	}
+	current, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	merged := make(application.ConfigAttributes)
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		merged[k] = v
+	}
+	_, err = k8s.AutoscalingPolicy(merged)
+	return errors.Trace(err)
+}
+
 func (api *APIBase) setConfig(app Application, generation, settingsYAML string, settingsStrings map[string]string) error {
 	// We need a guard on the API server-side for direct API callers such as
 	// python-libjuju, and for older clients.
@@ -1058,6 +1098,11 @@ func (api *APIBase) setConfig(app Application, generation, settingsYAML string,
 		configChanged = true
 	}
 	if cfgAttrs := appConfig.Attributes(); len(cfgAttrs) > 0 {
+		if api.modelType == state.ModelTypeCAAS {
+			if err := validateAutoscalingConfig(app, cfgAttrs); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		if err = app.UpdateApplicationConfig(cfgAttrs, nil, appConfigSchema, nil); err != nil {
 			return errors.Annotate(err, "updating application config settings")
 		}
@@ -2088,6 +2133,18 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 				return nil, errors.NotSupportedf("scale a %q application", charm.DeploymentDaemon)
 			}
 		}
+		appConfig, err := app.ApplicationConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		policy, err := k8s.AutoscalingPolicy(appConfig)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if policy != nil {
+			return nil, errors.NotSupportedf(
+				"scaling autoscaled application %q (between %d and %d units) manually", name, policy.MinReplicas, policy.MaxReplicas)
+		}
 
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
@@ -3073,6 +3130,64 @@ func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error
 	return params.UnitInfoResults{out}, nil
 }
 
+// UnitsEvents isn't on the v13 API.
+func (u *APIv13) UnitsEvents(_, _ struct{}) {}
+
+// UnitsEvents returns the most recent events reported by the cloud
+// for the workloads of the specified units.
+func (api *APIBase) UnitsEvents(in params.Entities) (params.UnitEventsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitEventsResults{}, errors.Trace(err)
+	}
+	if api.modelType != state.ModelTypeCAAS || api.caasBroker == nil {
+		return params.UnitEventsResults{}, errors.NotSupportedf("unit events on non-container models")
+	}
+	out := make([]params.UnitEventsResult, len(in.Entities))
+	for i, one := range in.Entities {
+		events, err := api.unitEvents(one.Tag)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		out[i].Events = events
+	}
+	return params.UnitEventsResults{Results: out}, nil
+}
+
+func (api *APIBase) unitEvents(unitTag string) ([]params.UnitEvent, error) {
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	container, err := unit.ContainerInfo()
+	if errors.IsNotFound(err) {
+		// The unit's workload hasn't been provisioned yet.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	events, err := api.caasBroker.UnitEvents(container.ProviderId())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.UnitEvent, len(events))
+	for i, event := range events {
+		result[i] = params.UnitEvent{
+			Type:    event.Type,
+			Reason:  event.Reason,
+			Message: event.Message,
+			Count:   event.Count,
+			Time:    event.Time,
+		}
+	}
+	return result, nil
+}
+
 // openPortsOnMachineForUnit returns the unique set of opened ports for the
 // specified unit and machine arguments without distinguishing between port
 // ranges across subnets. This method is provided for backwards compatibility
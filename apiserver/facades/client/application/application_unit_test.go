@@ -62,7 +62,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv13
+	api          *application.APIv14
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -93,7 +93,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv13{api}
+	s.api = &application.APIv14{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -385,7 +385,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettings(c *gc.C) {
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	err := api.Update(args)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -463,7 +463,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettingsInIAASModelTriggersE
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	err := api.Update(args)
 	c.Assert(err, gc.ErrorMatches, `.*unknown option "juju-external-hostname"`, gc.Commentf("expected to get an error when attempting to set CAAS-specific app setting in IAAS model"))
 }
@@ -1224,7 +1224,7 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "Scale", 5)
+	app.CheckCall(c, 2, "Scale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForOperator(c *gc.C) {
@@ -1275,6 +1275,25 @@ func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForDaemonSet(c *gc.C)
 	c.Assert(msg, gc.Matches, `scale a "daemon" application not supported`)
 }
 
+func (s *ApplicationSuite) TestScaleApplicationsAutoscaled(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-min-units": 2,
+		"kubernetes-autoscaling-max-units": 5,
+	}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`scaling autoscaled application "postgresql" \(between 2 and 5 units\) manually not supported`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "ApplicationConfig")
+}
+
 func (s *ApplicationSuite) TestScaleApplicationsBlocked(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	s.blockChecker.SetErrors(apiservererrors.ServerError(apiservererrors.OperationBlockedError("test block")))
@@ -1303,7 +1322,7 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "ChangeScale", 5)
+	app.CheckCall(c, 2, "ChangeScale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleArgCheck(c *gc.C) {
@@ -1850,7 +1869,7 @@ func (s *ApplicationSuite) TestSetApplicationConfigEmptyUsesMaster(c *gc.C) {
 
 func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1884,9 +1903,48 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	c.Check(s.backend.generation, gc.IsNil)
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigAutoscaling(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-units": 5,
+	}
+	api := &application.APIv12{&application.APIv13{s.api}}
+	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-autoscaling-min-units": "2",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app.CheckCallNames(c, "Charm", "Name", "ApplicationConfig", "UpdateApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigAutoscalingInvalid(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-units": 5,
+	}
+	api := &application.APIv12{&application.APIv13{s.api}}
+	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-autoscaling-min-units": "6",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches,
+		"kubernetes-autoscaling-min-units 6 must be between 0 and kubernetes-autoscaling-max-units 5")
+	app.CheckCallNames(c, "Charm", "Name", "ApplicationConfig")
+}
+
 func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1955,7 +2013,7 @@ func (s *ApplicationSuite) TestSetConfigBranch(c *gc.C) {
 
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
 	c.Assert(err, gc.ErrorMatches, "blocked")
 	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
@@ -1964,7 +2022,7 @@ func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 
 func (s *ApplicationSuite) TestSetApplicationConfigPermissionDenied(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("fred"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -2260,3 +2318,31 @@ func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
 		Message: `unit "mysql/0" not found`,
 	})
 }
+
+func (s *ApplicationSuite) TestUnitsEvents(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {"unit-mysql-0"}}
+	result, err := s.api.UnitsEvents(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Events, jc.DeepEquals, []params.UnitEvent{{
+		Type:    "Warning",
+		Reason:  "Failed",
+		Message: `Failed to pull image "mysql:bad"`,
+		Count:   3,
+		Time:    time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+	}})
+	c.Assert(result.Results[1].Error, jc.DeepEquals, &params.Error{
+		Code:    "not found",
+		Message: `unit "mysql/0" not found`,
+	})
+	s.caasBroker.CheckCall(c, 0, "UnitEvents", "provider-id")
+}
+
+func (s *ApplicationSuite) TestUnitsEventsIAAS(c *gc.C) {
+	_, err := s.api.UnitsEvents(params.Entities{[]params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
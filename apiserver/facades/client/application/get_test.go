@@ -63,7 +63,7 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv13{api}
+	s.applicationAPI = &application.APIv13{&application.APIv14{api}}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
@@ -261,7 +261,9 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 				&application.APIv11{
 					&application.APIv12{
 						&application.APIv13{
-							api,
+							&application.APIv14{
+								api,
+							},
 						},
 					},
 				},
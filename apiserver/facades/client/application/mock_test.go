@@ -1172,6 +1172,20 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 	return &ver, nil
 }
 
+func (m *mockCaasBroker) UnitEvents(providerId string) ([]caas.UnitEvent, error) {
+	m.MethodCall(m, "UnitEvents", providerId)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return []caas.UnitEvent{{
+		Type:    "Warning",
+		Reason:  "Failed",
+		Message: `Failed to pull image "mysql:bad"`,
+		Count:   3,
+		Time:    time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+	}}, nil
+}
+
 type mockGeneration struct {
 	jtesting.Stub
 }
@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides the facade used by clients
+// to query the controller's audit log.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// audit log facade.
+type Backend interface {
+	QueryAuditLog(state.AuditLogFilter) ([]state.AuditLogEntry, error)
+}
+
+// API is the backend for the AuditLog facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(st, st.ControllerTag(), ctx.Auth())
+}
+
+// NewAPI returns a new audit log API facade. The audit log records
+// the activity of every user of the controller, so only controller
+// superusers may query it.
+func NewAPI(backend Backend, controllerTag names.ControllerTag, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	isSuperuser, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isSuperuser {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Query returns the requests recorded in the audit log which
+// match the specified criteria, most recent first.
+func (api *API) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResults, error) {
+	var result params.AuditLogQueryResults
+	filter := state.AuditLogFilter{
+		Who:       args.User,
+		ModelUUID: args.ModelUUID,
+		Facade:    args.Facade,
+		Method:    args.Method,
+		Outcome:   args.Outcome,
+		Limit:     args.Limit,
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+	entries, err := api.backend.QueryAuditLog(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.AuditLogEntry, len(entries))
+	for i, e := range entries {
+		entry := params.AuditLogEntry{
+			ConversationID: e.ConversationID,
+			ConnectionID:   e.ConnectionID,
+			User:           e.Who,
+			What:           e.What,
+			ModelName:      e.ModelName,
+			ModelUUID:      e.ModelUUID,
+			RequestID:      e.RequestID,
+			Time:           e.Time,
+			Facade:         e.Facade,
+			Method:         e.Method,
+			Version:        e.Version,
+			Args:           e.Args,
+			Outcome:        e.Outcome,
+		}
+		for _, err := range e.Errors {
+			entry.Errors = append(entry.Errors, params.AuditLogError{
+				Message: err.Message,
+				Code:    err.Code,
+			})
+		}
+		result.Results[i] = entry
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	coretesting.BaseSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{}
+}
+
+func (s *AuditLogSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	_, err := auditlog.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AuditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AuditLogSuite) TestQuery(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	when := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.entries = []state.AuditLogEntry{{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		Who:            "fred",
+		What:           "juju remove-application mysql",
+		ModelName:      "admin/default",
+		ModelUUID:      coretesting.ModelTag.Id(),
+		RequestID:      25,
+		Time:           when,
+		Facade:         "Application",
+		Method:         "DestroyApplication",
+		Version:        13,
+		Outcome:        state.AuditOutcomeFailure,
+		Errors:         []coreauditlog.Error{{Message: "boom", Code: "not found"}},
+	}}
+	after := when.Add(-time.Hour)
+	result, err := api.Query(params.AuditLogQueryArgs{
+		User:    "fred",
+		Facade:  "Application",
+		Method:  "DestroyApplication",
+		After:   &after,
+		Outcome: "failure",
+		Limit:   10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogQueryResults{
+		Results: []params.AuditLogEntry{{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			User:           "fred",
+			What:           "juju remove-application mysql",
+			ModelName:      "admin/default",
+			ModelUUID:      coretesting.ModelTag.Id(),
+			RequestID:      25,
+			Time:           when,
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        13,
+			Outcome:        "failure",
+			Errors:         []params.AuditLogError{{Message: "boom", Code: "not found"}},
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{{
+		"QueryAuditLog", []interface{}{state.AuditLogFilter{
+			Who:     "fred",
+			Facade:  "Application",
+			Method:  "DestroyApplication",
+			After:   after,
+			Outcome: "failure",
+			Limit:   10,
+		}},
+	}})
+}
+
+type mockBackend struct {
+	testing.Stub
+	entries []state.AuditLogEntry
+}
+
+func (m *mockBackend) QueryAuditLog(filter state.AuditLogFilter) ([]state.AuditLogEntry, error) {
+	m.MethodCall(m, "QueryAuditLog", filter)
+	return m.entries, m.NextErr()
+}
@@ -45,6 +45,12 @@ type API struct {
 	machineID string
 }
 
+// APIv3 provides the Backups API facade for version 3, which
+// doesn't create encrypted backups.
+type APIv3 struct {
+	*API
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -149,6 +155,8 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.HANodes = meta.Controller.HANodes
 	result.ControllerMachineID = meta.Controller.MachineID
 	result.ControllerMachineInstanceID = meta.Controller.MachineInstanceID
+	result.EncryptedTo = meta.EncryptedTo
+	result.Location = meta.Location
 	result.Filename = filename
 
 	return result
@@ -176,6 +184,8 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 		MachineInstanceID: result.ControllerMachineInstanceID,
 		HANodes:           result.HANodes,
 	}
+	meta.EncryptedTo = result.EncryptedTo
+	meta.Location = result.Location
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
@@ -6,6 +6,7 @@ package backups
 import (
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
+	"golang.org/x/crypto/openpgp"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state/backups"
@@ -14,15 +15,25 @@ import (
 var waitUntilReady = replicaset.WaitUntilReady
 
 // Create is the API method that requests juju to create a new backup
-// of its state.
+// of its state. If an encryption key is given, the backup archive is
+// encrypted to it.
 func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	result := params.BackupsMetadataResult{}
+	var encryptTo openpgp.EntityList
+	if args.EncryptionKey != "" {
+		keys, err := backups.ParseEncryptionKeys(args.EncryptionKey)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		encryptTo = keys
+	}
+
 	backupsMethods, closer := newBackups(a.backend)
 	defer closer.Close()
 
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
 
-	result := params.BackupsMetadataResult{}
 	// Don't go if HA isn't ready.
 	err := waitUntilReady(session, 60)
 	if err != nil {
@@ -64,7 +75,7 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 	}
 	meta.Controller.HANodes = int64(len(nodes))
 
-	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload)
+	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload, encryptTo)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
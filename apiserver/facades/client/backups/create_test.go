@@ -4,7 +4,14 @@
 package backups_test
 
 import (
+	"bytes"
+	"crypto"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
@@ -70,3 +77,38 @@ func (s *backupsSuite) TestCreateController(c *gc.C) {
 	expected := backups.CreateResult(s.meta, "test-filename")
 	c.Check(result, gc.DeepEquals, expected)
 }
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	key, armored := newEncryptionKey(c)
+
+	_, err := s.api.Create(params.BackupsCreateArgs{EncryptionKey: armored})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.EncryptTo, gc.HasLen, 1)
+	c.Check(fake.EncryptTo[0].PrimaryKey.Fingerprint, gc.DeepEquals, key.PrimaryKey.Fingerprint)
+}
+
+func (s *backupsSuite) TestCreateInvalidEncryptionKey(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{EncryptionKey: "not a key"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func newEncryptionKey(c *gc.C) (*openpgp.Entity, string) {
+	config := &packet.Config{DefaultHash: crypto.SHA256}
+	key, err := openpgp.NewEntity("backups", "", "backups@example.com", config)
+	c.Assert(err, jc.ErrorIsNil)
+	// Signing the identity again records the preferred hash.
+	c.Assert(key.SerializePrivate(&bytes.Buffer{}, config), jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return key, buf.String()
+}
@@ -34,8 +34,17 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
-// NewFacadeV3 provides the required signature for facade registration.
-func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+// NewFacadeV3 provides the required signature for version 3 facade registration.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+// NewFacadeV4 provides the required signature for version 4 facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	model, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
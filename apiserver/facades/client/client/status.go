@@ -1268,6 +1268,15 @@ func (context *statusContext) processApplication(application *state.Application)
 			if len(serviceInfo.Addresses()) > 0 {
 				processedStatus.PublicAddress = serviceInfo.Addresses()[0].Value
 			}
+			if autoscaler := serviceInfo.Autoscaler(); autoscaler != nil {
+				processedStatus.Autoscaler = &params.AutoscalerStatus{
+					MinReplicas:     autoscaler.MinReplicas,
+					MaxReplicas:     autoscaler.MaxReplicas,
+					CurrentReplicas: autoscaler.CurrentReplicas,
+					DesiredReplicas: autoscaler.DesiredReplicas,
+					Message:         autoscaler.Message,
+				}
+			}
 		} else {
 			logger.Debugf("no service details for %v: %v", application.Name(), err)
 		}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -57,10 +58,16 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the BackupStatus method.
+type ControllerAPIv9 struct {
+	*ControllerAPI
+}
+
 // ControllerAPIv8 provides the v8 Controller API. The only difference
 // between this and v9 is that v8 doesn't have the model summary watchers.
 type ControllerAPIv8 struct {
-	*ControllerAPI
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
@@ -95,10 +102,10 @@ type ControllerAPIv3 struct {
 
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = NewControllerAPIv9
+var LatestAPI = NewControllerAPIv10
 
-// NewControllerAPIv9 creates a new ControllerAPIv9.
-func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -120,6 +127,15 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
 // NewControllerAPIv8 creates a new ControllerAPIv8.
 func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
 	v9, err := NewControllerAPIv9(ctx)
@@ -252,6 +268,53 @@ func (c *ControllerAPI) ControllerVersion() (params.ControllerVersionResults, er
 	return result, nil
 }
 
+// BackupStatus isn't on the v9 API.
+func (c *ControllerAPIv9) BackupStatus(_, _ struct{}) {}
+
+// BackupStatus returns the schedule and the outcome of the
+// controller's scheduled backups, and whether they are overdue.
+func (c *ControllerAPI) BackupStatus() (params.ControllerBackupStatus, error) {
+	var result params.ControllerBackupStatus
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	cfg, err := c.state.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	status, err := c.state.BackupStatus()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Schedule = cfg.BackupSchedule()
+	if !status.LastAttempt.IsZero() {
+		result.LastAttempt = &status.LastAttempt
+	}
+	if !status.LastSuccess.IsZero() {
+		result.LastSuccess = &status.LastSuccess
+	}
+	result.LastBackupID = status.LastBackupID
+	result.LastError = status.LastError
+	result.MaxAge = cfg.BackupMaxAge()
+	result.Overdue = result.Schedule != "" && status.Overdue(time.Now(), result.MaxAge)
+	return result, nil
+}
+
+// ControllerConfig returns the controller's configuration, without
+// the attributes which hold credentials.
+func (c *ControllerAPI) ControllerConfig() (params.ControllerConfigResult, error) {
+	result, err := c.ControllerConfigAPI.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for attr := range result.Config {
+		if corecontroller.SecretConfigAttributes.Contains(attr) {
+			delete(result.Config, attr)
+		}
+	}
+	return result, nil
+}
+
 // IdentityProviderURL isn't on the v6 API.
 func (c *ControllerAPIv6) IdentityProviderURL() {}
 
@@ -330,6 +330,20 @@ func (s *controllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestControllerConfigHidesSecrets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		corecontroller.AuditLogWebhookURL:    "https://audit.example.com/",
+		corecontroller.AuditLogWebhookSecret: "sekrit",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.controller.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config[corecontroller.AuditLogWebhookURL], gc.Equals, "https://audit.example.com/")
+	_, ok := cfg.Config[corecontroller.AuditLogWebhookSecret]
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -1062,6 +1076,60 @@ func (s *controllerSuite) TestIdentityProviderURL(c *gc.C) {
 	c.Assert(urlRes.Result, gc.Equals, expURL)
 }
 
+func (s *controllerSuite) TestBackupStatusNotScheduled(c *gc.C) {
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerBackupStatus{
+		MaxAge: corecontroller.DefaultBackupMaxAge,
+	})
+}
+
+func (s *controllerSuite) TestBackupStatus(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		corecontroller.BackupSchedule: "0 3 * * *",
+		corecontroller.BackupMaxAge:   "24h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	success := time.Now().Add(-48 * time.Hour).UTC().Round(time.Second)
+	attempt := success.Add(24 * time.Hour)
+	err = s.State.SetBackupStatus(state.BackupStatus{
+		LastAttempt:  attempt,
+		LastSuccess:  success,
+		LastBackupID: "backup-id",
+		LastError:    "disk full",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerBackupStatus{
+		Schedule:     "0 3 * * *",
+		LastAttempt:  &attempt,
+		LastSuccess:  &success,
+		LastBackupID: "backup-id",
+		LastError:    "disk full",
+		MaxAge:       24 * time.Hour,
+		Overdue:      true,
+	})
+}
+
+func (s *controllerSuite) TestBackupStatusRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endPoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endPoint.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) newSummaryWatcherFacade(c *gc.C, id string) *apiserver.SrvModelSummaryWatcher {
 	context := s.context
 	context.ID_ = id
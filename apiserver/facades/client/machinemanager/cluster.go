@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// EvacuateClusterMember moves the instances of the model's machines off
+// the specified member of the cloud's cluster, and records the zone that
+// each moved machine now occupies.
+func (mm *MachineManagerAPI) EvacuateClusterMember(arg params.EvacuateClusterMemberArg) (params.EvacuateClusterMemberResult, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.EvacuateClusterMemberResult{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.EvacuateClusterMemberResult{}, errors.Trace(err)
+	}
+	return evacuateClusterMember(mm, environs.GetEnviron, arg)
+}
+
+// EvacuateClusterMember is not available in versions prior to 7.
+func (*MachineManagerAPIV6) EvacuateClusterMember(_, _ struct{}) {}
+
+func evacuateClusterMember(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	arg params.EvacuateClusterMemberArg,
+) (params.EvacuateClusterMemberResult, error) {
+	if arg.Member == "" {
+		return params.EvacuateClusterMemberResult{
+			Error: apiservererrors.ServerError(errors.NotValidf("empty cluster member")),
+		}, nil
+	}
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return params.EvacuateClusterMemberResult{}, errors.Trace(err)
+	}
+	evacuator, ok := env.(environs.ClusterEvacuator)
+	if !ok {
+		return params.EvacuateClusterMemberResult{
+			Error: apiservererrors.ServerError(errors.NotSupportedf("evacuating cluster members on this cloud")),
+		}, nil
+	}
+
+	moves, err := evacuator.EvacuateClusterMember(mm.callContext, arg.Member, arg.Target)
+	if err != nil {
+		return params.EvacuateClusterMemberResult{
+			Error: apiservererrors.ServerError(err),
+		}, nil
+	}
+
+	machines, err := mm.machinesByInstanceId()
+	if err != nil {
+		return params.EvacuateClusterMemberResult{}, errors.Trace(err)
+	}
+	result := params.EvacuateClusterMemberResult{
+		Machines: make([]params.EvacuatedMachine, len(moves)),
+	}
+	for i, move := range moves {
+		evacuated := params.EvacuatedMachine{
+			InstanceId: string(move.Id),
+			Zone:       move.Zone,
+			Live:       move.Live,
+		}
+		err := move.Err
+		if m, ok := machines[move.Id]; ok {
+			evacuated.MachineTag = names.NewMachineTag(m.Id()).String()
+			// The instance poller would eventually notice the new zone,
+			// but record it now so that status reflects the move.
+			if err == nil {
+				err = m.SetAvailabilityZone(move.Zone)
+			}
+		}
+		evacuated.Error = apiservererrors.ServerError(err)
+		result.Machines[i] = evacuated
+	}
+	return result, nil
+}
+
+// machinesByInstanceId returns the model's provisioned machines,
+// keyed by their instance IDs.
+func (mm *MachineManagerAPI) machinesByInstanceId() (map[instance.Id]Machine, error) {
+	all, err := mm.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines := make(map[instance.Id]Machine)
+	for _, m := range all {
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machines[instId] = m
+	}
+	return machines, nil
+}
+
+// environ returns the environ of the model the facade serves.
+func (mm *MachineManagerAPI) environ(getEnviron environGetFunc) (environs.Environ, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudSpec := func() (environscloudspec.CloudSpec, error) {
+		return stateenvirons.CloudSpecForModel(model)
+	}
+	backend := common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}
+	env, err := getEnviron(backend, environs.New)
+	return env, errors.Trace(err)
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+func (s *MachineManagerSuite) environGetter(env environs.Environ) func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+	return func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+}
+
+func (s *MachineManagerSuite) TestEvacuateClusterMember(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", instanceId: "juju-0"}
+	s.st.machines["1"] = &mockMachine{id: "1", instanceId: "juju-1"}
+	s.st.machines["2"] = &mockMachine{id: "2"}
+	env := &mockEvacuatorEnviron{
+		moves: []environs.InstanceMove{
+			{Id: "juju-0", Zone: "node2", Live: true},
+			{Id: "juju-1", Zone: "node3", Err: errors.New("boom")},
+			{Id: "juju-foreign", Zone: "node2"},
+		},
+	}
+
+	result, err := machinemanager.EvacuateClusterMember(s.api, s.environGetter(env), params.EvacuateClusterMemberArg{
+		Member: "node1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EvacuateClusterMemberResult{
+		Machines: []params.EvacuatedMachine{{
+			MachineTag: "machine-0",
+			InstanceId: "juju-0",
+			Zone:       "node2",
+			Live:       true,
+		}, {
+			MachineTag: "machine-1",
+			InstanceId: "juju-1",
+			Zone:       "node3",
+			Error:      &params.Error{Message: "boom"},
+		}, {
+			InstanceId: "juju-foreign",
+			Zone:       "node2",
+		}},
+	})
+	env.CheckCall(c, 0, "EvacuateClusterMember", "node1", "")
+	s.st.machines["0"].CheckCall(c, 2, "SetAvailabilityZone", "node2")
+	c.Assert(s.st.machines["0"].zone, gc.Equals, "node2")
+	c.Assert(s.st.machines["1"].zone, gc.Equals, "")
+}
+
+func (s *MachineManagerSuite) TestEvacuateClusterMemberTarget(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	env := &mockEvacuatorEnviron{}
+	result, err := machinemanager.EvacuateClusterMember(s.api, s.environGetter(env), params.EvacuateClusterMemberArg{
+		Member: "node1",
+		Target: "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Machines, gc.HasLen, 0)
+	env.CheckCall(c, 0, "EvacuateClusterMember", "node1", "node2")
+}
+
+func (s *MachineManagerSuite) TestEvacuateClusterMemberError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	env := &mockEvacuatorEnviron{}
+	env.SetErrors(errors.NotFoundf("cluster member %q", "node9"))
+	result, err := machinemanager.EvacuateClusterMember(s.api, s.environGetter(env), params.EvacuateClusterMemberArg{
+		Member: "node9",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `cluster member "node9" not found`)
+	c.Assert(params.IsCodeNotFound(result.Error), jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestEvacuateClusterMemberNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	env := &mockEnviron{}
+	result, err := machinemanager.EvacuateClusterMember(s.api, s.environGetter(env), params.EvacuateClusterMemberArg{
+		Member: "node1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "evacuating cluster members on this cloud not supported")
+	c.Assert(params.IsCodeNotSupported(result.Error), jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestEvacuateClusterMemberEmptyMember(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	env := &mockEvacuatorEnviron{}
+	result, err := machinemanager.EvacuateClusterMember(s.api, s.environGetter(env), params.EvacuateClusterMemberArg{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "empty cluster member not valid")
+	env.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestEvacuateClusterMemberReadOnly(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	_, err := s.api.EvacuateClusterMember(params.EvacuateClusterMemberArg{Member: "node1"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockEvacuatorEnviron struct {
+	environs.Environ
+	jtesting.Stub
+
+	moves []environs.InstanceMove
+}
+
+func (e *mockEvacuatorEnviron) EvacuateClusterMember(
+	ctx context.ProviderCallContext, member, target string,
+) ([]environs.InstanceMove, error) {
+	e.MethodCall(e, "EvacuateClusterMember", member, target)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return e.moves, nil
+}
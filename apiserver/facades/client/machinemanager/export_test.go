@@ -5,3 +5,5 @@ package machinemanager
 
 var InstanceTypes = instanceTypes
 var IsSeriesLessThan = isSeriesLessThan
+var EvacuateClusterMember = evacuateClusterMember
+var MigrateMachine = migrateMachine
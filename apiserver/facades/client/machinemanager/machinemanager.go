@@ -101,6 +101,18 @@ type MachineManagerAPIV5 struct {
 // Version 6 of Machine Manager API.
 // Changes input parameters to DestroyMachineWithParams and ForceDestroyMachine.
 type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV7
+}
+
+// Version 7 of Machine Manager API.
+// Adds EvacuateClusterMember.
+type MachineManagerAPIV7 struct {
+	*MachineManagerAPIV8
+}
+
+// Version 8 of Machine Manager API.
+// Adds MigrateMachine.
+type MachineManagerAPIV8 struct {
 	*MachineManagerAPI
 }
 
@@ -124,11 +136,29 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 
 // NewFacadeV6 creates a new server-side MachineManager API facade.
 func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIv7, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIv7}, nil
+}
+
+// NewFacadeV7 creates a new server-side MachineManager API facade.
+func NewFacadeV7(ctx facade.Context) (*MachineManagerAPIV7, error) {
+	machineManagerAPIv8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV7{machineManagerAPIv8}, nil
+}
+
+// NewFacadeV8 creates a new server-side MachineManager API facade.
+func NewFacadeV8(ctx facade.Context) (*MachineManagerAPIV8, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV6{machineManagerAPI}, nil
+	return &MachineManagerAPIV8{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
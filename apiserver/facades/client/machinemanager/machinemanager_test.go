@@ -24,7 +24,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
@@ -523,7 +525,7 @@ func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) {
 }
 
 func (s *MachineManagerSuite) apiV5() machinemanager.MachineManagerAPIV5 {
-	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{s.api}}
+	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{&machinemanager.MachineManagerAPIV7{&machinemanager.MachineManagerAPIV8{s.api}}}}
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
@@ -1000,6 +1002,30 @@ func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
 	}
 }
 
+func (st *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
+	st.MethodCall(st, "AllSpaceInfos")
+	return network.SpaceInfos{{ID: "0", Name: "alpha"}}, st.NextErr()
+}
+
+func (st *mockState) ApplyOperation(op state.ModelOperation) error {
+	st.MethodCall(st, "ApplyOperation", op)
+	return st.NextErr()
+}
+
+func (st *mockState) AllMachines() ([]machinemanager.Machine, error) {
+	st.MethodCall(st, "AllMachines")
+	ids := make([]string, 0, len(st.machines))
+	for id := range st.machines {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]machinemanager.Machine, len(ids))
+	for i, id := range ids {
+		out[i] = st.machines[id]
+	}
+	return out, nil
+}
+
 func (st *mockState) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
 	st.MethodCall(st, "StorageInstance", tag)
 	return &mockStorage{
@@ -1051,6 +1077,8 @@ type mockMachine struct {
 	unitState                status.Status
 	isManager                bool
 	isLockedForSeriesUpgrade bool
+	instanceId               instance.Id
+	zone                     string
 
 	unitsF func() ([]machinemanager.Unit, error)
 }
@@ -1141,6 +1169,35 @@ func (m *mockMachine) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	return model.UpgradeSeriesNotStarted, nil
 }
 
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	m.MethodCall(m, "InstanceId")
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) SetAvailabilityZone(zone string) error {
+	m.MethodCall(m, "SetAvailabilityZone", zone)
+	m.zone = zone
+	return m.NextErr()
+}
+
+func (m *mockMachine) SetInstanceMoved(id instance.Id, zone string) error {
+	m.MethodCall(m, "SetInstanceMoved", id, zone)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.instanceId = id
+	m.zone = zone
+	return nil
+}
+
+func (m *mockMachine) SetProviderAddresses(addrs ...network.SpaceAddress) error {
+	m.MethodCall(m, "SetProviderAddresses", addrs)
+	return m.NextErr()
+}
+
 type mockUnit struct {
 	tag         names.UnitTag
 	agentStatus status.Status
@@ -0,0 +1,124 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common/networkingcommon"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+)
+
+// MigrateMachine moves the instance of a machine to the specified
+// availability zone, and records the instance's new location, addresses
+// and link-layer devices in state.
+func (mm *MachineManagerAPI) MigrateMachine(arg params.MigrateMachineArg) (params.MigrateMachineResult, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.MigrateMachineResult{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.MigrateMachineResult{}, errors.Trace(err)
+	}
+	return migrateMachine(mm, environs.GetEnviron, arg)
+}
+
+// MigrateMachine is not available in versions prior to 8.
+func (*MachineManagerAPIV7) MigrateMachine(_, _ struct{}) {}
+
+func migrateMachine(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	arg params.MigrateMachineArg,
+) (params.MigrateMachineResult, error) {
+	fail := func(err error) (params.MigrateMachineResult, error) {
+		return params.MigrateMachineResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+
+	tag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return fail(err)
+	}
+	if arg.Zone == "" {
+		return fail(errors.NotValidf("empty availability zone"))
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return fail(err)
+	}
+	instId, err := m.InstanceId()
+	if err != nil {
+		return fail(err)
+	}
+
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return params.MigrateMachineResult{}, errors.Trace(err)
+	}
+	migrator, ok := env.(environs.InstanceMigrator)
+	if !ok {
+		return fail(errors.NotSupportedf("moving machines on this cloud"))
+	}
+
+	move, err := migrator.MigrateInstance(mm.callContext, instId, arg.Zone)
+	if err != nil {
+		return fail(err)
+	}
+	if err := m.SetInstanceMoved(move.Id, move.Zone); err != nil {
+		return fail(err)
+	}
+
+	// The instance poller would eventually notice the new addresses and
+	// devices, but record them now so that the machine is reachable
+	// straight after the move.
+	if err := mm.updateMovedMachineNetworking(env, m, move.Id); err != nil {
+		logger.Warningf("cannot update networking of moved machine %v, "+
+			"waiting for the instance poller: %v", tag.Id(), err)
+	}
+
+	return params.MigrateMachineResult{
+		InstanceId: string(move.Id),
+		Zone:       move.Zone,
+		Live:       move.Live,
+	}, nil
+}
+
+// updateMovedMachineNetworking refreshes the provider addresses and
+// link-layer devices of a machine from its moved instance.
+func (mm *MachineManagerAPI) updateMovedMachineNetworking(env environs.Environ, m Machine, id instance.Id) error {
+	ids := []instance.Id{id}
+	insts, err := env.Instances(mm.callContext, ids)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	addrs, err := insts[0].Addresses(mm.callContext)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spaceAddrs, err := addrs.ToSpaceAddresses(mm.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.SetProviderAddresses(spaceAddrs...); err != nil {
+		return errors.Trace(err)
+	}
+
+	netEnv, ok := env.(environs.Networking)
+	if !ok {
+		return nil
+	}
+	ifaces, err := netEnv.NetworkInterfaces(mm.callContext, ids)
+	if errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if len(ifaces) == 0 || len(ifaces[0]) == 0 {
+		return nil
+	}
+	return errors.Trace(mm.st.ApplyOperation(networkingcommon.NewMergeMachineLinkLayerOp(m, ifaces[0])))
+}
@@ -0,0 +1,219 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+)
+
+func (s *MachineManagerSuite) TestMigrateMachine(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", instanceId: "juju-0"}
+	env := &mockMigratorEnviron{
+		move:  environs.InstanceMove{Id: "juju-0", Zone: "node2", Live: true},
+		addrs: network.NewProviderAddresses("10.0.0.2"),
+	}
+
+	result, err := machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "machine-0",
+		Zone:       "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateMachineResult{
+		InstanceId: "juju-0",
+		Zone:       "node2",
+		Live:       true,
+	})
+	env.CheckCalls(c, []jtesting.StubCall{
+		{"MigrateInstance", []interface{}{instance.Id("juju-0"), "node2"}},
+		{"Instances", []interface{}{[]instance.Id{"juju-0"}}},
+	})
+	m := s.st.machines["0"]
+	m.CheckCallNames(c, "InstanceId", "SetInstanceMoved", "SetProviderAddresses")
+	m.CheckCall(c, 1, "SetInstanceMoved", instance.Id("juju-0"), "node2")
+	m.CheckCall(c, 2, "SetProviderAddresses", []network.SpaceAddress{{
+		MachineAddress: network.NewMachineAddress("10.0.0.2"),
+	}})
+	c.Assert(m.zone, gc.Equals, "node2")
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineMergesLinkLayerDevices(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", instanceId: "juju-0"}
+	env := &mockNetworkingMigratorEnviron{
+		mockMigratorEnviron: mockMigratorEnviron{
+			move: environs.InstanceMove{Id: "juju-0", Zone: "node2"},
+		},
+		ifaces: []network.InterfaceInfos{{{InterfaceName: "eth0", MACAddress: "00:16:3e:00:00:01"}}},
+	}
+
+	result, err := machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "machine-0",
+		Zone:       "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	env.CheckCallNames(c, "MigrateInstance", "Instances", "NetworkInterfaces")
+	s.st.CheckCallNames(c, "ModelTag", "Machine", "Model", "ApplyOperation")
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineNetworkingErrorIgnored(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", instanceId: "juju-0"}
+	env := &mockMigratorEnviron{
+		move: environs.InstanceMove{Id: "juju-0", Zone: "node2"},
+	}
+	env.SetErrors(nil, errors.New("instances unavailable"))
+
+	result, err := machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "machine-0",
+		Zone:       "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateMachineResult{
+		InstanceId: "juju-0",
+		Zone:       "node2",
+	})
+	s.st.machines["0"].CheckCallNames(c, "InstanceId", "SetInstanceMoved")
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", instanceId: "juju-0"}
+	env := &mockMigratorEnviron{}
+	env.SetErrors(errors.NotFoundf("cluster member %q", "node9"))
+
+	result, err := machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "machine-0",
+		Zone:       "node9",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `cluster member "node9" not found`)
+	c.Assert(params.IsCodeNotFound(result.Error), jc.IsTrue)
+	s.st.machines["0"].CheckCallNames(c, "InstanceId")
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineNotProvisioned(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0"}
+	env := &mockMigratorEnviron{}
+	result, err := machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "machine-0",
+		Zone:       "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "machine 0 not provisioned")
+	env.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", instanceId: "juju-0"}
+	env := &mockEnviron{}
+	result, err := machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "machine-0",
+		Zone:       "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "moving machines on this cloud not supported")
+	c.Assert(params.IsCodeNotSupported(result.Error), jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineInvalidArgs(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	env := &mockMigratorEnviron{}
+	result, err := machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "unit-foo-0",
+		Zone:       "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+
+	result, err = machinemanager.MigrateMachine(s.api, s.environGetter(env), params.MigrateMachineArg{
+		MachineTag: "machine-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "empty availability zone not valid")
+	env.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineReadOnly(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	_, err := s.api.MigrateMachine(params.MigrateMachineArg{MachineTag: "machine-0", Zone: "node2"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockMigratorEnviron struct {
+	environs.Environ
+	jtesting.Stub
+
+	move  environs.InstanceMove
+	addrs network.ProviderAddresses
+}
+
+func (e *mockMigratorEnviron) MigrateInstance(
+	ctx context.ProviderCallContext, id instance.Id, zone string,
+) (environs.InstanceMove, error) {
+	e.MethodCall(e, "MigrateInstance", id, zone)
+	if err := e.NextErr(); err != nil {
+		return environs.InstanceMove{}, err
+	}
+	return e.move, nil
+}
+
+func (e *mockMigratorEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	e.MethodCall(e, "Instances", ids)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return []instances.Instance{&mockInstance{addrs: e.addrs}}, nil
+}
+
+type mockNetworkingMigratorEnviron struct {
+	mockMigratorEnviron
+	environs.Networking
+
+	ifaces []network.InterfaceInfos
+}
+
+func (e *mockNetworkingMigratorEnviron) NetworkInterfaces(
+	ctx context.ProviderCallContext, ids []instance.Id,
+) ([]network.InterfaceInfos, error) {
+	e.MethodCall(e, "NetworkInterfaces", ids)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return e.ifaces, nil
+}
+
+type mockInstance struct {
+	instances.Instance
+
+	addrs network.ProviderAddresses
+}
+
+func (i *mockInstance) Addresses(context.ProviderCallContext) (network.ProviderAddresses, error) {
+	return i.addrs, nil
+}
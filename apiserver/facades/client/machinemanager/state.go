@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
@@ -23,7 +24,9 @@ type Backend interface {
 	network.SpaceLookup
 
 	Machine(string) (Machine, error)
+	AllMachines() ([]Machine, error)
 	Model() (Model, error)
+	ApplyOperation(state.ModelOperation) error
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
@@ -44,6 +47,8 @@ type Model interface {
 }
 
 type Machine interface {
+	networkingcommon.LinkLayerMachine
+
 	Id() string
 	Destroy() error
 	ForceDestroy(time.Duration) error
@@ -60,6 +65,10 @@ type Machine interface {
 	IsManager() bool
 	IsLockedForSeriesUpgrade() (bool, error)
 	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	InstanceId() (instance.Id, error)
+	SetAvailabilityZone(string) error
+	SetInstanceMoved(instance.Id, string) error
+	SetProviderAddresses(...network.SpaceAddress) error
 }
 
 type stateShim struct {
@@ -74,6 +83,18 @@ func (s stateShim) Machine(name string) (Machine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) AllMachines() ([]Machine, error) {
+	all, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Machine, len(all))
+	for i, m := range all {
+		out[i] = machineShim{m}
+	}
+	return out, nil
+}
+
 func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
@@ -106,6 +127,30 @@ func (m machineShim) Units() ([]Unit, error) {
 	return out, nil
 }
 
+func (m machineShim) AllLinkLayerDevices() ([]networkingcommon.LinkLayerDevice, error) {
+	devList, err := m.Machine.AllLinkLayerDevices()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]networkingcommon.LinkLayerDevice, len(devList))
+	for i, dev := range devList {
+		out[i] = dev
+	}
+	return out, nil
+}
+
+func (m machineShim) AllAddresses() ([]networkingcommon.LinkLayerAddress, error) {
+	addrList, err := m.Machine.AllAddresses()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]networkingcommon.LinkLayerAddress, len(addrList))
+	for i, addr := range addrList {
+		out[i] = addr
+	}
+	return out, nil
+}
+
 type Unit interface {
 	UnitTag() names.UnitTag
 	Name() string
@@ -125,6 +125,11 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 		if attr == config.AuthorizedKeysKey {
 			continue
 		}
+		// The secret backend config holds credentials
+		// for the backend and must not be shown.
+		if attr == config.SecretBackendConfigKey {
+			continue
+		}
 		result.Config[attr] = params.ConfigValue{
 			Value:  val.Value,
 			Source: val.Source,
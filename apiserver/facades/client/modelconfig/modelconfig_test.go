@@ -42,6 +42,9 @@ func (s *modelconfigSuite) SetUpTest(c *gc.C) {
 			"ftp-proxy":       {"http://proxy", "model"},
 			"authorized-keys": {testing.FakeAuthKeys, "model"},
 			"charmhub-url":    {"http://meshuggah.rocks", "model"},
+			"secret-backend-config": {
+				"vault:\n  endpoint: http://vault:8200\n", "model",
+			},
 		},
 	}
 	var err error
@@ -0,0 +1,32 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// SecretsBackend defines the state functionality required by the
+// secrets facade. For details on the methods, see the methods on
+// state.SecretsStore with the same names.
+type SecretsBackend interface {
+	ListSecrets(state.SecretsFilter) ([]*secrets.SecretMetadata, error)
+	GetSecretValue(*secrets.URI, int) (secrets.SecretValue, *secrets.ValueRef, error)
+	ChangeSecretBackend(*secrets.URI, int, secrets.SecretData, *secrets.ValueRef) error
+}
+
+// Model defines the model methods required by the secrets facade.
+type Model interface {
+	ModelConfig() (*config.Config, error)
+	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	SecretBackendCredential(backendType string) (map[string]string, error)
+	SetSecretBackendCredential(backendType string, attrs map[string]string) error
+}
+
+// NewStateBackend converts a state.State into a SecretsBackend.
+func NewStateBackend(st *state.State) SecretsBackend {
+	return state.NewSecrets(st)
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockSecretsBackend struct {
+	testing.Stub
+
+	valueRef *secrets.ValueRef
+}
+
+func (m *mockSecretsBackend) ListSecrets(filter state.SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	m.MethodCall(m, "ListSecrets", filter)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*secrets.SecretMetadata{{
+		URI:            &secrets.URI{ModelUUID: coretesting.ModelTag.Id(), ID: "9m4e2mr0ui3e8a215n4g"},
+		OwnerTag:       "application-mariadb",
+		Description:    "my secret",
+		RotateInterval: time.Hour,
+		LatestRevision: 2,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}}, nil
+}
+
+func (m *mockSecretsBackend) GetSecretValue(uri *secrets.URI, revision int) (secrets.SecretValue, *secrets.ValueRef, error) {
+	m.MethodCall(m, "GetSecretValue", uri, revision)
+	if err := m.NextErr(); err != nil {
+		return nil, nil, err
+	}
+	if m.valueRef != nil {
+		return nil, m.valueRef, nil
+	}
+	return secrets.NewSecretValue(map[string]string{"foo": "YmFy"}), nil, nil
+}
+
+func (m *mockSecretsBackend) ChangeSecretBackend(uri *secrets.URI, revision int, data secrets.SecretData, ref *secrets.ValueRef) error {
+	m.MethodCall(m, "ChangeSecretBackend", uri, revision, data, ref)
+	return m.NextErr()
+}
+
+type mockModel struct {
+	testing.Stub
+
+	cfg        *config.Config
+	credential map[string]string
+}
+
+func (m *mockModel) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	return m.cfg, m.NextErr()
+}
+
+func (m *mockModel) UpdateModelConfig(update map[string]interface{}, remove []string, _ ...state.ValidateConfigFunc) error {
+	m.MethodCall(m, "UpdateModelConfig", update, remove)
+	return m.NextErr()
+}
+
+func (m *mockModel) SecretBackendCredential(backendType string) (map[string]string, error) {
+	m.MethodCall(m, "SecretBackendCredential", backendType)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	if m.credential == nil {
+		return nil, errors.NotFoundf("%q secret backend credential", backendType)
+	}
+	return m.credential, nil
+}
+
+func (m *mockModel) SetSecretBackendCredential(backendType string, attrs map[string]string) error {
+	m.MethodCall(m, "SetSecretBackendCredential", backendType, attrs)
+	return m.NextErr()
+}
+
+type mockStores struct {
+	testing.Stub
+
+	backendType string
+	store       *mockStore
+}
+
+func (m *mockStores) BackendType() (string, error) {
+	m.MethodCall(m, "BackendType")
+	return m.backendType, m.NextErr()
+}
+
+func (m *mockStores) Store(backendType string) (provider.SecretsStore, error) {
+	m.MethodCall(m, "Store", backendType)
+	return m.store, m.NextErr()
+}
+
+type mockStore struct {
+	testing.Stub
+}
+
+func (m *mockStore) SaveContent(uri *secrets.URI, value secrets.SecretValue) (string, error) {
+	m.MethodCall(m, "SaveContent", uri, value)
+	return "rev-id", m.NextErr()
+}
+
+func (m *mockStore) GetContent(revisionID string) (secrets.SecretValue, error) {
+	m.MethodCall(m, "GetContent", revisionID)
+	return secrets.NewSecretValue(map[string]string{"foo": "YmF6"}), m.NextErr()
+}
+
+func (m *mockStore) DeleteContent(revisionID string) error {
+	m.MethodCall(m, "DeleteContent", revisionID)
+	return m.NextErr()
+}
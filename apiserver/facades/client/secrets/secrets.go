@@ -0,0 +1,264 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	secretscommon "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.secrets")
+
+// SecretsAPI is the backend for the Secrets facade.
+type SecretsAPI struct {
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+	backend    SecretsBackend
+	model      Model
+	stores     secretscommon.Stores
+}
+
+// NewSecretsAPI provides the signature required for facade registration.
+func NewSecretsAPI(context facade.Context) (*SecretsAPI, error) {
+	model, err := context.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(
+		names.NewModelTag(context.State().ModelUUID()),
+		NewStateBackend(context.State()),
+		model,
+		secretscommon.NewStores(model),
+		context.Auth(),
+	)
+}
+
+// NewAPI returns a new secrets API facade.
+func NewAPI(
+	modelTag names.ModelTag,
+	backend SecretsBackend,
+	model Model,
+	stores secretscommon.Stores,
+	authorizer facade.Authorizer,
+) (*SecretsAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &SecretsAPI{
+		authorizer: authorizer,
+		modelTag:   modelTag,
+		backend:    backend,
+		model:      model,
+		stores:     stores,
+	}, nil
+}
+
+func (s *SecretsAPI) checkCanRead() error {
+	canRead, err := s.authorizer.HasPermission(permission.ReadAccess, s.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+func (s *SecretsAPI) checkCanAdmin() error {
+	canAdmin, err := s.authorizer.HasPermission(permission.AdminAccess, s.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canAdmin {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets lists available secrets. Secret values are only
+// included for model admins.
+func (s *SecretsAPI) ListSecrets(arg params.ListSecretsArgs) (params.ListSecretResults, error) {
+	result := params.ListSecretResults{}
+	if arg.ShowSecrets {
+		if err := s.checkCanAdmin(); err != nil {
+			return result, errors.Trace(err)
+		}
+	} else {
+		if err := s.checkCanRead(); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	metadata, err := s.backend.ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ListSecretResult, len(metadata))
+	for i, md := range metadata {
+		secretResult := params.ListSecretResult{
+			URI:            md.URI.String(),
+			OwnerTag:       md.OwnerTag,
+			Description:    md.Description,
+			RotateInterval: md.RotateInterval,
+			NextRotateTime: md.NextRotateTime,
+			LatestRevision: md.LatestRevision,
+			CreateTime:     md.CreateTime,
+			UpdateTime:     md.UpdateTime,
+		}
+		if arg.ShowSecrets {
+			val, ref, err := s.backend.GetSecretValue(md.URI, md.LatestRevision)
+			if err == nil {
+				val, err = secretscommon.GetContent(s.stores, val, ref)
+			}
+			valueResult := &params.SecretValueResult{
+				Error: apiservererrors.ServerError(err),
+			}
+			if err == nil {
+				valueResult.Data = val.EncodedValues()
+			}
+			secretResult.Value = valueResult
+		}
+		result.Results[i] = secretResult
+	}
+	return result, nil
+}
+
+// SecretBackend returns the type of backend used to store
+// the content of secrets in the model.
+func (s *SecretsAPI) SecretBackend() (params.SecretBackendResult, error) {
+	var result params.SecretBackendResult
+	if err := s.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	backendType, err := s.stores.BackendType()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.BackendType = backendType
+	return result, nil
+}
+
+// ChangeSecretBackend changes the type of backend used to store the
+// content of secrets in the model, and moves existing content to it.
+func (s *SecretsAPI) ChangeSecretBackend(arg params.ChangeSecretBackendArg) (params.ChangeSecretBackendResult, error) {
+	var result params.ChangeSecretBackendResult
+	if err := s.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	cfg, err := s.model.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	backendConfig := cfg.SecretBackendConfig()
+	credential, err := s.model.SecretBackendCredential(arg.BackendType)
+	if err != nil && !errors.IsNotFound(err) {
+		return result, errors.Trace(err)
+	}
+	if arg.Config != nil {
+		// Secret attributes, such as tokens, are held in the
+		// backend credential rather than the model config.
+		var backendTypeConfig map[string]interface{}
+		backendTypeConfig, credential, err = secretscommon.SplitCredential(arg.BackendType, arg.Config)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if backendConfig == nil {
+			backendConfig = make(map[string]map[string]interface{})
+		}
+		backendConfig[arg.BackendType] = backendTypeConfig
+	}
+	if err := secretscommon.ValidateBackend(arg.BackendType, backendConfig[arg.BackendType], credential); err != nil {
+		return result, errors.Trace(err)
+	}
+	if arg.Config != nil {
+		if err := s.model.SetSecretBackendCredential(arg.BackendType, credential); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+
+	attrs := map[string]interface{}{
+		config.SecretBackendKey: arg.BackendType,
+	}
+	if arg.Config != nil {
+		rawConfig, err := yaml.Marshal(backendConfig)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		attrs[config.SecretBackendConfigKey] = string(rawConfig)
+	}
+	if err := s.model.UpdateModelConfig(attrs, nil); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	// New content is now saved to the new backend, so move
+	// the content of all existing revisions over to it.
+	result.Migrated, err = s.migrateContent(arg.BackendType)
+	result.Error = apiservererrors.ServerError(err)
+	return result, nil
+}
+
+func (s *SecretsAPI) migrateContent(backendType string) (int, error) {
+	metadata, err := s.backend.ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	count := 0
+	for _, md := range metadata {
+		for rev := 1; rev <= md.LatestRevision; rev++ {
+			moved, err := s.migrateRevision(md.URI, rev, backendType)
+			if err != nil {
+				return count, errors.Annotatef(err, "moving secret %q revision %d", md.URI, rev)
+			}
+			if moved {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (s *SecretsAPI) migrateRevision(uri *secrets.URI, revision int, backendType string) (bool, error) {
+	val, ref, err := s.backend.GetSecretValue(uri, revision)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	currentType := provider.Internal
+	if ref != nil {
+		currentType = ref.BackendType
+	}
+	if currentType == backendType {
+		return false, nil
+	}
+	if val, err = secretscommon.GetContent(s.stores, val, ref); err != nil {
+		return false, errors.Trace(err)
+	}
+	data, newRef, err := secretscommon.SaveContent(s.stores, uri, val.EncodedValues())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := s.backend.ChangeSecretBackend(uri, revision, data, newRef); err != nil {
+		if deleteErr := secretscommon.DeleteContent(s.stores, newRef); deleteErr != nil {
+			logger.Warningf("cannot delete orphaned content for secret %q: %v", uri, deleteErr)
+		}
+		return false, errors.Trace(err)
+	}
+	if err := secretscommon.DeleteContent(s.stores, ref); err != nil {
+		logger.Warningf("cannot delete old content for secret %q revision %d: %v", uri, revision, err)
+	}
+	return true, nil
+}
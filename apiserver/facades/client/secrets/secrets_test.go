@@ -0,0 +1,264 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockSecretsBackend
+	model      *mockModel
+	stores     *mockStores
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("fred"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockSecretsBackend{}
+	s.model = &mockModel{cfg: coretesting.ModelConfig(c)}
+	s.stores = &mockStores{backendType: provider.Internal, store: &mockStore{}}
+}
+
+func (s *SecretsSuite) newAPI(c *gc.C) (*secrets.SecretsAPI, error) {
+	return secrets.NewAPI(coretesting.ModelTag, s.backend, s.model, s.stores, s.authorizer)
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	s.assertListSecrets(c, false)
+}
+
+func (s *SecretsSuite) TestListSecretsWithValue(c *gc.C) {
+	s.assertListSecrets(c, true)
+}
+
+func (s *SecretsSuite) assertListSecrets(c *gc.C, reveal bool) {
+	if reveal {
+		s.authorizer.Tag = names.NewUserTag("admin")
+	} else {
+		s.authorizer.HasWriteTag = names.NewUserTag("fred")
+	}
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ListSecrets(params.ListSecretsArgs{ShowSecrets: reveal})
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var valueResult *params.SecretValueResult
+	if reveal {
+		valueResult = &params.SecretValueResult{
+			Data: map[string]string{"foo": "YmFy"},
+		}
+	}
+	c.Assert(results, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.ListSecretResult{{
+			URI:            "secret://" + coretesting.ModelTag.Id() + "/9m4e2mr0ui3e8a215n4g",
+			OwnerTag:       "application-mariadb",
+			Description:    "my secret",
+			RotateInterval: time.Hour,
+			LatestRevision: 2,
+			CreateTime:     now,
+			UpdateTime:     now,
+			Value:          valueResult,
+		}},
+	})
+	if reveal {
+		s.backend.CheckCallNames(c, "ListSecrets", "GetSecretValue")
+	} else {
+		s.backend.CheckCallNames(c, "ListSecrets")
+	}
+}
+
+func (s *SecretsSuite) TestListSecretsPermissionDenied(c *gc.C) {
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestListSecretsRevealRequiresAdmin(c *gc.C) {
+	s.authorizer.HasWriteTag = names.NewUserTag("fred")
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ListSecrets(params.ListSecretsArgs{ShowSecrets: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mariadb/0")
+	_, err := s.newAPI(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestListSecretsWithExternalValue(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.backend.valueRef = &coresecrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"}
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ListSecrets(params.ListSecretsArgs{ShowSecrets: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Value, jc.DeepEquals, &params.SecretValueResult{
+		Data: map[string]string{"foo": "YmF6"},
+	})
+	s.stores.CheckCall(c, 0, "Store", "vault")
+	s.stores.store.CheckCall(c, 0, "GetContent", "rev-id")
+}
+
+func (s *SecretsSuite) TestSecretBackend(c *gc.C) {
+	s.authorizer.HasWriteTag = names.NewUserTag("fred")
+	s.stores.backendType = "vault"
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.SecretBackend()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretBackendResult{BackendType: "vault"})
+}
+
+func (s *SecretsSuite) TestSecretBackendPermissionDenied(c *gc.C) {
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.SecretBackend()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestChangeSecretBackend(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.stores.backendType = "vault"
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	vaultConfig := map[string]interface{}{
+		"endpoint": "http://vault:8200",
+		"token":    "token",
+	}
+	result, err := facade.ChangeSecretBackend(params.ChangeSecretBackendArg{
+		BackendType: "vault",
+		Config:      vaultConfig,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ChangeSecretBackendResult{Migrated: 2})
+
+	s.model.CheckCallNames(c, "ModelConfig", "SecretBackendCredential", "SetSecretBackendCredential", "UpdateModelConfig")
+	s.model.CheckCall(c, 2, "SetSecretBackendCredential", "vault", map[string]string{"token": "token"})
+	s.model.CheckCall(c, 3, "UpdateModelConfig", map[string]interface{}{
+		config.SecretBackendKey:       "vault",
+		config.SecretBackendConfigKey: "vault:\n  endpoint: http://vault:8200\n",
+	}, []string(nil))
+
+	uri := &coresecrets.URI{ModelUUID: coretesting.ModelTag.Id(), ID: "9m4e2mr0ui3e8a215n4g"}
+	ref := &coresecrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"}
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ListSecrets", []interface{}{state.SecretsFilter{}}},
+		{"GetSecretValue", []interface{}{uri, 1}},
+		{"ChangeSecretBackend", []interface{}{uri, 1, coresecrets.SecretData(nil), ref}},
+		{"GetSecretValue", []interface{}{uri, 2}},
+		{"ChangeSecretBackend", []interface{}{uri, 2, coresecrets.SecretData(nil), ref}},
+	})
+	s.stores.store.CheckCallNames(c, "SaveContent", "SaveContent")
+}
+
+func (s *SecretsSuite) TestChangeSecretBackendSkipsMigrated(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.backend.valueRef = &coresecrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"}
+	s.model.cfg = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		config.SecretBackendKey:       "vault",
+		config.SecretBackendConfigKey: "vault:\n  endpoint: http://vault:8200\n",
+	})
+	s.model.credential = map[string]string{"token": "token"}
+	s.stores.backendType = "vault"
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.ChangeSecretBackend(params.ChangeSecretBackendArg{BackendType: "vault"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ChangeSecretBackendResult{})
+	s.model.CheckCallNames(c, "ModelConfig", "SecretBackendCredential", "UpdateModelConfig")
+	s.model.CheckCall(c, 2, "UpdateModelConfig", map[string]interface{}{
+		config.SecretBackendKey: "vault",
+	}, []string(nil))
+	s.backend.CheckCallNames(c, "ListSecrets", "GetSecretValue", "GetSecretValue")
+	s.stores.store.CheckNoCalls(c)
+}
+
+func (s *SecretsSuite) TestChangeSecretBackendToInternal(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.backend.valueRef = &coresecrets.ValueRef{BackendType: "vault", RevisionID: "rev-id"}
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.ChangeSecretBackend(params.ChangeSecretBackendArg{BackendType: provider.Internal})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ChangeSecretBackendResult{Migrated: 2})
+
+	uri := &coresecrets.URI{ModelUUID: coretesting.ModelTag.Id(), ID: "9m4e2mr0ui3e8a215n4g"}
+	data := coresecrets.SecretData{"foo": "YmF6"}
+	s.backend.CheckCall(c, 2, "ChangeSecretBackend", uri, 1, data, (*coresecrets.ValueRef)(nil))
+	s.stores.store.CheckCallNames(c, "GetContent", "DeleteContent", "GetContent", "DeleteContent")
+}
+
+func (s *SecretsSuite) TestChangeSecretBackendInvalidConfig(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ChangeSecretBackend(params.ChangeSecretBackendArg{
+		BackendType: "vault",
+		Config:      map[string]interface{}{"endpoint": "http://vault:8200"},
+	})
+	c.Assert(err, gc.ErrorMatches, "empty vault token not valid")
+	s.model.CheckCallNames(c, "ModelConfig", "SecretBackendCredential")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *SecretsSuite) TestChangeSecretBackendTokenInModelConfig(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.model.cfg = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		config.SecretBackendConfigKey: "vault:\n  endpoint: http://vault:8200\n  token: token\n",
+	})
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ChangeSecretBackend(params.ChangeSecretBackendArg{BackendType: "vault"})
+	c.Assert(err, gc.ErrorMatches, "vault token in model config not valid")
+	s.model.CheckCallNames(c, "ModelConfig", "SecretBackendCredential")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *SecretsSuite) TestChangeSecretBackendRequiresAdmin(c *gc.C) {
+	s.authorizer.HasWriteTag = names.NewUserTag("fred")
+	facade, err := s.newAPI(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ChangeSecretBackend(params.ChangeSecretBackendArg{BackendType: "vault"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -75,7 +75,11 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPIv7: storage.StorageAPIv7{
+						StorageAPI: *newAPI,
+					},
+				},
 			},
 		},
 	}
@@ -111,6 +115,7 @@ const (
 	detachStorageCall                       = "detachStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
 )
 
@@ -316,6 +321,10 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(releaseStorageInstanceCall, tag, destroyAttached, force)
 			return errors.New("cannae do it")
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
 		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
@@ -4,7 +4,10 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -158,3 +161,34 @@ func (s *filesystemSuite) TestListFilesystemsVolumeBacked(c *gc.C) {
 	c.Assert(found.Results[0].Result, gc.HasLen, 1)
 	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
 }
+
+func (s *filesystemSuite) TestListFilesystemsUsage(c *gc.C) {
+	updated := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.storageAccessor.filesystemUsage = func(tag names.FilesystemTag) (state.FilesystemUsage, error) {
+		c.Assert(tag, gc.Equals, s.filesystemTag)
+		return state.FilesystemUsage{
+			BytesUsed:  768,
+			BytesFree:  256,
+			InodesUsed: 10,
+			InodesFree: 90,
+			Updated:    updated,
+		}, nil
+	}
+	usage := &params.FilesystemUsage{
+		BytesUsed:  768,
+		BytesFree:  256,
+		InodesUsed: 10,
+		InodesFree: 90,
+		Updated:    &updated,
+	}
+	expected := s.expectedFilesystemDetails()
+	expected.Usage = usage
+	expected.Storage.Usage = usage
+	found, err := s.api.ListFilesystems(params.FilesystemFilters{
+		[]params.FilesystemFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
+}
@@ -68,9 +68,11 @@ type mockStorageAccessor struct {
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag, bool, bool) error
 	releaseStorageInstance              func(names.StorageTag, bool, bool) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	filesystemUsage                     func(names.FilesystemTag) (state.FilesystemUsage, error)
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -181,6 +183,10 @@ func (st *mockStorageAccessor) ReleaseStorageInstance(tag names.StorageTag, dest
 	return st.releaseStorageInstance(tag, destroyAttached, force)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
 func (st *mockStorageAccessor) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	panic("should not be called")
 }
@@ -189,6 +195,13 @@ func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockStorageAccessor) FilesystemUsage(tag names.FilesystemTag) (state.FilesystemUsage, error) {
+	if st.filesystemUsage == nil {
+		return state.FilesystemUsage{}, errors.NotFoundf("usage of filesystem %q", tag.Id())
+	}
+	return st.filesystemUsage(tag)
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
@@ -7,11 +7,14 @@ import (
 	"fmt"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	apiserverstorage "github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
 )
@@ -54,6 +57,52 @@ func (s *poolSuite) TestList(c *gc.C) {
 	c.Assert(one.Result[0].Provider, gc.Equals, string(provider.LoopProviderType))
 }
 
+func (s *poolSuite) TestListUsage(c *gc.C) {
+	s.createPools(c, 2)
+	filesystem := func(id, pool string) state.Filesystem {
+		fs := &mockFilesystem{tag: names.NewFilesystemTag(id)}
+		if pool != "" {
+			fs.info = &state.FilesystemInfo{Pool: pool}
+		}
+		return fs
+	}
+	s.storageAccessor.allFilesystems = func() ([]state.Filesystem, error) {
+		return []state.Filesystem{
+			filesystem("0", "testpool0"),
+			filesystem("1", "testpool0"),
+			filesystem("2", "testpool1"),
+			filesystem("3", ""),
+		}, nil
+	}
+	s.storageAccessor.filesystemUsage = func(tag names.FilesystemTag) (state.FilesystemUsage, error) {
+		switch tag.Id() {
+		case "0":
+			return state.FilesystemUsage{BytesUsed: 100, BytesFree: 300}, nil
+		case "1":
+			return state.FilesystemUsage{BytesUsed: 50, BytesFree: 50}, nil
+		}
+		return state.FilesystemUsage{}, errors.NotFoundf("usage of filesystem %q", tag.Id())
+	}
+
+	results, err := s.api.ListPools(params.StoragePoolFilters{[]params.StoragePoolFilter{{}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Result, gc.HasLen, 2)
+	usage := make(map[string]*params.StoragePoolUsage)
+	for _, pool := range results.Results[0].Result {
+		usage[pool.Name] = pool.Usage
+	}
+	// No filesystem in testpool1 has reported usage.
+	c.Assert(usage, jc.DeepEquals, map[string]*params.StoragePoolUsage{
+		"testpool0": {
+			Filesystems: 2,
+			BytesUsed:   150,
+			BytesFree:   350,
+		},
+		"testpool1": nil,
+	})
+}
+
 func (s *poolSuite) TestListManyResults(c *gc.C) {
 	s.registry.Providers["static"] = nil
 	s.createPools(c, 2)
@@ -61,6 +61,11 @@ type storageInterface interface {
 
 	// ReleaseStorageInstance releases the storage instance with the specified tag.
 	ReleaseStorageInstance(names.StorageTag, bool, bool, time.Duration) error
+
+	// ResizeStorageInstance requests that the volume or filesystem backing
+	// the storage instance with the specified tag be grown to the given
+	// size in MiB.
+	ResizeStorageInstance(names.StorageTag, uint64) error
 }
 
 type storageVolume interface {
@@ -101,6 +106,9 @@ type storageFile interface {
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// FilesystemUsage returns the last reported usage of a filesystem.
+	FilesystemUsage(tag names.FilesystemTag) (state.FilesystemUsage, error)
 }
 
 var getStorageAccessor = func(st *state.State) (storageAccess, error) {
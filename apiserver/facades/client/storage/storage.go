@@ -31,7 +31,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v8) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -42,9 +42,19 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// StorageAPIv7 implements the storage v7 API.
+type StorageAPIv7 struct {
+	StorageAPI
+}
+
+// StorageAPIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPIv7
+}
+
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -105,14 +115,36 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV7 returns a new storage v7 API facade.
+func NewStorageAPIV7(context facade.Context) (*StorageAPIv7, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv7{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPIV7(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPIv7: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -248,6 +280,7 @@ func createStorageDetails(
 	// Get information from underlying volume or filesystem.
 	var persistent bool
 	var statusEntity status.StatusGetter
+	var usage *params.FilesystemUsage
 	if si.Kind() == state.StorageKindFilesystem {
 		stFile := st.FilesystemAccess()
 		if stFile == nil {
@@ -261,6 +294,9 @@ func createStorageDetails(
 			return nil, errors.Trace(err)
 		}
 		statusEntity = filesystem
+		if usage, err = filesystemUsage(stFile, filesystem.FilesystemTag()); err != nil {
+			return nil, errors.Trace(err)
+		}
 	} else {
 		stVolume := st.VolumeAccess()
 		if stVolume == nil {
@@ -320,6 +356,26 @@ func createStorageDetails(
 		Status:      common.EntityStatusFromState(aStatus),
 		Persistent:  persistent,
 		Attachments: storageAttachmentDetails,
+		Usage:       usage,
+	}, nil
+}
+
+// filesystemUsage returns the last reported usage of the filesystem
+// with the specified tag, or nil if no usage has been reported.
+func filesystemUsage(stFile storageFile, tag names.FilesystemTag) (*params.FilesystemUsage, error) {
+	usage, err := stFile.FilesystemUsage(tag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	updated := usage.Updated
+	return &params.FilesystemUsage{
+		BytesUsed:  usage.BytesUsed,
+		BytesFree:  usage.BytesFree,
+		InodesUsed: usage.InodesUsed,
+		InodesFree: usage.InodesFree,
+		Updated:    &updated,
 	}, nil
 }
 
@@ -396,9 +452,54 @@ func (a *StorageAPI) listPools(filter params.StoragePoolFilter) ([]params.Storag
 		filterPools(pools, matches),
 		filterProviders(providers, matches)...,
 	)
+	usage, err := a.poolUsage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, pool := range results {
+		results[i].Usage = usage[pool.Name]
+	}
 	return results, nil
 }
 
+// poolUsage returns the aggregate of the last reported usage of
+// the provisioned filesystems in each storage pool, keyed on pool
+// name. Filesystems which have not reported usage are ignored.
+func (a *StorageAPI) poolUsage() (map[string]*params.StoragePoolUsage, error) {
+	stFile := a.storageAccess.FilesystemAccess()
+	if stFile == nil {
+		return nil, nil
+	}
+	filesystems, err := stFile.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	usage := make(map[string]*params.StoragePoolUsage)
+	for _, f := range filesystems {
+		info, err := f.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fsUsage, err := stFile.FilesystemUsage(f.FilesystemTag())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		poolUsage, ok := usage[info.Pool]
+		if !ok {
+			poolUsage = &params.StoragePoolUsage{}
+			usage[info.Pool] = poolUsage
+		}
+		poolUsage.Filesystems++
+		poolUsage.BytesUsed += fsUsage.BytesUsed
+		poolUsage.BytesFree += fsUsage.BytesFree
+	}
+	return usage, nil
+}
+
 func buildFilter(filter params.StoragePoolFilter) func(n, p string) bool {
 	providerSet := set.NewStrings(filter.Providers...)
 	nameSet := set.NewStrings(filter.Names...)
@@ -824,6 +925,10 @@ func createFilesystemDetails(
 	}
 	details.Status = common.EntityStatusFromState(aStatus)
 
+	if details.Usage, err = filesystemUsage(st.FilesystemAccess(), f.FilesystemTag()); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if storageTag, err := f.Storage(); err == nil {
 		storageInstance, err := st.StorageInstance(storageTag)
 		if err != nil {
@@ -870,8 +975,8 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 		return params.AddStorageResults{}, errors.Trace(err)
 	}
 
-	paramsToState := func(p params.StorageConstraints) state.StorageConstraints {
-		s := state.StorageConstraints{Pool: p.Pool}
+	paramsToState := func(p params.StorageConstraints, fromSnapshot string) state.StorageConstraints {
+		s := state.StorageConstraints{Pool: p.Pool, Snapshot: fromSnapshot}
 		if p.Size != nil {
 			s.Size = *p.Size
 		}
@@ -890,7 +995,7 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 		}
 
 		storageTags, err := a.storageAccess.AddStorageForUnit(
-			u, one.StorageName, paramsToState(one.Constraints),
+			u, one.StorageName, paramsToState(one.Constraints, one.FromSnapshot),
 		)
 		if err != nil {
 			result[i].Error = apiservererrors.ServerError(err)
@@ -1176,6 +1281,163 @@ func (a *StorageAPI) importFilesystem(
 	}, nil
 }
 
+// CreateSnapshots creates snapshots of the volumes backing the specified
+// storage instances. A "CHANGE" block can block this operation.
+func (a *StorageAPI) CreateSnapshots(args params.Entities) (params.VolumeSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.VolumeSnapshotResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		details, err := a.createSnapshot(arg.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.VolumeSnapshotResults{Results: results}, nil
+}
+
+func (a *StorageAPI) createSnapshot(tag string) (*params.VolumeSnapshotDetails, error) {
+	storageTag, err := names.ParseStorageTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, info, snapshotter, err := a.storageSnapshotter(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resourceTags := map[string]string{
+		tags.JujuModel:      a.backend.ModelTag().Id(),
+		tags.JujuController: a.backend.ControllerTag().Id(),
+	}
+	results, err := snapshotter.CreateVolumeSnapshots(a.callContext, []storage.VolumeSnapshotParams{{
+		Volume:       volume.VolumeTag(),
+		VolumeId:     info.VolumeId,
+		ResourceTags: resourceTags,
+	}})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating volume snapshot")
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotate(results[0].Error, "creating volume snapshot")
+	}
+	details := createVolumeSnapshotDetails(storageTag, volume.VolumeTag(), *results[0].Snapshot)
+	return &details, nil
+}
+
+// ListSnapshots returns the snapshots of the volumes backing the
+// specified storage instances.
+func (a *StorageAPI) ListSnapshots(args params.Entities) (params.VolumeSnapshotListResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotListResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.VolumeSnapshotListResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		details, err := a.listSnapshots(arg.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.VolumeSnapshotListResults{Results: results}, nil
+}
+
+func (a *StorageAPI) listSnapshots(tag string) ([]params.VolumeSnapshotDetails, error) {
+	storageTag, err := names.ParseStorageTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, info, snapshotter, err := a.storageSnapshotter(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots, err := snapshotter.ListVolumeSnapshots(a.callContext, []string{info.VolumeId})
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volume snapshots")
+	}
+	details := make([]params.VolumeSnapshotDetails, len(snapshots))
+	for i, snapshot := range snapshots {
+		details[i] = createVolumeSnapshotDetails(storageTag, volume.VolumeTag(), snapshot)
+	}
+	return details, nil
+}
+
+// storageSnapshotter returns the provisioned volume backing the specified
+// storage instance, along with a storage.VolumeSnapshotter for the volume's
+// storage provider.
+func (a *StorageAPI) storageSnapshotter(tag names.StorageTag) (
+	state.Volume, state.VolumeInfo, storage.VolumeSnapshotter, error,
+) {
+	volume, err := a.storageAccess.VolumeAccess().StorageInstanceVolume(tag)
+	if errors.IsNotFound(err) {
+		return nil, state.VolumeInfo{}, nil, errors.NotSupportedf(
+			"snapshotting storage %q without a volume", tag.Id(),
+		)
+	} else if err != nil {
+		return nil, state.VolumeInfo{}, nil, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, state.VolumeInfo{}, nil, errors.Trace(err)
+	}
+
+	providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, state.VolumeInfo{}, nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, state.VolumeInfo{}, nil, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		// Machine-scoped volume sources are only accessible from
+		// the machine, so they cannot be snapshotted from here.
+		return nil, state.VolumeInfo{}, nil, errors.NotSupportedf(
+			"snapshotting volume with machine-scoped storage provider %q", providerType,
+		)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, state.VolumeInfo{}, nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, state.VolumeInfo{}, nil, errors.NotSupportedf(
+			"snapshotting volume with storage provider %q", providerType,
+		)
+	}
+	return volume, info, snapshotter, nil
+}
+
+func createVolumeSnapshotDetails(
+	storageTag names.StorageTag,
+	volumeTag names.VolumeTag,
+	snapshot storage.VolumeSnapshot,
+) params.VolumeSnapshotDetails {
+	return params.VolumeSnapshotDetails{
+		SnapshotId: snapshot.SnapshotId,
+		StorageTag: storageTag.String(),
+		VolumeTag:  volumeTag.String(),
+		VolumeId:   snapshot.VolumeId,
+		Size:       snapshot.Size,
+		Created:    snapshot.Created,
+		Status:     snapshot.Status,
+	}
+}
+
 // RemovePool deletes the named pool
 func (a *StorageAPI) RemovePool(p params.StoragePoolDeleteArgs) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -1210,10 +1472,48 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 	return results, nil
 }
 
+// ResizeStorage grows the volumes or filesystems backing the specified
+// storage instances to the requested sizes. The resize is carried out
+// asynchronously by the storage provisioner. A "CHANGE" block can block
+// this operation.
+func (a *StorageAPI) ResizeStorage(args params.StorageResizeParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			result[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if arg.Size == 0 {
+			result[i].Error = apiservererrors.ServerError(errors.NotValidf("zero size"))
+			continue
+		}
+		err = a.storageAccess.ResizeStorageInstance(tag, arg.Size)
+		result[i].Error = apiservererrors.ServerError(err)
+	}
+	return params.ErrorResults{result}, nil
+}
+
 // Mask out old methods from the new API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v8 api version
+func (*StorageAPIv7) ResizeStorage(_, _ struct{}) {}
+
+// Added in v7 api version
+func (*StorageAPIv6) CreateSnapshots(_, _ struct{}) {}
+func (*StorageAPIv6) ListSnapshots(_, _ struct{})   {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -347,6 +348,38 @@ func (s *storageSuite) TestRemove(c *gc.C) {
 	s.stub.CheckCall(c, 4, releaseStorageInstanceCall, names.NewStorageTag("foo/1"), true, false)
 }
 
+func (s *storageSuite) TestResizeStorage(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cannot grow"))
+	results, err := s.api.ResizeStorage(params.StorageResizeParams{[]params.StorageResize{
+		{StorageTag: "storage-data-0", Size: 2048},
+		{StorageTag: "storage-data-1", Size: 4096},
+		{StorageTag: "storage-data-2", Size: 0},
+		{StorageTag: "volume-0", Size: 1024},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Message: "cannot grow"}},
+		{Error: &params.Error{Message: "zero size not valid"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		resizeStorageInstanceCall,
+		resizeStorageInstanceCall,
+	)
+	s.stub.CheckCall(c, 1, resizeStorageInstanceCall, names.NewStorageTag("data/0"), uint64(2048))
+	s.stub.CheckCall(c, 2, resizeStorageInstanceCall, names.NewStorageTag("data/1"), uint64(4096))
+}
+
+func (s *storageSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.StorageResizeParams{[]params.StorageResize{
+		{StorageTag: "storage-data-0", Size: 2048},
+	}})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
+
 func (s *storageSuite) TestDestroyV3(c *gc.C) {
 	results, err := s.apiv3.Destroy(params.Entities{[]params.Entity{
 		{Tag: "storage-foo-0"},
@@ -371,7 +404,11 @@ func (s *storageSuite) TestDestroyV3(c *gc.C) {
 
 func (s *storageSuite) TestDetachV5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
@@ -442,7 +479,11 @@ func (s *storageSuite) TestDetachV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -512,7 +553,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentv5(c *gc.C) {
 		)
 	}
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0"},
@@ -568,7 +613,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachNoAttachmentsStorageNotFoundv5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-foo-42"},
@@ -833,6 +882,154 @@ func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) setupVolumeSnapshotter(scope storage.Scope) volumeSnapshotter {
+	s.state.modelTag = coretesting.ModelTag
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "radiance",
+		Size:     1024,
+	}
+	volumeSource := volumeSnapshotter{&dummy.VolumeSource{}}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: scope,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	return volumeSource
+}
+
+func (s *storageSuite) TestCreateSnapshots(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+		{Tag: "storage-db-dir-1010"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Result: &params.VolumeSnapshotDetails{
+			SnapshotId: "snap-0",
+			StorageTag: "storage-data-0",
+			VolumeTag:  "volume-22",
+			VolumeId:   "vol-0",
+			Size:       1024,
+			Created:    snapshotCreated,
+			Status:     "completed",
+		},
+	}, {
+		Error: &params.Error{
+			Message: `snapshotting storage "db-dir/1010" without a volume not supported`,
+			Code:    "not supported",
+		},
+	}})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"CreateVolumeSnapshots", []interface{}{
+			s.callContext,
+			[]storage.VolumeSnapshotParams{{
+				Volume:   s.volumeTag,
+				VolumeId: "vol-0",
+				ResourceTags: map[string]string{
+					"juju-model-uuid":      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+				},
+			}},
+		}},
+	})
+}
+
+func (s *storageSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSuite) TestCreateSnapshotsNotProvisioned(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	s.volume.info = nil
+
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Error: &params.Error{
+			Message: `volume-22 not provisioned`,
+			Code:    "not provisioned",
+		},
+	}})
+	volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSuite) TestCreateSnapshotsMachineScoped(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeMachine)
+
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Error: &params.Error{
+			Message: `snapshotting volume with machine-scoped storage provider "radiance" not supported`,
+			Code:    "not supported",
+		},
+	}})
+	volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.setupVolumeSnapshotter(storage.ScopeEnviron)
+	volumeSource := &dummy.VolumeSource{}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Error: &params.Error{
+			Message: `snapshotting volume with storage provider "radiance" not supported`,
+			Code:    "not supported",
+		},
+	}})
+	volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSuite) TestListSnapshots(c *gc.C) {
+	volumeSource := s.setupVolumeSnapshotter(storage.ScopeEnviron)
+
+	results, err := s.api.ListSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotListResult{{
+		Result: []params.VolumeSnapshotDetails{{
+			SnapshotId: "snap-0",
+			StorageTag: "storage-data-0",
+			VolumeTag:  "volume-22",
+			VolumeId:   "vol-0",
+			Size:       1024,
+			Created:    snapshotCreated,
+			Status:     "completed",
+		}},
+	}, {
+		Error: &params.Error{Message: `"volume-0" is not a valid storage tag`},
+	}})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ListVolumeSnapshots", []interface{}{s.callContext, []string{"vol-0"}}},
+	})
+}
+
 func (s *storageSuite) TestListStorageAsAdminOnNotOwnedModel(c *gc.C) {
 	s.state.modelTag = names.NewModelTag("foo")
 	s.authorizer = apiservertesting.FakeAuthorizer{
@@ -903,3 +1100,50 @@ func (v volumeImporter) ImportVolume(ctx context.ProviderCallContext, providerId
 		HardwareId: "hw",
 	}, v.NextErr()
 }
+
+var snapshotCreated = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type volumeSnapshotter struct {
+	*dummy.VolumeSource
+}
+
+// CreateVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	v.MethodCall(v, "CreateVolumeSnapshots", ctx, params)
+	if err := v.NextErr(); err != nil {
+		return nil, err
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].Snapshot = &storage.VolumeSnapshot{
+			SnapshotId: fmt.Sprintf("snap-%d", i),
+			VolumeId:   p.VolumeId,
+			Size:       1024,
+			Created:    snapshotCreated,
+			Status:     "completed",
+		}
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) ListVolumeSnapshots(ctx context.ProviderCallContext, volumeIds []string) ([]storage.VolumeSnapshot, error) {
+	v.MethodCall(v, "ListVolumeSnapshots", ctx, volumeIds)
+	var snapshots []storage.VolumeSnapshot
+	for i, volumeId := range volumeIds {
+		snapshots = append(snapshots, storage.VolumeSnapshot{
+			SnapshotId: fmt.Sprintf("snap-%d", i),
+			VolumeId:   volumeId,
+			Size:       1024,
+			Created:    snapshotCreated,
+			Status:     "completed",
+		})
+	}
+	return snapshots, v.NextErr()
+}
+
+// DeleteVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	v.MethodCall(v, "DeleteVolumeSnapshots", ctx, snapshotIds)
+	return make([]error, len(snapshotIds)), v.NextErr()
+}
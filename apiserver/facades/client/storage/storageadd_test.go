@@ -50,6 +50,22 @@ func (s *storageAddSuite) TestStorageAddUnit(c *gc.C) {
 	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitCall})
 }
 
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshot(c *gc.C) {
+	s.storageAccessor.addStorageForUnit = func(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error) {
+		s.stub.AddCall(addStorageForUnitCall, u, name, cons)
+		return nil, nil
+	}
+	args := params.StorageAddParams{
+		UnitTag:      s.unitTag.String(),
+		StorageName:  "data",
+		FromSnapshot: "snap-0",
+	}
+	s.assertStorageAddedNoErrors(c, args)
+	s.stub.CheckCall(c, 1, addStorageForUnitCall, s.unitTag, "data", state.StorageConstraints{
+		Snapshot: "snap-0",
+	})
+}
+
 func (s *storageAddSuite) TestStorageAddUnitBlocked(c *gc.C) {
 	s.blockAllChanges(c, "TestStorageAddUnitBlocked")
 
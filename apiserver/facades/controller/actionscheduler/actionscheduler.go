@@ -0,0 +1,201 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides the facade used by the
+// actionscheduler worker to run actions on their schedules.
+package actionscheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API is the backend for the ActionScheduler facade.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+	clock     clock.Clock
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(stateShim{st: st, model: model}, ctx.Resources(), ctx.Auth(), clock.WallClock)
+}
+
+// NewAPI returns a new action scheduler API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+		clock:     clock,
+	}, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher which fires when any
+// action schedule in the model is added, changed or removed.
+func (api *API) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	w := api.backend.WatchActionSchedules()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: apiservererrors.ServerError(watcher.EnsureErr(w)),
+	}, nil
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (api *API) ActionSchedules() (params.ActionSchedulesResult, error) {
+	schedules, err := api.backend.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedulesResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	result := params.ActionSchedulesResult{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = common.ActionScheduleToParams(schedule)
+	}
+	return result, nil
+}
+
+// RunActionSchedules enqueues an operation running each schedule's
+// action on its target, and records the run in the schedule's
+// history. A run which can't be enqueued, because the target
+// application has no leader or units for example, is recorded
+// with the reason. An error satisfying params.IsCodeAlreadyExists
+// is returned for a run which has already been recorded.
+func (api *API) RunActionSchedules(args params.RunActionSchedulesArgs) (params.ActionScheduleRunResults, error) {
+	results := params.ActionScheduleRunResults{
+		Results: make([]params.ActionScheduleRunResult, len(args.Runs)),
+	}
+	for i, arg := range args.Runs {
+		run, err := api.runSchedule(arg.Name, arg.Scheduled)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result := common.ActionScheduleRunToParams(run)
+		results.Results[i].Run = &result
+	}
+	return results, nil
+}
+
+func (api *API) runSchedule(name string, scheduled time.Time) (state.ActionScheduleRun, error) {
+	schedule, err := api.backend.ActionSchedule(name)
+	if err != nil {
+		return state.ActionScheduleRun{}, errors.Trace(err)
+	}
+	if schedule.Paused() {
+		return state.ActionScheduleRun{}, errors.Errorf("action schedule %q is paused", name)
+	}
+	runs := schedule.Runs()
+	if len(runs) > 0 && !scheduled.After(runs[len(runs)-1].Scheduled) {
+		return state.ActionScheduleRun{}, errors.AlreadyExistsf(
+			"run of action schedule %q due at %s", name, scheduled.UTC().Format(time.RFC3339),
+		)
+	}
+
+	run := state.ActionScheduleRun{Scheduled: scheduled}
+	run.OperationID, err = api.enqueue(schedule)
+	if err != nil {
+		run.Error = err.Error()
+	}
+	run.Enqueued = api.clock.Now()
+	if err := schedule.RecordRun(run); err != nil {
+		return state.ActionScheduleRun{}, errors.Trace(err)
+	}
+	return run, nil
+}
+
+// enqueue enqueues an operation running the schedule's action on each
+// of the units it targets, and returns the operation's ID. If the
+// action can't be enqueued on some of the units, the operation's ID
+// is returned along with an error naming them.
+func (api *API) enqueue(schedule ActionSchedule) (string, error) {
+	units, err := api.targetUnits(schedule.Target())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	summary := fmt.Sprintf("%v run on %v by schedule %v", schedule.Action(), strings.Join(units, ","), schedule.Name())
+	operationID, err := api.backend.EnqueueOperation(summary)
+	if err != nil {
+		return "", errors.Annotate(err, "creating operation for actions")
+	}
+	var failed []string
+	for _, unitName := range units {
+		err := api.enqueueAction(operationID, unitName, schedule)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", unitName, err))
+		}
+	}
+	if len(failed) > 0 {
+		return operationID, errors.Errorf("cannot enqueue action on %s", strings.Join(failed, "; "))
+	}
+	return operationID, nil
+}
+
+func (api *API) enqueueAction(operationID, unitName string, schedule ActionSchedule) error {
+	unit, err := api.backend.Unit(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// AddAction inserts the action's defaults into
+	// the parameters, so each unit needs its own copy.
+	parameters := make(map[string]interface{})
+	for k, v := range schedule.Parameters() {
+		parameters[k] = v
+	}
+	_, err = unit.AddAction(operationID, schedule.Action(), parameters, nil, nil)
+	return errors.Trace(err)
+}
+
+// targetUnits returns the names of the units targeted
+// by an application, unit or application leader name.
+func (api *API) targetUnits(target string) ([]string, error) {
+	if app := strings.TrimSuffix(target, "/leader"); app != target {
+		leaders, err := api.backend.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		leader, ok := leaders[app]
+		if !ok {
+			return nil, errors.Errorf("could not determine leader for %q", app)
+		}
+		return []string{leader}, nil
+	}
+	if names.IsValidUnit(target) {
+		return []string{target}, nil
+	}
+	units, err := api.backend.ApplicationUnitNames(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("application %q has no units", target)
+	}
+	sort.Strings(units)
+	return units, nil
+}
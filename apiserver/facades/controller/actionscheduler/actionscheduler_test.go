@@ -0,0 +1,303 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulerSuite struct {
+	coretesting.BaseSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	resources  *common.Resources
+	backend    *mockBackend
+	clock      *testclock.Clock
+}
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+var (
+	created = time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	due     = time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC)
+)
+
+func (s *ActionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.backend = &mockBackend{
+		schedules: map[string]*mockSchedule{
+			"nightly-backup": {
+				name:       "nightly-backup",
+				target:     "mysql",
+				action:     "backup",
+				cron:       "0 3 * * *",
+				parameters: map[string]interface{}{"outfile": "out.tar.gz"},
+				created:    created,
+			},
+		},
+		units: map[string]*mockUnit{
+			"mysql/0": {},
+			"mysql/1": {},
+		},
+		appUnits: map[string][]string{
+			"mysql":     {"mysql/1", "mysql/0"},
+			"wordpress": nil,
+		},
+		leaders: map[string]string{"mysql": "mysql/1"},
+	}
+	s.clock = testclock.NewClock(due.Add(time.Second))
+}
+
+func (s *ActionSchedulerSuite) newAPI(c *gc.C) *actionscheduler.API {
+	api, err := actionscheduler.NewAPI(s.backend, s.resources, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *ActionSchedulerSuite) run(c *gc.C, name string, scheduled time.Time) params.ActionScheduleRunResult {
+	results, err := s.newAPI(c).RunActionSchedules(params.RunActionSchedulesArgs{
+		Runs: []params.RunActionScheduleArg{{Name: name, Scheduled: scheduled}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *ActionSchedulerSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := actionscheduler.NewAPI(s.backend, s.resources, s.authorizer, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.newAPI(c).WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *ActionSchedulerSuite) TestActionSchedules(c *gc.C) {
+	result, err := s.newAPI(c).ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedulesResult{
+		Schedules: []params.ActionSchedule{{
+			Name:       "nightly-backup",
+			Target:     "mysql",
+			Action:     "backup",
+			Cron:       "0 3 * * *",
+			Parameters: map[string]interface{}{"outfile": "out.tar.gz"},
+			Created:    created,
+		}},
+	})
+}
+
+func (s *ActionSchedulerSuite) TestRunApplication(c *gc.C) {
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Run, jc.DeepEquals, &params.ActionScheduleRun{
+		Scheduled:    due,
+		Enqueued:     due.Add(time.Second),
+		OperationTag: "operation-1",
+	})
+
+	c.Check(s.backend.summaries, jc.DeepEquals, []string{
+		"backup run on mysql/0,mysql/1 by schedule nightly-backup",
+	})
+	for _, name := range []string{"mysql/0", "mysql/1"} {
+		s.backend.units[name].CheckCall(c, 0, "AddAction",
+			"1", "backup", map[string]interface{}{"outfile": "out.tar.gz"})
+	}
+	c.Check(s.backend.schedules["nightly-backup"].runs, jc.DeepEquals, []state.ActionScheduleRun{{
+		Scheduled:   due,
+		Enqueued:    due.Add(time.Second),
+		OperationID: "1",
+	}})
+}
+
+func (s *ActionSchedulerSuite) TestRunLeader(c *gc.C) {
+	s.backend.schedules["nightly-backup"].target = "mysql/leader"
+
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(s.backend.summaries, jc.DeepEquals, []string{
+		"backup run on mysql/1 by schedule nightly-backup",
+	})
+	s.backend.units["mysql/0"].CheckNoCalls(c)
+	s.backend.units["mysql/1"].CheckCallNames(c, "AddAction")
+}
+
+func (s *ActionSchedulerSuite) TestRunUnit(c *gc.C) {
+	s.backend.schedules["nightly-backup"].target = "mysql/0"
+
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, gc.IsNil)
+	s.backend.units["mysql/0"].CheckCallNames(c, "AddAction")
+	s.backend.units["mysql/1"].CheckNoCalls(c)
+}
+
+func (s *ActionSchedulerSuite) TestRunNoLeaderRecorded(c *gc.C) {
+	s.backend.schedules["nightly-backup"].target = "wordpress/leader"
+
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Run.Error, gc.Equals, `could not determine leader for "wordpress"`)
+	c.Check(result.Run.OperationTag, gc.Equals, "")
+	c.Check(s.backend.schedules["nightly-backup"].runs, gc.HasLen, 1)
+}
+
+func (s *ActionSchedulerSuite) TestRunNoUnitsRecorded(c *gc.C) {
+	s.backend.schedules["nightly-backup"].target = "wordpress"
+
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Run.Error, gc.Equals, `application "wordpress" has no units`)
+}
+
+func (s *ActionSchedulerSuite) TestRunPartialFailure(c *gc.C) {
+	s.backend.units["mysql/0"].SetErrors(errors.New("boom"))
+
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Run.OperationTag, gc.Equals, "operation-1")
+	c.Check(result.Run.Error, gc.Equals, "cannot enqueue action on mysql/0: boom")
+	s.backend.units["mysql/1"].CheckCallNames(c, "AddAction")
+}
+
+func (s *ActionSchedulerSuite) TestRunPaused(c *gc.C) {
+	s.backend.schedules["nightly-backup"].paused = true
+
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, gc.ErrorMatches, `action schedule "nightly-backup" is paused`)
+	c.Check(s.backend.summaries, gc.HasLen, 0)
+}
+
+func (s *ActionSchedulerSuite) TestRunAlreadyRecorded(c *gc.C) {
+	s.backend.schedules["nightly-backup"].runs = []state.ActionScheduleRun{{
+		Scheduled: due,
+		Enqueued:  due,
+	}}
+
+	result := s.run(c, "nightly-backup", due)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeAlreadyExists)
+	c.Check(s.backend.summaries, gc.HasLen, 0)
+}
+
+func (s *ActionSchedulerSuite) TestRunNotFound(c *gc.C) {
+	result := s.run(c, "weekly", due)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+type mockBackend struct {
+	testing.Stub
+	schedules map[string]*mockSchedule
+	units     map[string]*mockUnit
+	appUnits  map[string][]string
+	leaders   map[string]string
+	summaries []string
+}
+
+func (m *mockBackend) WatchActionSchedules() state.NotifyWatcher {
+	m.MethodCall(m, "WatchActionSchedules")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (m *mockBackend) ActionSchedule(name string) (actionscheduler.ActionSchedule, error) {
+	m.MethodCall(m, "ActionSchedule", name)
+	schedule, ok := m.schedules[name]
+	if !ok {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	}
+	return schedule, nil
+}
+
+func (m *mockBackend) AllActionSchedules() ([]actionscheduler.ActionSchedule, error) {
+	m.MethodCall(m, "AllActionSchedules")
+	var result []actionscheduler.ActionSchedule
+	for _, schedule := range m.schedules {
+		result = append(result, schedule)
+	}
+	return result, m.NextErr()
+}
+
+func (m *mockBackend) EnqueueOperation(summary string) (string, error) {
+	m.MethodCall(m, "EnqueueOperation", summary)
+	m.summaries = append(m.summaries, summary)
+	return "1", m.NextErr()
+}
+
+func (m *mockBackend) ApplicationLeaders() (map[string]string, error) {
+	m.MethodCall(m, "ApplicationLeaders")
+	return m.leaders, m.NextErr()
+}
+
+func (m *mockBackend) ApplicationUnitNames(name string) ([]string, error) {
+	m.MethodCall(m, "ApplicationUnitNames", name)
+	units, ok := m.appUnits[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return append([]string(nil), units...), nil
+}
+
+func (m *mockBackend) Unit(name string) (actionscheduler.Unit, error) {
+	m.MethodCall(m, "Unit", name)
+	unit, ok := m.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return unit, nil
+}
+
+type mockSchedule struct {
+	name, target, action, cron string
+	parameters                 map[string]interface{}
+	paused                     bool
+	created                    time.Time
+	runs                       []state.ActionScheduleRun
+}
+
+func (m *mockSchedule) Name() string                       { return m.name }
+func (m *mockSchedule) Target() string                     { return m.target }
+func (m *mockSchedule) Action() string                     { return m.action }
+func (m *mockSchedule) Parameters() map[string]interface{} { return m.parameters }
+func (m *mockSchedule) Cron() string                       { return m.cron }
+func (m *mockSchedule) Paused() bool                       { return m.paused }
+func (m *mockSchedule) Owner() string                      { return "" }
+func (m *mockSchedule) Created() time.Time                 { return m.created }
+func (m *mockSchedule) Runs() []state.ActionScheduleRun    { return m.runs }
+
+func (m *mockSchedule) RecordRun(run state.ActionScheduleRun) error {
+	m.runs = append(m.runs, run)
+	return nil
+}
+
+type mockUnit struct {
+	testing.Stub
+}
+
+func (m *mockUnit) AddAction(operationID, name string, payload map[string]interface{}, parallel *bool, executionGroup *string) (state.Action, error) {
+	m.MethodCall(m, "AddAction", operationID, name, payload)
+	return nil, m.NextErr()
+}
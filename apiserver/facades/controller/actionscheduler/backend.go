@@ -0,0 +1,85 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// action scheduler facade.
+type Backend interface {
+	WatchActionSchedules() state.NotifyWatcher
+	ActionSchedule(name string) (ActionSchedule, error)
+	AllActionSchedules() ([]ActionSchedule, error)
+	EnqueueOperation(summary string) (string, error)
+	ApplicationLeaders() (map[string]string, error)
+
+	// ApplicationUnitNames returns the names of the
+	// named application's units.
+	ApplicationUnitNames(name string) ([]string, error)
+
+	// Unit returns the named unit, on which actions are enqueued.
+	Unit(name string) (Unit, error)
+}
+
+// ActionSchedule describes a schedule on which an action is
+// run, and records its runs.
+type ActionSchedule interface {
+	common.ActionSchedule
+	RecordRun(state.ActionScheduleRun) error
+}
+
+// Unit is a unit on which actions are enqueued.
+type Unit interface {
+	AddAction(operationID, name string, payload map[string]interface{}, parallel *bool, executionGroup *string) (state.Action, error)
+}
+
+type stateShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+func (s stateShim) WatchActionSchedules() state.NotifyWatcher {
+	return s.model.WatchActionSchedules()
+}
+
+func (s stateShim) ActionSchedule(name string) (ActionSchedule, error) {
+	return s.model.ActionSchedule(name)
+}
+
+func (s stateShim) AllActionSchedules() ([]ActionSchedule, error) {
+	schedules, err := s.model.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = schedule
+	}
+	return result, nil
+}
+
+func (s stateShim) EnqueueOperation(summary string) (string, error) {
+	return s.model.EnqueueOperation(summary)
+}
+
+func (s stateShim) ApplicationLeaders() (map[string]string, error) {
+	return s.st.ApplicationLeaders()
+}
+
+func (s stateShim) ApplicationUnitNames(name string) ([]string, error) {
+	app, err := s.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.UnitNames()
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	return s.st.Unit(name)
+}
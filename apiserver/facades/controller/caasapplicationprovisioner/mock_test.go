@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/controller"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/resources"
@@ -28,6 +29,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	coretesting "github.com/juju/juju/testing"
@@ -137,7 +139,9 @@ func (m *mockStoragePoolManager) Get(name string) (*storage.Config, error) {
 
 type mockModel struct {
 	testing.Stub
-	state *mockState
+	state         *mockState
+	config        map[string]interface{}
+	configWatcher *statetesting.MockNotifyWatcher
 }
 
 func (m *mockModel) UUID() string {
@@ -150,9 +154,17 @@ func (m *mockModel) ModelConfig() (*config.Config, error) {
 	attrs := coretesting.FakeConfig()
 	attrs["operator-storage"] = "k8s-storage"
 	attrs["agent-version"] = "2.6-beta3"
+	for k, v := range m.config {
+		attrs[k] = v
+	}
 	return config.New(config.UseDefaults, attrs)
 }
 
+func (m *mockModel) WatchForModelConfigChanges() state.NotifyWatcher {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	return m.configWatcher
+}
+
 func (m *mockModel) Containers(providerIds ...string) ([]state.CloudContainer, error) {
 	m.MethodCall(m, "Containers", providerIds)
 	if err := m.NextErr(); err != nil {
@@ -191,6 +203,13 @@ type mockApplication struct {
 	storageConstraints   map[string]state.StorageConstraints
 	deviceConstraints    map[string]state.DeviceConstraints
 	charmModifiedVersion int
+	config               coreapplication.ConfigAttributes
+	configWatcher        *statetesting.MockNotifyWatcher
+	scale                int
+	exposed              bool
+	exposedEndpoints     map[string]state.ExposedEndpoint
+	related              []string
+	relationsWatcher     *statetesting.MockNotifyWatcher
 }
 
 func (a *mockApplication) Tag() names.Tag {
@@ -291,6 +310,51 @@ func (a *mockApplication) CharmURL() (curl *charm.URL, force bool) {
 	return a.charm.URL(), false
 }
 
+func (a *mockApplication) ApplicationConfig() (coreapplication.ConfigAttributes, error) {
+	a.MethodCall(a, "ApplicationConfig")
+	return a.config, a.NextErr()
+}
+
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
+func (a *mockApplication) GetScale() int {
+	a.MethodCall(a, "GetScale")
+	return a.scale
+}
+
+func (a *mockApplication) SetScale(scale int, generation int64, force bool) error {
+	a.MethodCall(a, "SetScale", scale, generation, force)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetAutoscalerStatus(status *state.AutoscalerStatus) error {
+	a.MethodCall(a, "SetAutoscalerStatus", status)
+	return a.NextErr()
+}
+
+func (a *mockApplication) IsExposed() bool {
+	a.MethodCall(a, "IsExposed")
+	return a.exposed
+}
+
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	a.MethodCall(a, "ExposedEndpoints")
+	return a.exposedEndpoints
+}
+
+func (a *mockApplication) RelatedApplications() ([]string, error) {
+	a.MethodCall(a, "RelatedApplications")
+	return a.related, a.NextErr()
+}
+
+func (a *mockApplication) WatchRelationChanges() state.NotifyWatcher {
+	a.MethodCall(a, "WatchRelationChanges")
+	return a.relationsWatcher
+}
+
 type mockCharm struct {
 	meta *charm.Meta
 	url  *charm.URL
@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/cloudconfig/podcfg"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
@@ -38,6 +39,7 @@ import (
 	"github.com/juju/juju/state"
 	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/version"
@@ -66,6 +68,42 @@ type API struct {
 	clock              clock.Clock
 }
 
+// APIGroupV2 provides version 2 of the CAASApplicationProvisioner API.
+type APIGroupV2 struct {
+	*APIGroup
+}
+
+// APIGroupV1 provides version 1 of the CAASApplicationProvisioner API.
+type APIGroupV1 struct {
+	*APIGroupV2
+}
+
+// NewStateCAASApplicationProvisionerAPIV2 provides the signature required
+// for version 2 facade registration.
+func NewStateCAASApplicationProvisionerAPIV2(ctx facade.Context) (*APIGroupV2, error) {
+	api, err := NewStateCAASApplicationProvisionerAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIGroupV2{api}, nil
+}
+
+// NewStateCAASApplicationProvisionerAPIV1 provides the signature required
+// for version 1 facade registration.
+func NewStateCAASApplicationProvisionerAPIV1(ctx facade.Context) (*APIGroupV1, error) {
+	api, err := NewStateCAASApplicationProvisionerAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIGroupV1{api}, nil
+}
+
+// WatchApplicationConfig is not available in versions prior to 2.
+func (*APIGroupV1) WatchApplicationConfig(_, _ struct{}) {}
+
+// WatchNetworkPolicy is not available in versions prior to 3.
+func (*APIGroupV2) WatchNetworkPolicy(_, _ struct{}) {}
+
 // NewStateCAASApplicationProvisionerAPI provides the signature required for facade registration.
 func NewStateCAASApplicationProvisionerAPI(ctx facade.Context) (*APIGroup, error) {
 	authorizer := ctx.Auth()
@@ -230,6 +268,18 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 			}
 		}
 	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	autoscaling, err := provider.AutoscalingPolicy(appConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid autoscaling policy")
+	}
+	networkPolicy, err := a.networkPolicy(app, modelConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	caCert, _ := cfg.CACert()
 	charmURL, _ := app.CharmURL()
 	return &params.CAASApplicationProvisioningInfo{
@@ -245,9 +295,126 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 		ImageRepo:            cfg.CAASImageRepo(),
 		CharmModifiedVersion: app.CharmModifiedVersion(),
 		CharmURL:             charmURL.String(),
+		Scale:                app.GetScale(),
+		Autoscaling:          autoscalingParams(autoscaling),
+		NetworkPolicy:        networkPolicy,
+	}, nil
+}
+
+// networkPolicy returns the sources allowed to connect to the units of
+// the application, or nil if the model does not deny ingress by default.
+func (a *API) networkPolicy(app Application, modelConfig *config.Config) (*params.KubernetesNetworkPolicy, error) {
+	if !modelConfig.DefaultDenyIngress() {
+		return nil, nil
+	}
+	related, err := app.RelatedApplications()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting related applications")
+	}
+	cidrs := set.NewStrings()
+	if app.IsExposed() {
+		exposed := app.ExposedEndpoints()
+		if len(exposed) == 0 {
+			// Applications exposed before expose settings were introduced
+			// are reachable from anywhere.
+			cidrs.Add(firewall.AllNetworksIPV4CIDR)
+			cidrs.Add(firewall.AllNetworksIPV6CIDR)
+		}
+		for _, ep := range exposed {
+			cidrs = cidrs.Union(set.NewStrings(ep.ExposeToCIDRs...))
+		}
+	}
+	return &params.KubernetesNetworkPolicy{
+		Applications: related,
+		CIDRs:        cidrs.SortedValues(),
 	}, nil
 }
 
+func autoscalingParams(policy *caas.AutoscalingPolicy) *params.KubernetesAutoscalingPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &params.KubernetesAutoscalingPolicy{
+		MinReplicas:             policy.MinReplicas,
+		MaxReplicas:             policy.MaxReplicas,
+		TargetCPUUtilization:    policy.TargetCPUUtilization,
+		TargetMemoryUtilization: policy.TargetMemoryUtilization,
+		Metrics:                 policy.Metrics,
+	}
+}
+
+// WatchApplicationConfig starts a NotifyWatcher to watch changes to the
+// application config of each given application.
+func (a *API) WatchApplicationConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := a.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (a *API) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := a.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return a.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// WatchNetworkPolicy starts a NotifyWatcher to watch changes that affect
+// the network policy of each given application: its relations and the
+// model config. Changes to its expose settings are reported by the
+// application watcher.
+func (a *API) WatchNetworkPolicy(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := a.watchNetworkPolicy(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (a *API) watchNetworkPolicy(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := a.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	model, err := a.state.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := common.NewMultiNotifyWatcher(app.WatchRelationChanges(), model.WatchForModelConfigChanges())
+	if _, ok := <-w.Changes(); ok {
+		return a.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
 // SetOperatorStatus sets the status of each given entity.
 func (a *API) SetOperatorStatus(args params.SetStatus) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -606,6 +773,7 @@ func (a *API) applicationFilesystemParams(
 				ReadOnly:   charmStorage.ReadOnly,
 			}
 			fsParams.Attachment = &filesystemAttachmentParams
+			fsParams.Shared = charmStorage.Shared
 			allFilesystemParams = append(allFilesystemParams, *fsParams)
 		}
 	}
@@ -773,11 +941,20 @@ func (a *API) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (pa
 				continue
 			}
 		}
+		// A forbidden scale change comes from a stale autoscaler report,
+		// which must not hold up the update of the units.
+		scaleErr := a.updateAutoscaling(app, appUpdate.Scale, appUpdate.Generation, appUpdate.Autoscaler)
+		if scaleErr != nil && !errors.IsForbidden(scaleErr) {
+			result.Results[i].Error = apiservererrors.ServerError(scaleErr)
+			continue
+		}
 		appUnitInfo, err := a.updateUnitsFromCloud(app, appUpdate.Units)
 		if err != nil {
 			// Mask any not found errors as the worker (caller) treats them specially
 			// and they are not relevant here.
 			result.Results[i].Error = apiservererrors.ServerError(errors.Mask(err))
+		} else if scaleErr != nil {
+			result.Results[i].Error = apiservererrors.ServerError(scaleErr)
 		}
 
 		// Errors from SetScale will also include unit info.
@@ -790,6 +967,41 @@ func (a *API) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (pa
 	return result, nil
 }
 
+// updateAutoscaling records the observed state of the application's
+// autoscaler. The autoscaler is authoritative for the scale of an
+// autoscaled application, so the scale it chose is recorded as the
+// application's desired scale. Like any scale reported by the cluster,
+// it does not override a scale set by the user that is yet to be
+// applied, nor a scale reported at a later generation.
+func (a *API) updateAutoscaling(app Application, scale *int, generation *int64, autoscaler *params.AutoscalerStatus) error {
+	var autoscalerStatus *state.AutoscalerStatus
+	if autoscaler != nil {
+		autoscalerStatus = &state.AutoscalerStatus{
+			MinReplicas:     autoscaler.MinReplicas,
+			MaxReplicas:     autoscaler.MaxReplicas,
+			CurrentReplicas: autoscaler.CurrentReplicas,
+			DesiredReplicas: autoscaler.DesiredReplicas,
+			Message:         autoscaler.Message,
+		}
+	}
+	if err := app.SetAutoscalerStatus(autoscalerStatus); err != nil {
+		return errors.Trace(err)
+	}
+	if autoscaler == nil || scale == nil {
+		return nil
+	}
+	if current := app.GetScale(); *scale != current {
+		logger.Debugf("autoscaler changed scale of %q from %d to %d", app.Name(), current, *scale)
+	}
+	// The scale is recorded even when unchanged, so that the generation
+	// is tracked and a user's scale is known to have been applied.
+	var gen int64
+	if generation != nil {
+		gen = *generation
+	}
+	return errors.Trace(app.SetScale(*scale, gen, false))
+}
+
 type filesystemInfo struct {
 	unitTag      names.UnitTag
 	providerId   string
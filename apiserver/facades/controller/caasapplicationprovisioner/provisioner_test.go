@@ -22,9 +22,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -114,6 +116,168 @@ func (s *CAASApplicationProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoAutoscaling(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		scale: 3,
+		config: coreapplication.ConfigAttributes{
+			"kubernetes-autoscaling-min-units":  2,
+			"kubernetes-autoscaling-max-units":  5,
+			"kubernetes-autoscaling-target-cpu": 70,
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Scale, gc.Equals, 3)
+	c.Assert(result.Results[0].Autoscaling, jc.DeepEquals, &params.KubernetesAutoscalingPolicy{
+		MinReplicas:          2,
+		MaxReplicas:          5,
+		TargetCPUUtilization: 70,
+	})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoInvalidAutoscaling(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url:  &charm.URL{Schema: "cs", Name: "gitlab", Revision: -1},
+		},
+		config: coreapplication.ConfigAttributes{
+			"kubernetes-autoscaling-min-units": 6,
+			"kubernetes-autoscaling-max-units": 5,
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "invalid autoscaling policy: .* must be between 0 and .*")
+}
+
+func (s *CAASApplicationProvisionerSuite) TestWatchApplicationConfig(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.st.app = &mockApplication{
+		configWatcher: statetesting.NewMockNotifyWatcher(changes),
+	}
+	results, err := s.api.WatchApplicationConfig(params.Entities{
+		Entities: []params.Entity{{"application-gitlab"}, {"application-other"}, {"unit-gitlab-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+	c.Assert(s.resources.Get("1"), gc.Equals, s.st.app.configWatcher)
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoNetworkPolicy(c *gc.C) {
+	s.st.model.config = map[string]interface{}{"default-deny-ingress": true}
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		related: []string{"gitlab", "postgresql"},
+		exposed: true,
+		exposedEndpoints: map[string]state.ExposedEndpoint{
+			"":     {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+			"http": {ExposeToCIDRs: []string{"192.168.0.0/16", "10.0.0.0/8"}},
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{Tag: "application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].NetworkPolicy, jc.DeepEquals, &params.KubernetesNetworkPolicy{
+		Applications: []string{"gitlab", "postgresql"},
+		CIDRs:        []string{"10.0.0.0/8", "192.168.0.0/16"},
+	})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoNetworkPolicyExposedWithoutSettings(c *gc.C) {
+	s.st.model.config = map[string]interface{}{"default-deny-ingress": true}
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		exposed: true,
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{Tag: "application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].NetworkPolicy, jc.DeepEquals, &params.KubernetesNetworkPolicy{
+		CIDRs: []string{"0.0.0.0/0", "::/0"},
+	})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoNetworkPolicyDisabled(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		related: []string{"postgresql"},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{Tag: "application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].NetworkPolicy, gc.IsNil)
+	for _, call := range s.st.app.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "RelatedApplications")
+	}
+}
+
+func (s *CAASApplicationProvisionerSuite) TestWatchNetworkPolicy(c *gc.C) {
+	relationChanges := make(chan struct{}, 1)
+	relationChanges <- struct{}{}
+	configChanges := make(chan struct{}, 1)
+	configChanges <- struct{}{}
+	s.st.model.configWatcher = statetesting.NewMockNotifyWatcher(configChanges)
+	s.st.app = &mockApplication{
+		relationsWatcher: statetesting.NewMockNotifyWatcher(relationChanges),
+	}
+	results, err := s.api.WatchNetworkPolicy(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}, {Tag: "unit-gitlab-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+	s.st.app.CheckCallNames(c, "WatchRelationChanges")
+	s.st.model.CheckCallNames(c, "WatchForModelConfigChanges")
+}
+
 func (s *CAASApplicationProvisionerSuite) TestSetOperatorStatus(c *gc.C) {
 	s.st.app = &mockApplication{
 		life: state.Alive,
@@ -636,7 +800,7 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithStorage
 			},
 		},
 	})
-	s.st.app.CheckCallNames(c, "Life", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCallNames(c, "Life", "SetAutoscalerStatus", "AllUnits", "UpdateUnits", "Name")
 	s.st.app.units[0].CheckCallNames(c, "UpdateOperation")
 	s.st.app.units[0].CheckCall(c, 0, "UpdateOperation", state.UnitUpdateProperties{
 		ProviderId: strPtr("gitlab-0"),
@@ -797,7 +961,7 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithoutStor
 			},
 		},
 	})
-	s.st.app.CheckCallNames(c, "Life", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCallNames(c, "Life", "SetAutoscalerStatus", "AllUnits", "UpdateUnits", "Name")
 	s.st.app.units[0].CheckCallNames(c, "UpdateOperation")
 	s.st.app.units[0].CheckCall(c, 0, "UpdateOperation", state.UnitUpdateProperties{
 		ProviderId: strPtr("gitlab-0"),
@@ -823,3 +987,88 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithoutStor
 func strPtr(s string) *string {
 	return &s
 }
+
+func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsAutoscaled(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:   names.NewApplicationTag("gitlab"),
+		life:  state.Alive,
+		scale: 2,
+	}
+	scale := 4
+	generation := int64(7)
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{{
+			ApplicationTag: "application-gitlab",
+			Scale:          &scale,
+			Generation:     &generation,
+			Autoscaler: &params.AutoscalerStatus{
+				MinReplicas:     2,
+				MaxReplicas:     5,
+				CurrentReplicas: 2,
+				DesiredReplicas: 4,
+			},
+		}},
+	}
+	results, err := s.api.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.st.app.CheckCallNames(c, "Life", "SetAutoscalerStatus", "GetScale", "Name", "SetScale", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCall(c, 1, "SetAutoscalerStatus", &state.AutoscalerStatus{
+		MinReplicas:     2,
+		MaxReplicas:     5,
+		CurrentReplicas: 2,
+		DesiredReplicas: 4,
+	})
+	s.st.app.CheckCall(c, 4, "SetScale", 4, int64(7), false)
+}
+
+func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsStaleAutoscalerScale(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:   names.NewApplicationTag("gitlab"),
+		life:  state.Alive,
+		scale: 2,
+	}
+	// The user's scale has not been applied yet, so the autoscaler's
+	// scale is rejected; the units are updated regardless.
+	s.st.app.SetErrors(nil, errors.Forbiddenf("SetScale(4) without force while desired scale 2 is not applied yet"))
+	scale := 4
+	generation := int64(7)
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{{
+			ApplicationTag: "application-gitlab",
+			Scale:          &scale,
+			Generation:     &generation,
+			Autoscaler: &params.AutoscalerStatus{
+				MinReplicas:     2,
+				MaxReplicas:     5,
+				CurrentReplicas: 2,
+				DesiredReplicas: 4,
+			},
+		}},
+	}
+	results, err := s.api.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeForbidden)
+	s.st.app.CheckCallNames(c, "Life", "SetAutoscalerStatus", "GetScale", "Name", "SetScale", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCall(c, 4, "SetScale", 4, int64(7), false)
+}
+
+func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsNotAutoscaledIgnoresScale(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:   names.NewApplicationTag("gitlab"),
+		life:  state.Alive,
+		scale: 2,
+	}
+	scale := 4
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{{
+			ApplicationTag: "application-gitlab",
+			Scale:          &scale,
+		}},
+	}
+	results, err := s.api.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.st.app.CheckCallNames(c, "Life", "SetAutoscalerStatus", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCall(c, 1, "SetAutoscalerStatus", (*state.AutoscalerStatus)(nil))
+}
@@ -8,9 +8,12 @@ import (
 	"time"
 
 	"github.com/juju/charm/v9"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -42,6 +45,7 @@ type Model interface {
 	UUID() string
 	ModelConfig() (*config.Config, error)
 	Containers(providerIds ...string) ([]state.CloudContainer, error)
+	WatchForModelConfigChanges() state.NotifyWatcher
 }
 
 type Application interface {
@@ -58,6 +62,15 @@ type Application interface {
 	SetStatus(statusInfo status.StatusInfo) error
 	CharmModifiedVersion() int
 	CharmURL() (curl *charm.URL, force bool)
+	ApplicationConfig() (application.ConfigAttributes, error)
+	WatchApplicationConfig() state.NotifyWatcher
+	GetScale() int
+	SetScale(scale int, generation int64, force bool) error
+	SetAutoscalerStatus(status *state.AutoscalerStatus) error
+	IsExposed() bool
+	ExposedEndpoints() map[string]state.ExposedEndpoint
+	RelatedApplications() ([]string, error)
+	WatchRelationChanges() state.NotifyWatcher
 }
 
 type Charm interface {
@@ -94,7 +107,7 @@ func (s stateShim) Application(name string) (Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &applicationShim{app}, nil
+	return &applicationShim{Application: app, st: s.State}, nil
 }
 
 func (s stateShim) Resources() (Resources, error) {
@@ -103,6 +116,7 @@ func (s stateShim) Resources() (Resources, error) {
 
 type applicationShim struct {
 	*state.Application
+	st *state.State
 }
 
 func (a *applicationShim) Charm() (Charm, bool, error) {
@@ -121,6 +135,31 @@ func (a *applicationShim) AllUnits() ([]Unit, error) {
 	return res, nil
 }
 
+// RelatedApplications returns the names of the applications in the model
+// that have a relation with the application, including the application
+// itself if it has a peer relation. Remote applications are not included.
+func (a *applicationShim) RelatedApplications() ([]string, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		for _, ep := range rel.Endpoints() {
+			if ep.ApplicationName == a.Name() && ep.Role != charm.RolePeer {
+				continue
+			}
+			if _, err := a.st.RemoteApplication(ep.ApplicationName); err == nil {
+				continue
+			} else if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			related.Add(ep.ApplicationName)
+		}
+	}
+	return related.SortedValues(), nil
+}
+
 // StorageBackend provides the subset of backend storage
 // functionality required by the CAAS app provisioner facade.
 type StorageBackend interface {
@@ -4,6 +4,8 @@
 package caasfirewaller
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -23,6 +25,24 @@ type Facade struct {
 	*common.ApplicationWatcherFacade
 }
 
+// FacadeV1 provides version 1 of the CAASFirewaller API.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeLegacyV1 provides the signature required for
+// version 1 facade registration.
+func NewStateFacadeLegacyV1(ctx facade.Context) (*FacadeV1, error) {
+	api, err := NewStateFacadeLegacy(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{api}, nil
+}
+
+// ExposedEndpoints isn't on the v1 API.
+func (*FacadeV1) ExposedEndpoints(_, _ struct{}) {}
+
 // NewStateFacadeLegacy provides the signature required for facade registration.
 func NewStateFacadeLegacy(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -92,6 +112,40 @@ func (f *Facade) isExposed(backend CAASFirewallerState, tagString string) (bool,
 	return app.IsExposed(), nil
 }
 
+// ExposedEndpoints returns the names of the exposed endpoints of the
+// specified applications. An empty name means all endpoints are exposed.
+func (f *Facade) ExposedEndpoints(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		endpoints, err := f.exposedEndpoints(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = endpoints
+	}
+	return results, nil
+}
+
+func (f *Facade) exposedEndpoints(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var endpoints []string
+	for name := range app.ExposedEndpoints() {
+		endpoints = append(endpoints, name)
+	}
+	sort.Strings(endpoints)
+	return endpoints, nil
+}
+
 // ApplicationsConfig returns the config for the specified applications.
 func (f *Facade) ApplicationsConfig(args params.Entities) (params.ApplicationGetConfigResults, error) {
 	results := params.ApplicationGetConfigResults{
@@ -125,6 +179,24 @@ type FacadeEmbedded struct {
 	accessModel common.GetAuthFunc
 }
 
+// FacadeEmbeddedV1 provides version 1 of the CAASFirewallerEmbedded API.
+type FacadeEmbeddedV1 struct {
+	*FacadeEmbedded
+}
+
+// NewStateFacadeEmbeddedV1 provides the signature required for
+// version 1 facade registration.
+func NewStateFacadeEmbeddedV1(ctx facade.Context) (*FacadeEmbeddedV1, error) {
+	api, err := NewStateFacadeEmbedded(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeEmbeddedV1{api}, nil
+}
+
+// ExposedEndpoints isn't on the v1 API.
+func (*FacadeEmbeddedV1) ExposedEndpoints(_, _ struct{}) {}
+
 // NewStateFacadeEmbedded provides the signature required for facade registration.
 func NewStateFacadeEmbedded(ctx facade.Context) (*FacadeEmbedded, error) {
 	authorizer := ctx.Auth()
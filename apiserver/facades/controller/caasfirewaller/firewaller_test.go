@@ -142,6 +142,7 @@ func (s *firewallerEmbeddedSuite) TestApplicationCharmURLs(c *gc.C) {
 
 type facadeCommon interface {
 	IsExposed(args params.Entities) (params.BoolResults, error)
+	ExposedEndpoints(args params.Entities) (params.StringsResults, error)
 	ApplicationsConfig(args params.Entities) (params.ApplicationGetConfigResults, error)
 	WatchApplications() (params.StringsWatchResult, error)
 	Life(args params.Entities) (params.LifeResults, error)
@@ -251,6 +252,29 @@ func (s *firewallerBaseSuite) TestIsExposed(c *gc.C) {
 	})
 }
 
+func (s *firewallerBaseSuite) TestExposedEndpoints(c *gc.C) {
+	s.st.application.exposedEndpoints = map[string]state.ExposedEndpoint{
+		"website": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		"api":     {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	}
+	results, err := s.facade.ExposedEndpoints(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"api", "website"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
 func (s *firewallerBaseSuite) TestLife(c *gc.C) {
 	results, err := s.facade.Life(params.Entities{
 		Entities: []params.Entity{
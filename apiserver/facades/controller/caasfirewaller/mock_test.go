@@ -67,9 +67,10 @@ func (st *mockState) Model() (*state.Model, error) {
 
 type mockApplication struct {
 	testing.Stub
-	life    state.Life
-	exposed bool
-	watcher state.NotifyWatcher
+	life             state.Life
+	exposed          bool
+	exposedEndpoints map[string]state.ExposedEndpoint
+	watcher          state.NotifyWatcher
 
 	charm mockAppWatcherCharm
 }
@@ -88,6 +89,11 @@ func (a *mockApplication) IsExposed() bool {
 	return a.exposed
 }
 
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	a.MethodCall(a, "ExposedEndpoints")
+	return a.exposedEndpoints
+}
+
 func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig")
 	return application.ConfigAttributes{"foo": "bar"}, a.NextErr()
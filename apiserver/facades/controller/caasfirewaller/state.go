@@ -25,6 +25,7 @@ type CAASFirewallerState interface {
 // required by the CAAS operator facade.
 type Application interface {
 	IsExposed() bool
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
 	Charm() (ch Charm, force bool, err error)
@@ -453,6 +453,7 @@ func (f *Facade) applicationFilesystemParams(
 				ReadOnly:   charmStorage.ReadOnly,
 			}
 			fsParams.Attachment = &filesystemAttachmentParams
+			fsParams.Shared = charmStorage.Shared
 			allFilesystemParams = append(allFilesystemParams, *fsParams)
 		}
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/networkingcommon"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -192,7 +193,7 @@ func maybeUpdateMachineProviderAddresses(m StateMachine, newSpaceAddrs network.S
 }
 
 func (a *InstancePollerAPI) mergeLinkLayer(m StateMachine, devs network.InterfaceInfos) error {
-	return errors.Trace(a.st.ApplyOperation(newMergeMachineLinkLayerOp(m, devs)))
+	return errors.Trace(a.st.ApplyOperation(networkingcommon.NewMergeMachineLinkLayerOp(m, devs)))
 }
 
 // mapNetworkConfigsToProviderAddresses iterates the list of incoming network
@@ -401,26 +402,58 @@ func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params
 	return result, nil
 }
 
+// SetAvailabilityZones records the availability zone that each given
+// entity's instance is running in. Only machine tags are accepted.
+func (a *InstancePollerAPI) SetAvailabilityZones(args params.SetAvailabilityZones) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Zones)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Zones {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			err = machine.SetAvailabilityZone(arg.Value)
+		}
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// InstancePollerAPIV4 implements the V4 API used by the instance poller
+// worker. Compared to V5, it lacks the SetAvailabilityZones method.
+type InstancePollerAPIV4 struct {
+	*InstancePollerAPI
+}
+
 // InstancePollerAPIV3 implements the V3 API used by the instance poller
 // worker. Compared to V4, it lacks the SetProviderNetworkConfig method.
 type InstancePollerAPIV3 struct {
-	*InstancePollerAPI
+	*InstancePollerAPIV4
 }
 
-// NewFacadeV3 creates a new instance of the V3 InstancePoller API.
-func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*InstancePollerAPIV3, error) {
-	m, err := st.Model()
+// NewFacadeV4 creates a new instance of the V4 InstancePoller API.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*InstancePollerAPIV4, error) {
+	api, err := NewFacade(st, resources, authorizer)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, err
 	}
+	return &InstancePollerAPIV4{api}, nil
+}
 
-	api, err := NewInstancePollerAPI(st, m, resources, authorizer, clock.WallClock)
+// NewFacadeV3 creates a new instance of the V3 InstancePoller API.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*InstancePollerAPIV3, error) {
+	api, err := NewFacadeV4(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
-
 	return &InstancePollerAPIV3{api}, nil
 }
 
+// SetAvailabilityZones is not available in V4.
+func (*InstancePollerAPIV4) SetAvailabilityZones(_, _ struct{}) {}
+
 // SetProviderNetworkConfig is not available in V3.
 func (*InstancePollerAPIV3) SetProviderNetworkConfig(_, _ struct{}) {}
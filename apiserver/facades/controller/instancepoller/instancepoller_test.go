@@ -745,6 +745,64 @@ func (s *InstancePollerSuite) TestAreManuallyProvisionedFailure(c *gc.C) {
 	s.st.CheckMachineCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestSetAvailabilityZonesSuccess(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{id: "1", availabilityZone: "zone-a"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", availabilityZone: "zone-a"})
+
+	result, err := s.api.SetAvailabilityZones(params.SetAvailabilityZones{
+		Zones: []params.EntityString{
+			{Tag: "machine-1", Value: "zone-b"},
+			{Tag: "machine-2", Value: "zone-a"},
+			{Tag: "machine-42", Value: "zone-b"},
+			{Tag: "application-unknown", Value: "zone-b"},
+			{Tag: "invalid-tag", Value: "zone-b"},
+			{Tag: "unit-missing-1", Value: "zone-b"},
+			{Tag: "", Value: "zone-b"},
+			{Tag: "42", Value: "zone-b"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, s.mixedErrorResults)
+
+	s.st.CheckMachineCall(c, 0, "1")
+	s.st.CheckCall(c, 1, "SetAvailabilityZone", "zone-b")
+	s.st.CheckMachineCall(c, 2, "2")
+	s.st.CheckCall(c, 3, "SetAvailabilityZone", "zone-a")
+	s.st.CheckMachineCall(c, 4, "42")
+}
+
+func (s *InstancePollerSuite) TestSetAvailabilityZonesFailure(c *gc.C) {
+	s.st.SetErrors(
+		errors.New("pow!"),                   // m1 := FindEntity("1")
+		nil,                                  // m2 := FindEntity("2")
+		errors.New("FAIL"),                   // m2.SetAvailabilityZone()
+		errors.NotProvisionedf("machine 42"), // FindEntity("3") (ensure wrapping is preserved)
+	)
+	s.st.SetMachineInfo(c, machineInfo{id: "1"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2"})
+
+	result, err := s.api.SetAvailabilityZones(params.SetAvailabilityZones{
+		Zones: []params.EntityString{
+			{Tag: "machine-1", Value: "zone-b"},
+			{Tag: "machine-2", Value: "zone-b"},
+			{Tag: "machine-3", Value: "zone-b"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ServerError("pow!")},
+			{Error: apiservertesting.ServerError("FAIL")},
+			{Error: apiservertesting.NotProvisionedError("42")},
+		}},
+	)
+
+	s.st.CheckMachineCall(c, 0, "1")
+	s.st.CheckMachineCall(c, 1, "2")
+	s.st.CheckCall(c, 2, "SetAvailabilityZone", "zone-b")
+	s.st.CheckMachineCall(c, 3, "3")
+}
+
 func (s *InstancePollerSuite) TestSetProviderNetworkConfigSuccess(c *gc.C) {
 	s.setDefaultSpaceInfo()
 
@@ -271,6 +271,7 @@ type machineInfo struct {
 	providerAddresses []network.SpaceAddress
 	life              state.Life
 	isManual          bool
+	availabilityZone  string
 
 	linkLayerDevices []networkingcommon.LinkLayerDevice
 	addresses        []networkingcommon.LinkLayerAddress
@@ -384,6 +385,19 @@ func (m *mockMachine) SetInstanceStatus(instanceStatus status.StatusInfo) error
 	return nil
 }
 
+// SetAvailabilityZone implements StateMachine.
+func (m *mockMachine) SetAvailabilityZone(zone string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "SetAvailabilityZone", zone)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.availabilityZone = zone
+	return nil
+}
+
 // Life implements StateMachine.
 func (m *mockMachine) Life() state.Life {
 	m.mu.Lock()
@@ -27,6 +27,7 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+	SetAvailabilityZone(string) error
 }
 
 type StateInterface interface {
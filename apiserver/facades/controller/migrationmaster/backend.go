@@ -27,6 +27,7 @@ type Backend interface {
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	ExportSecrets() ([]byte, error)
 }
 
 // OfferConnection describes methods offer connection methods
@@ -242,6 +242,10 @@ func (api *API) Export() (params.SerializedModel, error) {
 	if model.Type() == string(coremodel.IAAS) {
 		serialized.Tools = getUsedTools(model)
 	}
+	serialized.Secrets, err = api.backend.ExportSecrets()
+	if err != nil {
+		return serialized, errors.Annotate(err, "exporting secrets")
+	}
 	return serialized, nil
 }
 
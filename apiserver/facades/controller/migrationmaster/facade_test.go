@@ -338,9 +338,11 @@ func (s *Suite) assertExport(c *gc.C, modelType string) {
 	unitRev := unitRes.Revision()
 
 	s.backend.EXPECT().Export().Return(s.model, nil)
+	s.backend.EXPECT().ExportSecrets().Return([]byte("secrets"), nil)
 
 	serialized, err := s.mustMakeAPI(c).Export()
 	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Secrets, gc.DeepEquals, []byte("secrets"))
 
 	// We don't want to tie this test the serialisation output (that's
 	// tested elsewhere). Just check that at least one thing we expect
@@ -66,6 +66,21 @@ func (mr *MockBackendMockRecorder) Export() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBackend)(nil).Export))
 }
 
+// ExportSecrets mocks base method
+func (m *MockBackend) ExportSecrets() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSecrets")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportSecrets indicates an expected call of ExportSecrets
+func (mr *MockBackendMockRecorder) ExportSecrets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSecrets", reflect.TypeOf((*MockBackend)(nil).ExportSecrets))
+}
+
 // LatestMigration mocks base method
 func (m *MockBackend) LatestMigration() (state.ModelMigration, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +118,9 @@ func (api *API) Import(serialized params.SerializedModel) error {
 		return err
 	}
 	defer st.Close()
+	if err := st.ImportSecrets(serialized.Secrets); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(mjs) - post import checks
 	// NOTE(fwereade) - checks here would be sensible, but we will
 	// also need to check after the binaries are imported too.
@@ -174,6 +174,7 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Location: m.Location,
 		Level:    level,
 		Message:  m.Message,
+		Labels:   m.Labels,
 	}}), "logging to DB failed")
 
 	m.Entity = s.entity
@@ -186,3 +186,17 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// ActionOutput holds output written by an action
+// to stdout or stderr while it runs.
+type ActionOutput struct {
+	Tag    string `json:"tag"`
+	Stream string `json:"stream"`
+	Output string `json:"output"`
+}
+
+// ActionOutputParams holds the arguments for
+// logging the output of some actions.
+type ActionOutputParams struct {
+	Output []ActionOutput `json:"output"`
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ActionSchedule describes a schedule on which an action is run on an
+// application's units, one of its units, or its leader.
+type ActionSchedule struct {
+	Name string `json:"name"`
+
+	// Target is an application, unit or application
+	// leader ("<application>/leader") name.
+	Target     string                 `json:"target"`
+	Action     string                 `json:"action"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Cron       string                 `json:"cron"`
+	Paused     bool                   `json:"paused,omitempty"`
+	Owner      string                 `json:"owner,omitempty"`
+	Created    time.Time              `json:"created,omitempty"`
+
+	// Runs holds the most recent runs of the schedule, oldest first.
+	Runs []ActionScheduleRun `json:"runs,omitempty"`
+}
+
+// ActionScheduleRun describes a run of an action schedule.
+type ActionScheduleRun struct {
+	Scheduled    time.Time `json:"scheduled"`
+	Enqueued     time.Time `json:"enqueued"`
+	OperationTag string    `json:"operation,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// AddActionSchedulesArgs holds the action schedules to add.
+type AddActionSchedulesArgs struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleNames holds the names of action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds the results of a bulk
+// request for action schedules.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionSchedulesResult holds all the action schedules in a model.
+type ActionSchedulesResult struct {
+	Schedules []ActionSchedule `json:"schedules"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// RunActionScheduleArg identifies a run of an action schedule
+// which has become due.
+type RunActionScheduleArg struct {
+	Name      string    `json:"name"`
+	Scheduled time.Time `json:"scheduled"`
+}
+
+// RunActionSchedulesArgs holds the action schedule runs to enqueue.
+type RunActionSchedulesArgs struct {
+	Runs []RunActionScheduleArg `json:"runs"`
+}
+
+// ActionScheduleRunResult holds the run of an action schedule
+// recorded when its operation was enqueued, or an error.
+type ActionScheduleRunResult struct {
+	Run   *ActionScheduleRun `json:"run,omitempty"`
+	Error *Error             `json:"error,omitempty"`
+}
+
+// ActionScheduleRunResults holds the results of a
+// bulk request to run action schedules.
+type ActionScheduleRunResults struct {
+	Results []ActionScheduleRunResult `json:"results"`
+}
@@ -634,6 +634,27 @@ type UnitInfoResults struct {
 	Results []UnitInfoResult `json:"results"`
 }
 
+// UnitEvent holds a substrate event for a unit,
+// such as a Kubernetes event for the unit's pod.
+type UnitEvent struct {
+	Type    string    `json:"type"`
+	Reason  string    `json:"reason"`
+	Message string    `json:"message"`
+	Count   int       `json:"count,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// UnitEventsResult holds the events for a unit, or an error.
+type UnitEventsResult struct {
+	Events []UnitEvent `json:"events,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// UnitEventsResults holds the events for units associated with entities.
+type UnitEventsResults struct {
+	Results []UnitEventsResult `json:"results"`
+}
+
 // ExposeInfoResults the expose info for a list of applications.
 type ExposeInfoResults struct {
 	Results []ExposeInfoResult `json:"results"`
@@ -0,0 +1,52 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the criteria used to query the
+// controller's audit log. Only the criteria which are set
+// are applied.
+type AuditLogQueryArgs struct {
+	User      string     `json:"user,omitempty"`
+	ModelUUID string     `json:"model-uuid,omitempty"`
+	Facade    string     `json:"facade,omitempty"`
+	Method    string     `json:"method,omitempty"`
+	After     *time.Time `json:"after,omitempty"`
+	Before    *time.Time `json:"before,omitempty"`
+	Outcome   string     `json:"outcome,omitempty"`
+	Limit     int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds an API request recorded in the audit
+// log, along with the details of the conversation it was
+// made in.
+type AuditLogEntry struct {
+	ConversationID string          `json:"conversation-id"`
+	ConnectionID   string          `json:"connection-id"`
+	User           string          `json:"user,omitempty"`
+	What           string          `json:"what,omitempty"`
+	ModelName      string          `json:"model-name,omitempty"`
+	ModelUUID      string          `json:"model-uuid,omitempty"`
+	RequestID      uint64          `json:"request-id"`
+	Time           time.Time       `json:"time"`
+	Facade         string          `json:"facade"`
+	Method         string          `json:"method"`
+	Version        int             `json:"version"`
+	Args           string          `json:"args,omitempty"`
+	Outcome        string          `json:"outcome,omitempty"`
+	Errors         []AuditLogError `json:"errors,omitempty"`
+}
+
+// AuditLogError holds an error returned in response
+// to a request recorded in the audit log.
+type AuditLogError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// AuditLogQueryResults holds the results of an audit log query.
+type AuditLogQueryResults struct {
+	Results []AuditLogEntry `json:"results"`
+}
@@ -14,6 +14,10 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+
+	// EncryptionKey holds the armored OpenPGP public keys
+	// the backup archive is encrypted to, if any.
+	EncryptionKey string `json:"encryption-key,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -88,4 +92,12 @@ type BackupsMetadataResult struct {
 
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
+
+	// EncryptedTo holds the fingerprints of the keys
+	// the backup archive is encrypted to.
+	EncryptedTo []string `json:"encrypted-to,omitempty"`
+
+	// Location is where the backup archive is stored, when
+	// it's stored outside the controller.
+	Location string `json:"location,omitempty"`
 }
@@ -47,9 +47,29 @@ type CAASApplicationProvisioningInfo struct {
 	ImageRepo            string                       `json:"image-repo,omitempty"`
 	CharmModifiedVersion int                          `json:"charm-modified-version,omitempty"`
 	CharmURL             string                       `json:"charm-url,omitempty"`
+	Scale                int                          `json:"scale,omitempty"`
+	Autoscaling          *KubernetesAutoscalingPolicy `json:"autoscaling,omitempty"`
+	NetworkPolicy        *KubernetesNetworkPolicy     `json:"network-policy,omitempty"`
 	Error                *Error                       `json:"error,omitempty"`
 }
 
+// KubernetesNetworkPolicy holds the sources allowed to connect to the
+// units of a Kubernetes application.
+type KubernetesNetworkPolicy struct {
+	Applications []string `json:"applications,omitempty"`
+	CIDRs        []string `json:"cidrs,omitempty"`
+}
+
+// KubernetesAutoscalingPolicy holds the bounds and targets used to scale
+// a Kubernetes application horizontally.
+type KubernetesAutoscalingPolicy struct {
+	MinReplicas             int               `json:"min-replicas"`
+	MaxReplicas             int               `json:"max-replicas"`
+	TargetCPUUtilization    int               `json:"target-cpu-utilization,omitempty"`
+	TargetMemoryUtilization int               `json:"target-memory-utilization,omitempty"`
+	Metrics                 map[string]string `json:"metrics,omitempty"`
+}
+
 // CAASApplicationGarbageCollectArg holds info needed to cleanup units that have
 // gone away permanently.
 type CAASApplicationGarbageCollectArg struct {
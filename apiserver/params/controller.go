@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/core/life"
+import (
+	"time"
+
+	"github.com/juju/juju/core/life"
+)
 
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
@@ -115,3 +119,24 @@ type ControllerVersionResults struct {
 	Version   string `json:"version"`
 	GitCommit string `json:"git-commit"`
 }
+
+// ControllerBackupStatus holds the outcome of the
+// controller's scheduled backups.
+type ControllerBackupStatus struct {
+	// Schedule is the cron schedule on which backups are
+	// created, or "" if scheduled backups are disabled.
+	Schedule string `json:"schedule,omitempty"`
+
+	LastAttempt  *time.Time `json:"last-attempt,omitempty"`
+	LastSuccess  *time.Time `json:"last-success,omitempty"`
+	LastBackupID string     `json:"last-backup-id,omitempty"`
+	LastError    string     `json:"last-error,omitempty"`
+
+	// MaxAge is how old the last successful backup may be
+	// before backups are overdue.
+	MaxAge time.Duration `json:"max-age"`
+
+	// Overdue is true if the last successful backup is
+	// older than MaxAge.
+	Overdue bool `json:"overdue"`
+}
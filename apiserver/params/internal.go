@@ -947,12 +947,14 @@ type DashboardVersionRequest struct {
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ModelUUID string    `json:"uuid,omitempty"`
 	Entity    string    `json:"tag"`
 	Timestamp time.Time `json:"ts"`
 	Severity  string    `json:"sev"`
 	Module    string    `json:"mod"`
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
+	Labels    []string  `json:"lab,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
@@ -989,6 +991,12 @@ type EntityString struct {
 	Value string `json:"value"`
 }
 
+// SetAvailabilityZones holds the arguments for recording the
+// availability zones that machines' instances are running in.
+type SetAvailabilityZones struct {
+	Zones []EntityString `json:"zones"`
+}
+
 // SetPodSpecParams holds the arguments for setting the pod
 // spec for a set of applications.
 // TODO(juju3) - remove
@@ -48,6 +48,7 @@ type KubernetesFilesystemParams struct {
 	Attributes  map[string]interface{}                `json:"attributes,omitempty"`
 	Tags        map[string]string                     `json:"tags,omitempty"`
 	Attachment  *KubernetesFilesystemAttachmentParams `json:"attachment,omitempty"`
+	Shared      bool                                  `json:"shared,omitempty"`
 }
 
 // KubernetesFilesystemAttachmentParams holds the parameters for
@@ -72,6 +72,7 @@ type SerializedModel struct {
 	Charms    []string                  `json:"charms"`
 	Tools     []SerializedModelTools    `json:"tools"`
 	Resources []SerializedModelResource `json:"resources"`
+	Secrets   []byte                    `json:"secrets,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
@@ -312,6 +312,46 @@ type UpdateSeriesArgs struct {
 	Args []UpdateSeriesArg `json:"args"`
 }
 
+// EvacuateClusterMemberArg holds the parameters for moving the model's
+// machines off a member of the cloud's cluster. If Target is empty, the
+// machines are spread over the remaining members.
+type EvacuateClusterMemberArg struct {
+	Member string `json:"member"`
+	Target string `json:"target,omitempty"`
+}
+
+// EvacuatedMachine describes the result of moving a single machine's
+// instance during a cluster member evacuation.
+type EvacuatedMachine struct {
+	MachineTag string `json:"machine-tag,omitempty"`
+	InstanceId string `json:"instance-id"`
+	Zone       string `json:"zone"`
+	Live       bool   `json:"live"`
+	Error      *Error `json:"error,omitempty"`
+}
+
+// EvacuateClusterMemberResult holds the results of a cluster
+// member evacuation.
+type EvacuateClusterMemberResult struct {
+	Machines []EvacuatedMachine `json:"machines,omitempty"`
+	Error    *Error             `json:"error,omitempty"`
+}
+
+// MigrateMachineArg holds the parameters for moving a machine's
+// instance to another availability zone of the cloud.
+type MigrateMachineArg struct {
+	MachineTag string `json:"machine-tag"`
+	Zone       string `json:"zone"`
+}
+
+// MigrateMachineResult holds the result of moving a machine's instance.
+type MigrateMachineResult struct {
+	InstanceId string `json:"instance-id,omitempty"`
+	Zone       string `json:"zone,omitempty"`
+	Live       bool   `json:"live"`
+	Error      *Error `json:"error,omitempty"`
+}
+
 // LXDProfileUpgrade holds the parameters for an application
 // lxd profile machines
 type LXDProfileUpgrade struct {
@@ -451,6 +491,20 @@ type UpdateApplicationUnits struct {
 	Generation     *int64                  `json:"generation,omitempty"`
 	Status         EntityStatus            `json:"status,omitempty"`
 	Units          []ApplicationUnitParams `json:"units"`
+
+	// Autoscaler is the observed state of the application's autoscaler.
+	// For sidecar applications, nil means the application is not autoscaled.
+	Autoscaler *AutoscalerStatus `json:"autoscaler,omitempty"`
+}
+
+// AutoscalerStatus holds the observed state of an application's
+// autoscaler.
+type AutoscalerStatus struct {
+	MinReplicas     int    `json:"min-replicas"`
+	MaxReplicas     int    `json:"max-replicas"`
+	CurrentReplicas int    `json:"current-replicas"`
+	DesiredReplicas int    `json:"desired-replicas"`
+	Message         string `json:"message,omitempty"`
 }
 
 // ApplicationUnitParams holds unit parameters used to update a unit.
@@ -1026,6 +1080,7 @@ type LogRecord struct {
 	Level    string    `json:"v"`
 	Message  string    `json:"x"`
 	Entity   string    `json:"e,omitempty"`
+	Labels   []string  `json:"c,omitempty"`
 }
 
 // PubSubMessage is used to propagate pubsub messages from one api server to the
@@ -0,0 +1,161 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// CreateSecretArgs holds the args for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the args for creating a secret.
+type CreateSecretArg struct {
+	// OwnerTag is the tag of the application which will own the secret.
+	OwnerTag string `json:"owner-tag"`
+
+	// RotateInterval is how often the secret should be rotated.
+	RotateInterval *time.Duration `json:"rotate-interval,omitempty"`
+
+	// Description is a human readable description of the secret.
+	Description *string `json:"description,omitempty"`
+
+	// Data is the base64 encoded secret content.
+	Data map[string]string `json:"data"`
+}
+
+// UpdateSecretArgs holds the args for updating secrets.
+type UpdateSecretArgs struct {
+	Args []UpdateSecretArg `json:"args"`
+}
+
+// UpdateSecretArg holds the args for updating a secret.
+type UpdateSecretArg struct {
+	// URI identifies the secret to update.
+	URI string `json:"uri"`
+
+	// RotateInterval, if set, is how often the secret should be rotated.
+	RotateInterval *time.Duration `json:"rotate-interval,omitempty"`
+
+	// Description, if set, is a human readable description of the secret.
+	Description *string `json:"description,omitempty"`
+
+	// Data, if set, is the base64 encoded content of a new secret revision.
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// GetSecretValueArgs holds the args for getting secret values.
+type GetSecretValueArgs struct {
+	Args []GetSecretValueArg `json:"args"`
+}
+
+// GetSecretValueArg holds the args for getting a secret value.
+type GetSecretValueArg struct {
+	// URI identifies the secret.
+	URI string `json:"uri"`
+
+	// Revision is the secret revision to get,
+	// or zero for the latest revision.
+	Revision int `json:"revision,omitempty"`
+}
+
+// SecretValueResults holds secret value results.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult is the result of getting a secret value.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// ListSecretsArgs holds the args for listing secrets.
+type ListSecretsArgs struct {
+	// ShowSecrets is true if the secret values are to be included.
+	ShowSecrets bool `json:"show-secrets"`
+}
+
+// ListSecretResults holds secret metadata results.
+type ListSecretResults struct {
+	Results []ListSecretResult `json:"results"`
+}
+
+// ListSecretResult is the result of getting secret metadata.
+type ListSecretResult struct {
+	URI            string             `json:"uri"`
+	OwnerTag       string             `json:"owner-tag"`
+	Description    string             `json:"description,omitempty"`
+	RotateInterval time.Duration      `json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time         `json:"next-rotate-time,omitempty"`
+	LatestRevision int                `json:"latest-revision"`
+	CreateTime     time.Time          `json:"create-time"`
+	UpdateTime     time.Time          `json:"update-time"`
+	Value          *SecretValueResult `json:"value,omitempty"`
+}
+
+// GrantRevokeSecretArgs holds args for changing access to secrets.
+type GrantRevokeSecretArgs struct {
+	Args []GrantRevokeSecretArg `json:"args"`
+}
+
+// GrantRevokeSecretArg holds the args for changing access to a secret.
+type GrantRevokeSecretArg struct {
+	// URI identifies the secret to grant.
+	URI string `json:"uri"`
+
+	// ScopeTag is the entity, typically a relation, whose
+	// lifetime bounds the access.
+	ScopeTag string `json:"scope-tag"`
+
+	// SubjectTags are the target applications or units
+	// being granted or losing access.
+	SubjectTags []string `json:"subject-tags"`
+
+	// Role is the role being granted.
+	Role string `json:"role"`
+}
+
+// SecretRotatedArgs holds the args for recording secret rotations.
+type SecretRotatedArgs struct {
+	Args []SecretRotatedArg `json:"args"`
+}
+
+// SecretRotatedArg holds the args for recording a secret rotation.
+type SecretRotatedArg struct {
+	// URI identifies the secret which was rotated.
+	URI string `json:"uri"`
+
+	// When is when the secret was rotated.
+	When time.Time `json:"when"`
+}
+
+// SecretBackendResult holds the secret backend used by a model.
+type SecretBackendResult struct {
+	// BackendType is the type of backend used to store secret content.
+	BackendType string `json:"backend-type"`
+}
+
+// ChangeSecretBackendArg holds the args for changing
+// the secret backend used by a model.
+type ChangeSecretBackendArg struct {
+	// BackendType is the type of backend to use.
+	BackendType string `json:"backend-type"`
+
+	// Config, if set, replaces the backend specific config.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// ChangeSecretBackendResult is the result of changing
+// the secret backend used by a model.
+type ChangeSecretBackendResult struct {
+	// Migrated is the number of secret revisions whose
+	// content was moved to the new backend.
+	Migrated int `json:"migrated"`
+
+	// Error is set if not all content could be moved.
+	Error *Error `json:"error,omitempty"`
+}
@@ -158,9 +158,10 @@ type ApplicationStatus struct {
 	EndpointBindings map[string]string          `json:"endpoint-bindings"`
 
 	// The following are for CAAS models.
-	Scale         int    `json:"int,omitempty"`
-	ProviderId    string `json:"provider-id,omitempty"`
-	PublicAddress string `json:"public-address"`
+	Scale         int               `json:"int,omitempty"`
+	ProviderId    string            `json:"provider-id,omitempty"`
+	PublicAddress string            `json:"public-address"`
+	Autoscaler    *AutoscalerStatus `json:"autoscaler,omitempty"`
 }
 
 // TODO(wallyworld) - remove in Juju 3
@@ -99,6 +99,7 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     life.Value  `json:"life"`
+	Size     uint64      `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -113,6 +114,21 @@ type StorageAttachmentIds struct {
 	Ids []StorageAttachmentId `json:"ids"`
 }
 
+// StorageResizeParams holds the parameters for growing storage
+// instances.
+type StorageResizeParams struct {
+	Storage []StorageResize `json:"storage"`
+}
+
+// StorageResize holds the parameters for growing a storage instance.
+type StorageResize struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the requested new size of the storage, in MiB.
+	Size uint64 `json:"size"`
+}
+
 type StorageDetachmentParams struct {
 	// StorageIds to detach
 	StorageIds StorageAttachmentIds `json:"ids"`
@@ -259,6 +275,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // RemoveVolumeParams holds the parameters for destroying or releasing a
@@ -356,6 +373,33 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// ResizeVolumeParams holds the parameters for growing a storage volume.
+type ResizeVolumeParams struct {
+	// VolumeTag is the tag of the volume to resize.
+	VolumeTag string `json:"volume-tag"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the requested size of the volume in MiB.
+	Size uint64 `json:"size"`
+}
+
+// ResizeVolumeParamsResult holds parameters for resizing a volume,
+// or an error.
+type ResizeVolumeParamsResult struct {
+	Result ResizeVolumeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ResizeVolumeParamsResults holds parameters for resizing multiple volumes.
+type ResizeVolumeParamsResults struct {
+	Results []ResizeVolumeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -495,6 +539,59 @@ type RemoveFilesystemParamsResults struct {
 	Results []RemoveFilesystemParamsResult `json:"results,omitempty"`
 }
 
+// ResizeFilesystemParams holds the parameters for growing a filesystem.
+type ResizeFilesystemParams struct {
+	// FilesystemTag is the tag of the filesystem to resize.
+	FilesystemTag string `json:"filesystem-tag"`
+
+	// Provider is the storage provider that manages the filesystem.
+	Provider string `json:"provider"`
+
+	// FilesystemId is the storage provider's unique ID for the filesystem.
+	FilesystemId string `json:"filesystem-id"`
+
+	// Size is the requested size of the filesystem in MiB.
+	Size uint64 `json:"size"`
+}
+
+// ResizeFilesystemParamsResult holds parameters for resizing a filesystem,
+// or an error.
+type ResizeFilesystemParamsResult struct {
+	Result ResizeFilesystemParams `json:"result"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// ResizeFilesystemParamsResults holds parameters for resizing multiple
+// filesystems.
+type ResizeFilesystemParamsResults struct {
+	Results []ResizeFilesystemParamsResult `json:"results,omitempty"`
+}
+
+// FilesystemUsage describes the space and inode usage of a filesystem.
+type FilesystemUsage struct {
+	BytesUsed  uint64 `json:"bytes-used"`
+	BytesFree  uint64 `json:"bytes-free"`
+	InodesUsed uint64 `json:"inodes-used"`
+	InodesFree uint64 `json:"inodes-free"`
+
+	// Updated is the time at which the usage was reported.
+	// It is not set by the agents reporting usage.
+	Updated *time.Time `json:"updated,omitempty"`
+}
+
+// FilesystemUsageArg holds the usage of a filesystem, as
+// observed on the machine that it is attached to.
+type FilesystemUsageArg struct {
+	FilesystemTag string          `json:"filesystem-tag"`
+	MachineTag    string          `json:"machine-tag"`
+	Usage         FilesystemUsage `json:"usage"`
+}
+
+// FilesystemUsageArgs holds the usage of multiple filesystems.
+type FilesystemUsageArgs struct {
+	Args []FilesystemUsageArg `json:"args"`
+}
+
 // FilesystemAttachmentParamsResults holds provisioning parameters for a filesystem
 // attachment.
 type FilesystemAttachmentParamsResult struct {
@@ -535,6 +632,10 @@ type StorageDetails struct {
 	// Attachments contains a mapping from unit tag to
 	// storage attachment details.
 	Attachments map[string]StorageAttachmentDetails `json:"attachments,omitempty"`
+
+	// Usage contains the last reported usage of the filesystem
+	// backing the storage, if any.
+	Usage *FilesystemUsage `json:"usage,omitempty"`
 }
 
 // StorageFilter holds filter terms for listing storage details.
@@ -602,6 +703,21 @@ type StoragePool struct {
 
 	// Attrs are the pool's configuration attributes.
 	Attrs map[string]interface{} `json:"attrs"`
+
+	// Usage contains the aggregate of the last reported usage
+	// of the pool's filesystems, if any have reported usage.
+	Usage *StoragePoolUsage `json:"usage,omitempty"`
+}
+
+// StoragePoolUsage describes the space usage of the filesystems
+// in a storage pool.
+type StoragePoolUsage struct {
+	// Filesystems is the number of filesystems in the pool
+	// whose usage has been reported.
+	Filesystems int `json:"filesystems"`
+
+	BytesUsed uint64 `json:"bytes-used"`
+	BytesFree uint64 `json:"bytes-free"`
 }
 
 // StoragePoolArgs contains a set of StoragePool.
@@ -790,6 +906,10 @@ type FilesystemDetails struct {
 	// Storage contains details about the storage instance
 	// that the volume is assigned to, if any.
 	Storage *StorageDetails `json:"storage,omitempty"`
+
+	// Usage contains the last reported usage of the
+	// filesystem, if any.
+	Usage *FilesystemUsage `json:"usage,omitempty"`
 }
 
 // FilesystemAttachmentDetails describes a filesystem attachment.
@@ -855,6 +975,10 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// FromSnapshot, if non-empty, is the ID of a volume snapshot from
+	// which the new storage instances' volumes should be created.
+	FromSnapshot string `json:"from-snapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
@@ -934,6 +1058,56 @@ type ImportStorageDetails struct {
 	StorageTag string `json:"storage-tag"`
 }
 
+// VolumeSnapshotDetails describes a snapshot of the volume backing
+// a storage instance.
+type VolumeSnapshotDetails struct {
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// StorageTag is the tag of the storage instance whose volume
+	// was snapshotted.
+	StorageTag string `json:"storage-tag"`
+
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size of the snapshot, in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is the time at which the snapshot was created.
+	Created time.Time `json:"created"`
+
+	// Status is the provider-specific status of the snapshot.
+	Status string `json:"status,omitempty"`
+}
+
+// VolumeSnapshotResults contains the results of snapshotting storage.
+type VolumeSnapshotResults struct {
+	Results []VolumeSnapshotResult `json:"results"`
+}
+
+// VolumeSnapshotResult contains the result of snapshotting a storage
+// instance.
+type VolumeSnapshotResult struct {
+	Result *VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// VolumeSnapshotListResults contains the results of listing the
+// snapshots of a collection of storage instances.
+type VolumeSnapshotListResults struct {
+	Results []VolumeSnapshotListResult `json:"results"`
+}
+
+// VolumeSnapshotListResult contains the snapshots of a storage instance.
+type VolumeSnapshotListResult struct {
+	Result []VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
 // AddStorageResults contains the results of adding storage to units.
 type AddStorageResults struct {
 	Results []AddStorageResult `json:"results"`
@@ -15,6 +15,8 @@ import (
 var commonModelFacadeNames = set.NewStrings(
 	"Action",
 	"ActionPruner",
+	"ActionSchedule",
+	"ActionScheduler",
 	"AllWatcher",
 	"Agent",
 	"Annotations",
@@ -56,6 +58,8 @@ var commonModelFacadeNames = set.NewStrings(
 	"RemoteRelations",
 	"Resumer",
 	"RetryStrategy",
+	"Secrets",
+	"SecretsManager",
 	"Singular",
 	"StatusHistory",
 	"Storage",
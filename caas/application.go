@@ -25,9 +25,77 @@ type Application interface {
 	State() (ApplicationState, error)
 	Units() ([]Unit, error)
 
+	// Scale sets the number of replicas of the application.
+	Scale(replicas int) error
+
+	// EnsureAutoscaler creates or updates the autoscaler that controls the
+	// number of replicas of the application, or removes it if policy is nil.
+	EnsureAutoscaler(policy *AutoscalingPolicy) error
+
+	// AutoscalerStatus returns the observed state of the application's
+	// autoscaler, or an error satisfying errors.IsNotFound if there is none.
+	AutoscalerStatus() (AutoscalerStatus, error)
+
+	// EnsureNetworkPolicy restricts ingress to the application's units to
+	// the sources allowed by policy, or removes the restriction if policy
+	// is nil.
+	EnsureNetworkPolicy(policy *NetworkPolicy) error
+
 	ServiceInterface
 }
 
+// AutoscalingPolicy defines the bounds and targets used to scale an
+// application horizontally.
+type AutoscalingPolicy struct {
+	// MinReplicas is the lower limit for the number of replicas.
+	MinReplicas int
+
+	// MaxReplicas is the upper limit for the number of replicas.
+	MaxReplicas int
+
+	// TargetCPUUtilization is the target average CPU utilization across
+	// all replicas, as a percentage of the requested CPU.
+	TargetCPUUtilization int
+
+	// TargetMemoryUtilization is the target average memory utilization
+	// across all replicas, as a percentage of the requested memory.
+	TargetMemoryUtilization int
+
+	// Metrics maps the names of custom per-replica metrics to the
+	// target average value of each metric, as a resource quantity.
+	Metrics map[string]string
+}
+
+// AutoscalerStatus represents the observed state of an application's
+// autoscaler.
+type AutoscalerStatus struct {
+	MinReplicas     int
+	MaxReplicas     int
+	CurrentReplicas int
+	DesiredReplicas int
+
+	// Generation is the generation of the application's workload
+	// when the autoscaler was observed. It orders reports of the
+	// desired replicas against other changes to the scale.
+	Generation int64
+
+	// Message explains why the autoscaler is unable or limited in scaling,
+	// and is empty when the autoscaler is working normally.
+	Message string
+}
+
+// NetworkPolicy defines the sources allowed to connect to the units of
+// an application. Connections from the controller and the model operator
+// are always allowed.
+type NetworkPolicy struct {
+	// Applications are the names of the applications in the same model
+	// whose units may connect.
+	Applications []string
+
+	// CIDRs are the address ranges that may connect.
+	CIDRs []string
+}
+
 // ServicePort represents service ports mapping from service to units.
 type ServicePort struct {
 	Name       string `json:"name"`
@@ -5,6 +5,7 @@ package caas
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -234,6 +235,10 @@ type ApplicationBroker interface {
 	// the provider id for the unit. If containerName is empty, then the first workload container
 	// is used.
 	WatchContainerStart(appName string, containerName string) (watcher.StringsWatcher, error)
+
+	// UnitEvents returns the most recent substrate events
+	// for the unit with the specified provider id, oldest first.
+	UnitEvents(providerId string) ([]UnitEvent, error)
 }
 
 // ModelOperatorManager provides an API for deploying operators for individual
@@ -309,7 +314,9 @@ type ServiceManager interface {
 	DeleteService(appName string) error
 
 	// ExposeService sets up external access to the specified service.
-	ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error
+	// An empty set of exposed endpoints, or one containing the wildcard
+	// endpoint "", means all of the application's endpoints are exposed.
+	ExposeService(appName string, resourceTags map[string]string, exposedEndpoints []string, config application.ConfigAttributes) error
 
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
@@ -363,6 +370,16 @@ type Unit struct {
 	FilesystemInfo []FilesystemInfo
 }
 
+// UnitEvent represents a substrate event for a unit, such as
+// a Kubernetes event for the unit's pod.
+type UnitEvent struct {
+	Type    string
+	Reason  string
+	Message string
+	Count   int
+	Time    time.Time
+}
+
 // Operator represents information about the status of an "operator pod".
 type Operator struct {
 	Id     string
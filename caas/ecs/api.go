@@ -13,6 +13,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs/cloudspec"
@@ -54,7 +56,7 @@ func cloudSpecToAWSConfig(c cloudspec.CloudSpec) (*aws.Config, error) {
 	}, nil
 }
 
-func newECSClient(config *aws.Config) (ecsiface.ECSAPI, error) {
+func newSession(config *aws.Config) *session.Session {
 	s := session.Must(session.NewSession())
 	// Enable request and response logging, but only if TRACE is enabled (as
 	// they're probably fairly expensive to produce).
@@ -62,5 +64,13 @@ func newECSClient(config *aws.Config) (ecsiface.ECSAPI, error) {
 		config.Logger = awsLogger{s}
 		config.LogLevel = aws.LogLevel(aws.LogDebug | aws.LogDebugWithRequestErrors | aws.LogDebugWithRequestRetries)
 	}
-	return ecs.New(s, config), nil
+	return s
+}
+
+func newECSClient(config *aws.Config) (ecsiface.ECSAPI, error) {
+	return ecs.New(newSession(config), config), nil
+}
+
+func newELBClient(config *aws.Config) (elbv2iface.ELBV2API, error) {
+	return elbv2.New(newSession(config), config), nil
 }
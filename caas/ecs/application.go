@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -22,6 +23,7 @@ import (
 	"github.com/kr/pretty"
 
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/ecs/constants"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
@@ -42,6 +44,11 @@ type app struct {
 	deploymentType caas.DeploymentType
 	client         ecsiface.ECSAPI
 	clock          clock.Clock
+
+	// elbClient and loadBalancerARN are used to expose the application
+	// through the model's load balancer.
+	elbClient       elbv2iface.ELBV2API
+	loadBalancerARN string
 }
 
 func newApplication(
@@ -52,17 +59,21 @@ func newApplication(
 	modelName string,
 	deploymentType caas.DeploymentType,
 	client ecsiface.ECSAPI,
+	elbClient elbv2iface.ELBV2API,
+	loadBalancerARN string,
 	clock clock.Clock,
-) caas.Application {
+) *app {
 	return &app{
-		name:           name,
-		clusterName:    clusterName,
-		controllerUUID: controllerUUID,
-		modelUUID:      modelUUID,
-		modelName:      modelName,
-		deploymentType: deploymentType,
-		client:         client,
-		clock:          clock,
+		name:            name,
+		clusterName:     clusterName,
+		controllerUUID:  controllerUUID,
+		modelUUID:       modelUUID,
+		modelName:       modelName,
+		deploymentType:  deploymentType,
+		client:          client,
+		clock:           clock,
+		elbClient:       elbClient,
+		loadBalancerARN: loadBalancerARN,
 	}
 }
 
@@ -84,6 +95,9 @@ func (a *app) resourceName() string {
 
 // Delete deletes the specified application.
 func (a *app) Delete() error {
+	if err := a.removeLoadBalancing(); err != nil {
+		return errors.Trace(err)
+	}
 	if err := a.deleteService(); err != nil {
 		return errors.Trace(err)
 	}
@@ -190,23 +204,9 @@ func (a *app) handleFileSystems(filesystems []jujustorage.KubernetesFilesystemPa
 		}
 		volNames.Add(fs.StorageName)
 
-		ebsCfg, err := newEbsConfig(fs.Attributes)
+		vol, err := a.filesystemVolume(fs)
 		if err != nil {
-			// This should never happen because it's been validated `storageProvider.ValidateConfig`.
-			return nil, nil, errors.NotValidf("storage attribute for %q", fs.StorageName)
-		}
-		vol := &ecs.Volume{
-			Name: aws.String(a.volumeName(fs.StorageName)),
-			DockerVolumeConfiguration: &ecs.DockerVolumeConfiguration{
-				Scope:         aws.String("shared"),
-				Autoprovision: aws.Bool(true),
-				Driver:        aws.String(ebsCfg.driver),
-				Labels:        a.labels(fs.ResourceTags),
-				DriverOpts: map[string]*string{
-					"volumetype": aws.String(ebsCfg.volumeType),
-					"size":       aws.String(strconv.FormatUint(fs.Size/1024, 10)), // unit of size here should be `Gi`
-				},
-			},
+			return nil, nil, errors.Trace(err)
 		}
 		vols[idx] = vol
 
@@ -225,6 +225,38 @@ func (a *app) handleFileSystems(filesystems []jujustorage.KubernetesFilesystemPa
 	return vols, mounts, nil
 }
 
+// filesystemVolume returns the task volume for the filesystem,
+// either an EFS file system or a Docker volume backed by EBS.
+func (a *app) filesystemVolume(fs jujustorage.KubernetesFilesystemParams) (*ecs.Volume, error) {
+	vol := &ecs.Volume{
+		Name: aws.String(a.volumeName(fs.StorageName)),
+	}
+	// This should never fail because it's been validated by the storage provider's ValidateConfig.
+	if fs.Provider == constants.EFSStorageProviderType {
+		efsCfg, err := newEFSConfig(fs.Attributes)
+		if err != nil {
+			return nil, errors.NotValidf("storage attribute for %q", fs.StorageName)
+		}
+		vol.EfsVolumeConfiguration = efsCfg.volumeConfiguration()
+		return vol, nil
+	}
+	ebsCfg, err := newEbsConfig(fs.Attributes)
+	if err != nil {
+		return nil, errors.NotValidf("storage attribute for %q", fs.StorageName)
+	}
+	vol.DockerVolumeConfiguration = &ecs.DockerVolumeConfiguration{
+		Scope:         aws.String("shared"),
+		Autoprovision: aws.Bool(true),
+		Driver:        aws.String(ebsCfg.driver),
+		Labels:        a.labels(fs.ResourceTags),
+		DriverOpts: map[string]*string{
+			"volumetype": aws.String(ebsCfg.volumeType),
+			"size":       aws.String(strconv.FormatUint(fs.Size/1024, 10)), // unit of size here should be `Gi`
+		},
+	}
+	return vol, nil
+}
+
 func (a *app) applicationTaskDefinition(config caas.ApplicationConfig) (*ecs.RegisterTaskDefinitionInput, error) {
 	var containerNames []string
 	var containers []caas.ContainerConfig
@@ -267,16 +299,6 @@ func (a *app) applicationTaskDefinition(config caas.ApplicationConfig) (*ecs.Reg
 						Name:  aws.String("JUJU_CONTAINER_NAMES"),
 						Value: aws.String(strings.Join(containerNames, ",")),
 					},
-					{
-						// TODO(ecs): remove me when we solve the ECS unit's identity issue.
-						Name:  aws.String("JUJU_K8S_POD_NAME"),
-						Value: aws.String("cockroachdb-0"),
-					},
-					{
-						// TODO(ecs): remove me when we solve the ECS unit's identity issue.
-						Name:  aws.String("JUJU_K8S_POD_UUID"),
-						Value: aws.String("c83b286e-8f45-4dbf-b2a6-0c393d93bd6a"),
-					},
 					// appSecret
 					{
 						Name:  aws.String("JUJU_K8S_APPLICATION"),
@@ -471,119 +493,304 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 // Exists indicates if the application for the specified
 // application exists, and whether the application is terminating.
 func (a *app) Exists() (caas.DeploymentState, error) {
-	// TODO(ecs)
-	return caas.DeploymentState{}, nil
+	svc, err := a.describeService()
+	if errors.IsNotFound(err) {
+		return caas.DeploymentState{}, nil
+	}
+	if err != nil {
+		return caas.DeploymentState{}, errors.Trace(err)
+	}
+	return caas.DeploymentState{
+		Exists:      true,
+		Terminating: aws.StringValue(svc.Status) == "DRAINING",
+	}, nil
 }
 
+// State returns the desired number of units and the tasks running
+// for the application.
 func (a *app) State() (caas.ApplicationState, error) {
-	// TODO(ecs)
-	return caas.ApplicationState{}, nil
+	svc, err := a.describeService()
+	if err != nil {
+		return caas.ApplicationState{}, errors.Trace(err)
+	}
+	tasks, err := a.tasks()
+	if err != nil {
+		return caas.ApplicationState{}, errors.Trace(err)
+	}
+	state := caas.ApplicationState{
+		DesiredReplicas: int(aws.Int64Value(svc.DesiredCount)),
+	}
+	for _, t := range tasks {
+		if aws.StringValue(t.DesiredStatus) == ecs.DesiredStatusRunning {
+			state.Replicas = append(state.Replicas, aws.StringValue(t.TaskArn))
+		}
+	}
+	sort.Strings(state.Replicas)
+	return state, nil
 }
 
-func computeStatus(ctx context.Context, t *ecs.Task) (statusMessage string, jujuStatus status.Status, since time.Time) {
-	// TODO(ecs)
-	if t.StoppedAt != nil || t.StoppingAt != nil {
+// describeService returns the ECS service for the application, or an
+// error satisfying errors.IsNotFound if there isn't an active one.
+func (a *app) describeService() (*ecs.Service, error) {
+	result, err := a.client.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(a.clusterName),
+		Services: []*string{aws.String(a.resourceName())},
+	})
+	if err = a.handleErr(err); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, svc := range result.Services {
+		if aws.StringValue(svc.Status) != "INACTIVE" {
+			return svc, nil
+		}
+	}
+	return nil, errors.NotFoundf("service %q in cluster %q", a.resourceName(), a.clusterName)
+}
+
+// tasks returns the tasks of the application's service which are wanted
+// running or are still stopping.
+func (a *app) tasks() ([]*ecs.Task, error) {
+	var taskArns []*string
+	for _, desiredStatus := range []string{ecs.DesiredStatusRunning, ecs.DesiredStatusStopped} {
+		arns, err := a.listTasks(desiredStatus)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		taskArns = append(taskArns, arns...)
+	}
+	if len(taskArns) == 0 {
+		return nil, nil
+	}
+	result, err := a.client.DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(a.clusterName),
+		Tasks:   taskArns,
+	})
+	if err = a.handleErr(err); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Failures) > 0 {
+		// Tasks may have gone since they were listed.
+		logger.Debugf("describing tasks of %q: %v", a.resourceName(), errorOrFailures(nil, result.Failures))
+	}
+	var tasks []*ecs.Task
+	for _, t := range result.Tasks {
+		if aws.StringValue(t.DesiredStatus) == ecs.DesiredStatusStopped &&
+			aws.StringValue(t.LastStatus) == ecs.DesiredStatusStopped {
+			// Stopped tasks are kept around for a while but are gone as far as Juju is concerned.
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+func (a *app) listTasks(desiredStatus string) (taskArns []*string, err error) {
+	input := &ecs.ListTasksInput{
+		Cluster:       aws.String(a.clusterName),
+		ServiceName:   aws.String(a.resourceName()),
+		DesiredStatus: aws.String(desiredStatus),
+	}
+	for {
+		result, err := a.client.ListTasks(input)
+		err = a.handleErr(err)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		taskArns = append(taskArns, result.TaskArns...)
+		if result.NextToken == nil {
+			return taskArns, nil
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// computeStatus returns the unit status for the task.
+func computeStatus(t *ecs.Task) (statusMessage string, jujuStatus status.Status, since time.Time) {
+	switch aws.StringValue(t.LastStatus) {
+	case "PROVISIONING", "PENDING", "ACTIVATING":
+		if message, failed := containerFailure(t.Containers); failed {
+			return message, status.Error, aws.TimeValue(t.CreatedAt)
+		}
+		return "", status.Allocating, aws.TimeValue(t.CreatedAt)
+	case "RUNNING":
+		if aws.StringValue(t.HealthStatus) == ecs.HealthStatusUnhealthy {
+			return "task health check failing", status.Error, aws.TimeValue(t.StartedAt)
+		}
+		return "", status.Running, aws.TimeValue(t.StartedAt)
+	case "DEACTIVATING", "STOPPING", "DEPROVISIONING", "STOPPED":
 		since = aws.TimeValue(t.StoppedAt)
 		if t.StoppedAt == nil {
 			since = aws.TimeValue(t.StoppingAt)
 		}
-		return "", status.Terminated, since
+		statusMessage = aws.StringValue(t.StoppedReason)
+		switch aws.StringValue(t.StopCode) {
+		case ecs.TaskStopCodeTaskFailedToStart, ecs.TaskStopCodeEssentialContainerExited:
+			if message, failed := containerFailure(t.Containers); failed {
+				statusMessage = message
+			}
+			return statusMessage, status.Error, since
+		}
+		return statusMessage, status.Terminated, since
 	}
-	jujuStatus = status.Unknown
-	healthStatus := aws.StringValue(t.HealthStatus)
-	switch healthStatus {
-	case "UNKNOWN":
-	case "RUNNING":
-		jujuStatus = status.Running
-		since = aws.TimeValue(t.StartedAt)
-	case "UNHEALTHY":
-		jujuStatus = status.Error
-		since = aws.TimeValue(t.ExecutionStoppedAt)
-	case "PENDING":
-		jujuStatus = status.Allocating
-		since = aws.TimeValue(t.CreatedAt)
+	return aws.StringValue(t.StoppedReason), status.Unknown, aws.TimeValue(t.CreatedAt)
+}
+
+// containerFailure returns a status message for the first container
+// which failed to start or exited with an error.
+func containerFailure(containers []*ecs.Container) (string, bool) {
+	for _, c := range containers {
+		name := aws.StringValue(c.Name)
+		if reason := aws.StringValue(c.Reason); reason != "" {
+			return fmt.Sprintf("container %q: %s", name, reason), true
+		}
+		if exitCode := aws.Int64Value(c.ExitCode); exitCode != 0 {
+			return fmt.Sprintf("container %q: exited with code %d", name, exitCode), true
+		}
 	}
-	statusMessage = aws.StringValue(t.StoppedReason)
-	return statusMessage, jujuStatus, since
+	return "", false
 }
 
-// Units of the application fetched from kubernetes by matching pod labels.
-func (a *app) Units() (units []caas.Unit, err error) {
-	ctx := context.Background()
+// taskAddress returns the private address of the task's network interface.
+func taskAddress(t *ecs.Task) string {
+	for _, attachment := range t.Attachments {
+		if aws.StringValue(attachment.Type) != "ElasticNetworkInterface" {
+			continue
+		}
+		for _, detail := range attachment.Details {
+			if aws.StringValue(detail.Name) == "privateIPv4Address" {
+				return aws.StringValue(detail.Value)
+			}
+		}
+	}
+	return ""
+}
 
-	result, err := a.client.ListTasks(&ecs.ListTasksInput{
-		Cluster:     aws.String(a.clusterName),
-		ServiceName: aws.String(a.resourceName()),
-	})
+// Units returns the units of the application, one for each task of its
+// ECS service. The unit's agent identifies itself by the task ARN.
+func (a *app) Units() (units []caas.Unit, err error) {
+	tasks, err := a.tasks()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	tasks, err := a.client.DescribeTasks(&ecs.DescribeTasksInput{
-		Cluster: aws.String(a.clusterName),
-		Tasks:   result.TaskArns,
-	})
-	if err != nil {
-		return nil, errors.Trace(err)
+	if a.loadBalancerARN != "" {
+		// ECS can't attach load balancers to an existing service,
+		// so the targets of the exposed ports follow the tasks here.
+		if err := a.syncTargets(tasks); err != nil {
+			logger.Warningf("updating load balancer targets for %q: %v", a.name, err)
+		}
 	}
-	if len(tasks.Failures) > 0 {
-		failures := ""
-		for _, failure := range tasks.Failures {
-			failures = " | " + failure.String()
-		}
-		logger.Warningf("a.client.DescribeTasks(%#v), tasks.Failures: %q", result.TaskArns, failures)
-	}
-	for _, t := range tasks.Tasks {
-		logger.Warningf("Units() task -> %s", pretty.Sprint(t))
-		statusMessage, unitStatus, since := computeStatus(ctx, t)
-		unitInfo := caas.Unit{
-			// Id:       aws.StringValue(t.TaskArn),
-			Id:       "cockroachdb-0", // TODO(ecs): Get the real ID(probably the TaskID or ARN) once we solve the identity of ECS unit issue.
-			Address:  "",
-			Ports:    nil,
-			Dying:    t.StoppedAt != nil || t.StoppingAt != nil,
+	for _, t := range tasks {
+		statusMessage, unitStatus, since := computeStatus(t)
+		units = append(units, caas.Unit{
+			Id:       aws.StringValue(t.TaskArn),
+			Address:  taskAddress(t),
+			Dying:    aws.StringValue(t.DesiredStatus) == ecs.DesiredStatusStopped,
 			Stateful: a.deploymentType == caas.DeploymentStateful,
 			Status: status.StatusInfo{
 				Status:  unitStatus,
 				Message: statusMessage,
 				Since:   &since,
 			},
-			FilesystemInfo: []caas.FilesystemInfo{
-				{
-					Size:         1,
-					FilesystemId: "cockroachdb-0",
-					MountPoint:   "/var/lib/juju/storage/database/0",
-					ReadOnly:     false,
-					Status: status.StatusInfo{
-						Status: status.Attached,
-						Since:  &since,
-					},
-					Volume: caas.VolumeInfo{
-						VolumeId:   "cockroachdb-0",
-						Size:       1,
-						Persistent: false,
-						Status: status.StatusInfo{
-							Status: status.Attached,
-							Since:  &since,
-						},
-					},
-				},
-			},
-		}
-		units = append(units, unitInfo)
+		})
 	}
 	return units, nil
 }
 
+// Scale sets the desired number of tasks of the application's service.
+func (a *app) Scale(replicas int) error {
+	if replicas < 0 {
+		return errors.NotValidf("negative scale %d", replicas)
+	}
+	_, err := a.client.UpdateService(&ecs.UpdateServiceInput{
+		Cluster:      aws.String(a.clusterName),
+		Service:      aws.String(a.resourceName()),
+		DesiredCount: aws.Int64(int64(replicas)),
+	})
+	return errors.Trace(a.handleErr(err))
+}
+
+// EnsureAutoscaler is not supported on ECS yet.
+func (a *app) EnsureAutoscaler(policy *caas.AutoscalingPolicy) error {
+	// TODO(ecs): use Application Auto Scaling target tracking policies.
+	if policy == nil {
+		return nil
+	}
+	return errors.NotSupportedf("autoscaling on ECS")
+}
+
+// AutoscalerStatus is not supported on ECS yet.
+func (a *app) AutoscalerStatus() (caas.AutoscalerStatus, error) {
+	return caas.AutoscalerStatus{}, errors.NotFoundf("autoscaler for %q", a.name)
+}
+
+// EnsureNetworkPolicy is not supported on ECS yet.
+func (a *app) EnsureNetworkPolicy(policy *caas.NetworkPolicy) error {
+	// TODO(ecs): use security group rules for the service.
+	if policy == nil {
+		return nil
+	}
+	return errors.NotSupportedf("network policies on ECS")
+}
+
 // UpdatePorts updates port mappings on the specified service.
 func (a *app) UpdatePorts(ports []caas.ServicePort, updateContainerPorts bool) error {
 	// TODO(ecs)
 	return nil
 }
 
-// UpdateService updates the default service with specific service type and port mappings.
+// UpdateService exposes the ports of the application through the model's
+// load balancer for the "loadbalancer" service type, and otherwise stops
+// exposing the application. Without any ports, the container ports of
+// the application's task definition are exposed.
 func (a *app) UpdateService(param caas.ServiceParam) error {
-	// TODO(ecs)
-	return nil
+	if !strings.EqualFold(param.Type, string(caas.ServiceLoadBalancer)) {
+		return errors.Trace(a.removeLoadBalancing())
+	}
+	if a.loadBalancerARN == "" {
+		return errors.NotValidf("exposing %q without model config %q", a.name, loadBalancerARNKey)
+	}
+	ports := param.Ports
+	if ports == nil {
+		var err error
+		if ports, err = a.containerPorts(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(ports) == 0 {
+		return errors.Errorf("cannot expose %q without a port", a.name)
+	}
+	return errors.Trace(a.ensureLoadBalancing(ports))
+}
+
+// containerPorts returns the ports mapped by the containers
+// of the task definition of the application's service.
+func (a *app) containerPorts() ([]caas.ServicePort, error) {
+	svc, err := a.describeService()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result, err := a.client.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: svc.TaskDefinition,
+	})
+	if err = a.handleErr(err); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ports []caas.ServicePort
+	for _, container := range result.TaskDefinition.ContainerDefinitions {
+		for _, mapping := range container.PortMappings {
+			port := int(aws.Int64Value(mapping.ContainerPort))
+			ports = append(ports, caas.ServicePort{
+				Name:       fmt.Sprintf("%s-%d", aws.StringValue(container.Name), port),
+				Port:       port,
+				TargetPort: port,
+				Protocol:   aws.StringValue(mapping.Protocol),
+			})
+		}
+	}
+	return ports, nil
 }
 
 func errorOrFailures(err error, failures []*ecs.Failure) error {
@@ -634,8 +841,25 @@ func (a *app) Watch() (watcher.NotifyWatcher, error) {
 
 // WatchReplicas returns a watcher for watching the number of units changes.
 func (a *app) WatchReplicas() (watcher.NotifyWatcher, error) {
-	// TODO(ecs)
-	return newNotifyWatcher(a.name, a.clock, func() (bool, error) { return false, nil })
+	var last string
+	hasChanged := func() (bool, error) {
+		svc, err := a.describeService()
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		counts := fmt.Sprintf("%d/%d/%d",
+			aws.Int64Value(svc.DesiredCount), aws.Int64Value(svc.RunningCount), aws.Int64Value(svc.PendingCount),
+		)
+		if counts == last {
+			return false, nil
+		}
+		last = counts
+		return true, nil
+	}
+	return newNotifyWatcher(a.name, a.clock, hasChanged)
 }
 
 func (a *app) registerTaskDefinition(config caas.ApplicationConfig) (*ecs.RegisterTaskDefinitionOutput, error) {
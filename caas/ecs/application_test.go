@@ -4,14 +4,19 @@
 package ecs_test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/caas"
 	coreresources "github.com/juju/juju/core/resources"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/storage"
 )
 
@@ -71,14 +76,6 @@ func (s *applicationSuite) assertEnsure(c *gc.C, app caas.Application, assertCal
 						Name:  aws.String("JUJU_CONTAINER_NAMES"),
 						Value: aws.String("gitlab"),
 					},
-					{
-						Name:  aws.String("JUJU_K8S_POD_NAME"),
-						Value: aws.String("cockroachdb-0"),
-					},
-					{
-						Name:  aws.String("JUJU_K8S_POD_UUID"),
-						Value: aws.String("c83b286e-8f45-4dbf-b2a6-0c393d93bd6a"),
-					},
 					// appSecret
 					{
 						Name:  aws.String("JUJU_K8S_APPLICATION"),
@@ -385,3 +382,230 @@ func (s *applicationSuite) TestDelete(c *gc.C) {
 
 	c.Assert(app.Delete(), jc.ErrorIsNil)
 }
+
+func (s *applicationSuite) TestScale(c *gc.C) {
+	app, ctrl := s.getApp(c, caas.DeploymentStateless)
+	defer ctrl.Finish()
+
+	s.ecsClient.EXPECT().UpdateService(&ecs.UpdateServiceInput{
+		Cluster:      aws.String("test-cluster"),
+		Service:      aws.String("test-gitlab"),
+		DesiredCount: aws.Int64(3),
+	}).Return(nil, nil)
+	c.Assert(app.Scale(3), jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestEnsureNetworkPolicy(c *gc.C) {
+	app, ctrl := s.getApp(c, caas.DeploymentStateless)
+	defer ctrl.Finish()
+
+	c.Assert(app.EnsureNetworkPolicy(nil), jc.ErrorIsNil)
+	err := app.EnsureNetworkPolicy(&caas.NetworkPolicy{Applications: []string{"mysql"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+// createService creates the application's service directly in the fake
+// ECS API, which launches the tasks in the PROVISIONING state.
+func (s *applicationSuite) createService(c *gc.C, desiredCount int64) []*ecs.Task {
+	_, err := s.fakeECS.RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+		Family: aws.String("test-gitlab"),
+		ContainerDefinitions: []*ecs.ContainerDefinition{{
+			Name:  aws.String("gitlab"),
+			Image: aws.String("gitlab-image:latest"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.fakeECS.CreateService(&ecs.CreateServiceInput{
+		Cluster:        aws.String(s.clusterName),
+		ServiceName:    aws.String("test-gitlab"),
+		TaskDefinition: aws.String("test-gitlab:1"),
+		DesiredCount:   aws.Int64(desiredCount),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tasks := s.fakeECS.Tasks(s.clusterName, "test-gitlab")
+	c.Assert(tasks, gc.HasLen, int(desiredCount))
+	return tasks
+}
+
+func (s *applicationSuite) TestExistsAndState(c *gc.C) {
+	s.setupFakes(c)
+	app := s.environ.Application(s.appName, caas.DeploymentStateless)
+
+	exists, err := app.Exists()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.DeepEquals, caas.DeploymentState{})
+	_, err = app.State()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	tasks := s.createService(c, 2)
+	exists, err = app.Exists()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.DeepEquals, caas.DeploymentState{Exists: true})
+
+	state, err := app.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, caas.ApplicationState{
+		DesiredReplicas: 2,
+		Replicas:        []string{aws.StringValue(tasks[0].TaskArn), aws.StringValue(tasks[1].TaskArn)},
+	})
+
+	c.Assert(app.Scale(1), jc.ErrorIsNil)
+	state, err = app.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, caas.ApplicationState{
+		DesiredReplicas: 1,
+		Replicas:        []string{aws.StringValue(tasks[0].TaskArn)},
+	})
+
+	c.Assert(app.Delete(), jc.ErrorIsNil)
+	exists, err = app.Exists()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.DeepEquals, caas.DeploymentState{})
+}
+
+func (s *applicationSuite) TestUnits(c *gc.C) {
+	s.setupFakes(c)
+	app := s.environ.Application(s.appName, caas.DeploymentStateful)
+
+	units, err := app.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+
+	now := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+	tasks := s.createService(c, 4)
+	err = s.fakeECS.UpdateTask(aws.StringValue(tasks[0].TaskArn), func(t *ecs.Task) {
+		t.LastStatus = aws.String("RUNNING")
+		t.StartedAt = aws.Time(now)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.fakeECS.UpdateTask(aws.StringValue(tasks[1].TaskArn), func(t *ecs.Task) {
+		t.LastStatus = aws.String("RUNNING")
+		t.HealthStatus = aws.String(ecs.HealthStatusUnhealthy)
+		t.StartedAt = aws.Time(now)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.fakeECS.UpdateTask(aws.StringValue(tasks[2].TaskArn), func(t *ecs.Task) {
+		t.LastStatus = aws.String("PENDING")
+		t.Containers = []*ecs.Container{{
+			Name:   aws.String("gitlab"),
+			Reason: aws.String("CannotPullContainerError: pull access denied"),
+		}}
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err = app.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 4)
+	for i, unit := range units {
+		c.Check(unit.Id, gc.Equals, aws.StringValue(tasks[i].TaskArn))
+		c.Check(unit.Address, gc.Equals, fmt.Sprintf("10.0.0.%d", i+1))
+		c.Check(unit.Stateful, jc.IsTrue)
+		c.Check(unit.Dying, jc.IsFalse)
+	}
+	c.Check(units[0].Status.Status, gc.Equals, status.Running)
+	c.Check(*units[0].Status.Since, gc.Equals, now)
+	c.Check(units[1].Status.Status, gc.Equals, status.Error)
+	c.Check(units[1].Status.Message, gc.Equals, "task health check failing")
+	c.Check(units[2].Status.Status, gc.Equals, status.Error)
+	c.Check(units[2].Status.Message, gc.Equals, `container "gitlab": CannotPullContainerError: pull access denied`)
+	c.Check(units[3].Status.Status, gc.Equals, status.Allocating)
+}
+
+func (s *applicationSuite) TestUnitsStopping(c *gc.C) {
+	s.setupFakes(c)
+	app := s.environ.Application(s.appName, caas.DeploymentStateless)
+
+	tasks := s.createService(c, 3)
+	err := s.fakeECS.UpdateTask(aws.StringValue(tasks[0].TaskArn), func(t *ecs.Task) {
+		t.DesiredStatus = aws.String(ecs.DesiredStatusStopped)
+		t.LastStatus = aws.String("STOPPING")
+		t.StopCode = aws.String(ecs.TaskStopCodeEssentialContainerExited)
+		t.StoppedReason = aws.String("Essential container in task exited")
+		t.Containers = []*ecs.Container{
+			{Name: aws.String("charm-init"), ExitCode: aws.Int64(0)},
+			{Name: aws.String("gitlab"), ExitCode: aws.Int64(137)},
+		}
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.fakeECS.UpdateTask(aws.StringValue(tasks[1].TaskArn), func(t *ecs.Task) {
+		t.DesiredStatus = aws.String(ecs.DesiredStatusStopped)
+		t.LastStatus = aws.String("DEPROVISIONING")
+		t.StopCode = aws.String(ecs.TaskStopCodeUserInitiated)
+		t.StoppedReason = aws.String("Task stopped by user")
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.fakeECS.UpdateTask(aws.StringValue(tasks[2].TaskArn), func(t *ecs.Task) {
+		t.DesiredStatus = aws.String(ecs.DesiredStatusStopped)
+		t.LastStatus = aws.String("STOPPED")
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := app.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	c.Check(units[0].Id, gc.Equals, aws.StringValue(tasks[0].TaskArn))
+	c.Check(units[0].Dying, jc.IsTrue)
+	c.Check(units[0].Status.Status, gc.Equals, status.Error)
+	c.Check(units[0].Status.Message, gc.Equals, `container "gitlab": exited with code 137`)
+	c.Check(units[1].Id, gc.Equals, aws.StringValue(tasks[1].TaskArn))
+	c.Check(units[1].Dying, jc.IsTrue)
+	c.Check(units[1].Status.Status, gc.Equals, status.Terminated)
+	c.Check(units[1].Status.Message, gc.Equals, "Task stopped by user")
+
+	state, err := app.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.Replicas, gc.HasLen, 0)
+}
+
+func (s *applicationSuite) TestEnsureEFSFilesystem(c *gc.C) {
+	app, ctrl := s.getApp(c, caas.DeploymentStateless)
+	defer ctrl.Finish()
+
+	var input *ecs.RegisterTaskDefinitionInput
+	s.ecsClient.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(in *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+			input = in
+			return nil, errors.New("boom")
+		},
+	)
+	err := app.Ensure(caas.ApplicationConfig{
+		AgentImagePath: "operator/image-path",
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Provider:    "ecs-efs",
+			Attributes: map[string]interface{}{
+				"file-system-id":  "fs-12345678",
+				"access-point-id": "fsap-1234",
+			},
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "path/to/here",
+			},
+		}},
+		Containers: map[string]caas.ContainerConfig{
+			"gitlab": {
+				Name:  "gitlab",
+				Image: coreresources.DockerImageDetails{RegistryPath: "gitlab-image:latest"},
+			},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(input, gc.NotNil)
+
+	var efsVolumes []*ecs.Volume
+	for _, vol := range input.Volumes {
+		if vol.EfsVolumeConfiguration != nil {
+			efsVolumes = append(efsVolumes, vol)
+		}
+	}
+	c.Assert(efsVolumes, jc.DeepEquals, []*ecs.Volume{{
+		Name: aws.String("gitlab-database"),
+		EfsVolumeConfiguration: &ecs.EFSVolumeConfiguration{
+			FileSystemId:      aws.String("fs-12345678"),
+			RootDirectory:     aws.String("/"),
+			TransitEncryption: aws.String(ecs.EFSTransitEncryptionEnabled),
+			AuthorizationConfig: &ecs.EFSAuthorizationConfig{
+				AccessPointId: aws.String("fsap-1234"),
+			},
+		},
+	}})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/golang/mock/gomock"
 	"github.com/juju/clock/testclock"
 	jc "github.com/juju/testing/checkers"
@@ -15,6 +16,7 @@ import (
 
 	provider "github.com/juju/juju/caas/ecs"
 	"github.com/juju/juju/caas/ecs/mocks"
+	ecstesting "github.com/juju/juju/caas/ecs/testing"
 	"github.com/juju/juju/cloud"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
@@ -31,6 +33,9 @@ type baseSuite struct {
 
 	ecsClient *mocks.MockECSAPI
 
+	fakeECS *ecstesting.FakeECS
+	fakeELB *ecstesting.FakeELB
+
 	clusterName string
 }
 
@@ -69,6 +74,8 @@ func (s *baseSuite) TearDownTest(c *gc.C) {
 	s.cfg = nil
 	s.awsConfig = nil
 	s.ecsClient = nil
+	s.fakeECS = nil
+	s.fakeELB = nil
 
 	s.BaseSuite.TearDownTest(c)
 }
@@ -77,6 +84,18 @@ func (s *baseSuite) setupController(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
 	s.ecsClient = mocks.NewMockECSAPI(ctrl)
+	s.newEnviron(c, s.ecsClient, ecstesting.NewFakeELB())
+	return ctrl
+}
+
+// setupFakes creates an environ backed by the in-memory ECS and ELB APIs.
+func (s *baseSuite) setupFakes(c *gc.C) {
+	s.fakeECS = ecstesting.NewFakeECS(s.clusterName)
+	s.fakeELB = ecstesting.NewFakeELB()
+	s.newEnviron(c, s.fakeECS, s.fakeELB)
+}
+
+func (s *baseSuite) newEnviron(c *gc.C, ecsClient ecsiface.ECSAPI, elbClient elbv2iface.ELBV2API) {
 	s.clock = testclock.NewClock(time.Time{})
 
 	var err error
@@ -84,9 +103,11 @@ func (s *baseSuite) setupController(c *gc.C) *gomock.Controller {
 		testing.ControllerTag.Id(), s.clusterName, s.clock,
 		s.cfg, s.awsConfig,
 		func(*aws.Config) (ecsiface.ECSAPI, error) {
-			return s.ecsClient, nil
+			return ecsClient, nil
+		},
+		func(*aws.Config) (elbv2iface.ELBV2API, error) {
+			return elbClient, nil
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return ctrl
 }
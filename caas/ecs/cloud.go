@@ -4,16 +4,33 @@
 package ecs
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
+	"github.com/juju/utils/v2"
 	"gopkg.in/juju/environschema.v1"
 
+	"github.com/juju/juju/caas/ecs/constants"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
 )
 
-var configSchema = environschema.Fields{}
+const (
+	// loadBalancerARNKey is the model config key for the ARN of the
+	// load balancer that exposed applications are served through.
+	loadBalancerARNKey = "load-balancer-arn"
+)
+
+var configSchema = environschema.Fields{
+	loadBalancerARNKey: {
+		Description: "The ARN of an application or network load balancer in the cluster's VPC used to expose applications (optional).",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+}
 
 func providerConfigFields() (schema.Fields, error) {
 	fs, _, err := configSchema.ValidationSchema()
@@ -23,7 +40,9 @@ func providerConfigFields() (schema.Fields, error) {
 	return fs, nil
 }
 
-var providerConfigDefaults = schema.Defaults{}
+var providerConfigDefaults = schema.Defaults{
+	loadBalancerARNKey: schema.Omit,
+}
 
 type brokerConfig struct {
 	*config.Config
@@ -92,3 +111,90 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 func (p environProvider) FinalizeCloud(ctx environs.FinalizeCloudContext, cld cloud.Cloud) (cloud.Cloud, error) {
 	return cld, nil
 }
+
+// CloudParams defines the parameters used for adding an ECS cluster as a cloud.
+type CloudParams struct {
+	CloudName   string
+	ClusterName string
+	Region      string
+	AccessKey   string
+	SecretKey   string
+}
+
+// Validate checks that all the parameters are set.
+func (p CloudParams) Validate() error {
+	if p.CloudName == "" {
+		return errors.NotValidf("empty cloud name")
+	}
+	if p.ClusterName == "" {
+		return errors.NotValidf("empty cluster name")
+	}
+	if p.Region == "" {
+		return errors.NotValidf("empty region")
+	}
+	if p.AccessKey == "" || p.SecretKey == "" {
+		return errors.NotValidf("empty access key or secret key")
+	}
+	return nil
+}
+
+// CloudFromParams returns the cloud and credential for the ECS cluster.
+func CloudFromParams(p CloudParams) (cloud.Cloud, cloud.Credential, error) {
+	if err := p.Validate(); err != nil {
+		return cloud.Cloud{}, cloud.Credential{}, errors.Trace(err)
+	}
+	endpoint := fmt.Sprintf("https://ecs.%s.amazonaws.com", p.Region)
+	newCloud := cloud.Cloud{
+		Name:        p.CloudName,
+		Type:        constants.ECSProviderType,
+		Description: cloud.DefaultCloudDescription(constants.ECSProviderType),
+		AuthTypes:   cloud.AuthTypes{cloud.AccessKeyAuthType},
+		Endpoint:    endpoint,
+		Regions: []cloud.Region{{
+			Name:     p.Region,
+			Endpoint: endpoint,
+		}},
+	}
+	credential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		credAttrClusterName: p.ClusterName,
+		credAttrRegionKey:   p.Region,
+		credAttrAccessKey:   p.AccessKey,
+		credAttrSecretKey:   p.SecretKey,
+	})
+	return newCloud, credential, nil
+}
+
+// BaseCloudOpenParams returns the params to open a broker for the
+// ECS cloud outside of any model, to check the cluster is usable.
+func BaseCloudOpenParams(cld cloud.Cloud, credential cloud.Credential, controllerUUID string) (environs.OpenParams, error) {
+	// The model config isn't used unless operating on a
+	// real model but we need to supply it.
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return environs.OpenParams{}, errors.Trace(err)
+	}
+	cfg, err := config.New(config.NoDefaults, map[string]interface{}{
+		config.NameKey: "add-cloud",
+		config.TypeKey: constants.ECSProviderType,
+		config.UUIDKey: uuid.String(),
+	})
+	if err != nil {
+		return environs.OpenParams{}, errors.Trace(err)
+	}
+	var region string
+	if len(cld.Regions) > 0 {
+		region = cld.Regions[0].Name
+	}
+	cloudSpec, err := environscloudspec.MakeCloudSpec(cld, region, &credential)
+	if err != nil {
+		return environs.OpenParams{}, errors.Trace(err)
+	}
+	if controllerUUID == "" {
+		controllerUUID = uuid.String()
+	}
+	return environs.OpenParams{
+		ControllerUUID: controllerUUID,
+		Cloud:          cloudSpec,
+		Config:         cfg,
+	}, nil
+}
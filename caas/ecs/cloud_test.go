@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/ecs"
+	"github.com/juju/juju/cloud"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type cloudSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&cloudSuite{})
+
+func (s *cloudSuite) TestCloudFromParams(c *gc.C) {
+	newCloud, credential, err := ecs.CloudFromParams(ecs.CloudParams{
+		CloudName:   "myecs",
+		ClusterName: "mycluster",
+		Region:      "ap-southeast-2",
+		AccessKey:   "access-key",
+		SecretKey:   "secret-key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCloud, jc.DeepEquals, cloud.Cloud{
+		Name:        "myecs",
+		Type:        "ecs",
+		Description: "Amazon Elastic Container Service",
+		AuthTypes:   cloud.AuthTypes{cloud.AccessKeyAuthType},
+		Endpoint:    "https://ecs.ap-southeast-2.amazonaws.com",
+		Regions: []cloud.Region{{
+			Name:     "ap-southeast-2",
+			Endpoint: "https://ecs.ap-southeast-2.amazonaws.com",
+		}},
+	})
+	c.Assert(credential.AuthType(), gc.Equals, cloud.AccessKeyAuthType)
+	c.Assert(credential.Attributes(), jc.DeepEquals, map[string]string{
+		"cluster-name": "mycluster",
+		"region":       "ap-southeast-2",
+		"access-key":   "access-key",
+		"secret-key":   "secret-key",
+	})
+}
+
+func (s *cloudSuite) TestCloudFromParamsInvalid(c *gc.C) {
+	_, _, err := ecs.CloudFromParams(ecs.CloudParams{
+		CloudName: "myecs",
+		Region:    "ap-southeast-2",
+		AccessKey: "access-key",
+		SecretKey: "secret-key",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "empty cluster name not valid")
+}
+
+func (s *cloudSuite) TestBaseCloudOpenParams(c *gc.C) {
+	newCloud, credential, err := ecs.CloudFromParams(ecs.CloudParams{
+		CloudName:   "myecs",
+		ClusterName: "mycluster",
+		Region:      "ap-southeast-2",
+		AccessKey:   "access-key",
+		SecretKey:   "secret-key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	params, err := ecs.BaseCloudOpenParams(newCloud, credential, coretesting.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.ControllerUUID, gc.Equals, coretesting.ControllerTag.Id())
+	c.Assert(params.Cloud.Region, gc.Equals, "ap-southeast-2")
+	c.Assert(params.Cloud.Endpoint, gc.Equals, "https://ecs.ap-southeast-2.amazonaws.com")
+
+	broker, err := ecs.NewProvider().Open(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(broker, gc.NotNil)
+
+	// Without a controller, a placeholder UUID is used.
+	params, err = ecs.BaseCloudOpenParams(newCloud, credential, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.ControllerUUID, gc.Not(gc.Equals), "")
+}
@@ -7,7 +7,9 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	jujuclock "github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -37,12 +39,16 @@ type environ struct {
 	envCfgUnlocked *config.Config
 	awsCfgUnlocked *aws.Config
 
-	clientUnlocked ecsiface.ECSAPI
-	newECSClient   newECSClientFunc
+	clientUnlocked    ecsiface.ECSAPI
+	elbClientUnlocked elbv2iface.ELBV2API
+	newECSClient      newECSClientFunc
+	newELBClient      newELBClientFunc
 }
 
 type newECSClientFunc func(*aws.Config) (ecsiface.ECSAPI, error)
 
+type newELBClientFunc func(*aws.Config) (elbv2iface.ELBV2API, error)
+
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/ecs_mock.go github.com/aws/aws-sdk-go/service/ecs/ecsiface ECSAPI
 func newEnviron(
 	controllerUUID string,
@@ -51,6 +57,7 @@ func newEnviron(
 	envCfg *config.Config,
 	awsCfg *aws.Config,
 	newECSClient func(*aws.Config) (ecsiface.ECSAPI, error),
+	newELBClient func(*aws.Config) (elbv2iface.ELBV2API, error),
 ) (_ *environ, err error) {
 	if controllerUUID == "" {
 		return nil, errors.NotValidf("controllerUUID is required")
@@ -77,10 +84,14 @@ func newEnviron(
 		envCfgUnlocked: envCfg,
 		awsCfgUnlocked: awsCfg,
 		newECSClient:   newECSClient,
+		newELBClient:   newELBClient,
 	}
 	if env.clientUnlocked, err = newECSClient(awsCfg); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if env.elbClientUnlocked, err = newELBClient(awsCfg); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return env, nil
 }
 
@@ -91,6 +102,20 @@ func (env *environ) client() ecsiface.ECSAPI {
 	return client
 }
 
+func (env *environ) elbClient() elbv2iface.ELBV2API {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	client := env.elbClientUnlocked
+	return client
+}
+
+// loadBalancerARN returns the load balancer which
+// exposed applications are served through, if any.
+func (env *environ) loadBalancerARN() string {
+	arn, _ := env.Config().UnknownAttrs()[loadBalancerARNKey].(string)
+	return arn
+}
+
 // APIVersion returns the version info for the cluster.
 func (env *environ) APIVersion() (string, error) {
 	// TODO(ecs)
@@ -118,7 +143,10 @@ func (env *environ) SetCloudSpec(spec cloudspec.CloudSpec) (err error) {
 	if env.awsCfgUnlocked, err = cloudSpecToAWSConfig(spec); err != nil {
 		return errors.Annotate(err, "validating cloud spec")
 	}
-	if env.clientUnlocked, err = newECSClient(env.awsCfgUnlocked); err != nil {
+	if env.clientUnlocked, err = env.newECSClient(env.awsCfgUnlocked); err != nil {
+		return errors.Trace(err)
+	}
+	if env.elbClientUnlocked, err = env.newELBClient(env.awsCfgUnlocked); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
@@ -148,7 +176,19 @@ func (env *environ) SetConfig(cfg *config.Config) error {
 // CheckCloudCredentials verifies the the cloud credentials provided to the
 // broker are functioning.
 func (env *environ) CheckCloudCredentials() error {
-	// TODO(ecs)
+	result, err := env.client().DescribeClusters(&ecs.DescribeClustersInput{
+		Clusters: []*string{aws.String(env.clusterName)},
+	})
+	if err != nil {
+		return errors.Annotatef(err, "describing cluster %q", env.clusterName)
+	}
+	// A missing cluster is reported as a failure rather than an error.
+	if len(result.Clusters) == 0 {
+		return errors.NotFoundf("cluster %q", env.clusterName)
+	}
+	if clusterStatus := aws.StringValue(result.Clusters[0].Status); clusterStatus != "ACTIVE" {
+		return errors.NotValidf("cluster %q with status %q", env.clusterName, clusterStatus)
+	}
 	return nil
 }
 
@@ -214,10 +254,14 @@ func (env *environ) EnsureService(
 	return nil
 }
 
-// ExposeService sets up external access to the specified application.
-func (env *environ) ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error {
-	// TODO(ecs): remove from caas.Broker?
-	return nil
+// ExposeService sets up external access to the specified application
+// through the model's load balancer, on the container ports of the
+// application's task definition.
+func (env *environ) ExposeService(appName string, resourceTags map[string]string, exposedEndpoints []string, config application.ConfigAttributes) error {
+	a := env.application(appName, caas.DeploymentStateless)
+	return errors.Trace(a.UpdateService(caas.ServiceParam{
+		Type: string(caas.ServiceLoadBalancer),
+	}))
 }
 
 // GetAnnotations returns current namespace's annotations.
@@ -228,14 +272,14 @@ func (env *environ) GetAnnotations() annotations.Annotation {
 
 // GetService returns the service for the specified application.
 func (env *environ) GetService(appName string, mode caas.DeploymentMode, includeClusterIP bool) (*caas.Service, error) {
-	// TODO(ecs): remove from caas.Broker?
-	return nil, nil
+	a := env.application(appName, caas.DeploymentStateless)
+	return a.service()
 }
 
 // UnexposeService removes external access to the specified service.
 func (env *environ) UnexposeService(appName string) error {
-	// TODO(ecs): remove from caas.Broker?
-	return nil
+	a := env.application(appName, caas.DeploymentStateless)
+	return errors.Trace(a.removeLoadBalancing())
 }
 
 // Units returns all units and any associated filesystems of the specified application.
@@ -254,6 +298,11 @@ func (env *environ) WatchContainerStart(appName string, containerName string) (w
 	return nil, nil
 }
 
+// UnitEvents returns the most recent events for the specified unit.
+func (env *environ) UnitEvents(providerId string) ([]caas.UnitEvent, error) {
+	return nil, errors.NotSupportedf("unit events")
+}
+
 // WatchService returns a watcher which notifies when there
 // are changes to the deployment of the specified application.
 func (env *environ) WatchService(appName string, mode caas.DeploymentMode) (watcher.NotifyWatcher, error) {
@@ -277,8 +326,13 @@ func (env *environ) AdoptResources(ctx envcontext.ProviderCallContext, controlle
 
 // Application returns an Application interface.
 func (env *environ) Application(name string, deploymentType caas.DeploymentType) caas.Application {
+	return env.application(name, deploymentType)
+}
+
+func (env *environ) application(name string, deploymentType caas.DeploymentType) *app {
 	return newApplication(
-		name, env.clusterName, env.controllerUUID, env.modelUUID, env.CurrentModel(), deploymentType, env.client(), env.clock,
+		name, env.clusterName, env.controllerUUID, env.modelUUID, env.CurrentModel(), deploymentType,
+		env.client(), env.elbClient(), env.loadBalancerARN(), env.clock,
 	)
 }
 
@@ -2,3 +2,39 @@
 // Licensed under the AGPLv3, see LICENCE file for details.
 
 package ecs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type environSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) TestCheckCloudCredentials(c *gc.C) {
+	s.setupFakes(c)
+	c.Assert(s.environ.CheckCloudCredentials(), jc.ErrorIsNil)
+}
+
+func (s *environSuite) TestCheckCloudCredentialsClusterNotFound(c *gc.C) {
+	s.setupFakes(c)
+	s.clusterName = "another-cluster"
+	s.newEnviron(c, s.fakeECS, s.fakeELB)
+
+	err := s.environ.CheckCloudCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cluster "another-cluster" not found`)
+}
+
+func (s *environSuite) TestCheckCloudCredentialsClusterInactive(c *gc.C) {
+	s.setupFakes(c)
+	s.fakeECS.AddCluster(s.clusterName, "INACTIVE")
+
+	err := s.environ.CheckCloudCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `cluster "test-cluster" with status "INACTIVE" not valid`)
+}
@@ -0,0 +1,355 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/network"
+)
+
+// ECS only allows load balancers to be attached when a service is created,
+// so exposed applications get a target group with IP targets and a listener
+// on the model's load balancer for each port, and the targets are kept in
+// step with the application's tasks.
+
+// targetGroupPrefix returns the prefix of the names of the application's
+// target groups. Target group names are limited to 32 characters, so a
+// hash of the model and application is used rather than the names.
+func (a *app) targetGroupPrefix() string {
+	sum := sha256.Sum256([]byte(a.modelUUID + "/" + a.name))
+	return fmt.Sprintf("juju-%x-", sum[:6])
+}
+
+func (a *app) targetGroupName(port int) string {
+	return fmt.Sprintf("%s%d", a.targetGroupPrefix(), port)
+}
+
+func (a *app) loadBalancer() (*elbv2.LoadBalancer, error) {
+	result, err := a.elbClient.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []*string{aws.String(a.loadBalancerARN)},
+	})
+	if err = handleELBErr(err); err != nil {
+		return nil, errors.Annotatef(err, "load balancer %q", a.loadBalancerARN)
+	}
+	if len(result.LoadBalancers) == 0 {
+		return nil, errors.NotFoundf("load balancer %q", a.loadBalancerARN)
+	}
+	return result.LoadBalancers[0], nil
+}
+
+// targetGroups returns the application's target groups keyed by name.
+func (a *app) targetGroups() (map[string]*elbv2.TargetGroup, error) {
+	prefix := a.targetGroupPrefix()
+	groups := make(map[string]*elbv2.TargetGroup)
+	input := &elbv2.DescribeTargetGroupsInput{}
+	for {
+		result, err := a.elbClient.DescribeTargetGroups(input)
+		if err = handleELBErr(err); err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, tg := range result.TargetGroups {
+			if name := aws.StringValue(tg.TargetGroupName); strings.HasPrefix(name, prefix) {
+				groups[name] = tg
+			}
+		}
+		if result.NextMarker == nil {
+			return groups, nil
+		}
+		input.Marker = result.NextMarker
+	}
+}
+
+// listeners returns the load balancer's listeners forwarding
+// to the target groups, keyed by target group ARN.
+func (a *app) listeners(groups map[string]*elbv2.TargetGroup) (map[string]*elbv2.Listener, error) {
+	groupARNs := set.NewStrings()
+	for _, tg := range groups {
+		groupARNs.Add(aws.StringValue(tg.TargetGroupArn))
+	}
+	listeners := make(map[string]*elbv2.Listener)
+	input := &elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(a.loadBalancerARN),
+	}
+	for {
+		result, err := a.elbClient.DescribeListeners(input)
+		if err = handleELBErr(err); err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, l := range result.Listeners {
+			for _, action := range l.DefaultActions {
+				if arn := aws.StringValue(action.TargetGroupArn); groupARNs.Contains(arn) {
+					listeners[arn] = l
+				}
+			}
+		}
+		if result.NextMarker == nil {
+			return listeners, nil
+		}
+		input.Marker = result.NextMarker
+	}
+}
+
+// ensureLoadBalancing creates a listener and target group for each
+// of the ports and removes those for ports no longer exposed.
+func (a *app) ensureLoadBalancing(ports []caas.ServicePort) error {
+	lb, err := a.loadBalancer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	groups, err := a.targetGroups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	listeners, err := a.listeners(groups)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	wanted := set.NewStrings()
+	for _, port := range ports {
+		name := a.targetGroupName(port.Port)
+		wanted.Add(name)
+		if tg, ok := groups[name]; ok {
+			if _, ok := listeners[aws.StringValue(tg.TargetGroupArn)]; ok {
+				continue
+			}
+		}
+		protocol := strings.ToUpper(port.Protocol)
+		if protocol == "" {
+			protocol = elbv2.ProtocolEnumTcp
+		}
+		if aws.StringValue(lb.Type) == elbv2.LoadBalancerTypeEnumApplication {
+			protocol = elbv2.ProtocolEnumHttp
+		}
+		targetPort := port.TargetPort
+		if targetPort == 0 {
+			targetPort = port.Port
+		}
+		tg, ok := groups[name]
+		if !ok {
+			result, err := a.elbClient.CreateTargetGroup(&elbv2.CreateTargetGroupInput{
+				Name:       aws.String(name),
+				Port:       aws.Int64(int64(targetPort)),
+				Protocol:   aws.String(protocol),
+				TargetType: aws.String(elbv2.TargetTypeEnumIp),
+				VpcId:      lb.VpcId,
+				Tags:       a.elbTags(),
+			})
+			if err = handleELBErr(err); err != nil {
+				return errors.Annotatef(err, "creating target group for port %d", port.Port)
+			}
+			tg = result.TargetGroups[0]
+			groups[name] = tg
+		}
+		_, err := a.elbClient.CreateListener(&elbv2.CreateListenerInput{
+			LoadBalancerArn: aws.String(a.loadBalancerARN),
+			Port:            aws.Int64(int64(port.Port)),
+			Protocol:        aws.String(protocol),
+			DefaultActions: []*elbv2.Action{{
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+				TargetGroupArn: tg.TargetGroupArn,
+			}},
+		})
+		if err = handleELBErr(err); err != nil {
+			return errors.Annotatef(err, "creating listener for port %d", port.Port)
+		}
+	}
+
+	for name, tg := range groups {
+		if wanted.Contains(name) {
+			continue
+		}
+		if err := a.deleteTargetGroup(tg, listeners); err != nil {
+			return errors.Trace(err)
+		}
+		delete(groups, name)
+	}
+
+	tasks, err := a.tasks()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.registerTargets(groups, tasks))
+}
+
+// removeLoadBalancing removes the application's listeners and target groups.
+func (a *app) removeLoadBalancing() error {
+	if a.loadBalancerARN == "" {
+		return nil
+	}
+	groups, err := a.targetGroups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	listeners, err := a.listeners(groups)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, tg := range groups {
+		if err := a.deleteTargetGroup(tg, listeners); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// deleteTargetGroup deletes the target group along with the listener
+// forwarding to it, which must go first.
+func (a *app) deleteTargetGroup(tg *elbv2.TargetGroup, listeners map[string]*elbv2.Listener) error {
+	if l, ok := listeners[aws.StringValue(tg.TargetGroupArn)]; ok {
+		_, err := a.elbClient.DeleteListener(&elbv2.DeleteListenerInput{
+			ListenerArn: l.ListenerArn,
+		})
+		if err = handleELBErr(err); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting listener %q", aws.StringValue(l.ListenerArn))
+		}
+	}
+	_, err := a.elbClient.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{
+		TargetGroupArn: tg.TargetGroupArn,
+	})
+	if err = handleELBErr(err); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting target group %q", aws.StringValue(tg.TargetGroupName))
+	}
+	return nil
+}
+
+// syncTargets registers the addresses of the tasks with the
+// application's target groups.
+func (a *app) syncTargets(tasks []*ecs.Task) error {
+	groups, err := a.targetGroups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.registerTargets(groups, tasks))
+}
+
+// registerTargets makes the targets of each group the addresses
+// of the running tasks.
+func (a *app) registerTargets(groups map[string]*elbv2.TargetGroup, tasks []*ecs.Task) error {
+	addresses := set.NewStrings()
+	for _, t := range tasks {
+		if aws.StringValue(t.LastStatus) != ecs.DesiredStatusRunning ||
+			aws.StringValue(t.DesiredStatus) != ecs.DesiredStatusRunning {
+			continue
+		}
+		if addr := taskAddress(t); addr != "" {
+			addresses.Add(addr)
+		}
+	}
+	for _, tg := range groups {
+		result, err := a.elbClient.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: tg.TargetGroupArn,
+		})
+		if err = handleELBErr(err); err != nil {
+			return errors.Trace(err)
+		}
+		current := set.NewStrings()
+		var remove []*elbv2.TargetDescription
+		for _, th := range result.TargetHealthDescriptions {
+			id := aws.StringValue(th.Target.Id)
+			current.Add(id)
+			if !addresses.Contains(id) {
+				remove = append(remove, th.Target)
+			}
+		}
+		var add []*elbv2.TargetDescription
+		for _, addr := range addresses.Difference(current).SortedValues() {
+			add = append(add, &elbv2.TargetDescription{Id: aws.String(addr), Port: tg.Port})
+		}
+		if len(add) > 0 {
+			_, err := a.elbClient.RegisterTargets(&elbv2.RegisterTargetsInput{
+				TargetGroupArn: tg.TargetGroupArn,
+				Targets:        add,
+			})
+			if err = handleELBErr(err); err != nil {
+				return errors.Annotatef(err, "registering targets with %q", aws.StringValue(tg.TargetGroupName))
+			}
+		}
+		if len(remove) > 0 {
+			_, err := a.elbClient.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+				TargetGroupArn: tg.TargetGroupArn,
+				Targets:        remove,
+			})
+			if err = handleELBErr(err); err != nil {
+				return errors.Annotatef(err, "deregistering targets from %q", aws.StringValue(tg.TargetGroupName))
+			}
+		}
+	}
+	return nil
+}
+
+func (a *app) elbTags() (out []*elbv2.Tag) {
+	for k, v := range a.labels(nil) {
+		out = append(out, &elbv2.Tag{Key: aws.String(k), Value: v})
+	}
+	return out
+}
+
+// service returns the application's service, with the
+// load balancer's address if the application is exposed.
+func (a *app) service() (*caas.Service, error) {
+	svc, err := a.describeService()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	scale := int(aws.Int64Value(svc.DesiredCount))
+	result := &caas.Service{
+		Id:    aws.StringValue(svc.ServiceArn),
+		Scale: &scale,
+	}
+	if a.loadBalancerARN == "" {
+		return result, nil
+	}
+	groups, err := a.targetGroups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(groups) == 0 {
+		return result, nil
+	}
+	lb, err := a.loadBalancer()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if dnsName := aws.StringValue(lb.DNSName); dnsName != "" {
+		result.Addresses = network.ProviderAddresses{
+			network.NewScopedProviderAddress(dnsName, network.ScopePublic),
+		}
+	}
+	return result, nil
+}
+
+func handleELBErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	switch aerr.Code() {
+	case elbv2.ErrCodeLoadBalancerNotFoundException, elbv2.ErrCodeTargetGroupNotFoundException,
+		elbv2.ErrCodeListenerNotFoundException:
+		return errors.NewNotFound(err, aerr.Message())
+	case elbv2.ErrCodeDuplicateTargetGroupNameException, elbv2.ErrCodeDuplicateListenerException:
+		return errors.NewAlreadyExists(err, aerr.Message())
+	case elbv2.ErrCodeInvalidConfigurationRequestException:
+		return errors.NewNotValid(err, aerr.Message())
+	}
+	return err
+}
@@ -0,0 +1,263 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ecs_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	ecstesting "github.com/juju/juju/caas/ecs/testing"
+	"github.com/juju/juju/core/network"
+)
+
+type loadBalancerSuite struct {
+	baseSuite
+
+	lbArn string
+	app   caas.Application
+	tasks []*ecs.Task
+}
+
+var _ = gc.Suite(&loadBalancerSuite{})
+
+func (s *loadBalancerSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.setupFakes(c)
+
+	s.lbArn = s.fakeELB.AddLoadBalancer("juju", elbv2.LoadBalancerTypeEnumNetwork, "vpc-1234")
+	cfg, err := s.cfg.Apply(map[string]interface{}{"load-balancer-arn": s.lbArn})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.environ.SetConfig(cfg), jc.ErrorIsNil)
+
+	s.app = s.environ.Application("gitlab", caas.DeploymentStateless)
+	s.tasks = s.createRunningService(c, 2)
+}
+
+func (s *loadBalancerSuite) createRunningService(c *gc.C, desiredCount int64) []*ecs.Task {
+	_, err := s.fakeECS.RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+		Family: aws.String("test-gitlab"),
+		ContainerDefinitions: []*ecs.ContainerDefinition{{
+			Name:  aws.String("gitlab"),
+			Image: aws.String("gitlab-image:latest"),
+			PortMappings: []*ecs.PortMapping{{
+				ContainerPort: aws.Int64(8080),
+				Protocol:      aws.String(ecs.TransportProtocolTcp),
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.fakeECS.CreateService(&ecs.CreateServiceInput{
+		Cluster:        aws.String(s.clusterName),
+		ServiceName:    aws.String("test-gitlab"),
+		TaskDefinition: aws.String("test-gitlab:1"),
+		DesiredCount:   aws.Int64(desiredCount),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.setTasksRunning(c)
+	return s.fakeECS.Tasks(s.clusterName, "test-gitlab")
+}
+
+func (s *loadBalancerSuite) setTasksRunning(c *gc.C) {
+	for _, t := range s.fakeECS.Tasks(s.clusterName, "test-gitlab") {
+		err := s.fakeECS.UpdateTask(aws.StringValue(t.TaskArn), func(t *ecs.Task) {
+			if aws.StringValue(t.DesiredStatus) == ecs.DesiredStatusRunning {
+				t.LastStatus = aws.String(ecs.DesiredStatusRunning)
+			}
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *loadBalancerSuite) expose(c *gc.C, ports ...caas.ServicePort) {
+	err := s.app.UpdateService(caas.ServiceParam{
+		Type:  "LoadBalancer",
+		Ports: ports,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loadBalancerSuite) TestExpose(c *gc.C) {
+	s.expose(c,
+		caas.ServicePort{Name: "http", Port: 80, TargetPort: 8080, Protocol: "tcp"},
+		caas.ServicePort{Name: "dns", Port: 53, Protocol: "udp"},
+	)
+
+	groups := s.fakeELB.TargetGroups()
+	c.Assert(groups, gc.HasLen, 2)
+	c.Assert(aws.StringValue(groups[0].TargetGroupName), gc.Matches, `juju-[0-9a-f]{12}-53`)
+	c.Assert(aws.Int64Value(groups[0].Port), gc.Equals, int64(53))
+	c.Assert(aws.StringValue(groups[0].Protocol), gc.Equals, "UDP")
+	c.Assert(aws.StringValue(groups[1].TargetGroupName), gc.Matches, `juju-[0-9a-f]{12}-80`)
+	c.Assert(aws.Int64Value(groups[1].Port), gc.Equals, int64(8080))
+	c.Assert(aws.StringValue(groups[1].Protocol), gc.Equals, "TCP")
+	for _, tg := range groups {
+		c.Check(aws.StringValue(tg.TargetType), gc.Equals, elbv2.TargetTypeEnumIp)
+		c.Check(aws.StringValue(tg.VpcId), gc.Equals, "vpc-1234")
+		c.Check(s.fakeELB.Targets(aws.StringValue(tg.TargetGroupArn)), jc.DeepEquals, []string{"10.0.0.1", "10.0.0.2"})
+	}
+
+	listeners := s.fakeELB.Listeners(s.lbArn)
+	c.Assert(listeners, gc.HasLen, 2)
+	c.Assert(aws.Int64Value(listeners[0].Port), gc.Equals, int64(53))
+	c.Assert(listeners[0].DefaultActions[0].TargetGroupArn, gc.DeepEquals, groups[0].TargetGroupArn)
+	c.Assert(aws.Int64Value(listeners[1].Port), gc.Equals, int64(80))
+	c.Assert(listeners[1].DefaultActions[0].TargetGroupArn, gc.DeepEquals, groups[1].TargetGroupArn)
+
+	svc, err := s.environ.GetService("gitlab", caas.ModeEmbedded, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Addresses, jc.DeepEquals, network.ProviderAddresses{
+		network.NewScopedProviderAddress("juju-1.elb."+ecstesting.Region+".amazonaws.com", network.ScopePublic),
+	})
+	c.Assert(*svc.Scale, gc.Equals, 2)
+
+	// Exposing again changes nothing.
+	s.expose(c,
+		caas.ServicePort{Name: "http", Port: 80, TargetPort: 8080, Protocol: "tcp"},
+		caas.ServicePort{Name: "dns", Port: 53, Protocol: "udp"},
+	)
+	c.Assert(s.fakeELB.TargetGroups(), jc.DeepEquals, groups)
+	c.Assert(s.fakeELB.Listeners(s.lbArn), jc.DeepEquals, listeners)
+}
+
+func (s *loadBalancerSuite) TestExposeApplicationLoadBalancer(c *gc.C) {
+	s.lbArn = s.fakeELB.AddLoadBalancer("juju-alb", elbv2.LoadBalancerTypeEnumApplication, "vpc-1234")
+	cfg, err := s.cfg.Apply(map[string]interface{}{"load-balancer-arn": s.lbArn})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.environ.SetConfig(cfg), jc.ErrorIsNil)
+	s.app = s.environ.Application("gitlab", caas.DeploymentStateless)
+
+	s.expose(c, caas.ServicePort{Name: "http", Port: 80, TargetPort: 8080, Protocol: "tcp"})
+
+	groups := s.fakeELB.TargetGroups()
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(aws.StringValue(groups[0].Protocol), gc.Equals, elbv2.ProtocolEnumHttp)
+	listeners := s.fakeELB.Listeners(s.lbArn)
+	c.Assert(listeners, gc.HasLen, 1)
+	c.Assert(aws.StringValue(listeners[0].Protocol), gc.Equals, elbv2.ProtocolEnumHttp)
+}
+
+func (s *loadBalancerSuite) TestExposeRemovesUnwantedPorts(c *gc.C) {
+	s.expose(c,
+		caas.ServicePort{Name: "http", Port: 80, TargetPort: 8080},
+		caas.ServicePort{Name: "https", Port: 443, TargetPort: 8443},
+	)
+	c.Assert(s.fakeELB.TargetGroups(), gc.HasLen, 2)
+
+	s.expose(c, caas.ServicePort{Name: "https", Port: 443, TargetPort: 8443})
+	groups := s.fakeELB.TargetGroups()
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(aws.StringValue(groups[0].TargetGroupName), gc.Matches, `juju-[0-9a-f]{12}-443`)
+	listeners := s.fakeELB.Listeners(s.lbArn)
+	c.Assert(listeners, gc.HasLen, 1)
+	c.Assert(aws.Int64Value(listeners[0].Port), gc.Equals, int64(443))
+}
+
+func (s *loadBalancerSuite) TestUnexpose(c *gc.C) {
+	s.expose(c, caas.ServicePort{Name: "http", Port: 80, TargetPort: 8080})
+
+	err := s.app.UpdateService(caas.ServiceParam{Type: "ClusterIP"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeELB.TargetGroups(), gc.HasLen, 0)
+	c.Assert(s.fakeELB.Listeners(s.lbArn), gc.HasLen, 0)
+
+	svc, err := s.environ.GetService("gitlab", caas.ModeEmbedded, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Addresses, gc.HasLen, 0)
+}
+
+func (s *loadBalancerSuite) TestExposeService(c *gc.C) {
+	err := s.environ.ExposeService("gitlab", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The container ports of the task definition are exposed.
+	groups := s.fakeELB.TargetGroups()
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(aws.StringValue(groups[0].TargetGroupName), gc.Matches, `juju-[0-9a-f]{12}-8080`)
+	c.Assert(aws.Int64Value(groups[0].Port), gc.Equals, int64(8080))
+	c.Assert(aws.StringValue(groups[0].Protocol), gc.Equals, "TCP")
+	c.Assert(s.fakeELB.Targets(aws.StringValue(groups[0].TargetGroupArn)), jc.DeepEquals, []string{"10.0.0.1", "10.0.0.2"})
+	listeners := s.fakeELB.Listeners(s.lbArn)
+	c.Assert(listeners, gc.HasLen, 1)
+	c.Assert(aws.Int64Value(listeners[0].Port), gc.Equals, int64(8080))
+
+	err = s.environ.UnexposeService("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeELB.TargetGroups(), gc.HasLen, 0)
+	c.Assert(s.fakeELB.Listeners(s.lbArn), gc.HasLen, 0)
+
+	// Unexposing again has nothing to do.
+	err = s.environ.UnexposeService("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loadBalancerSuite) TestExposeServiceWithoutLoadBalancer(c *gc.C) {
+	cfg, err := s.cfg.Remove([]string{"load-balancer-arn"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.environ.SetConfig(cfg), jc.ErrorIsNil)
+
+	err = s.environ.ExposeService("gitlab", nil, nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.environ.UnexposeService("gitlab"), jc.ErrorIsNil)
+}
+
+func (s *loadBalancerSuite) TestDeleteRemovesLoadBalancing(c *gc.C) {
+	s.expose(c, caas.ServicePort{Name: "http", Port: 80, TargetPort: 8080})
+
+	c.Assert(s.app.Delete(), jc.ErrorIsNil)
+	c.Assert(s.fakeELB.TargetGroups(), gc.HasLen, 0)
+	c.Assert(s.fakeELB.Listeners(s.lbArn), gc.HasLen, 0)
+}
+
+func (s *loadBalancerSuite) TestUnitsSyncTargets(c *gc.C) {
+	s.expose(c, caas.ServicePort{Name: "http", Port: 80, TargetPort: 8080})
+	tgArn := aws.StringValue(s.fakeELB.TargetGroups()[0].TargetGroupArn)
+	c.Assert(s.fakeELB.Targets(tgArn), jc.DeepEquals, []string{"10.0.0.1", "10.0.0.2"})
+
+	c.Assert(s.app.Scale(3), jc.ErrorIsNil)
+	s.setTasksRunning(c)
+	units, err := s.app.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 3)
+	c.Assert(s.fakeELB.Targets(tgArn), jc.DeepEquals, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"})
+
+	c.Assert(s.app.Scale(1), jc.ErrorIsNil)
+	_, err = s.app.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeELB.Targets(tgArn), jc.DeepEquals, []string{"10.0.0.1"})
+}
+
+func (s *loadBalancerSuite) TestExposeWithoutLoadBalancer(c *gc.C) {
+	cfg, err := s.cfg.Remove([]string{"load-balancer-arn"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.environ.SetConfig(cfg), jc.ErrorIsNil)
+	app := s.environ.Application("gitlab", caas.DeploymentStateless)
+
+	err = app.UpdateService(caas.ServiceParam{
+		Type:  "loadbalancer",
+		Ports: []caas.ServicePort{{Name: "http", Port: 80}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `exposing "gitlab" without model config "load-balancer-arn" not valid`)
+
+	// Unexposing without a load balancer has nothing to do.
+	c.Assert(app.UpdateService(caas.ServiceParam{Type: "ClusterIP"}), jc.ErrorIsNil)
+}
+
+func (s *loadBalancerSuite) TestExposeLoadBalancerNotFound(c *gc.C) {
+	cfg, err := s.cfg.Apply(map[string]interface{}{"load-balancer-arn": "arn:aws:elasticloadbalancing:missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.environ.SetConfig(cfg), jc.ErrorIsNil)
+	app := s.environ.Application("gitlab", caas.DeploymentStateless)
+
+	err = app.UpdateService(caas.ServiceParam{
+		Type:  "loadbalancer",
+		Ports: []caas.ServicePort{{Name: "http", Port: 80}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -4,7 +4,9 @@
 package ecs
 
 import (
+	"net/http"
 	"net/url"
+	"time"
 
 	jujuclock "github.com/juju/clock"
 	"github.com/juju/errors"
@@ -51,7 +53,7 @@ func (p environProvider) Open(args environs.OpenParams) (caas.Broker, error) {
 		clusterName,
 		jujuclock.WallClock,
 		args.Config, awsCfg,
-		newECSClient,
+		newECSClient, newELBClient,
 	)
 }
 
@@ -61,9 +63,23 @@ func (p environProvider) CloudSchema() *jsonschema.Schema {
 	return nil
 }
 
+// pingClient is used to check that an ECS endpoint is reachable.
+var pingClient = &http.Client{Timeout: 30 * time.Second}
+
 // Ping tests the connection to the cloud, to verify the endpoint is valid.
 func (p environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
-	return errors.NotImplementedf("Ping")
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return errors.NotValidf("endpoint %q", endpoint)
+	}
+	// The ECS API rejects unsigned requests, but any response at
+	// all means there is an endpoint listening at the address.
+	resp, err := pingClient.Get(u.String())
+	if err != nil {
+		return errors.Wrap(err, errors.Errorf("No ECS endpoint running at %s", endpoint))
+	}
+	_ = resp.Body.Close()
+	return nil
 }
 
 // PrepareConfig is specified in the EnvironProvider interface.
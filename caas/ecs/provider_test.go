@@ -4,6 +4,10 @@
 package ecs_test
 
 import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
@@ -15,6 +19,7 @@ import (
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -134,3 +139,31 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validAttrs := validCfg.AllAttrs()
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
+
+func (s *providerSuite) TestPing(c *gc.C) {
+	// The ECS API rejects the unsigned request, which still
+	// shows there is an endpoint listening.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := s.provider.Ping(context.NewCloudCallContext(), srv.URL)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestPingNoEndpoint(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	endpoint := srv.URL
+	srv.Close()
+
+	err := s.provider.Ping(context.NewCloudCallContext(), endpoint)
+	c.Assert(err, gc.ErrorMatches, "No ECS endpoint running at "+endpoint)
+}
+
+func (s *providerSuite) TestPingInvalidEndpoint(c *gc.C) {
+	for _, endpoint := range []string{"", "ecs.ap-southeast-2.amazonaws.com", "ftp://ecs.ap-southeast-2.amazonaws.com"} {
+		err := s.provider.Ping(context.NewCloudCallContext(), endpoint)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
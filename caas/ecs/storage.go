@@ -4,6 +4,11 @@
 package ecs
 
 import (
+	"fmt"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 
@@ -26,6 +31,18 @@ const (
 
 	// EBSDriverValueRexray is the Juju opinionated storage plugin driver for ECS.
 	EBSDriverValueRexray = "rexray/ebs" // Fix: should we opinion on this or NOT??
+
+	// EFSFileSystemIDKey is the config key for the ID of the EFS file system.
+	EFSFileSystemIDKey = "file-system-id"
+	// EFSRootDirectoryKey is the config key for the directory in the EFS
+	// file system to mount as the root of the filesystem.
+	EFSRootDirectoryKey = "root-directory"
+	// EFSAccessPointIDKey is the config key for the EFS access point
+	// used to mount the file system.
+	EFSAccessPointIDKey = "access-point-id"
+	// EFSTransitEncryptionKey is the config key for whether data is
+	// encrypted between the tasks and the EFS file system.
+	EFSTransitEncryptionKey = "transit-encryption"
 )
 
 var ebsConfigFields = schema.Fields{
@@ -64,17 +81,92 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 	return ebsConfig, nil
 }
 
+var efsConfigChecker = schema.FieldMap(
+	schema.Fields{
+		EFSFileSystemIDKey:      schema.String(),
+		EFSRootDirectoryKey:     schema.String(),
+		EFSAccessPointIDKey:     schema.String(),
+		EFSTransitEncryptionKey: schema.Bool(),
+	},
+	schema.Defaults{
+		EFSRootDirectoryKey:     "/",
+		EFSAccessPointIDKey:     "",
+		EFSTransitEncryptionKey: true,
+	},
+)
+
+type efsConfig struct {
+	fileSystemID      string
+	rootDirectory     string
+	accessPointID     string
+	transitEncryption bool
+}
+
+func newEFSConfig(attrs map[string]interface{}) (*efsConfig, error) {
+	out, err := efsConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating EFS storage config for ecs")
+	}
+	coerced := out.(map[string]interface{})
+	cfg := &efsConfig{
+		fileSystemID:      coerced[EFSFileSystemIDKey].(string),
+		rootDirectory:     coerced[EFSRootDirectoryKey].(string),
+		accessPointID:     coerced[EFSAccessPointIDKey].(string),
+		transitEncryption: coerced[EFSTransitEncryptionKey].(bool),
+	}
+	if cfg.fileSystemID == "" {
+		return nil, errors.NotValidf("empty %q", EFSFileSystemIDKey)
+	}
+	if !path.IsAbs(cfg.rootDirectory) {
+		return nil, errors.NotValidf("%s %q, must be an absolute path,", EFSRootDirectoryKey, cfg.rootDirectory)
+	}
+	if cfg.accessPointID != "" {
+		// The access point decides the directory to mount, and
+		// ECS only mounts access points over an encrypted connection.
+		if path.Clean(cfg.rootDirectory) != "/" {
+			return nil, errors.NotValidf("%s with %s", EFSRootDirectoryKey, EFSAccessPointIDKey)
+		}
+		if !cfg.transitEncryption {
+			return nil, errors.NotValidf("%s without %s", EFSAccessPointIDKey, EFSTransitEncryptionKey)
+		}
+	}
+	return cfg, nil
+}
+
+func (c *efsConfig) volumeConfiguration() *ecs.EFSVolumeConfiguration {
+	out := &ecs.EFSVolumeConfiguration{
+		FileSystemId:      aws.String(c.fileSystemID),
+		RootDirectory:     aws.String(c.rootDirectory),
+		TransitEncryption: aws.String(ecs.EFSTransitEncryptionDisabled),
+	}
+	if c.transitEncryption {
+		out.TransitEncryption = aws.String(ecs.EFSTransitEncryptionEnabled)
+	}
+	if c.accessPointID != "" {
+		out.AuthorizationConfig = &ecs.EFSAuthorizationConfig{
+			AccessPointId: aws.String(c.accessPointID),
+		}
+	}
+	return out
+}
+
 // StorageProvider is defined on the jujustorage.ProviderRegistry interface.
 func (env *environ) StorageProvider(t jujustorage.ProviderType) (jujustorage.Provider, error) {
-	if t == constants.StorageProviderType {
+	switch t {
+	case constants.StorageProviderType:
 		return &storageProvider{env}, nil
+	case constants.EFSStorageProviderType:
+		return &efsStorageProvider{}, nil
 	}
 	return nil, errors.NotFoundf("storage provider %q", t)
 }
 
 // StorageProviderTypes is defined on the jujustorage.ProviderRegistry interface.
 func (*environ) StorageProviderTypes() ([]jujustorage.ProviderType, error) {
-	return []jujustorage.ProviderType{constants.StorageProviderType}, nil
+	return []jujustorage.ProviderType{
+		constants.StorageProviderType,
+		constants.EFSStorageProviderType,
+	}, nil
 }
 
 // ValidateStorageClass returns an error if the storage config is not valid.
@@ -202,3 +294,125 @@ func (v *volumeSource) DetachVolumes(ctx jujucontext.ProviderCallContext, attach
 	// noop
 	return make([]error, len(attachParams)), nil
 }
+
+// efsStorageProvider provides filesystems backed by an existing Amazon EFS
+// file system. Every unit of an application mounts the same file system.
+type efsStorageProvider struct{}
+
+var _ jujustorage.Provider = (*efsStorageProvider)(nil)
+
+// ValidateConfig is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) ValidateConfig(cfg *jujustorage.Config) error {
+	_, err := newEFSConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// Supports is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) Supports(k jujustorage.StorageKind) bool {
+	return k == jujustorage.StorageKindFilesystem
+}
+
+// Scope is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) Scope() jujustorage.Scope {
+	return jujustorage.ScopeEnviron
+}
+
+// Dynamic is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) DefaultPools() []*jujustorage.Config {
+	// There is no default pool as the file system needs to be specified.
+	return nil
+}
+
+// VolumeSource is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) VolumeSource(cfg *jujustorage.Config) (jujustorage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the jujustorage.Provider interface.
+func (*efsStorageProvider) FilesystemSource(providerConfig *jujustorage.Config) (jujustorage.FilesystemSource, error) {
+	cfg, err := newEFSConfig(providerConfig.Attrs())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &efsFilesystemSource{cfg: cfg}, nil
+}
+
+// efsFilesystemSource records the filesystems on an EFS file system.
+// The file system is mounted by ECS when a task starts, so there is
+// nothing to create or attach here.
+type efsFilesystemSource struct {
+	cfg *efsConfig
+}
+
+var _ jujustorage.FilesystemSource = (*efsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is specified on the jujustorage.FilesystemSource interface.
+func (s *efsFilesystemSource) ValidateFilesystemParams(params jujustorage.FilesystemParams) error {
+	return nil
+}
+
+// CreateFilesystems is specified on the jujustorage.FilesystemSource interface.
+func (s *efsFilesystemSource) CreateFilesystems(
+	ctx jujucontext.ProviderCallContext, params []jujustorage.FilesystemParams,
+) ([]jujustorage.CreateFilesystemsResult, error) {
+	results := make([]jujustorage.CreateFilesystemsResult, len(params))
+	for i, p := range params {
+		results[i].Filesystem = &jujustorage.Filesystem{
+			Tag: p.Tag,
+			FilesystemInfo: jujustorage.FilesystemInfo{
+				// The EFS file system is shared, so qualify its ID
+				// to keep the filesystem ID unique.
+				FilesystemId: fmt.Sprintf("%s:%s", s.cfg.fileSystemID, p.Tag.Id()),
+				Size:         p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DestroyFilesystems is specified on the jujustorage.FilesystemSource interface.
+func (s *efsFilesystemSource) DestroyFilesystems(ctx jujucontext.ProviderCallContext, fsIds []string) ([]error, error) {
+	// The data belongs to the EFS file system, which Juju doesn't manage.
+	logger.Debugf("leaving data for filesystems %v on EFS file system %q", fsIds, s.cfg.fileSystemID)
+	return make([]error, len(fsIds)), nil
+}
+
+// ReleaseFilesystems is specified on the jujustorage.FilesystemSource interface.
+func (s *efsFilesystemSource) ReleaseFilesystems(ctx jujucontext.ProviderCallContext, fsIds []string) ([]error, error) {
+	return make([]error, len(fsIds)), nil
+}
+
+// AttachFilesystems is specified on the jujustorage.FilesystemSource interface.
+func (s *efsFilesystemSource) AttachFilesystems(
+	ctx jujucontext.ProviderCallContext, params []jujustorage.FilesystemAttachmentParams,
+) ([]jujustorage.AttachFilesystemsResult, error) {
+	results := make([]jujustorage.AttachFilesystemsResult, len(params))
+	for i, p := range params {
+		results[i].FilesystemAttachment = &jujustorage.FilesystemAttachment{
+			Filesystem: p.Filesystem,
+			Machine:    p.Machine,
+			FilesystemAttachmentInfo: jujustorage.FilesystemAttachmentInfo{
+				Path:     p.Path,
+				ReadOnly: p.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DetachFilesystems is specified on the jujustorage.FilesystemSource interface.
+func (s *efsFilesystemSource) DetachFilesystems(
+	ctx jujucontext.ProviderCallContext, params []jujustorage.FilesystemAttachmentParams,
+) ([]error, error) {
+	return make([]error, len(params)), nil
+}
@@ -5,11 +5,14 @@ package ecs_test
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/caas/ecs"
 	"github.com/juju/juju/caas/ecs/constants"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/storage"
 )
 
@@ -57,3 +60,114 @@ func (s *storageSuite) TestScope(c *gc.C) {
 	p := s.ecsStorageProvider(c, ctrl)
 	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
 }
+
+func (s *storageSuite) efsStorageProvider(c *gc.C) storage.Provider {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	p, err := s.environ.StorageProvider(constants.EFSStorageProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	return p
+}
+
+func (s *storageSuite) TestStorageProviderTypes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	types, err := s.environ.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{
+		constants.StorageProviderType, constants.EFSStorageProviderType,
+	})
+}
+
+func (s *storageSuite) TestEFSValidateConfig(c *gc.C) {
+	p := s.efsStorageProvider(c)
+	for i, t := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"file-system-id": "fs-1234"},
+	}, {
+		attrs: map[string]interface{}{
+			"file-system-id":     "fs-1234",
+			"root-directory":     "/data",
+			"transit-encryption": false,
+		},
+	}, {
+		attrs: map[string]interface{}{"file-system-id": "fs-1234", "access-point-id": "fsap-1234"},
+	}, {
+		attrs: map[string]interface{}{},
+		err:   `validating EFS storage config for ecs: file-system-id: expected string, got nothing`,
+	}, {
+		attrs: map[string]interface{}{"file-system-id": ""},
+		err:   `empty "file-system-id" not valid`,
+	}, {
+		attrs: map[string]interface{}{"file-system-id": "fs-1234", "root-directory": "data"},
+		err:   `root-directory "data", must be an absolute path, not valid`,
+	}, {
+		attrs: map[string]interface{}{
+			"file-system-id":  "fs-1234",
+			"access-point-id": "fsap-1234",
+			"root-directory":  "/data",
+		},
+		err: `root-directory with access-point-id not valid`,
+	}, {
+		attrs: map[string]interface{}{
+			"file-system-id":     "fs-1234",
+			"access-point-id":    "fsap-1234",
+			"transit-encryption": false,
+		},
+		err: `access-point-id without transit-encryption not valid`,
+	}} {
+		c.Logf("test %d", i)
+		cfg, err := storage.NewConfig("efs", constants.EFSStorageProviderType, t.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *storageSuite) TestEFSSupports(c *gc.C) {
+	p := s.efsStorageProvider(c)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.DefaultPools(), gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestEFSFilesystemSource(c *gc.C) {
+	p := s.efsStorageProvider(c)
+	cfg, err := storage.NewConfig("efs", constants.EFSStorageProviderType, map[string]interface{}{
+		"file-system-id": "fs-1234",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	fsSource, err := p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := fsSource.CreateFilesystems(context.NewCloudCallContext(), []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "fs-1234:0",
+				Size:         1024,
+			},
+		},
+	}})
+
+	errs, err := fsSource.DestroyFilesystems(context.NewCloudCallContext(), []string{"fs-1234:0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
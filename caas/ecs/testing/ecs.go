@@ -0,0 +1,487 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/juju/errors"
+)
+
+const (
+	// Region is the region of the resources created by the fakes.
+	Region = "ap-southeast-2"
+	// AccountID is the account of the resources created by the fakes.
+	AccountID = "000000000000"
+)
+
+// FakeECS is an in-memory implementation of the parts of the ECS API
+// used by the ECS provider. Services launch tasks to meet their desired
+// count; tests drive the tasks through their lifecycle with UpdateTask.
+// Calls to any other method of the API panic.
+type FakeECS struct {
+	ecsiface.ECSAPI
+
+	mu              sync.Mutex
+	now             func() time.Time
+	clusters        map[string]*ecs.Cluster
+	services        map[string]map[string]*ecs.Service
+	tasks           map[string]*ecs.Task
+	taskDefinitions map[string][]*ecs.TaskDefinition
+	taskCount       int
+	eventCount      int
+}
+
+var _ ecsiface.ECSAPI = (*FakeECS)(nil)
+
+// NewFakeECS returns a FakeECS with the specified active clusters.
+func NewFakeECS(clusterNames ...string) *FakeECS {
+	f := &FakeECS{
+		now:             time.Now,
+		clusters:        make(map[string]*ecs.Cluster),
+		services:        make(map[string]map[string]*ecs.Service),
+		tasks:           make(map[string]*ecs.Task),
+		taskDefinitions: make(map[string][]*ecs.TaskDefinition),
+	}
+	for _, name := range clusterNames {
+		f.AddCluster(name, "ACTIVE")
+	}
+	return f
+}
+
+// AddCluster adds or replaces a cluster with the specified status.
+func (f *FakeECS) AddCluster(name, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clusters[name] = &ecs.Cluster{
+		ClusterArn:  aws.String(arn("cluster", name)),
+		ClusterName: aws.String(name),
+		Status:      aws.String(status),
+	}
+	if f.services[name] == nil {
+		f.services[name] = make(map[string]*ecs.Service)
+	}
+}
+
+// Tasks returns copies of the tasks launched for the service,
+// ordered by ARN.
+func (f *FakeECS) Tasks(cluster, serviceName string) []*ecs.Task {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*ecs.Task
+	for _, t := range f.serviceTasks(cluster, serviceName) {
+		out = append(out, awsutil.CopyOf(t).(*ecs.Task))
+	}
+	return out
+}
+
+// UpdateTask calls update with the task to change its state,
+// for example to set its LastStatus to RUNNING.
+func (f *FakeECS) UpdateTask(taskArn string, update func(*ecs.Task)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tasks[taskArn]
+	if !ok {
+		return errors.NotFoundf("task %q", taskArn)
+	}
+	update(t)
+	return nil
+}
+
+// DescribeClusters is part of ecsiface.ECSAPI.
+func (f *FakeECS) DescribeClusters(input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := &ecs.DescribeClustersOutput{}
+	for _, name := range input.Clusters {
+		c, ok := f.clusters[aws.StringValue(name)]
+		if !ok {
+			out.Failures = append(out.Failures, missing(arn("cluster", aws.StringValue(name))))
+			continue
+		}
+		out.Clusters = append(out.Clusters, awsutil.CopyOf(c).(*ecs.Cluster))
+	}
+	return out, nil
+}
+
+// RegisterTaskDefinition is part of ecsiface.ECSAPI.
+func (f *FakeECS) RegisterTaskDefinition(input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	family := aws.StringValue(input.Family)
+	if family == "" {
+		return nil, awserr.New(ecs.ErrCodeClientException, "Family must not be empty", nil)
+	}
+	if len(input.ContainerDefinitions) == 0 {
+		return nil, awserr.New(ecs.ErrCodeClientException, "Container list cannot be empty", nil)
+	}
+	revision := int64(len(f.taskDefinitions[family]) + 1)
+	td := &ecs.TaskDefinition{
+		TaskDefinitionArn:    aws.String(arn("task-definition", fmt.Sprintf("%s:%d", family, revision))),
+		Family:               input.Family,
+		Revision:             aws.Int64(revision),
+		Status:               aws.String(ecs.TaskDefinitionStatusActive),
+		ContainerDefinitions: input.ContainerDefinitions,
+		Volumes:              input.Volumes,
+		NetworkMode:          input.NetworkMode,
+		Cpu:                  input.Cpu,
+		Memory:               input.Memory,
+	}
+	f.taskDefinitions[family] = append(f.taskDefinitions[family], td)
+	return &ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: awsutil.CopyOf(td).(*ecs.TaskDefinition),
+		Tags:           input.Tags,
+	}, nil
+}
+
+// DescribeTaskDefinition is part of ecsiface.ECSAPI.
+func (f *FakeECS) DescribeTaskDefinition(input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	td, err := f.taskDefinition(aws.StringValue(input.TaskDefinition))
+	if err != nil {
+		return nil, err
+	}
+	return &ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: awsutil.CopyOf(td).(*ecs.TaskDefinition),
+	}, nil
+}
+
+// DeregisterTaskDefinition is part of ecsiface.ECSAPI.
+func (f *FakeECS) DeregisterTaskDefinition(input *ecs.DeregisterTaskDefinitionInput) (*ecs.DeregisterTaskDefinitionOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	td, err := f.taskDefinition(aws.StringValue(input.TaskDefinition))
+	if err != nil {
+		return nil, err
+	}
+	td.Status = aws.String(ecs.TaskDefinitionStatusInactive)
+	return &ecs.DeregisterTaskDefinitionOutput{
+		TaskDefinition: awsutil.CopyOf(td).(*ecs.TaskDefinition),
+	}, nil
+}
+
+// ListTaskDefinitionsWithContext is part of ecsiface.ECSAPI.
+func (f *FakeECS) ListTaskDefinitionsWithContext(
+	_ aws.Context, input *ecs.ListTaskDefinitionsInput, _ ...request.Option,
+) (*ecs.ListTaskDefinitionsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status := aws.StringValue(input.Status)
+	if status == "" {
+		status = ecs.TaskDefinitionStatusActive
+	}
+	out := &ecs.ListTaskDefinitionsOutput{}
+	for family, tds := range f.taskDefinitions {
+		if !strings.HasPrefix(family, aws.StringValue(input.FamilyPrefix)) {
+			continue
+		}
+		for _, td := range tds {
+			if aws.StringValue(td.Status) == status {
+				out.TaskDefinitionArns = append(out.TaskDefinitionArns, td.TaskDefinitionArn)
+			}
+		}
+	}
+	sort.Slice(out.TaskDefinitionArns, func(i, j int) bool {
+		return aws.StringValue(out.TaskDefinitionArns[i]) < aws.StringValue(out.TaskDefinitionArns[j])
+	})
+	return out, nil
+}
+
+// CreateService is part of ecsiface.ECSAPI.
+func (f *FakeECS) CreateService(input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	services, err := f.clusterServices(aws.StringValue(input.Cluster))
+	if err != nil {
+		return nil, err
+	}
+	name := aws.StringValue(input.ServiceName)
+	if svc, ok := services[name]; ok && aws.StringValue(svc.Status) != "INACTIVE" {
+		return nil, awserr.New(ecs.ErrCodeInvalidParameterException, "Creation of service was not idempotent.", nil)
+	}
+	if _, err := f.taskDefinition(aws.StringValue(input.TaskDefinition)); err != nil {
+		return nil, err
+	}
+	svc := &ecs.Service{
+		ClusterArn:     aws.String(arn("cluster", aws.StringValue(input.Cluster))),
+		ServiceArn:     aws.String(arn("service", aws.StringValue(input.Cluster)+"/"+name)),
+		ServiceName:    input.ServiceName,
+		Status:         aws.String("ACTIVE"),
+		DesiredCount:   aws.Int64(aws.Int64Value(input.DesiredCount)),
+		TaskDefinition: input.TaskDefinition,
+		LaunchType:     input.LaunchType,
+		CreatedAt:      aws.Time(f.now()),
+	}
+	services[name] = svc
+	f.reconcile(aws.StringValue(input.Cluster), svc)
+	return &ecs.CreateServiceOutput{Service: f.describeService(aws.StringValue(input.Cluster), svc)}, nil
+}
+
+// UpdateService is part of ecsiface.ECSAPI.
+func (f *FakeECS) UpdateService(input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cluster := aws.StringValue(input.Cluster)
+	svc, err := f.activeService(cluster, aws.StringValue(input.Service))
+	if err != nil {
+		return nil, err
+	}
+	if input.TaskDefinition != nil {
+		if _, err := f.taskDefinition(aws.StringValue(input.TaskDefinition)); err != nil {
+			return nil, err
+		}
+		svc.TaskDefinition = input.TaskDefinition
+	}
+	if input.DesiredCount != nil {
+		svc.DesiredCount = aws.Int64(aws.Int64Value(input.DesiredCount))
+	}
+	f.reconcile(cluster, svc)
+	return &ecs.UpdateServiceOutput{Service: f.describeService(cluster, svc)}, nil
+}
+
+// DeleteService is part of ecsiface.ECSAPI.
+func (f *FakeECS) DeleteService(input *ecs.DeleteServiceInput) (*ecs.DeleteServiceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cluster := aws.StringValue(input.Cluster)
+	svc, err := f.activeService(cluster, aws.StringValue(input.Service))
+	if err != nil {
+		return nil, err
+	}
+	if aws.Int64Value(svc.DesiredCount) > 0 && !aws.BoolValue(input.Force) {
+		return nil, awserr.New(ecs.ErrCodeInvalidParameterException,
+			"The service cannot be stopped while it is scaled above 0.", nil)
+	}
+	svc.Status = aws.String("INACTIVE")
+	svc.DesiredCount = aws.Int64(0)
+	for _, t := range f.serviceTasks(cluster, aws.StringValue(svc.ServiceName)) {
+		f.stopTask(t, "ServiceSchedulerInitiated", "Service deleted")
+		t.LastStatus = aws.String(ecs.DesiredStatusStopped)
+	}
+	return &ecs.DeleteServiceOutput{Service: f.describeService(cluster, svc)}, nil
+}
+
+// DescribeServices is part of ecsiface.ECSAPI.
+func (f *FakeECS) DescribeServices(input *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cluster := aws.StringValue(input.Cluster)
+	services, err := f.clusterServices(cluster)
+	if err != nil {
+		return nil, err
+	}
+	out := &ecs.DescribeServicesOutput{}
+	for _, name := range input.Services {
+		svc, ok := services[aws.StringValue(name)]
+		if !ok {
+			out.Failures = append(out.Failures, missing(arn("service", cluster+"/"+aws.StringValue(name))))
+			continue
+		}
+		out.Services = append(out.Services, f.describeService(cluster, svc))
+	}
+	return out, nil
+}
+
+// ListTasks is part of ecsiface.ECSAPI. All the tasks
+// are returned in one page.
+func (f *FakeECS) ListTasks(input *ecs.ListTasksInput) (*ecs.ListTasksOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cluster := aws.StringValue(input.Cluster)
+	if _, err := f.clusterServices(cluster); err != nil {
+		return nil, err
+	}
+	serviceName := aws.StringValue(input.ServiceName)
+	if serviceName != "" {
+		if _, err := f.activeService(cluster, serviceName); err != nil {
+			return nil, err
+		}
+	}
+	desiredStatus := aws.StringValue(input.DesiredStatus)
+	if desiredStatus == "" {
+		desiredStatus = ecs.DesiredStatusRunning
+	}
+	out := &ecs.ListTasksOutput{}
+	for _, t := range f.serviceTasks(cluster, serviceName) {
+		if aws.StringValue(t.DesiredStatus) == desiredStatus {
+			out.TaskArns = append(out.TaskArns, t.TaskArn)
+		}
+	}
+	return out, nil
+}
+
+// DescribeTasks is part of ecsiface.ECSAPI.
+func (f *FakeECS) DescribeTasks(input *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.clusterServices(aws.StringValue(input.Cluster)); err != nil {
+		return nil, err
+	}
+	out := &ecs.DescribeTasksOutput{}
+	for _, taskArn := range input.Tasks {
+		t, ok := f.tasks[aws.StringValue(taskArn)]
+		if !ok {
+			out.Failures = append(out.Failures, missing(aws.StringValue(taskArn)))
+			continue
+		}
+		out.Tasks = append(out.Tasks, awsutil.CopyOf(t).(*ecs.Task))
+	}
+	return out, nil
+}
+
+func (f *FakeECS) clusterServices(cluster string) (map[string]*ecs.Service, error) {
+	c, ok := f.clusters[cluster]
+	if !ok || aws.StringValue(c.Status) != "ACTIVE" {
+		return nil, awserr.New(ecs.ErrCodeClusterNotFoundException, "Cluster not found.", nil)
+	}
+	return f.services[cluster], nil
+}
+
+func (f *FakeECS) activeService(cluster, name string) (*ecs.Service, error) {
+	services, err := f.clusterServices(cluster)
+	if err != nil {
+		return nil, err
+	}
+	svc, ok := services[name]
+	if !ok {
+		return nil, awserr.New(ecs.ErrCodeServiceNotFoundException, "Service not found.", nil)
+	}
+	if aws.StringValue(svc.Status) != "ACTIVE" {
+		return nil, awserr.New(ecs.ErrCodeServiceNotActiveException, "Service was not ACTIVE.", nil)
+	}
+	return svc, nil
+}
+
+// taskDefinition finds a task definition by ARN or "family:revision".
+func (f *FakeECS) taskDefinition(id string) (*ecs.TaskDefinition, error) {
+	id = id[strings.LastIndex(id, "/")+1:]
+	family, revision := id, ""
+	if i := strings.LastIndex(id, ":"); i >= 0 {
+		family, revision = id[:i], id[i+1:]
+	}
+	tds := f.taskDefinitions[family]
+	if len(tds) > 0 && revision == "" {
+		return tds[len(tds)-1], nil
+	}
+	for _, td := range tds {
+		if fmt.Sprint(aws.Int64Value(td.Revision)) == revision {
+			return td, nil
+		}
+	}
+	return nil, awserr.New(ecs.ErrCodeClientException, "Unable to describe task definition.", nil)
+}
+
+// serviceTasks returns the tasks of the service, or all the
+// tasks in the cluster if serviceName is empty, ordered by ARN.
+func (f *FakeECS) serviceTasks(cluster, serviceName string) (out []*ecs.Task) {
+	group := "service:" + serviceName
+	for _, t := range f.tasks {
+		if aws.StringValue(t.ClusterArn) != arn("cluster", cluster) {
+			continue
+		}
+		if serviceName == "" || aws.StringValue(t.Group) == group {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return aws.StringValue(out[i].TaskArn) < aws.StringValue(out[j].TaskArn)
+	})
+	return out
+}
+
+// reconcile launches or stops tasks so the number of tasks
+// wanted running matches the desired count of the service.
+func (f *FakeECS) reconcile(cluster string, svc *ecs.Service) {
+	var running []*ecs.Task
+	for _, t := range f.serviceTasks(cluster, aws.StringValue(svc.ServiceName)) {
+		if aws.StringValue(t.DesiredStatus) == ecs.DesiredStatusRunning {
+			running = append(running, t)
+		}
+	}
+	desired := int(aws.Int64Value(svc.DesiredCount))
+	for i := len(running); i < desired; i++ {
+		f.taskCount++
+		id := fmt.Sprintf("%032x", f.taskCount)
+		t := &ecs.Task{
+			TaskArn:           aws.String(arn("task", cluster+"/"+id)),
+			ClusterArn:        aws.String(arn("cluster", cluster)),
+			TaskDefinitionArn: svc.TaskDefinition,
+			Group:             aws.String("service:" + aws.StringValue(svc.ServiceName)),
+			LastStatus:        aws.String("PROVISIONING"),
+			DesiredStatus:     aws.String(ecs.DesiredStatusRunning),
+			CreatedAt:         aws.Time(f.now()),
+			Attachments: []*ecs.Attachment{{
+				Type:   aws.String("ElasticNetworkInterface"),
+				Status: aws.String("ATTACHED"),
+				Details: []*ecs.KeyValuePair{{
+					Name:  aws.String("privateIPv4Address"),
+					Value: aws.String(fmt.Sprintf("10.0.%d.%d", f.taskCount/256, f.taskCount%256)),
+				}},
+			}},
+		}
+		f.tasks[aws.StringValue(t.TaskArn)] = t
+	}
+	for i := desired; i < len(running); i++ {
+		f.stopTask(running[i], "ServiceSchedulerInitiated", "Scaling activity initiated by deployment")
+	}
+	f.eventCount++
+	svc.Events = append([]*ecs.ServiceEvent{{
+		Id:        aws.String(fmt.Sprint(f.eventCount)),
+		CreatedAt: aws.Time(f.now()),
+		Message:   aws.String(fmt.Sprintf("(service %s) has reached a steady state.", aws.StringValue(svc.ServiceName))),
+	}}, svc.Events...)
+}
+
+func (f *FakeECS) stopTask(t *ecs.Task, stopCode, reason string) {
+	if aws.StringValue(t.DesiredStatus) == ecs.DesiredStatusStopped {
+		return
+	}
+	t.DesiredStatus = aws.String(ecs.DesiredStatusStopped)
+	t.LastStatus = aws.String("DEACTIVATING")
+	t.StopCode = aws.String(stopCode)
+	t.StoppedReason = aws.String(reason)
+	t.StoppingAt = aws.Time(f.now())
+}
+
+// describeService returns a copy of the service with its task counts.
+func (f *FakeECS) describeService(cluster string, svc *ecs.Service) *ecs.Service {
+	out := awsutil.CopyOf(svc).(*ecs.Service)
+	var runningCount, pendingCount int64
+	for _, t := range f.serviceTasks(cluster, aws.StringValue(svc.ServiceName)) {
+		if aws.StringValue(t.DesiredStatus) != ecs.DesiredStatusRunning {
+			continue
+		}
+		switch aws.StringValue(t.LastStatus) {
+		case ecs.DesiredStatusRunning:
+			runningCount++
+		case "PROVISIONING", "PENDING", "ACTIVATING":
+			pendingCount++
+		}
+	}
+	out.RunningCount = aws.Int64(runningCount)
+	out.PendingCount = aws.Int64(pendingCount)
+	return out
+}
+
+func arn(resourceType, resource string) string {
+	return fmt.Sprintf("arn:aws:ecs:%s:%s:%s/%s", Region, AccountID, resourceType, resource)
+}
+
+func missing(resourceArn string) *ecs.Failure {
+	return &ecs.Failure{
+		Arn:    aws.String(resourceArn),
+		Reason: aws.String("MISSING"),
+	}
+}
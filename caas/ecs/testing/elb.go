@@ -0,0 +1,353 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+)
+
+// FakeELB is an in-memory implementation of the parts of the
+// Elastic Load Balancing API used by the ECS provider to expose
+// applications. Calls to any other method of the API panic.
+type FakeELB struct {
+	elbv2iface.ELBV2API
+
+	mu            sync.Mutex
+	loadBalancers map[string]*elbv2.LoadBalancer
+	targetGroups  map[string]*elbv2.TargetGroup
+	listeners     map[string]*elbv2.Listener
+	targets       map[string]map[string]*elbv2.TargetDescription
+	count         int
+}
+
+var _ elbv2iface.ELBV2API = (*FakeELB)(nil)
+
+// NewFakeELB returns a FakeELB without any load balancers.
+func NewFakeELB() *FakeELB {
+	return &FakeELB{
+		loadBalancers: make(map[string]*elbv2.LoadBalancer),
+		targetGroups:  make(map[string]*elbv2.TargetGroup),
+		listeners:     make(map[string]*elbv2.Listener),
+		targets:       make(map[string]map[string]*elbv2.TargetDescription),
+	}
+}
+
+// AddLoadBalancer adds a load balancer of the specified type
+// ("application" or "network") and returns its ARN.
+func (f *FakeELB) AddLoadBalancer(name, lbType, vpcID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.count++
+	lbArn := elbARN("loadbalancer", fmt.Sprintf("%s/%s/%016x", lbTypePrefix(lbType), name, f.count))
+	f.loadBalancers[lbArn] = &elbv2.LoadBalancer{
+		LoadBalancerArn:  aws.String(lbArn),
+		LoadBalancerName: aws.String(name),
+		Type:             aws.String(lbType),
+		VpcId:            aws.String(vpcID),
+		DNSName:          aws.String(fmt.Sprintf("%s-%d.elb.%s.amazonaws.com", name, f.count, Region)),
+		State:            &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumActive)},
+	}
+	return lbArn
+}
+
+// Listeners returns copies of the listeners of the
+// load balancer, ordered by port.
+func (f *FakeELB) Listeners(lbArn string) []*elbv2.Listener {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lbListeners(lbArn)
+}
+
+// TargetGroups returns copies of all the target groups, ordered by name.
+func (f *FakeELB) TargetGroups() []*elbv2.TargetGroup {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*elbv2.TargetGroup
+	for _, tg := range f.targetGroups {
+		out = append(out, awsutil.CopyOf(tg).(*elbv2.TargetGroup))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return aws.StringValue(out[i].TargetGroupName) < aws.StringValue(out[j].TargetGroupName)
+	})
+	return out
+}
+
+// Targets returns the IDs of the targets registered
+// with the target group, sorted.
+func (f *FakeELB) Targets(tgArn string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []string
+	for id := range f.targets[tgArn] {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// DescribeLoadBalancers is part of elbv2iface.ELBV2API.
+func (f *FakeELB) DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := &elbv2.DescribeLoadBalancersOutput{}
+	for _, lbArn := range input.LoadBalancerArns {
+		lb, ok := f.loadBalancers[aws.StringValue(lbArn)]
+		if !ok {
+			return nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "One or more load balancers not found", nil)
+		}
+		out.LoadBalancers = append(out.LoadBalancers, awsutil.CopyOf(lb).(*elbv2.LoadBalancer))
+	}
+	return out, nil
+}
+
+// CreateTargetGroup is part of elbv2iface.ELBV2API.
+func (f *FakeELB) CreateTargetGroup(input *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := aws.StringValue(input.Name)
+	if len(name) > 32 {
+		return nil, awserr.New(elbv2.ErrCodeInvalidConfigurationRequestException,
+			fmt.Sprintf("Target group name %q cannot be longer than 32 characters", name), nil)
+	}
+	for _, tg := range f.targetGroups {
+		if aws.StringValue(tg.TargetGroupName) == name {
+			return nil, awserr.New(elbv2.ErrCodeDuplicateTargetGroupNameException,
+				"A target group with the same name exists, but with different settings", nil)
+		}
+	}
+	f.count++
+	tgArn := elbARN("targetgroup", fmt.Sprintf("%s/%016x", name, f.count))
+	tg := &elbv2.TargetGroup{
+		TargetGroupArn:  aws.String(tgArn),
+		TargetGroupName: input.Name,
+		Port:            input.Port,
+		Protocol:        input.Protocol,
+		TargetType:      input.TargetType,
+		VpcId:           input.VpcId,
+	}
+	f.targetGroups[tgArn] = tg
+	f.targets[tgArn] = make(map[string]*elbv2.TargetDescription)
+	return &elbv2.CreateTargetGroupOutput{
+		TargetGroups: []*elbv2.TargetGroup{awsutil.CopyOf(tg).(*elbv2.TargetGroup)},
+	}, nil
+}
+
+// DescribeTargetGroups is part of elbv2iface.ELBV2API. All the
+// target groups are returned in one page.
+func (f *FakeELB) DescribeTargetGroups(input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := aws.StringValueSlice(input.Names)
+	arns := aws.StringValueSlice(input.TargetGroupArns)
+	out := &elbv2.DescribeTargetGroupsOutput{}
+	for _, tg := range f.targetGroups {
+		if len(names) > 0 && !contains(names, aws.StringValue(tg.TargetGroupName)) {
+			continue
+		}
+		if len(arns) > 0 && !contains(arns, aws.StringValue(tg.TargetGroupArn)) {
+			continue
+		}
+		lbArns := aws.StringValueSlice(tg.LoadBalancerArns)
+		if input.LoadBalancerArn != nil && !contains(lbArns, aws.StringValue(input.LoadBalancerArn)) {
+			continue
+		}
+		out.TargetGroups = append(out.TargetGroups, awsutil.CopyOf(tg).(*elbv2.TargetGroup))
+	}
+	if len(out.TargetGroups) == 0 && (len(names) > 0 || len(arns) > 0) {
+		return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "One or more target groups not found", nil)
+	}
+	sort.Slice(out.TargetGroups, func(i, j int) bool {
+		return aws.StringValue(out.TargetGroups[i].TargetGroupName) < aws.StringValue(out.TargetGroups[j].TargetGroupName)
+	})
+	return out, nil
+}
+
+// DeleteTargetGroup is part of elbv2iface.ELBV2API.
+func (f *FakeELB) DeleteTargetGroup(input *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tgArn := aws.StringValue(input.TargetGroupArn)
+	tg, ok := f.targetGroups[tgArn]
+	if !ok {
+		// Deleting a target group which doesn't exist succeeds.
+		return &elbv2.DeleteTargetGroupOutput{}, nil
+	}
+	if len(tg.LoadBalancerArns) > 0 {
+		return nil, awserr.New(elbv2.ErrCodeResourceInUseException,
+			fmt.Sprintf("Target group %q is currently in use by a listener or a rule", tgArn), nil)
+	}
+	delete(f.targetGroups, tgArn)
+	delete(f.targets, tgArn)
+	return &elbv2.DeleteTargetGroupOutput{}, nil
+}
+
+// CreateListener is part of elbv2iface.ELBV2API.
+func (f *FakeELB) CreateListener(input *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lbArn := aws.StringValue(input.LoadBalancerArn)
+	lb, ok := f.loadBalancers[lbArn]
+	if !ok {
+		return nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "One or more load balancers not found", nil)
+	}
+	for _, l := range f.lbListeners(lbArn) {
+		if aws.Int64Value(l.Port) == aws.Int64Value(input.Port) {
+			return nil, awserr.New(elbv2.ErrCodeDuplicateListenerException, "A listener already exists on this port for this load balancer", nil)
+		}
+	}
+	var groups []*elbv2.TargetGroup
+	for _, action := range input.DefaultActions {
+		tg, ok := f.targetGroups[aws.StringValue(action.TargetGroupArn)]
+		if !ok {
+			return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "One or more target groups not found", nil)
+		}
+		if aws.StringValue(tg.VpcId) != aws.StringValue(lb.VpcId) {
+			return nil, awserr.New(elbv2.ErrCodeInvalidConfigurationRequestException,
+				"The target group and load balancer are in different VPCs", nil)
+		}
+		groups = append(groups, tg)
+	}
+	f.count++
+	l := &elbv2.Listener{
+		ListenerArn:     aws.String(elbARN("listener", fmt.Sprintf("%s/%016x", lbArn[len(elbARN("loadbalancer", "")):], f.count))),
+		LoadBalancerArn: input.LoadBalancerArn,
+		Port:            input.Port,
+		Protocol:        input.Protocol,
+		DefaultActions:  input.DefaultActions,
+	}
+	f.listeners[aws.StringValue(l.ListenerArn)] = l
+	for _, tg := range groups {
+		tg.LoadBalancerArns = append(tg.LoadBalancerArns, input.LoadBalancerArn)
+	}
+	return &elbv2.CreateListenerOutput{
+		Listeners: []*elbv2.Listener{awsutil.CopyOf(l).(*elbv2.Listener)},
+	}, nil
+}
+
+// DescribeListeners is part of elbv2iface.ELBV2API. All the
+// listeners are returned in one page.
+func (f *FakeELB) DescribeListeners(input *elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lbArn := aws.StringValue(input.LoadBalancerArn)
+	if _, ok := f.loadBalancers[lbArn]; !ok {
+		return nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "One or more load balancers not found", nil)
+	}
+	return &elbv2.DescribeListenersOutput{Listeners: f.lbListeners(lbArn)}, nil
+}
+
+// DeleteListener is part of elbv2iface.ELBV2API.
+func (f *FakeELB) DeleteListener(input *elbv2.DeleteListenerInput) (*elbv2.DeleteListenerOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l, ok := f.listeners[aws.StringValue(input.ListenerArn)]
+	if !ok {
+		return nil, awserr.New(elbv2.ErrCodeListenerNotFoundException, "One or more listeners not found", nil)
+	}
+	delete(f.listeners, aws.StringValue(input.ListenerArn))
+	for _, action := range l.DefaultActions {
+		tg, ok := f.targetGroups[aws.StringValue(action.TargetGroupArn)]
+		if !ok {
+			continue
+		}
+		var lbArns []*string
+		for _, lbArn := range tg.LoadBalancerArns {
+			if aws.StringValue(lbArn) != aws.StringValue(l.LoadBalancerArn) {
+				lbArns = append(lbArns, lbArn)
+			}
+		}
+		tg.LoadBalancerArns = lbArns
+	}
+	return &elbv2.DeleteListenerOutput{}, nil
+}
+
+// RegisterTargets is part of elbv2iface.ELBV2API.
+func (f *FakeELB) RegisterTargets(input *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	targets, ok := f.targets[aws.StringValue(input.TargetGroupArn)]
+	if !ok {
+		return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "One or more target groups not found", nil)
+	}
+	for _, t := range input.Targets {
+		targets[aws.StringValue(t.Id)] = awsutil.CopyOf(t).(*elbv2.TargetDescription)
+	}
+	return &elbv2.RegisterTargetsOutput{}, nil
+}
+
+// DeregisterTargets is part of elbv2iface.ELBV2API.
+func (f *FakeELB) DeregisterTargets(input *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	targets, ok := f.targets[aws.StringValue(input.TargetGroupArn)]
+	if !ok {
+		return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "One or more target groups not found", nil)
+	}
+	for _, t := range input.Targets {
+		delete(targets, aws.StringValue(t.Id))
+	}
+	return &elbv2.DeregisterTargetsOutput{}, nil
+}
+
+// DescribeTargetHealth is part of elbv2iface.ELBV2API.
+// All the targets are reported healthy.
+func (f *FakeELB) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	targets, ok := f.targets[aws.StringValue(input.TargetGroupArn)]
+	if !ok {
+		return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "One or more target groups not found", nil)
+	}
+	out := &elbv2.DescribeTargetHealthOutput{}
+	for _, t := range targets {
+		out.TargetHealthDescriptions = append(out.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			Target:       awsutil.CopyOf(t).(*elbv2.TargetDescription),
+			TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)},
+		})
+	}
+	sort.Slice(out.TargetHealthDescriptions, func(i, j int) bool {
+		return aws.StringValue(out.TargetHealthDescriptions[i].Target.Id) < aws.StringValue(out.TargetHealthDescriptions[j].Target.Id)
+	})
+	return out, nil
+}
+
+func (f *FakeELB) lbListeners(lbArn string) (out []*elbv2.Listener) {
+	for _, l := range f.listeners {
+		if aws.StringValue(l.LoadBalancerArn) == lbArn {
+			out = append(out, awsutil.CopyOf(l).(*elbv2.Listener))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return aws.Int64Value(out[i].Port) < aws.Int64Value(out[j].Port)
+	})
+	return out
+}
+
+func lbTypePrefix(lbType string) string {
+	if lbType == elbv2.LoadBalancerTypeEnumNetwork {
+		return "net"
+	}
+	return "app"
+}
+
+func elbARN(resourceType, resource string) string {
+	return fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:%s/%s", Region, AccountID, resourceType, resource)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -225,7 +225,7 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 			storageUniqueID,
 			config.Filesystems,
 			storageClasses,
-			handleVolume, handleVolumeMount, handlePVC, handleStorageClass,
+			handleVolume, handleVolumeMount, handlePVC, handlePVCForStatelessResource, handleStorageClass,
 		)
 		return errors.Trace(err)
 	}
@@ -293,6 +293,8 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		); err != nil {
 			return errors.Trace(err)
 		}
+		// Storage configuration may have added volumes to the pod.
+		statefulset.Spec.Template.Spec = *podSpec
 
 		applier.Apply(&statefulset)
 	case caas.DeploymentStateless:
@@ -684,6 +686,8 @@ func (a *app) Delete() error {
 	default:
 		return errors.NotSupportedf("unknown deployment type")
 	}
+	applier.Delete(resources.NewHorizontalPodAutoscaler(a.name, a.namespace, nil))
+	applier.Delete(resources.NewNetworkPolicy(a.name, a.namespace, nil))
 	applier.Delete(resources.NewService(a.name, a.namespace, nil))
 	applier.Delete(resources.NewSecret(a.secretName(), a.namespace, nil))
 	return applier.Run(context.Background(), a.client, false)
@@ -1113,6 +1117,7 @@ func (a *app) configureStorage(
 	handleVolume handleVolumeFunc,
 	handleVolumeMount handleVolumeMountFunc,
 	handlePVC handlePVCFunc,
+	handleSharedPVC handlePVCFunc,
 	handleStorageClass handleStorageClassFunc,
 ) error {
 	storageClassMap := make(map[string]resources.StorageClass)
@@ -1158,7 +1163,15 @@ func (a *app) configureStorage(
 			}
 			storageClassMap[sc.Name] = resources.StorageClass{StorageClass: *sc}
 		}
-		if pvc != nil && handlePVC != nil {
+		if pvc != nil && fs.Shared && handleSharedPVC != nil {
+			// Shared storage is a single claim mounted by every
+			// pod of the application, rather than a claim per pod.
+			logger.Debugf("using shared persistent volume claim for %s filesystem %s: %s", a.name, fs.StorageName, pretty.Sprint(*pvc))
+			volumeMount, err = handleSharedPVC(*pvc, mountPath, readOnly)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		} else if pvc != nil && handlePVC != nil {
 			logger.Debugf("using persistent volume claim for %s filesystem %s: %s", a.name, fs.StorageName, pretty.Sprint(*pvc))
 			volumeMount, err = handlePVC(*pvc, mountPath, readOnly)
 			if err != nil {
@@ -1201,6 +1214,9 @@ func (a *app) filesystemToVolumeInfo(name string,
 	if err != nil {
 		return nil, nil, nil, errors.Annotatef(err, "getting volume params for %s", fs.StorageName)
 	}
+	if fs.Shared {
+		params.AccessMode = storage.SharedAccessMode(params.AccessMode)
+	}
 
 	var newStorageClass *storagev1.StorageClass
 	qualifiedStorageClassName := constants.QualifiedStorageClassName(a.namespace, params.StorageConfig.StorageClass)
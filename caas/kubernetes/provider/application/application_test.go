@@ -17,7 +17,9 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -472,6 +474,62 @@ func (s *applicationSuite) TestEnsureStateful(c *gc.C) {
 	)
 }
 
+func (s *applicationSuite) TestEnsureStatefulSharedStorage(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.Ensure(
+		caas.ApplicationConfig{
+			AgentImagePath: "operator/image-path",
+			CharmBaseImage: coreresources.DockerImageDetails{
+				RegistryPath: "ubuntu:20.04",
+			},
+			CharmModifiedVersion: 9001,
+			Filesystems: []storage.KubernetesFilesystemParams{{
+				StorageName: "database",
+				Size:        100,
+				Provider:    "kubernetes",
+				Attributes:  map[string]interface{}{"storage-class": "workload-storage"},
+				Attachment: &storage.KubernetesFilesystemAttachmentParams{
+					Path: "path/to/here",
+				},
+				Shared: true,
+			}},
+			Containers: map[string]caas.ContainerConfig{
+				"gitlab": {
+					Name: "gitlab",
+					Image: coreresources.DockerImageDetails{
+						RegistryPath: "gitlab-image:latest",
+					},
+					Mounts: []caas.MountConfig{{
+						StorageName: "database",
+						Path:        "path/to/here",
+					}},
+				},
+			},
+		},
+	), jc.ErrorIsNil)
+
+	// The shared filesystem is a single claim, rather than a
+	// claim template for each pod of the stateful set.
+	pvc, err := s.client.CoreV1().PersistentVolumeClaims("test").Get(context.TODO(), "gitlab-database-appuuid", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pvc.Spec.AccessModes, jc.DeepEquals, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany})
+
+	ss, err := s.client.AppsV1().StatefulSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ss.Spec.VolumeClaimTemplates, gc.HasLen, 0)
+	var found bool
+	for _, vol := range ss.Spec.Template.Spec.Volumes {
+		if vol.Name != "gitlab-database-appuuid" {
+			continue
+		}
+		found = true
+		c.Assert(vol.PersistentVolumeClaim, jc.DeepEquals, &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "gitlab-database-appuuid",
+		})
+	}
+	c.Assert(found, jc.IsTrue)
+}
+
 func (s *applicationSuite) TestEnsureStateless(c *gc.C) {
 	s.assertEnsure(
 		c, caas.DeploymentStateless, func() {
@@ -745,6 +803,8 @@ func (s *applicationSuite) TestDeleteStateful(c *gc.C) {
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewStatefulSet("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab-endpoints", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewHorizontalPodAutoscaler("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Run(context.Background(), s.client, false).Return(nil),
@@ -758,6 +818,8 @@ func (s *applicationSuite) TestDeleteStateless(c *gc.C) {
 
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewDeployment("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewHorizontalPodAutoscaler("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Run(context.Background(), s.client, false).Return(nil),
@@ -771,6 +833,8 @@ func (s *applicationSuite) TestDeleteDaemon(c *gc.C) {
 
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewDaemonSet("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewHorizontalPodAutoscaler("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Run(context.Background(), s.client, false).Return(nil),
@@ -1668,3 +1732,180 @@ func (c *fakeCharm) Actions() *charm.Actions {
 func (c *fakeCharm) Revision() int {
 	return 0
 }
+
+func (s *applicationSuite) TestScaleStateful(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	_, err := s.client.AppsV1().StatefulSets("test").Create(context.TODO(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab", Namespace: "test"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: application.Int32Ptr(1)},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.Scale(3), jc.ErrorIsNil)
+
+	ss, err := s.client.AppsV1().StatefulSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*ss.Spec.Replicas, gc.Equals, int32(3))
+}
+
+func (s *applicationSuite) TestScaleNotFound(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateless, false)
+	err := app.Scale(3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationSuite) TestScaleDaemonNotSupported(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentDaemon, false)
+	err := app.Scale(3)
+	c.Assert(err, gc.ErrorMatches, `scaling daemon application not supported`)
+}
+
+func (s *applicationSuite) TestEnsureAutoscaler(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	err := app.EnsureAutoscaler(&caas.AutoscalingPolicy{
+		MinReplicas:          2,
+		MaxReplicas:          5,
+		TargetCPUUtilization: 70,
+		Metrics:              map[string]string{"requests_per_second": "100"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	hpa, err := s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hpa.Spec.ScaleTargetRef, gc.DeepEquals, autoscalingv2beta2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Name:       "gitlab",
+	})
+	c.Assert(*hpa.Spec.MinReplicas, gc.Equals, int32(2))
+	c.Assert(hpa.Spec.MaxReplicas, gc.Equals, int32(5))
+	target := k8sresource.MustParse("100")
+	c.Assert(hpa.Spec.Metrics, gc.DeepEquals, []autoscalingv2beta2.MetricSpec{{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:               autoscalingv2beta2.UtilizationMetricType,
+				AverageUtilization: application.Int32Ptr(70),
+			},
+		},
+	}, {
+		Type: autoscalingv2beta2.PodsMetricSourceType,
+		Pods: &autoscalingv2beta2.PodsMetricSource{
+			Metric: autoscalingv2beta2.MetricIdentifier{Name: "requests_per_second"},
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:         autoscalingv2beta2.AverageValueMetricType,
+				AverageValue: &target,
+			},
+		},
+	}})
+
+	c.Assert(app.EnsureAutoscaler(nil), jc.ErrorIsNil)
+	_, err = s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, gc.ErrorMatches, `horizontalpodautoscalers.autoscaling "gitlab" not found`)
+}
+
+func (s *applicationSuite) TestEnsureAutoscalerInvalid(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	err := app.EnsureAutoscaler(&caas.AutoscalingPolicy{MinReplicas: 3, MaxReplicas: 2})
+	c.Assert(err, gc.ErrorMatches, `max replicas 2 less than min replicas 3 not valid`)
+
+	err = app.EnsureAutoscaler(&caas.AutoscalingPolicy{MaxReplicas: 2, Metrics: map[string]string{"foo": "bar"}})
+	c.Assert(err, gc.ErrorMatches, `target value "bar" for metric "foo" not valid`)
+
+	app, _ = s.getApp(c, caas.DeploymentDaemon, false)
+	err = app.EnsureAutoscaler(&caas.AutoscalingPolicy{MaxReplicas: 2})
+	c.Assert(err, gc.ErrorMatches, `autoscaling daemon application not supported`)
+}
+
+func (s *applicationSuite) TestEnsureNetworkPolicy(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	err := app.EnsureNetworkPolicy(&caas.NetworkPolicy{
+		Applications: []string{"postgresql", "gitlab"},
+		CIDRs:        []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	np, err := s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(np.Spec, gc.DeepEquals, networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"model.juju.is/name": "controller"},
+				},
+			}, {
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"operator.juju.is/target": "model"},
+				},
+			}, {
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+				},
+			}, {
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "postgresql"},
+				},
+			}, {
+				IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+			}},
+		}},
+	})
+
+	// Removing a relation removes the peer.
+	err = app.EnsureNetworkPolicy(&caas.NetworkPolicy{Applications: []string{"gitlab"}})
+	c.Assert(err, jc.ErrorIsNil)
+	np, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(np.Spec.Ingress[0].From, gc.HasLen, 3)
+
+	c.Assert(app.EnsureNetworkPolicy(nil), jc.ErrorIsNil)
+	_, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, gc.ErrorMatches, `networkpolicies.networking.k8s.io "gitlab" not found`)
+}
+
+func (s *applicationSuite) TestAutoscalerStatus(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	_, err := app.AutoscalerStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.client.AppsV1().StatefulSets("test").Create(context.TODO(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab", Namespace: "test", Generation: 7},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Create(context.TODO(), &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab", Namespace: "test"},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			MinReplicas: application.Int32Ptr(2),
+			MaxReplicas: 5,
+		},
+		Status: autoscalingv2beta2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 5,
+			Conditions: []autoscalingv2beta2.HorizontalPodAutoscalerCondition{{
+				Type:   autoscalingv2beta2.AbleToScale,
+				Status: corev1.ConditionTrue,
+			}, {
+				Type:    autoscalingv2beta2.ScalingLimited,
+				Status:  corev1.ConditionTrue,
+				Message: "the desired replica count is more than the maximum replica count",
+			}},
+		},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := app.AutoscalerStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, caas.AutoscalerStatus{
+		MinReplicas:     2,
+		MaxReplicas:     5,
+		CurrentReplicas: 3,
+		DesiredReplicas: 5,
+		Generation:      7,
+		Message:         "the desired replica count is more than the maximum replica count",
+	})
+}
@@ -0,0 +1,196 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+)
+
+// Scale sets the number of replicas of the application.
+func (a *app) Scale(replicas int) error {
+	if replicas < 0 {
+		return errors.NotValidf("negative scale %d", replicas)
+	}
+	ctx := context.Background()
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	opts := metav1.PatchOptions{FieldManager: resources.JujuFieldManager}
+	var err error
+	switch a.deploymentType {
+	case caas.DeploymentStateful:
+		_, err = a.client.AppsV1().StatefulSets(a.namespace).Patch(ctx, a.name, types.MergePatchType, patch, opts)
+	case caas.DeploymentStateless:
+		_, err = a.client.AppsV1().Deployments(a.namespace).Patch(ctx, a.name, types.MergePatchType, patch, opts)
+	default:
+		return errors.NotSupportedf("scaling %s application", a.deploymentType)
+	}
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("application %q", a.name)
+	}
+	return errors.Trace(err)
+}
+
+// EnsureAutoscaler creates or updates the horizontal pod autoscaler of the
+// application, or removes it if policy is nil.
+func (a *app) EnsureAutoscaler(policy *caas.AutoscalingPolicy) error {
+	applier := a.newApplier()
+	if policy == nil {
+		applier.Delete(resources.NewHorizontalPodAutoscaler(a.name, a.namespace, nil))
+		return applier.Run(context.Background(), a.client, false)
+	}
+	spec, err := a.autoscalerSpec(*policy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hpa := resources.NewHorizontalPodAutoscaler(a.name, a.namespace, &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: a.labels(),
+		},
+		Spec: spec,
+	})
+	applier.Apply(hpa)
+	return applier.Run(context.Background(), a.client, false)
+}
+
+func (a *app) autoscalerSpec(policy caas.AutoscalingPolicy) (autoscalingv2beta2.HorizontalPodAutoscalerSpec, error) {
+	var kind string
+	switch a.deploymentType {
+	case caas.DeploymentStateful:
+		kind = "StatefulSet"
+	case caas.DeploymentStateless:
+		kind = "Deployment"
+	default:
+		return autoscalingv2beta2.HorizontalPodAutoscalerSpec{},
+			errors.NotSupportedf("autoscaling %s application", a.deploymentType)
+	}
+	minReplicas := policy.MinReplicas
+	if minReplicas < 1 {
+		minReplicas = 1
+	}
+	if policy.MaxReplicas < minReplicas {
+		return autoscalingv2beta2.HorizontalPodAutoscalerSpec{},
+			errors.NotValidf("max replicas %d less than min replicas %d", policy.MaxReplicas, minReplicas)
+	}
+	spec := autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       kind,
+			Name:       a.name,
+		},
+		MinReplicas: int32Ptr(int32(minReplicas)),
+		MaxReplicas: int32(policy.MaxReplicas),
+	}
+	// Without any metrics, kubernetes defaults to targeting 80% of the
+	// requested CPU.
+	if policy.TargetCPUUtilization > 0 {
+		spec.Metrics = append(spec.Metrics, resourceUtilizationMetric(corev1.ResourceCPU, policy.TargetCPUUtilization))
+	}
+	if policy.TargetMemoryUtilization > 0 {
+		spec.Metrics = append(spec.Metrics, resourceUtilizationMetric(corev1.ResourceMemory, policy.TargetMemoryUtilization))
+	}
+	names := make([]string, 0, len(policy.Metrics))
+	for name := range policy.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := resource.ParseQuantity(policy.Metrics[name])
+		if err != nil {
+			return autoscalingv2beta2.HorizontalPodAutoscalerSpec{},
+				errors.NotValidf("target value %q for metric %q", policy.Metrics[name], name)
+		}
+		spec.Metrics = append(spec.Metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.PodsMetricSourceType,
+			Pods: &autoscalingv2beta2.PodsMetricSource{
+				Metric: autoscalingv2beta2.MetricIdentifier{Name: name},
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:         autoscalingv2beta2.AverageValueMetricType,
+					AverageValue: &value,
+				},
+			},
+		})
+	}
+	return spec, nil
+}
+
+func resourceUtilizationMetric(name corev1.ResourceName, utilization int) autoscalingv2beta2.MetricSpec {
+	return autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:               autoscalingv2beta2.UtilizationMetricType,
+				AverageUtilization: int32Ptr(int32(utilization)),
+			},
+		},
+	}
+}
+
+// AutoscalerStatus returns the observed state of the horizontal pod
+// autoscaler of the application.
+func (a *app) AutoscalerStatus() (caas.AutoscalerStatus, error) {
+	hpa := resources.NewHorizontalPodAutoscaler(a.name, a.namespace, nil)
+	if err := hpa.Get(context.Background(), a.client); err != nil {
+		return caas.AutoscalerStatus{}, errors.Trace(err)
+	}
+	result := caas.AutoscalerStatus{
+		MinReplicas:     1,
+		MaxReplicas:     int(hpa.Spec.MaxReplicas),
+		CurrentReplicas: int(hpa.Status.CurrentReplicas),
+		DesiredReplicas: int(hpa.Status.DesiredReplicas),
+	}
+	if hpa.Spec.MinReplicas != nil {
+		result.MinReplicas = int(*hpa.Spec.MinReplicas)
+	}
+	generation, err := a.workloadGeneration()
+	if err != nil {
+		return caas.AutoscalerStatus{}, errors.Trace(err)
+	}
+	result.Generation = generation
+	for _, cond := range hpa.Status.Conditions {
+		switch {
+		case cond.Type == autoscalingv2beta2.AbleToScale && cond.Status == corev1.ConditionFalse,
+			cond.Type == autoscalingv2beta2.ScalingActive && cond.Status == corev1.ConditionFalse,
+			cond.Type == autoscalingv2beta2.ScalingLimited && cond.Status == corev1.ConditionTrue:
+			result.Message = cond.Message
+		}
+		if result.Message != "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+// workloadGeneration returns the generation of the workload scaled by
+// the autoscaler, which changes each time the autoscaler scales it.
+func (a *app) workloadGeneration() (int64, error) {
+	switch a.deploymentType {
+	case caas.DeploymentStateful:
+		ss := resources.NewStatefulSet(a.name, a.namespace, nil)
+		if err := ss.Get(context.Background(), a.client); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return ss.GetGeneration(), nil
+	case caas.DeploymentStateless:
+		d := resources.NewDeployment(a.name, a.namespace, nil)
+		if err := d.Get(context.Background(), a.client); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return d.GetGeneration(), nil
+	default:
+		return 0, errors.NotSupportedf("autoscaling %s application", a.deploymentType)
+	}
+}
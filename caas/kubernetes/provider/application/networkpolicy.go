@@ -0,0 +1,104 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"context"
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+	k8sutils "github.com/juju/juju/caas/kubernetes/provider/utils"
+)
+
+const (
+	// controllerModelName is the name of the model whose namespace
+	// the controller runs in.
+	controllerModelName = "controller"
+
+	// modelOperatorTarget is the operator target label value of the
+	// model operator.
+	modelOperatorTarget = "model"
+)
+
+// EnsureNetworkPolicy creates or updates the network policy restricting
+// ingress to the units of the application, or removes it if policy is nil.
+func (a *app) EnsureNetworkPolicy(policy *caas.NetworkPolicy) error {
+	applier := a.newApplier()
+	if policy == nil {
+		applier.Delete(resources.NewNetworkPolicy(a.name, a.namespace, nil))
+		return applier.Run(context.Background(), a.client, false)
+	}
+	np := resources.NewNetworkPolicy(a.name, a.namespace, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: a.labels(),
+		},
+		Spec: a.networkPolicySpec(*policy),
+	})
+	applier.Apply(np)
+	return applier.Run(context.Background(), a.client, false)
+}
+
+func (a *app) networkPolicySpec(policy caas.NetworkPolicy) networkingv1.NetworkPolicySpec {
+	peers := []networkingv1.NetworkPolicyPeer{
+		// The controller.
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: k8sutils.LabelsForModel(controllerModelName, false),
+			},
+		},
+		// The model operator.
+		{
+			PodSelector: a.modelOperatorSelector(),
+		},
+	}
+
+	applications := append([]string(nil), policy.Applications...)
+	sort.Strings(applications)
+	for _, name := range applications {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: k8sutils.SelectorLabelsForApp(name, a.legacyLabels),
+			},
+		})
+	}
+
+	cidrs := append([]string(nil), policy.CIDRs...)
+	sort.Strings(cidrs)
+	for _, cidr := range cidrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: a.selectorLabels(),
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			From: peers,
+		}},
+	}
+}
+
+func (a *app) modelOperatorSelector() *metav1.LabelSelector {
+	if a.legacyLabels {
+		return &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      constants.LegacyLabelModelOperator,
+				Operator: metav1.LabelSelectorOpExists,
+			}},
+		}
+	}
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			constants.LabelJujuOperatorTarget: modelOperatorTarget,
+		},
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+const autoscalingConfigPrefix = "kubernetes-autoscaling-"
+
+// IsAutoscalingConfigKey reports whether the application config key is
+// part of the autoscaling policy.
+func IsAutoscalingConfigKey(key string) bool {
+	return strings.HasPrefix(key, autoscalingConfigPrefix)
+}
+
+// AutoscalingPolicy returns the autoscaling policy defined by the
+// application config, or nil if autoscaling is not enabled.
+func AutoscalingPolicy(config application.ConfigAttributes) (*caas.AutoscalingPolicy, error) {
+	maxUnits, err := configInt(config, autoscalingMaxUnitsKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if maxUnits == 0 {
+		return nil, nil
+	}
+	policy := &caas.AutoscalingPolicy{MaxReplicas: maxUnits}
+	if policy.MinReplicas, err = configInt(config, autoscalingMinUnitsKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.TargetCPUUtilization, err = configInt(config, autoscalingTargetCPUKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.TargetMemoryUtilization, err = configInt(config, autoscalingTargetMemoryKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.Metrics, err = config.GetStringMap(autoscalingMetricsKey, nil); err != nil {
+		return nil, errors.Annotatef(err, "%s", autoscalingMetricsKey)
+	}
+	if err := validateAutoscalingPolicy(policy); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return policy, nil
+}
+
+func validateAutoscalingPolicy(policy *caas.AutoscalingPolicy) error {
+	if policy.MaxReplicas < 0 {
+		return errors.NotValidf("%s %d", autoscalingMaxUnitsKey, policy.MaxReplicas)
+	}
+	if policy.MinReplicas < 0 || policy.MinReplicas > policy.MaxReplicas {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"%s %d must be between 0 and %s %d",
+			autoscalingMinUnitsKey, policy.MinReplicas, autoscalingMaxUnitsKey, policy.MaxReplicas,
+		))
+	}
+	if policy.TargetCPUUtilization < 0 {
+		return errors.NotValidf("%s %d", autoscalingTargetCPUKey, policy.TargetCPUUtilization)
+	}
+	if policy.TargetMemoryUtilization < 0 {
+		return errors.NotValidf("%s %d", autoscalingTargetMemoryKey, policy.TargetMemoryUtilization)
+	}
+	for name, value := range policy.Metrics {
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return errors.NotValidf("%s target %q for metric %q", autoscalingMetricsKey, value, name)
+		}
+	}
+	return nil
+}
+
+// configInt returns the value of an integer config attribute, which
+// may have been decoded from the database or from JSON as a different
+// numeric type.
+func configInt(config application.ConfigAttributes, key string) (int, error) {
+	switch v := config.Get(key, 0).(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	default:
+		return 0, errors.NotValidf("%s value of type %T", key, v)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/testing"
+)
+
+type autoscalingSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&autoscalingSuite{})
+
+func (s *autoscalingSuite) TestIsAutoscalingConfigKey(c *gc.C) {
+	c.Assert(provider.IsAutoscalingConfigKey("kubernetes-autoscaling-max-units"), jc.IsTrue)
+	c.Assert(provider.IsAutoscalingConfigKey("kubernetes-service-type"), jc.IsFalse)
+}
+
+func (s *autoscalingSuite) TestAutoscalingPolicyDisabled(c *gc.C) {
+	policy, err := provider.AutoscalingPolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.IsNil)
+
+	policy, err = provider.AutoscalingPolicy(application.ConfigAttributes{
+		"kubernetes-autoscaling-min-units":  2,
+		"kubernetes-autoscaling-target-cpu": 70,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.IsNil)
+}
+
+func (s *autoscalingSuite) TestAutoscalingPolicy(c *gc.C) {
+	policy, err := provider.AutoscalingPolicy(application.ConfigAttributes{
+		"kubernetes-autoscaling-min-units":     int64(2),
+		"kubernetes-autoscaling-max-units":     float64(10),
+		"kubernetes-autoscaling-target-cpu":    70,
+		"kubernetes-autoscaling-target-memory": 80,
+		"kubernetes-autoscaling-metrics":       map[string]interface{}{"requests_per_second": "100"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, &caas.AutoscalingPolicy{
+		MinReplicas:             2,
+		MaxReplicas:             10,
+		TargetCPUUtilization:    70,
+		TargetMemoryUtilization: 80,
+		Metrics:                 map[string]string{"requests_per_second": "100"},
+	})
+}
+
+func (s *autoscalingSuite) TestAutoscalingPolicyInvalid(c *gc.C) {
+	for i, t := range []struct {
+		config application.ConfigAttributes
+		err    string
+	}{{
+		config: application.ConfigAttributes{"kubernetes-autoscaling-max-units": "ten"},
+		err:    `kubernetes-autoscaling-max-units value of type string not valid`,
+	}, {
+		config: application.ConfigAttributes{"kubernetes-autoscaling-max-units": -1},
+		err:    `kubernetes-autoscaling-max-units -1 not valid`,
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-min-units": 3,
+			"kubernetes-autoscaling-max-units": 2,
+		},
+		err: `kubernetes-autoscaling-min-units 3 must be between 0 and kubernetes-autoscaling-max-units 2`,
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-units":  2,
+			"kubernetes-autoscaling-target-cpu": -10,
+		},
+		err: `kubernetes-autoscaling-target-cpu -10 not valid`,
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-units": 2,
+			"kubernetes-autoscaling-metrics":   map[string]interface{}{"foo": "bar"},
+		},
+		err: `kubernetes-autoscaling-metrics target "bar" for metric "foo" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := provider.AutoscalingPolicy(t.config)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
@@ -28,6 +28,15 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	gatewayKey       = "kubernetes-gateway"
+	gatewayRoutesKey = "kubernetes-gateway-routes"
+
+	autoscalingMinUnitsKey     = "kubernetes-autoscaling-min-units"
+	autoscalingMaxUnitsKey     = "kubernetes-autoscaling-max-units"
+	autoscalingTargetCPUKey    = "kubernetes-autoscaling-target-cpu"
+	autoscalingTargetMemoryKey = "kubernetes-autoscaling-target-memory"
+	autoscalingMetricsKey      = "kubernetes-autoscaling-metrics"
 )
 
 var configFields = environschema.Fields{
@@ -86,6 +95,41 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	gatewayKey: {
+		Description: "the name, or namespace/name, of the Gateway to attach Gateway API routes to when exposed instead of creating an ingress",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	gatewayRoutesKey: {
+		Description: "a space separated set of endpoints and the Gateway API routes to create for them when exposed, e.g. website=http:80 api=grpc:9000 db=tcp:5432",
+		Type:        environschema.Tattrs,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMinUnitsKey: {
+		Description: "the minimum number of units the autoscaler scales the application down to",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMaxUnitsKey: {
+		Description: "the maximum number of units the autoscaler scales the application up to, autoscaling is enabled when set",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingTargetCPUKey: {
+		Description: "the target average CPU utilization of the units, as a percentage of the requested CPU",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingTargetMemoryKey: {
+		Description: "the target average memory utilization of the units, as a percentage of the requested memory",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMetricsKey: {
+		Description: "a space separated set of custom per-unit metrics and their target average values, e.g. requests_per_second=100",
+		Type:        environschema.Tattrs,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -95,6 +139,15 @@ var schemaDefaults = schema.Defaults{
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+
+	gatewayKey:       schema.Omit,
+	gatewayRoutesKey: schema.Omit,
+
+	autoscalingMinUnitsKey:     schema.Omit,
+	autoscalingMaxUnitsKey:     schema.Omit,
+	autoscalingTargetCPUKey:    schema.Omit,
+	autoscalingTargetMemoryKey: schema.Omit,
+	autoscalingMetricsKey:      schema.Omit,
 }
 
 // ConfigSchema returns the configuration schema for
@@ -40,6 +40,10 @@ const (
 	// describe their name.
 	LabelJujuStorageName = "storage.juju.is/name"
 
+	// LabelJujuSecretID is the juju label applied to Kubernetes secrets
+	// holding charm secret content to identify the charm secret.
+	LabelJujuSecretID = "secret.juju.is/id"
+
 	// LegacyLabelKubernetesAppName is the legacy label key used for juju app
 	// identification. This purely exists to maintain backwards functionality.
 	// See https://bugs.launchpad.net/juju/+bug/1888513
@@ -5,16 +5,24 @@ package provider
 
 import (
 	"context"
+	"sort"
 
 	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/informers"
 
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
 	"github.com/juju/juju/core/watcher"
 )
 
+// maxUnitEvents is the number of the most recent pod
+// events returned for a unit.
+const maxUnitEvents = 20
+
 // Constants below are copied from "k8s.io/kubernetes/pkg/kubelet/events"
 // to avoid introducing the huge dependency.
 // Remove them once k8s.io/kubernetes added as a dependency.
@@ -59,6 +67,46 @@ func (k *kubernetesClient) getEvents(objName string, objKind string) ([]core.Eve
 	return eventList.Items, nil
 }
 
+// UnitEvents returns the most recent events for the pod of the unit
+// with the specified provider id, oldest first.
+func (k *kubernetesClient) UnitEvents(providerId string) ([]caas.UnitEvent, error) {
+	// Stateful units use the pod name as the provider id, others the pod UID.
+	field := "involvedObject.uid"
+	_, err := k.client().CoreV1().Pods(k.namespace).Get(context.TODO(), providerId, v1.GetOptions{})
+	if err == nil {
+		field = "involvedObject.name"
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	eventList, err := k.client().CoreV1().Events(k.namespace).List(context.TODO(), v1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector(field, providerId),
+			fields.OneTermEqualSelector("involvedObject.kind", "Pod"),
+		).String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	events := eventList.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return resources.EventTime(events[i]).Before(resources.EventTime(events[j]))
+	})
+	if len(events) > maxUnitEvents {
+		events = events[len(events)-maxUnitEvents:]
+	}
+	result := make([]caas.UnitEvent, len(events))
+	for i, event := range events {
+		result[i] = caas.UnitEvent{
+			Type:    event.Type,
+			Reason:  event.Reason,
+			Message: event.Message,
+			Count:   int(event.Count),
+			Time:    resources.EventTime(event),
+		}
+	}
+	return result, nil
+}
+
 func (k *kubernetesClient) watchEvents(objName string, objKind string) (watcher.NotifyWatcher, error) {
 	factory := informers.NewSharedInformerFactoryWithOptions(k.client(), 0,
 		informers.WithNamespace(k.namespace),
@@ -0,0 +1,75 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+func (s *K8sBrokerSuite) TestUnitEventsByPodName(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get(gomock.Any(), "mysql-0", v1.GetOptions{}).
+			Return(&core.Pod{ObjectMeta: v1.ObjectMeta{Name: "mysql-0"}}, nil),
+		s.mockEvents.EXPECT().List(gomock.Any(), v1.ListOptions{
+			FieldSelector: "involvedObject.name=mysql-0,involvedObject.kind=Pod",
+		}).Return(&core.EventList{Items: []core.Event{{
+			Type:          core.EventTypeWarning,
+			Reason:        "Failed",
+			Message:       `Failed to pull image "mysql:bad"`,
+			Count:         3,
+			LastTimestamp: v1.NewTime(now.Add(time.Minute)),
+		}, {
+			Type:          core.EventTypeNormal,
+			Reason:        "Scheduled",
+			Message:       "Successfully assigned test/mysql-0 to node1",
+			Count:         1,
+			LastTimestamp: v1.NewTime(now),
+		}}}, nil),
+	)
+
+	events, err := s.broker.UnitEvents("mysql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, []caas.UnitEvent{{
+		Type:    "Normal",
+		Reason:  "Scheduled",
+		Message: "Successfully assigned test/mysql-0 to node1",
+		Count:   1,
+		Time:    now,
+	}, {
+		Type:    "Warning",
+		Reason:  "Failed",
+		Message: `Failed to pull image "mysql:bad"`,
+		Count:   3,
+		Time:    now.Add(time.Minute),
+	}})
+}
+
+func (s *K8sBrokerSuite) TestUnitEventsByPodUID(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get(gomock.Any(), "uuid", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockEvents.EXPECT().List(gomock.Any(), v1.ListOptions{
+			FieldSelector: "involvedObject.uid=uuid,involvedObject.kind=Pod",
+		}).Return(&core.EventList{}, nil),
+	)
+
+	events, err := s.broker.UnitEvents("uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 0)
+}
@@ -40,6 +40,7 @@ var (
 	ToYaml                 = toYaml
 	Indent                 = indent
 	ProcessSecretData      = processSecretData
+	GetSvcAddresses        = getSvcAddresses
 
 	CompileK8sCloudCheckers                    = compileK8sCloudCheckers
 	CompileLifecycleApplicationRemovalSelector = compileLifecycleApplicationRemovalSelector
@@ -0,0 +1,293 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	// gatewayHostnamesAnnotationKey records the hostnames of the
+	// Gateway API routes of an exposed application on its service,
+	// so they can be reported as public addresses.
+	gatewayHostnamesAnnotationKey = "gateway.juju.is/hostnames"
+
+	gatewayRouteHTTP = "http"
+	gatewayRouteGRPC = "grpc"
+	gatewayRouteTCP  = "tcp"
+)
+
+// gatewayRouteResources maps the supported route kinds to
+// the Gateway API resources used to create them.
+var gatewayRouteResources = map[string]struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}{
+	gatewayRouteHTTP: {
+		kind: "HTTPRoute",
+		gvr:  schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "httproutes"},
+	},
+	gatewayRouteGRPC: {
+		kind: "GRPCRoute",
+		gvr:  schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "grpcroutes"},
+	},
+	gatewayRouteTCP: {
+		kind: "TCPRoute",
+		gvr:  schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "tcproutes"},
+	},
+}
+
+// gatewayRoute describes a single route to an application's service.
+type gatewayRoute struct {
+	name string
+	kind string
+	port int32
+}
+
+// parseGatewayRef parses a "name" or "namespace/name" Gateway reference
+// into the parentRef of a route.
+func parseGatewayRef(ref string) (map[string]interface{}, error) {
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return map[string]interface{}{"name": parts[0]}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return map[string]interface{}{"namespace": parts[0], "name": parts[1]}, nil
+	}
+	return nil, errors.NotValidf("%s %q", gatewayKey, ref)
+}
+
+// gatewayRoutes returns the routes to create for the exposed endpoints of
+// the application. Without any configured routes, a single HTTP route to
+// the first port of the service is used.
+func gatewayRoutes(
+	deploymentName string, exposedEndpoints []string, config application.ConfigAttributes, svc *core.Service,
+) ([]gatewayRoute, error) {
+	if len(svc.Spec.Ports) == 0 {
+		return nil, errors.Errorf("cannot create gateway route for service %q without a port", svc.Name)
+	}
+	defaultPort := svc.Spec.Ports[0].Port
+
+	configured, err := config.GetStringMap(gatewayRoutesKey, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "%s", gatewayRoutesKey)
+	}
+	if len(configured) == 0 {
+		return []gatewayRoute{{
+			name: deploymentName,
+			kind: gatewayRouteHTTP,
+			port: defaultPort,
+		}}, nil
+	}
+
+	// An empty set of exposed endpoints, or the wildcard endpoint,
+	// means all of the application's endpoints are exposed.
+	exposed := set.NewStrings(exposedEndpoints...)
+	allExposed := exposed.IsEmpty() || exposed.Contains("")
+
+	var routes []gatewayRoute
+	for endpoint, value := range configured {
+		if !allExposed && !exposed.Contains(endpoint) {
+			continue
+		}
+		route := gatewayRoute{
+			name: fmt.Sprintf("%s-%s", deploymentName, endpoint),
+			port: defaultPort,
+		}
+		parts := strings.SplitN(value, ":", 2)
+		route.kind = parts[0]
+		if _, ok := gatewayRouteResources[route.kind]; !ok {
+			return nil, errors.NotValidf("%s route kind %q for endpoint %q", gatewayRoutesKey, route.kind, endpoint)
+		}
+		if len(parts) == 2 {
+			port, err := strconv.ParseInt(parts[1], 10, 32)
+			if err != nil || port <= 0 {
+				return nil, errors.NotValidf("%s port %q for endpoint %q", gatewayRoutesKey, parts[1], endpoint)
+			}
+			route.port = int32(port)
+		}
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].name < routes[j].name
+	})
+	return routes, nil
+}
+
+// gatewayRouteSpec returns the Gateway API object for the specified route.
+func gatewayRouteSpec(
+	route gatewayRoute, parentRef map[string]interface{}, labels map[string]string,
+	hostname, httpPath, serviceName string,
+) *unstructured.Unstructured {
+	res := gatewayRouteResources[route.kind]
+	backendRefs := []interface{}{
+		map[string]interface{}{
+			"name": serviceName,
+			"port": int64(route.port),
+		},
+	}
+	rule := map[string]interface{}{
+		"backendRefs": backendRefs,
+	}
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"rules":      []interface{}{rule},
+	}
+	if route.kind != gatewayRouteTCP && hostname != "" {
+		spec["hostnames"] = []interface{}{hostname}
+	}
+	if route.kind == gatewayRouteHTTP {
+		rule["matches"] = []interface{}{
+			map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":  "PathPrefix",
+					"value": httpPath,
+				},
+			},
+		}
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	obj.SetAPIVersion(res.gvr.GroupVersion().String())
+	obj.SetKind(res.kind)
+	obj.SetName(route.name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+// exposeGatewayRoutes creates or updates the Gateway API routes attached to the
+// configured Gateway for the exposed endpoints of the application, and removes
+// any of the application's routes which are no longer needed.
+func (k *kubernetesClient) exposeGatewayRoutes(
+	appName string, resourceTags map[string]string, exposedEndpoints []string, config application.ConfigAttributes,
+) error {
+	logger.Debugf("creating/updating gateway routes for %s", appName)
+
+	parentRef, err := parseGatewayRef(config.GetString(gatewayKey, ""))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hostname := config.GetString(caas.JujuExternalHostNameKey, "")
+	httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
+	if httpPath == "$appname" {
+		httpPath = appName
+	}
+	if !strings.HasPrefix(httpPath, "/") {
+		httpPath = "/" + httpPath
+	}
+
+	deploymentName := k.deploymentName(appName, true)
+	svc, err := k.client().CoreV1().Services(k.namespace).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	routes, err := gatewayRoutes(deploymentName, exposedEndpoints, config, svc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	labels := k8slabels.Merge(resourceTags, k.getIngressLabels(appName))
+	keep := set.NewStrings()
+	var hostnames []string
+	for _, route := range routes {
+		obj := gatewayRouteSpec(route, parentRef, labels, hostname, httpPath, svc.Name)
+		api := k.dynamicClient().Resource(gatewayRouteResources[route.kind].gvr).Namespace(k.namespace)
+		if _, _, err := ensureCustomResource(api, obj); err != nil {
+			if k8serrors.IsNotFound(errors.Cause(err)) {
+				return errors.NewNotSupported(err, fmt.Sprintf("gateway API %s resources", obj.GetKind()))
+			}
+			return errors.Annotatef(err, "ensuring %s %q", obj.GetKind(), obj.GetName())
+		}
+		keep.Add(route.name)
+		if route.kind != gatewayRouteTCP && hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	// The application may previously have been exposed with an ingress.
+	if err := k.deleteIngress(deploymentName, ""); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteGatewayRoutes(appName, keep); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.setGatewayHostnames(svc, hostnames))
+}
+
+// deleteGatewayRoutes deletes the application's Gateway API routes,
+// except for those named in keep.
+func (k *kubernetesClient) deleteGatewayRoutes(appName string, keep set.Strings) error {
+	selector := utils.LabelsToSelector(k.getIngressLabels(appName)).String()
+	for _, routeKind := range []string{gatewayRouteHTTP, gatewayRouteGRPC, gatewayRouteTCP} {
+		api := k.dynamicClient().Resource(gatewayRouteResources[routeKind].gvr).Namespace(k.namespace)
+		list, err := api.List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+		if k8serrors.IsNotFound(err) {
+			// The Gateway API resource isn't installed in the cluster.
+			continue
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, item := range list.Items {
+			if keep.Contains(item.GetName()) {
+				continue
+			}
+			logger.Debugf("deleting %s %q", item.GetKind(), item.GetName())
+			if err := deleteCustomResourceDefinition(api, item.GetName(), item.GetUID()); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// setGatewayHostnames records the hostnames of the application's
+// Gateway API routes on its service.
+func (k *kubernetesClient) setGatewayHostnames(svc *core.Service, hostnames []string) error {
+	value := strings.Join(set.NewStrings(hostnames...).SortedValues(), ",")
+	if svc.Annotations[gatewayHostnamesAnnotationKey] == value {
+		return nil
+	}
+	if value == "" {
+		delete(svc.Annotations, gatewayHostnamesAnnotationKey)
+	} else {
+		if svc.Annotations == nil {
+			svc.Annotations = make(map[string]string)
+		}
+		svc.Annotations[gatewayHostnamesAnnotationKey] = value
+	}
+	_, err := k.client().CoreV1().Services(k.namespace).Update(context.TODO(), svc, metav1.UpdateOptions{})
+	return errors.Trace(err)
+}
+
+// gatewayHostnames returns the Gateway API route hostnames
+// recorded on the service.
+func gatewayHostnames(svc *core.Service) []string {
+	value := svc.Annotations[gatewayHostnamesAnnotationKey]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
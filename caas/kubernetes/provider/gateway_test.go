@@ -0,0 +1,319 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/network"
+)
+
+var (
+	httpRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "httproutes"}
+	grpcRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "grpcroutes"}
+	tcpRouteGVR  = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "tcproutes"}
+
+	gatewayRouteLabels = map[string]interface{}{
+		"app.kubernetes.io/managed-by": "juju",
+		"app.kubernetes.io/name":       "gitlab",
+	}
+)
+
+// expectGatewayRoutes expects the application's gateway routes of each
+// kind to be listed, returning the supplied existing routes.
+func (s *K8sBrokerSuite) expectGatewayRoutes(existing ...unstructured.Unstructured) {
+	var calls []*gomock.Call
+	for _, kind := range []struct {
+		name string
+		gvr  schema.GroupVersionResource
+	}{
+		{"HTTPRoute", httpRouteGVR},
+		{"GRPCRoute", grpcRouteGVR},
+		{"TCPRoute", tcpRouteGVR},
+	} {
+		var items []unstructured.Unstructured
+		for _, route := range existing {
+			if route.GetKind() == kind.name {
+				items = append(items, route)
+			}
+		}
+		calls = append(calls,
+			s.mockDynamicClient.EXPECT().Resource(kind.gvr).Return(s.mockNamespaceableResourceClient),
+			s.mockResourceClient.EXPECT().List(gomock.Any(), v1.ListOptions{
+				LabelSelector: "app.kubernetes.io/managed-by=juju,app.kubernetes.io/name=gitlab",
+			}).Return(&unstructured.UnstructuredList{Items: items}, nil),
+		)
+	}
+	gomock.InOrder(calls...)
+}
+
+func gatewayService() *core.Service {
+	return &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "juju", "app.kubernetes.io/name": "gitlab"},
+		},
+		Spec: core.ServiceSpec{
+			Type: core.ServiceTypeClusterIP,
+			Ports: []core.ServicePort{{
+				Protocol: core.ProtocolTCP,
+				Port:     80,
+			}},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceGatewayDefaultRoute(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name":   "gitlab",
+			"labels": gatewayRouteLabels,
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"namespace": "infra", "name": "gateway"},
+			},
+			"hostnames": []interface{}{"gitlab.example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"matches": []interface{}{
+						map[string]interface{}{
+							"path": map[string]interface{}{"type": "PathPrefix", "value": "/"},
+						},
+					},
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "gitlab", "port": int64(80)},
+					},
+				},
+			},
+		},
+	}}
+	stale := unstructured.Unstructured{}
+	stale.SetKind("TCPRoute")
+	stale.SetName("gitlab-db")
+	stale.SetUID(k8stypes.UID("stale-uid"))
+
+	svc := gatewayService()
+	annotatedSvc := gatewayService()
+	annotatedSvc.Annotations = map[string]string{"gateway.juju.is/hostnames": "gitlab.example.com"}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "gitlab", v1.GetOptions{}).
+			Return(svc, nil),
+		s.mockDynamicClient.EXPECT().Resource(httpRouteGVR).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any(), route, v1.CreateOptions{}).Return(route, nil),
+		s.mockIngressV1.EXPECT().Delete(gomock.Any(), "gitlab", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+	s.expectGatewayRoutes(*route, stale)
+	gomock.InOrder(
+		s.mockResourceClient.EXPECT().Delete(gomock.Any(), "gitlab-db", s.deleteOptions(v1.DeletePropagationForeground, "stale-uid")).
+			Return(nil),
+		s.mockServices.EXPECT().Update(gomock.Any(), annotatedSvc, v1.UpdateOptions{}).
+			Return(annotatedSvc, nil),
+	)
+
+	err := s.broker.ExposeService("gitlab", nil, nil, application.ConfigAttributes{
+		"kubernetes-gateway":     "infra/gateway",
+		"juju-external-hostname": "gitlab.example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceGatewayRoutesForExposedEndpoints(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	grpcRoute := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1alpha2",
+		"kind":       "GRPCRoute",
+		"metadata": map[string]interface{}{
+			"name":   "gitlab-api",
+			"labels": gatewayRouteLabels,
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "gateway"},
+			},
+			"hostnames": []interface{}{"gitlab.example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "gitlab", "port": int64(9000)},
+					},
+				},
+			},
+		},
+	}}
+	tcpRoute := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1alpha2",
+		"kind":       "TCPRoute",
+		"metadata": map[string]interface{}{
+			"name":   "gitlab-db",
+			"labels": gatewayRouteLabels,
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "gateway"},
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "gitlab", "port": int64(5432)},
+					},
+				},
+			},
+		},
+	}}
+
+	svc := gatewayService()
+	annotatedSvc := gatewayService()
+	annotatedSvc.Annotations = map[string]string{"gateway.juju.is/hostnames": "gitlab.example.com"}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "gitlab", v1.GetOptions{}).
+			Return(svc, nil),
+		s.mockDynamicClient.EXPECT().Resource(grpcRouteGVR).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any(), grpcRoute, v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockResourceClient.EXPECT().Get(gomock.Any(), "gitlab-api", v1.GetOptions{}).
+			Return(grpcRoute, nil),
+		s.mockResourceClient.EXPECT().Update(gomock.Any(), grpcRoute, v1.UpdateOptions{}).
+			Return(grpcRoute, nil),
+		s.mockDynamicClient.EXPECT().Resource(tcpRouteGVR).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any(), tcpRoute, v1.CreateOptions{}).Return(tcpRoute, nil),
+		s.mockIngressV1.EXPECT().Delete(gomock.Any(), "gitlab", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+	s.expectGatewayRoutes(*grpcRoute, *tcpRoute)
+	s.mockServices.EXPECT().Update(gomock.Any(), annotatedSvc, v1.UpdateOptions{}).Return(annotatedSvc, nil)
+
+	err := s.broker.ExposeService("gitlab", nil, []string{"api", "db"}, application.ConfigAttributes{
+		"kubernetes-gateway": "gateway",
+		"kubernetes-gateway-routes": map[string]interface{}{
+			"website": "http",
+			"api":     "grpc:9000",
+			"db":      "tcp:5432",
+		},
+		"juju-external-hostname": "gitlab.example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceGatewayInvalidRoute(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "gitlab", v1.GetOptions{}).
+			Return(gatewayService(), nil),
+	)
+
+	err := s.broker.ExposeService("gitlab", nil, nil, application.ConfigAttributes{
+		"kubernetes-gateway": "gateway",
+		"kubernetes-gateway-routes": map[string]interface{}{
+			"website": "udp:53",
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `kubernetes-gateway-routes route kind "udp" for endpoint "website" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceGatewayInvalidGateway(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.broker.ExposeService("gitlab", nil, nil, application.ConfigAttributes{
+		"kubernetes-gateway": "infra/",
+	})
+	c.Assert(err, gc.ErrorMatches, `kubernetes-gateway "infra/" not valid`)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceGatewayAPINotInstalled(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "gitlab", v1.GetOptions{}).
+			Return(gatewayService(), nil),
+		s.mockDynamicClient.EXPECT().Resource(httpRouteGVR).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any(), gomock.Any(), v1.CreateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	err := s.broker.ExposeService("gitlab", nil, nil, application.ConfigAttributes{
+		"kubernetes-gateway": "gateway",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *K8sBrokerSuite) TestUnexposeService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	route := unstructured.Unstructured{}
+	route.SetKind("HTTPRoute")
+	route.SetName("gitlab")
+	route.SetUID(k8stypes.UID("route-uid"))
+
+	svc := gatewayService()
+	svc.Annotations = map[string]string{"gateway.juju.is/hostnames": "gitlab.example.com"}
+	unannotatedSvc := gatewayService()
+	unannotatedSvc.Annotations = map[string]string{}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressV1.EXPECT().Delete(gomock.Any(), "gitlab", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+	s.expectGatewayRoutes(route)
+	gomock.InOrder(
+		s.mockResourceClient.EXPECT().Delete(gomock.Any(), "gitlab", s.deleteOptions(v1.DeletePropagationForeground, "route-uid")).
+			Return(nil),
+		s.mockServices.EXPECT().Get(gomock.Any(), "gitlab", v1.GetOptions{}).
+			Return(svc, nil),
+		s.mockServices.EXPECT().Update(gomock.Any(), unannotatedSvc, v1.UpdateOptions{}).
+			Return(unannotatedSvc, nil),
+	)
+
+	err := s.broker.UnexposeService("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestGatewayHostnamesArePublicAddresses(c *gc.C) {
+	svc := gatewayService()
+	svc.Spec.ClusterIP = "10.0.0.1"
+	svc.Annotations = map[string]string{"gateway.juju.is/hostnames": "api.example.com,gitlab.example.com"}
+
+	c.Assert(provider.GetSvcAddresses(svc, false), jc.DeepEquals, []network.ProviderAddress{
+		network.NewScopedProviderAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedProviderAddress("api.example.com", network.ScopePublic),
+		network.NewScopedProviderAddress("gitlab.example.com", network.ScopePublic),
+	})
+}
@@ -595,6 +595,8 @@ func getSvcAddresses(svc *core.Service, includeClusterIP bool) []network.Provide
 	case core.ServiceTypeLoadBalancer:
 		appendUniqueAddrs(network.ScopePublic, getLoadBalancerAddress(svc))
 	}
+	// Hostnames of any gateway routes for the exposed service are public.
+	appendUniqueAddrs(network.ScopePublic, gatewayHostnames(svc)...)
 	if includeClusterIP {
 		// append clusterIP as a fixed internal address.
 		appendUniqueAddrs(network.ScopeCloudLocal, clusterIP)
@@ -1334,6 +1336,15 @@ func (k *kubernetesClient) configureStorage(
 				MountPath: mountPath,
 			})
 		}
+		if pvc != nil && fs.Shared {
+			// Shared storage is a single claim mounted by every
+			// pod of the application, rather than a claim per pod.
+			logger.Debugf("using shared persistent volume claim for %s filesystem %s: %s", appName, fs.StorageName, pretty.Sprint(*pvc))
+			if _, err = k.configurePVCForStatelessResource(*pvc, mountPath, readOnly, podSpec); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
 		if pvc != nil && handlePVC != nil {
 			logger.Debugf("using persistent volume claim for %s filesystem %s: %s", appName, fs.StorageName, pretty.Sprint(*pvc))
 			if err = handlePVC(*pvc, mountPath, readOnly); err != nil {
@@ -1856,7 +1867,13 @@ func (k *kubernetesClient) findDefaultIngressClassResource() (*string, error) {
 }
 
 // ExposeService sets up external access to the specified application.
-func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error {
+func (k *kubernetesClient) ExposeService(
+	appName string, resourceTags map[string]string, exposedEndpoints []string, config application.ConfigAttributes,
+) error {
+	if config.GetString(gatewayKey, "") != "" {
+		return errors.Trace(k.exposeGatewayRoutes(appName, resourceTags, exposedEndpoints, config))
+	}
+
 	logger.Debugf("creating/updating ingress resource for %s", appName)
 
 	host := config.GetString(caas.JujuExternalHostNameKey, "")
@@ -1934,15 +1951,34 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 
 	// TODO(caas): refactor juju expose to solve potential conflict with ingress definition in podspec.
 	// https://bugs.launchpad.net/juju/+bug/1854123
-	_, err = k.ensureIngressV1(appName, spec, true)
-	return errors.Trace(err)
+	if _, err = k.ensureIngressV1(appName, spec, true); err != nil {
+		return errors.Trace(err)
+	}
+	// The application may previously have been exposed with gateway routes.
+	if err := k.deleteGatewayRoutes(appName, nil); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.setGatewayHostnames(svc, nil))
 }
 
 // UnexposeService removes external access to the specified service.
 func (k *kubernetesClient) UnexposeService(appName string) error {
-	logger.Debugf("deleting ingress resource for %s", appName)
+	logger.Debugf("deleting ingress resource and gateway routes for %s", appName)
 	deploymentName := k.deploymentName(appName, true)
-	return errors.Trace(k.deleteIngress(deploymentName, ""))
+	if err := k.deleteIngress(deploymentName, ""); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteGatewayRoutes(appName, nil); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := k.client().CoreV1().Services(k.namespace).Get(context.TODO(), deploymentName, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.setGatewayHostnames(svc, nil))
 }
 
 func (k *kubernetesClient) applicationSelector(appName string, mode caas.DeploymentMode) string {
@@ -7562,8 +7562,9 @@ func (s *K8sBrokerSuite) TestExposeServiceIngressClassProvided(c *gc.C) {
 			Return(svc1, nil),
 		s.mockIngressV1.EXPECT().Create(gomock.Any(), ingress, v1.CreateOptions{}).Return(nil, nil),
 	)
+	s.expectGatewayRoutes()
 
-	err := s.broker.ExposeService("gitlab", nil, application.ConfigAttributes{
+	err := s.broker.ExposeService("gitlab", nil, nil, application.ConfigAttributes{
 		"kubernetes-ingress-class": "foo",
 		"juju-external-hostname":   "172.0.0.1.xip.io",
 	})
@@ -7647,8 +7648,9 @@ func (s *K8sBrokerSuite) TestExposeServiceGetDefaultIngressClassFromResource(c *
 			}}, nil),
 		s.mockIngressV1.EXPECT().Create(gomock.Any(), ingress, v1.CreateOptions{}).Return(nil, nil),
 	)
+	s.expectGatewayRoutes()
 
-	err := s.broker.ExposeService("gitlab", nil, application.ConfigAttributes{
+	err := s.broker.ExposeService("gitlab", nil, nil, application.ConfigAttributes{
 		"juju-external-hostname": "172.0.0.1.xip.io",
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -7721,8 +7723,9 @@ func (s *K8sBrokerSuite) TestExposeServiceGetDefaultIngressClass(c *gc.C) {
 			Return(&networkingv1.IngressClassList{Items: []networkingv1.IngressClass{}}, nil),
 		s.mockIngressV1.EXPECT().Create(gomock.Any(), ingress, v1.CreateOptions{}).Return(nil, nil),
 	)
+	s.expectGatewayRoutes()
 
-	err := s.broker.ExposeService("gitlab", nil, application.ConfigAttributes{
+	err := s.broker.ExposeService("gitlab", nil, nil, application.ConfigAttributes{
 		"juju-external-hostname": "172.0.0.1.xip.io",
 	})
 	c.Assert(err, jc.ErrorIsNil)
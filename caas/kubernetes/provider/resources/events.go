@@ -5,6 +5,7 @@ package resources
 
 import (
 	"context"
+	"time"
 
 	"github.com/juju/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -42,3 +43,32 @@ func ListEventsForObject(ctx context.Context, client kubernetes.Interface,
 	}
 	return items, nil
 }
+
+// LatestWarningEvent returns the most recent warning event in the list.
+func LatestWarningEvent(events []corev1.Event) (corev1.Event, bool) {
+	var (
+		latest corev1.Event
+		found  bool
+	)
+	for _, event := range events {
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		if !found || !EventTime(event).Before(EventTime(latest)) {
+			latest = event
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// EventTime returns the time an event last occurred.
+func EventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
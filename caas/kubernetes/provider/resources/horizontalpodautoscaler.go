@@ -0,0 +1,112 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/status"
+)
+
+// HorizontalPodAutoscaler extends the k8s horizontal pod autoscaler.
+type HorizontalPodAutoscaler struct {
+	autoscalingv2beta2.HorizontalPodAutoscaler
+}
+
+// NewHorizontalPodAutoscaler creates a new horizontal pod autoscaler resource.
+func NewHorizontalPodAutoscaler(
+	name string, namespace string, in *autoscalingv2beta2.HorizontalPodAutoscaler,
+) *HorizontalPodAutoscaler {
+	if in == nil {
+		in = &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	}
+	in.SetName(name)
+	in.SetNamespace(namespace)
+	return &HorizontalPodAutoscaler{*in}
+}
+
+// Clone returns a copy of the resource.
+func (h *HorizontalPodAutoscaler) Clone() Resource {
+	clone := *h
+	return &clone
+}
+
+// Apply patches the resource change.
+func (h *HorizontalPodAutoscaler) Apply(ctx context.Context, client kubernetes.Interface) error {
+	api := client.AutoscalingV2beta2().HorizontalPodAutoscalers(h.Namespace)
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &h.HorizontalPodAutoscaler)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := api.Patch(ctx, h.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{
+		FieldManager: JujuFieldManager,
+	})
+	if k8serrors.IsNotFound(err) {
+		res, err = api.Create(ctx, &h.HorizontalPodAutoscaler, metav1.CreateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	h.HorizontalPodAutoscaler = *res
+	return nil
+}
+
+// Get refreshes the resource.
+func (h *HorizontalPodAutoscaler) Get(ctx context.Context, client kubernetes.Interface) error {
+	api := client.AutoscalingV2beta2().HorizontalPodAutoscalers(h.Namespace)
+	res, err := api.Get(ctx, h.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "k8s")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	h.HorizontalPodAutoscaler = *res
+	return nil
+}
+
+// Delete removes the resource.
+func (h *HorizontalPodAutoscaler) Delete(ctx context.Context, client kubernetes.Interface) error {
+	api := client.AutoscalingV2beta2().HorizontalPodAutoscalers(h.Namespace)
+	err := api.Delete(ctx, h.Name, metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Events emitted by the resource.
+func (h *HorizontalPodAutoscaler) Events(ctx context.Context, client kubernetes.Interface) ([]corev1.Event, error) {
+	return ListEventsForObject(ctx, client, h.Namespace, h.Name, "HorizontalPodAutoscaler")
+}
+
+// ComputeStatus returns a juju status for the resource.
+func (h *HorizontalPodAutoscaler) ComputeStatus(ctx context.Context, client kubernetes.Interface, now time.Time) (string, status.Status, time.Time, error) {
+	if h.DeletionTimestamp != nil {
+		return "", status.Terminated, h.DeletionTimestamp.Time, nil
+	}
+	for _, cond := range h.Status.Conditions {
+		if cond.Type == autoscalingv2beta2.AbleToScale && cond.Status == corev1.ConditionFalse {
+			return cond.Message, status.Error, cond.LastTransitionTime.Time, nil
+		}
+	}
+	return "", status.Active, h.CreationTimestamp.Time, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+)
+
+type horizontalPodAutoscalerSuite struct {
+	resourceSuite
+}
+
+var _ = gc.Suite(&horizontalPodAutoscalerSuite{})
+
+func (s *horizontalPodAutoscalerSuite) TestApply(c *gc.C) {
+	ds := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hpa1",
+			Namespace: "test",
+		},
+	}
+	// Create.
+	dsResource := resources.NewHorizontalPodAutoscaler("hpa1", "test", ds)
+	c.Assert(dsResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+	result, err := s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "hpa1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(result.GetAnnotations()), gc.Equals, 0)
+
+	// Update.
+	ds.SetAnnotations(map[string]string{"a": "b"})
+	dsResource = resources.NewHorizontalPodAutoscaler("hpa1", "test", ds)
+	c.Assert(dsResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+
+	result, err = s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "hpa1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.GetName(), gc.Equals, `hpa1`)
+	c.Assert(result.GetNamespace(), gc.Equals, `test`)
+	c.Assert(result.GetAnnotations(), gc.DeepEquals, map[string]string{"a": "b"})
+}
+
+func (s *horizontalPodAutoscalerSuite) TestGet(c *gc.C) {
+	template := autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hpa1",
+			Namespace: "test",
+		},
+	}
+	ds1 := template
+	ds1.SetAnnotations(map[string]string{"a": "b"})
+	_, err := s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Create(context.TODO(), &ds1, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	dsResource := resources.NewHorizontalPodAutoscaler("hpa1", "test", &template)
+	c.Assert(len(dsResource.GetAnnotations()), gc.Equals, 0)
+	err = dsResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dsResource.GetName(), gc.Equals, `hpa1`)
+	c.Assert(dsResource.GetNamespace(), gc.Equals, `test`)
+	c.Assert(dsResource.GetAnnotations(), gc.DeepEquals, map[string]string{"a": "b"})
+}
+
+func (s *horizontalPodAutoscalerSuite) TestDelete(c *gc.C) {
+	ds := autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hpa1",
+			Namespace: "test",
+		},
+	}
+	_, err := s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Create(context.TODO(), &ds, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "hpa1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.GetName(), gc.Equals, `hpa1`)
+
+	dsResource := resources.NewHorizontalPodAutoscaler("hpa1", "test", &ds)
+	err = dsResource.Delete(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = dsResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.client.AutoscalingV2beta2().HorizontalPodAutoscalers("test").Get(context.TODO(), "hpa1", metav1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+}
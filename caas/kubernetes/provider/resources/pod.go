@@ -5,8 +5,10 @@ package resources
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -129,6 +131,14 @@ func (p *Pod) ComputeStatus(ctx context.Context, client kubernetes.Interface, no
 	case corev1.PodPending:
 		jujuStatus = status.Allocating
 	}
+	// A failing container explains why the pod isn't running,
+	// whatever phase the pod is in.
+	if message, failed := containerFailure(p.Status.InitContainerStatuses); failed {
+		return message, status.Error, now, nil
+	}
+	if message, failed := containerFailure(p.Status.ContainerStatuses); failed {
+		return message, status.Error, now, nil
+	}
 	statusMessage := p.Status.Message
 	since := now
 	if statusMessage == "" {
@@ -148,10 +158,52 @@ func (p *Pod) ComputeStatus(ctx context.Context, client kubernetes.Interface, no
 		if err != nil {
 			return "", "", time.Time{}, errors.Trace(err)
 		}
-		// Take the most recent event.
-		if count := len(eventList); count > 0 {
+		// Prefer the most recent warning, otherwise take the most recent event.
+		if event, ok := LatestWarningEvent(eventList); ok {
+			statusMessage = fmt.Sprintf("%s: %s", event.Reason, event.Message)
+		} else if count := len(eventList); count > 0 {
 			statusMessage = eventList[count-1].Message
 		}
 	}
 	return statusMessage, jujuStatus, since, nil
 }
+
+// containerFailureReasons are the container waiting and terminated
+// reasons which mean the container can't run without intervention.
+var containerFailureReasons = set.NewStrings(
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+	"ErrImageNeverPull",
+	"CrashLoopBackOff",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+	"RunContainerError",
+	"ContainerCannotRun",
+	"OOMKilled",
+	"Error",
+)
+
+// containerFailure returns a status message for the first container
+// which is waiting or terminated for a failure reason.
+func containerFailure(containers []corev1.ContainerStatus) (string, bool) {
+	for _, c := range containers {
+		var reason, message string
+		switch {
+		case c.State.Waiting != nil:
+			reason, message = c.State.Waiting.Reason, c.State.Waiting.Message
+		case c.State.Terminated != nil && c.State.Terminated.ExitCode != 0:
+			reason, message = c.State.Terminated.Reason, c.State.Terminated.Message
+		default:
+			continue
+		}
+		if !containerFailureReasons.Contains(reason) {
+			continue
+		}
+		if message == "" {
+			return fmt.Sprintf("container %q: %s", c.Name, reason), true
+		}
+		return fmt.Sprintf("container %q: %s: %s", c.Name, reason, message), true
+	}
+	return "", false
+}
@@ -5,6 +5,8 @@ package resources_test
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/caas/kubernetes/provider/resources"
+	"github.com/juju/juju/core/status"
 )
 
 type podSuite struct {
@@ -93,3 +96,108 @@ func (s *podSuite) TestDelete(c *gc.C) {
 	_, err = s.client.CoreV1().Pods("test").Get(context.TODO(), "ds1", metav1.GetOptions{})
 	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
 }
+
+func (s *podSuite) TestComputeStatusContainerFailure(c *gc.C) {
+	pod := resources.NewPod("pod1", "test", &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "charm",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}, {
+				Name: "mysql",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: `Back-off pulling image "mysql:bad"`,
+					},
+				},
+			}},
+		},
+	})
+	now := time.Now()
+	message, podStatus, since, err := pod.ComputeStatus(context.TODO(), s.client, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(podStatus, gc.Equals, status.Error)
+	c.Assert(message, gc.Equals, `container "mysql": ImagePullBackOff: Back-off pulling image "mysql:bad"`)
+	c.Assert(since, gc.Equals, now)
+}
+
+func (s *podSuite) TestComputeStatusContainerTerminated(c *gc.C) {
+	pod := resources.NewPod("pod1", "test", &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "mysql",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 137,
+						Reason:   "OOMKilled",
+					},
+				},
+			}},
+		},
+	})
+	message, podStatus, _, err := pod.ComputeStatus(context.TODO(), s.client, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(podStatus, gc.Equals, status.Error)
+	c.Assert(message, gc.Equals, `container "mysql": OOMKilled`)
+}
+
+func (s *podSuite) TestComputeStatusContainerCreating(c *gc.C) {
+	pod := resources.NewPod("pod1", "test", &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodPending,
+			Message: "creating",
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "mysql",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{
+						Reason: "ContainerCreating",
+					},
+				},
+			}},
+		},
+	})
+	message, podStatus, _, err := pod.ComputeStatus(context.TODO(), s.client, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(podStatus, gc.Equals, status.Allocating)
+	c.Assert(message, gc.Equals, "creating")
+}
+
+func (s *podSuite) TestComputeStatusLatestWarningEvent(c *gc.C) {
+	now := time.Now()
+	for i, event := range []corev1.Event{{
+		Type:          corev1.EventTypeWarning,
+		Reason:        "FailedMount",
+		Message:       "old warning",
+		LastTimestamp: metav1.NewTime(now.Add(-2 * time.Minute)),
+	}, {
+		Type:          corev1.EventTypeWarning,
+		Reason:        "FailedScheduling",
+		Message:       "0/3 nodes are available: 3 Insufficient memory.",
+		LastTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+	}, {
+		Type:          corev1.EventTypeNormal,
+		Reason:        "Scheduled",
+		Message:       "normal event",
+		LastTimestamp: metav1.NewTime(now),
+	}} {
+		event.Name = fmt.Sprintf("event%d", i)
+		event.InvolvedObject = corev1.ObjectReference{Name: "pod1", Kind: "Pod"}
+		_, err := s.client.CoreV1().Events("test").Create(context.TODO(), &event, metav1.CreateOptions{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	pod := resources.NewPod("pod1", "test", &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+		},
+	})
+	message, podStatus, _, err := pod.ComputeStatus(context.TODO(), s.client, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(podStatus, gc.Equals, status.Allocating)
+	c.Assert(message, gc.Equals, "FailedScheduling: 0/3 nodes are available: 3 Insufficient memory.")
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 
 	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	"github.com/juju/juju/caas/specs"
 	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/secrets"
 )
 
 func getSecretLabels(appName string, legacy bool) map[string]string {
@@ -181,3 +183,53 @@ func (k *kubernetesClient) deleteSecrets(appName string) error {
 	}
 	return errors.Trace(err)
 }
+
+// SaveJujuSecret saves new content for the specified charm secret in
+// a Kubernetes secret, returning the name of the Kubernetes secret.
+func (k *kubernetesClient) SaveJujuSecret(uri *secrets.URI, value secrets.SecretValue) (string, error) {
+	data, err := processSecretData(value.EncodedValues())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	suffix, err := k.randomPrefix()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	name := fmt.Sprintf("juju-secret-%s-%s", uri.ID, suffix)
+	spec := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels: utils.LabelsMerge(
+				utils.LabelsJuju,
+				utils.LabelForKeyValue(constants.LabelJujuSecretID, uri.ID),
+			),
+		},
+		Type: core.SecretTypeOpaque,
+		Data: data,
+	}
+	if _, err := k.createSecret(spec); err != nil {
+		return "", errors.Trace(err)
+	}
+	logger.Debugf("saved content for secret %q in %q", uri, name)
+	return name, nil
+}
+
+// GetJujuSecret returns the charm secret content held
+// in the specified Kubernetes secret.
+func (k *kubernetesClient) GetJujuSecret(name string) (secrets.SecretValue, error) {
+	secret, err := k.getSecret(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := make(map[string]string, len(secret.Data))
+	for key, val := range secret.Data {
+		data[key] = base64.StdEncoding.EncodeToString(val)
+	}
+	return secrets.NewSecretValue(data), nil
+}
+
+// DeleteJujuSecret deletes the Kubernetes secret holding charm secret content.
+func (k *kubernetesClient) DeleteJujuSecret(name string) error {
+	return errors.Trace(k.deleteSecret(name, ""))
+}
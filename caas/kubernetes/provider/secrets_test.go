@@ -4,10 +4,16 @@
 package provider_test
 
 import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/testing"
 )
 
 var _ = gc.Suite(&secretsSuite{})
@@ -29,3 +35,72 @@ func (s *secretsSuite) TestProcessSecretData(c *gc.C) {
 		"password": []byte("1f2d1e2e67df"),
 	})
 }
+
+func (s *K8sBrokerSuite) TestSaveJujuSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	uri := secrets.NewURI(testing.ModelTag.Id())
+	secretArg := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-secret-" + uri.ID + "-appuuid",
+			Namespace: "test",
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "juju",
+				"secret.juju.is/id":            uri.ID,
+			},
+		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{"foo": []byte("bar")},
+	}
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Create(gomock.Any(), secretArg, v1.CreateOptions{}).
+			Return(secretArg, nil),
+	)
+
+	name, err := s.broker.SaveJujuSecret(uri, secrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "juju-secret-"+uri.ID+"-appuuid")
+}
+
+func (s *K8sBrokerSuite) TestGetJujuSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Get(gomock.Any(), "juju-secret-foo", v1.GetOptions{}).
+			Return(&core.Secret{
+				Data: map[string][]byte{"foo": []byte("bar")},
+			}, nil),
+	)
+
+	val, err := s.broker.GetJujuSecret("juju-secret-foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+}
+
+func (s *K8sBrokerSuite) TestGetJujuSecretNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Get(gomock.Any(), "juju-secret-foo", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	_, err := s.broker.GetJujuSecret("juju-secret-foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestDeleteJujuSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Delete(gomock.Any(), "juju-secret-foo", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.DeleteJujuSecret("juju-secret-foo")
+	c.Assert(err, jc.ErrorIsNil)
+}
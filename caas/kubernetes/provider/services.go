@@ -51,6 +51,13 @@ func (k *kubernetesClient) ensureK8sService(spec *core.Service) (func(), error)
 	if err == nil {
 		spec.Spec.ClusterIP = existing.Spec.ClusterIP
 		spec.ObjectMeta.ResourceVersion = existing.ObjectMeta.ResourceVersion
+		// Keep the hostnames of any gateway routes created by juju expose.
+		if hostnames, ok := existing.Annotations[gatewayHostnamesAnnotationKey]; ok {
+			if spec.Annotations == nil {
+				spec.Annotations = make(map[string]string)
+			}
+			spec.Annotations[gatewayHostnamesAnnotationKey] = hostnames
+		}
 	}
 	_, err = api.Update(context.TODO(), spec, v1.UpdateOptions{})
 	if k8serrors.IsNotFound(err) {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/caas/kubernetes/provider/constants"
@@ -109,7 +110,10 @@ type volumeSource struct {
 	client *kubernetesClient
 }
 
-var _ jujustorage.VolumeSource = (*volumeSource)(nil)
+var (
+	_ jujustorage.VolumeSource  = (*volumeSource)(nil)
+	_ jujustorage.VolumeResizer = (*volumeSource)(nil)
+)
 
 // CreateVolumes is specified on the jujustorage.VolumeSource interface.
 func (v *volumeSource) CreateVolumes(ctx jujucontext.ProviderCallContext, params []jujustorage.VolumeParams) (_ []jujustorage.CreateVolumesResult, err error) {
@@ -212,6 +216,72 @@ func (v *volumeSource) DetachVolumes(ctx jujucontext.ProviderCallContext, attach
 	return make([]error, len(attachParams)), nil
 }
 
+// ResizeVolumes is specified on the jujustorage.VolumeResizer interface.
+// The volume is expanded by updating the storage request on the persistent
+// volume claim bound to it; the storage class must allow volume expansion.
+// Expansion happens asynchronously, so the result is pending until the
+// claim's status reports the requested capacity.
+func (v *volumeSource) ResizeVolumes(ctx jujucontext.ProviderCallContext, params []jujustorage.VolumeResizeParams) ([]jujustorage.ResizeVolumesResult, error) {
+	results := make([]jujustorage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeVolume(p.VolumeId, p.Size)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", p.VolumeId)
+			continue
+		}
+		if size == 0 {
+			logger.Debugf("resize of volume %v to %dMiB is pending", p.VolumeId, p.Size)
+			results[i].Pending = true
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+// resizeVolume requests that the volume's claim be expanded to the
+// specified size, if it hasn't been already. It returns the size of
+// the claim once its capacity has reached the specified size, or 0
+// if the expansion is still in progress.
+func (v *volumeSource) resizeVolume(volumeId string, size uint64) (uint64, error) {
+	vol, err := v.client.client().CoreV1().PersistentVolumes().Get(context.TODO(), volumeId, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return 0, errors.NotFoundf("volume %v", volumeId)
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	claimRef := vol.Spec.ClaimRef
+	if claimRef == nil {
+		return 0, errors.NotValidf("volume %v without a claim", volumeId)
+	}
+	pClaims := v.client.client().CoreV1().PersistentVolumeClaims(claimRef.Namespace)
+	pvc, err := pClaims.Get(context.TODO(), claimRef.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return 0, errors.NotFoundf("volume claim %v", claimRef.Name)
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	requested := resource.MustParse(fmt.Sprintf("%dMi", size))
+	current, ok := pvc.Spec.Resources.Requests[core.ResourceStorage]
+	if ok && current.Cmp(requested) > 0 {
+		return 0, errors.NotSupportedf("shrinking volume claim %v from %v to %v", claimRef.Name, current.String(), requested.String())
+	}
+	if !ok || current.Cmp(requested) < 0 {
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = core.ResourceList{}
+		}
+		pvc.Spec.Resources.Requests[core.ResourceStorage] = requested
+		if pvc, err = pClaims.Update(context.TODO(), pvc, v1.UpdateOptions{}); err != nil {
+			return 0, errors.Annotatef(err, "updating volume claim %v", claimRef.Name)
+		}
+	}
+	capacity, ok := pvc.Status.Capacity[core.ResourceStorage]
+	if !ok || capacity.Cmp(requested) < 0 {
+		return 0, nil
+	}
+	return uint64(capacity.Value() / (1024 * 1024)), nil
+}
+
 func foreachVolume(volumeIds []string, f func(string) error) []error {
 	results := make([]error, len(volumeIds))
 	var wg sync.WaitGroup
@@ -144,6 +144,16 @@ func ParseStorageMode(attrs map[string]interface{}) (*corev1.PersistentVolumeAcc
 	return parseMode(coerced[k8sconstants.StorageMode].(string))
 }
 
+// SharedAccessMode returns the access mode to use for a persistent volume
+// claim that is shared by all pods of an application. ReadWriteOnce claims
+// can only be mounted by pods on a single node, so are made ReadWriteMany.
+func SharedAccessMode(mode corev1.PersistentVolumeAccessMode) corev1.PersistentVolumeAccessMode {
+	if mode == corev1.ReadWriteOnce {
+		return corev1.ReadWriteMany
+	}
+	return mode
+}
+
 // PushUniqueVolume ensures to only add unique volumes because k8s will not schedule pods if it has duplicated volumes.
 // The existing volume will be replaced if force sets to true.
 func PushUniqueVolume(podSpec *corev1.PodSpec, vol corev1.Volume, force bool) error {
@@ -92,6 +92,12 @@ func (s *storageSuite) TestGetStorageMode(c *gc.C) {
 	}
 }
 
+func (s *storageSuite) TestSharedAccessMode(c *gc.C) {
+	c.Assert(storage.SharedAccessMode(core.ReadWriteOnce), gc.Equals, core.ReadWriteMany)
+	c.Assert(storage.SharedAccessMode(core.ReadWriteMany), gc.Equals, core.ReadWriteMany)
+	c.Assert(storage.SharedAccessMode(core.ReadOnlyMany), gc.Equals, core.ReadOnlyMany)
+}
+
 func (s *storageSuite) TestPushUniqueVolume(c *gc.C) {
 	podSpec := &core.PodSpec{}
 
@@ -104,6 +104,94 @@ func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 }
 
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pvc := &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "vol-1-pvc"},
+		Spec: core.PersistentVolumeClaimSpec{
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	expanded := &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "vol-1-pvc"},
+		Spec: core.PersistentVolumeClaimSpec{
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("2048Mi")},
+			},
+		},
+	}
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "vol-1", v1.GetOptions{}).
+			Return(&core.PersistentVolume{
+				Spec: core.PersistentVolumeSpec{
+					ClaimRef: &core.ObjectReference{Namespace: "test", Name: "vol-1-pvc"},
+				}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get(gomock.Any(), "vol-1-pvc", v1.GetOptions{}).
+			Return(pvc, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Update(gomock.Any(), expanded, v1.UpdateOptions{}).
+			Return(expanded, nil),
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "vol-2", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeResizer))
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{
+		{VolumeId: "vol-1", Size: 2048},
+		{VolumeId: "vol-2", Size: 2048},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	// The claim's capacity hasn't grown yet.
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeVolumesResult{Pending: true})
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing volume vol-2: volume vol-2 not found")
+}
+
+func (s *storageSuite) TestResizeVolumesCompleted(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	expanded := &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "vol-1-pvc"},
+		Spec: core.PersistentVolumeClaimSpec{
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("2048Mi")},
+			},
+		},
+		Status: core.PersistentVolumeClaimStatus{
+			Capacity: core.ResourceList{core.ResourceStorage: resource.MustParse("3Gi")},
+		},
+	}
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "vol-1", v1.GetOptions{}).
+			Return(&core.PersistentVolume{
+				Spec: core.PersistentVolumeSpec{
+					ClaimRef: &core.ObjectReference{Namespace: "test", Name: "vol-1-pvc"},
+				}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get(gomock.Any(), "vol-1-pvc", v1.GetOptions{}).
+			Return(expanded, nil),
+	)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The claim already requests the size, so it isn't updated,
+	// and the size reported is the capacity of the claim.
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{
+		{VolumeId: "vol-1", Size: 2048},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 3072}})
+}
+
 func (s *storageSuite) TestDestroyVolumesNotFoundIgnored(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -261,6 +261,9 @@ func (k *kubernetesClient) filesystemToVolumeInfo(
 	if err != nil {
 		return nil, nil, errors.Annotatef(err, "getting volume params for %s", fs.StorageName)
 	}
+	if fs.Shared {
+		params.AccessMode = storage.SharedAccessMode(params.AccessMode)
+	}
 	pvcSpec, err := k.maybeGetVolumeClaimSpec(*params)
 	if err != nil {
 		return nil, nil, errors.Annotatef(err, "finding volume for %s", fs.StorageName)
@@ -34,6 +34,21 @@ func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
 	return m.recorder
 }
 
+// AutoscalerStatus mocks base method
+func (m *MockApplication) AutoscalerStatus() (caas.AutoscalerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoscalerStatus")
+	ret0, _ := ret[0].(caas.AutoscalerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoscalerStatus indicates an expected call of AutoscalerStatus
+func (mr *MockApplicationMockRecorder) AutoscalerStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoscalerStatus", reflect.TypeOf((*MockApplication)(nil).AutoscalerStatus))
+}
+
 // Delete mocks base method
 func (m *MockApplication) Delete() error {
 	m.ctrl.T.Helper()
@@ -62,6 +77,34 @@ func (mr *MockApplicationMockRecorder) Ensure(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ensure", reflect.TypeOf((*MockApplication)(nil).Ensure), arg0)
 }
 
+// EnsureAutoscaler mocks base method
+func (m *MockApplication) EnsureAutoscaler(arg0 *caas.AutoscalingPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAutoscaler", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAutoscaler indicates an expected call of EnsureAutoscaler
+func (mr *MockApplicationMockRecorder) EnsureAutoscaler(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAutoscaler", reflect.TypeOf((*MockApplication)(nil).EnsureAutoscaler), arg0)
+}
+
+// EnsureNetworkPolicy mocks base method
+func (m *MockApplication) EnsureNetworkPolicy(arg0 *caas.NetworkPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureNetworkPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureNetworkPolicy indicates an expected call of EnsureNetworkPolicy
+func (mr *MockApplicationMockRecorder) EnsureNetworkPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNetworkPolicy", reflect.TypeOf((*MockApplication)(nil).EnsureNetworkPolicy), arg0)
+}
+
 // Exists mocks base method
 func (m *MockApplication) Exists() (caas.DeploymentState, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +120,20 @@ func (mr *MockApplicationMockRecorder) Exists() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockApplication)(nil).Exists))
 }
 
+// Scale mocks base method
+func (m *MockApplication) Scale(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scale", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scale indicates an expected call of Scale
+func (mr *MockApplicationMockRecorder) Scale(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scale", reflect.TypeOf((*MockApplication)(nil).Scale), arg0)
+}
+
 // State mocks base method
 func (m *MockApplication) State() (caas.ApplicationState, error) {
 	m.ctrl.T.Helper()
@@ -270,17 +270,17 @@ func (mr *MockBrokerMockRecorder) EnsureService(arg0, arg1, arg2, arg3, arg4 int
 }
 
 // ExposeService mocks base method
-func (m *MockBroker) ExposeService(arg0 string, arg1 map[string]string, arg2 application.ConfigAttributes) error {
+func (m *MockBroker) ExposeService(arg0 string, arg1 map[string]string, arg2 []string, arg3 application.ConfigAttributes) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExposeService", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ExposeService", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ExposeService indicates an expected call of ExposeService
-func (mr *MockBrokerMockRecorder) ExposeService(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBrokerMockRecorder) ExposeService(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposeService", reflect.TypeOf((*MockBroker)(nil).ExposeService), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposeService", reflect.TypeOf((*MockBroker)(nil).ExposeService), arg0, arg1, arg2, arg3)
 }
 
 // GetService mocks base method
@@ -458,6 +458,21 @@ func (mr *MockBrokerMockRecorder) UnexposeService(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnexposeService", reflect.TypeOf((*MockBroker)(nil).UnexposeService), arg0)
 }
 
+// UnitEvents mocks base method
+func (m *MockBroker) UnitEvents(arg0 string) ([]caas.UnitEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnitEvents", arg0)
+	ret0, _ := ret[0].([]caas.UnitEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnitEvents indicates an expected call of UnitEvents
+func (mr *MockBrokerMockRecorder) UnitEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnitEvents", reflect.TypeOf((*MockBroker)(nil).UnitEvents), arg0)
+}
+
 // Units mocks base method
 func (m *MockBroker) Units(arg0 string, arg1 caas.DeploymentMode) ([]caas.Unit, error) {
 	m.ctrl.T.Helper()
@@ -425,6 +425,7 @@ var defaultCloudDescription = map[string]string{
 	"openstack":   "Openstack Cloud",
 	"oracle":      "Oracle Compute Cloud Service",
 	"kubernetes":  "A Kubernetes Cluster",
+	"ecs":         "Amazon Elastic Container Service",
 }
 
 // WritePublicCloudMetadata marshals to YAML and writes the cloud metadata
@@ -50,6 +50,10 @@ type APIClient interface {
 
 	// WatchActionProgress reports on logged action progress messages.
 	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// WatchTaskEvents reports on the output, logged messages and
+	// status changes of a task as they happen.
+	WatchTaskEvents(taskID string) (watcher.StringsWatcher, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -0,0 +1,151 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/cron"
+)
+
+// NewAddScheduleCommand returns a command which adds a schedule
+// on which an action is run.
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+type addScheduleCommand struct {
+	ScheduleCommandBase
+
+	name         string
+	cron         string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+
+	target     string
+	actionName string
+	args       [][]string
+}
+
+const addScheduleDoc = `
+Add a schedule on which the controller runs a charm action. When the
+schedule is due, the action is enqueued as an operation, exactly as if
+it had been started with 'juju run', and the outcome is recorded in the
+schedule's history.
+
+The target of the action may be:
+  an application, such as mysql, to run the action on each of its units;
+  a unit, such as mysql/0, or;
+  the leader of an application, such as mysql/leader, which is resolved
+  each time the action is run.
+
+The --cron option takes a standard five field cron expression
+("minute hour day-of-month month day-of-week"), or one of @yearly,
+@monthly, @weekly, @daily or @hourly. Times are in UTC.
+
+If the controller is unavailable when a run is due, the run is made when
+the controller returns, as long as that is within an hour; otherwise it
+is skipped.
+
+Params are given as with 'juju run', and are validated against the
+charm's action schema when the schedule is added. The schedule is named
+after the action and target unless --name is given.
+
+Examples:
+
+    juju add-schedule mysql/leader backup --cron '0 3 * * *'
+    juju add-schedule mysql/leader backup --cron @daily --params backup.yaml
+    juju add-schedule mysql health-check --cron '*/15 * * * *' --name health
+    juju add-schedule mysql/0 backup --cron '30 2 * * sun' out=weekly.tar.gz
+
+See also:
+    schedules
+    show-schedule
+    pause-schedule
+    resume-schedule
+    remove-schedule
+    run
+`
+
+// SetFlags implements Command.SetFlags.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ScheduleCommandBase.SetFlags(f)
+	f.StringVar(&c.name, "name", "", "Name of the schedule (defaults to <action>-<target>)")
+	f.StringVar(&c.cron, "cron", "", "Cron expression describing when the action is run")
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info implements Command.Info.
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add-schedule",
+		Args:    "<application>|<unit>|<application>/leader <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Purpose: "Run an action on a schedule.",
+		Doc:     addScheduleDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *addScheduleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no target specified")
+	case 1:
+		return errors.New("no action specified")
+	}
+	c.target, c.actionName = args[0], args[1]
+	if !names.IsValidApplication(c.target) && !names.IsValidUnit(c.target) && !validLeader.MatchString(c.target) {
+		return errors.Errorf("invalid application or unit name %q", c.target)
+	}
+	if !nameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	if c.cron == "" {
+		return errors.New("no schedule specified, use --cron")
+	}
+	if _, err := cron.Parse(c.cron); err != nil {
+		return errors.Trace(err)
+	}
+	if c.name == "" {
+		c.name = c.actionName + "-" + strings.Replace(c.target, "/", "-", 1)
+	}
+	var err error
+	c.args, err = parseParamArgs(args[2:])
+	return errors.Trace(err)
+}
+
+// Run implements Command.Run.
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	actionParams, err := buildParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	err = api.AddSchedule(params.ActionSchedule{
+		Name:       c.name,
+		Target:     c.target,
+		Action:     c.actionName,
+		Parameters: actionParams,
+		Cron:       c.cron,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added schedule %q.", c.name)
+	return nil
+}
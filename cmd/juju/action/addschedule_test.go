@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type AddScheduleSuite struct {
+	BaseScheduleSuite
+}
+
+var _ = gc.Suite(&AddScheduleSuite{})
+
+func (s *AddScheduleSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no target specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no action specified",
+	}, {
+		args: []string{"mysql/x", "backup", "--cron", "@daily"},
+		err:  `invalid application or unit name "mysql/x"`,
+	}, {
+		args: []string{"mysql", "Backup", "--cron", "@daily"},
+		err:  `invalid action name "Backup"`,
+	}, {
+		args: []string{"mysql", "backup"},
+		err:  "no schedule specified, use --cron",
+	}, {
+		args: []string{"mysql", "backup", "--cron", "0 3 * *"},
+		err:  `cron expression "0 3 \* \*": expected 5 fields, got 4 not valid`,
+	}, {
+		args: []string{"mysql", "backup", "--cron", "@daily", "outfile"},
+		err:  `argument "outfile" must be of the form key.key.key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *AddScheduleSuite) TestAddSchedule(c *gc.C) {
+	paramsFile := filepath.Join(c.MkDir(), "params.yaml")
+	err := ioutil.WriteFile(paramsFile, []byte("outfile: out.tar.gz\ncompression:\n  kind: gzip\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"mysql/leader", "backup", "--cron", "0 3 * * *",
+		"--params", paramsFile, "compression.kind=xz", "retain=7",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Added schedule \"backup-mysql-leader\".\n")
+	s.api.CheckCall(c, 0, "AddSchedule", params.ActionSchedule{
+		Name:   "backup-mysql-leader",
+		Target: "mysql/leader",
+		Action: "backup",
+		Parameters: map[string]interface{}{
+			"outfile":     "out.tar.gz",
+			"compression": map[string]interface{}{"kind": "xz"},
+			"retain":      7,
+		},
+		Cron: "0 3 * * *",
+	})
+	s.api.CheckCallNames(c, "AddSchedule", "Close")
+}
+
+func (s *AddScheduleSuite) TestAddScheduleNamed(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"mysql", "health-check", "--cron", "@hourly", "--name", "health", "--string-args", "level=1",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AddSchedule", params.ActionSchedule{
+		Name:       "health",
+		Target:     "mysql",
+		Action:     "health-check",
+		Parameters: map[string]interface{}{"level": "1"},
+		Cron:       "@hourly",
+	})
+}
@@ -24,6 +24,7 @@ import (
 
 	actionapi "github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/core/actions"
 	coreactions "github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/watcher"
@@ -47,6 +48,7 @@ type runCommandBase struct {
 	ActionCommandBase
 	api        APIClient
 	background bool
+	stream     bool
 	out        cmd.Output
 	utc        bool
 
@@ -67,6 +69,7 @@ func (c *runCommandBase) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.BoolVar(&c.background, "background", false, "Run the task in the background")
+	f.BoolVar(&c.stream, "stream", false, "Stream task output, logs and status as the task runs")
 	f.DurationVar(&c.wait, "wait", 0, "Maximum wait time for a task to complete")
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
 }
@@ -75,6 +78,9 @@ func (c *runCommandBase) Init(args []string) error {
 	if c.background && c.wait > 0 {
 		return errors.New("cannot specify both --wait and --background")
 	}
+	if c.background && c.stream {
+		return errors.New("cannot specify both --stream and --background")
+	}
 	if !c.background && c.wait == 0 {
 		c.wait = c.defaultWait
 		if c.wait == 0 {
@@ -146,6 +152,9 @@ func (c *runCommandBase) processOperationResults(ctx *cmd.Context, results *acti
 		}
 		return nil
 	}
+	if c.stream {
+		return c.streamTasks(ctx, tasks, info)
+	}
 	return c.waitForTasks(ctx, tasks, info)
 }
 
@@ -211,6 +220,90 @@ func (c *runCommandBase) waitForTasks(ctx *cmd.Context, tasks []enqueuedAction,
 	return c.out.Write(ctx, info)
 }
 
+// streamTasks writes the output, logs and status of the tasks as they
+// run, then their results. The tasks are streamed concurrently; when
+// there is more than one, each line streamed starts with the task's
+// receiver.
+func (c *runCommandBase) streamTasks(ctx *cmd.Context, tasks []enqueuedAction, info map[string]interface{}) error {
+	var wait clock.Timer
+	if c.wait < 0 {
+		// Indefinite wait. Discard the tick.
+		wait = c.clock.NewTimer(0 * time.Second)
+		_ = <-wait.Chan()
+	} else {
+		wait = c.clock.NewTimer(c.wait)
+	}
+
+	watchers := make([]watcher.StringsWatcher, len(tasks))
+	for i, task := range tasks {
+		w, err := c.api.WatchTaskEvents(task.task)
+		if errors.IsNotSupported(err) && i == 0 {
+			ctx.Warningf("this controller cannot stream task output, waiting for results instead")
+			return c.waitForTasks(ctx, tasks, info)
+		}
+		if err != nil {
+			for _, w := range watchers[:i] {
+				w.Kill()
+			}
+			return errors.Trace(err)
+		}
+		watchers[i] = w
+	}
+
+	done := make(chan struct{})
+	streamer := &taskStreamer{ctx: ctx, utc: c.utc}
+	finished := make([]<-chan struct{}, len(tasks))
+	for i, task := range tasks {
+		var prefix string
+		if len(tasks) > 1 {
+			prefix = task.receiverId() + ": "
+		}
+		finished[i] = streamer.stream(watchers[i], task.task, prefix, done)
+	}
+	stopStreaming := func() {
+		close(done)
+		for i, w := range watchers {
+			_ = w.Wait()
+			<-finished[i]
+		}
+	}
+
+	resultReceivers := set.NewStrings()
+	var failures []error
+	for i, task := range tasks {
+		select {
+		case <-finished[i]:
+		case <-wait.Chan():
+			stopStreaming()
+			return c.handleTimeout(tasks, resultReceivers)
+		}
+		result, err := fetchResult(c.api, task.task)
+		if err != nil {
+			stopStreaming()
+			return errors.Trace(err)
+		}
+		resultReceivers.Add(task.receiver)
+		d := streamedResult(formatActionResult(task.task, result, c.utc), c.out.Name() == "plain")
+		d["id"] = task.task
+		info[task.receiverId()] = d
+		if err := taskResultError(task.task, result); err != nil {
+			failures = append(failures, err)
+		}
+	}
+	stopStreaming()
+	if err := c.out.Write(ctx, info); err != nil {
+		return errors.Trace(err)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	if len(tasks) == 1 {
+		return failures[0]
+	}
+	// The results written show which tasks failed.
+	return cmd.ErrSilent
+}
+
 func (c *runCommandBase) handleTimeout(tasks []enqueuedAction, got set.Strings) error {
 	want := set.NewStrings()
 	for _, t := range tasks {
@@ -493,6 +586,83 @@ func convertActionOutput(output map[string]interface{}) map[string]interface{} {
 	return values
 }
 
+// parseParamArgs parses action parameters given as key.key.key...=value
+// arguments, returning the keys of each followed by its value.
+func parseParamArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0, len(args))
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, errors.Errorf("argument %q must be of the form key.key.key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := nameRule.MatchString(key); !valid {
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
+					"and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		result = append(result, append(keySlice, thisArg[1]))
+	}
+	return result, nil
+}
+
+// buildParams returns the action parameters read from the YAML file,
+// if one was given, overridden by the parsed key=value arguments.
+// Argument values are parsed as YAML unless parseStrings is true.
+func buildParams(ctx *cmd.Context, paramsYAML cmd.FileVar, args [][]string, parseStrings bool) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		err = yaml.Unmarshal(b, &actionParams)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a YAML map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return actionParams, nil
+}
+
 // addValueToMap adds the given value to the map on which the method is run.
 // This allows us to merge maps such as {foo: {bar: baz}} and {foo: {baz: faz}}
 // into {foo: {bar: baz, baz: faz}}.
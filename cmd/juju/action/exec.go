@@ -112,11 +112,17 @@ Since juju exec creates tasks, you can query for the status of commands
 started with juju run by calling 
 "juju operations --machines <id>,... --actions juju-exec".
 
+To see the output of the commands as they run, use --stream. The command
+then exits with the return code of the commands, or an error if they could
+not be run. When the commands run on more than one target, each line streamed
+starts with the target's name, and the command fails if any of them fail.
+
 If you need to pass options to the command being run, you must precede the
 command and its arguments with "--", to tell "juju exec" to stop processing
 those arguments. For example:
 
     juju exec --all -- hostname -f
+    juju exec --unit mysql/0 --stream -- tail -n 20 /var/log/syslog
 
 `
 
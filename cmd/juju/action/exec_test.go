@@ -18,6 +18,7 @@ import (
 
 	actionapi "github.com/juju/juju/api/action"
 	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
@@ -292,6 +293,99 @@ mysql/0:
 	c.Assert(cmdtesting.Stdout(context), gc.Equals, expected)
 }
 
+func (s *ExecSuite) TestExecStream(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		taskEvents: map[string]chan []string{
+			validActionId:  make(chan []string, 1),
+			validActionId2: make(chan []string, 1),
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	fakeClient.actionResults = []actionapi.ActionResult{{
+		Action: &actionapi.Action{
+			ID:       validActionId,
+			Receiver: "machine-0",
+		},
+		Output: map[string]interface{}{
+			"return-code": 0,
+			"stdout":      "megatron\n",
+		},
+		Status: "completed",
+	}, {
+		Action: &actionapi.Action{
+			ID:       validActionId2,
+			Receiver: "unit-mysql-0",
+		},
+		Output: map[string]interface{}{
+			"return-code": 1,
+			"stdout":      "bumble",
+			"stderr":      "bee\n",
+		},
+		Status: "completed",
+	}}
+	fakeClient.taskEvents[validActionId] <- encodeTaskEvents(c,
+		actions.TaskEvent{Stream: "stdout", Message: "megatron\n"},
+		actions.TaskEvent{Status: "completed"},
+	)
+	fakeClient.taskEvents[validActionId2] <- encodeTaskEvents(c,
+		actions.TaskEvent{Stream: "stdout", Message: "bumble"},
+		actions.TaskEvent{Stream: "stderr", Message: "bee\n"},
+		actions.TaskEvent{Status: "completed"},
+	)
+
+	runCmd, _ := newTestExecCommand(testClock(), model.IAAS)
+	context, err := cmdtesting.RunCommand(c, runCmd,
+		"--format=yaml", "--machine=0", "--unit=mysql/0", "--stream", "hostname",
+	)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	stdout := cmdtesting.Stdout(context)
+	c.Check(stdout, jc.Contains, "0: megatron\n")
+	c.Check(stdout, jc.Contains, "mysql/0: bumble\n")
+	stderr := cmdtesting.Stderr(context)
+	c.Check(stderr, jc.Contains, "mysql/0: bee\n")
+	c.Check(stderr, jc.Contains, "0: task 1 completed\n")
+	c.Check(stderr, jc.Contains, "mysql/0: task 2 completed\n")
+	// The results are written in full when not in plain format.
+	c.Check(stdout, jc.Contains, `
+mysql/0:
+  id: "2"
+  results:
+    return-code: 1
+    stderr: |
+      bee
+    stdout: bumble
+  status: completed
+  unit: mysql/0
+`[1:])
+}
+
+func (s *ExecSuite) TestExecStreamReturnCode(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		taskEvents: map[string]chan []string{validActionId: make(chan []string, 1)},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	fakeClient.actionResults = []actionapi.ActionResult{{
+		Action: &actionapi.Action{
+			ID:       validActionId,
+			Receiver: "machine-0",
+		},
+		Output: map[string]interface{}{
+			"return-code": 42,
+		},
+		Status: "completed",
+	}}
+	fakeClient.taskEvents[validActionId] <- encodeTaskEvents(c, actions.TaskEvent{Status: "completed"})
+
+	runCmd, _ := newTestExecCommand(testClock(), model.IAAS)
+	_, err := cmdtesting.RunCommand(c, runCmd, "--machine=0", "--stream", "false")
+	c.Assert(err, gc.DeepEquals, cmd.NewRcPassthroughError(42))
+}
+
 func (s *ExecSuite) TestAllMachines(c *gc.C) {
 	fakeClient := &fakeAPIClient{}
 	restore := s.patchAPIClient(fakeClient)
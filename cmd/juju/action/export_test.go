@@ -15,8 +15,9 @@ import (
 )
 
 var (
-	NewActionAPIClient = &newAPIClient
-	AddValueToMap      = addValueToMap
+	NewActionAPIClient   = &newAPIClient
+	NewScheduleAPIClient = &newScheduleAPIClient
+	AddValueToMap        = addValueToMap
 )
 
 type ShowOperationCommand struct {
@@ -178,3 +179,33 @@ func NewListOperationsCommandForTest(store jujuclient.ClientStore) (cmd.Command,
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ListOperationsCommand{c}
 }
+
+func NewAddScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &addScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewShowScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewPauseScheduleCommandForTest(store jujuclient.ClientStore, paused bool) cmd.Command {
+	c := &pauseScheduleCommand{paused: paused}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
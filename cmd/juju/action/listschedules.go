@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewListSchedulesCommand returns a command which lists the
+// action schedules in the model.
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+type listSchedulesCommand struct {
+	ScheduleCommandBase
+	out cmd.Output
+	utc bool
+}
+
+const listSchedulesDoc = `
+List the schedules on which actions are run in the model, along with
+the outcome of the most recent run of each.
+
+Examples:
+
+    juju schedules
+    juju schedules --format yaml
+
+See also:
+    add-schedule
+    show-schedule
+`
+
+// SetFlags implements Command.SetFlags.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ScheduleCommandBase.SetFlags(f)
+	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Info implements Command.Info.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedules",
+		Purpose: "List the schedules on which actions are run.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-schedules"},
+	})
+}
+
+// Init implements Command.Init.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	schedules, err := api.ListSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No schedules to display.")
+		return nil
+	}
+	result := make(map[string]scheduleInfo)
+	for _, schedule := range schedules {
+		result[schedule.Name] = newScheduleInfo(schedule, c.utc)
+	}
+	return c.out.Write(ctx, result)
+}
+
+func (c *listSchedulesCommand) formatTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	names := make([]string, 0, len(schedules))
+	for name := range schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Schedule", "Target", "Action", "Cron", "Status", "Last run", "Operation", "Error")
+	for _, name := range names {
+		info := schedules[name]
+		var lastRun, operation, message string
+		if n := len(info.Runs); n > 0 {
+			run := info.Runs[n-1]
+			lastRun = run.Scheduled.Format(time.RFC3339)
+			operation = run.Operation
+			message = run.Error
+		}
+		w.Print(name, info.Target, info.Action, info.Cron)
+		if info.Status == "paused" {
+			w.PrintColor(output.WarningHighlight, info.Status)
+		} else {
+			w.Print(info.Status)
+		}
+		w.Println(lastRun, operation, message)
+	}
+	return tw.Flush()
+}
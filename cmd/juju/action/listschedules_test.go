@@ -0,0 +1,52 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ListSchedulesSuite struct {
+	BaseScheduleSuite
+}
+
+var _ = gc.Suite(&ListSchedulesSuite{})
+
+func (s *ListSchedulesSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Schedule            Target        Action        Cron          Status  Last run              Operation  Error
+health-check-mysql  mysql         health-check  */15 * * * *  paused                                   
+nightly-backup      mysql/leader  backup        0 3 * * *     active  2021-06-03T03:00:00Z             could not determine leader for "mysql"
+
+`[1:])
+}
+
+func (s *ListSchedulesSuite) TestYAML(c *gc.C) {
+	s.api.schedules = s.api.schedules[1:]
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+health-check-mysql:
+  target: mysql
+  action: health-check
+  cron: '*/15 * * * *'
+  status: paused
+  owner: admin
+  created: 2021-06-01T11:00:00Z
+`[1:])
+}
+
+func (s *ListSchedulesSuite) TestNone(c *gc.C) {
+	s.api.schedules = nil
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No schedules to display.\n")
+}
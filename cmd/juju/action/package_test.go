@@ -146,6 +146,7 @@ type fakeAPIClient struct {
 	execParams         *actionapi.RunParams
 	apiErr             error
 	logMessageCh       chan []string
+	taskEvents         map[string]chan []string
 	waitForResults     chan bool
 }
 
@@ -235,6 +236,13 @@ func (c *fakeAPIClient) WatchActionProgress(_ string) (watcher.StringsWatcher, e
 	return watchertest.NewMockStringsWatcher(c.logMessageCh), nil
 }
 
+func (c *fakeAPIClient) WatchTaskEvents(taskID string) (watcher.StringsWatcher, error) {
+	if c.taskEvents == nil {
+		return nil, errors.NotSupportedf("watching task events")
+	}
+	return watchertest.NewMockStringsWatcher(c.taskEvents[taskID]), nil
+}
+
 func (c *fakeAPIClient) ListOperations(args actionapi.OperationQueryArgs) (actionapi.Operations, error) {
 	c.operationQueryArgs = args
 	return c.operationResults, c.apiErr
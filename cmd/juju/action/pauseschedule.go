@@ -0,0 +1,112 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewPauseScheduleCommand returns a command which pauses
+// runs of an action schedule.
+func NewPauseScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&pauseScheduleCommand{paused: true})
+}
+
+// NewResumeScheduleCommand returns a command which resumes
+// runs of an action schedule.
+func NewResumeScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&pauseScheduleCommand{paused: false})
+}
+
+// pauseScheduleCommand implements both pause-schedule
+// and resume-schedule.
+type pauseScheduleCommand struct {
+	ScheduleCommandBase
+	paused bool
+	names  []string
+}
+
+const pauseScheduleDoc = `
+Pause one or more schedules on which actions are run. No runs are made
+while a schedule is paused; runs which were due while it was paused are
+skipped when it is resumed. Operations already enqueued by the schedule
+are not affected.
+
+Examples:
+
+    juju pause-schedule nightly-backup
+
+See also:
+    resume-schedule
+    schedules
+`
+
+const resumeScheduleDoc = `
+Resume one or more paused schedules on which actions are run. The next
+run is made when the schedule is next due.
+
+Examples:
+
+    juju resume-schedule nightly-backup
+
+See also:
+    pause-schedule
+    schedules
+`
+
+// Info implements Command.Info.
+func (c *pauseScheduleCommand) Info() *cmd.Info {
+	if c.paused {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "pause-schedule",
+			Args:    "<schedule-name> [<schedule-name> ...]",
+			Purpose: "Pause runs of an action schedule.",
+			Doc:     pauseScheduleDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resume-schedule",
+		Args:    "<schedule-name> [<schedule-name> ...]",
+		Purpose: "Resume runs of a paused action schedule.",
+		Doc:     resumeScheduleDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *pauseScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *pauseScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	update, verb := api.Resume, "resume"
+	if c.paused {
+		update, verb = api.Pause, "pause"
+	}
+	var failed bool
+	for _, name := range c.names {
+		if err := update(name); err != nil {
+			ctx.Infof("cannot %s schedule %q: %v", verb, name, err)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
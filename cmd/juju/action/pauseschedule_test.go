@@ -0,0 +1,50 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type PauseScheduleSuite struct {
+	BaseScheduleSuite
+}
+
+var _ = gc.Suite(&PauseScheduleSuite{})
+
+func (s *PauseScheduleSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewPauseScheduleCommandForTest(s.store, true))
+	c.Assert(err, gc.ErrorMatches, "no schedule specified")
+}
+
+func (s *PauseScheduleSuite) TestPause(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewPauseScheduleCommandForTest(s.store, true), "nightly-backup", "health")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Pause", "Pause", "Close")
+	s.api.CheckCall(c, 1, "Pause", "health")
+}
+
+func (s *PauseScheduleSuite) TestResume(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewPauseScheduleCommandForTest(s.store, false), "nightly-backup")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Resume", []interface{}{"nightly-backup"}},
+		{"Close", nil},
+	})
+}
+
+func (s *PauseScheduleSuite) TestPauseError(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf(`action schedule "weekly"`))
+	ctx, err := cmdtesting.RunCommand(c, action.NewPauseScheduleCommandForTest(s.store, true), "weekly", "nightly-backup")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "cannot pause schedule \"weekly\": action schedule \"weekly\" not found\n")
+	s.api.CheckCallNames(c, "Pause", "Pause", "Close")
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRemoveScheduleCommand returns a command which removes
+// action schedules.
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+type removeScheduleCommand struct {
+	ScheduleCommandBase
+	names []string
+}
+
+const removeScheduleDoc = `
+Remove one or more schedules on which actions are run. Operations
+already enqueued by the schedules are not affected.
+
+Examples:
+
+    juju remove-schedule nightly-backup
+
+See also:
+    add-schedule
+    pause-schedule
+    schedules
+`
+
+// Info implements Command.Info.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-schedule",
+		Args:    "<schedule-name> [<schedule-name> ...]",
+		Purpose: "Remove an action schedule.",
+		Doc:     removeScheduleDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	var failed bool
+	for _, name := range c.names {
+		if err := api.Remove(name); err != nil {
+			ctx.Infof("cannot remove schedule %q: %v", name, err)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type RemoveScheduleSuite struct {
+	BaseScheduleSuite
+}
+
+var _ = gc.Suite(&RemoveScheduleSuite{})
+
+func (s *RemoveScheduleSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store))
+	c.Assert(err, gc.ErrorMatches, "no schedule specified")
+}
+
+func (s *RemoveScheduleSuite) TestRemove(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "nightly-backup", "health")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Remove", "Remove", "Close")
+	s.api.CheckCall(c, 0, "Remove", "nightly-backup")
+	s.api.CheckCall(c, 1, "Remove", "health")
+}
+
+func (s *RemoveScheduleSuite) TestRemoveError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	ctx, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "nightly-backup")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "cannot remove schedule \"nightly-backup\": boom\n")
+}
@@ -11,11 +11,9 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
-	"gopkg.in/yaml.v2"
 
 	actionapi "github.com/juju/juju/api/action"
 	jujucmd "github.com/juju/juju/cmd"
-	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -57,6 +55,11 @@ use the --background option.
 
 To set the maximum time to wait for a action to complete, use the --wait option.
 
+To see the output, logged messages and status of the action as it runs, use the
+--stream option. Once the action has finished its results are shown, and
+the command exits with an error if it did not complete. When the action runs
+on more than one unit, each line streamed starts with the unit's name.
+
 By default, the output of a single action will just be that action's stdout.
 For multiple actions, each action stdout is printed with the action id.
 To see more detailed information about run timings etc, use --format yaml.
@@ -84,6 +87,7 @@ Examples:
 
     juju run mysql/3 backup --background
     juju run mysql/3 backup --wait=2m
+    juju run mysql/3 backup --stream
     juju run mysql/3 backup --format yaml
     juju run mysql/3 backup --utc
     juju run mysql/3 backup
@@ -142,23 +146,8 @@ func (c *runCommand) Init(args []string) (err error) {
 	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(c.unitReceivers)+1:] {
-		thisArg := strings.SplitN(arg, "=", 2)
-		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key.key.key...=value", arg)
-		}
-		keySlice := strings.Split(thisArg[0], ".")
-		// check each key for validity
-		for _, key := range keySlice {
-			if valid := nameRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
-					"and contain only lowercase alphanumeric and hyphens", key)
-			}
-		}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
-	}
-	return nil
+	c.args, err = parseParamArgs(args[len(c.unitReceivers)+1:])
+	return errors.Trace(err)
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -175,54 +164,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *runCommand) enqueueActions(ctx *cmd.Context) (*actionapi.EnqueuedActions, error) {
-	actionParams := map[string]interface{}{}
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-
-		conformantParams, err := common.ConformYAML(actionParams)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return nil, errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
-	}
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-	conformantParams, err := common.ConformYAML(actionParams)
+	actionParams, err := buildParams(ctx, c.paramsYAML, c.args, c.parseStrings)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
-	}
 	actions := make([]actionapi.Action, len(c.unitReceivers))
 	for i, unitReceiver := range c.unitReceivers {
 		if strings.HasSuffix(unitReceiver, "leader") {
@@ -82,6 +82,10 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should:      "fail with both --background and --wait",
 		args:        []string{"--background", "--wait=60s", validUnitId, "action"},
 		expectError: "cannot specify both --wait and --background",
+	}, {
+		should:      "fail with both --background and --stream",
+		args:        []string{"--background", "--stream", validUnitId, "action"},
+		expectError: "cannot specify both --stream and --background",
 	}, {
 		should:      "fail with no action specified",
 		args:        []string{validUnitId},
@@ -921,3 +925,73 @@ Check task status with 'juju show-task %s'`[1:],
 		}
 	}
 }
+
+func encodeTaskEvents(c *gc.C, events ...actions.TaskEvent) []string {
+	encoded := make([]string, len(events))
+	for i, event := range events {
+		data, err := json.Marshal(event)
+		c.Assert(err, jc.ErrorIsNil)
+		encoded[i] = string(data)
+	}
+	return encoded
+}
+
+func (s *RunSuite) TestRunStream(c *gc.C) {
+	timestamp := time.Date(2015, time.February, 14, 6, 6, 6, 0, time.UTC)
+	client := &fakeAPIClient{
+		taskEvents: map[string]chan []string{validActionId: make(chan []string, 1)},
+		actionResults: []actionapi.ActionResult{{
+			Action: &actionapi.Action{
+				ID:       validActionId,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Status: "completed",
+			Output: map[string]interface{}{
+				"outcome": "success",
+				"stdout":  "hello\n",
+			},
+		}},
+	}
+	client.taskEvents[validActionId] <- encodeTaskEvents(c,
+		actions.TaskEvent{Timestamp: timestamp, Status: "running"},
+		actions.TaskEvent{Timestamp: timestamp, Message: "starting"},
+		actions.TaskEvent{Timestamp: timestamp, Stream: "stdout", Message: "hello\n"},
+		actions.TaskEvent{Timestamp: timestamp, Status: "completed"},
+	)
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	runCmd, _ := action.NewRunCommandForTest(s.store, s.clock, nil)
+	ctx, err := cmdtesting.RunCommand(c, runCmd, "-m", "admin", validUnitId, "some-action", "--stream", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "hello\noutcome: success\n\n\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Running operation 1 with 1 task
+  - task 1 on unit-mysql-0
+
+06:06:06 task 1 running
+06:06:06 starting
+06:06:06 task 1 completed
+`[1:])
+}
+
+func (s *RunSuite) TestRunStreamFailed(c *gc.C) {
+	client := &fakeAPIClient{
+		taskEvents: map[string]chan []string{validActionId: make(chan []string, 1)},
+		actionResults: []actionapi.ActionResult{{
+			Action: &actionapi.Action{
+				ID:       validActionId,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Status:  "failed",
+			Message: "oops",
+		}},
+	}
+	client.taskEvents[validActionId] <- encodeTaskEvents(c, actions.TaskEvent{Status: "failed"})
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	runCmd, _ := action.NewRunCommandForTest(s.store, s.clock, nil)
+	_, err := cmdtesting.RunCommand(c, runCmd, "-m", "admin", validUnitId, "some-action", "--stream")
+	c.Assert(err, gc.ErrorMatches, "task "+validActionId+" failed: oops")
+}
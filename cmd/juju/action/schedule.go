@@ -0,0 +1,125 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/actionschedule"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// ScheduleAPIClient represents the action schedule API functionality.
+type ScheduleAPIClient interface {
+	io.Closer
+
+	// AddSchedule adds a schedule on which an action is run.
+	AddSchedule(params.ActionSchedule) error
+
+	// ListSchedules returns all the action schedules in the model.
+	ListSchedules() ([]params.ActionSchedule, error)
+
+	// Schedule returns the named action schedule and its recent runs.
+	Schedule(name string) (params.ActionSchedule, error)
+
+	// Pause pauses runs of the named action schedule.
+	Pause(name string) error
+
+	// Resume resumes runs of the named action schedule.
+	Resume(name string) error
+
+	// Remove removes the named action schedule.
+	Remove(name string) error
+}
+
+// ScheduleCommandBase is the base type for action schedule sub-commands.
+type ScheduleCommandBase struct {
+	modelcmd.ModelCommandBase
+}
+
+// NewScheduleAPIClient returns a client for the action schedule api endpoint.
+func (c *ScheduleCommandBase) NewScheduleAPIClient() (ScheduleAPIClient, error) {
+	return newScheduleAPIClient(c)
+}
+
+var newScheduleAPIClient = func(c *ScheduleCommandBase) (ScheduleAPIClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return actionschedule.NewClient(root), nil
+}
+
+// scheduleRun is the formatted form of a run of an action schedule.
+type scheduleRun struct {
+	Scheduled time.Time `yaml:"scheduled" json:"scheduled"`
+	Enqueued  time.Time `yaml:"enqueued" json:"enqueued"`
+	Operation string    `yaml:"operation,omitempty" json:"operation,omitempty"`
+	Error     string    `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// scheduleInfo is the formatted form of an action schedule.
+type scheduleInfo struct {
+	Target     string                 `yaml:"target" json:"target"`
+	Action     string                 `yaml:"action" json:"action"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Cron       string                 `yaml:"cron" json:"cron"`
+	Status     string                 `yaml:"status" json:"status"`
+	Owner      string                 `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Created    time.Time              `yaml:"created" json:"created"`
+	Runs       []scheduleRun          `yaml:"runs,omitempty" json:"runs,omitempty"`
+}
+
+func newScheduleInfo(schedule params.ActionSchedule, utc bool) scheduleInfo {
+	info := scheduleInfo{
+		Target:     schedule.Target,
+		Action:     schedule.Action,
+		Parameters: schedule.Parameters,
+		Cron:       schedule.Cron,
+		Status:     scheduleStatus(schedule),
+		Owner:      schedule.Owner,
+		Created:    scheduleTime(schedule.Created, utc),
+	}
+	for _, run := range schedule.Runs {
+		info.Runs = append(info.Runs, scheduleRun{
+			Scheduled: scheduleTime(run.Scheduled, utc),
+			Enqueued:  scheduleTime(run.Enqueued, utc),
+			Operation: operationID(run.OperationTag),
+			Error:     run.Error,
+		})
+	}
+	return info
+}
+
+func scheduleStatus(schedule params.ActionSchedule) string {
+	if schedule.Paused {
+		return "paused"
+	}
+	return "active"
+}
+
+func scheduleTime(t time.Time, utc bool) time.Time {
+	if utc {
+		return t.UTC()
+	}
+	return t.Local()
+}
+
+// operationID returns the ID of the operation with the given tag,
+// as shown by show-operation.
+func operationID(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	opTag, err := names.ParseOperationTag(tag)
+	if err != nil {
+		return tag
+	}
+	return opTag.Id()
+}
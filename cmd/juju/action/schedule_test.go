@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type BaseScheduleSuite struct {
+	BaseActionSuite
+	api *fakeScheduleAPIClient
+}
+
+func (s *BaseScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.store.Models["ctrl"].CurrentModel = "admin/admin"
+	s.api = &fakeScheduleAPIClient{
+		schedules: []params.ActionSchedule{{
+			Name:       "nightly-backup",
+			Target:     "mysql/leader",
+			Action:     "backup",
+			Parameters: map[string]interface{}{"outfile": "out.tar.gz"},
+			Cron:       "0 3 * * *",
+			Owner:      "admin",
+			Created:    time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+			Runs: []params.ActionScheduleRun{{
+				Scheduled:    time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC),
+				Enqueued:     time.Date(2021, 6, 2, 3, 0, 1, 0, time.UTC),
+				OperationTag: "operation-7",
+			}, {
+				Scheduled: time.Date(2021, 6, 3, 3, 0, 0, 0, time.UTC),
+				Enqueued:  time.Date(2021, 6, 3, 3, 0, 1, 0, time.UTC),
+				Error:     `could not determine leader for "mysql"`,
+			}},
+		}, {
+			Name:    "health-check-mysql",
+			Target:  "mysql",
+			Action:  "health-check",
+			Cron:    "*/15 * * * *",
+			Paused:  true,
+			Owner:   "admin",
+			Created: time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC),
+		}},
+	}
+	s.PatchValue(action.NewScheduleAPIClient, func(*action.ScheduleCommandBase) (action.ScheduleAPIClient, error) {
+		return s.api, nil
+	})
+}
+
+type fakeScheduleAPIClient struct {
+	jujutesting.Stub
+	schedules []params.ActionSchedule
+}
+
+func (f *fakeScheduleAPIClient) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeScheduleAPIClient) AddSchedule(schedule params.ActionSchedule) error {
+	f.MethodCall(f, "AddSchedule", schedule)
+	return f.NextErr()
+}
+
+func (f *fakeScheduleAPIClient) ListSchedules() ([]params.ActionSchedule, error) {
+	f.MethodCall(f, "ListSchedules")
+	return f.schedules, f.NextErr()
+}
+
+func (f *fakeScheduleAPIClient) Schedule(name string) (params.ActionSchedule, error) {
+	f.MethodCall(f, "Schedule", name)
+	for _, schedule := range f.schedules {
+		if schedule.Name == name {
+			return schedule, nil
+		}
+	}
+	return params.ActionSchedule{}, errors.NotFoundf("action schedule %q", name)
+}
+
+func (f *fakeScheduleAPIClient) Pause(name string) error {
+	f.MethodCall(f, "Pause", name)
+	return f.NextErr()
+}
+
+func (f *fakeScheduleAPIClient) Resume(name string) error {
+	f.MethodCall(f, "Resume", name)
+	return f.NextErr()
+}
+
+func (f *fakeScheduleAPIClient) Remove(name string) error {
+	f.MethodCall(f, "Remove", name)
+	return f.NextErr()
+}
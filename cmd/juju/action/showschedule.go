@@ -0,0 +1,89 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewShowScheduleCommand returns a command which shows an action
+// schedule and the history of its runs.
+func NewShowScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&showScheduleCommand{})
+}
+
+type showScheduleCommand struct {
+	ScheduleCommandBase
+	out  cmd.Output
+	utc  bool
+	name string
+}
+
+const showScheduleDoc = `
+Show the details of a schedule on which an action is run, including its
+most recent runs, oldest first. Each run shows when it was due, when its
+operation was enqueued, and the operation's ID for use with
+'juju show-operation'. Runs which couldn't be enqueued, because the
+application had no leader for example, show the reason.
+
+Examples:
+
+    juju show-schedule nightly-backup
+    juju show-schedule nightly-backup --format json --utc
+
+See also:
+    schedules
+    show-operation
+`
+
+// SetFlags implements Command.SetFlags.
+func (c *showScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ScheduleCommandBase.SetFlags(f)
+	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Info implements Command.Info.
+func (c *showScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-schedule",
+		Args:    "<schedule-name>",
+		Purpose: "Show an action schedule and its recent runs.",
+		Doc:     showScheduleDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *showScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	schedule, err := api.Schedule(c.name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, map[string]scheduleInfo{
+		schedule.Name: newScheduleInfo(schedule, c.utc),
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ShowScheduleSuite struct {
+	BaseScheduleSuite
+}
+
+var _ = gc.Suite(&ShowScheduleSuite{})
+
+func (s *ShowScheduleSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewShowScheduleCommandForTest(s.store))
+	c.Assert(err, gc.ErrorMatches, "no schedule specified")
+	_, err = cmdtesting.RunCommand(c, action.NewShowScheduleCommandForTest(s.store), "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *ShowScheduleSuite) TestShow(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewShowScheduleCommandForTest(s.store), "nightly-backup", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+nightly-backup:
+  target: mysql/leader
+  action: backup
+  parameters:
+    outfile: out.tar.gz
+  cron: 0 3 * * *
+  status: active
+  owner: admin
+  created: 2021-06-01T10:00:00Z
+  runs:
+  - scheduled: 2021-06-02T03:00:00Z
+    enqueued: 2021-06-02T03:00:01Z
+    operation: "7"
+  - scheduled: 2021-06-03T03:00:00Z
+    enqueued: 2021-06-03T03:00:01Z
+    error: could not determine leader for "mysql"
+`[1:])
+}
+
+func (s *ShowScheduleSuite) TestNotFound(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewShowScheduleCommandForTest(s.store), "weekly")
+	c.Assert(err, gc.ErrorMatches, `action schedule "weekly" not found`)
+}
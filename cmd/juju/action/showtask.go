@@ -48,6 +48,11 @@ To block until the result is known completed or failed, use
 the --wait option with a duration, as in --wait 5s or --wait 1h.
 Use --watch to wait indefinitely.  
 
+While waiting on a controller which supports it, the output the task writes to stdout and stderr, the
+messages it logs and changes to its status are shown as they happen.
+The command then exits with an error if the task did not complete, or
+with the return code of a failed command run with "juju exec".
+
 The default behavior without --wait or --watch is to immediately check and return;
 if the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
@@ -143,6 +148,13 @@ func (c *showTaskCommand) Run(ctx *cmd.Context) error {
 	}
 
 	if shouldWatch {
+		eventsWatcher, err := api.WatchTaskEvents(c.requestedId)
+		if err == nil {
+			return c.streamTask(ctx, api, eventsWatcher, wait)
+		}
+		if !errors.IsNotSupported(err) {
+			return errors.Trace(err)
+		}
 		logsWatcher, err = api.WatchActionProgress(c.requestedId)
 		if err != nil {
 			return errors.Trace(err)
@@ -171,8 +183,39 @@ func (c *showTaskCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return c.writeResult(ctx, formatActionResult(c.requestedId, result, c.utc))
+}
+
+// streamTask writes the output, logs and status of the task as it runs,
+// then its result. The command fails if the task doesn't complete, or
+// returns the code of a command it ran which failed.
+func (c *showTaskCommand) streamTask(ctx *cmd.Context, api APIClient, w watcher.StringsWatcher, wait clock.Timer) error {
+	done := make(chan struct{})
+	streamer := &taskStreamer{ctx: ctx, utc: c.utc}
+	finished := streamer.stream(w, c.requestedId, "", done)
+	defer func() {
+		close(done)
+		_ = w.Wait()
+		<-finished
+	}()
+
+	select {
+	case <-finished:
+	case <-wait.Chan():
+		return errors.Errorf("timed out waiting for results from task %s", c.requestedId)
+	}
+	result, err := fetchResult(api, c.requestedId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatted := streamedResult(formatActionResult(c.requestedId, result, c.utc), c.out.Name() == "plain")
+	if err := c.writeResult(ctx, formatted); err != nil {
+		return errors.Trace(err)
+	}
+	return taskResultError(c.requestedId, result)
+}
 
-	formatted := formatActionResult(c.requestedId, result, c.utc)
+func (c *showTaskCommand) writeResult(ctx *cmd.Context, formatted map[string]interface{}) error {
 	if c.out.Name() != "plain" {
 		return c.out.Write(ctx, formatted)
 	}
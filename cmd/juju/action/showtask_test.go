@@ -390,6 +390,50 @@ timing:
 	}
 }
 
+func (s *ShowTaskSuite) TestWatchStreamsEvents(c *gc.C) {
+	timestamp := time.Date(2015, time.February, 14, 6, 6, 6, 0, time.UTC)
+	client := &fakeAPIClient{
+		taskEvents:     map[string]chan []string{validActionId: make(chan []string)},
+		waitForResults: make(chan bool),
+		actionResults: []actionapi.ActionResult{{
+			Action: &actionapi.Action{
+				ID:       validActionId,
+				Receiver: "unit-mysql-0",
+			},
+			Status: "completed",
+			Output: map[string]interface{}{
+				"outcome":     "done",
+				"return-code": 3,
+				"stdout":      "hello\n",
+			},
+		}},
+	}
+	unpatch := s.patchAPIClient(client)
+	defer unpatch()
+
+	go func() {
+		client.taskEvents[validActionId] <- encodeTaskEvents(c,
+			actions.TaskEvent{Timestamp: timestamp, Status: "running"},
+			actions.TaskEvent{Timestamp: timestamp, Stream: "stdout", Message: "hello\n"},
+		)
+		// The task's result is only available once it has finished.
+		close(client.waitForResults)
+		client.taskEvents[validActionId] <- encodeTaskEvents(c,
+			actions.TaskEvent{Timestamp: timestamp, Status: "completed"},
+		)
+	}()
+
+	runCmd, _ := action.NewShowTaskCommandForTest(s.store, s.clock, nil)
+	ctx, err := cmdtesting.RunCommand(c, runCmd, "-m", "admin", validActionId, "--watch", "--utc")
+	c.Assert(err, gc.DeepEquals, cmd.NewRcPassthroughError(3))
+	// The output already streamed isn't repeated in the results.
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "hello\noutcome: done\n\n\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+06:06:06 task 1 running
+06:06:06 task 1 completed
+`[1:])
+}
+
 func (s *ShowTaskSuite) testRunHelper(c *gc.C, client *fakeAPIClient,
 	expectedErr, expectedOutput, format, wait, query, modelFlag string,
 	watch bool,
@@ -0,0 +1,172 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	coreactions "github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/watcher"
+)
+
+// isTaskFinished returns true if a task with the given
+// status will not run, or run any further.
+func isTaskFinished(status string) bool {
+	switch status {
+	case params.ActionCompleted, params.ActionFailed, params.ActionCancelled, params.ActionAborted:
+		return true
+	}
+	return false
+}
+
+// taskStreamer writes the events of tasks to a command's output as they
+// are reported. Output written by a task to stdout and stderr is copied
+// to the command's stdout and stderr; messages logged by the task and
+// changes to its status are written to the command's stderr.
+type taskStreamer struct {
+	ctx *cmd.Context
+	utc bool
+
+	// mu serialises the writes of tasks streamed concurrently.
+	mu sync.Mutex
+}
+
+// stream starts a goroutine writing the events reported by w for the
+// given task, each line starting with prefix. The returned channel is
+// closed once the task has finished, or w has stopped; no more events
+// are written once done is closed.
+func (s *taskStreamer) stream(w watcher.StringsWatcher, taskID, prefix string, done <-chan struct{}) <-chan struct{} {
+	finished := make(chan struct{})
+	stdout := &lineWriter{w: s.ctx.Stdout, prefix: prefix}
+	stderr := &lineWriter{w: s.ctx.Stderr, prefix: prefix}
+	go func() {
+		defer close(finished)
+		defer w.Kill()
+		defer func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			stdout.flush()
+			stderr.flush()
+		}()
+		for {
+			select {
+			case <-done:
+				return
+			case events, ok := <-w.Changes():
+				if !ok {
+					return
+				}
+				for _, encoded := range events {
+					var event coreactions.TaskEvent
+					if err := json.Unmarshal([]byte(encoded), &event); err != nil {
+						logger.Warningf("badly formatted task event: %v\n%v", err, encoded)
+						continue
+					}
+					if s.write(event, taskID, stdout, stderr) {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return finished
+}
+
+// write writes the event, and reports whether
+// it shows the task has finished.
+func (s *taskStreamer) write(event coreactions.TaskEvent, taskID string, stdout, stderr *lineWriter) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case event.Status != "":
+		stderr.flush()
+		stderr.write(s.timestamped(event, fmt.Sprintf("task %s %s", taskID, event.Status)))
+		return isTaskFinished(event.Status)
+	case event.Stream == coreactions.StdoutStream:
+		stdout.write(event.Message)
+	case event.Stream == coreactions.StderrStream:
+		stderr.write(event.Message)
+	default:
+		stderr.flush()
+		stderr.write(s.timestamped(event, event.Message))
+	}
+	return false
+}
+
+// timestamped returns a line holding the message,
+// starting with the time of the event.
+func (s *taskStreamer) timestamped(event coreactions.TaskEvent, message string) string {
+	timestamp := formatTimestamp(event.Timestamp, true, s.utc, true)
+	if timestamp == "" {
+		return message + "\n"
+	}
+	return timestamp + " " + message + "\n"
+}
+
+// lineWriter writes text to w. When prefix is set, text is written
+// a whole line at a time, each line starting with prefix.
+type lineWriter struct {
+	w       io.Writer
+	prefix  string
+	partial string
+}
+
+func (lw *lineWriter) write(text string) {
+	if lw.prefix == "" {
+		fmt.Fprint(lw.w, text)
+		return
+	}
+	lines := strings.SplitAfter(lw.partial+text, "\n")
+	lw.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		fmt.Fprint(lw.w, lw.prefix+line)
+	}
+}
+
+// flush writes any incomplete line.
+func (lw *lineWriter) flush() {
+	if lw.partial != "" {
+		fmt.Fprintln(lw.w, lw.prefix+lw.partial)
+		lw.partial = ""
+	}
+}
+
+// streamedResult returns the formatted result of a streamed task.
+// The output the task wrote to stdout and stderr has already been
+// streamed, so it's removed from the results written in plain format.
+func streamedResult(formatted map[string]interface{}, plain bool) map[string]interface{} {
+	results, ok := formatted["results"].(map[string]interface{})
+	if !plain || !ok {
+		return formatted
+	}
+	delete(results, "stdout")
+	delete(results, "stderr")
+	return formatted
+}
+
+// taskResultError returns the error the command running or watching the
+// task exits with: none if the task completed, the task's return code if
+// it ran a command which failed, and an error if the task didn't
+// complete.
+func taskResultError(taskID string, result actionapi.ActionResult) error {
+	if result.Status != params.ActionCompleted {
+		if result.Message != "" {
+			return errors.Errorf("task %s %s: %s", taskID, result.Status, result.Message)
+		}
+		return errors.Errorf("task %s %s", taskID, result.Status)
+	}
+	if code, ok := convertActionOutput(result.Output)["return-code"].(int); ok && code != 0 {
+		return cmd.NewRcPassthroughError(code)
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/api/application"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -23,12 +24,17 @@ The command takes deployed unit names as an argument.
 Optionally, relation data for only a specified endpoint
 or related unit may be shown, or just the application data. 
 
+For units deployed to Kubernetes, the --events option also shows
+the most recent events reported by the cluster for the unit's pod,
+which can explain why the unit is stuck waiting or in error.
+
 Examples:
     juju show-unit mysql/0
     juju show-unit mysql/0 wordpress/1
     juju show-unit mysql/0 --app
     juju show-unit mysql/0 --endpoint db
     juju show-unit mysql/0 --related-unit wordpress/2
+    juju show-unit mysql/0 --events
 `
 
 // NewShowUnitCommand returns a command that displays unit info.
@@ -48,6 +54,7 @@ type showUnitCommand struct {
 	endpoint    string
 	relatedUnit string
 	appOnly     bool
+	events      bool
 
 	newAPIFunc func() (UnitsInfoAPI, error)
 }
@@ -95,12 +102,14 @@ func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.endpoint, "endpoint", "", "only show relation data for the specified endpoint")
 	f.StringVar(&c.relatedUnit, "related-unit", "", "only show relation data for the specified unit")
 	f.BoolVar(&c.appOnly, "app", false, "only show application relation data")
+	f.BoolVar(&c.events, "events", false, "show recent cloud events for the unit's workload")
 }
 
 // UnitsInfoAPI defines the API methods that show-unit command uses.
 type UnitsInfoAPI interface {
 	Close() error
 	UnitsInfo([]names.UnitTag) ([]application.UnitInfo, error)
+	UnitsEvents([]names.UnitTag) ([]application.UnitEventsResult, error)
 }
 
 func (c *showUnitCommand) newUnitAPI() (UnitsInfoAPI, error) {
@@ -129,13 +138,28 @@ func (c *showUnitCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	var events []application.UnitEventsResult
+	if c.events {
+		if events, err = client.UnitsEvents(tags); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	var errs []error
 	var valid []application.UnitInfo
-	for _, result := range results {
+	unitEvents := make(map[string][]application.UnitEvent)
+	for i, result := range results {
 		if result.Error != nil {
 			errs = append(errs, result.Error)
 			continue
 		}
+		if c.events {
+			if events[i].Error != nil {
+				errs = append(errs, events[i].Error)
+				continue
+			}
+			unitEvents[result.Tag] = events[i].Events
+		}
 		valid = append(valid, result)
 	}
 	if len(errs) > 0 {
@@ -146,7 +170,7 @@ func (c *showUnitCommand) Run(ctx *cmd.Context) error {
 		return errors.New(strings.Join(errorStrings, "\n"))
 	}
 
-	output, err := c.formatUnitInfos(valid)
+	output, err := c.formatUnitInfos(valid, unitEvents)
 	if err != nil {
 		return err
 	}
@@ -164,7 +188,9 @@ func (c *showUnitCommand) getUnitTags() ([]names.UnitTag, error) {
 	return tags, nil
 }
 
-func (c *showUnitCommand) formatUnitInfos(all []application.UnitInfo) (map[string]UnitInfo, error) {
+func (c *showUnitCommand) formatUnitInfos(
+	all []application.UnitInfo, events map[string][]application.UnitEvent,
+) (map[string]UnitInfo, error) {
 	if len(all) == 0 {
 		return nil, nil
 	}
@@ -174,6 +200,9 @@ func (c *showUnitCommand) formatUnitInfos(all []application.UnitInfo) (map[strin
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		for _, event := range events[one.Tag] {
+			info.Events = append(info.Events, formatUnitEvent(event))
+		}
 		output[tag.Id()] = info
 	}
 	return output, nil
@@ -204,8 +233,32 @@ type UnitInfo struct {
 	RelationData    []RelationData `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
 
 	// The following are for CAAS models.
-	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
-	Address    string `yaml:"address,omitempty" json:"address,omitempty"`
+	ProviderId string      `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Address    string      `yaml:"address,omitempty" json:"address,omitempty"`
+	Events     []UnitEvent `yaml:"events,omitempty" json:"events,omitempty"`
+}
+
+// UnitEvent defines the serialization behaviour of a cloud event
+// reported for the workload of a unit.
+type UnitEvent struct {
+	Type    string `yaml:"type" json:"type"`
+	Reason  string `yaml:"reason" json:"reason"`
+	Message string `yaml:"message" json:"message"`
+	Count   int    `yaml:"count,omitempty" json:"count,omitempty"`
+	Time    string `yaml:"time,omitempty" json:"time,omitempty"`
+}
+
+func formatUnitEvent(event application.UnitEvent) UnitEvent {
+	out := UnitEvent{
+		Type:    event.Type,
+		Reason:  event.Reason,
+		Message: event.Message,
+		Count:   event.Count,
+	}
+	if !event.Time.IsZero() {
+		out.Time = common.FormatTime(&event.Time, true)
+	}
+	return out
 }
 
 func (c *showUnitCommand) createUnitInfo(details application.UnitInfo) (names.UnitTag, UnitInfo, error) {
@@ -6,6 +6,7 @@ package application_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -380,8 +381,70 @@ wordpress/0:
 	})
 }
 
+func (s *ShowUnitSuite) TestShowEvents(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		info := s.createTestUnitInfo("wordpress", "")
+		info.RelationData = nil
+		return []apiapplication.UnitInfo{info}, nil
+	}
+	s.mockAPI.unitsEventsFunc = func(tags []names.UnitTag) ([]apiapplication.UnitEventsResult, error) {
+		c.Assert(tags, jc.DeepEquals, []names.UnitTag{names.NewUnitTag("wordpress/0")})
+		return []apiapplication.UnitEventsResult{{
+			Events: []apiapplication.UnitEvent{{
+				Type:    "Warning",
+				Reason:  "Failed",
+				Message: `Failed to pull image "wordpress:bad"`,
+				Count:   3,
+				Time:    time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+			}, {
+				Type:    "Normal",
+				Reason:  "BackOff",
+				Message: `Back-off pulling image "wordpress:bad"`,
+			}},
+		}}, nil
+	}
+	s.assertRunShow(c, showUnitTest{
+		args: []string{"wordpress/0", "--events"},
+		stdout: `
+wordpress/0:
+  workload-version: "666"
+  machine: "0"
+  opened-ports:
+  - 100-102/ip
+  public-address: 10.0.0.1
+  charm: charm-wordpress
+  leader: true
+  provider-id: provider-id
+  address: 192.168.1.1
+  events:
+  - type: Warning
+    reason: Failed
+    message: Failed to pull image "wordpress:bad"
+    count: 3
+    time: 2021-03-01 10:00:00Z
+  - type: Normal
+    reason: BackOff
+    message: Back-off pulling image "wordpress:bad"
+`[1:],
+	})
+}
+
+func (s *ShowUnitSuite) TestShowEventsError(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		return []apiapplication.UnitInfo{s.createTestUnitInfo("wordpress", "")}, nil
+	}
+	s.mockAPI.unitsEventsFunc = func([]names.UnitTag) ([]apiapplication.UnitEventsResult, error) {
+		return []apiapplication.UnitEventsResult{{Error: errors.New("boom")}}, nil
+	}
+	s.assertRunShow(c, showUnitTest{
+		args: []string{"wordpress/0", "--events"},
+		err:  "boom",
+	})
+}
+
 type mockShowUnitAPI struct {
-	unitsInfoFunc func([]names.UnitTag) ([]apiapplication.UnitInfo, error)
+	unitsInfoFunc   func([]names.UnitTag) ([]apiapplication.UnitInfo, error)
+	unitsEventsFunc func([]names.UnitTag) ([]apiapplication.UnitEventsResult, error)
 }
 
 func (s mockShowUnitAPI) Close() error {
@@ -391,3 +454,7 @@ func (s mockShowUnitAPI) Close() error {
 func (s mockShowUnitAPI) UnitsInfo(tags []names.UnitTag) ([]apiapplication.UnitInfo, error) {
 	return s.unitsInfoFunc(tags)
 }
+
+func (s mockShowUnitAPI) UnitsEvents(tags []names.UnitTag) ([]apiapplication.UnitEventsResult, error) {
+	return s.unitsEventsFunc(tags)
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"strings"
 	"text/template"
 	"time"
 
@@ -31,8 +32,9 @@ import (
 //go:generate go run github.com/golang/mock/mockgen -package backups_test -destination mock_test.go github.com/juju/juju/cmd/juju/backups ArchiveReader,APIClient
 type APIClient interface {
 	io.Closer
-	// Create sends an RPC request to create a new backup.
-	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	// Create sends an RPC request to create a new backup, encrypted
+	// to the armored OpenPGP public key if one is given.
+	Create(notes string, keepCopy, noDownload bool, encryptionKey string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -134,7 +136,9 @@ created on host:       {{.Hostname}}
 checksum:              {{.Checksum}} 
 checksum format:       {{.ChecksumFormat}} 
 size (B):              {{.Size}} 
-stored:                {{.Stored}} 
+stored:                {{.Stored}} {{if .Location}}
+location:              {{.Location}} {{end}}{{if .EncryptedTo}}
+encrypted to:          {{.EncryptedTo}} {{end}}
 started:               {{.Started}} 
 finished:              {{.Finished}} 
 
@@ -158,6 +162,8 @@ type MetadataParams struct {
 	Hostname       string
 	JujuVersion    version.Number
 	Series         string
+	EncryptedTo    string
+	Location       string
 }
 
 func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
@@ -178,6 +184,8 @@ func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
 		result.Hostname,
 		result.Version,
 		result.Series,
+		strings.Join(result.EncryptedTo, ", "),
+		result.Location,
 	}
 	t := template.Must(template.New("template").Parse(backupMetadataTemplate))
 	content := bytes.Buffer{}
@@ -6,7 +6,7 @@ package backups
 import (
 	"fmt"
 	"io"
-	"time"
+	"io/ioutil"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -37,6 +37,16 @@ Use --keep-copy option to store a copy of backup remotely on the controller.
 
 Use --verbose to see extra information about backup.
 
+Use --encrypt-to to encrypt the backup archive to the OpenPGP public key
+in the given file, as exported by "gpg --export --armor". The archive is
+encrypted on the controller while it is created, so neither the copy kept
+on the controller nor the downloaded file can be read without the private
+key. Decrypt the archive with "gpg --decrypt" before restoring it.
+
+If the controller is configured with backup-storage-s3-* keys, copies
+kept with --keep-copy are stored in that object store rather than on the
+controller.
+
 To access remote backups stored on the controller, see 'juju download-backup'.
 
 Examples:
@@ -45,6 +55,7 @@ Examples:
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
     juju create-backup --verbose
+    juju create-backup --encrypt-to backups.asc
 
 See also:
     backups
@@ -67,6 +78,8 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
+	// EncryptTo is the file holding the public key to encrypt the backup to.
+	EncryptTo string
 }
 
 // Info implements Command.Info.
@@ -85,6 +98,7 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.EncryptTo, "encrypt-to", "", "Encrypt the archive to the OpenPGP public key in this file")
 	c.fs = f
 }
 
@@ -129,6 +143,14 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	if err := c.validateIaasController(c.Info().Name); err != nil {
 		return errors.Trace(err)
 	}
+	var encryptionKey string
+	if c.EncryptTo != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.EncryptTo))
+		if err != nil {
+			return errors.Annotate(err, "reading encryption key")
+		}
+		encryptionKey = string(data)
+	}
 	client, apiVersion, err := c.NewGetAPI()
 	if err != nil {
 		return errors.Trace(err)
@@ -148,7 +170,7 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		c.KeepCopy = true
 	}
 
-	metadataResult, copyFrom, err := c.create(client, apiVersion)
+	metadataResult, copyFrom, err := c.create(client, apiVersion, encryptionKey)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -165,7 +187,7 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 
 	// Handle download.
 	if !c.NoDownload {
-		filename := c.decideFilename(ctx, c.Filename, metadataResult.Started)
+		filename := c.decideFilename(ctx, c.Filename, metadataResult)
 		if err := c.download(ctx, client, copyFrom, filename); err != nil {
 			return errors.Trace(err)
 		}
@@ -174,12 +196,16 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
-func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timestamp time.Time) string {
+func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, result *params.BackupsMetadataResult) string {
 	if filename != notset {
 		return filename
 	}
 	// Downloading but no filename given, so generate one.
-	return timestamp.Format(backups.FilenameTemplate)
+	filename = result.Started.Format(backups.FilenameTemplate)
+	if len(result.EncryptedTo) > 0 {
+		filename += backups.EncryptedFileSuffix
+	}
+	return filename
 }
 
 func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom string, archiveFilename string) error {
@@ -203,8 +229,8 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 	return nil
 }
 
-func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+func (c *createCommand) create(client APIClient, apiVersion int, encryptionKey string) (*params.BackupsMetadataResult, string, error) {
+	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload, encryptionKey)
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -302,3 +303,30 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) TestEncryptTo(c *gc.C) {
+	keyFile := filepath.Join(c.MkDir(), "backups.asc")
+	err := ioutil.WriteFile(keyFile, []byte("<public key>"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.metaresult.EncryptedTo = []string{"FINGERPRINT"}
+	client := s.setDownload()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--encrypt-to", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	c.Check(client.encryptionKey, gc.Equals, "<public key>")
+	s.expectedOut = strings.Replace(MetaResultString,
+		"+0000 UTC \nstarted:", "+0000 UTC \nencrypted to:          FINGERPRINT \nstarted:", 1)
+	s.expectedErr = `
+Remote backup was not created.
+Downloaded to juju-backup-00010101-000000.tar.gz.gpg.
+`[1:]
+	s.checkDownload(c, ctx)
+}
+
+func (s *createSuite) TestEncryptToMissingKey(c *gc.C) {
+	client := s.setDownload()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--encrypt-to", filepath.Join(c.MkDir(), "missing.asc"))
+	c.Assert(err, gc.ErrorMatches, "reading encryption key: .* no such file or directory")
+	client.CheckCalls(c)
+}
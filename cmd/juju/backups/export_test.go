@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/state/backups"
 )
 
 const (
@@ -63,3 +64,9 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c)
 }
+
+func NewRestoreCommandForTest(
+	newRestorer func(archive *backups.ArchiveWorkspace, dataDir string) (Restorer, func(), error),
+) cmd.Command {
+	return &restoreCommand{newRestorer: newRestorer}
+}
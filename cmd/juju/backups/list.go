@@ -4,20 +4,29 @@
 package backups
 
 import (
-	"fmt"
-
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+Each backup is listed with where its archive is stored: either on the
+controller, or at a URL in the backup object store configured with the
+backup-storage-s3-* controller config keys.
+
+Use --verbose to see all the metadata of each backup.
 `
 
+// controllerLocation is shown as the location of
+// backup archives stored on the controller.
+const controllerLocation = "controller"
+
 // NewListCommand returns a command used to list metadata for backups.
 func NewListCommand() cmd.Command {
 	return modelcmd.Wrap(&listCommand{})
@@ -76,12 +85,22 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 		return nil
 	}
 
+	if c.verbose {
+		for _, resultItem := range result.List {
+			c.dumpMetadata(ctx, &resultItem)
+		}
+		return nil
+	}
+
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("ID", "Location")
 	for _, resultItem := range result.List {
-		if !c.verbose {
-			fmt.Fprintln(ctx.Stdout, resultItem.ID)
-			continue
+		location := resultItem.Location
+		if location == "" {
+			location = controllerLocation
 		}
-		c.dumpMetadata(ctx, &resultItem)
+		w.Println(resultItem.ID, location)
 	}
-	return nil
+	return errors.Trace(tw.Flush())
 }
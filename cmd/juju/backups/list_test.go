@@ -4,6 +4,8 @@
 package backups_test
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -39,11 +41,41 @@ func (s *listSuite) TestBrief(c *gc.C) {
 	s.setSuccess()
 	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
 	c.Assert(err, jc.ErrorIsNil)
-	out := s.metaresult.ID + "\n"
+	out := `
+ID    Location
+spam  controller
+`[1:]
 	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, out)
 }
 
+func (s *listSuite) TestBriefRemote(c *gc.C) {
+	s.metaresult.Location = "s3://juju-backups/backups/model-uuid/spam"
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+	out := `
+ID    Location
+spam  s3://juju-backups/backups/model-uuid/spam
+`[1:]
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, out)
+}
+
+func (s *listSuite) TestVerboseRemote(c *gc.C) {
+	s.metaresult.Location = "s3://juju-backups/backups/model-uuid/spam"
+	s.metaresult.EncryptedTo = []string{"FINGERPRINT1", "FINGERPRINT2"}
+	s.setSuccess()
+	s.subcommand = s.createCommandForGlobalOptionTesting(s.subcommand)
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "backups", "--verbose")
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := strings.Replace(MetaResultString, "+0000 UTC \nstarted:", `+0000 UTC 
+location:              s3://juju-backups/backups/model-uuid/spam 
+encrypted to:          FINGERPRINT1, FINGERPRINT2 
+started:`, 1)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, expected[:len(expected)-1])
+}
+
 func (s *listSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := cmdtesting.RunCommand(c, s.subcommand)
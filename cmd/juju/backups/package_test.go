@@ -171,10 +171,11 @@ type fakeAPIClient struct {
 	archive    io.ReadCloser
 	err        error
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+	calls         []string
+	args          []string
+	idArg         string
+	notes         string
+	encryptionKey string
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -191,10 +192,11 @@ func (f *fakeAPIClient) CheckArgs(c *gc.C, args ...string) {
 	c.Check(f.args, jc.DeepEquals, args)
 }
 
-func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool, encryptionKey string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, notes, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload))
 	c.notes = notes
+	c.encryptionKey = encryptionKey
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -0,0 +1,191 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/paths"
+	"github.com/juju/juju/state/backups"
+)
+
+const restoreDoc = `
+restore-backup restores a controller from an archive created by
+create-backup. It must be run on the controller machine whose
+database is the primary of the controller's replica set.
+
+The archive is checked against the controller before anything is
+changed: it must be a backup of the same controller, taken by the
+same major and minor version of Juju, and no later patch version.
+The machine agents of all the controller machines are then stopped,
+the databases and files in the archive restored, and the agents
+started again. Controller machines added since the backup was taken
+are removed from the replica set, and their agents are left stopped.
+
+Use --dry-run to check the archive and report what restoring it
+would change, without changing anything.
+
+Encrypted archives must be decrypted before they can be restored.
+
+Examples:
+    sudo juju restore-backup juju-backup-20210524-120011.tar.gz
+    sudo juju restore-backup --dry-run juju-backup-20210524-120011.tar.gz
+    gpg --output backup.tar.gz --decrypt juju-backup-20210524-120011.tar.gz.gpg
+    sudo juju restore-backup backup.tar.gz
+
+See also:
+    create-backup
+    download-backup
+`
+
+// Restorer restores a backup to a controller.
+type Restorer interface {
+	// Plan checks the backup can be restored, and
+	// returns what restoring it would change.
+	Plan() (*backups.RestorePlan, error)
+
+	// Restore restores the backup.
+	Restore() (*backups.RestorePlan, error)
+}
+
+// NewRestoreCommand returns a command used to restore a
+// controller from a backup archive.
+func NewRestoreCommand() cmd.Command {
+	return &restoreCommand{newRestorer: newControllerRestorer}
+}
+
+// restoreCommand is the sub-command for restoring a controller from
+// a backup archive. Unlike the other backups commands it talks to the
+// controller machine it runs on rather than the API server, which
+// doesn't run while the controller is restored.
+type restoreCommand struct {
+	cmd.CommandBase
+
+	// Filename is the backup archive to restore.
+	Filename string
+	// DataDir is the data directory of the controller machine agent.
+	DataDir string
+	// DryRun reports what restoring the backup would change,
+	// without restoring it.
+	DryRun bool
+
+	assumeYes bool
+
+	newRestorer func(archive *backups.ArchiveWorkspace, dataDir string) (Restorer, func(), error)
+}
+
+// Info implements Command.Info.
+func (c *restoreCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore-backup",
+		Args:    "<filename>",
+		Purpose: "Restore a controller from a backup archive.",
+		Doc:     restoreDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.DataDir, "data-dir", paths.DataDir(paths.CurrentOS()), "Data directory of the controller machine agent")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Report what restoring the backup would change, without restoring it")
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+}
+
+// Init implements Command.Init.
+func (c *restoreCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	filename, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.Filename = filename
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *restoreCommand) Run(ctx *cmd.Context) error {
+	archive, err := os.Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Annotate(err, "opening backup archive")
+	}
+	defer func() { _ = archive.Close() }()
+
+	reader := bufio.NewReader(archive)
+	encrypted, err := backups.IsEncryptedArchive(reader)
+	if err != nil {
+		return errors.Annotate(err, "reading backup archive")
+	}
+	if encrypted {
+		return errors.Errorf("backup archive %q is encrypted: decrypt it first, with gpg --decrypt", c.Filename)
+	}
+
+	workspace, err := backups.NewArchiveWorkspaceReader(reader)
+	if err != nil {
+		return errors.Annotate(err, "unpacking backup archive")
+	}
+	defer func() { _ = workspace.Close() }()
+
+	restorer, closer, err := c.newRestorer(workspace, c.DataDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+
+	plan, err := restorer.Plan()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	writePlan(ctx.Stdout, plan)
+	if c.DryRun {
+		return nil
+	}
+	if !c.assumeYes {
+		fmt.Fprint(ctx.Stdout, "Continue [y/N]? ")
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "restore-backup")
+		}
+	}
+
+	if _, err := restorer.Restore(); err != nil {
+		return errors.Annotate(err, "restoring backup")
+	}
+	ctx.Infof("Backup %s restored.", plan.Metadata.ID())
+	return nil
+}
+
+// writePlan writes what restoring a backup changes.
+func writePlan(w io.Writer, plan *backups.RestorePlan) {
+	meta := plan.Metadata
+	fmt.Fprintf(w, "Backup %s of controller %s\n", meta.ID(), meta.Controller.UUID)
+	fmt.Fprintf(w, "  taken by juju %s on machine %s at %s\n",
+		meta.Origin.Version, meta.Origin.Machine, meta.Started.UTC().Format("2006-01-02 15:04:05 MST"))
+	if meta.Notes != "" {
+		fmt.Fprintf(w, "  notes: %s\n", meta.Notes)
+	}
+	fmt.Fprintf(w, "\nDatabases replaced:\n")
+	for _, name := range plan.Databases {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+	fmt.Fprintf(w, "\nFiles restored:\n")
+	for _, name := range plan.Files {
+		fmt.Fprintf(w, "  /%s\n", strings.TrimPrefix(name, "/"))
+	}
+	fmt.Fprintf(w, "\nAgents stopped on controller machines: %s\n", strings.Join(plan.Agents, ", "))
+	if len(plan.RemovedMembers) > 0 {
+		fmt.Fprintf(w, "Controller machines removed from the replica set: %s\n", strings.Join(plan.RemovedMembers, ", "))
+	}
+}
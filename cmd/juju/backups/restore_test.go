@@ -0,0 +1,202 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+type restoreSuite struct {
+	testing.IsolationSuite
+
+	filename string
+	restorer *fakeRestorer
+	dataDir  string
+	closed   bool
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.filename = filepath.Join(c.MkDir(), "juju-backup-20210524-120011.tar.gz")
+	s.createArchive(c)
+
+	meta := statebackups.NewMetadata()
+	meta.SetID("20210524-120011.controller-uuid")
+	meta.Started = time.Date(2021, 5, 24, 12, 0, 11, 0, time.UTC)
+	meta.Origin.Version = version.MustParse("2.9.1")
+	meta.Origin.Machine = "0"
+	meta.Controller.UUID = "controller-uuid"
+	s.restorer = &fakeRestorer{
+		plan: &statebackups.RestorePlan{
+			Metadata:       meta,
+			Databases:      []string{"juju", "logs"},
+			Files:          []string{"var/lib/juju/server.pem"},
+			Agents:         []string{"2", "1", "0"},
+			RemovedMembers: []string{"2"},
+		},
+	}
+	s.dataDir = ""
+	s.closed = false
+}
+
+func (s *restoreSuite) createArchive(c *gc.C) {
+	archive, err := os.Create(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	compressed := gzip.NewWriter(archive)
+	defer compressed.Close()
+
+	tarball := tar.NewWriter(compressed)
+	defer tarball.Close()
+
+	body := "<metadata>"
+	err = tarball.WriteHeader(&tar.Header{
+		Name: "juju-backup/metadata.json",
+		Mode: 0600,
+		Size: int64(len(body)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tarball.Write([]byte(body))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) newCommand() cmd.Command {
+	return backups.NewRestoreCommandForTest(
+		func(archive *statebackups.ArchiveWorkspace, dataDir string) (backups.Restorer, func(), error) {
+			s.dataDir = dataDir
+			return s.restorer, func() { s.closed = true }, nil
+		},
+	)
+}
+
+const expectedPlan = `
+Backup 20210524-120011.controller-uuid of controller controller-uuid
+  taken by juju 2.9.1 on machine 0 at 2021-05-24 12:00:11 UTC
+
+Databases replaced:
+  juju
+  logs
+
+Files restored:
+  /var/lib/juju/server.pem
+
+Agents stopped on controller machines: 2, 1, 0
+Controller machines removed from the replica set: 2
+`
+
+func (s *restoreSuite) TestInitMissingFilename(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), nil)
+	c.Assert(err, gc.ErrorMatches, "missing filename")
+}
+
+func (s *restoreSuite) TestInitTooManyArgs(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{s.filename, "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *restoreSuite) TestDryRun(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--dry-run", "--data-dir", "/srv/juju", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expectedPlan[1:])
+	c.Check(s.restorer.calls, jc.DeepEquals, []string{"Plan"})
+	c.Check(s.dataDir, gc.Equals, "/srv/juju")
+	c.Check(s.closed, jc.IsTrue)
+}
+
+func (s *restoreSuite) TestRestore(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "-y", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expectedPlan[1:])
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Backup 20210524-120011.controller-uuid restored.\n")
+	c.Check(s.restorer.calls, jc.DeepEquals, []string{"Plan", "Restore"})
+}
+
+func (s *restoreSuite) TestRestoreConfirmed(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("y\n")
+	code := cmd.Main(s.newCommand(), ctx, []string{s.filename})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expectedPlan[1:]+"Continue [y/N]? ")
+	c.Check(s.restorer.calls, jc.DeepEquals, []string{"Plan", "Restore"})
+}
+
+func (s *restoreSuite) TestRestoreAborted(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("n\n")
+	code := cmd.Main(s.newCommand(), ctx, []string{s.filename})
+	c.Assert(code, gc.Equals, 1)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "ERROR restore-backup: aborted\n")
+	c.Check(s.restorer.calls, jc.DeepEquals, []string{"Plan"})
+}
+
+func (s *restoreSuite) TestPlanFails(c *gc.C) {
+	s.restorer.err = errors.NotSupportedf("restoring backup from juju 2.8.10 to controller running juju 2.9.1")
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "-y", s.filename)
+	c.Assert(err, gc.ErrorMatches, "restoring backup from juju 2.8.10 to controller running juju 2.9.1 not supported")
+	c.Check(s.restorer.calls, jc.DeepEquals, []string{"Plan"})
+}
+
+func (s *restoreSuite) TestRestoreFails(c *gc.C) {
+	s.restorer.restoreErr = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "-y", s.filename)
+	c.Assert(err, gc.ErrorMatches, "restoring backup: boom")
+	c.Check(s.restorer.calls, jc.DeepEquals, []string{"Plan", "Restore"})
+}
+
+func (s *restoreSuite) TestEncryptedArchive(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("<encrypted archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.newCommand(), "-y", s.filename)
+	c.Assert(err, gc.ErrorMatches, `backup archive ".*" is encrypted: decrypt it first, with gpg --decrypt`)
+	c.Check(s.restorer.calls, gc.HasLen, 0)
+}
+
+func (s *restoreSuite) TestMissingArchive(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), filepath.Join(c.MkDir(), "missing.tar.gz"))
+	c.Assert(err, gc.ErrorMatches, "opening backup archive: .* no such file or directory")
+	c.Check(s.restorer.calls, gc.HasLen, 0)
+}
+
+type fakeRestorer struct {
+	plan       *statebackups.RestorePlan
+	err        error
+	restoreErr error
+	calls      []string
+}
+
+func (r *fakeRestorer) Plan() (*statebackups.RestorePlan, error) {
+	r.calls = append(r.calls, "Plan")
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.plan, nil
+}
+
+func (r *fakeRestorer) Restore() (*statebackups.RestorePlan, error) {
+	r.calls = append(r.calls, "Restore")
+	if r.restoreErr != nil {
+		return nil, r.restoreErr
+	}
+	return r.plan, nil
+}
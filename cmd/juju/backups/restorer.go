@@ -0,0 +1,182 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"net"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/replicaset"
+	"github.com/juju/utils/v2/ssh"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/state/backups"
+)
+
+// newControllerRestorer returns a Restorer which restores the backup in
+// the archive to the controller machine with the given data directory,
+// and a function which releases its resources.
+func newControllerRestorer(archive *backups.ArchiveWorkspace, dataDir string) (Restorer, func(), error) {
+	config, err := readMachineAgentConfig(dataDir)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	info, ok := config.MongoInfo()
+	if !ok {
+		return nil, nil, errors.Errorf("machine %s is not a controller", config.Tag().Id())
+	}
+	session, err := mongo.DialWithInfo(*info, mongo.DefaultDialOpts())
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "connecting to database")
+	}
+	restorer, err := newRestorerWithSession(archive, config, info, session)
+	if err != nil {
+		session.Close()
+		return nil, nil, errors.Trace(err)
+	}
+	return restorer, session.Close, nil
+}
+
+func newRestorerWithSession(
+	archive *backups.ArchiveWorkspace, config agent.Config, info *mongo.MongoInfo, session *mgo.Session,
+) (Restorer, error) {
+	status, err := replicaset.IsMaster(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting replica set status")
+	}
+	if !status.IsMaster {
+		return nil, errors.Errorf("restore-backup must be run on the primary controller machine (%s)", status.PrimaryAddress)
+	}
+
+	dbInfo, err := backups.NewDBInfo(info, session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	database, err := backups.NewRestoreDatabase(dbInfo, session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	members, err := database.ReplicaSetMembers()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting replica set members")
+	}
+
+	// The local agent is stopped last and started first.
+	local := config.Tag().Id()
+	identity := filepath.Join(config.DataDir(), agent.SystemIdentity)
+	var nodes []backups.ControllerNode
+	for _, member := range members {
+		id := member.Tags["juju-machine-id"]
+		if id == local {
+			continue
+		}
+		host, _, err := net.SplitHostPort(member.Address)
+		if err != nil {
+			return nil, errors.Annotatef(err, "replica set member %q", member.Address)
+		}
+		nodes = append(nodes, &remoteNode{id: id, host: host, identity: identity})
+	}
+	nodes = append(nodes, &localNode{id: local})
+
+	return backups.NewRestorer(backups.RestoreConfig{
+		Archive:           archive,
+		ControllerUUID:    config.Controller().Id(),
+		ControllerVersion: config.UpgradedToVersion(),
+		Database:          database,
+		Nodes:             nodes,
+		RootDir:           "/",
+	})
+}
+
+// readMachineAgentConfig reads the configuration of
+// the machine agent with the given data directory.
+func readMachineAgentConfig(dataDir string) (agent.ConfigSetterWriter, error) {
+	agentDirs, err := filepath.Glob(filepath.Join(dataDir, "agents", "machine-*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(agentDirs) != 1 {
+		return nil, errors.NotFoundf("machine agent in %q", dataDir)
+	}
+	tag, err := names.ParseMachineTag(filepath.Base(agentDirs[0]))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, err := agent.ReadConfig(agent.ConfigPath(dataDir, tag))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading machine agent config")
+	}
+	return config, nil
+}
+
+func agentServiceName(id string) string {
+	return "jujud-" + names.NewMachineTag(id).String()
+}
+
+// localNode controls the machine agent running alongside the command.
+type localNode struct {
+	id string
+}
+
+// ID is part of the backups.ControllerNode interface.
+func (n *localNode) ID() string {
+	return n.id
+}
+
+// StopAgent is part of the backups.ControllerNode interface.
+func (n *localNode) StopAgent() error {
+	svc, err := service.DiscoverService(agentServiceName(n.id), common.Conf{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(svc.Stop())
+}
+
+// StartAgent is part of the backups.ControllerNode interface.
+func (n *localNode) StartAgent() error {
+	svc, err := service.DiscoverService(agentServiceName(n.id), common.Conf{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(svc.Start())
+}
+
+// remoteNode controls the machine agent on another controller
+// machine over ssh, using the controller's system identity.
+type remoteNode struct {
+	id       string
+	host     string
+	identity string
+}
+
+// ID is part of the backups.ControllerNode interface.
+func (n *remoteNode) ID() string {
+	return n.id
+}
+
+// StopAgent is part of the backups.ControllerNode interface.
+func (n *remoteNode) StopAgent() error {
+	return errors.Trace(n.systemctl("stop"))
+}
+
+// StartAgent is part of the backups.ControllerNode interface.
+func (n *remoteNode) StartAgent() error {
+	return errors.Trace(n.systemctl("start"))
+}
+
+func (n *remoteNode) systemctl(action string) error {
+	var options ssh.Options
+	options.SetIdentities(n.identity)
+	command := ssh.Command("ubuntu@"+n.host, []string{"sudo", "systemctl", action, agentServiceName(n.id)}, &options)
+	if output, err := command.CombinedOutput(); err != nil {
+		return errors.Annotatef(err, "running systemctl %s on %s: %s", action, n.host, output)
+	}
+	return nil
+}
@@ -580,7 +580,7 @@ func (c *AddCAASCommand) Run(ctx *cmd.Context) (err error) {
 		if err := addCloudToLocal(c.cloudMetadataStore, newCloud); err != nil {
 			return errors.Trace(err)
 		}
-		if err := c.addCredentialToLocal(c.credentialStoreAPI, cloudName, newcredential, credentialName); err != nil {
+		if err := addCredentialToLocal(c.credentialStoreAPI, cloudName, newcredential, credentialName); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -611,7 +611,7 @@ func (c *AddCAASCommand) Run(ctx *cmd.Context) (err error) {
 		if err := addCloudToController(cloudClient, newCloud); err != nil {
 			return errors.Trace(err)
 		}
-		if err := c.addCredentialToController(cloudClient, newcredential, cloudName, credentialName); err != nil {
+		if err := addCredentialToController(c.Store, c.ControllerName, cloudClient, newcredential, cloudName, credentialName); err != nil {
 			return errors.Trace(err)
 		}
 		if !msgDisplayed {
@@ -873,7 +873,7 @@ func addCloudToController(apiClient AddCloudAPI, newCloud jujucloud.Cloud) error
 	return nil
 }
 
-func (c *AddCAASCommand) addCredentialToLocal(store CredentialStoreAPI, cloudName string, newCredential jujucloud.Credential, credentialName string) error {
+func addCredentialToLocal(store CredentialStoreAPI, cloudName string, newCredential jujucloud.Credential, credentialName string) error {
 	newCredentials := &jujucloud.CloudCredential{
 		AuthCredentials: make(map[string]jujucloud.Credential),
 	}
@@ -885,13 +885,16 @@ func (c *AddCAASCommand) addCredentialToLocal(store CredentialStoreAPI, cloudNam
 	return nil
 }
 
-func (c *AddCAASCommand) addCredentialToController(apiClient AddCloudAPI, newCredential jujucloud.Credential, cloudName, credentialName string) error {
-	_, err := c.Store.ControllerByName(c.ControllerName)
+func addCredentialToController(
+	store jujuclient.ClientStore, controllerName string,
+	apiClient AddCloudAPI, newCredential jujucloud.Credential, cloudName, credentialName string,
+) error {
+	_, err := store.ControllerByName(controllerName)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	currentAccountDetails, err := c.Store.AccountDetails(c.ControllerName)
+	currentAccountDetails, err := store.AccountDetails(controllerName)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -0,0 +1,243 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	cloudapi "github.com/juju/juju/api/cloud"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/ecs"
+	jujucloud "github.com/juju/juju/cloud"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// CloudCredentialChecker checks that a cloud's credential works.
+type CloudCredentialChecker interface {
+	CheckCloudCredentials() error
+}
+
+// ECSBrokerGetter returns a broker for checking the ECS cluster.
+type ECSBrokerGetter func(cloud jujucloud.Cloud, credential jujucloud.Credential) (CloudCredentialChecker, error)
+
+var usageAddECSSummary = `
+Adds an Amazon ECS cluster and credential to Juju.`[1:]
+
+var usageAddECSDetails = `
+Creates a user-defined cloud based on an Amazon Elastic Container Service
+(ECS) cluster.
+
+The new ECS cloud can then be added to an existing controller, where
+models hosted on the cluster can be created.
+
+Use --controller option to add ECS cloud to a controller.
+Use --client option to add ECS cloud to this client.
+
+The cluster must already exist in the specified region. The AWS access key
+and secret key are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+environment variables unless --access-key and --secret-key are used.
+
+Before the cloud is added, Juju checks the cluster can be accessed with the
+credential and is active.
+
+Examples:
+    juju add-ecs myecscloud --cluster-name mycluster --region ap-southeast-2
+    juju add-ecs myecscloud --cluster-name mycluster --region ap-southeast-2 --client
+    juju add-ecs myecscloud --cluster-name mycluster --region ap-southeast-2 --controller mycontroller
+    juju add-ecs myecscloud --cluster-name mycluster --region us-east-1 --access-key <key> --secret-key <secret>
+
+See also:
+    add-k8s
+    remove-cloud
+`
+
+// AddECSCommand is the command that allows you to add an ECS cluster and credential.
+type AddECSCommand struct {
+	modelcmd.OptionalControllerCommand
+
+	// These attributes are used when adding a cluster to a controller.
+	addCloudAPIFunc func() (AddCloudAPI, error)
+
+	// cloudName is the name of the cloud to add.
+	cloudName string
+
+	// clusterName is the name of the ECS cluster.
+	clusterName string
+
+	// region is the AWS region the cluster runs in.
+	region string
+
+	accessKey string
+	secretKey string
+
+	// brokerGetter returns a broker for checking the cluster.
+	brokerGetter ECSBrokerGetter
+
+	cloudMetadataStore CloudMetadataStore
+	credentialStoreAPI CredentialStoreAPI
+}
+
+// NewAddECSCommand returns a command to add an ECS cluster.
+func NewAddECSCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
+	store := jujuclient.NewFileClientStore()
+	command := &AddECSCommand{
+		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
+			Store: store,
+		},
+		cloudMetadataStore: cloudMetadataStore,
+		credentialStoreAPI: store,
+	}
+	command.addCloudAPIFunc = func() (AddCloudAPI, error) {
+		root, err := command.NewAPIRoot(command.Store, command.ControllerName, "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return cloudapi.NewClient(root), nil
+	}
+	command.brokerGetter = command.newECSClusterBroker
+	return modelcmd.WrapBase(command)
+}
+
+// Info returns help information about the command.
+func (c *AddECSCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add-ecs",
+		Args:    "<ECS cloud name>",
+		Purpose: usageAddECSSummary,
+		Doc:     usageAddECSDetails,
+	})
+}
+
+// SetFlags initializes the flags supported by the command.
+func (c *AddECSCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.OptionalControllerCommand.SetFlags(f)
+	f.StringVar(&c.clusterName, "cluster-name", "", "the name of the ECS cluster")
+	f.StringVar(&c.region, "region", "", "the AWS region the cluster runs in")
+	f.StringVar(&c.accessKey, "access-key", "", "the AWS access key (defaults to $AWS_ACCESS_KEY_ID)")
+	f.StringVar(&c.secretKey, "secret-key", "", "the AWS secret key (defaults to $AWS_SECRET_ACCESS_KEY)")
+}
+
+// Init populates the command with the args from the command line.
+func (c *AddECSCommand) Init(args []string) error {
+	if err := c.OptionalControllerCommand.Init(args); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.Errorf("missing ECS cloud name.")
+	}
+	c.cloudName = args[0]
+	if c.clusterName == "" {
+		return errors.New("cluster name must be specified using --cluster-name")
+	}
+	if c.region == "" {
+		return errors.New("region must be specified using --region")
+	}
+	if c.accessKey == "" {
+		c.accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if c.secretKey == "" {
+		c.secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if c.accessKey == "" || c.secretKey == "" {
+		return errors.New("AWS access key and secret key must be specified using --access-key and --secret-key or $AWS_ACCESS_KEY_ID and $AWS_SECRET_ACCESS_KEY")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is defined on the Command interface.
+func (c *AddECSCommand) Run(ctx *cmd.Context) error {
+	public, _, err := c.cloudMetadataStore.PublicCloudMetadata()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	msg, err := nameExists(c.cloudName, public)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if msg != "" {
+		return errors.Errorf(msg)
+	}
+
+	newCloud, newCredential, err := ecs.CloudFromParams(ecs.CloudParams{
+		CloudName:   c.cloudName,
+		ClusterName: c.clusterName,
+		Region:      c.region,
+		AccessKey:   c.accessKey,
+		SecretKey:   c.secretKey,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.MaybePrompt(ctx, fmt.Sprintf("add ECS cloud %v to", c.cloudName)); err != nil {
+		return errors.Trace(err)
+	}
+
+	broker, err := c.brokerGetter(newCloud, newCredential)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := broker.CheckCloudCredentials(); err != nil {
+		return errors.Annotatef(err, "checking ECS cluster %q in region %q", c.clusterName, c.region)
+	}
+
+	cloudName := c.cloudName
+	credentialName := c.cloudName
+	successMsg := fmt.Sprintf("ECS cluster %q added as cloud %q", c.clusterName, cloudName)
+	if c.Client {
+		if err := addCloudToLocal(c.cloudMetadataStore, newCloud); err != nil {
+			return errors.Trace(err)
+		}
+		if err := addCredentialToLocal(c.credentialStoreAPI, cloudName, newCredential, credentialName); err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintln(ctx.Stdout, successMsg)
+	}
+	if c.ControllerName != "" {
+		if err := jujuclient.ValidateControllerName(c.ControllerName); err != nil {
+			return errors.Trace(err)
+		}
+		cloudClient, err := c.addCloudAPIFunc()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer cloudClient.Close()
+
+		if err := addCloudToController(cloudClient, newCloud); err != nil {
+			return errors.Trace(err)
+		}
+		if err := addCredentialToController(c.Store, c.ControllerName, cloudClient, newCredential, cloudName, credentialName); err != nil {
+			return errors.Trace(err)
+		}
+		if !c.Client {
+			fmt.Fprintln(ctx.Stdout, successMsg)
+		}
+	}
+	return nil
+}
+
+func (c *AddECSCommand) newECSClusterBroker(cloud jujucloud.Cloud, credential jujucloud.Credential) (CloudCredentialChecker, error) {
+	var controllerUUID string
+	if c.ControllerName != "" {
+		var err error
+		if controllerUUID, err = c.ControllerUUID(c.Store, c.ControllerName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	openParams, err := ecs.BaseCloudOpenParams(cloud, credential, controllerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	broker, err := caas.New(openParams)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return broker, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/caas"
+	"github.com/juju/juju/cmd/juju/caas/mocks"
+)
+
+type addECSSuite struct {
+	jujutesting.IsolationSuite
+
+	fakeCloudAPI       *fakeAddCloudAPI
+	cloudMetadataStore *fakeCloudMetadataStore
+	credentialStoreAPI *mocks.MockCredentialStoreAPI
+	personalClouds     map[string]cloud.Cloud
+	checkErr           error
+	checkedCloud       cloud.Cloud
+	checkedCredential  cloud.Credential
+}
+
+var _ = gc.Suite(&addECSSuite{})
+
+func (s *addECSSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchEnvironment("AWS_ACCESS_KEY_ID", "")
+	s.PatchEnvironment("AWS_SECRET_ACCESS_KEY", "")
+
+	var logger loggo.Logger
+	s.fakeCloudAPI = &fakeAddCloudAPI{CallMocker: jujutesting.NewCallMocker(logger)}
+	s.cloudMetadataStore = &fakeCloudMetadataStore{CallMocker: jujutesting.NewCallMocker(logger)}
+	s.personalClouds = map[string]cloud.Cloud{
+		"mrcloud1": {Name: "mrcloud1", Type: "kubernetes"},
+	}
+	s.cloudMetadataStore.Call("PublicCloudMetadata", []string(nil)).Returns(map[string]cloud.Cloud{
+		"aws": {Name: "aws", Type: "ec2"},
+	}, false, nil)
+	s.cloudMetadataStore.Call("PersonalCloudMetadata").Returns(s.personalClouds, nil)
+	s.cloudMetadataStore.Call("WritePersonalCloudMetadata", s.personalClouds).Returns(nil)
+	s.checkErr = nil
+}
+
+func (s *addECSSuite) CheckCloudCredentials() error {
+	return s.checkErr
+}
+
+func (s *addECSSuite) makeCommand() cmd.Command {
+	return caas.NewAddECSCommandForTest(
+		s.cloudMetadataStore,
+		s.credentialStoreAPI,
+		NewMockClientStore(),
+		func() (caas.AddCloudAPI, error) {
+			return s.fakeCloudAPI, nil
+		},
+		func(cloud cloud.Cloud, credential cloud.Credential) (caas.CloudCredentialChecker, error) {
+			s.checkedCloud = cloud
+			s.checkedCredential = credential
+			return s, nil
+		},
+	)
+}
+
+func (s *addECSSuite) expectedCloud() cloud.Cloud {
+	return cloud.Cloud{
+		Name:        "myecs",
+		Type:        "ecs",
+		Description: "Amazon Elastic Container Service",
+		AuthTypes:   cloud.AuthTypes{cloud.AccessKeyAuthType},
+		Endpoint:    "https://ecs.ap-southeast-2.amazonaws.com",
+		Regions: []cloud.Region{{
+			Name:     "ap-southeast-2",
+			Endpoint: "https://ecs.ap-southeast-2.amazonaws.com",
+		}},
+	}
+}
+
+func (s *addECSSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--client"},
+		err:  `missing ECS cloud name.`,
+	}, {
+		args: []string{"myecs", "--client", "--region", "ap-southeast-2"},
+		err:  `cluster name must be specified using --cluster-name`,
+	}, {
+		args: []string{"myecs", "--client", "--cluster-name", "mycluster"},
+		err:  `region must be specified using --region`,
+	}, {
+		args: []string{"myecs", "--client", "--cluster-name", "mycluster", "--region", "ap-southeast-2"},
+		err:  `AWS access key and secret key must be specified .*`,
+	}, {
+		args: []string{
+			"myecs", "extra", "--client", "--cluster-name", "mycluster", "--region", "ap-southeast-2",
+			"--access-key", "access-key", "--secret-key", "secret-key",
+		},
+		err: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(s.makeCommand(), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *addECSSuite) TestAddClient(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.credentialStoreAPI = mocks.NewMockCredentialStoreAPI(ctrl)
+
+	expectedCredential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"cluster-name": "mycluster",
+		"region":       "ap-southeast-2",
+		"access-key":   "access-key",
+		"secret-key":   "secret-key",
+	})
+	s.credentialStoreAPI.EXPECT().UpdateCredential("myecs", cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{"myecs": expectedCredential},
+	}).Return(nil)
+
+	s.PatchEnvironment("AWS_ACCESS_KEY_ID", "access-key")
+	s.PatchEnvironment("AWS_SECRET_ACCESS_KEY", "secret-key")
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(),
+		"myecs", "--client", "--cluster-name", "mycluster", "--region", "ap-southeast-2",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `ECS cluster "mycluster" added as cloud "myecs"`+"\n")
+
+	c.Assert(s.checkedCloud, jc.DeepEquals, s.expectedCloud())
+	c.Assert(s.checkedCredential, jc.DeepEquals, expectedCredential)
+	c.Assert(s.personalClouds["myecs"], jc.DeepEquals, s.expectedCloud())
+	s.fakeCloudAPI.CheckNoCalls(c)
+}
+
+func (s *addECSSuite) TestAddController(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(),
+		"myecs", "--controller", "foo", "--cluster-name", "mycluster", "--region", "ap-southeast-2",
+		"--access-key", "access-key", "--secret-key", "secret-key",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `ECS cluster "mycluster" added as cloud "myecs"`+"\n")
+
+	s.fakeCloudAPI.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "AddCloud", Args: []interface{}{s.expectedCloud(), false}},
+	})
+	_, ok := s.personalClouds["myecs"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *addECSSuite) TestAddNameClash(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(),
+		"aws", "--client", "--cluster-name", "mycluster", "--region", "ap-southeast-2",
+		"--access-key", "access-key", "--secret-key", "secret-key",
+	)
+	c.Assert(err, gc.ErrorMatches, `"aws" is the name of a public cloud`)
+}
+
+func (s *addECSSuite) TestAddClusterCheckFails(c *gc.C) {
+	s.checkErr = errors.NotFoundf(`cluster "mycluster"`)
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(),
+		"myecs", "--client", "--cluster-name", "mycluster", "--region", "ap-southeast-2",
+		"--access-key", "access-key", "--secret-key", "secret-key",
+	)
+	c.Assert(err, gc.ErrorMatches, `checking ECS cluster "mycluster" in region "ap-southeast-2": cluster "mycluster" not found`)
+	_, ok := s.personalClouds["myecs"]
+	c.Assert(ok, jc.IsFalse)
+}
@@ -43,6 +43,22 @@ func NewAddCAASCommandForTest(
 	return command
 }
 
+func NewAddECSCommandForTest(
+	cloudMetadataStore CloudMetadataStore,
+	credentialStoreAPI CredentialStoreAPI,
+	store jujuclient.ClientStore,
+	addCloudAPIFunc func() (AddCloudAPI, error),
+	brokerGetter ECSBrokerGetter,
+) cmd.Command {
+	return &AddECSCommand{
+		OptionalControllerCommand: modelcmd.OptionalControllerCommand{Store: store},
+		cloudMetadataStore:        cloudMetadataStore,
+		credentialStoreAPI:        credentialStoreAPI,
+		addCloudAPIFunc:           addCloudAPIFunc,
+		brokerGetter:              brokerGetter,
+	}
+}
+
 func NewUpdateCAASCommandForTest(
 	cloudMetadataStore CloudMetadataStore,
 	store jujuclient.ClientStore,
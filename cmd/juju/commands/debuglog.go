@@ -4,12 +4,16 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/juju/ansiterm"
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -18,6 +22,7 @@ import (
 	"github.com/juju/loggo/loggocolor"
 	"github.com/juju/names/v4"
 	"github.com/mattn/go-isatty"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api/common"
 	jujucmd "github.com/juju/juju/cmd"
@@ -57,9 +62,36 @@ The filtering options combine as follows:
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
+* All --include-label options are logically ORed together.
+* All --exclude-label options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-label, --exclude-label, --since, --until and --message-regex
   selections are logically ANDed to form the complete filter.
 
+The '--since' and '--until' options limit the messages shown to those logged
+in a time window. Each takes either a timestamp in RFC3339 format, such as
+2021-06-01T10:00:00Z, or a duration, such as 90m, meaning that long ago. Once
+the '--until' time has passed no further messages are shown, and the command
+exits.
+
+The '--message-regex' option only shows messages matching the given regular
+expression. The expression is evaluated by the controller, so only matching
+messages are sent to the client. It is checked using Go's RE2 syntax but run
+by the controller's database, which uses PCRE, so only syntax common to both
+should be used. Lookarounds and backreferences are rejected.
+
+The '--include-label' and '--exclude-label' options filter on labels which
+agents attach to some messages. When tracing is enabled, messages logged
+while handling API requests, running transactions, starting machines and
+running hooks are labelled with trace-id=<id> and span-id=<id>, so that
+they can be matched to the exported traces.
+
+The '--format' option controls how each message is written. The default
+"text" format is described above. The "json" format writes each message as a
+JSON object on a line of its own, and the "yaml" format writes each message as
+a separate YAML document, so that the output can be processed by other tools
+as it is streamed.
+
 Examples:
 
 Exclude all machine 0 messages; show a maximum of 100 lines; and continue to
@@ -101,6 +133,20 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages logged in the last two hours that mention "hook failed",
+as JSON, and then exit:
+
+    juju debug-log --since 2h --no-tail --message-regex "hook failed" \
+        --format json
+
+Show all messages logged as part of a trace:
+
+    juju debug-log --replay --include-label trace-id=4bf92f3577b34da6a3ce929d0e0e4736
+
+Show all messages logged between two times:
+
+    juju debug-log --since 2021-06-01T10:00:00Z --until 2021-06-01T11:00:00Z
+
 See also:
     status
     ssh`
@@ -118,7 +164,7 @@ func newDebugLogCommand(store jujuclient.ClientStore) cmd.Command {
 }
 
 func newDebugLogCommandTZ(store jujuclient.ClientStore, tz *time.Location) cmd.Command {
-	cmd := &debugLogCommand{tz: tz}
+	cmd := &debugLogCommand{tz: tz, clock: clock.WallClock}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
@@ -138,10 +184,22 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
-	format string
-	tz     *time.Location
+	since        string
+	until        string
+	messageRegex string
+	format       string
+
+	tsFormat string
+	tz       *time.Location
+	clock    clock.Clock
 }
 
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeEntity), "i", "Only show log messages for these entities")
@@ -150,6 +208,11 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabel), "include-label", "Only show log messages with these labels")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLabel), "exclude-label", "Do not show log messages with these labels")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time (RFC3339 timestamp or duration ago)")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time (RFC3339 timestamp or duration ago)")
+	f.StringVar(&c.messageRegex, "message-regex", "", "Only show log messages matching this regular expression")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -167,6 +230,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
+	f.StringVar(&c.format, "format", formatText, "Output format, one of [text, json, yaml]")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -185,12 +249,44 @@ func (c *debugLogCommand) Init(args []string) error {
 		c.tz = time.UTC
 	}
 	if c.date {
-		c.format = "2006-01-02 15:04:05"
+		c.tsFormat = "2006-01-02 15:04:05"
 	} else {
-		c.format = "15:04:05"
+		c.tsFormat = "15:04:05"
 	}
 	if c.ms {
-		c.format = c.format + ".000"
+		c.tsFormat = c.tsFormat + ".000"
+	}
+	switch c.format {
+	case formatText, formatJSON, formatYAML:
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q, %q",
+			c.format, formatText, formatJSON, formatYAML)
+	}
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	if c.since != "" {
+		t, err := c.parseTime(c.since)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = t
+	}
+	if c.until != "" {
+		t, err := c.parseTime(c.until)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.params.EndTime = t
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() && c.params.EndTime.Before(c.params.StartTime) {
+		return errors.NotValidf("--until time before --since time")
+	}
+	if c.messageRegex != "" {
+		if _, err := regexp.Compile(c.messageRegex); err != nil {
+			return errors.Annotate(err, "invalid --message-regex value")
+		}
+		c.params.MessageRegex = c.messageRegex
 	}
 	modelType, err := c.ModelType()
 	if err != nil {
@@ -202,6 +298,19 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseTime parses value as either an RFC3339 timestamp, or
+// a duration which is subtracted from the current time.
+func (c *debugLogCommand) parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%q is neither an RFC3339 timestamp nor a positive duration", value)
+	}
+	return c.clock.Now().Add(-d), nil
+}
+
 func (c *debugLogCommand) processEntities(isCAAS bool, entities []string) []string {
 	if entities == nil {
 		return nil
@@ -285,7 +394,17 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 		if !ok {
 			break
 		}
-		c.writeLogRecord(writer, msg)
+		switch c.format {
+		case formatJSON:
+			err = c.writeJSONLogRecord(ctx.Stdout, msg)
+		case formatYAML:
+			err = c.writeYAMLLogRecord(ctx.Stdout, msg)
+		default:
+			c.writeLogRecord(writer, msg)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	return nil
@@ -304,7 +423,7 @@ var SeverityColor = map[string]*ansiterm.Context{
 }
 
 func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage) {
-	ts := r.Timestamp.In(c.tz).Format(c.format)
+	ts := r.Timestamp.In(c.tz).Format(c.tsFormat)
 	fmt.Fprintf(w, "%s: %s ", r.Entity, ts)
 	SeverityColor[r.Severity].Fprintf(w, r.Severity)
 	fmt.Fprintf(w, " %s ", r.Module)
@@ -313,3 +432,44 @@ func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage
 	}
 	fmt.Fprintln(w, r.Message)
 }
+
+// logRecord is the structured form of a log message
+// written when the json or yaml formats are requested.
+type logRecord struct {
+	ModelUUID string   `json:"model-uuid,omitempty" yaml:"model-uuid,omitempty"`
+	Entity    string   `json:"entity" yaml:"entity"`
+	Timestamp string   `json:"timestamp" yaml:"timestamp"`
+	Level     string   `json:"level" yaml:"level"`
+	Module    string   `json:"module" yaml:"module"`
+	Location  string   `json:"location" yaml:"location"`
+	Message   string   `json:"message" yaml:"message"`
+	Labels    []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+func (c *debugLogCommand) toLogRecord(r common.LogMessage) logRecord {
+	return logRecord{
+		ModelUUID: r.ModelUUID,
+		Entity:    r.Entity,
+		Timestamp: r.Timestamp.In(c.tz).Format(time.RFC3339Nano),
+		Level:     r.Severity,
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+		Labels:    r.Labels,
+	}
+}
+
+func (c *debugLogCommand) writeJSONLogRecord(w io.Writer, r common.LogMessage) error {
+	return errors.Trace(json.NewEncoder(w).Encode(c.toLogRecord(r)))
+}
+
+func (c *debugLogCommand) writeYAMLLogRecord(w io.Writer, r common.LogMessage) error {
+	data, err := yaml.Marshal(c.toLogRecord(r))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
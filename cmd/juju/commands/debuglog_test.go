@@ -6,6 +6,7 @@ package commands
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -104,6 +105,41 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--include-label", "http", "--include-label", "charmhub", "--exclude-label", "metrics"},
+			expected: common.DebugLogParams{
+				IncludeLabel: []string{"http", "charmhub"},
+				ExcludeLabel: []string{"metrics"},
+				Backlog:      10,
+			},
+		}, {
+			args: []string{"--since", "2021-06-01T10:00:00Z", "--until", "2021-06-01T11:00:00Z"},
+			expected: common.DebugLogParams{
+				StartTime: time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC),
+				Backlog:   10,
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is neither an RFC3339 timestamp nor a positive duration`,
+		}, {
+			args:     []string{"--until", "-5m"},
+			errMatch: `invalid --until value: "-5m" is neither an RFC3339 timestamp nor a positive duration`,
+		}, {
+			args:     []string{"--since", "2021-06-01T11:00:00Z", "--until", "2021-06-01T10:00:00Z"},
+			errMatch: `--until time before --since time not valid`,
+		}, {
+			args: []string{"--message-regex", "hook (failed|error)"},
+			expected: common.DebugLogParams{
+				MessageRegex: "hook (failed|error)",
+				Backlog:      10,
+			},
+		}, {
+			args:     []string{"--message-regex", "hook ("},
+			errMatch: `invalid --message-regex value: .*`,
+		}, {
+			args:     []string{"--format", "xml"},
+			errMatch: `format value "xml" is not one of "text", "json", "yaml"`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -119,6 +155,16 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestRelativeTimes(c *gc.C) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	command := &debugLogCommand{clock: testclock.NewClock(now)}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--since", "2h", "--until", "30m"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.params.StartTime, gc.Equals, now.Add(-2*time.Hour))
+	c.Assert(command.params.EndTime, gc.Equals, now.Add(-30*time.Minute))
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -154,6 +200,8 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 				Module:    "test.module",
 				Location:  "somefile.go:123",
 				Message:   "this is the log output",
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Labels:    []string{"http"},
 			},
 		}}, nil
 	})
@@ -182,6 +230,23 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	checkOutput(
 		"--location",
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
+	checkOutput(
+		"--format", "json",
+		`{"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","entity":"machine-0","timestamp":"2016-10-09T14:15:23.345+06:00",`+
+			`"level":"INFO","module":"test.module","location":"somefile.go:123","message":"this is the log output","labels":["http"]}`+"\n")
+	checkOutput(
+		"--format", "yaml", "--utc",
+		`---
+model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+entity: machine-0
+timestamp: "2016-10-09T08:15:23.345Z"
+level: INFO
+module: test.module
+location: somefile.go:123
+message: this is the log output
+labels:
+- http
+`)
 }
 
 type fakeDebugLogAPI struct {
@@ -126,6 +126,11 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-grant",
+	"secret-revoke",
+	"secret-update",
 	"state-delete",
 	"state-get",
 	"state-set",
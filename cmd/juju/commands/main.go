@@ -36,6 +36,7 @@ import (
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -321,6 +322,10 @@ func registerCommands(r commandRegistry) {
 	r.Register(firewall.NewSetFirewallRuleCommand())
 	r.Register(firewall.NewListFirewallRulesCommand())
 
+	// Secrets commands.
+	r.Register(secrets.NewListSecretsCommand())
+	r.Register(secrets.NewSecretBackendCommand())
+
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
 	r.Register(application.NewRemoveApplicationCommand())
@@ -364,6 +369,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(backups.NewListCommand())
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewRestoreCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -393,6 +399,8 @@ func registerCommands(r commandRegistry) {
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
 	r.Register(machine.NewUpgradeSeriesCommand())
+	r.Register(machine.NewEvacuateLXDMemberCommand())
+	r.Register(machine.NewMoveMachineCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -430,6 +438,12 @@ func registerCommands(r commandRegistry) {
 	r.Register(action.NewListOperationsCommand())
 	r.Register(action.NewShowOperationCommand())
 	r.Register(action.NewShowTaskCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewShowScheduleCommand())
+	r.Register(action.NewPauseScheduleCommand())
+	r.Register(action.NewResumeScheduleCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -463,6 +477,9 @@ func registerCommands(r commandRegistry) {
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewSnapshotStorageCommand())
+	r.Register(storage.NewListSnapshotsCommand())
+	r.Register(storage.NewResizeStorageCommand())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -488,6 +505,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -515,6 +533,7 @@ func registerCommands(r commandRegistry) {
 
 	// CAAS commands
 	r.Register(caas.NewAddCAASCommand(&cloudToCommandAdapter{}))
+	r.Register(caas.NewAddECSCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewUpdateCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
@@ -288,10 +288,12 @@ var commandNames = []string{
 	"actions",
 	"add-cloud",
 	"add-credential",
+	"add-ecs",
 	"add-k8s",
 	"add-machine",
 	"add-model",
 	"add-relation",
+	"add-schedule",
 	"add-space",
 	"add-ssh-key",
 	"add-storage",
@@ -303,6 +305,7 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bind",
@@ -344,6 +347,7 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"evacuate-lxd-member",
 	"exec",
 	"export-bundle",
 	"expose",
@@ -380,6 +384,9 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-schedules",
+	"list-secrets",
+	"list-snapshots",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -395,11 +402,14 @@ var commandNames = []string{
 	"model-config",
 	"model-default",
 	"model-defaults",
+	"model-secret-backend",
 	"models",
+	"move-machine",
 	"move-to-space",
 	"offer",
 	"offers",
 	"operations",
+	"pause-schedule",
 	"payloads",
 	"plans",
 	"refresh",
@@ -418,6 +428,7 @@ var commandNames = []string{
 	"remove-offer",
 	"remove-relation",
 	"remove-saas",
+	"remove-schedule",
 	"remove-space",
 	"remove-ssh-key",
 	"remove-storage",
@@ -425,16 +436,21 @@ var commandNames = []string{
 	"remove-unit",
 	"remove-user",
 	"rename-space",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
+	"restore-backup",
 	"resume-relation",
+	"resume-schedule",
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
 	"run",
 	"scale-application",
+	"schedules",
 	"scp",
+	"secrets",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
@@ -456,6 +472,7 @@ var commandNames = []string{
 	"show-model",
 	"show-offer",
 	"show-operation",
+	"show-schedule",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -465,6 +482,8 @@ var commandNames = []string{
 	"show-user",
 	"show-wallet",
 	"sla",
+	"snapshot-storage",
+	"snapshots",
 	"spaces",
 	"ssh",
 	"ssh-keys",
@@ -0,0 +1,273 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var auditLogHelpSummary = `
+Shows the API requests recorded in the controller's audit log.`[1:]
+
+var auditLogHelpDetails = `
+The controller records the API requests which change the state of its
+models in an audit log shared by all of its machines. This command
+queries that log, showing the most recent requests first.
+
+Requests may be filtered by the user who made them, the model they were
+made against, the API method called, the time they were made and whether
+or not they succeeded. Methods are specified as either "Facade" or
+"Facade.Method", for example "Application.Deploy".
+
+The '--since' and '--until' options accept either an RFC3339 timestamp
+or a duration, which is taken as that long before the current time.
+
+Only controller superusers may view the audit log. Entries older than
+the controller's audit-log-max-age setting are removed.
+
+Examples:
+    juju audit-log
+    juju audit-log --user fred --since 24h
+    juju audit-log --model prod --method Application.RemoveApplication
+    juju audit-log --outcome failure --limit 20 --format yaml
+
+See also:
+    controller-config
+`
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Query(params.AuditLogQueryArgs) ([]params.AuditLogEntry, error)
+	Close() error
+}
+
+// NewAuditLogCommand returns a command that shows the contents
+// of the controller's audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{
+		clock: clock.WallClock,
+	})
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out   cmd.Output
+	api   AuditLogAPI
+	clock clock.Clock
+
+	user    string
+	model   string
+	method  string
+	since   string
+	until   string
+	outcome string
+	limit   int
+
+	args params.AuditLogQueryArgs
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-log",
+		Purpose: auditLogHelpSummary,
+		Doc:     auditLogHelpDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show requests made against this model")
+	f.StringVar(&c.method, "method", "", "Only show requests to this facade or facade method")
+	f.StringVar(&c.since, "since", "", "Only show requests made at or after this time (RFC3339 timestamp or duration ago)")
+	f.StringVar(&c.until, "until", "", "Only show requests made at or before this time (RFC3339 timestamp or duration ago)")
+	f.StringVar(&c.outcome, "outcome", "", "Only show requests with this outcome (success|failure)")
+	f.IntVar(&c.limit, "limit", 100, "Maximum number of requests to show (0 for no limit)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.limit < 0 {
+		return errors.NotValidf("negative --limit")
+	}
+	switch c.outcome {
+	case "", "success", "failure":
+	default:
+		return errors.NotValidf("--outcome %q", c.outcome)
+	}
+	c.args = params.AuditLogQueryArgs{
+		User:    c.user,
+		Outcome: c.outcome,
+		Limit:   c.limit,
+	}
+	if c.method != "" {
+		parts := strings.Split(c.method, ".")
+		if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+			return errors.NotValidf("--method %q", c.method)
+		}
+		c.args.Facade = parts[0]
+		if len(parts) == 2 {
+			c.args.Method = parts[1]
+		}
+	}
+	if c.since != "" {
+		t, err := c.parseTime(c.since)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.args.After = &t
+	}
+	if c.until != "" {
+		t, err := c.parseTime(c.until)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.args.Before = &t
+	}
+	if c.args.After != nil && c.args.Before != nil && c.args.Before.Before(*c.args.After) {
+		return errors.NotValidf("--until time before --since time")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseTime parses value as either an RFC3339 timestamp, or
+// a duration which is subtracted from the current time.
+func (c *auditLogCommand) parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%q is neither an RFC3339 timestamp nor a positive duration", value)
+	}
+	return c.clock.Now().Add(-d), nil
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	args := c.args
+	if c.model != "" {
+		uuids, err := c.ModelUUIDs([]string{c.model})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		args.ModelUUID = uuids[0]
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := make([]auditLogEntry, len(entries))
+	for i, e := range entries {
+		result[i] = newAuditLogEntry(e)
+	}
+	return c.out.Write(ctx, result)
+}
+
+type auditLogError struct {
+	Message string `json:"message" yaml:"message"`
+	Code    string `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
+type auditLogEntry struct {
+	Time           time.Time       `json:"time" yaml:"time"`
+	User           string          `json:"user,omitempty" yaml:"user,omitempty"`
+	Model          string          `json:"model,omitempty" yaml:"model,omitempty"`
+	ModelUUID      string          `json:"model-uuid,omitempty" yaml:"model-uuid,omitempty"`
+	Facade         string          `json:"facade" yaml:"facade"`
+	Method         string          `json:"method" yaml:"method"`
+	Version        int             `json:"version" yaml:"version"`
+	Args           string          `json:"args,omitempty" yaml:"args,omitempty"`
+	Outcome        string          `json:"outcome,omitempty" yaml:"outcome,omitempty"`
+	Errors         []auditLogError `json:"errors,omitempty" yaml:"errors,omitempty"`
+	What           string          `json:"what,omitempty" yaml:"what,omitempty"`
+	ConversationID string          `json:"conversation-id" yaml:"conversation-id"`
+	ConnectionID   string          `json:"connection-id" yaml:"connection-id"`
+	RequestID      uint64          `json:"request-id" yaml:"request-id"`
+}
+
+func newAuditLogEntry(e params.AuditLogEntry) auditLogEntry {
+	entry := auditLogEntry{
+		Time:           e.Time,
+		User:           e.User,
+		Model:          e.ModelName,
+		ModelUUID:      e.ModelUUID,
+		Facade:         e.Facade,
+		Method:         e.Method,
+		Version:        e.Version,
+		Args:           e.Args,
+		Outcome:        e.Outcome,
+		What:           e.What,
+		ConversationID: e.ConversationID,
+		ConnectionID:   e.ConnectionID,
+		RequestID:      e.RequestID,
+	}
+	for _, err := range e.Errors {
+		entry.Errors = append(entry.Errors, auditLogError{
+			Message: err.Message,
+			Code:    err.Code,
+		})
+	}
+	return entry
+}
+
+// formatAuditLogTabular writes a tabular summary of audit log entries.
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+
+	w.Println("Time", "User", "Model", "Method", "Outcome", "Error")
+	for _, e := range entries {
+		method := fmt.Sprintf("%s.%s", e.Facade, e.Method)
+		var message string
+		if len(e.Errors) > 0 {
+			message = e.Errors[0].Message
+		}
+		w.Println(e.Time.UTC().Format(time.RFC3339), e.User, e.Model, method, e.Outcome, message)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,207 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testclock.Clock
+	t0    time.Time
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	store := s.createTestClientStore(c)
+	store.Models["mallards"].Models["admin/prod"] = jujuclient.ModelDetails{
+		ModelUUID: "prod-uuid",
+		ModelType: model.IAAS,
+	}
+	s.t0 = time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(s.t0)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			ConversationID: "c1",
+			ConnectionID:   "1A",
+			User:           "fred",
+			What:           "juju remove-application mysql",
+			ModelName:      "admin/my-model",
+			ModelUUID:      "def",
+			RequestID:      2,
+			Time:           s.t0.Add(-time.Minute),
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        13,
+			Outcome:        "failure",
+			Errors:         []params.AuditLogError{{Message: "boom", Code: "not found"}},
+		}, {
+			ConversationID: "c1",
+			ConnectionID:   "1A",
+			User:           "fred",
+			ModelName:      "admin/my-model",
+			ModelUUID:      "def",
+			RequestID:      1,
+			Time:           s.t0.Add(-2 * time.Minute),
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        13,
+			Outcome:        "success",
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, s.clock)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--limit", "-1"},
+		err:  "negative --limit not valid",
+	}, {
+		args: []string{"--outcome", "meh"},
+		err:  `--outcome "meh" not valid`,
+	}, {
+		args: []string{"--method", "Application.Deploy.Foo"},
+		err:  `--method "Application.Deploy.Foo" not valid`,
+	}, {
+		args: []string{"--method", ".Deploy"},
+		err:  `--method ".Deploy" not valid`,
+	}, {
+		args: []string{"--since", "yesterday"},
+		err:  `invalid --since value: "yesterday" is neither an RFC3339 timestamp nor a positive duration`,
+	}, {
+		args: []string{"--until", "-1h"},
+		err:  `invalid --until value: "-1h" is neither an RFC3339 timestamp nor a positive duration`,
+	}, {
+		args: []string{"--since", "1h", "--until", "2h"},
+		err:  "--until time before --since time not valid",
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAuditLogCommandForTest(s.api, s.store, s.clock)
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestQueryArgs(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "fred",
+		"--model", "prod",
+		"--method", "Application.Deploy",
+		"--since", "2h",
+		"--until", "2021-06-01T09:30:00Z",
+		"--outcome", "success",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	after := s.t0.Add(-2 * time.Hour)
+	before := s.t0.Add(-30 * time.Minute)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Query", []interface{}{params.AuditLogQueryArgs{
+			User:      "fred",
+			ModelUUID: "prod-uuid",
+			Facade:    "Application",
+			Method:    "Deploy",
+			After:     &after,
+			Before:    &before,
+			Outcome:   "success",
+			Limit:     5,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestQueryFacadeOnly(c *gc.C) {
+	_, err := s.run(c, "--method", "Application", "--limit", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{
+		Facade: "Application",
+	})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{Limit: 100})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  User  Model           Method                          Outcome  Error
+2021-06-01T09:59:00Z  fred  admin/my-model  Application.DestroyApplication  failure  boom
+2021-06-01T09:58:00Z  fred  admin/my-model  Application.Deploy              success  
+
+`[1:])
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	s.api.entries = s.api.entries[:1]
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- time: 2021-06-01T09:59:00Z
+  user: fred
+  model: admin/my-model
+  model-uuid: def
+  facade: Application
+  method: DestroyApplication
+  version: 13
+  outcome: failure
+  errors:
+  - message: boom
+    code: not found
+  what: juju remove-application mysql
+  conversation-id: c1
+  connection-id: 1A
+  request-id: 2
+`[1:])
+}
+
+func (s *AuditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "Query", "Close")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Query(args params.AuditLogQueryArgs) ([]params.AuditLogEntry, error) {
+	f.MethodCall(f, "Query", args)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -189,3 +189,11 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewAuditLogCommandForTest returns an audit-log command with the
+// api, client store and clock provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &auditLogCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -99,6 +100,7 @@ type ControllerAccessAPI interface {
 	MongoVersion() (string, error)
 	IdentityProviderURL() (string, error)
 	ControllerVersion() (controller.ControllerVersion, error)
+	BackupStatus() (params.ControllerBackupStatus, error)
 	Close() error
 }
 
@@ -193,6 +195,13 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 				details.Errors = append(details.Errors, err.Error())
 				mongoVersion = "(error)"
 			}
+			// Fetch the status of scheduled backups if the apiserver supports it
+			backupStatus, err := client.BackupStatus()
+			if err != nil && !errors.IsNotSupported(err) {
+				details.Errors = append(details.Errors, err.Error())
+			} else if err == nil && backupStatus.Schedule != "" {
+				details.Backups = convertBackupStatusForShow(backupStatus)
+			}
 		}
 
 		// Fetch identityURL if the apiserver supports it
@@ -296,6 +305,9 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// Backups holds the status of the controller's scheduled backups.
+	Backups *BackupDetails `yaml:"backups,omitempty" json:"backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -391,6 +403,45 @@ type AccountDetails struct {
 	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// BackupDetails holds the status of a controller's scheduled backups.
+type BackupDetails struct {
+	// Schedule is the cron schedule on which backups are created.
+	Schedule string `yaml:"schedule" json:"schedule"`
+
+	// LastAttempt is when a scheduled backup was last started.
+	LastAttempt *time.Time `yaml:"last-attempt,omitempty" json:"last-attempt,omitempty"`
+
+	// LastSuccess is when the last successful scheduled backup was started.
+	LastSuccess *time.Time `yaml:"last-success,omitempty" json:"last-success,omitempty"`
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string `yaml:"last-backup-id,omitempty" json:"last-backup-id,omitempty"`
+
+	// LastError holds the reason the last scheduled backup failed.
+	LastError string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+
+	// Warning is set if scheduled backups are overdue.
+	Warning string `yaml:"warning,omitempty" json:"warning,omitempty"`
+}
+
+func convertBackupStatusForShow(status params.ControllerBackupStatus) *BackupDetails {
+	details := &BackupDetails{
+		Schedule:     status.Schedule,
+		LastAttempt:  status.LastAttempt,
+		LastSuccess:  status.LastSuccess,
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	if status.Overdue {
+		if status.LastSuccess == nil {
+			details.Warning = "no scheduled backup has succeeded"
+		} else {
+			details.Warning = fmt.Sprintf("last successful backup is older than %v", status.MaxAge)
+		}
+	}
+	return details
+}
+
 func (c *showControllerCommand) convertControllerForShow(
 	controller *ShowControllerDetails,
 	controllerName string,
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
@@ -527,6 +529,60 @@ func (s *ShowControllerSuite) TestShowControllerWithIdentityProvider(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "identity-url: "+expURL)
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithBackups(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	s.fakeController.bestAPIVersion = 10
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "backups:")
+
+	success := time.Date(2021, 5, 24, 3, 0, 0, 0, time.UTC)
+	s.fakeController.backupStatus = params.ControllerBackupStatus{
+		Schedule:     "0 3 * * *",
+		LastAttempt:  &success,
+		LastSuccess:  &success,
+		LastBackupID: "backup-id",
+		MaxAge:       48 * time.Hour,
+	}
+	ctx, err = s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `
+  backups:
+    schedule: 0 3 * * *
+    last-attempt: 2021-05-24T03:00:00Z
+    last-success: 2021-05-24T03:00:00Z
+    last-backup-id: backup-id
+`[1:])
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "warning:")
+}
+
+func (s *ShowControllerSuite) TestShowControllerWithBackupsOverdue(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	s.fakeController.bestAPIVersion = 10
+	success := time.Date(2021, 5, 24, 3, 0, 0, 0, time.UTC)
+	attempt := success.Add(48 * time.Hour)
+	s.fakeController.backupStatus = params.ControllerBackupStatus{
+		Schedule:     "0 3 * * *",
+		LastAttempt:  &attempt,
+		LastSuccess:  &success,
+		LastBackupID: "backup-id",
+		LastError:    "disk full",
+		MaxAge:       48 * time.Hour,
+		Overdue:      true,
+	}
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `
+    last-error: disk full
+    warning: last successful backup is older than 48h0m0s
+`[1:])
+
+	s.fakeController.backupStatus.LastSuccess = nil
+	ctx, err = s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "warning: no scheduled backup has succeeded\n")
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithCAFingerprint(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -668,6 +724,7 @@ type fakeController struct {
 	bestAPIVersion    int
 	identityURL       string
 	controllerVersion apicontroller.ControllerVersion
+	backupStatus      params.ControllerBackupStatus
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -728,6 +785,13 @@ func (c *fakeController) ControllerVersion() (apicontroller.ControllerVersion, e
 	return c.controllerVersion, nil
 }
 
+func (c *fakeController) BackupStatus() (params.ControllerBackupStatus, error) {
+	if c.bestAPIVersion < 10 {
+		return params.ControllerBackupStatus{}, errors.NotSupportedf("requires APIVersion >= 10")
+	}
+	return c.backupStatus, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
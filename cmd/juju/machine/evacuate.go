@@ -0,0 +1,202 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewEvacuateLXDMemberCommand returns a command used to move a model's
+// machines off a member of an LXD cluster.
+func NewEvacuateLXDMemberCommand() cmd.Command {
+	return modelcmd.Wrap(&evacuateLXDMemberCommand{})
+}
+
+const evacuateLXDMemberDoc = `
+Moves the containers backing the model's machines off the specified
+member of the LXD cluster that the model is deployed to, for example
+before the member is taken down for maintenance.
+
+Running containers are live-migrated if the cluster supports it, and are
+otherwise stopped, moved and started again. Containers are spread over
+the remaining cluster members, unless a single target member is given
+with --to.
+
+The new cluster member of each machine is shown as its availability zone
+in the output of "juju status".
+
+Examples:
+
+    juju evacuate-lxd-member node1
+    juju evacuate-lxd-member node1 --to node2
+
+See also:
+    add-machine
+    status
+`
+
+// EvacuateLXDMemberAPI defines the API methods that the evacuate-lxd-member
+// command uses.
+type EvacuateLXDMemberAPI interface {
+	EvacuateClusterMember(member, target string) ([]params.EvacuatedMachine, error)
+	Close() error
+}
+
+// evacuateLXDMemberCommand moves the model's machines off an LXD
+// cluster member.
+type evacuateLXDMemberCommand struct {
+	baseMachinesCommand
+	api    EvacuateLXDMemberAPI
+	out    cmd.Output
+	member string
+	target string
+}
+
+// Info implements Command.Info.
+func (c *evacuateLXDMemberCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "evacuate-lxd-member",
+		Args:    "<member>",
+		Purpose: "Moves machines off a member of an LXD cluster.",
+		Doc:     evacuateLXDMemberDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *evacuateLXDMemberCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.target, "to", "", "The cluster member to move the machines to")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatEvacuatedMachinesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *evacuateLXDMemberCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no cluster member specified")
+	case 1:
+		c.member = args[0]
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+	if c.member == c.target {
+		return errors.New("cannot evacuate a cluster member to itself")
+	}
+	return nil
+}
+
+func (c *evacuateLXDMemberCommand) getAPI() (EvacuateLXDMemberAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *evacuateLXDMemberCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.EvacuateClusterMember(c.member, c.target)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		ctx.Infof("No machines on cluster member %q.", c.member)
+		return nil
+	}
+
+	anyFailed := false
+	moves := make([]EvacuatedMachine, len(results))
+	for i, result := range results {
+		move := EvacuatedMachine{
+			Instance: result.InstanceId,
+			Member:   result.Zone,
+			Live:     result.Live,
+		}
+		if result.MachineTag != "" {
+			tag, err := names.ParseMachineTag(result.MachineTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			move.Machine = tag.Id()
+		}
+		if result.Error != nil {
+			move.Error = result.Error.Error()
+			anyFailed = true
+		}
+		moves[i] = move
+	}
+	if err := c.out.Write(ctx, moves); err != nil {
+		return errors.Trace(err)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// EvacuatedMachine defines the serialization behaviour of the outcome
+// of moving a machine off an LXD cluster member.
+type EvacuatedMachine struct {
+	Machine  string `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Instance string `yaml:"instance" json:"instance"`
+	Member   string `yaml:"member" json:"member"`
+	Live     bool   `yaml:"live" json:"live"`
+	Error    string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// formatEvacuatedMachinesTabular returns a tabular summary of the moves
+// made while evacuating an LXD cluster member.
+func formatEvacuatedMachinesTabular(writer io.Writer, value interface{}) error {
+	moves, ok := value.([]EvacuatedMachine)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", moves, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Machine", "Instance", "Member", "Migration", "Message")
+	for _, move := range moves {
+		machine := move.Machine
+		if machine == "" {
+			machine = "-"
+		}
+		migration := "cold"
+		if move.Live {
+			migration = "live"
+		}
+		if move.Error != "" {
+			migration = "failed"
+		}
+		print(machine, move.Instance, move.Member, migration, move.Error)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type EvacuateLXDMemberSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeEvacuateLXDMemberAPI
+}
+
+var _ = gc.Suite(&EvacuateLXDMemberSuite{})
+
+func (s *EvacuateLXDMemberSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeEvacuateLXDMemberAPI{}
+}
+
+func (s *EvacuateLXDMemberSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, machine.NewEvacuateLXDMemberCommandForTest(s.api), args...)
+}
+
+func (s *EvacuateLXDMemberSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		errorString: "no cluster member specified",
+	}, {
+		args:        []string{"node1", "node2"},
+		errorString: `unrecognized args: \["node2"\]`,
+	}, {
+		args:        []string{"node1", "--to", "node1"},
+		errorString: "cannot evacuate a cluster member to itself",
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(machine.NewEvacuateLXDMemberCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.errorString)
+	}
+}
+
+func (s *EvacuateLXDMemberSuite) TestEvacuate(c *gc.C) {
+	s.api.results = []params.EvacuatedMachine{{
+		MachineTag: "machine-0",
+		InstanceId: "juju-0",
+		Zone:       "node2",
+		Live:       true,
+	}, {
+		MachineTag: "machine-1",
+		InstanceId: "juju-1",
+		Zone:       "node3",
+	}}
+	ctx, err := s.run(c, "node1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"EvacuateClusterMember", []interface{}{"node1", ""}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Machine  Instance  Member  Migration  Message\n"+
+		"0        juju-0    node2   live       \n"+
+		"1        juju-1    node3   cold       \n"+
+		"\n")
+}
+
+func (s *EvacuateLXDMemberSuite) TestEvacuateTarget(c *gc.C) {
+	s.api.results = []params.EvacuatedMachine{{
+		InstanceId: "juju-0",
+		Zone:       "node2",
+	}}
+	ctx, err := s.run(c, "node1", "--to", "node2", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "EvacuateClusterMember", "node1", "node2")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- instance: juju-0
+  member: node2
+  live: false
+`[1:])
+}
+
+func (s *EvacuateLXDMemberSuite) TestEvacuatePartialFailure(c *gc.C) {
+	s.api.results = []params.EvacuatedMachine{{
+		MachineTag: "machine-0",
+		InstanceId: "juju-0",
+		Zone:       "node2",
+		Error:      &params.Error{Message: "boom"},
+	}}
+	ctx, err := s.run(c, "node1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Machine  Instance  Member  Migration  Message
+0        juju-0    node2   failed     boom
+
+`[1:])
+}
+
+func (s *EvacuateLXDMemberSuite) TestEvacuateNoMachines(c *gc.C) {
+	ctx, err := s.run(c, "node1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No machines on cluster member \"node1\".\n")
+}
+
+func (s *EvacuateLXDMemberSuite) TestEvacuateError(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf("cluster member %q", "node9"))
+	_, err := s.run(c, "node9")
+	c.Assert(err, gc.ErrorMatches, `cluster member "node9" not found`)
+}
+
+func (s *EvacuateLXDMemberSuite) TestEvacuateBlocked(c *gc.C) {
+	s.api.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "change blocked"})
+	_, err := s.run(c, "node1")
+	c.Assert(err.Error(), jc.Contains, "All operations that change model have been disabled")
+}
+
+type fakeEvacuateLXDMemberAPI struct {
+	jujutesting.Stub
+	results []params.EvacuatedMachine
+}
+
+func (f *fakeEvacuateLXDMemberAPI) EvacuateClusterMember(member, target string) ([]params.EvacuatedMachine, error) {
+	f.MethodCall(f, "EvacuateClusterMember", member, target)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.results, nil
+}
+
+func (f *fakeEvacuateLXDMemberAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
@@ -76,3 +76,19 @@ func NewUpgradeSeriesCommandForTest(upgradeAPI UpgradeMachineSeriesAPI) cmd.Comm
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewEvacuateLXDMemberCommandForTest returns an evacuate-lxd-member
+// command with the api provided as specified.
+func NewEvacuateLXDMemberCommandForTest(api EvacuateLXDMemberAPI) cmd.Command {
+	command := &evacuateLXDMemberCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
+// NewMoveMachineCommandForTest returns a move-machine command with the
+// api provided as specified.
+func NewMoveMachineCommandForTest(api MoveMachineAPI) cmd.Command {
+	command := &moveMachineCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewMoveMachineCommand returns a command used to move a machine's
+// instance to another availability zone.
+func NewMoveMachineCommand() cmd.Command {
+	return modelcmd.Wrap(&moveMachineCommand{})
+}
+
+const moveMachineDoc = `
+Moves the instance backing a machine to another availability zone of the
+cloud, such as another member of an LXD cluster or another vSphere host
+cluster, for example to rebalance load or before taking a host down for
+maintenance.
+
+Running instances are live-migrated if the cloud supports it, and are
+otherwise stopped, moved and started again. Once the move completes the
+machine's instance ID, availability zone, addresses and network devices
+are updated to match the moved instance.
+
+Examples:
+
+    juju move-machine 3 --to zone=node2
+
+See also:
+    evacuate-lxd-member
+    status
+`
+
+// MoveMachineAPI defines the API methods that the move-machine command
+// uses.
+type MoveMachineAPI interface {
+	MigrateMachine(machineId, zone string) (params.MigrateMachineResult, error)
+	Close() error
+}
+
+// moveMachineCommand moves a machine's instance to another
+// availability zone.
+type moveMachineCommand struct {
+	baseMachinesCommand
+	api       MoveMachineAPI
+	machineId string
+	to        string
+	zone      string
+}
+
+// Info implements Command.Info.
+func (c *moveMachineCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "move-machine",
+		Args:    "<machine> --to zone=<zone>",
+		Purpose: "Moves a machine's instance to another availability zone.",
+		Doc:     moveMachineDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *moveMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.to, "to", "", "The availability zone to move the machine to, as zone=<zone>")
+}
+
+// Init implements Command.Init.
+func (c *moveMachineCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine specified")
+	case 1:
+		c.machineId = args[0]
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+	if !names.IsValidMachine(c.machineId) {
+		return errors.NotValidf("machine ID %q", c.machineId)
+	}
+	if c.to == "" {
+		return errors.New("no destination specified, use --to zone=<zone>")
+	}
+	parts := strings.SplitN(c.to, "=", 2)
+	if len(parts) != 2 || parts[0] != "zone" || parts[1] == "" {
+		return errors.Errorf("invalid destination %q, expected zone=<zone>", c.to)
+	}
+	c.zone = parts[1]
+	return nil
+}
+
+func (c *moveMachineCommand) getAPI() (MoveMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *moveMachineCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.MigrateMachine(c.machineId, c.zone)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	migration := "cold"
+	if result.Live {
+		migration = "live"
+	}
+	ctx.Infof("Machine %s (instance %s) moved to zone %q (%s migration).",
+		c.machineId, result.InstanceId, result.Zone, migration)
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type MoveMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeMoveMachineAPI
+}
+
+var _ = gc.Suite(&MoveMachineSuite{})
+
+func (s *MoveMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeMoveMachineAPI{}
+}
+
+func (s *MoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, machine.NewMoveMachineCommandForTest(s.api), args...)
+}
+
+func (s *MoveMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		errorString: "no machine specified",
+	}, {
+		args:        []string{"0", "1"},
+		errorString: `unrecognized args: \["1"\]`,
+	}, {
+		args:        []string{"foo", "--to", "zone=node2"},
+		errorString: `machine ID "foo" not valid`,
+	}, {
+		args:        []string{"0"},
+		errorString: `no destination specified, use --to zone=<zone>`,
+	}, {
+		args:        []string{"0", "--to", "node2"},
+		errorString: `invalid destination "node2", expected zone=<zone>`,
+	}, {
+		args:        []string{"0", "--to", "host=node2"},
+		errorString: `invalid destination "host=node2", expected zone=<zone>`,
+	}, {
+		args:        []string{"0", "--to", "zone="},
+		errorString: `invalid destination "zone=", expected zone=<zone>`,
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(machine.NewMoveMachineCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.errorString)
+	}
+}
+
+func (s *MoveMachineSuite) TestMove(c *gc.C) {
+	s.api.result = params.MigrateMachineResult{
+		InstanceId: "juju-0",
+		Zone:       "node2",
+		Live:       true,
+	}
+	ctx, err := s.run(c, "0/lxd/1", "--to", "zone=node2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrateMachine", []interface{}{"0/lxd/1", "node2"}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"Machine 0/lxd/1 (instance juju-0) moved to zone \"node2\" (live migration).\n")
+}
+
+func (s *MoveMachineSuite) TestMoveCold(c *gc.C) {
+	s.api.result = params.MigrateMachineResult{
+		InstanceId: "juju-0",
+		Zone:       "node2",
+	}
+	ctx, err := s.run(c, "0", "--to", "zone=node2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"Machine 0 (instance juju-0) moved to zone \"node2\" (cold migration).\n")
+}
+
+func (s *MoveMachineSuite) TestMoveError(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("moving machines on this cloud"))
+	_, err := s.run(c, "0", "--to", "zone=node2")
+	c.Assert(err, gc.ErrorMatches, "moving machines on this cloud not supported")
+}
+
+func (s *MoveMachineSuite) TestMoveBlocked(c *gc.C) {
+	s.api.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "change blocked"})
+	_, err := s.run(c, "0", "--to", "zone=node2")
+	c.Assert(err.Error(), jc.Contains, "All operations that change model have been disabled")
+}
+
+type fakeMoveMachineAPI struct {
+	jujutesting.Stub
+	result params.MigrateMachineResult
+}
+
+func (f *fakeMoveMachineAPI) MigrateMachine(machineId, zone string) (params.MigrateMachineResult, error) {
+	f.MethodCall(f, "MigrateMachine", machineId, zone)
+	if err := f.NextErr(); err != nil {
+		return params.MigrateMachineResult{}, err
+	}
+	return f.result, nil
+}
+
+func (f *fakeMoveMachineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
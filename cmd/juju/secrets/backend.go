@@ -0,0 +1,155 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var secretBackendHelpSummary = `
+Displays or sets the backend used to store secret content.`[1:]
+
+var secretBackendHelpDetails = `
+With no arguments, displays the type of backend used to store the content
+of secrets in the model. By default, secret content is stored in the
+controller ("internal").
+
+When a backend type is specified, new secret content is stored in that
+backend, and the content of all existing secret revisions is moved to it.
+Backend specific config, such as the endpoint and token for a Vault
+backend, may be supplied in a YAML file using --config. If no config is
+supplied, any config previously used for the backend type is reused.
+Credentials, such as the Vault token, are stored apart from the model
+config and are never displayed.
+
+Available backend types are: internal, kubernetes, vault.
+
+Examples:
+    juju model-secret-backend
+    juju model-secret-backend vault --config vault.yaml
+    juju model-secret-backend internal
+
+See also:
+    secrets
+`
+
+// SecretBackendAPI is the secrets client API used
+// to display and change the model's secret backend.
+type SecretBackendAPI interface {
+	SecretBackend() (string, error)
+	ChangeSecretBackend(backendType string, config map[string]interface{}) (int, error)
+	Close() error
+}
+
+type secretBackendCommand struct {
+	modelcmd.ModelCommandBase
+
+	secretBackendAPIFunc func() (SecretBackendAPI, error)
+	backendType          string
+	configFile           cmd.FileVar
+}
+
+// NewSecretBackendCommand returns a command to display
+// or change the backend used to store secret content.
+func NewSecretBackendCommand() cmd.Command {
+	c := &secretBackendCommand{}
+	c.secretBackendAPIFunc = c.secretsAPI
+
+	return modelcmd.Wrap(c)
+}
+
+func (c *secretBackendCommand) secretsAPI() (SecretBackendAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apisecrets.NewClient(root), nil
+}
+
+// Info implements cmd.Command.
+func (c *secretBackendCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "model-secret-backend",
+		Args:    "[<backend-type>]",
+		Purpose: secretBackendHelpSummary,
+		Doc:     secretBackendHelpDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretBackendCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(&c.configFile, "config", "Path to yaml-formatted backend config file")
+}
+
+// Init implements cmd.Command.
+func (c *secretBackendCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.backendType, args = args[0], args[1:]
+	}
+	if c.backendType == "" && c.configFile.Path != "" {
+		return errors.New("--config requires a backend type")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Run.
+func (c *secretBackendCommand) Run(ctx *cmd.Context) error {
+	api, err := c.secretBackendAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if c.backendType == "" {
+		backendType, err := api.SecretBackend()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintln(ctx.Stdout, backendType)
+		return nil
+	}
+
+	var cfg map[string]interface{}
+	if c.configFile.Path != "" {
+		if cfg, err = c.readConfig(ctx); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	migrated, err := api.ChangeSecretBackend(c.backendType, cfg)
+	if migrated > 0 {
+		ctx.Infof("moved %d secret revision(s) to the %q backend", migrated, c.backendType)
+	}
+	return errors.Trace(err)
+}
+
+func (c *secretBackendCommand) readConfig(ctx *cmd.Context) (map[string]interface{}, error) {
+	b, err := c.configFile.Read(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var attrs map[string]interface{}
+	if err := yaml.Unmarshal(b, &attrs); err != nil {
+		return nil, errors.Annotate(err, "parsing backend config")
+	}
+	conformant, err := common.ConformYAML(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, ok := conformant.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("backend config must contain a YAML map with string keys")
+	}
+	return cfg, nil
+}
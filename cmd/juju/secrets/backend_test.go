@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type SecretBackendSuite struct {
+	testing.BaseSuite
+
+	api *mockSecretBackendAPI
+}
+
+var _ = gc.Suite(&SecretBackendSuite{})
+
+func (s *SecretBackendSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.api = &mockSecretBackendAPI{backendType: "internal"}
+}
+
+func (s *SecretBackendSuite) TestShow(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewSecretBackendCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "internal\n")
+}
+
+func (s *SecretBackendSuite) TestChange(c *gc.C) {
+	s.api.migrated = 3
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewSecretBackendCommandForTest(s.api), "kubernetes")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.backendType, gc.Equals, "kubernetes")
+	c.Assert(s.api.config, gc.IsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "moved 3 secret revision(s) to the \"kubernetes\" backend\n")
+}
+
+func (s *SecretBackendSuite) TestChangeWithConfig(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "vault.yaml")
+	err := ioutil.WriteFile(path, []byte("endpoint: http://vault:8200\ntoken: foo\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, secrets.NewSecretBackendCommandForTest(s.api), "vault", "--config", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.backendType, gc.Equals, "vault")
+	c.Assert(s.api.config, jc.DeepEquals, map[string]interface{}{
+		"endpoint": "http://vault:8200",
+		"token":    "foo",
+	})
+}
+
+func (s *SecretBackendSuite) TestChangeError(c *gc.C) {
+	s.api.migrated = 1
+	s.api.err = errors.New("boom")
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewSecretBackendCommandForTest(s.api), "vault")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "moved 1 secret revision(s) to the \"vault\" backend\n")
+}
+
+func (s *SecretBackendSuite) TestInitConfigWithoutType(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, secrets.NewSecretBackendCommandForTest(s.api), "--config", "vault.yaml")
+	c.Assert(err, gc.ErrorMatches, "--config requires a backend type")
+}
+
+func (s *SecretBackendSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, secrets.NewSecretBackendCommandForTest(s.api), "vault", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+type mockSecretBackendAPI struct {
+	backendType string
+	config      map[string]interface{}
+	migrated    int
+	err         error
+}
+
+func (m *mockSecretBackendAPI) SecretBackend() (string, error) {
+	return m.backendType, m.err
+}
+
+func (m *mockSecretBackendAPI) ChangeSecretBackend(backendType string, config map[string]interface{}) (int, error) {
+	m.backendType = backendType
+	m.config = config
+	return m.migrated, m.err
+}
+
+func (*mockSecretBackendAPI) Close() error {
+	return nil
+}
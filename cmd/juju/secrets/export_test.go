@@ -0,0 +1,29 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+// NewListCommandForTest returns a secrets command for testing.
+func NewListCommandForTest(listSecretsAPI ListSecretsAPI) cmd.Command {
+	c := &listSecretsCommand{
+		listSecretsAPIFunc: func() (ListSecretsAPI, error) { return listSecretsAPI, nil },
+	}
+	c.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(c)
+}
+
+// NewSecretBackendCommandForTest returns a model-secret-backend command for testing.
+func NewSecretBackendCommandForTest(secretBackendAPI SecretBackendAPI) cmd.Command {
+	c := &secretBackendCommand{
+		secretBackendAPIFunc: func() (SecretBackendAPI, error) { return secretBackendAPI, nil },
+	}
+	c.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(c)
+}
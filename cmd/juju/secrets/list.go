@@ -0,0 +1,184 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var listSecretsHelpSummary = `
+Lists secrets available in the model.`[1:]
+
+var listSecretsHelpDetails = `
+Displays the secrets available in the model, along with the application
+which owns each secret, its latest revision and rotation schedule.
+
+Secret values are only shown if --show-secrets is specified and the
+user has admin access to the model.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml --show-secrets
+`
+
+// ListSecretsAPI is the secrets client API.
+type ListSecretsAPI interface {
+	ListSecrets(showSecrets bool) ([]apisecrets.SecretDetails, error)
+	Close() error
+}
+
+type listSecretsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	listSecretsAPIFunc func() (ListSecretsAPI, error)
+	showSecrets        bool
+}
+
+// NewListSecretsCommand returns a command to list secrets metadata.
+func NewListSecretsCommand() cmd.Command {
+	c := &listSecretsCommand{}
+	c.listSecretsAPIFunc = c.secretsAPI
+
+	return modelcmd.Wrap(c)
+}
+
+func (c *listSecretsCommand) secretsAPI() (ListSecretsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apisecrets.NewClient(root), nil
+
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secrets",
+		Purpose: listSecretsHelpSummary,
+		Doc:     listSecretsHelpDetails,
+		Aliases: []string{"list-secrets"},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.showSecrets, "show-secrets", false, "Show secret values")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+type secretValueDetails struct {
+	Data  map[string]string `json:"data,omitempty" yaml:"data,omitempty"`
+	Error string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type secretDisplayDetails struct {
+	URI            string              `json:"uri" yaml:"uri"`
+	Owner          string              `json:"owner" yaml:"owner"`
+	Revision       int                 `json:"revision" yaml:"revision"`
+	Description    string              `json:"description,omitempty" yaml:"description,omitempty"`
+	RotateInterval time.Duration       `json:"rotate-interval,omitempty" yaml:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time          `json:"next-rotate-time,omitempty" yaml:"next-rotate-time,omitempty"`
+	CreateTime     time.Time           `json:"create-time" yaml:"create-time"`
+	UpdateTime     time.Time           `json:"update-time" yaml:"update-time"`
+	Value          *secretValueDetails `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Run implements cmd.Run.
+func (c *listSecretsCommand) Run(ctxt *cmd.Context) error {
+	api, err := c.listSecretsAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.ListSecrets(c.showSecrets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details := gatherSecretInfo(result)
+	return c.out.Write(ctxt, details)
+}
+
+func gatherSecretInfo(secrets []apisecrets.SecretDetails) []secretDisplayDetails {
+	var details []secretDisplayDetails
+	for _, m := range secrets {
+		owner := m.Metadata.OwnerTag
+		if tag, err := names.ParseTag(owner); err == nil {
+			owner = tag.Id()
+		}
+		info := secretDisplayDetails{
+			URI:            m.Metadata.URI.String(),
+			Owner:          owner,
+			Revision:       m.Metadata.LatestRevision,
+			Description:    m.Metadata.Description,
+			RotateInterval: m.Metadata.RotateInterval,
+			NextRotateTime: m.Metadata.NextRotateTime,
+			CreateTime:     m.Metadata.CreateTime,
+			UpdateTime:     m.Metadata.UpdateTime,
+		}
+		if m.Error != "" {
+			info.Value = &secretValueDetails{Error: m.Error}
+		} else if m.Value != nil && !m.Value.IsEmpty() {
+			data, err := m.Value.Values()
+			if err != nil {
+				info.Value = &secretValueDetails{Error: err.Error()}
+			} else {
+				info.Value = &secretValueDetails{Data: data}
+			}
+		}
+		details = append(details, info)
+	}
+	sort.Slice(details, func(i, j int) bool {
+		if details[i].Owner != details[j].Owner {
+			return details[i].Owner < details[j].Owner
+		}
+		return details[i].URI < details[j].URI
+	})
+	return details
+}
+
+// formatSecretsTabular writes a tabular summary of secret information.
+func formatSecretsTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]secretDisplayDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+
+	w.Println("URI", "Owner", "Revision", "Rotate", "Description")
+	for _, s := range secrets {
+		rotate := "never"
+		if s.RotateInterval > 0 {
+			rotate = s.RotateInterval.String()
+		}
+		w.Println(s.URI, s.Owner, s.Revision, rotate, s.Description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.BaseSuite
+
+	api *mockListSecretsAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.api = &mockListSecretsAPI{}
+}
+
+func (s *ListSuite) secrets(c *gc.C) []apisecrets.SecretDetails {
+	uri, err := coresecrets.ParseURI("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/c5ftls1a")
+	c.Assert(err, jc.ErrorIsNil)
+	uri2, err := coresecrets.ParseURI("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/c5ftls2b")
+	c.Assert(err, jc.ErrorIsNil)
+	created := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	next := created.Add(24 * time.Hour)
+	return []apisecrets.SecretDetails{{
+		Metadata: coresecrets.SecretMetadata{
+			URI:            uri2,
+			OwnerTag:       "application-wordpress",
+			LatestRevision: 1,
+			CreateTime:     created,
+			UpdateTime:     created,
+		},
+		Error: "boom",
+	}, {
+		Metadata: coresecrets.SecretMetadata{
+			URI:            uri,
+			OwnerTag:       "application-mariadb",
+			Description:    "db password",
+			RotateInterval: 24 * time.Hour,
+			NextRotateTime: &next,
+			LatestRevision: 2,
+			CreateTime:     created,
+			UpdateTime:     created,
+		},
+		Value: coresecrets.NewSecretValue(map[string]string{"password": "c2VjcmV0"}),
+	}}
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	s.api.result = s.secrets(c)
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+URI                                                     Owner      Revision  Rotate   Description
+secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/c5ftls1a  mariadb    2         24h0m0s  db password
+secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/c5ftls2b  wordpress  1         never    
+
+`[1:])
+	c.Assert(s.api.showSecrets, jc.IsFalse)
+}
+
+func (s *ListSuite) TestListYAML(c *gc.C) {
+	s.api.result = s.secrets(c)
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api), "--format", "yaml", "--show-secrets")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- uri: secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/c5ftls1a
+  owner: mariadb
+  revision: 2
+  description: db password
+  rotate-interval: 24h0m0s
+  next-rotate-time: 2021-06-02T10:00:00Z
+  create-time: 2021-06-01T10:00:00Z
+  update-time: 2021-06-01T10:00:00Z
+  value:
+    data:
+      password: secret
+- uri: secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/c5ftls2b
+  owner: wordpress
+  revision: 1
+  create-time: 2021-06-01T10:00:00Z
+  update-time: 2021-06-01T10:00:00Z
+  value:
+    error: boom
+`[1:])
+	c.Assert(s.api.showSecrets, jc.IsTrue)
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.api.err = errors.New("fail")
+	_, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+type mockListSecretsAPI struct {
+	showSecrets bool
+	result      []apisecrets.SecretDetails
+	err         error
+}
+
+func (m *mockListSecretsAPI) ListSecrets(showSecrets bool) ([]apisecrets.SecretDetails, error) {
+	m.showSecrets = showSecrets
+	return m.result, m.err
+}
+
+func (*mockListSecretsAPI) Close() error {
+	return nil
+}
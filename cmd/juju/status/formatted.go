@@ -125,6 +125,7 @@ type applicationStatus struct {
 	CharmProfile     string                `json:"charm-profile,omitempty" yaml:"charm-profile,omitempty"`
 	CanUpgradeTo     string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
 	Scale            int                   `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Autoscaler       *autoscalerStatus     `json:"autoscaler,omitempty" yaml:"autoscaler,omitempty"`
 	ProviderId       string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Address          string                `json:"address,omitempty" yaml:"address,omitempty"`
 	Exposed          bool                  `json:"exposed" yaml:"exposed"`
@@ -137,6 +138,16 @@ type applicationStatus struct {
 	EndpointBindings map[string]string     `json:"endpoint-bindings,omitempty" yaml:"endpoint-bindings,omitempty"`
 }
 
+// autoscalerStatus holds the observed state of an application's
+// horizontal autoscaler.
+type autoscalerStatus struct {
+	MinUnits     int    `json:"min-units" yaml:"min-units"`
+	MaxUnits     int    `json:"max-units" yaml:"max-units"`
+	CurrentUnits int    `json:"current-units" yaml:"current-units"`
+	DesiredUnits int    `json:"desired-units" yaml:"desired-units"`
+	Message      string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
 type applicationStatusNoMarshal applicationStatus
 
 func (s applicationStatus) MarshalJSON() ([]byte, error) {
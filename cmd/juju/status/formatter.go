@@ -286,6 +286,16 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		EndpointBindings: application.EndpointBindings,
 	}
 
+	if as := application.Autoscaler; as != nil {
+		out.Autoscaler = &autoscalerStatus{
+			MinUnits:     as.MinReplicas,
+			MaxUnits:     as.MaxReplicas,
+			CurrentUnits: as.CurrentReplicas,
+			DesiredUnits: as.DesiredReplicas,
+			Message:      as.Message,
+		}
+	}
+
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
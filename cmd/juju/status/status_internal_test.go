@@ -5986,6 +5986,37 @@ func (s *StatusSuite) TestControllerTimestampInFullStatus(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatApplicationAutoscaler(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mariadb-k8s": {
+				Charm: "cs:mariadb-k8s-1",
+				Scale: 2,
+				Autoscaler: &params.AutoscalerStatus{
+					MinReplicas:     1,
+					MaxReplicas:     5,
+					CurrentReplicas: 2,
+					DesiredReplicas: 3,
+					Message:         "the desired replica count is increasing faster than the maximum scale rate",
+				},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(formatted.Applications["mariadb-k8s"].Autoscaler, jc.DeepEquals, &autoscalerStatus{
+		MinUnits:     1,
+		MaxUnits:     5,
+		CurrentUnits: 2,
+		DesiredUnits: 3,
+		Message:      "the desired replica count is increasing faster than the maximum scale rate",
+	})
+}
+
 func (s *StatusSuite) TestTabularNoRelations(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
 	defer s.resetContext(c, ctx)
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/params"
@@ -82,6 +83,11 @@ Examples:
 	# storage pool for "brick" storage to unit gluster/0:
     juju add-storage gluster/0 brick=ebs-ssd
 
+    # Add a storage instance for "pgdata" storage to unit
+    # postgresql/1, restoring its volume from a snapshot
+    # previously taken with 'juju snapshot-storage':
+    juju add-storage --from-snapshot snap-0123 postgresql/1 pgdata
+
 
 Further reading:
 
@@ -91,6 +97,8 @@ Further reading:
 See also:
 
     import-filesystem
+    snapshot-storage
+    snapshots
     storage
     storage-pools
 `
@@ -107,6 +115,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// fromSnapshot is the ID of a volume snapshot from which
+	// to create the storage instances' volumes.
+	fromSnapshot string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.fromSnapshot, "from-snapshot", "", "Create the storage from the specified volume snapshot")
 }
 
 // Init implements Command.Init.
@@ -122,7 +140,17 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u)
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.fromSnapshot != "" {
+		for name, cons := range c.storageCons {
+			if cons.Count > 1 {
+				return errors.Errorf("cannot add more than one %q storage instance from a snapshot", name)
+			}
+		}
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -211,6 +239,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 				&cons.Size,
 				&cons.Count,
 			},
+			FromSnapshot: c.fromSnapshot,
 		})
 	}
 
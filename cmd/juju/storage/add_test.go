@@ -118,6 +118,25 @@ added storage foo/1 to tst/123
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	var added []params.StorageAddParams
+	addToUnit := s.mockAPI.addToUnitFunc
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+		added = storages
+		return addToUnit(storages)
+	}
+	_, err := s.runAdd(c, "--from-snapshot", "snap-0", "tst/123", "data=ebs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 1)
+	c.Assert(added[0].StorageName, gc.Equals, "data")
+	c.Assert(added[0].FromSnapshot, gc.Equals, "snap-0")
+}
+
+func (s *addSuite) TestAddFromSnapshotMultipleInstances(c *gc.C) {
+	_, err := s.runAdd(c, "--from-snapshot", "snap-0", "tst/123", "data=ebs,2")
+	c.Assert(err, gc.ErrorMatches, `cannot add more than one "data" storage instance from a snapshot`)
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
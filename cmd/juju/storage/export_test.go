@@ -91,3 +91,27 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewSnapshotStorageCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotStorageCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewListSnapshotsCommandForTest(api StorageSnapshotListAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listSnapshotsCommand{newAPIFunc: func() (StorageSnapshotListAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeStorageCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
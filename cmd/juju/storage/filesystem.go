@@ -45,6 +45,9 @@ type FilesystemInfo struct {
 
 	// from params.FilesystemInfo.
 	Status EntityStatus `yaml:"status,omitempty" json:"status,omitempty"`
+
+	// Usage is the last reported usage of the filesystem, if any.
+	Usage *FilesystemUsage `yaml:"usage,omitempty" json:"usage,omitempty"`
 }
 
 type FilesystemAttachments struct {
@@ -113,6 +116,7 @@ func createFilesystemInfo(details params.FilesystemDetails) (names.FilesystemTag
 		// TODO(axw) we should support formatting as ISO time
 		common.FormatTime(details.Status.Since, false),
 	}
+	info.Usage = createFilesystemUsage(details.Usage)
 
 	if details.VolumeTag != "" {
 		volumeId, err := idFromTag(details.VolumeTag)
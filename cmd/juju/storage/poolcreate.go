@@ -49,6 +49,8 @@ Examples:
 
     juju create-storage-pool ebsrotary ebs volume-type=standard
     juju create-storage-pool gcepd storage-provisioner=kubernetes.io/gce-pd [storage-mode=RWX|RWO|ROX] parameters.type=pd-standard
+    juju create-storage-pool shared nfs server=nfs.example.com export=/srv/share
+    juju create-storage-pool cephshared cephfs monitors=10.0.0.1,10.0.0.2 path=/volumes/web user=web secret-file=/etc/ceph/web.secret
 
 See also:
     remove-storage-pool
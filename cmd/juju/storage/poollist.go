@@ -21,6 +21,23 @@ type PoolCommandBase struct {
 type PoolInfo struct {
 	Provider string                 `yaml:"provider" json:"provider"`
 	Attrs    map[string]interface{} `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+	Usage    *PoolUsage             `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// PoolUsage contains the aggregate of the last reported usage of the
+// filesystems in a storage pool.
+type PoolUsage struct {
+	// Filesystems is the number of filesystems which have reported usage.
+	Filesystems int `yaml:"filesystems" json:"filesystems"`
+
+	// Capacity is the total size of the filesystems, in MiB.
+	Capacity uint64 `yaml:"capacity" json:"capacity"`
+
+	// Free is the amount of space remaining on the filesystems, in MiB.
+	Free uint64 `yaml:"free" json:"free"`
+
+	// UsedPercent is the percentage of the filesystems' capacity in use.
+	UsedPercent int `yaml:"used-percent" json:"used-percent"`
 }
 
 func formatPoolInfo(all []params.StoragePool) map[string]PoolInfo {
@@ -29,11 +46,29 @@ func formatPoolInfo(all []params.StoragePool) map[string]PoolInfo {
 		output[one.Name] = PoolInfo{
 			Provider: one.Provider,
 			Attrs:    one.Attrs,
+			Usage:    createPoolUsage(one.Usage),
 		}
 	}
 	return output
 }
 
+func createPoolUsage(usage *params.StoragePoolUsage) *PoolUsage {
+	if usage == nil {
+		return nil
+	}
+	const mib = 1024 * 1024
+	total := usage.BytesUsed + usage.BytesFree
+	out := &PoolUsage{
+		Filesystems: usage.Filesystems,
+		Capacity:    total / mib,
+		Free:        usage.BytesFree / mib,
+	}
+	if total > 0 {
+		out.UsedPercent = int(usage.BytesUsed * 100 / total)
+	}
+	return out
+}
+
 const poolListCommandDoc = `
 The user can filter on pool type, name.
 
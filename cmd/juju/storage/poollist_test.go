@@ -112,6 +112,27 @@ xyz   testType  a=true b=maybe c=well
 
 type unmarshaller func(in []byte, out interface{}) (err error)
 
+func (s *poolListSuite) TestPoolListUsageYAML(c *gc.C) {
+	s.mockAPI.attrs = nil
+	s.mockAPI.usage = &params.StoragePoolUsage{
+		Filesystems: 2,
+		BytesUsed:   3 * 1024 * 1024 * 1024,
+		BytesFree:   1024 * 1024 * 1024,
+	}
+	s.assertValidList(
+		c,
+		[]string{"--name", "xyz", "--format", "yaml"},
+		`
+xyz:
+  provider: testType
+  usage:
+    filesystems: 2
+    capacity: 4096
+    free: 1024
+    used-percent: 75
+`[1:])
+}
+
 func (s *poolListSuite) assertUnmarshalledOutput(c *gc.C, unmarshall unmarshaller, args ...string) {
 
 	context, err := s.runPoolList(c, args)
@@ -155,7 +176,10 @@ func (s poolListSuite) expect(c *gc.C, types, names []string) map[string]storage
 	c.Assert(err, jc.ErrorIsNil)
 	result := make(map[string]storage.PoolInfo, len(all))
 	for _, one := range all {
-		result[one.Name] = storage.PoolInfo{one.Provider, one.Attrs}
+		result[one.Name] = storage.PoolInfo{
+			Provider: one.Provider,
+			Attrs:    one.Attrs,
+		}
 	}
 	return result
 }
@@ -170,6 +194,7 @@ func (s *poolListSuite) assertValidList(c *gc.C, args []string, expected string)
 
 type mockPoolListAPI struct {
 	attrs map[string]interface{}
+	usage *params.StoragePoolUsage
 }
 
 func (s mockPoolListAPI) Close() error {
@@ -197,5 +222,6 @@ func (s mockPoolListAPI) createTestPoolInstance(aname, atype string) params.Stor
 		Name:     aname,
 		Provider: atype,
 		Attrs:    s.attrs,
+		Usage:    s.usage,
 	}
 }
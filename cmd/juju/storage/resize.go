@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommand returns a command used to grow the volume or
+// filesystem backing a storage instance.
+func NewResizeStorageCommand() cmd.Command {
+	command := &resizeStorageCommand{}
+	command.newAPIFunc = func() (StorageResizeAPI, error) {
+		return command.NewStorageAPI()
+	}
+	return modelcmd.Wrap(command)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grows the volume or filesystem backing the specified storage instance to
+the given size. The size is a number, optionally followed by one of the
+suffixes M, G, T, P, E, Z or Y (mebibytes by default).
+
+The resize is carried out online by the storage provisioner; storage can
+only be grown, never shrunk. Once the resize completes, the charm will be
+notified with a "<name>-storage-resized" hook. Not all storage providers
+support resizing.
+
+Examples:
+    juju resize-storage pgdata/0 200G
+
+See also:
+    show-storage
+    storage
+`
+	resizeStorageCommandArgs = `<storage> <size>`
+)
+
+// resizeStorageCommand grows storage instances.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizeAPI, error)
+	storageId  string
+	size       uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.New("size must be greater than zero")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows the volume or filesystem backing a storage instance.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.ResizeStorage(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resizing %s to %s", c.storageId, humanize.IBytes(c.size*humanize.MiByte))
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	ResizeStorage(storageId string, size uint64) error
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type resizeStorageSuite struct {
+	SubStorageSuite
+	api *mockResizeAPI
+}
+
+var _ = gc.Suite(&resizeStorageSuite{})
+
+func (s *resizeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockResizeAPI{}
+}
+
+func (s *resizeStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewResizeStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *resizeStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "resize-storage requires a storage ID and a size")
+	_, err = s.run(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "resize-storage requires a storage ID and a size")
+	_, err = s.run(c, "foo", "10G")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+	_, err = s.run(c, "pgdata/0", "lots")
+	c.Assert(err, gc.ErrorMatches, `cannot parse size: .*`)
+	_, err = s.run(c, "pgdata/0", "0")
+	c.Assert(err, gc.ErrorMatches, "size must be greater than zero")
+	_, err = s.run(c, "pgdata/0", "10G", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *resizeStorageSuite) TestResize(c *gc.C) {
+	ctx, err := s.run(c, "pgdata/0", "10G")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"ResizeStorage", []interface{}{"pgdata/0", uint64(10 * 1024)}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing pgdata/0 to 10GiB\n")
+}
+
+func (s *resizeStorageSuite) TestResizeDefaultsToMiB(c *gc.C) {
+	_, err := s.run(c, "pgdata/0", "2048")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ResizeStorage", "pgdata/0", uint64(2048))
+}
+
+func (s *resizeStorageSuite) TestResizeError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "pgdata/0", "10G")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockResizeAPI struct {
+	testing.Stub
+}
+
+func (m *mockResizeAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockResizeAPI) ResizeStorage(id string, size uint64) error {
+	m.MethodCall(m, "ResizeStorage", id, size)
+	return m.NextErr()
+}
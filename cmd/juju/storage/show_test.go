@@ -74,6 +74,46 @@ shared-fs/0:
 	)
 }
 
+func (s *ShowSuite) TestShowUsage(c *gc.C) {
+	now := time.Now()
+	s.mockAPI.time = now
+	s.mockAPI.usage = &params.FilesystemUsage{
+		BytesUsed:  768 * 1024 * 1024,
+		BytesFree:  256 * 1024 * 1024,
+		InodesUsed: 10,
+		InodesFree: 90,
+		Updated:    &now,
+	}
+	since := common.FormatTime(&now, false)
+	s.assertValidShow(
+		c,
+		[]string{"shared-fs/0"},
+		fmt.Sprintf(`
+shared-fs/0:
+  kind: filesystem
+  status:
+    current: attached
+    since: %s
+  persistent: true
+  attachments:
+    units:
+      transcode/0:
+        machine: "1"
+        location: a location
+      transcode/1:
+        machine: "2"
+        location: b location
+  usage:
+    used: 768
+    free: 256
+    used-percent: 75
+    inodes-used: 10
+    inodes-free: 90
+    updated: %s
+`[1:], since, since),
+	)
+}
+
 func (s *ShowSuite) TestShowInvalidId(c *gc.C) {
 	_, err := s.runShow(c, []string{"foo"})
 	c.Assert(err, gc.ErrorMatches, ".*invalid storage id foo.*")
@@ -137,6 +177,7 @@ func (s *ShowSuite) assertValidShow(c *gc.C, args []string, expected string) {
 type mockShowAPI struct {
 	noMatch bool
 	time    time.Time
+	usage   *params.FilesystemUsage
 }
 
 func (s mockShowAPI) Close() error {
@@ -169,6 +210,7 @@ func (s mockShowAPI) StorageDetails(tags []names.StorageTag) ([]params.StorageDe
 						Location:   "b location",
 					},
 				},
+				Usage: s.usage,
 			}
 		} else {
 			all[i].Result = &params.StorageDetails{
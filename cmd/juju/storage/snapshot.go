@@ -0,0 +1,319 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewSnapshotStorageCommand returns a command used to snapshot the
+// volumes backing storage instances.
+func NewSnapshotStorageCommand() cmd.Command {
+	command := &snapshotStorageCommand{}
+	command.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return command.NewStorageAPI()
+	}
+	return modelcmd.Wrap(command)
+}
+
+const (
+	snapshotStorageCommandDoc = `
+Creates a snapshot of the volume backing each of the specified storage
+instances. Specify one or more storage IDs, as output by "juju storage".
+
+Snapshots are taken by the cloud's storage provider, and can later be used
+to create new storage with "juju add-storage --from-snapshot". Only volumes
+managed by the cloud, such as EBS or Cinder volumes, can be snapshotted;
+volumes local to a machine, such as loop devices, cannot.
+
+Examples:
+    juju snapshot-storage pgdata/0
+    juju snapshot-storage pgdata/0 pgdata/1
+
+See also:
+    add-storage
+    snapshots
+    storage
+`
+	snapshotStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+// snapshotStorageCommand creates snapshots of storage volumes.
+type snapshotStorageCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *snapshotStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Creates snapshots of storage volumes.",
+		Doc:     snapshotStorageCommandDoc,
+		Args:    snapshotStorageCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("created snapshot %s of %s", result.Result.SnapshotId, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewListSnapshotsCommand returns a command used to list the snapshots
+// of the volumes backing storage instances.
+func NewListSnapshotsCommand() cmd.Command {
+	command := &listSnapshotsCommand{}
+	command.newAPIFunc = func() (StorageSnapshotListAPI, error) {
+		return command.NewStorageAPI()
+	}
+	return modelcmd.Wrap(command)
+}
+
+const (
+	listSnapshotsCommandDoc = `
+Lists the snapshots of the volumes backing the specified storage instances.
+If no storage IDs are specified, the snapshots of all storage in the model
+whose storage provider supports snapshots are listed.
+
+Examples:
+    juju snapshots
+    juju snapshots pgdata/0
+
+See also:
+    snapshot-storage
+    storage
+`
+	listSnapshotsCommandArgs = `[<storage> ...]`
+)
+
+// listSnapshotsCommand lists snapshots of storage volumes.
+type listSnapshotsCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageSnapshotListAPI, error)
+	storageIds []string
+	out        cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "snapshots",
+		Purpose: "Lists snapshots of storage volumes.",
+		Doc:     listSnapshotsCommandDoc,
+		Args:    listSnapshotsCommandArgs,
+		Aliases: []string{"list-snapshots"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	storageIds := c.storageIds
+	if len(storageIds) == 0 {
+		details, err := api.ListStorageDetails()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, d := range details {
+			tag, err := names.ParseStorageTag(d.StorageTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			storageIds = append(storageIds, tag.Id())
+		}
+	}
+	if len(storageIds) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+
+	results, err := api.ListSnapshots(storageIds)
+	if err != nil {
+		return err
+	}
+	snapshots := make(map[string]SnapshotInfo)
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			if len(c.storageIds) == 0 && params.IsCodeNotSupported(result.Error) {
+				// We're listing all storage, so silently skip
+				// storage that doesn't support snapshots.
+				continue
+			}
+			ctx.Infof("failed to list snapshots of %s: %s", storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		for _, details := range result.Result {
+			snapshots[details.SnapshotId] = SnapshotInfo{
+				Storage:  storageIds[i],
+				VolumeId: details.VolumeId,
+				Size:     details.Size,
+				Created:  details.Created,
+				Status:   details.Status,
+			}
+		}
+	}
+	if len(snapshots) == 0 {
+		if !anyFailed {
+			ctx.Infof("No storage snapshots to display.")
+		}
+	} else if err := c.out.Write(ctx, snapshots); err != nil {
+		return errors.Trace(err)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// SnapshotInfo defines the serialization behaviour of storage snapshot
+// information.
+type SnapshotInfo struct {
+	Storage  string    `yaml:"storage" json:"storage"`
+	VolumeId string    `yaml:"volume-id" json:"volume-id"`
+	Size     uint64    `yaml:"size" json:"size"`
+	Created  time.Time `yaml:"created" json:"created"`
+	Status   string    `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// formatSnapshotListTabular returns a tabular summary of snapshots, keyed
+// on snapshot ID.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Snapshot", "Storage", "Volume", "Size", "Created", "Status")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := snapshots[ids[i]], snapshots[ids[j]]
+		if a.Storage != b.Storage {
+			return a.Storage < b.Storage
+		}
+		if !a.Created.Equal(b.Created) {
+			return a.Created.Before(b.Created)
+		}
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		snapshot := snapshots[id]
+		print(
+			id,
+			snapshot.Storage,
+			snapshot.VolumeId,
+			humanize.IBytes(snapshot.Size*humanize.MiByte),
+			common.FormatTime(&snapshot.Created, false),
+			snapshot.Status,
+		)
+	}
+	return tw.Flush()
+}
+
+// StorageSnapshotAPI defines the API methods that the snapshot-storage
+// command uses.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotResult, error)
+}
+
+// StorageSnapshotListAPI defines the API methods that the snapshots
+// command uses.
+type StorageSnapshotListAPI interface {
+	Close() error
+	ListStorageDetails() ([]params.StorageDetails, error)
+	ListSnapshots(storageIds []string) ([]params.VolumeSnapshotListResult, error)
+}
@@ -0,0 +1,210 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type snapshotStorageSuite struct {
+	SubStorageSuite
+	api *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotStorageSuite{})
+
+func (s *snapshotStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockSnapshotAPI{}
+}
+
+func (s *snapshotStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *snapshotStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot-storage requires at least one storage ID")
+	_, err = s.run(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *snapshotStorageSuite) TestSnapshot(c *gc.C) {
+	s.api.createSnapshots = func(ids []string) ([]params.VolumeSnapshotResult, error) {
+		return []params.VolumeSnapshotResult{
+			{Result: &params.VolumeSnapshotDetails{SnapshotId: "snap-0"}},
+			{Error: &params.Error{Message: "not supported"}},
+		}, nil
+	}
+	ctx, err := s.run(c, "pgdata/0", "pgdata/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"CreateSnapshots", []interface{}{[]string{"pgdata/0", "pgdata/1"}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+created snapshot snap-0 of pgdata/0
+failed to snapshot pgdata/1: not supported
+`[1:])
+}
+
+func (s *snapshotStorageSuite) TestSnapshotError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type listSnapshotsSuite struct {
+	SubStorageSuite
+	api *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&listSnapshotsSuite{})
+
+func (s *listSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.api = &mockSnapshotAPI{
+		listSnapshots: func(ids []string) ([]params.VolumeSnapshotListResult, error) {
+			results := make([]params.VolumeSnapshotListResult, len(ids))
+			for i, id := range ids {
+				switch id {
+				case "pgdata/0":
+					results[i].Result = []params.VolumeSnapshotDetails{{
+						SnapshotId: "snap-1",
+						StorageTag: "storage-pgdata-0",
+						VolumeId:   "vol-0",
+						Size:       2048,
+						Created:    created.Add(time.Hour),
+						Status:     "completed",
+					}, {
+						SnapshotId: "snap-0",
+						StorageTag: "storage-pgdata-0",
+						VolumeId:   "vol-0",
+						Size:       1024,
+						Created:    created,
+						Status:     "completed",
+					}}
+				default:
+					results[i].Error = &params.Error{
+						Message: "snapshotting volume with machine-scoped storage provider \"loop\" not supported",
+						Code:    params.CodeNotSupported,
+					}
+				}
+			}
+			return results, nil
+		},
+	}
+}
+
+func (s *listSnapshotsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), args...)
+}
+
+func (s *listSnapshotsSuite) TestListAll(c *gc.C) {
+	s.api.listStorageDetails = func() ([]params.StorageDetails, error) {
+		return []params.StorageDetails{
+			{StorageTag: "storage-pgdata-0"},
+			{StorageTag: "storage-logs-0"},
+		}, nil
+	}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"ListStorageDetails", nil},
+		{"ListSnapshots", []interface{}{[]string{"pgdata/0", "logs/0"}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Snapshot  Storage   Volume  Size    Created                Status
+snap-0    pgdata/0  vol-0   1.0GiB  02 Jan 2020 03:04:05Z  completed
+snap-1    pgdata/0  vol-0   2.0GiB  02 Jan 2020 04:04:05Z  completed
+
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *listSnapshotsSuite) TestListNone(c *gc.C) {
+	s.api.listStorageDetails = func() ([]params.StorageDetails, error) {
+		return nil, nil
+	}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "ListStorageDetails", "Close")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *listSnapshotsSuite) TestListSpecifiedNotSupported(c *gc.C) {
+	ctx, err := s.run(c, "logs/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	s.api.CheckCallNames(c, "ListSnapshots", "Close")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"failed to list snapshots of logs/0: snapshotting volume with machine-scoped storage provider \"loop\" not supported\n",
+	)
+}
+
+func (s *listSnapshotsSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.run(c, "pgdata/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+snap-0:
+  storage: pgdata/0
+  volume-id: vol-0
+  size: 1024
+  created: 2020-01-02T03:04:05Z
+  status: completed
+snap-1:
+  storage: pgdata/0
+  volume-id: vol-0
+  size: 2048
+  created: 2020-01-02T04:04:05Z
+  status: completed
+`[1:])
+}
+
+type mockSnapshotAPI struct {
+	testing.Stub
+	createSnapshots    func([]string) ([]params.VolumeSnapshotResult, error)
+	listSnapshots      func([]string) ([]params.VolumeSnapshotListResult, error)
+	listStorageDetails func() ([]params.StorageDetails, error)
+}
+
+func (m *mockSnapshotAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockSnapshotAPI) CreateSnapshots(ids []string) ([]params.VolumeSnapshotResult, error) {
+	m.MethodCall(m, "CreateSnapshots", ids)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.createSnapshots(ids)
+}
+
+func (m *mockSnapshotAPI) ListSnapshots(ids []string) ([]params.VolumeSnapshotListResult, error) {
+	m.MethodCall(m, "ListSnapshots", ids)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.listSnapshots(ids)
+}
+
+func (m *mockSnapshotAPI) ListStorageDetails() ([]params.StorageDetails, error) {
+	m.MethodCall(m, "ListStorageDetails")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.listStorageDetails()
+}
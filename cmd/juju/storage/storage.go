@@ -39,6 +39,48 @@ type StorageInfo struct {
 	Status      EntityStatus        `yaml:"status" json:"status"`
 	Persistent  bool                `yaml:"persistent" json:"persistent"`
 	Attachments *StorageAttachments `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+	Usage       *FilesystemUsage    `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// FilesystemUsage contains the last reported usage of a filesystem.
+type FilesystemUsage struct {
+	// Used is the amount of space used on the filesystem, in MiB.
+	Used uint64 `yaml:"used" json:"used"`
+
+	// Free is the amount of space available on the filesystem, in MiB.
+	Free uint64 `yaml:"free" json:"free"`
+
+	// UsedPercent is the percentage of the filesystem's capacity in use.
+	UsedPercent int `yaml:"used-percent" json:"used-percent"`
+
+	// InodesUsed is the number of inodes used on the filesystem.
+	InodesUsed uint64 `yaml:"inodes-used" json:"inodes-used"`
+
+	// InodesFree is the number of free inodes on the filesystem.
+	InodesFree uint64 `yaml:"inodes-free" json:"inodes-free"`
+
+	// Updated is the time at which the usage was reported.
+	Updated string `yaml:"updated,omitempty" json:"updated,omitempty"`
+}
+
+func createFilesystemUsage(usage *params.FilesystemUsage) *FilesystemUsage {
+	if usage == nil {
+		return nil
+	}
+	const mib = 1024 * 1024
+	out := &FilesystemUsage{
+		Used:       usage.BytesUsed / mib,
+		Free:       usage.BytesFree / mib,
+		InodesUsed: usage.InodesUsed,
+		InodesFree: usage.InodesFree,
+	}
+	if total := usage.BytesUsed + usage.BytesFree; total > 0 {
+		out.UsedPercent = int(usage.BytesUsed * 100 / total)
+	}
+	if usage.Updated != nil {
+		out.Updated = common.FormatTime(usage.Updated, false)
+	}
+	return out
 }
 
 // StorageAttachments contains details about all attachments to a storage
@@ -98,6 +140,7 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 			common.FormatTime(details.Status.Since, false),
 		},
 		Persistent: details.Persistent,
+		Usage:      createFilesystemUsage(details.Usage),
 	}
 
 	if len(details.Attachments) > 0 {
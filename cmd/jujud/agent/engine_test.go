@@ -33,6 +33,7 @@ var (
 	}
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-scheduler",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
@@ -56,6 +57,7 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"application-scaler",
 		"charm-revision-updater",
 		"compute-provisioner",
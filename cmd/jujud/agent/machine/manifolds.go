@@ -42,7 +42,9 @@ import (
 	"github.com/juju/juju/worker/apiserver"
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
+	"github.com/juju/juju/worker/auditlogpruner"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
@@ -90,6 +92,7 @@ import (
 	"github.com/juju/juju/worker/storageprovisioner"
 	"github.com/juju/juju/worker/terminationworker"
 	"github.com/juju/juju/worker/toolsversionchecker"
+	"github.com/juju/juju/worker/tracer"
 	"github.com/juju/juju/worker/txnpruner"
 	"github.com/juju/juju/worker/upgradedatabase"
 	"github.com/juju/juju/worker/upgrader"
@@ -611,6 +614,18 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			LogSource:     config.LogSource,
 		})),
 
+		// The tracer records traces of the work done in this process
+		// and exports them to the collector named in the controller
+		// config. We should only need one of these in a consolidated
+		// agent.
+		tracerName: ifNotMigrating(tracer.Manifold(tracer.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Logger:        loggo.GetLogger("juju.worker.tracer"),
+			NewWorker:     tracer.NewWorker,
+		})),
+
 		resumerName: ifNotMigrating(resumer.Manifold(resumer.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
@@ -641,6 +656,16 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		auditLogPrunerName: ifNotMigrating(ifPrimaryController(auditlogpruner.Manifold(
+			auditlogpruner.ManifoldConfig{
+				ClockName:     clockName,
+				StateName:     stateName,
+				PruneInterval: auditlogpruner.DefaultPruneInterval,
+				Logger:        loggo.GetLogger("juju.worker.auditlogpruner"),
+				NewWorker:     auditlogpruner.New,
+			},
+		))),
+
 		httpServerArgsName: httpserverargs.Manifold(httpserverargs.ManifoldConfig{
 			ClockName:             clockName,
 			ControllerPortName:    controllerPortName,
@@ -726,9 +751,12 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		})),
 
 		auditConfigUpdaterName: ifController(auditconfigupdater.Manifold(auditconfigupdater.ManifoldConfig{
-			AgentName: agentName,
-			StateName: stateName,
-			NewWorker: auditconfigupdater.New,
+			AgentName:            agentName,
+			StateName:            stateName,
+			Clock:                config.Clock,
+			Logger:               loggo.GetLogger("juju.worker.auditconfigupdater"),
+			PrometheusRegisterer: config.PrometheusRegisterer,
+			NewWorker:            auditconfigupdater.New,
 		})),
 
 		raftTransportName: ifController(rafttransport.Manifold(rafttransport.ManifoldConfig{
@@ -979,6 +1007,19 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewClient:     instancemutater.NewClient,
 			NewWorker:     instancemutater.NewContainerWorker,
 		})),
+
+		// The backup scheduler creates controller backups on the
+		// schedule in the controller config. Backups aren't
+		// supported on kubernetes controllers.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName: agentName,
+				ClockName: clockName,
+				StateName: stateName,
+				Logger:    loggo.GetLogger("juju.worker.backupscheduler"),
+				NewWorker: backupscheduler.New,
+			},
+		))),
 	}
 
 	return mergeManifolds(config, manifolds)
@@ -1103,6 +1144,7 @@ const (
 	apiAddressUpdaterName         = "api-address-updater"
 	machinerName                  = "machiner"
 	logSenderName                 = "log-sender"
+	tracerName                    = "tracer"
 	deployerName                  = "deployer"
 	authenticationWorkerName      = "ssh-authkeys-updater"
 	storageProvisionerName        = "storage-provisioner"
@@ -1118,6 +1160,8 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	txnPrunerName                 = "transaction-pruner"
+	auditLogPrunerName            = "audit-log-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelCacheInitializedFlagName = "model-cache-initialized-flag"
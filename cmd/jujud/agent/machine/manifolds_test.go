@@ -65,6 +65,8 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"audit-log-pruner",
+			"backup-scheduler",
 			"broker-tracker",
 			"central-hub",
 			"certificate-updater",
@@ -114,6 +116,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"storage-provisioner",
 			"termination-signal-handler",
 			"tools-version-checker",
+			"tracer",
 			"transaction-pruner",
 			"unconverted-api-workers",
 			"upgrade-check-flag",
@@ -143,6 +146,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"audit-log-pruner",
 			"central-hub",
 			"certificate-watcher",
 			"clock",
@@ -179,6 +183,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"state",
 			"state-config-watcher",
 			"termination-signal-handler",
+			"tracer",
 			"transaction-pruner",
 			"unconverted-api-workers",
 			"upgrade-check-flag",
@@ -302,6 +307,8 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"upgrade-database-runner",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"audit-log-pruner",
+		"backup-scheduler",
 		"external-controller-updater",
 		"transaction-pruner",
 	)
@@ -464,6 +471,40 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"audit-log-pruner": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"broker-tracker": {
 		"agent",
 		"api-caller",
@@ -987,6 +1028,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"tracer": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"transaction-pruner": {
 		"agent",
 		"api-caller",
@@ -103,6 +103,8 @@ func (c *applicationCommand) Run(ctx *cmd.Context) error {
 		}
 
 		switch c.appInfo.Life {
+		case "":
+			ctx.Infof("Application %q was not found", c.name)
 		case life.Dead:
 			ctx.Infof("Application %q has been removed", c.name)
 		case life.Dying:
@@ -103,6 +103,8 @@ func (c *machineCommand) Run(ctx *cmd.Context) error {
 		}
 
 		switch c.machineInfo.Life {
+		case "":
+			ctx.Infof("Machine %q was not found", c.id)
 		case life.Dead:
 			ctx.Infof("Machine %q has been removed", c.id)
 		case life.Dying:
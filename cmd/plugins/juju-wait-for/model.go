@@ -104,7 +104,11 @@ func (c *modelCommand) Run(ctx *cmd.Context) error {
 	scopedContext := MakeScopeContext()
 
 	defer func() {
-		if c.model == nil || !c.summary {
+		if !c.summary {
+			return
+		}
+		if c.model == nil {
+			ctx.Infof("Model %q was not found", c.name)
 			return
 		}
 
@@ -87,11 +87,14 @@ func (s *Strategy) run(q query.Query, name string, input string, fn StrategyFunc
 	}()
 
 	timeout := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
 		select {
 		case <-time.After(s.Timeout):
 			close(timeout)
 			_ = watcher.Stop()
+		case <-done:
 		}
 	}()
 
@@ -100,7 +103,7 @@ func (s *Strategy) run(q query.Query, name string, input string, fn StrategyFunc
 		if err != nil {
 			select {
 			case <-timeout:
-				return errors.Errorf("timed out waiting for %q to reach goal state", name)
+				return errors.Errorf("timed out after %v waiting for %q to reach goal state: %s", s.Timeout, name, input)
 			default:
 				return errors.Trace(err)
 			}
@@ -4,9 +4,11 @@
 package main
 
 import (
+	"sync"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -112,6 +114,39 @@ func (s *strategySuite) TestRunWithInvalidQuery(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `Syntax Error:<:1:7> invalid character '<UNKNOWN>' found`)
 }
 
+func (s *strategySuite) TestRunTimeout(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	stopped := make(chan struct{})
+	var once sync.Once
+
+	allWatcher := mocks.NewMockAllWatcher(ctrl)
+	allWatcher.EXPECT().Next().DoAndReturn(func() ([]params.Delta, error) {
+		<-stopped
+		return nil, errors.New("watcher was stopped")
+	})
+	allWatcher.EXPECT().Stop().DoAndReturn(func() error {
+		once.Do(func() { close(stopped) })
+		return nil
+	}).AnyTimes()
+
+	client := mocks.NewMockWatchAllAPI(ctrl)
+	client.EXPECT().WatchAll().Return(allWatcher, nil)
+
+	strategy := Strategy{
+		ClientFn: func() (api.WatchAllAPI, error) {
+			return client, nil
+		},
+		Timeout: 10 * time.Millisecond,
+	}
+	err := strategy.Run("generic", `life=="active"`, func(_ string, d []params.Delta, _ query.Query) (bool, error) {
+		c.FailNow()
+		return false, nil
+	})
+	c.Assert(err, gc.ErrorMatches, `timed out after 10ms waiting for "generic" to reach goal state: life=="active"`)
+}
+
 type MockEntityInfo struct {
 	Name    string `json:"name"`
 	Integer int    `json:"int"`
@@ -43,7 +43,7 @@ name
    unit name identifier
 
 options:
---query (= 'life=="alive" && status=="active"')
+--query (= 'life=="alive" && workload-status=="active"')
    query represents the goal state of a given unit
 `
 
@@ -103,6 +103,8 @@ func (c *unitCommand) Run(ctx *cmd.Context) error {
 		}
 
 		switch c.unitInfo.Life {
+		case "":
+			ctx.Infof("Unit %q was not found", c.name)
 		case life.Dead:
 			ctx.Infof("Unit %q has been removed", c.name)
 		case life.Dying:
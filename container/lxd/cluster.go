@@ -3,6 +3,11 @@
 
 package lxd
 
+import (
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared/api"
+)
+
 func (s *Server) ClusterSupported() bool {
 	return s.clusterAPISupport
 }
@@ -14,3 +19,63 @@ func (s Server) UseTargetServer(name string) (*Server, error) {
 	logger.Debugf("creating LXD server for cluster node %q", name)
 	return NewServer(s.UseTarget(name))
 }
+
+// MoveContainer moves the container with the input name to the input
+// cluster member. If live is true, the container is migrated along with
+// its running state, which requires CRIU to be available on both members.
+// Otherwise a running container is stopped before it is moved, and
+// started again on the target member.
+func (s *Server) MoveContainer(name, member string, live bool) error {
+	if !s.clustered {
+		return errors.NotSupportedf("moving containers between members of an unclustered server")
+	}
+	logger.Debugf("moving container %q to cluster node %q (live: %v)", name, member, live)
+
+	req := api.ContainerPost{
+		Name:      name,
+		Migration: true,
+		Live:      live,
+	}
+	if live {
+		op, err := s.UseTarget(member).MigrateContainer(name, req)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(op.Wait())
+	}
+
+	state, eTag, err := s.GetContainerState(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	running := state.StatusCode != api.Stopped
+	if running {
+		stopReq := api.ContainerStatePut{
+			Action:  "stop",
+			Timeout: -1,
+		}
+		op, err := s.UpdateContainerState(name, stopReq, eTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := op.Wait(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	op, err := s.UseTarget(member).MigrateContainer(name, req)
+	if err == nil {
+		err = op.Wait()
+	}
+	if running {
+		// Start the container wherever it now resides, so that a
+		// failed move does not leave it stopped.
+		if startErr := s.StartContainer(name); startErr != nil {
+			if err == nil {
+				return errors.Trace(startErr)
+			}
+			logger.Errorf("restarting container %q after failed move: %v", name, startErr)
+		}
+	}
+	return errors.Trace(err)
+}
@@ -6,6 +6,7 @@ package lxd_test
 import (
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/container/lxd"
@@ -51,3 +52,106 @@ func (s *imageSuite) TestUseTargetBadNode(c *gc.C) {
 	_, err = jujuSvr.UseTargetServer("cluster-2")
 	c.Assert(err, gc.ErrorMatches, "not a cluster member")
 }
+
+func (s *clusterSuite) TestMoveContainerLive(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	migrateOp := lxdtesting.NewMockOperation(ctrl)
+	migrateOp.EXPECT().Wait().Return(nil)
+
+	c1Svr.EXPECT().UseTarget("cluster-2").Return(c2Svr)
+	c2Svr.EXPECT().MigrateContainer("juju-0", api.ContainerPost{
+		Name:      "juju-0",
+		Migration: true,
+		Live:      true,
+	}).Return(migrateOp, nil)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("juju-0", "cluster-2", true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clusterSuite) TestMoveContainerStopsAndStartsRunningContainer(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	stopOp := lxdtesting.NewMockOperation(ctrl)
+	stopOp.EXPECT().Wait().Return(nil)
+	migrateOp := lxdtesting.NewMockOperation(ctrl)
+	migrateOp.EXPECT().Wait().Return(nil)
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	stopReq := api.ContainerStatePut{
+		Action:  "stop",
+		Timeout: -1,
+	}
+	startReq := api.ContainerStatePut{
+		Action:  "start",
+		Timeout: -1,
+	}
+
+	exp := c1Svr.EXPECT()
+	gomock.InOrder(
+		exp.GetContainerState("juju-0").Return(&api.ContainerState{StatusCode: api.Running}, lxdtesting.ETag, nil),
+		exp.UpdateContainerState("juju-0", stopReq, lxdtesting.ETag).Return(stopOp, nil),
+		exp.UseTarget("cluster-2").Return(c2Svr),
+		c2Svr.EXPECT().MigrateContainer("juju-0", api.ContainerPost{
+			Name:      "juju-0",
+			Migration: true,
+		}).Return(migrateOp, nil),
+		exp.UpdateContainerState("juju-0", startReq, "").Return(startOp, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("juju-0", "cluster-2", false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clusterSuite) TestMoveContainerRestartsOnFailure(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	stopOp := lxdtesting.NewMockOperation(ctrl)
+	stopOp.EXPECT().Wait().Return(nil)
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	exp := c1Svr.EXPECT()
+	exp.GetContainerState("juju-0").Return(&api.ContainerState{StatusCode: api.Running}, lxdtesting.ETag, nil)
+	exp.UpdateContainerState("juju-0", gomock.Any(), lxdtesting.ETag).Return(stopOp, nil)
+	exp.UseTarget("cluster-2").Return(c2Svr)
+	c2Svr.EXPECT().MigrateContainer("juju-0", gomock.Any()).Return(nil, errors.New("no space left"))
+	exp.UpdateContainerState("juju-0", gomock.Any(), "").Return(startOp, nil)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("juju-0", "cluster-2", false)
+	c.Assert(err, gc.ErrorMatches, "no space left")
+}
+
+func (s *clusterSuite) TestMoveContainerNotClustered(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	jujuSvr, err := lxd.NewServer(s.NewMockServer(ctrl))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("juju-0", "cluster-2", false)
+	c.Assert(err, gc.ErrorMatches, "moving containers between members of an unclustered server not supported")
+}
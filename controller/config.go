@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/charmrepo/v7/csclient"
@@ -16,10 +17,13 @@ import (
 	"github.com/juju/romulus"
 	"github.com/juju/schema"
 	"github.com/juju/utils/v2"
+	"golang.org/x/crypto/openpgp"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 
+	"github.com/juju/juju/core/cron"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/pki"
 )
 
@@ -82,6 +86,38 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogMaxAge is the maximum age of records kept in the
+	// controller's audit log collection, eg "2160h".
+	AuditLogMaxAge = "audit-log-max-age"
+
+	// AuditLogMaxDBSize is the maximum size of the controller's
+	// audit log collection, eg "1G".
+	AuditLogMaxDBSize = "audit-log-max-db-size"
+
+	// AuditLogSyslogHost is the host:port of a syslog server that
+	// audit records are forwarded to over TLS, using RFC 5424.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogSyslogCACert is the CA certificate (PEM-encoded) used
+	// to validate the audit syslog server's certificate.
+	AuditLogSyslogCACert = "audit-log-syslog-ca-cert"
+
+	// AuditLogSyslogClientCert is the client certificate (PEM-encoded)
+	// presented to the audit syslog server.
+	AuditLogSyslogClientCert = "audit-log-syslog-client-cert"
+
+	// AuditLogSyslogClientKey is the private key (PEM-encoded) of the
+	// client certificate presented to the audit syslog server.
+	AuditLogSyslogClientKey = "audit-log-syslog-client-key"
+
+	// AuditLogWebhookURL is the URL that audit records are POSTed
+	// to as they are recorded.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogWebhookSecret is the key used to sign the audit records
+	// POSTed to the audit webhook.
+	AuditLogWebhookSecret = "audit-log-webhook-secret"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -225,6 +261,26 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogMaxAge is the default maximum age of records
+	// kept in the audit log collection.
+	DefaultAuditLogMaxAge = 90 * 24 * time.Hour
+
+	// DefaultAuditLogMaxDBSizeMB is the default maximum size in MB
+	// of the audit log collection.
+	DefaultAuditLogMaxDBSizeMB = 1024
+
+	// DefaultBackupRetentionDaily is the default number of days
+	// for which a scheduled backup is kept.
+	DefaultBackupRetentionDaily = 7
+
+	// DefaultBackupRetentionWeekly is the default number of weeks
+	// for which a scheduled backup is kept.
+	DefaultBackupRetentionWeekly = 4
+
+	// DefaultBackupMaxAge is the default age of the last successful
+	// scheduled backup after which backups are overdue.
+	DefaultBackupMaxAge = 48 * time.Hour
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -292,6 +348,10 @@ const (
 	// non-synced-writes-to-raft-log value. It is set to false by default.
 	DefaultNonSyncedWritesToRaftLog = false
 
+	// DefaultOpenTelemetryEnabled is the default value for the
+	// OpenTelemetryEnabled config value.
+	DefaultOpenTelemetryEnabled = false
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -317,6 +377,55 @@ const (
 
 	// PublicDNSAddress is the public DNS address (and port) of the controller.
 	PublicDNSAddress = "public-dns-address"
+
+	// OpenTelemetryEnabled determines whether the controller and its
+	// agents record traces of their work.
+	OpenTelemetryEnabled = "open-telemetry-enabled"
+
+	// OpenTelemetryEndpoint is the URL of the OpenTelemetry collector
+	// that traces are exported to, using OTLP over HTTP.
+	OpenTelemetryEndpoint = "open-telemetry-endpoint"
+
+	// BackupStorageS3Endpoint is the URL of the S3-compatible object
+	// store that backup archives kept by the controller are stored in.
+	// When it isn't set, archives are stored in the controller's database.
+	BackupStorageS3Endpoint = "backup-storage-s3-endpoint"
+
+	// BackupStorageS3Region is the region of the backup object store.
+	BackupStorageS3Region = "backup-storage-s3-region"
+
+	// BackupStorageS3Bucket is the bucket backup archives are stored in.
+	BackupStorageS3Bucket = "backup-storage-s3-bucket"
+
+	// BackupStorageS3AccessKey is the access key used to
+	// authenticate with the backup object store.
+	BackupStorageS3AccessKey = "backup-storage-s3-access-key"
+
+	// BackupStorageS3SecretKey is the secret key used to
+	// authenticate with the backup object store.
+	BackupStorageS3SecretKey = "backup-storage-s3-secret-key"
+
+	// BackupSchedule is the cron expression describing when the
+	// controller backs itself up. Scheduled backups are disabled
+	// when it isn't set.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetentionDaily is the number of days for which the
+	// most recent scheduled backup of the day is kept.
+	BackupRetentionDaily = "backup-retention-daily"
+
+	// BackupRetentionWeekly is the number of weeks for which the
+	// most recent scheduled backup of the week is kept.
+	BackupRetentionWeekly = "backup-retention-weekly"
+
+	// BackupMaxAge is how old the last successful scheduled backup
+	// may be before the controller warns that backups are overdue.
+	BackupMaxAge = "backup-max-age"
+
+	// BackupEncryptionKeys is the armored OpenPGP public key ring
+	// that scheduled backup archives are encrypted to. Scheduled
+	// backups aren't encrypted when it isn't set.
+	BackupEncryptionKeys = "backup-encryption-keys"
 )
 
 var (
@@ -358,6 +467,14 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogMaxAge,
+		AuditLogMaxDBSize,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogWebhookSecret,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -365,6 +482,18 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		OpenTelemetryEnabled,
+		OpenTelemetryEndpoint,
+		BackupStorageS3Endpoint,
+		BackupStorageS3Region,
+		BackupStorageS3Bucket,
+		BackupStorageS3AccessKey,
+		BackupStorageS3SecretKey,
+		BackupSchedule,
+		BackupRetentionDaily,
+		BackupRetentionWeekly,
+		BackupMaxAge,
+		BackupEncryptionKeys,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -389,6 +518,14 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogMaxAge,
+		AuditLogMaxDBSize,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogWebhookSecret,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -411,6 +548,25 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		OpenTelemetryEnabled,
+		OpenTelemetryEndpoint,
+		BackupStorageS3Endpoint,
+		BackupStorageS3Region,
+		BackupStorageS3Bucket,
+		BackupStorageS3AccessKey,
+		BackupStorageS3SecretKey,
+		BackupSchedule,
+		BackupRetentionDaily,
+		BackupRetentionWeekly,
+		BackupMaxAge,
+		BackupEncryptionKeys,
+	)
+
+	// SecretConfigAttributes contains the controller config attributes
+	// which hold credentials, and so are not shown to clients.
+	SecretConfigAttributes = set.NewStrings(
+		AuditLogSyslogClientKey,
+		AuditLogWebhookSecret,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -483,6 +639,18 @@ func (c Config) intOrDefault(name string, defaultVal int) int {
 	return defaultVal
 }
 
+// countOrDefault is like intOrDefault, but allows the value to be zero.
+func (c Config) countOrDefault(name string, defaultVal int) int {
+	switch v := c[name].(type) {
+	case int:
+		return v
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(v)
+	}
+	return defaultVal
+}
+
 func (c Config) sizeMBOrDefault(name string, defaultVal int) int {
 	size := c.asString(name)
 	if size != "" {
@@ -626,6 +794,44 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogMaxAge returns the maximum age of records kept
+// in the controller's audit log collection.
+func (c Config) AuditLogMaxAge() time.Duration {
+	return c.durationOrDefault(AuditLogMaxAge, DefaultAuditLogMaxAge)
+}
+
+// AuditLogMaxDBSizeMB returns the maximum size in MB of
+// the controller's audit log collection.
+func (c Config) AuditLogMaxDBSizeMB() int {
+	return c.sizeMBOrDefault(AuditLogMaxDBSize, DefaultAuditLogMaxDBSizeMB)
+}
+
+// AuditLogSyslogConfig returns the configuration of the syslog
+// server that audit records are forwarded to. The returned config
+// is only enabled if a host has been specified.
+func (c Config) AuditLogSyslogConfig() syslog.RawConfig {
+	host := c.asString(AuditLogSyslogHost)
+	return syslog.RawConfig{
+		Enabled:    host != "",
+		Host:       host,
+		CACert:     c.asString(AuditLogSyslogCACert),
+		ClientCert: c.asString(AuditLogSyslogClientCert),
+		ClientKey:  c.asString(AuditLogSyslogClientKey),
+	}
+}
+
+// AuditLogWebhookURL returns the URL audit records are
+// POSTed to, or "" if they're not sent to a webhook.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogWebhookSecret returns the key used to sign
+// the audit records sent to the webhook.
+func (c Config) AuditLogWebhookSecret() string {
+	return c.asString(AuditLogWebhookSecret)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -853,6 +1059,107 @@ func (c Config) NonSyncedWritesToRaftLog() bool {
 	return DefaultNonSyncedWritesToRaftLog
 }
 
+// OpenTelemetryEnabled returns whether the controller and its
+// agents should record traces.
+func (c Config) OpenTelemetryEnabled() bool {
+	if v, ok := c[OpenTelemetryEnabled]; ok {
+		return v.(bool)
+	}
+	return DefaultOpenTelemetryEnabled
+}
+
+// OpenTelemetryEndpoint returns the URL of the collector
+// that traces are exported to.
+func (c Config) OpenTelemetryEndpoint() string {
+	return c.asString(OpenTelemetryEndpoint)
+}
+
+// BackupStorageS3Config holds the details of the S3-compatible
+// object store that backup archives are stored in.
+type BackupStorageS3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// Enabled returns whether backup archives are stored in the object store.
+func (cfg BackupStorageS3Config) Enabled() bool {
+	return cfg.Endpoint != ""
+}
+
+// Validate returns an error if the object store config isn't valid.
+func (cfg BackupStorageS3Config) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", BackupStorageS3Endpoint)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return errors.NotValidf("%s %q", BackupStorageS3Endpoint, cfg.Endpoint)
+	}
+	for _, required := range []struct {
+		key, value string
+	}{
+		{BackupStorageS3Bucket, cfg.Bucket},
+		{BackupStorageS3AccessKey, cfg.AccessKey},
+		{BackupStorageS3SecretKey, cfg.SecretKey},
+	} {
+		if required.value == "" {
+			return errors.Errorf("%s must be set if %s is set", required.key, BackupStorageS3Endpoint)
+		}
+	}
+	return nil
+}
+
+// BackupStorageS3Config returns the details of the object store
+// backup archives are stored in. The returned config is only
+// enabled if an endpoint has been specified.
+func (c Config) BackupStorageS3Config() BackupStorageS3Config {
+	return BackupStorageS3Config{
+		Endpoint:  c.asString(BackupStorageS3Endpoint),
+		Region:    c.asString(BackupStorageS3Region),
+		Bucket:    c.asString(BackupStorageS3Bucket),
+		AccessKey: c.asString(BackupStorageS3AccessKey),
+		SecretKey: c.asString(BackupStorageS3SecretKey),
+	}
+}
+
+// BackupSchedule returns the cron expression describing when the
+// controller backs itself up, or "" if scheduled backups are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupRetentionDaily returns the number of days for which
+// the most recent scheduled backup of the day is kept.
+func (c Config) BackupRetentionDaily() int {
+	return c.countOrDefault(BackupRetentionDaily, DefaultBackupRetentionDaily)
+}
+
+// BackupRetentionWeekly returns the number of weeks for which
+// the most recent scheduled backup of the week is kept.
+func (c Config) BackupRetentionWeekly() int {
+	return c.countOrDefault(BackupRetentionWeekly, DefaultBackupRetentionWeekly)
+}
+
+// BackupMaxAge returns how old the last successful scheduled backup
+// may be before backups are overdue. Zero means backups are never
+// overdue.
+func (c Config) BackupMaxAge() time.Duration {
+	return c.durationOrDefault(BackupMaxAge, DefaultBackupMaxAge)
+}
+
+// BackupEncryptionKeys returns the armored OpenPGP public key ring
+// that scheduled backup archives are encrypted to, or "" if they
+// aren't encrypted.
+func (c Config) BackupEncryptionKeys() string {
+	return c.asString(BackupEncryptionKeys)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -997,6 +1304,76 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogMaxAge].(time.Duration); ok && v < 0 {
+		return errors.Errorf("%s cannot be negative", AuditLogMaxAge)
+	}
+
+	if v, ok := c[AuditLogMaxDBSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid audit log max db size in configuration")
+		}
+	}
+
+	if err := c.AuditLogSyslogConfig().Validate(); err != nil {
+		return errors.Annotate(err, "invalid audit log syslog configuration")
+	}
+
+	if v, ok := c[AuditLogWebhookURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", AuditLogWebhookURL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.NotValidf("%s %q", AuditLogWebhookURL, v)
+		}
+		if c.AuditLogWebhookSecret() == "" {
+			return errors.Errorf("%s must be set if %s is set", AuditLogWebhookSecret, AuditLogWebhookURL)
+		}
+	}
+
+	if v, ok := c[OpenTelemetryEndpoint].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", OpenTelemetryEndpoint)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("%s %q", OpenTelemetryEndpoint, v)
+		}
+	}
+	if c.OpenTelemetryEnabled() && c.OpenTelemetryEndpoint() == "" {
+		return errors.Errorf("%s must be set if %s is true", OpenTelemetryEndpoint, OpenTelemetryEnabled)
+	}
+
+	if err := c.BackupStorageS3Config().Validate(); err != nil {
+		return errors.Annotate(err, "invalid backup storage configuration")
+	}
+
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupSchedule)
+		}
+	}
+	for _, key := range []string{BackupRetentionDaily, BackupRetentionWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("%s cannot be negative", key)
+		}
+	}
+	if c.BackupSchedule() != "" && c.BackupRetentionDaily() == 0 && c.BackupRetentionWeekly() == 0 {
+		return errors.Errorf("%s or %s must be set if %s is set", BackupRetentionDaily, BackupRetentionWeekly, BackupSchedule)
+	}
+	if v, ok := c[BackupMaxAge].(time.Duration); ok && v < 0 {
+		return errors.Errorf("%s cannot be negative", BackupMaxAge)
+	}
+	if v, ok := c[BackupEncryptionKeys].(string); ok && v != "" {
+		keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(v))
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupEncryptionKeys)
+		}
+		if len(keys) == 0 {
+			return errors.Errorf("invalid %s: no keys", BackupEncryptionKeys)
+		}
+	}
+
 	if v, ok := c[AuditLogExcludeMethods].([]interface{}); ok {
 		for i, name := range v {
 			name := name.(string)
@@ -1116,6 +1493,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:          schema.String(),
 	AuditLogMaxBackups:       schema.ForceInt(),
 	AuditLogExcludeMethods:   schema.List(schema.String()),
+	AuditLogMaxAge:           schema.TimeDuration(),
+	AuditLogMaxDBSize:        schema.String(),
+	AuditLogSyslogHost:       schema.String(),
+	AuditLogSyslogCACert:     schema.String(),
+	AuditLogSyslogClientCert: schema.String(),
+	AuditLogSyslogClientKey:  schema.String(),
+	AuditLogWebhookURL:       schema.String(),
+	AuditLogWebhookSecret:    schema.String(),
 	APIPort:                  schema.ForceInt(),
 	APIPortOpenDelay:         schema.String(),
 	ControllerAPIPort:        schema.ForceInt(),
@@ -1149,6 +1534,18 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:        schema.ForceInt(),
 	MaxAgentStateSize:        schema.ForceInt(),
 	NonSyncedWritesToRaftLog: schema.Bool(),
+	OpenTelemetryEnabled:     schema.Bool(),
+	OpenTelemetryEndpoint:    schema.String(),
+	BackupStorageS3Endpoint:  schema.String(),
+	BackupStorageS3Region:    schema.String(),
+	BackupStorageS3Bucket:    schema.String(),
+	BackupStorageS3AccessKey: schema.String(),
+	BackupStorageS3SecretKey: schema.String(),
+	BackupSchedule:           schema.String(),
+	BackupRetentionDaily:     schema.ForceInt(),
+	BackupRetentionWeekly:    schema.ForceInt(),
+	BackupMaxAge:             schema.TimeDuration(),
+	BackupEncryptionKeys:     schema.String(),
 }, schema.Defaults{
 	AgentRateLimitMax:        schema.Omit,
 	AgentRateLimitRate:       schema.Omit,
@@ -1161,6 +1558,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:          fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:       DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:   DefaultAuditLogExcludeMethods,
+	AuditLogMaxAge:           DefaultAuditLogMaxAge,
+	AuditLogMaxDBSize:        fmt.Sprintf("%vM", DefaultAuditLogMaxDBSizeMB),
+	AuditLogSyslogHost:       schema.Omit,
+	AuditLogSyslogCACert:     schema.Omit,
+	AuditLogSyslogClientCert: schema.Omit,
+	AuditLogSyslogClientKey:  schema.Omit,
+	AuditLogWebhookURL:       schema.Omit,
+	AuditLogWebhookSecret:    schema.Omit,
 	StatePort:                DefaultStatePort,
 	IdentityURL:              schema.Omit,
 	IdentityPublicKey:        schema.Omit,
@@ -1190,6 +1595,18 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:        DefaultMaxCharmStateSize,
 	MaxAgentStateSize:        DefaultMaxAgentStateSize,
 	NonSyncedWritesToRaftLog: DefaultNonSyncedWritesToRaftLog,
+	OpenTelemetryEnabled:     DefaultOpenTelemetryEnabled,
+	OpenTelemetryEndpoint:    schema.Omit,
+	BackupStorageS3Endpoint:  schema.Omit,
+	BackupStorageS3Region:    schema.Omit,
+	BackupStorageS3Bucket:    schema.Omit,
+	BackupStorageS3AccessKey: schema.Omit,
+	BackupStorageS3SecretKey: schema.Omit,
+	BackupSchedule:           schema.Omit,
+	BackupRetentionDaily:     DefaultBackupRetentionDaily,
+	BackupRetentionWeekly:    DefaultBackupRetentionWeekly,
+	BackupMaxAge:             DefaultBackupMaxAge,
+	BackupEncryptionKeys:     schema.Omit,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1224,6 +1641,38 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.FieldType("list of strings"),
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogMaxAge: {
+		Type:        environschema.Tstring,
+		Description: "The maximum age of records kept in the controller audit log collection",
+	},
+	AuditLogMaxDBSize: {
+		Type:        environschema.Tstring,
+		Description: "The maximum size of the controller audit log collection",
+	},
+	AuditLogSyslogHost: {
+		Type:        environschema.Tstring,
+		Description: "The host:port of a syslog server that audit records are forwarded to over TLS",
+	},
+	AuditLogSyslogCACert: {
+		Type:        environschema.Tstring,
+		Description: "The CA certificate used to validate the audit syslog server's certificate, in PEM format",
+	},
+	AuditLogSyslogClientCert: {
+		Type:        environschema.Tstring,
+		Description: "The client certificate presented to the audit syslog server, in PEM format",
+	},
+	AuditLogSyslogClientKey: {
+		Type:        environschema.Tstring,
+		Description: "The private key of the client certificate presented to the audit syslog server, in PEM format",
+	},
+	AuditLogWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: "The URL that audit records are POSTed to as they are recorded",
+	},
+	AuditLogWebhookSecret: {
+		Type:        environschema.Tstring,
+		Description: "The key used to sign the audit records POSTed to the audit webhook",
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -1362,4 +1811,52 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tbool,
 		Description: `Do not perform fsync calls after appending entries to the raft log. Disabling sync improves performance at the cost of reliability`,
 	},
+	OpenTelemetryEnabled: {
+		Type:        environschema.Tbool,
+		Description: `Determines if the controller and its agents record traces and export them to the open-telemetry-endpoint`,
+	},
+	OpenTelemetryEndpoint: {
+		Type:        environschema.Tstring,
+		Description: `The URL of the OpenTelemetry collector that traces are exported to using OTLP over HTTP`,
+	},
+	BackupStorageS3Endpoint: {
+		Type:        environschema.Tstring,
+		Description: `The URL of the S3-compatible object store backup archives kept by the controller are stored in, instead of the controller's database`,
+	},
+	BackupStorageS3Region: {
+		Type:        environschema.Tstring,
+		Description: `The region of the backup object store`,
+	},
+	BackupStorageS3Bucket: {
+		Type:        environschema.Tstring,
+		Description: `The bucket in the backup object store that backup archives are stored in`,
+	},
+	BackupStorageS3AccessKey: {
+		Type:        environschema.Tstring,
+		Description: `The access key used to authenticate with the backup object store`,
+	},
+	BackupStorageS3SecretKey: {
+		Type:        environschema.Tstring,
+		Description: `The secret key used to authenticate with the backup object store`,
+	},
+	BackupSchedule: {
+		Type:        environschema.Tstring,
+		Description: `A cron expression describing when the controller backs itself up, for example "@daily"`,
+	},
+	BackupRetentionDaily: {
+		Type:        environschema.Tint,
+		Description: `The number of days for which the most recent scheduled backup of the day is kept`,
+	},
+	BackupRetentionWeekly: {
+		Type:        environschema.Tint,
+		Description: `The number of weeks for which the most recent scheduled backup of the week is kept`,
+	},
+	BackupMaxAge: {
+		Type:        environschema.Tstring,
+		Description: `How old the last successful scheduled backup may be before show-controller warns that backups are overdue (0 to never warn)`,
+	},
+	BackupEncryptionKeys: {
+		Type:        environschema.Tstring,
+		Description: `The armored OpenPGP public keys that scheduled backup archives are encrypted to`,
+	},
 }
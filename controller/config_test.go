@@ -15,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -345,6 +346,106 @@ var newConfigTests = []struct {
 		controller.PublicDNSAddress: 42,
 	},
 	expectError: `public-dns-address: expected string, got int\(42\)`,
+}, {
+	about: "open-telemetry-endpoint not a URL",
+	config: controller.Config{
+		controller.OpenTelemetryEndpoint: "collector:4318",
+	},
+	expectError: `open-telemetry-endpoint "collector:4318" not valid`,
+}, {
+	about: "open-telemetry-enabled without endpoint",
+	config: controller.Config{
+		controller.OpenTelemetryEnabled: true,
+	},
+	expectError: `open-telemetry-endpoint must be set if open-telemetry-enabled is true`,
+}, {
+	about: "negative audit-log-max-age",
+	config: controller.Config{
+		controller.AuditLogMaxAge: "-1h",
+	},
+	expectError: `audit-log-max-age cannot be negative`,
+}, {
+	about: "invalid audit-log-max-db-size",
+	config: controller.Config{
+		controller.AuditLogMaxDBSize: "1Q",
+	},
+	expectError: `invalid audit log max db size in configuration: invalid multiplier suffix "Q", expected one of MGTPEZY`,
+}, {
+	about: "audit-log-syslog-host without certificates",
+	config: controller.Config{
+		controller.AuditLogSyslogHost: "syslog.example.com:6514",
+	},
+	expectError: `invalid audit log syslog configuration: validating TLS config: parsing client key pair: .*`,
+}, {
+	about: "audit-log-webhook-url not a URL",
+	config: controller.Config{
+		controller.AuditLogWebhookURL:    "siem.example.com/audit",
+		controller.AuditLogWebhookSecret: "sekrit",
+	},
+	expectError: `audit-log-webhook-url "siem.example.com/audit" not valid`,
+}, {
+	about: "audit-log-webhook-url without secret",
+	config: controller.Config{
+		controller.AuditLogWebhookURL: "https://siem.example.com/audit",
+	},
+	expectError: `audit-log-webhook-secret must be set if audit-log-webhook-url is set`,
+}, {
+	about: "backup-storage-s3-endpoint not a URL",
+	config: controller.Config{
+		controller.BackupStorageS3Endpoint:  "minio.example.com:9000",
+		controller.BackupStorageS3Bucket:    "backups",
+		controller.BackupStorageS3AccessKey: "access",
+		controller.BackupStorageS3SecretKey: "secret",
+	},
+	expectError: `invalid backup storage configuration: backup-storage-s3-endpoint "minio.example.com:9000" not valid`,
+}, {
+	about: "backup-storage-s3-endpoint without bucket",
+	config: controller.Config{
+		controller.BackupStorageS3Endpoint:  "https://minio.example.com:9000",
+		controller.BackupStorageS3AccessKey: "access",
+		controller.BackupStorageS3SecretKey: "secret",
+	},
+	expectError: `invalid backup storage configuration: backup-storage-s3-bucket must be set if backup-storage-s3-endpoint is set`,
+}, {
+	about: "backup-storage-s3-endpoint without secret key",
+	config: controller.Config{
+		controller.BackupStorageS3Endpoint:  "https://minio.example.com:9000",
+		controller.BackupStorageS3Bucket:    "backups",
+		controller.BackupStorageS3AccessKey: "access",
+	},
+	expectError: `invalid backup storage configuration: backup-storage-s3-secret-key must be set if backup-storage-s3-endpoint is set`,
+}, {
+	about: "invalid backup-schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "every day",
+	},
+	expectError: `invalid backup-schedule: .*`,
+}, {
+	about: "negative backup-retention-daily",
+	config: controller.Config{
+		controller.BackupRetentionDaily: -1,
+	},
+	expectError: `backup-retention-daily cannot be negative`,
+}, {
+	about: "backup-schedule without retention",
+	config: controller.Config{
+		controller.BackupSchedule:        "@daily",
+		controller.BackupRetentionDaily:  0,
+		controller.BackupRetentionWeekly: 0,
+	},
+	expectError: `backup-retention-daily or backup-retention-weekly must be set if backup-schedule is set`,
+}, {
+	about: "negative backup-max-age",
+	config: controller.Config{
+		controller.BackupMaxAge: "-1h",
+	},
+	expectError: `backup-max-age cannot be negative`,
+}, {
+	about: "invalid backup-encryption-keys",
+	config: controller.Config{
+		controller.BackupEncryptionKeys: "not a key",
+	},
+	expectError: `invalid backup-encryption-keys: .*`,
 }, {}}
 
 func (s *ConfigSuite) TestNewConfig(c *gc.C) {
@@ -489,6 +590,8 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
+	c.Assert(cfg.AuditLogMaxAge(), gc.Equals, 90*24*time.Hour)
+	c.Assert(cfg.AuditLogMaxDBSizeMB(), gc.Equals, 1024)
 }
 
 func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
@@ -501,6 +604,8 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 			"audit-log-max-size":        "100M",
 			"audit-log-max-backups":     10.0,
 			"audit-log-exclude-methods": []string{"Fleet.Foxes", "King.Gizzard", "ReadOnlyMethods"},
+			"audit-log-max-age":         "24h",
+			"audit-log-max-db-size":     "2G",
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -513,6 +618,119 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 		"King.Gizzard",
 		"ReadOnlyMethods",
 	))
+	c.Assert(cfg.AuditLogMaxAge(), gc.Equals, 24*time.Hour)
+	c.Assert(cfg.AuditLogMaxDBSizeMB(), gc.Equals, 2048)
+}
+
+func (s *ConfigSuite) TestOpenTelemetryDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OpenTelemetryEnabled(), jc.IsFalse)
+	c.Assert(cfg.OpenTelemetryEndpoint(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestOpenTelemetryValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"open-telemetry-enabled":  true,
+			"open-telemetry-endpoint": "http://collector:4318",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OpenTelemetryEnabled(), jc.IsTrue)
+	c.Assert(cfg.OpenTelemetryEndpoint(), gc.Equals, "http://collector:4318")
+}
+
+func (s *ConfigSuite) TestAuditLogForwardingDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslogConfig().Enabled, jc.IsFalse)
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuditLogWebhookSecret(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestAuditLogForwardingValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-syslog-host":        "syslog.example.com:6514",
+			"audit-log-syslog-ca-cert":     testing.CACert,
+			"audit-log-syslog-client-cert": testing.ServerCert,
+			"audit-log-syslog-client-key":  testing.ServerKey,
+			"audit-log-webhook-url":        "https://siem.example.com/audit",
+			"audit-log-webhook-secret":     "sekrit",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslogConfig(), jc.DeepEquals, syslog.RawConfig{
+		Enabled:    true,
+		Host:       "syslog.example.com:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://siem.example.com/audit")
+	c.Assert(cfg.AuditLogWebhookSecret(), gc.Equals, "sekrit")
+}
+
+func (s *ConfigSuite) TestBackupStorageS3Defaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorageS3Config().Enabled(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestBackupStorageS3Values(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage-s3-endpoint":   "https://minio.example.com:9000",
+			"backup-storage-s3-region":     "eu-west-1",
+			"backup-storage-s3-bucket":     "backups",
+			"backup-storage-s3-access-key": "access",
+			"backup-storage-s3-secret-key": "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorageS3Config(), jc.DeepEquals, controller.BackupStorageS3Config{
+		Endpoint:  "https://minio.example.com:9000",
+		Region:    "eu-west-1",
+		Bucket:    "backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	c.Assert(cfg.BackupStorageS3Config().Enabled(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestBackupScheduleDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "")
+	c.Check(cfg.BackupRetentionDaily(), gc.Equals, controller.DefaultBackupRetentionDaily)
+	c.Check(cfg.BackupRetentionWeekly(), gc.Equals, controller.DefaultBackupRetentionWeekly)
+	c.Check(cfg.BackupMaxAge(), gc.Equals, controller.DefaultBackupMaxAge)
+	c.Check(cfg.BackupEncryptionKeys(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestBackupScheduleValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":         "0 3 * * *",
+			"backup-retention-daily":  3,
+			"backup-retention-weekly": 0,
+			"backup-max-age":          "30h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "0 3 * * *")
+	c.Check(cfg.BackupRetentionDaily(), gc.Equals, 3)
+	c.Check(cfg.BackupRetentionWeekly(), gc.Equals, 0)
+	c.Check(cfg.BackupMaxAge(), gc.Equals, 30*time.Hour)
 }
 
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
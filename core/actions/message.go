@@ -5,8 +5,39 @@ package actions
 
 import "time"
 
+const (
+	// StdoutStream identifies output written by an action to stdout.
+	StdoutStream = "stdout"
+
+	// StderrStream identifies output written by an action to stderr.
+	StderrStream = "stderr"
+)
+
+// IsValidStream returns true if stream identifies one of
+// the streams an action can write output to.
+func IsValidStream(stream string) bool {
+	return stream == StdoutStream || stream == StderrStream
+}
+
 // ActionMessage is a timestamped message logged by a running action.
 type ActionMessage struct {
 	Message   string    `json:"message"`
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// TaskEvent is a timestamped event in the life of a task: a change
+// of its status, a message it logged or output it wrote.
+type TaskEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	// Status is set when the event records a change of
+	// the task's status.
+	Status string `json:"status,omitempty"`
+
+	// Message holds the logged message or the output written.
+	Message string `json:"message,omitempty"`
+
+	// Stream is set when the event holds output written to stdout
+	// or stderr, rather than a message logged with action-log.
+	Stream string `json:"stream,omitempty"`
+}
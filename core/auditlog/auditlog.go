@@ -178,6 +178,46 @@ func newConversationID() string {
 	return hex.EncodeToString(buf)
 }
 
+type multiLog []AuditLog
+
+// NewMultiLog returns an audit entry sink which writes each entry to
+// all of the specified logs. A failure to write to one of the logs
+// doesn't stop the entry being written to the others; the first
+// error encountered is returned.
+func NewMultiLog(logs ...AuditLog) AuditLog {
+	return multiLog(logs)
+}
+
+// AddConversation implements AuditLog.
+func (m multiLog) AddConversation(c Conversation) error {
+	return m.each(func(log AuditLog) error { return log.AddConversation(c) })
+}
+
+// AddRequest implements AuditLog.
+func (m multiLog) AddRequest(r Request) error {
+	return m.each(func(log AuditLog) error { return log.AddRequest(r) })
+}
+
+// AddResponse implements AuditLog.
+func (m multiLog) AddResponse(r ResponseErrors) error {
+	return m.each(func(log AuditLog) error { return log.AddResponse(r) })
+}
+
+// Close implements AuditLog.
+func (m multiLog) Close() error {
+	return m.each(func(log AuditLog) error { return log.Close() })
+}
+
+func (m multiLog) each(f func(AuditLog) error) error {
+	var result error
+	for _, log := range m {
+		if err := f(log); err != nil && result == nil {
+			result = errors.Trace(err)
+		}
+	}
+	return result
+}
+
 type auditLogFile struct {
 	fileLogger io.WriteCloser
 }
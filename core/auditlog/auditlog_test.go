@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/core/paths"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -142,6 +143,31 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestMultiLog(c *gc.C) {
+	log1, log2 := &fakeLog{}, &fakeLog{}
+	log1.stub.SetErrors(nil, errors.New("kaboom"))
+	log := auditlog.NewMultiLog(log1, log2)
+
+	conversation := auditlog.Conversation{Who: "deerhoof", ConversationID: "0123456789abcdef"}
+	c.Assert(log.AddConversation(conversation), jc.ErrorIsNil)
+	request := auditlog.Request{ConversationID: "0123456789abcdef", RequestID: 25}
+	c.Assert(log.AddRequest(request), gc.ErrorMatches, "kaboom")
+	response := auditlog.ResponseErrors{ConversationID: "0123456789abcdef", RequestID: 25}
+	c.Assert(log.AddResponse(response), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	// A failure writing to one log doesn't stop
+	// the entry being written to the others.
+	for _, l := range []*fakeLog{log1, log2} {
+		l.stub.CheckCalls(c, []testing.StubCall{
+			{"AddConversation", []interface{}{conversation}},
+			{"AddRequest", []interface{}{request}},
+			{"AddResponse", []interface{}{response}},
+			{"Close", nil},
+		})
+	}
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
@@ -6,6 +6,8 @@ package auditlog
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 // Config holds parameters to control audit logging.
@@ -29,6 +31,17 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Syslog holds the details of the syslog server that records
+	// are forwarded to, if it's enabled.
+	Syslog syslog.RawConfig
+
+	// WebhookURL is the URL records are POSTed to, if any.
+	WebhookURL string
+
+	// WebhookSecret is the key used to sign the records
+	// POSTed to the webhook.
+	WebhookSecret string
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -40,3 +53,11 @@ func (cfg Config) Validate() error {
 	}
 	return nil
 }
+
+// SameForwarding returns whether cfg and other forward
+// records to the same syslog server and webhook.
+func (cfg Config) SameForwarding(other Config) bool {
+	return cfg.Syslog == other.Syslog &&
+		cfg.WebhookURL == other.WebhookURL &&
+		cfg.WebhookSecret == other.WebhookSecret
+}
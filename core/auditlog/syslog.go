@@ -0,0 +1,242 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/rfc5424"
+)
+
+const (
+	// syslogAppName identifies audit records amongst the other
+	// messages received by the syslog server.
+	syslogAppName = "juju-audit"
+
+	// minRetryDelay and maxRetryDelay bound the delay between
+	// attempts to deliver a record to a remote sink.
+	minRetryDelay = time.Second
+	maxRetryDelay = 5 * time.Minute
+)
+
+// SyslogSender sends RFC 5424 messages to a syslog server. The
+// Sender of a logfwd/syslog Client satisfies this interface.
+type SyslogSender interface {
+	Send(rfc5424.Message) error
+	Close() error
+}
+
+// SyslogConfig holds the details needed to forward audit
+// records to a syslog server.
+type SyslogConfig struct {
+	// Open connects to the syslog server.
+	Open func() (SyslogSender, error)
+
+	// Hostname identifies the controller machine sending the records.
+	Hostname string
+
+	// QueueSize is the number of records held while the syslog
+	// server is unreachable; records added once the queue is full
+	// are dropped.
+	QueueSize int
+
+	// Clock is used to wait between attempts to reach the server.
+	Clock clock.Clock
+}
+
+// Validate checks the syslog sink configuration.
+func (config SyslogConfig) Validate() error {
+	if config.Open == nil {
+		return errors.NotValidf("nil Open")
+	}
+	if config.Hostname == "" {
+		return errors.NotValidf("empty Hostname")
+	}
+	if config.QueueSize <= 0 {
+		return errors.NotValidf("non-positive QueueSize")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+type syslogSink struct {
+	config  SyslogConfig
+	records chan Record
+
+	mu     sync.Mutex
+	closed bool
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSyslogSink returns an audit entry sink which forwards records to
+// a syslog server as RFC 5424 messages. Records are queued and sent in
+// the background so that an unreachable server never holds up the API
+// requests being audited; the connection is re-established, backing
+// off between attempts, whenever sending fails.
+func NewSyslogSink(config SyslogConfig) (AuditLog, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &syslogSink{
+		config:  config,
+		records: make(chan Record, config.QueueSize),
+		done:    make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.loop()
+	}()
+	return s, nil
+}
+
+// AddConversation implements AuditLog.
+func (s *syslogSink) AddConversation(c Conversation) error {
+	s.enqueue(Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (s *syslogSink) AddRequest(r Request) error {
+	s.enqueue(Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (s *syslogSink) AddResponse(r ResponseErrors) error {
+	s.enqueue(Record{Errors: &r})
+	return nil
+}
+
+// Close implements AuditLog. Records still queued are discarded.
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *syslogSink) enqueue(r Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.records <- r:
+	default:
+		logger.Warningf("audit syslog queue full, dropping record")
+	}
+}
+
+func (s *syslogSink) loop() {
+	var sender SyslogSender
+	defer func() {
+		if sender != nil {
+			_ = sender.Close()
+		}
+	}()
+	var delay time.Duration
+	for {
+		var record Record
+		select {
+		case <-s.done:
+			return
+		case record = <-s.records:
+		}
+		msg, err := syslogMessage(s.config.Hostname, record, s.config.Clock.Now())
+		if err != nil {
+			logger.Errorf("cannot convert audit record to syslog message: %v", err)
+			continue
+		}
+		for {
+			if sender == nil {
+				sender, err = s.config.Open()
+			}
+			if err == nil {
+				if err = sender.Send(msg); err == nil {
+					delay = 0
+					break
+				}
+				_ = sender.Close()
+				sender = nil
+			}
+			delay = nextRetryDelay(delay)
+			logger.Warningf("sending audit record to syslog (retrying in %v): %v", delay, err)
+			select {
+			case <-s.done:
+				return
+			case <-s.config.Clock.After(delay):
+			}
+		}
+	}
+}
+
+// syslogMessage returns the RFC 5424 message holding the record,
+// which is sent as JSON in the same form as it is written to the
+// audit log file.
+func syslogMessage(hostname string, record Record, now time.Time) (rfc5424.Message, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return rfc5424.Message{}, errors.Trace(err)
+	}
+	severity := rfc5424.SeverityNotice
+	var msgID, when string
+	switch {
+	case record.Conversation != nil:
+		msgID, when = "conversation", record.Conversation.When
+	case record.Request != nil:
+		msgID, when = "request", record.Request.When
+	case record.Errors != nil:
+		msgID, when = "response", record.Errors.When
+		if len(record.Errors.Errors) > 0 {
+			severity = rfc5424.SeverityWarning
+		}
+	}
+	timestamp := now
+	if t, err := time.Parse(time.RFC3339, when); err == nil {
+		timestamp = t
+	}
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: severity,
+				Facility: rfc5424.FacilityAuthpriv,
+			},
+			Timestamp: rfc5424.Timestamp{timestamp},
+			Hostname:  rfc5424.Hostname{FQDN: hostname},
+			AppName:   syslogAppName,
+			MsgID:     rfc5424.MsgID(msgID),
+		},
+		Msg: string(data),
+	}
+	if err := msg.Validate(); err != nil {
+		return rfc5424.Message{}, errors.Trace(err)
+	}
+	return msg, nil
+}
+
+// nextRetryDelay returns the delay to wait before the next attempt
+// to deliver a record, given the delay before the previous attempt.
+func nextRetryDelay(delay time.Duration) time.Duration {
+	delay *= 2
+	if delay < minRetryDelay {
+		return minRetryDelay
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,174 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/rfc5424"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SyslogSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	sender *fakeSyslogSender
+	opens  int
+	config auditlog.SyslogConfig
+}
+
+var _ = gc.Suite(&SyslogSuite{})
+
+func (s *SyslogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC))
+	s.sender = &fakeSyslogSender{messages: make(chan rfc5424.Message, 10)}
+	s.opens = 0
+	s.config = auditlog.SyslogConfig{
+		Open: func() (auditlog.SyslogSender, error) {
+			s.opens++
+			return s.sender, nil
+		},
+		Hostname:  "controller-0",
+		QueueSize: 10,
+		Clock:     s.clock,
+	}
+}
+
+func (s *SyslogSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*auditlog.SyslogConfig)
+		err    string
+	}{
+		{func(cfg *auditlog.SyslogConfig) { cfg.Open = nil }, "nil Open not valid"},
+		{func(cfg *auditlog.SyslogConfig) { cfg.Hostname = "" }, "empty Hostname not valid"},
+		{func(cfg *auditlog.SyslogConfig) { cfg.QueueSize = 0 }, "non-positive QueueSize not valid"},
+		{func(cfg *auditlog.SyslogConfig) { cfg.Clock = nil }, "nil Clock not valid"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.err)
+		config := s.config
+		test.mutate(&config)
+		_, err := auditlog.NewSyslogSink(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SyslogSuite) TestSendsRecords(c *gc.C) {
+	sink, err := auditlog.NewSyslogSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2021-05-31T11:34:56Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        13,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2021-05-31T11:34:57Z",
+		Errors:         []*auditlog.Error{{Message: "oops"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.nextMessage(c)
+	c.Check(msg.Priority, jc.DeepEquals, rfc5424.Priority{
+		Severity: rfc5424.SeverityNotice,
+		Facility: rfc5424.FacilityAuthpriv,
+	})
+	c.Check(msg.Timestamp.Time, gc.Equals, time.Date(2021, 5, 31, 11, 34, 56, 0, time.UTC))
+	c.Check(msg.Hostname.FQDN, gc.Equals, "controller-0")
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("juju-audit"))
+	c.Check(msg.MsgID, gc.Equals, rfc5424.MsgID("request"))
+	var record auditlog.Record
+	err = json.Unmarshal([]byte(msg.Msg), &record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(record.Request.Method, gc.Equals, "Deploy")
+
+	msg = s.nextMessage(c)
+	c.Check(msg.Priority.Severity, gc.Equals, rfc5424.SeverityWarning)
+	c.Check(msg.MsgID, gc.Equals, rfc5424.MsgID("response"))
+
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+	c.Assert(s.opens, gc.Equals, 1)
+	s.sender.CheckCallNames(c, "Send", "Send", "Close")
+}
+
+func (s *SyslogSuite) TestReconnectsAfterFailure(c *gc.C) {
+	s.sender.SetErrors(errors.New("connection reset"))
+	sink, err := auditlog.NewSyslogSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddConversation(auditlog.Conversation{
+		ConversationID: "0123456789abcdef",
+		When:           "2021-05-31T11:34:56Z",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	msg := s.nextMessage(c)
+	c.Check(msg.MsgID, gc.Equals, rfc5424.MsgID("conversation"))
+	s.sender.CheckCallNames(c, "Send", "Close", "Send")
+}
+
+func (s *SyslogSuite) TestDropsRecordsWhenQueueFull(c *gc.C) {
+	s.config.QueueSize = 1
+	s.config.Open = func() (auditlog.SyslogSender, error) {
+		return nil, errors.New("no route to host")
+	}
+	sink, err := auditlog.NewSyslogSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	for i := 0; i < 5; i++ {
+		err := sink.AddRequest(auditlog.Request{RequestID: uint64(i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *SyslogSuite) nextMessage(c *gc.C) rfc5424.Message {
+	select {
+	case msg := <-s.sender.messages:
+		return msg
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for syslog message")
+	}
+	return rfc5424.Message{}
+}
+
+type fakeSyslogSender struct {
+	testing.Stub
+	messages chan rfc5424.Message
+}
+
+func (f *fakeSyslogSender) Send(msg rfc5424.Message) error {
+	f.MethodCall(f, "Send", msg)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.messages <- msg
+	return nil
+}
+
+func (f *fakeSyslogSender) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
@@ -0,0 +1,325 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// WebhookSignatureHeader holds the hex-encoded HMAC-SHA256 of
+	// the request body, keyed with the webhook secret and prefixed
+	// with "sha256=".
+	WebhookSignatureHeader = "X-Juju-Signature"
+
+	// WebhookDeliveryHeader holds an identifier which is unique to
+	// each record, so that the receiver can discard any record
+	// delivered more than once.
+	WebhookDeliveryHeader = "X-Juju-Delivery"
+
+	spoolFileSuffix = ".json"
+)
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// WebhookConfig holds the details needed to deliver audit
+// records to an HTTP webhook.
+type WebhookConfig struct {
+	// URL is where the records are POSTed.
+	URL string
+
+	// Secret is the key used to sign each record.
+	Secret string
+
+	// SpoolDir is the directory in which records are held until
+	// they have been delivered.
+	SpoolDir string
+
+	// MaxSpooled is the maximum number of undelivered records held
+	// in the spool; records added once the spool is full are dropped.
+	MaxSpooled int
+
+	// Client is used to send the records.
+	Client HTTPClient
+
+	// Clock is used to wait between delivery attempts.
+	Clock clock.Clock
+
+	// Dropped, if not nil, counts the records discarded without
+	// being delivered, labelled with the reason: "rejected" by the
+	// webhook, "spool-full" or "unreadable".
+	Dropped *prometheus.CounterVec
+}
+
+// Validate checks the webhook sink configuration.
+func (config WebhookConfig) Validate() error {
+	if config.URL == "" {
+		return errors.NotValidf("empty URL")
+	}
+	if config.Secret == "" {
+		return errors.NotValidf("empty Secret")
+	}
+	if config.SpoolDir == "" {
+		return errors.NotValidf("empty SpoolDir")
+	}
+	if config.MaxSpooled <= 0 {
+		return errors.NotValidf("non-positive MaxSpooled")
+	}
+	if config.Client == nil {
+		return errors.NotValidf("nil Client")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+type webhookSink struct {
+	config WebhookConfig
+	ctx    context.Context
+	cancel func()
+	wake   chan struct{}
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	seq     int
+	spooled int
+}
+
+// NewWebhookSink returns an audit entry sink which POSTs each record,
+// as JSON, to an HTTP webhook. Records are written to an on-disk spool
+// as they are added, and delivered in order in the background; failed
+// deliveries are retried, backing off between attempts, so records
+// aren't lost while the webhook is unreachable or the controller is
+// restarted.
+func NewWebhookSink(config WebhookConfig) (AuditLog, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := os.MkdirAll(config.SpoolDir, 0700); err != nil {
+		return nil, errors.Annotate(err, "creating audit webhook spool")
+	}
+	names, err := spooledRecords(config.SpoolDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &webhookSink{
+		config:  config,
+		ctx:     ctx,
+		cancel:  cancel,
+		wake:    make(chan struct{}, 1),
+		spooled: len(names),
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.loop()
+	}()
+	return s, nil
+}
+
+// AddConversation implements AuditLog.
+func (s *webhookSink) AddConversation(c Conversation) error {
+	return errors.Trace(s.spool(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (s *webhookSink) AddRequest(r Request) error {
+	return errors.Trace(s.spool(Record{Request: &r}))
+}
+
+// AddResponse implements AuditLog.
+func (s *webhookSink) AddResponse(r ResponseErrors) error {
+	return errors.Trace(s.spool(Record{Errors: &r}))
+}
+
+// Close implements AuditLog. Records which haven't yet been
+// delivered are left in the spool, to be delivered by the
+// next sink using it.
+func (s *webhookSink) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// spool writes the record to the spool directory and
+// wakes the delivery loop.
+func (s *webhookSink) spool(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.mu.Lock()
+	if s.spooled >= s.config.MaxSpooled {
+		s.mu.Unlock()
+		logger.Warningf("audit webhook spool full, dropping record")
+		s.dropped("spool-full")
+		return nil
+	}
+	s.spooled++
+	s.seq++
+	// The names sort in the order the records were added,
+	// including across restarts.
+	name := fmt.Sprintf("%020d-%06d%s", s.config.Clock.Now().UnixNano(), s.seq%1000000, spoolFileSuffix)
+	s.mu.Unlock()
+
+	// Write to a temporary file first so that the delivery
+	// loop never sees a partially written record.
+	path := filepath.Join(s.config.SpoolDir, name)
+	tmpPath := filepath.Join(s.config.SpoolDir, "."+name)
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		s.unspooled()
+		return errors.Annotate(err, "spooling audit record")
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		s.unspooled()
+		return errors.Annotate(err, "spooling audit record")
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *webhookSink) unspooled() {
+	s.mu.Lock()
+	s.spooled--
+	s.mu.Unlock()
+}
+
+func (s *webhookSink) loop() {
+	var delay time.Duration
+	for {
+		names, err := spooledRecords(s.config.SpoolDir)
+		if err != nil {
+			logger.Errorf("reading audit webhook spool: %v", err)
+		}
+		for len(names) > 0 {
+			err := s.deliver(names[0])
+			if err == nil {
+				delay = 0
+				names = names[1:]
+				continue
+			}
+			delay = nextRetryDelay(delay)
+			logger.Warningf("delivering audit record to webhook (retrying in %v): %v", delay, err)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-s.config.Clock.After(delay):
+			}
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.wake:
+		}
+	}
+}
+
+// deliver POSTs the spooled record to the webhook, removing it from
+// the spool once it has been accepted, or rejected outright.
+func (s *webhookSink) deliver(name string) error {
+	path := filepath.Join(s.config.SpoolDir, name)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		logger.Errorf("discarding unreadable audit record %q: %v", name, err)
+		s.dropped("unreadable")
+		return s.remove(path)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.config.URL, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req = req.WithContext(s.ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookBody(s.config.Secret, data))
+	req.Header.Set(WebhookDeliveryHeader, strings.TrimSuffix(name, spoolFileSuffix))
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= 500:
+		return errors.Errorf("webhook returned %s", resp.Status)
+	default:
+		// Retrying a record the webhook has refused
+		// would block delivery of every later record.
+		logger.Errorf("audit webhook rejected record %q: %s", name, resp.Status)
+		s.dropped("rejected")
+	}
+	return s.remove(path)
+}
+
+// dropped counts a record discarded for the given reason.
+func (s *webhookSink) dropped(reason string) {
+	if s.config.Dropped != nil {
+		s.config.Dropped.With(prometheus.Labels{"reason": reason}).Inc()
+	}
+}
+
+func (s *webhookSink) remove(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	s.unspooled()
+	return nil
+}
+
+// SignWebhookBody returns the hex-encoded HMAC-SHA256 of
+// the body, as sent in the WebhookSignatureHeader.
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// spooledRecords returns the names of the records in the spool
+// directory, in the order they were added.
+func spooledRecords(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, spoolFileSuffix) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WebhookSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	client *fakeHTTPClient
+	config auditlog.WebhookConfig
+}
+
+var _ = gc.Suite(&WebhookSuite{})
+
+func (s *WebhookSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC))
+	s.client = &fakeHTTPClient{requests: make(chan delivery, 10)}
+	s.config = auditlog.WebhookConfig{
+		URL:        "https://siem.example.com/audit",
+		Secret:     "sekrit",
+		SpoolDir:   filepath.Join(c.MkDir(), "spool"),
+		MaxSpooled: 10,
+		Client:     s.client,
+		Clock:      s.clock,
+		Dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dropped",
+		}, []string{"reason"}),
+	}
+}
+
+func (s *WebhookSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*auditlog.WebhookConfig)
+		err    string
+	}{
+		{func(cfg *auditlog.WebhookConfig) { cfg.URL = "" }, "empty URL not valid"},
+		{func(cfg *auditlog.WebhookConfig) { cfg.Secret = "" }, "empty Secret not valid"},
+		{func(cfg *auditlog.WebhookConfig) { cfg.SpoolDir = "" }, "empty SpoolDir not valid"},
+		{func(cfg *auditlog.WebhookConfig) { cfg.MaxSpooled = 0 }, "non-positive MaxSpooled not valid"},
+		{func(cfg *auditlog.WebhookConfig) { cfg.Client = nil }, "nil Client not valid"},
+		{func(cfg *auditlog.WebhookConfig) { cfg.Clock = nil }, "nil Clock not valid"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.err)
+		config := s.config
+		test.mutate(&config)
+		_, err := auditlog.NewWebhookSink(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WebhookSuite) TestDeliversSignedRecords(c *gc.C) {
+	sink, err := auditlog.NewWebhookSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddConversation(auditlog.Conversation{
+		Who:            "fred",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		Method:         "Deploy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	d := s.nextDelivery(c)
+	c.Check(d.url, gc.Equals, "https://siem.example.com/audit")
+	c.Check(d.header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Check(d.header.Get(auditlog.WebhookSignatureHeader), gc.Equals,
+		"sha256="+auditlog.SignWebhookBody("sekrit", d.body))
+	c.Check(d.header.Get(auditlog.WebhookDeliveryHeader), gc.Not(gc.Equals), "")
+	var record auditlog.Record
+	c.Assert(json.Unmarshal(d.body, &record), jc.ErrorIsNil)
+	c.Check(record.Conversation.Who, gc.Equals, "fred")
+
+	d2 := s.nextDelivery(c)
+	c.Assert(json.Unmarshal(d2.body, &record), jc.ErrorIsNil)
+	c.Check(record.Request.Method, gc.Equals, "Deploy")
+	c.Check(d2.header.Get(auditlog.WebhookDeliveryHeader), gc.Not(gc.Equals), d.header.Get(auditlog.WebhookDeliveryHeader))
+
+	s.waitForSpool(c, 0)
+}
+
+func (s *WebhookSuite) TestRetriesWithBackoff(c *gc.C) {
+	s.client.statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+	sink, err := auditlog.NewWebhookSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddRequest(auditlog.Request{RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	first := s.nextDelivery(c)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	second := s.nextDelivery(c)
+	c.Check(second.body, jc.DeepEquals, first.body)
+	c.Assert(s.clock.WaitAdvance(2*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	third := s.nextDelivery(c)
+	c.Check(third.body, jc.DeepEquals, first.body)
+	s.waitForSpool(c, 0)
+}
+
+func (s *WebhookSuite) TestRejectedRecordDiscarded(c *gc.C) {
+	s.client.statuses = []int{http.StatusBadRequest}
+	sink, err := auditlog.NewWebhookSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddRequest(auditlog.Request{RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddRequest(auditlog.Request{RequestID: 2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.nextDelivery(c)
+	d := s.nextDelivery(c)
+	var record auditlog.Record
+	c.Assert(json.Unmarshal(d.body, &record), jc.ErrorIsNil)
+	c.Check(record.Request.RequestID, gc.Equals, uint64(2))
+	s.waitForSpool(c, 0)
+	c.Check(s.dropped(c, "rejected"), gc.Equals, float64(1))
+}
+
+func (s *WebhookSuite) TestSpoolSurvivesClose(c *gc.C) {
+	s.client.err = errors.New("connection refused")
+	sink, err := auditlog.NewWebhookSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sink.AddRequest(auditlog.Request{RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	s.nextDelivery(c)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+	s.waitForSpool(c, 1)
+
+	s.client.setError(nil)
+	sink, err = auditlog.NewWebhookSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	d := s.nextDelivery(c)
+	var record auditlog.Record
+	c.Assert(json.Unmarshal(d.body, &record), jc.ErrorIsNil)
+	c.Check(record.Request.RequestID, gc.Equals, uint64(1))
+	s.waitForSpool(c, 0)
+}
+
+func (s *WebhookSuite) TestDropsRecordsWhenSpoolFull(c *gc.C) {
+	s.client.err = errors.New("connection refused")
+	s.config.MaxSpooled = 2
+	sink, err := auditlog.NewWebhookSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	for i := 0; i < 5; i++ {
+		err := sink.AddRequest(auditlog.Request{RequestID: uint64(i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(s.spooled(c), gc.HasLen, 2)
+	c.Assert(s.dropped(c, "spool-full"), gc.Equals, float64(3))
+}
+
+func (s *WebhookSuite) dropped(c *gc.C, reason string) float64 {
+	var m dto.Metric
+	err := s.config.Dropped.With(prometheus.Labels{"reason": reason}).Write(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	return m.GetCounter().GetValue()
+}
+
+func (s *WebhookSuite) nextDelivery(c *gc.C) delivery {
+	select {
+	case d := <-s.client.requests:
+		return d
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for webhook delivery")
+	}
+	return delivery{}
+}
+
+func (s *WebhookSuite) spooled(c *gc.C) []string {
+	infos, err := ioutil.ReadDir(s.config.SpoolDir)
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, info := range infos {
+		if !strings.HasPrefix(info.Name(), ".") {
+			names = append(names, info.Name())
+		}
+	}
+	return names
+}
+
+func (s *WebhookSuite) waitForSpool(c *gc.C, n int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.spooled(c)) == n {
+			return
+		}
+	}
+	c.Fatalf("spool has %d records, expected %d", len(s.spooled(c)), n)
+}
+
+type delivery struct {
+	url    string
+	header http.Header
+	body   []byte
+}
+
+type fakeHTTPClient struct {
+	mu       sync.Mutex
+	requests chan delivery
+	statuses []int
+	err      error
+}
+
+func (f *fakeHTTPClient) setError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.requests <- delivery{
+		url:    req.URL.String(),
+		header: req.Header,
+		body:   body,
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	status := http.StatusOK
+	if len(f.statuses) > 0 {
+		status = f.statuses[0]
+		f.statuses = f.statuses[1:]
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
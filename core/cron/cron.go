@@ -0,0 +1,214 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses the standard five field cron expressions used to
+// schedule recurring work, and computes when that work is next due.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxSearchYears bounds the search for the next matching time, so
+// that expressions which can never match (such as "0 0 30 2 *")
+// don't search forever.
+const maxSearchYears = 5
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day of month and day
+	// of week fields were unrestricted. If both are restricted a
+	// day matches if either field matches.
+	domStar, dowStar bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week allows 7 as well as 0 for Sunday.
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression: either five space separated fields
+// (minute, hour, day of month, month and day of week) or one of the
+// descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight
+// and @hourly.
+//
+// Each field is "*" or a comma separated list of values and ranges
+// ("1-5"), either of which may be followed by a step ("*/15", "0-30/10").
+// Months and days of the week may also be given by their three letter
+// English names.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	expr := spec
+	if strings.HasPrefix(expr, "@") {
+		var ok bool
+		if expr, ok = descriptors[strings.ToLower(expr)]; !ok {
+			return nil, errors.NotValidf("cron descriptor %q", spec)
+		}
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("cron expression %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	s := &Schedule{spec: spec}
+	var err error
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, errors.Annotatef(err, "cron expression %q", spec)
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, errors.Annotatef(err, "cron expression %q", spec)
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, errors.Annotatef(err, "cron expression %q", spec)
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, errors.Annotatef(err, "cron expression %q", spec)
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, errors.Annotatef(err, "cron expression %q", spec)
+	}
+	// Fold Sunday as 7 into Sunday as 0.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t, in t's location, which matches
+// the schedule. It returns the zero time if there is no such time in
+// the next few years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(bits uint64, n int) bool {
+	return bits&(1<<uint(n)) != 0
+}
+
+// parse returns the set of values matched by the field expression
+// as a bit set.
+func (f field) parse(expr string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.NotValidf("%s step %q", f.name, part[i+1:])
+			}
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			i := strings.Index(rangeExpr, "-")
+			var err error
+			if lo, err = f.value(rangeExpr[:i]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = f.value(rangeExpr[i+1:]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if lo > hi {
+				return 0, errors.NotValidf("%s range %q", f.name, rangeExpr)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangeExpr); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			// "5/15" means every 15 starting at 5.
+			if step > 1 {
+				hi = f.max
+			}
+		}
+		for n := lo; n <= hi; n += step {
+			bits |= 1 << uint(n)
+		}
+	}
+	return bits, nil
+}
+
+func (f field) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, errors.NotValidf("%s %q", f.name, s)
+	}
+	return n, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+func mustTime(c *gc.C, s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	c.Assert(err, jc.ErrorIsNil)
+	return t
+}
+
+func (s *CronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		after    string
+		expected string
+	}{
+		{"* * * * *", "2021-03-04 05:06", "2021-03-04 05:07"},
+		{"0 3 * * *", "2021-03-04 05:06", "2021-03-05 03:00"},
+		{"0 3 * * *", "2021-03-04 02:59", "2021-03-04 03:00"},
+		{"0 3 * * *", "2021-03-04 03:00", "2021-03-05 03:00"},
+		{"*/15 * * * *", "2021-03-04 05:06", "2021-03-04 05:15"},
+		{"5/15 * * * *", "2021-03-04 05:51", "2021-03-04 06:05"},
+		{"0-30/10 9-17 * * *", "2021-03-04 17:31", "2021-03-05 09:00"},
+		{"30 12 * * mon-fri", "2021-03-05 13:00", "2021-03-08 12:30"},
+		{"0 0 * * 7", "2021-03-04 00:00", "2021-03-07 00:00"},
+		{"0 0 1,15 * *", "2021-03-02 00:00", "2021-03-15 00:00"},
+		{"0 0 29 2 *", "2021-03-01 00:00", "2024-02-29 00:00"},
+		{"0 0 31 * *", "2021-04-01 00:00", "2021-05-31 00:00"},
+		{"0 0 13 * fri", "2021-03-04 00:00", "2021-03-05 00:00"},
+		{"0 0 1 jan *", "2021-03-04 00:00", "2022-01-01 00:00"},
+		{"@hourly", "2021-03-04 05:06", "2021-03-04 06:00"},
+		{"@daily", "2021-12-31 05:06", "2022-01-01 00:00"},
+		{"@weekly", "2021-03-04 05:06", "2021-03-07 00:00"},
+		{"@monthly", "2021-03-04 05:06", "2021-04-01 00:00"},
+		{"@yearly", "2021-03-04 05:06", "2022-01-01 00:00"},
+	} {
+		c.Logf("test %d: %q after %s", i, test.spec, test.after)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.spec)
+		next := schedule.Next(mustTime(c, test.after))
+		c.Check(next, gc.Equals, mustTime(c, test.expected))
+	}
+}
+
+func (s *CronSuite) TestNextIgnoresSeconds(c *gc.C) {
+	schedule, err := cron.Parse("* * * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Date(2021, 3, 4, 5, 6, 59, 999, time.UTC)
+	c.Check(schedule.Next(after), gc.Equals, time.Date(2021, 3, 4, 5, 7, 0, 0, time.UTC))
+}
+
+func (s *CronSuite) TestNextNeverMatches(c *gc.C) {
+	schedule, err := cron.Parse("0 0 30 2 *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Next(mustTime(c, "2021-03-04 05:06")).IsZero(), jc.IsTrue)
+}
+
+func (s *CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{
+		{"", `cron expression "": expected 5 fields, got 0 not valid`},
+		{"* * * *", `cron expression "\* \* \* \*": expected 5 fields, got 4 not valid`},
+		{"@fortnightly", `cron descriptor "@fortnightly" not valid`},
+		{"60 * * * *", `cron expression "60 \* \* \* \*": minute "60" not valid`},
+		{"* 24 * * *", `cron expression "\* 24 \* \* \*": hour "24" not valid`},
+		{"* * 0 * *", `cron expression "\* \* 0 \* \*": day of month "0" not valid`},
+		{"* * * 13 *", `cron expression "\* \* \* 13 \*": month "13" not valid`},
+		{"* * * * 8", `cron expression "\* \* \* \* 8": day of week "8" not valid`},
+		{"*/0 * * * *", `cron expression "\*/0 \* \* \* \*": minute step "0" not valid`},
+		{"5-1 * * * *", `cron expression "5-1 \* \* \* \*": minute range "5-1" not valid`},
+		{"* * * * funday", `cron expression "\* \* \* \* funday": day of week "funday" not valid`},
+	} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
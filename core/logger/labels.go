@@ -0,0 +1,52 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logger defines how labels are attached to log messages, so
+// that agents can send them to the controller with the log records
+// they belong to and debug-log can filter on them.
+package logger
+
+import (
+	"strings"
+)
+
+const (
+	labelsStart = "[labels "
+	labelsEnd   = "] "
+)
+
+// FormatLabels returns a message prefix holding the specified labels,
+// which ParseLabels will recognise. Labels may not contain spaces or
+// "]"; any that do are dropped. If there are no valid labels, the
+// empty string is returned.
+func FormatLabels(labels ...string) string {
+	valid := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label == "" || strings.ContainsAny(label, " ]") {
+			continue
+		}
+		valid = append(valid, label)
+	}
+	if len(valid) == 0 {
+		return ""
+	}
+	return labelsStart + strings.Join(valid, " ") + labelsEnd
+}
+
+// ParseLabels returns the labels held in a prefix of message written
+// by FormatLabels, and the rest of the message. If message has no
+// such prefix, it is returned unchanged with no labels.
+func ParseLabels(message string) ([]string, string) {
+	if !strings.HasPrefix(message, labelsStart) {
+		return nil, message
+	}
+	end := strings.Index(message, labelsEnd)
+	if end < 0 {
+		return nil, message
+	}
+	labels := strings.Fields(message[len(labelsStart):end])
+	if len(labels) == 0 {
+		return nil, message
+	}
+	return labels, message[end+len(labelsEnd):]
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logger_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logger"
+)
+
+type labelsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&labelsSuite{})
+
+func (s *labelsSuite) TestFormatLabels(c *gc.C) {
+	c.Assert(logger.FormatLabels("trace-id=1", "span-id=2"), gc.Equals, "[labels trace-id=1 span-id=2] ")
+	c.Assert(logger.FormatLabels("a b", "", "c]", "d"), gc.Equals, "[labels d] ")
+	c.Assert(logger.FormatLabels(), gc.Equals, "")
+	c.Assert(logger.FormatLabels("a b"), gc.Equals, "")
+}
+
+func (s *labelsSuite) TestParseLabels(c *gc.C) {
+	labels, message := logger.ParseLabels(logger.FormatLabels("trace-id=1", "span-id=2") + "hook failed")
+	c.Assert(labels, jc.DeepEquals, []string{"trace-id=1", "span-id=2"})
+	c.Assert(message, gc.Equals, "hook failed")
+}
+
+func (s *labelsSuite) TestParseLabelsNone(c *gc.C) {
+	for _, message := range []string{
+		"hook failed",
+		"[labels unterminated",
+		"[labels ] empty",
+		"[other] message",
+	} {
+		labels, rest := logger.ParseLabels(message)
+		c.Check(labels, gc.IsNil)
+		c.Check(rest, gc.Equals, message)
+	}
+}
@@ -51,6 +51,10 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// Secrets contains the serialized secrets in the model. Secrets
+	// are not yet part of the model description so are sent separately.
+	Secrets []byte
 }
 
 // SerializedModelResource defines the resource revisions for a
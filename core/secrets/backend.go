@@ -0,0 +1,27 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+const (
+	// InternalBackend stores secret content in the controller
+	// database alongside its metadata.
+	InternalBackend = "internal"
+
+	// KubernetesBackend stores secret content as secrets in the
+	// model's Kubernetes namespace.
+	KubernetesBackend = "kubernetes"
+
+	// VaultBackend stores secret content in a Vault server.
+	VaultBackend = "vault"
+)
+
+// IsBackendType returns true if backendType is a known
+// type of secret backend.
+func IsBackendType(backendType string) bool {
+	switch backendType {
+	case InternalBackend, KubernetesBackend, VaultBackend:
+		return true
+	}
+	return false
+}
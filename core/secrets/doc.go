@@ -0,0 +1,11 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets holds the types used to describe charm secrets:
+// the URIs used to refer to them, their metadata and their content.
+//
+// A secret is owned by an application and is created by that
+// application's leader unit. The content of a secret is versioned;
+// each update creates a new revision. The owner may grant other
+// applications or units the right to read a secret.
+package secrets
@@ -0,0 +1,170 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+)
+
+// SecretScheme is the URL scheme used for secret URIs.
+const SecretScheme = "secret"
+
+// URI represents a reference to a secret.
+type URI struct {
+	// ModelUUID is the UUID of the model hosting the secret.
+	ModelUUID string
+
+	// ID is the model unique identifier of the secret.
+	ID string
+}
+
+var idRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// NewURI returns a new secret URI with a unique id
+// for the specified model.
+func NewURI(modelUUID string) *URI {
+	return &URI{
+		ModelUUID: modelUUID,
+		ID:        utils.MustNewUUID().String(),
+	}
+}
+
+// ParseURI parses the specified string into a secret URI.
+// The expected format is secret://<model-uuid>/<id>.
+func ParseURI(str string) (*URI, error) {
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if u.Scheme != SecretScheme {
+		return nil, errors.NotValidf("secret URI scheme %q", u.Scheme)
+	}
+	if !utils.IsValidUUIDString(u.Host) {
+		return nil, errors.NotValidf("secret URI model UUID %q", u.Host)
+	}
+	id := strings.TrimPrefix(u.Path, "/")
+	if !idRegexp.MatchString(id) {
+		return nil, errors.NotValidf("secret URI id %q", id)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return nil, errors.NotValidf("secret URI %q", str)
+	}
+	return &URI{
+		ModelUUID: u.Host,
+		ID:        id,
+	}, nil
+}
+
+// String returns the string representation of the secret URI.
+func (u *URI) String() string {
+	if u == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s/%s", SecretScheme, u.ModelUUID, u.ID)
+}
+
+// MinRotateInterval is the smallest rotate interval a secret may have.
+const MinRotateInterval = time.Hour
+
+// SecretConfig is used when creating or updating a secret.
+type SecretConfig struct {
+	// RotateInterval, if set, is how often the secret
+	// should be rotated.
+	RotateInterval *time.Duration
+
+	// Description, if set, is a human readable description of the secret.
+	Description *string
+}
+
+// Validate returns an error if the config is not valid.
+func (c *SecretConfig) Validate() error {
+	if c.RotateInterval != nil && *c.RotateInterval != 0 && *c.RotateInterval < MinRotateInterval {
+		return errors.NotValidf("rotate interval %v less than %v", *c.RotateInterval, MinRotateInterval)
+	}
+	if c.RotateInterval != nil && *c.RotateInterval < 0 {
+		return errors.NotValidf("negative rotate interval")
+	}
+	return nil
+}
+
+// SecretMetadata holds metadata about a secret.
+type SecretMetadata struct {
+	// URI is the reference to the secret.
+	URI *URI
+
+	// OwnerTag is the tag of the application which owns the secret.
+	OwnerTag string
+
+	// Description is a human readable description of the secret.
+	Description string
+
+	// RotateInterval is how often the secret should be rotated.
+	// A zero value means the secret is not rotated.
+	RotateInterval time.Duration
+
+	// NextRotateTime is when the secret is next due to be rotated.
+	// It is nil if the secret is not rotated.
+	NextRotateTime *time.Time
+
+	// LatestRevision is the most recent revision of the secret content.
+	LatestRevision int
+
+	// CreateTime is when the secret was created.
+	CreateTime time.Time
+
+	// UpdateTime is when the secret was last updated.
+	UpdateTime time.Time
+}
+
+// SecretRole is an access role on a secret.
+type SecretRole string
+
+const (
+	// RoleNone means no access to the secret.
+	RoleNone SecretRole = ""
+
+	// RoleView allows the secret content to be read.
+	RoleView SecretRole = "view"
+
+	// RoleManage allows the secret to be updated, and
+	// access to it granted and revoked.
+	RoleManage SecretRole = "manage"
+)
+
+// IsValid returns true if r is a known secret role.
+func (r SecretRole) IsValid() bool {
+	switch r {
+	case RoleView, RoleManage:
+		return true
+	}
+	return false
+}
+
+// Allowed returns true if r grants at least the wanted role.
+func (r SecretRole) Allowed(wanted SecretRole) bool {
+	switch wanted {
+	case RoleView:
+		return r == RoleView || r == RoleManage
+	case RoleManage:
+		return r == RoleManage
+	}
+	return false
+}
+
+// ValueRef is a reference to secret content held
+// in an external secret backend.
+type ValueRef struct {
+	// BackendType is the type of backend holding the content.
+	BackendType string
+
+	// RevisionID is the backend specific id of the content.
+	RevisionID string
+}
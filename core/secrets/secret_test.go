@@ -0,0 +1,86 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretURISuite struct{}
+
+var _ = gc.Suite(&SecretURISuite{})
+
+func (s *SecretURISuite) TestParseURI(c *gc.C) {
+	str := "secret://" + coretesting.ModelTag.Id() + "/9e4bd72c-2c1b-4a2c-8d4e-6d2c4bc6e2f1"
+	uri, err := secrets.ParseURI(str)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uri, jc.DeepEquals, &secrets.URI{
+		ModelUUID: coretesting.ModelTag.Id(),
+		ID:        "9e4bd72c-2c1b-4a2c-8d4e-6d2c4bc6e2f1",
+	})
+	c.Assert(uri.String(), gc.Equals, str)
+}
+
+func (s *SecretURISuite) TestParseURIErrors(c *gc.C) {
+	for _, t := range []struct {
+		in  string
+		err string
+	}{{
+		in:  "http://" + coretesting.ModelTag.Id() + "/id",
+		err: `secret URI scheme "http" not valid`,
+	}, {
+		in:  "secret://model/id",
+		err: `secret URI model UUID "model" not valid`,
+	}, {
+		in:  "secret://" + coretesting.ModelTag.Id() + "/",
+		err: `secret URI id "" not valid`,
+	}, {
+		in:  "secret://" + coretesting.ModelTag.Id() + "/id?foo=bar",
+		err: `secret URI ".*" not valid`,
+	}} {
+		_, err := secrets.ParseURI(t.in)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretURISuite) TestNewURI(c *gc.C) {
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	parsed, err := secrets.ParseURI(uri.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, uri)
+}
+
+type SecretConfigSuite struct{}
+
+var _ = gc.Suite(&SecretConfigSuite{})
+
+func (s *SecretConfigSuite) TestValidate(c *gc.C) {
+	interval := 2 * time.Hour
+	cfg := secrets.SecretConfig{RotateInterval: &interval}
+	c.Assert(cfg.Validate(), jc.ErrorIsNil)
+
+	interval = 0
+	c.Assert(cfg.Validate(), jc.ErrorIsNil)
+
+	interval = time.Minute
+	c.Assert(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
+
+	interval = -time.Hour
+	c.Assert(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SecretConfigSuite) TestRoleAllowed(c *gc.C) {
+	c.Assert(secrets.RoleView.Allowed(secrets.RoleView), jc.IsTrue)
+	c.Assert(secrets.RoleView.Allowed(secrets.RoleManage), jc.IsFalse)
+	c.Assert(secrets.RoleManage.Allowed(secrets.RoleView), jc.IsTrue)
+	c.Assert(secrets.RoleManage.Allowed(secrets.RoleManage), jc.IsTrue)
+	c.Assert(secrets.RoleNone.Allowed(secrets.RoleView), jc.IsFalse)
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// SecretData holds secret key values, with the values
+// base64 encoded.
+type SecretData map[string]string
+
+// SecretValue holds the content of a secret revision.
+type SecretValue interface {
+	// EncodedValues returns the key values of a secret as
+	// the raw base64 encoded strings.
+	EncodedValues() map[string]string
+
+	// Values returns the key values of a secret as strings.
+	Values() (map[string]string, error)
+
+	// KeyValue returns the decoded value of the specified key.
+	KeyValue(key string) (string, error)
+
+	// IsEmpty returns true if the secret has no content.
+	IsEmpty() bool
+}
+
+type secretValue struct {
+	data SecretData
+}
+
+// NewSecretValue returns a secret value for the specified
+// base64 encoded data.
+func NewSecretValue(data map[string]string) SecretValue {
+	dataCopy := make(SecretData, len(data))
+	for k, v := range data {
+		dataCopy[k] = v
+	}
+	return &secretValue{data: dataCopy}
+}
+
+// EncodedValues implements SecretValue.
+func (v secretValue) EncodedValues() map[string]string {
+	result := make(map[string]string, len(v.data))
+	for k, val := range v.data {
+		result[k] = val
+	}
+	return result
+}
+
+// Values implements SecretValue.
+func (v secretValue) Values() (map[string]string, error) {
+	result := make(map[string]string, len(v.data))
+	for k, val := range v.data {
+		data, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decoding secret key %q", k)
+		}
+		result[k] = string(data)
+	}
+	return result, nil
+}
+
+// KeyValue implements SecretValue.
+func (v secretValue) KeyValue(key string) (string, error) {
+	val, ok := v.data[key]
+	if !ok {
+		return "", errors.NotFoundf("secret key %q", key)
+	}
+	data, err := base64.StdEncoding.DecodeString(val)
+	if err != nil {
+		return "", errors.Annotatef(err, "decoding secret key %q", key)
+	}
+	return string(data), nil
+}
+
+// IsEmpty implements SecretValue.
+func (v secretValue) IsEmpty() bool {
+	return len(v.data) == 0
+}
+
+const base64Suffix = "#base64"
+
+var keyRegExp = regexp.MustCompile("^([a-z](?:-?[a-z0-9]){2,})$")
+
+// CreateSecretData creates secret data from the specified
+// key=value arguments. Values are base64 encoded, unless the
+// key has a "#base64" suffix, in which case the value is
+// assumed to be already encoded.
+func CreateSecretData(args []string) (SecretData, error) {
+	data := make(SecretData)
+	for _, val := range args {
+		keyVal := strings.SplitN(val, "=", 2)
+		if len(keyVal) != 2 {
+			return nil, errors.NotValidf("key value %q", val)
+		}
+		key, value := keyVal[0], keyVal[1]
+		if strings.HasSuffix(key, base64Suffix) {
+			key = strings.TrimSuffix(key, base64Suffix)
+			if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+				return nil, errors.NotValidf("base64 value for key %q", key)
+			}
+		} else {
+			value = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+		if !keyRegExp.MatchString(key) {
+			return nil, errors.NotValidf("key %q", key)
+		}
+		if _, ok := data[key]; ok {
+			return nil, errors.Errorf("duplicate key %q", key)
+		}
+		data[key] = value
+	}
+	return data, nil
+}
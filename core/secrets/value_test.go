@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+type SecretValueSuite struct{}
+
+var _ = gc.Suite(&SecretValueSuite{})
+
+func (s *SecretValueSuite) TestCreateSecretData(c *gc.C) {
+	data, err := secrets.CreateSecretData([]string{"password=s3cret", "token#base64=aGVsbG8="})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, secrets.SecretData{
+		"password": "czNjcmV0",
+		"token":    "aGVsbG8=",
+	})
+}
+
+func (s *SecretValueSuite) TestCreateSecretDataErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"password"},
+		err:  `key value "password" not valid`,
+	}, {
+		args: []string{"Password=foo"},
+		err:  `key "Password" not valid`,
+	}, {
+		args: []string{"token#base64=!!!"},
+		err:  `base64 value for key "token" not valid`,
+	}, {
+		args: []string{"password=foo", "password=bar"},
+		err:  `duplicate key "password"`,
+	}} {
+		_, err := secrets.CreateSecretData(t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretValueSuite) TestValues(c *gc.C) {
+	val := secrets.NewSecretValue(map[string]string{"password": "czNjcmV0"})
+	c.Assert(val.IsEmpty(), jc.IsFalse)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"password": "czNjcmV0"})
+	values, err := val.Values()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]string{"password": "s3cret"})
+	v, err := val.KeyValue("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(v, gc.Equals, "s3cret")
+	_, err = val.KeyValue("missing")
+	c.Assert(err, gc.ErrorMatches, `secret key "missing" not found`)
+}
@@ -171,6 +171,10 @@ const (
 	// machine.
 	Attached Status = "attached"
 
+	// Warning indicates that the storage is attached to a
+	// machine, but is running low on free space.
+	Warning Status = "warning"
+
 	// Detaching indicates that the storage is being detached
 	// from a machine.
 	Detaching Status = "detaching"
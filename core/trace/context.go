@@ -0,0 +1,45 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace
+
+import (
+	"context"
+)
+
+type spanKey struct{}
+
+type remoteSpanContextKey struct{}
+
+// ContextWithSpan returns a copy of ctx holding the span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span held by ctx. If there is none,
+// a span which records nothing is returned, so the result is
+// always safe to use.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+// ContextWithRemoteSpanContext returns a copy of ctx holding a span
+// context received from another process. Spans started from the
+// returned context are children of the remote span.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the context of the span held by ctx,
+// or failing that, the remote span context held by ctx. The result is
+// not valid if ctx holds neither.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span.SpanContext()
+	}
+	sc, _ := ctx.Value(remoteSpanContextKey{}).(SpanContext)
+	return sc
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	defaultMu     sync.RWMutex
+	defaultTracer Tracer = noopTracer{}
+)
+
+// SetDefaultTracer sets the tracer used by Start. If tracer is nil,
+// spans are no longer recorded, but span contexts received from other
+// processes continue to be propagated.
+func SetDefaultTracer(tracer Tracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	defaultMu.Lock()
+	defaultTracer = tracer
+	defaultMu.Unlock()
+}
+
+// DefaultTracer returns the tracer used by Start.
+func DefaultTracer() Tracer {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultTracer
+}
+
+// Start starts a span using the default tracer.
+// See Tracer.Start for details.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return DefaultTracer().Start(ctx, name, attrs...)
+}
+
+// noopTracer creates spans which record nothing,
+// but which carry the parent span context, if any.
+type noopTracer struct{}
+
+// Start implements Tracer.
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	span := noopSpan{sc: SpanContextFromContext(ctx)}
+	return ContextWithSpan(ctx, span), span
+}
+
+type noopSpan struct {
+	sc SpanContext
+}
+
+func (s noopSpan) SpanContext() SpanContext { return s.sc }
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
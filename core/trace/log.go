@@ -0,0 +1,125 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace
+
+import (
+	"context"
+
+	"github.com/juju/loggo"
+
+	corelogger "github.com/juju/juju/core/logger"
+)
+
+// LeveledLogger is the logging interface used by workers,
+// which WrapLogger annotates with a span context.
+type LeveledLogger interface {
+	Errorf(string, ...interface{})
+	Warningf(string, ...interface{})
+	Infof(string, ...interface{})
+	Debugf(string, ...interface{})
+	Tracef(string, ...interface{})
+}
+
+// LogLabels returns the log labels identifying the span held by
+// ctx: "trace-id=<id>" and "span-id=<id>". If ctx holds no valid
+// span context, there are no labels.
+func LogLabels(ctx context.Context) []string {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []string{"trace-id=" + sc.TraceID.String(), "span-id=" + sc.SpanID.String()}
+}
+
+// Logger returns a logger which labels messages with the trace and
+// span IDs of the span held by ctx. The labels are sent with the log
+// records to the controller, so that records shown by debug-log can
+// be correlated with traces, and filtered with --include-label. If
+// ctx holds no valid span context, messages are logged unchanged.
+func Logger(ctx context.Context, logger loggo.Logger) ContextLogger {
+	return ContextLogger{logger: logger, prefix: corelogger.FormatLabels(LogLabels(ctx)...)}
+}
+
+// WrapLogger returns a logger which labels messages as Logger does.
+// Messages logged through a loggo.Logger keep the location of their
+// caller.
+func WrapLogger(ctx context.Context, logger LeveledLogger) LeveledLogger {
+	if l, ok := logger.(loggo.Logger); ok {
+		return Logger(ctx, l)
+	}
+	prefix := corelogger.FormatLabels(LogLabels(ctx)...)
+	if prefix == "" {
+		return logger
+	}
+	return prefixLogger{logger: logger, prefix: prefix}
+}
+
+// ContextLogger logs messages annotated with a span context.
+type ContextLogger struct {
+	logger loggo.Logger
+	prefix string
+}
+
+// logf skips itself and the level method calling it, so that
+// the source of the message is reported as that method's caller.
+func (l ContextLogger) logf(level loggo.Level, message string, args ...interface{}) {
+	l.logger.LogCallf(2, level, l.prefix+message, args...)
+}
+
+// Criticalf logs a message at the critical level.
+func (l ContextLogger) Criticalf(message string, args ...interface{}) {
+	l.logf(loggo.CRITICAL, message, args...)
+}
+
+// Errorf logs a message at the error level.
+func (l ContextLogger) Errorf(message string, args ...interface{}) {
+	l.logf(loggo.ERROR, message, args...)
+}
+
+// Warningf logs a message at the warning level.
+func (l ContextLogger) Warningf(message string, args ...interface{}) {
+	l.logf(loggo.WARNING, message, args...)
+}
+
+// Infof logs a message at the info level.
+func (l ContextLogger) Infof(message string, args ...interface{}) {
+	l.logf(loggo.INFO, message, args...)
+}
+
+// Debugf logs a message at the debug level.
+func (l ContextLogger) Debugf(message string, args ...interface{}) {
+	l.logf(loggo.DEBUG, message, args...)
+}
+
+// Tracef logs a message at the trace level.
+func (l ContextLogger) Tracef(message string, args ...interface{}) {
+	l.logf(loggo.TRACE, message, args...)
+}
+
+// prefixLogger prefixes messages logged through
+// a LeveledLogger which isn't a loggo.Logger.
+type prefixLogger struct {
+	logger LeveledLogger
+	prefix string
+}
+
+func (l prefixLogger) Errorf(message string, args ...interface{}) {
+	l.logger.Errorf(l.prefix+message, args...)
+}
+
+func (l prefixLogger) Warningf(message string, args ...interface{}) {
+	l.logger.Warningf(l.prefix+message, args...)
+}
+
+func (l prefixLogger) Infof(message string, args ...interface{}) {
+	l.logger.Infof(l.prefix+message, args...)
+}
+
+func (l prefixLogger) Debugf(message string, args ...interface{}) {
+	l.logger.Debugf(l.prefix+message, args...)
+}
+
+func (l prefixLogger) Tracef(message string, args ...interface{}) {
+	l.logger.Tracef(l.prefix+message, args...)
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelogger "github.com/juju/juju/core/logger"
+	"github.com/juju/juju/core/trace"
+)
+
+type logSuite struct {
+	testing.LoggingCleanupSuite
+}
+
+var _ = gc.Suite(&logSuite{})
+
+func (s *logSuite) TestLogger(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("trace-test", &tw), gc.IsNil)
+	logger := loggo.GetLogger("test.trace")
+	logger.SetLogLevel(loggo.DEBUG)
+
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), testSpanContext)
+	trace.Logger(ctx, logger).Infof("hello %s", "world")
+	trace.Logger(context.Background(), logger).Debugf("plain")
+
+	log := tw.Log()
+	c.Assert(log, gc.HasLen, 2)
+	c.Assert(log[0].Level, gc.Equals, loggo.INFO)
+	c.Assert(log[0].Message, gc.Equals, "[labels trace-id=4bf92f3577b34da6a3ce929d0e0e4736 span-id=00f067aa0ba902b7] hello world")
+	c.Assert(log[0].Filename, gc.Equals, "log_test.go")
+	c.Assert(log[1].Message, gc.Equals, "plain")
+
+	labels, message := corelogger.ParseLabels(log[0].Message)
+	c.Assert(labels, jc.DeepEquals, []string{
+		"trace-id=4bf92f3577b34da6a3ce929d0e0e4736",
+		"span-id=00f067aa0ba902b7",
+	})
+	c.Assert(message, gc.Equals, "hello world")
+}
+
+func (s *logSuite) TestLogLabels(c *gc.C) {
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), testSpanContext)
+	c.Assert(trace.LogLabels(ctx), jc.DeepEquals, []string{
+		"trace-id=4bf92f3577b34da6a3ce929d0e0e4736",
+		"span-id=00f067aa0ba902b7",
+	})
+	c.Assert(trace.LogLabels(context.Background()), gc.HasLen, 0)
+}
+
+func (s *logSuite) TestWrapLoggoLogger(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("trace-test", &tw), gc.IsNil)
+	logger := loggo.GetLogger("test.trace")
+	logger.SetLogLevel(loggo.DEBUG)
+
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), testSpanContext)
+	trace.WrapLogger(ctx, logger).Infof("hello")
+
+	log := tw.Log()
+	c.Assert(log, gc.HasLen, 1)
+	c.Assert(log[0].Message, gc.Matches, `\[labels trace-id=\w+ span-id=\w+\] hello`)
+	c.Assert(log[0].Filename, gc.Equals, "log_test.go")
+}
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) record(message string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(message, args...))
+}
+
+func (l *recordingLogger) Errorf(message string, args ...interface{})   { l.record(message, args...) }
+func (l *recordingLogger) Warningf(message string, args ...interface{}) { l.record(message, args...) }
+func (l *recordingLogger) Infof(message string, args ...interface{})    { l.record(message, args...) }
+func (l *recordingLogger) Debugf(message string, args ...interface{})   { l.record(message, args...) }
+func (l *recordingLogger) Tracef(message string, args ...interface{})   { l.record(message, args...) }
+
+func (s *logSuite) TestWrapLogger(c *gc.C) {
+	var logger recordingLogger
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), testSpanContext)
+	trace.WrapLogger(ctx, &logger).Warningf("hello %d", 1)
+	c.Assert(logger.messages, jc.DeepEquals, []string{
+		"[labels trace-id=4bf92f3577b34da6a3ce929d0e0e4736 span-id=00f067aa0ba902b7] hello 1",
+	})
+
+	// Without a span, the logger is used as is.
+	c.Assert(trace.WrapLogger(context.Background(), &logger), gc.Equals, &logger)
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package otlp exports spans to an OpenTelemetry collector using
+// the OTLP/HTTP protocol with JSON encoding.
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/trace"
+)
+
+const (
+	tracesPath = "/v1/traces"
+
+	// scopeName identifies the instrumentation which created the spans.
+	scopeName = "github.com/juju/juju"
+
+	spanKindInternal = 1
+	statusCodeError  = 2
+
+	// maxErrorBody limits how much of an error response is reported.
+	maxErrorBody = 1024
+)
+
+// Config holds the configuration of an Exporter.
+type Config struct {
+	// Endpoint is the base URL of the collector,
+	// for example "http://collector:4318".
+	Endpoint string
+
+	// ServiceName identifies the process sending spans.
+	ServiceName string
+
+	// Client is used to send requests. If nil,
+	// a client with a 30 second timeout is used.
+	Client *http.Client
+}
+
+// Validate returns an error if the config is not valid.
+func (c Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if c.ServiceName == "" {
+		return errors.NotValidf("empty ServiceName")
+	}
+	return nil
+}
+
+// Exporter sends spans to an OpenTelemetry collector.
+type Exporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+}
+
+// NewExporter returns an exporter sending spans
+// to the collector specified in config.
+func NewExporter(config Config) (*Exporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := config.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Exporter{
+		url:         strings.TrimRight(config.Endpoint, "/") + tracesPath,
+		serviceName: config.ServiceName,
+		client:      client,
+	}, nil
+}
+
+// ExportSpans implements trace.Exporter.
+func (e *Exporter) ExportSpans(spans []trace.SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Annotate(err, "exporting spans")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return errors.Errorf("exporting spans: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// The types below are the JSON mapping of the OTLP
+// ExportTraceServiceRequest protobuf message.
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            *status    `json:"status,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e *Exporter) request(spans []trace.SpanData) exportRequest {
+	out := make([]span, len(spans))
+	for i, s := range spans {
+		out[i] = span{
+			TraceID:           s.SpanContext.TraceID.String(),
+			SpanID:            s.SpanContext.SpanID.String(),
+			Name:              s.Name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: unixNano(s.StartTime),
+			EndTimeUnixNano:   unixNano(s.EndTime),
+			Attributes:        keyValues(s.Attributes),
+		}
+		if s.ParentSpanID.IsValid() {
+			out[i].ParentSpanID = s.ParentSpanID.String()
+		}
+		if s.Error != "" {
+			out[i].Status = &status{Code: statusCodeError, Message: s.Error}
+		}
+	}
+	return exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: keyValues([]trace.Attribute{
+					trace.StringAttr("service.name", e.serviceName),
+				}),
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: scopeName},
+				Spans: out,
+			}},
+		}},
+	}
+}
+
+func keyValues(attrs []trace.Attribute) []keyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	result := make([]keyValue, len(attrs))
+	for i, attr := range attrs {
+		result[i] = keyValue{Key: attr.Key, Value: anyValue{StringValue: attr.Value}}
+	}
+	return result
+}
+
+// unixNano formats t as the string encoding of a
+// 64 bit integer, as the protobuf JSON mapping requires.
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+	"github.com/juju/juju/core/trace/otlp"
+)
+
+type otlpSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&otlpSuite{})
+
+func (s *otlpSuite) TestValidate(c *gc.C) {
+	_, err := otlp.NewExporter(otlp.Config{ServiceName: "juju"})
+	c.Assert(err, gc.ErrorMatches, "empty Endpoint not valid")
+	_, err = otlp.NewExporter(otlp.Config{Endpoint: "http://localhost:4318"})
+	c.Assert(err, gc.ErrorMatches, "empty ServiceName not valid")
+}
+
+func (s *otlpSuite) TestExportSpans(c *gc.C) {
+	var (
+		path, contentType string
+		body              map[string]interface{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		contentType = req.Header.Get("Content-Type")
+		data, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(json.Unmarshal(data, &body), jc.ErrorIsNil)
+	}))
+	defer srv.Close()
+
+	exporter, err := otlp.NewExporter(otlp.Config{
+		Endpoint:    srv.URL + "/",
+		ServiceName: "machine-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	start := time.Unix(1, 500)
+	err = exporter.ExportSpans([]trace.SpanData{{
+		Name: "op",
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{15: 1},
+			SpanID:  trace.SpanID{7: 2},
+			Sampled: true,
+		},
+		ParentSpanID: trace.SpanID{7: 3},
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   []trace.Attribute{trace.StringAttr("a", "b")},
+		Error:        "boom",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, "/v1/traces")
+	c.Assert(contentType, gc.Equals, "application/json")
+
+	var expected map[string]interface{}
+	err = json.Unmarshal([]byte(`{
+		"resourceSpans": [{
+			"resource": {
+				"attributes": [{"key": "service.name", "value": {"stringValue": "machine-0"}}]
+			},
+			"scopeSpans": [{
+				"scope": {"name": "github.com/juju/juju"},
+				"spans": [{
+					"traceId": "00000000000000000000000000000001",
+					"spanId": "0000000000000002",
+					"parentSpanId": "0000000000000003",
+					"name": "op",
+					"kind": 1,
+					"startTimeUnixNano": "1000000500",
+					"endTimeUnixNano": "2000000500",
+					"attributes": [{"key": "a", "value": {"stringValue": "b"}}],
+					"status": {"code": 2, "message": "boom"}
+				}]
+			}]
+		}]
+	}`), &expected)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(body, jc.DeepEquals, expected)
+}
+
+func (s *otlpSuite) TestExportSpansNone(c *gc.C) {
+	exporter, err := otlp.NewExporter(otlp.Config{
+		Endpoint:    "http://0.1.2.3:4318",
+		ServiceName: "machine-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exporter.ExportSpans(nil), jc.ErrorIsNil)
+}
+
+func (s *otlpSuite) TestExportSpansError(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "bad spans", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exporter, err := otlp.NewExporter(otlp.Config{Endpoint: srv.URL, ServiceName: "machine-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = exporter.ExportSpans([]trace.SpanData{{Name: "op"}})
+	c.Assert(err, gc.ErrorMatches, "exporting spans: 400 Bad Request: bad spans")
+}
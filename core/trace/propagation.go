@@ -0,0 +1,68 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	traceParentVersion = "00"
+	flagSampled        = 0x01
+)
+
+// TraceParent returns the span context formatted as a W3C
+// traceparent header value, or "" if the context is not valid.
+// See https://www.w3.org/TR/trace-context/#traceparent-header.
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	var flags byte
+	if sc.Sampled {
+		flags |= flagSampled
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceParent parses a W3C traceparent header value.
+func ParseTraceParent(s string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(s, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, errors.NotValidf("traceparent %q", s)
+	}
+	// Later versions may append fields, but must keep
+	// the layout of those defined by version 00.
+	if parts[0] == traceParentVersion && len(parts) != 4 {
+		return sc, errors.NotValidf("traceparent %q", s)
+	}
+	if err := decodeHex(sc.TraceID[:], parts[1]); err != nil {
+		return sc, errors.NotValidf("traceparent %q trace id", s)
+	}
+	if err := decodeHex(sc.SpanID[:], parts[2]); err != nil {
+		return sc, errors.NotValidf("traceparent %q span id", s)
+	}
+	var flags [1]byte
+	if err := decodeHex(flags[:], parts[3]); err != nil {
+		return sc, errors.NotValidf("traceparent %q flags", s)
+	}
+	if !sc.IsValid() {
+		return sc, errors.NotValidf("traceparent %q with zero id", s)
+	}
+	sc.Sampled = flags[0]&flagSampled != 0
+	return sc, nil
+}
+
+func decodeHex(dst []byte, s string) error {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return errors.New("bad length or case")
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+)
+
+type propagationSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&propagationSuite{})
+
+var testSpanContext = trace.SpanContext{
+	TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+	SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	Sampled: true,
+}
+
+func (s *propagationSuite) TestTraceParent(c *gc.C) {
+	c.Assert(testSpanContext.TraceParent(), gc.Equals, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	unsampled := testSpanContext
+	unsampled.Sampled = false
+	c.Assert(unsampled.TraceParent(), gc.Equals, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+	c.Assert(trace.SpanContext{}.TraceParent(), gc.Equals, "")
+}
+
+func (s *propagationSuite) TestParseTraceParent(c *gc.C) {
+	sc, err := trace.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sc, gc.Equals, testSpanContext)
+
+	// Later versions may add fields.
+	sc, err = trace.ParseTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sc.SpanID, gc.Equals, testSpanContext.SpanID)
+	c.Assert(sc.Sampled, jc.IsFalse)
+}
+
+func (s *propagationSuite) TestParseTraceParentInvalid(c *gc.C) {
+	for i, value := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902zz-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+	} {
+		c.Logf("%d: %q", i, value)
+		_, err := trace.ParseTraceParent(value)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
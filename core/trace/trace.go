@@ -0,0 +1,100 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package trace provides distributed tracing of operations across the
+// API server, RPC connections and agents. Span contexts are propagated
+// between processes using the W3C trace context format, and finished
+// spans are exported in batches, typically to an OpenTelemetry collector.
+package trace
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+)
+
+// TraceID identifies a trace: a tree of spans which may cross
+// process boundaries.
+type TraceID [16]byte
+
+// IsValid returns whether the trace ID is non-zero.
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// String returns the hex encoding of the trace ID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// IsValid returns whether the span ID is non-zero.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// String returns the hex encoding of the span ID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext holds the identity of a span, which is all
+// that is propagated between processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+
+	// Sampled records whether the span, and therefore its
+	// children, are recorded.
+	Sampled bool
+}
+
+// IsValid returns whether both the trace and span IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// StringAttr returns an attribute with a string value.
+func StringAttr(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// IntAttr returns an attribute with an integer value.
+func IntAttr(key string, value int) Attribute {
+	return Attribute{Key: key, Value: strconv.Itoa(value)}
+}
+
+// Span represents a single timed operation within a trace.
+type Span interface {
+	// SpanContext returns the identity of the span.
+	SpanContext() SpanContext
+
+	// SetAttributes adds the attributes to the span,
+	// replacing any existing values with the same keys.
+	SetAttributes(attrs ...Attribute)
+
+	// RecordError records that the operation failed
+	// with the error. It is a no-op if err is nil.
+	RecordError(err error)
+
+	// End completes the span. Calls to the span's
+	// methods after End have no effect.
+	End()
+}
+
+// Tracer creates spans.
+type Tracer interface {
+	// Start starts a new span with the given name. The span is a
+	// child of the span in ctx, if any, otherwise of the remote
+	// span context in ctx, if any; otherwise it starts a new trace.
+	// The returned context holds the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
@@ -0,0 +1,174 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+)
+
+// SpanData holds the details of a finished span.
+type SpanData struct {
+	Name         string
+	SpanContext  SpanContext
+	ParentSpanID SpanID
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   []Attribute
+
+	// Error holds the message of the error recorded
+	// against the span, if any.
+	Error string
+}
+
+// Exporter sends finished spans to a collector.
+type Exporter interface {
+	ExportSpans(spans []SpanData) error
+}
+
+// BufferedTracer is a Tracer which holds finished spans
+// in memory until they are collected by Drain.
+type BufferedTracer struct {
+	clock    clock.Clock
+	maxSpans int
+
+	mu      sync.Mutex
+	spans   []SpanData
+	dropped int
+}
+
+// NewBufferedTracer returns a tracer which holds up to maxSpans
+// finished spans. Spans which finish while the buffer is full
+// are dropped.
+func NewBufferedTracer(clock clock.Clock, maxSpans int) *BufferedTracer {
+	return &BufferedTracer{
+		clock:    clock,
+		maxSpans: maxSpans,
+	}
+}
+
+// Start implements Tracer.
+func (t *BufferedTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	parent := SpanContextFromContext(ctx)
+	if parent.IsValid() && !parent.Sampled {
+		// The originator of the trace chose not to record it,
+		// so neither do we, but the decision is propagated.
+		span := noopSpan{sc: parent}
+		return ContextWithSpan(ctx, span), span
+	}
+	span := &bufferedSpan{
+		tracer: t,
+		data: SpanData{
+			Name:         name,
+			ParentSpanID: parent.SpanID,
+			StartTime:    t.clock.Now(),
+		},
+	}
+	span.data.SpanContext = SpanContext{
+		TraceID: parent.TraceID,
+		Sampled: true,
+	}
+	if !parent.IsValid() {
+		randomID(span.data.SpanContext.TraceID[:])
+	}
+	randomID(span.data.SpanContext.SpanID[:])
+	span.SetAttributes(attrs...)
+	return ContextWithSpan(ctx, span), span
+}
+
+// Drain returns the finished spans held by the tracer, along
+// with the number of spans dropped since the last call, and
+// empties the buffer.
+func (t *BufferedTracer) Drain() ([]SpanData, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans, dropped := t.spans, t.dropped
+	t.spans, t.dropped = nil, 0
+	return spans, dropped
+}
+
+func (t *BufferedTracer) finish(data SpanData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.spans) >= t.maxSpans {
+		t.dropped++
+		return
+	}
+	t.spans = append(t.spans, data)
+}
+
+type bufferedSpan struct {
+	tracer *BufferedTracer
+
+	mu    sync.Mutex
+	ended bool
+	data  SpanData
+}
+
+// SpanContext implements Span.
+func (s *bufferedSpan) SpanContext() SpanContext {
+	return s.data.SpanContext
+}
+
+// SetAttributes implements Span.
+func (s *bufferedSpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+next:
+	for _, attr := range attrs {
+		for i, existing := range s.data.Attributes {
+			if existing.Key == attr.Key {
+				s.data.Attributes[i] = attr
+				continue next
+			}
+		}
+		s.data.Attributes = append(s.data.Attributes, attr)
+	}
+}
+
+// RecordError implements Span.
+func (s *bufferedSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.data.Error = err.Error()
+	}
+}
+
+// End implements Span.
+func (s *bufferedSpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.EndTime = s.tracer.clock.Now()
+	data := s.data
+	s.mu.Unlock()
+	s.tracer.finish(data)
+}
+
+// randomID fills id with random bytes. Failure to read random data
+// is not fatal to tracing, so it is ignored, but an all-zero ID is
+// avoided to keep the span context valid.
+func randomID(id []byte) {
+	_, _ = rand.Read(id)
+	for _, b := range id {
+		if b != 0 {
+			return
+		}
+	}
+	id[len(id)-1] = 1
+}
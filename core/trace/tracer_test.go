@@ -0,0 +1,135 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+)
+
+type tracerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	tracer *trace.BufferedTracer
+}
+
+var _ = gc.Suite(&tracerSuite{})
+
+func (s *tracerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.tracer = trace.NewBufferedTracer(s.clock, 2)
+}
+
+func (s *tracerSuite) TestStartNewTrace(c *gc.C) {
+	ctx, span := s.tracer.Start(context.Background(), "op", trace.StringAttr("a", "1"))
+	sc := span.SpanContext()
+	c.Assert(sc.IsValid(), jc.IsTrue)
+	c.Assert(sc.Sampled, jc.IsTrue)
+	c.Assert(trace.SpanFromContext(ctx), gc.Equals, span)
+
+	s.clock.Advance(time.Second)
+	span.SetAttributes(trace.StringAttr("a", "2"), trace.IntAttr("b", 3))
+	span.RecordError(errors.New("boom"))
+	span.End()
+	// Changes after End are ignored.
+	span.SetAttributes(trace.StringAttr("c", "4"))
+	span.End()
+
+	spans, dropped := s.tracer.Drain()
+	c.Assert(dropped, gc.Equals, 0)
+	c.Assert(spans, jc.DeepEquals, []trace.SpanData{{
+		Name:        "op",
+		SpanContext: sc,
+		StartTime:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2021, 1, 1, 0, 0, 1, 0, time.UTC),
+		Attributes: []trace.Attribute{
+			{Key: "a", Value: "2"},
+			{Key: "b", Value: "3"},
+		},
+		Error: "boom",
+	}})
+
+	spans, _ = s.tracer.Drain()
+	c.Assert(spans, gc.HasLen, 0)
+}
+
+func (s *tracerSuite) TestStartChild(c *gc.C) {
+	ctx, parent := s.tracer.Start(context.Background(), "parent")
+	_, child := s.tracer.Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans, _ := s.tracer.Drain()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Assert(spans[0].Name, gc.Equals, "child")
+	c.Assert(spans[0].SpanContext.TraceID, gc.Equals, parent.SpanContext().TraceID)
+	c.Assert(spans[0].ParentSpanID, gc.Equals, parent.SpanContext().SpanID)
+	c.Assert(spans[0].SpanContext.SpanID, gc.Not(gc.Equals), parent.SpanContext().SpanID)
+}
+
+func (s *tracerSuite) TestStartRemoteParent(c *gc.C) {
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), testSpanContext)
+	_, span := s.tracer.Start(ctx, "op")
+	span.End()
+
+	spans, _ := s.tracer.Drain()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].SpanContext.TraceID, gc.Equals, testSpanContext.TraceID)
+	c.Assert(spans[0].ParentSpanID, gc.Equals, testSpanContext.SpanID)
+}
+
+func (s *tracerSuite) TestStartRemoteParentNotSampled(c *gc.C) {
+	unsampled := testSpanContext
+	unsampled.Sampled = false
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), unsampled)
+	_, span := s.tracer.Start(ctx, "op")
+	c.Assert(span.SpanContext(), gc.Equals, unsampled)
+	span.End()
+
+	spans, _ := s.tracer.Drain()
+	c.Assert(spans, gc.HasLen, 0)
+}
+
+func (s *tracerSuite) TestBufferFull(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		_, span := s.tracer.Start(context.Background(), "op")
+		span.End()
+	}
+	spans, dropped := s.tracer.Drain()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Assert(dropped, gc.Equals, 1)
+}
+
+func (s *tracerSuite) TestDefaultTracer(c *gc.C) {
+	// The default tracer records nothing, but propagates span contexts.
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), testSpanContext)
+	ctx, span := trace.Start(ctx, "op")
+	c.Assert(span.SpanContext(), gc.Equals, testSpanContext)
+	c.Assert(trace.SpanContextFromContext(ctx), gc.Equals, testSpanContext)
+
+	trace.SetDefaultTracer(s.tracer)
+	defer trace.SetDefaultTracer(nil)
+	_, span = trace.Start(context.Background(), "op")
+	span.End()
+	spans, _ := s.tracer.Drain()
+	c.Assert(spans, gc.HasLen, 1)
+}
+
+func (s *tracerSuite) TestSpanFromContextWithoutSpan(c *gc.C) {
+	span := trace.SpanFromContext(context.Background())
+	c.Assert(span.SpanContext().IsValid(), jc.IsFalse)
+	// Safe to use.
+	span.RecordError(errors.New("boom"))
+	span.End()
+}
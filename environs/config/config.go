@@ -24,8 +24,10 @@ import (
 
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/httpfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 	jujuversion "github.com/juju/juju/version"
@@ -180,6 +182,35 @@ const (
 	// LogForwardEnabled determines whether the log forward functionality is enabled.
 	LogForwardEnabled = "logforward-enabled"
 
+	// LogForwardType sets the kind of server logs are forwarded to:
+	// syslog (the default), loki, elasticsearch or json.
+	LogForwardType = "logforward-type"
+
+	// LogFwdURL sets the URL of the Loki, Elasticsearch or JSON
+	// log collector.
+	LogFwdURL = "logforward-url"
+
+	// LogFwdUsername and LogFwdPassword set the basic auth
+	// credentials used with the log collector.
+	LogFwdUsername = "logforward-username"
+	LogFwdPassword = "logforward-password"
+
+	// LogFwdCACert sets the certificate of the CA that signed the log
+	// collector's certificate.
+	LogFwdCACert = "logforward-ca-cert"
+
+	// LogFwdClientCert and LogFwdClientKey set the client certificate
+	// and key presented to the log collector.
+	LogFwdClientCert = "logforward-client-cert"
+	LogFwdClientKey  = "logforward-client-key"
+
+	// LogFwdIndex sets the Elasticsearch index logs are written to.
+	LogFwdIndex = "logforward-index"
+
+	// LogFwdBatchSize sets the maximum number of log records sent to
+	// the log collector in a single request.
+	LogFwdBatchSize = "logforward-batch-size"
+
 	// LogFwdSyslogHost sets the hostname:port of the syslog server.
 	LogFwdSyslogHost = "syslog-host"
 
@@ -265,6 +296,25 @@ const (
 	//  - strict mode ensures that we handle any fallbacks as errors.
 	ModeKey = "mode"
 
+	// SecretBackendKey is the key for the type of backend used to store
+	// the content of charm secrets. By default, secret content is stored
+	// in the controller database.
+	SecretBackendKey = "secret-backend"
+
+	// SecretBackendConfigKey is the key for the YAML configuration of
+	// the secret backends, keyed on backend type.
+	SecretBackendConfigKey = "secret-backend-config"
+
+	// StorageUsageThresholdKey is the key for the percentage of a
+	// filesystem's capacity that may be used before its status is
+	// set to warning. A value of 0 disables the warning.
+	StorageUsageThresholdKey = "storage-usage-warning-threshold"
+
+	// DefaultDenyIngressKey is the key for whether Kubernetes
+	// applications only accept traffic from related applications, the
+	// controller and the CIDRs they are exposed to.
+	DefaultDenyIngressKey = "default-deny-ingress"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -448,6 +498,10 @@ const (
 
 	// DefaultActionResultsSize is the default size of the action results.
 	DefaultActionResultsSize = "5G"
+
+	// DefaultStorageUsageThreshold is the default value for
+	// StorageUsageThreshold.
+	DefaultStorageUsageThreshold = 90
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -642,6 +696,16 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if t := cfg.logForwardType(); t != LogForwardTypeSyslog {
+		lfCfg, _ := cfg.LogFwdHTTP()
+		if lfCfg == nil {
+			lfCfg = &httpfwd.RawConfig{Format: httpfwd.Format(t)}
+		}
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotatef(err, "invalid %s forwarding config", t)
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -753,6 +817,33 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[SecretBackendKey].(string); ok && v != "" {
+		if !secrets.IsBackendType(v) {
+			return errors.NotValidf("%s %q", SecretBackendKey, v)
+		}
+	}
+
+	if raw, ok := cfg.defined[SecretBackendConfigKey].(string); ok && raw != "" {
+		backendConfig, err := ensureStringMaps(raw)
+		if err != nil {
+			return errors.Annotate(err, "secret-backend-config")
+		}
+		for backendType, attrs := range backendConfig {
+			if !secrets.IsBackendType(backendType) {
+				return errors.NotValidf("secret-backend-config backend %q", backendType)
+			}
+			if _, ok := attrs.(map[string]interface{}); !ok {
+				return errors.Errorf("secret-backend-config: %q config must be a map", backendType)
+			}
+		}
+	}
+
+	if v, ok := cfg.defined[StorageUsageThresholdKey].(int); ok {
+		if v < 0 || v > 100 {
+			return errors.NotValidf("%s value %d, must be between 0 and 100", StorageUsageThresholdKey, v)
+		}
+	}
+
 	if raw, ok := cfg.defined[ContainerInheritPropertiesKey].(string); ok && raw != "" {
 		rawProperties := strings.Split(raw, ",")
 		propertySet := set.NewStrings()
@@ -1125,8 +1216,23 @@ func (c *Config) SnapStoreProxyURL() string {
 	return c.asString(SnapStoreProxyURLKey)
 }
 
-// LogFwdSyslog returns the syslog forwarding config.
+// LogForwardTypeSyslog is the default value of LogForwardType.
+const LogForwardTypeSyslog = "syslog"
+
+func (c *Config) logForwardType() string {
+	if s, _ := c.defined[LogForwardType].(string); s != "" {
+		return s
+	}
+	return LogForwardTypeSyslog
+}
+
+// LogFwdSyslog returns the syslog forwarding config. It is only
+// returned if logs are forwarded to syslog rather than one of the
+// HTTP log collectors.
 func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
+	if c.logForwardType() != LogForwardTypeSyslog {
+		return nil, false
+	}
 	partial := false
 	var lfCfg syslog.RawConfig
 
@@ -1161,6 +1267,29 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdHTTP returns the config for forwarding logs to a Loki,
+// Elasticsearch or JSON log collector. It is only returned if
+// logforward-type names one of those collectors.
+func (c *Config) LogFwdHTTP() (*httpfwd.RawConfig, bool) {
+	logForwardType := c.logForwardType()
+	if logForwardType == LogForwardTypeSyslog {
+		return nil, false
+	}
+	lfCfg := httpfwd.RawConfig{
+		Format:     httpfwd.Format(logForwardType),
+		URL:        c.asString(LogFwdURL),
+		Username:   c.asString(LogFwdUsername),
+		Password:   c.asString(LogFwdPassword),
+		CACert:     c.asString(LogFwdCACert),
+		ClientCert: c.asString(LogFwdClientCert),
+		ClientKey:  c.asString(LogFwdClientKey),
+		Index:      c.asString(LogFwdIndex),
+	}
+	lfCfg.Enabled, _ = c.defined[LogForwardEnabled].(bool)
+	lfCfg.BatchSize, _ = c.defined[LogFwdBatchSize].(int)
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1524,6 +1653,46 @@ func (c *Config) ContainerInheritProperties() string {
 	return c.asString(ContainerInheritPropertiesKey)
 }
 
+// SecretBackend returns the type of backend used to store secret
+// content, or "" if content is stored in the controller database.
+func (c *Config) SecretBackend() string {
+	return c.asString(SecretBackendKey)
+}
+
+// SecretBackendConfig returns the configuration of the secret
+// backends, keyed on backend type.
+func (c *Config) SecretBackendConfig() map[string]map[string]interface{} {
+	raw := c.asString(SecretBackendConfigKey)
+	if raw == "" {
+		return nil
+	}
+	// The raw data has already passed Validate()
+	backendConfig, _ := ensureStringMaps(raw)
+	result := make(map[string]map[string]interface{}, len(backendConfig))
+	for backendType, attrs := range backendConfig {
+		result[backendType], _ = attrs.(map[string]interface{})
+	}
+	return result
+}
+
+// StorageUsageThreshold returns the percentage of a filesystem's
+// capacity that may be used before its status is set to warning,
+// or 0 if the warning is disabled.
+func (c *Config) StorageUsageThreshold() int {
+	if value, ok := c.defined[StorageUsageThresholdKey].(int); ok {
+		return value
+	}
+	return DefaultStorageUsageThreshold
+}
+
+// DefaultDenyIngress reports whether Kubernetes applications deny
+// ingress traffic that is not allowed by their relations or expose
+// settings.
+func (c *Config) DefaultDenyIngress() bool {
+	value, _ := c.defined[DefaultDenyIngressKey].(bool)
+	return value
+}
+
 // LXDSnapChannel returns the channel to be used when installing LXD from a snap.
 func (c *Config) LXDSnapChannel() string {
 	return c.asString(LXDSnapChannel)
@@ -1599,6 +1768,15 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogForwardType:         schema.Omit,
+	LogFwdURL:              schema.Omit,
+	LogFwdUsername:         schema.Omit,
+	LogFwdPassword:         schema.Omit,
+	LogFwdCACert:           schema.Omit,
+	LogFwdClientCert:       schema.Omit,
+	LogFwdClientKey:        schema.Omit,
+	LogFwdIndex:            schema.Omit,
+	LogFwdBatchSize:        schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1646,6 +1824,10 @@ var alwaysOptional = schema.Defaults{
 	AutomaticallyRetryHooks:       schema.Omit,
 	TestModeKey:                   schema.Omit,
 	ModeKey:                       schema.Omit,
+	SecretBackendKey:              schema.Omit,
+	SecretBackendConfigKey:        schema.Omit,
+	StorageUsageThresholdKey:      schema.Omit,
+	DefaultDenyIngressKey:         schema.Omit,
 	TransmitVendorMetricsKey:      schema.Omit,
 	NetBondReconfigureDelayKey:    schema.Omit,
 	ContainerNetworkingMethod:     schema.Omit,
@@ -2041,7 +2223,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
@@ -2065,6 +2247,52 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogForwardType: {
+		Description: `The kind of server logs are forwarded to: syslog, loki, elasticsearch or json (default syslog).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{LogForwardTypeSyslog, string(httpfwd.FormatLoki), string(httpfwd.FormatElasticsearch), string(httpfwd.FormatJSON)},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdURL: {
+		Description: `The URL of the Loki or Elasticsearch server, or the URL JSON log records are posted to.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdUsername: {
+		Description: `The username used to authenticate with the log collector.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdPassword: {
+		Description: `The password used to authenticate with the log collector.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdCACert: {
+		Description: `The certificate of the CA that signed the log collector's certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdClientCert: {
+		Description: `The client certificate presented to the log collector, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdClientKey: {
+		Description: `The client key for the log collector, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIndex: {
+		Description: `The Elasticsearch index logs are written to (default juju-logs).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdBatchSize: {
+		Description: `The maximum number of log records sent to the log collector in a single request (default 500).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -2095,6 +2323,23 @@ where possible. (default "")`,
 		Type:  environschema.Tlist,
 		Group: environschema.EnvironGroup,
 	},
+	SecretBackendKey: {
+		Description: `The type of backend used to store the content of charm
+secrets, eg vault or kubernetes. By default, secret content is stored
+in the controller database. (default "")`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	SecretBackendConfigKey: {
+		Description: "Configuration (in yaml format) of the secret backends, keyed on backend type",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	StorageUsageThresholdKey: {
+		Description: "The percentage of a filesystem's capacity that may be used before its status is set to warning, or 0 to disable (default 90)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	TypeKey: {
 		Description: "Type of model, e.g. local, ec2",
 		Type:        environschema.Tstring,
@@ -2113,6 +2358,11 @@ where possible. (default "")`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	DefaultDenyIngressKey: {
+		Description: "Whether Kubernetes applications only accept traffic from related applications, the controller and the CIDRs they are exposed to (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	TransmitVendorMetricsKey: {
 		Description: "Determines whether metrics declared by charms deployed into this model are sent for anonymized aggregate analytics",
 		Type:        environschema.Tbool,
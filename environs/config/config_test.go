@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/httpfwd"
 	"github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -419,6 +420,41 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"mode": []interface{}{"strict"},
 		}),
+	}, {
+		about:       "Valid secret-backend-config",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend":        "vault",
+			"secret-backend-config": "vault:\n  endpoint: http://vault:8200\n  token: foo\n",
+		}),
+	}, {
+		about:       "Invalid secret-backend-config YAML",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend-config": "vault: [",
+		}),
+		err: `secret-backend-config: must be valid YAML: .*`,
+	}, {
+		about:       "Invalid secret-backend-config backend",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend-config": "vault: foo\n",
+		}),
+		err: `secret-backend-config: "vault" config must be a map`,
+	}, {
+		about:       "Unknown secret-backend",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend": "bogus",
+		}),
+		err: `secret-backend "bogus" not valid`,
+	}, {
+		about:       "Unknown secret-backend-config backend",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend-config": "bogus:\n  token: foo\n",
+		}),
+		err: `secret-backend-config backend "bogus" not valid`,
 	}, {
 		about:       "valid uuid",
 		useDefaults: config.UseDefaults,
@@ -534,6 +570,39 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "Invalid logforward-type",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-type": "splunk",
+		}),
+		err: `logforward-type: expected one of \[syslog loki elasticsearch json\], got "splunk"`,
+	}, {
+		about:       "Loki forwarding without URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-type":    "loki",
+		}),
+		err: `invalid loki forwarding config: URL "" not valid`,
+	}, {
+		about:       "Elasticsearch forwarding with username but no password",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-type":     "elasticsearch",
+			"logforward-url":      "https://elastic:9200",
+			"logforward-username": "juju",
+		}),
+		err: `invalid elasticsearch forwarding config: Username without Password not valid`,
+	}, {
+		about:       "Invalid log collector CA cert",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-type":    "json",
+			"logforward-url":     "https://collector",
+			"logforward-ca-cert": invalidCACert,
+		}),
+		err: `invalid json forwarding config: validating TLS config: parsing CA certificate: .*`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -1129,6 +1198,51 @@ func newTestConfig(c *gc.C, explicit testing.Attrs) *config.Config {
 	return result
 }
 
+func (s *ConfigSuite) TestLogFwdHTTP(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":    true,
+		"logforward-type":       "elasticsearch",
+		"logforward-url":        "https://elastic:9200",
+		"logforward-username":   "juju",
+		"logforward-password":   "secret",
+		"logforward-ca-cert":    testing.CACert,
+		"logforward-index":      "model-logs",
+		"logforward-batch-size": 100,
+		"syslog-host":           "localhost:1234",
+	})
+
+	_, ok := cfg.LogFwdSyslog()
+	c.Check(ok, jc.IsFalse)
+	lfCfg, ok := cfg.LogFwdHTTP()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg, jc.DeepEquals, &httpfwd.RawConfig{
+		Enabled:   true,
+		Format:    httpfwd.FormatElasticsearch,
+		URL:       "https://elastic:9200",
+		Username:  "juju",
+		Password:  "secret",
+		CACert:    testing.CACert,
+		Index:     "model-logs",
+		BatchSize: 100,
+	})
+}
+
+func (s *ConfigSuite) TestLogFwdHTTPDefaultsToSyslog(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "localhost:1234",
+		"syslog-ca-cert":     testing.CACert,
+		"syslog-client-cert": testing.ServerCert,
+		"syslog-client-key":  testing.ServerKey,
+	})
+
+	_, ok := cfg.LogFwdHTTP()
+	c.Check(ok, jc.IsFalse)
+	lfCfg, ok := cfg.LogFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg.Host, gc.Equals, "localhost:1234")
+}
+
 func (s *ConfigSuite) TestLoggingConfig(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{
@@ -1509,6 +1623,48 @@ func (s *ConfigSuite) TestCoerceForStorage(c *gc.C) {
 	c.Assert(tagsMap, gc.DeepEquals, expectedTags)
 }
 
+func (s *ConfigSuite) TestSecretBackendConfig(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"secret-backend":        "vault",
+		"secret-backend-config": "vault:\n  endpoint: http://vault:8200\n  token: foo\n",
+	})
+	c.Assert(config.SecretBackend(), gc.Equals, "vault")
+	c.Assert(config.SecretBackendConfig(), jc.DeepEquals, map[string]map[string]interface{}{
+		"vault": {"endpoint": "http://vault:8200", "token": "foo"},
+	})
+}
+
+func (s *ConfigSuite) TestStorageUsageThreshold(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.StorageUsageThreshold(), gc.Equals, 90)
+
+	config = newTestConfig(c, testing.Attrs{
+		"storage-usage-warning-threshold": 75,
+	})
+	c.Assert(config.StorageUsageThreshold(), gc.Equals, 75)
+}
+
+func (s *ConfigSuite) TestStorageUsageThresholdInvalid(c *gc.C) {
+	s.addJujuFiles(c)
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"storage-usage-warning-threshold": 101,
+	}))
+	c.Assert(err, gc.ErrorMatches, `storage-usage-warning-threshold value 101, must be between 0 and 100 not valid`)
+}
+
+func (s *ConfigSuite) TestDefaultDenyIngress(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.DefaultDenyIngress(), jc.IsFalse)
+
+	config = newTestConfig(c, testing.Attrs{
+		"default-deny-ingress": true,
+	})
+	c.Assert(config.DefaultDenyIngress(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestLXDSnapChannelConfig(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{
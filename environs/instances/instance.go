@@ -41,3 +41,16 @@ type InstanceFirewaller interface {
 	// address rules for that port range.
 	IngressRules(ctx context.ProviderCallContext, machineId string) (firewall.IngressRules, error)
 }
+
+// ZonedInstance is implemented by instances that can report the
+// availability zone they are currently running in. Instances of
+// providers that can move them between zones, such as a clustered
+// LXD, implement this so that the zone recorded in Juju can be
+// kept up to date.
+type ZonedInstance interface {
+	Instance
+
+	// AvailabilityZone returns the name of the availability
+	// zone that the instance is running in.
+	AvailabilityZone() string
+}
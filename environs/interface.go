@@ -618,3 +618,44 @@ type HardwareCharacteristicsDetector interface {
 	// controller instance.
 	DetectHardware() (*instance.HardwareCharacteristics, error)
 }
+
+// ClusterEvacuator is implemented by environs whose availability zones
+// are the members of a cluster that can move instances between its
+// members, such as a clustered LXD.
+type ClusterEvacuator interface {
+	// EvacuateClusterMember moves the model's instances off the named
+	// cluster member. Instances are moved to the target member if one
+	// is specified, and otherwise spread over the remaining members.
+	// A failure to move one instance does not prevent the others from
+	// being moved; the result for each instance is reported in the
+	// returned InstanceMoves.
+	EvacuateClusterMember(ctx context.ProviderCallContext, member, target string) ([]InstanceMove, error)
+}
+
+// InstanceMigrator is implemented by environs that can move a running
+// instance to another availability zone without reprovisioning it, such
+// as LXD clusters and vSphere with vMotion.
+type InstanceMigrator interface {
+	// MigrateInstance moves the instance with the given ID to the named
+	// availability zone. The instance is moved live where the provider
+	// supports it. The returned InstanceMove describes where the instance
+	// now runs, and the ID by which it is now known.
+	MigrateInstance(ctx context.ProviderCallContext, id instance.Id, zone string) (InstanceMove, error)
+}
+
+// InstanceMove describes the outcome of moving an instance from one
+// availability zone to another.
+type InstanceMove struct {
+	// Id is the ID of the instance that was moved.
+	Id instance.Id
+
+	// Zone is the availability zone that the instance was moved to.
+	Zone string
+
+	// Live is true if the instance was moved without being stopped.
+	Live bool
+
+	// Err is set if the instance could not be moved, when several
+	// instances are moved at once.
+	Err error
+}
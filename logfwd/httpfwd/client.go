@@ -0,0 +1,209 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpfwd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+var logger = loggo.GetLogger("juju.logfwd.httpfwd")
+
+const (
+	// requestTimeout is the time allowed for the
+	// collector to accept each batch of records.
+	requestTimeout = 30 * time.Second
+
+	// maxSendAttempts is the number of times the records of a batch
+	// which the collector fails to accept are sent, before giving up.
+	maxSendAttempts = 3
+)
+
+// retryDelay is the time waited before the first resend of the
+// records of a batch which weren't accepted; it doubles after
+// each further attempt.
+var retryDelay = time.Second
+
+// Doer sends HTTP requests.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// encoder converts records to the requests understood by
+// a particular kind of collector.
+type encoder interface {
+	// url returns the URL batches of records are POSTed to.
+	url(cfg RawConfig) string
+
+	// contentType returns the content type of an encoded batch.
+	contentType() string
+
+	// encode returns the request body for the batch of records.
+	encode(records []logfwd.Record) ([]byte, error)
+
+	// checkResponse examines the body of a successful response to
+	// the batch of records. It returns the records which weren't
+	// accepted but may be on a later attempt, and the number which
+	// were rejected outright and should not be sent again.
+	checkResponse(body []byte, records []logfwd.Record) (retry []logfwd.Record, rejected int, err error)
+}
+
+// Client is the wrapper around a connection to an HTTP log collector.
+type Client struct {
+	cfg     RawConfig
+	doer    Doer
+	encoder encoder
+
+	// dropped is accessed atomically.
+	dropped int64
+}
+
+// Open returns a client which sends records to the collector
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	client, err := OpenForDoer(cfg, &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	})
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a client which uses the supplied Doer to send
+// records to the collector described by the config.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var enc encoder
+	switch cfg.Format {
+	case FormatLoki:
+		enc = lokiEncoder{}
+	case FormatElasticsearch:
+		enc = elasticsearchEncoder{}
+	case FormatJSON:
+		enc = jsonEncoder{}
+	}
+	return &Client{
+		cfg:     cfg,
+		doer:    doer,
+		encoder: enc,
+	}, nil
+}
+
+// Close releases the client's idle connections.
+func (client *Client) Close() error {
+	if c, ok := client.doer.(*http.Client); ok {
+		c.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Dropped returns the number of records rejected outright by
+// the collector, which have been dropped rather than resent.
+func (client *Client) Dropped() int64 {
+	return atomic.LoadInt64(&client.dropped)
+}
+
+// Send sends the records to the collector, in batches of
+// no more than the configured batch size.
+func (client *Client) Send(records []logfwd.Record) error {
+	size := client.cfg.batchSize()
+	for len(records) > 0 {
+		n := size
+		if n > len(records) {
+			n = len(records)
+		}
+		if err := client.send(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+// send sends the batch of records to the collector. Records which
+// the collector fails to accept are resent, on their own, a limited
+// number of times; records it rejects are dropped.
+func (client *Client) send(records []logfwd.Record) error {
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := client.post(records)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(retry) == 0 {
+			return nil
+		}
+		if attempt == maxSendAttempts {
+			return errors.Errorf("%d log records not accepted after %d attempts", len(retry), attempt)
+		}
+		records = retry
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post sends a single request holding the records, returning
+// those which should be sent again.
+func (client *Client) post(records []logfwd.Record) ([]logfwd.Record, error) {
+	body, err := client.encoder.encode(records)
+	if err != nil {
+		return nil, errors.Annotate(err, "encoding log records")
+	}
+	req, err := http.NewRequest(http.MethodPost, client.encoder.url(client.cfg), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", client.encoder.contentType())
+	if client.cfg.Username != "" {
+		req.SetBasicAuth(client.cfg.Username, client.cfg.Password)
+	}
+	resp, err := client.doer.Do(req)
+	if err != nil {
+		return nil, errors.Annotate(err, "sending log records")
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(respBody))
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		return nil, errors.Errorf("sending log records: %s: %s", resp.Status, msg)
+	}
+	retry, rejected, err := client.encoder.checkResponse(respBody, records)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	atomic.AddInt64(&client.dropped, int64(rejected))
+	return retry, nil
+}
+
+// joinURL returns base with the path appended.
+func joinURL(base, path string) string {
+	return strings.TrimSuffix(base, "/") + path
+}
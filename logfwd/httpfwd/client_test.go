@@ -0,0 +1,378 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpfwd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpfwd"
+)
+
+const (
+	controllerUUID = "9f484882-2f18-4fd2-967d-db9663db7bea"
+	modelUUID      = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	doer *stubDoer
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.doer = &stubDoer{stub: s.stub}
+}
+
+func (s *ClientSuite) open(c *gc.C, cfg httpfwd.RawConfig) *httpfwd.Client {
+	cfg.Enabled = true
+	client, err := httpfwd.OpenForDoer(cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func machineRecord(id int64, level loggo.Level, msg string) logfwd.Record {
+	tag := names.NewMachineTag("99")
+	return logfwd.Record{
+		Origin:    logfwd.OriginForMachineAgent(tag, controllerUUID, modelUUID, version.MustParse("1.2.3")),
+		ID:        id,
+		Timestamp: time.Unix(12345, 0),
+		Level:     level,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: msg,
+	}
+}
+
+func unitRecord(id int64, msg string) logfwd.Record {
+	tag := names.NewUnitTag("mysql/1")
+	return logfwd.Record{
+		Origin:    logfwd.OriginForUnitAgent(tag, controllerUUID, modelUUID, version.MustParse("1.2.3")),
+		ID:        id,
+		Timestamp: time.Unix(12346, 0),
+		Level:     loggo.INFO,
+		Message:   msg,
+	}
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpfwd.OpenForDoer(httpfwd.RawConfig{Format: "splunk"}, s.doer)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ClientSuite) TestLabels(c *gc.C) {
+	labels := httpfwd.Labels(unitRecord(1, "hello"))
+	c.Check(labels, jc.DeepEquals, map[string]string{
+		"controller_uuid":  controllerUUID,
+		"model_uuid":       modelUUID,
+		"host":             "unit-mysql-1." + modelUUID,
+		"unit":             "mysql/1",
+		"application":      "mysql",
+		"software":         "jujud-unit-agent",
+		"software_version": "1.2.3",
+	})
+}
+
+func (s *ClientSuite) TestSendLoki(c *gc.C) {
+	client := s.open(c, httpfwd.RawConfig{
+		Format:   httpfwd.FormatLoki,
+		URL:      "http://loki:3100/",
+		Username: "juju",
+		Password: "secret",
+	})
+
+	err := client.Send([]logfwd.Record{
+		machineRecord(1, loggo.ERROR, "one"),
+		unitRecord(2, "two"),
+		machineRecord(3, loggo.ERROR, "three"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do")
+	req := s.doer.requests[0]
+	c.Check(req.url, gc.Equals, "http://loki:3100/loki/api/v1/push")
+	c.Check(req.contentType, gc.Equals, "application/json")
+	c.Check(req.username, gc.Equals, "juju")
+	c.Check(req.password, gc.Equals, "secret")
+
+	var push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	err = json.Unmarshal(req.body, &push)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(push.Streams, gc.HasLen, 2)
+	c.Check(push.Streams[0].Stream, jc.DeepEquals, map[string]string{
+		"controller_uuid":  controllerUUID,
+		"model_uuid":       modelUUID,
+		"host":             "machine-99." + modelUUID,
+		"machine":          "99",
+		"software":         "jujud-machine-agent",
+		"software_version": "1.2.3",
+		"level":            "error",
+	})
+	c.Check(push.Streams[0].Values, jc.DeepEquals, [][2]string{
+		{"12345000000000", `{"record-id":1,"module":"juju.x.y","source":"x/y/spam.go:42","message":"one"}`},
+		{"12345000000000", `{"record-id":3,"module":"juju.x.y","source":"x/y/spam.go:42","message":"three"}`},
+	})
+	c.Check(push.Streams[1].Stream["unit"], gc.Equals, "mysql/1")
+	c.Check(push.Streams[1].Values, jc.DeepEquals, [][2]string{
+		{"12346000000000", `{"record-id":2,"message":"two"}`},
+	})
+}
+
+func (s *ClientSuite) TestSendElasticsearch(c *gc.C) {
+	s.doer.responses = []string{`{"errors":false,"items":[]}`}
+	client := s.open(c, httpfwd.RawConfig{
+		Format: httpfwd.FormatElasticsearch,
+		URL:    "https://elastic:9200",
+	})
+
+	err := client.Send([]logfwd.Record{machineRecord(1, loggo.WARNING, "one")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := s.doer.requests[0]
+	c.Check(req.url, gc.Equals, "https://elastic:9200/juju-logs/_bulk")
+	c.Check(req.contentType, gc.Equals, "application/x-ndjson")
+	c.Check(req.username, gc.Equals, "")
+	lines := strings.Split(strings.TrimSuffix(string(req.body), "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	c.Check(lines[0], gc.Equals, `{"index":{}}`)
+	var doc map[string]interface{}
+	err = json.Unmarshal([]byte(lines[1]), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc["@timestamp"], gc.Equals, "1970-01-01T03:25:45Z")
+	c.Check(doc["level"], gc.Equals, "WARNING")
+	c.Check(doc["message"], gc.Equals, "one")
+	c.Check(doc["source"], gc.Equals, "x/y/spam.go:42")
+	c.Check(doc["labels"].(map[string]interface{})["machine"], gc.Equals, "99")
+}
+
+func (s *ClientSuite) TestSendElasticsearchRejected(c *gc.C) {
+	s.doer.responses = []string{`{"errors":true,"items":[
+		{"index":{"status":201}},
+		{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+	]}`}
+	client := s.open(c, httpfwd.RawConfig{
+		Format: httpfwd.FormatElasticsearch,
+		URL:    "https://elastic:9200",
+		Index:  "logs",
+	})
+
+	// The rejected record is dropped rather than resent.
+	err := client.Send([]logfwd.Record{
+		machineRecord(1, loggo.INFO, "one"),
+		machineRecord(2, loggo.INFO, "two"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Do")
+	c.Check(s.doer.requests[0].url, gc.Equals, "https://elastic:9200/logs/_bulk")
+	c.Check(client.Dropped(), gc.Equals, int64(1))
+}
+
+func (s *ClientSuite) TestSendElasticsearchRetriesFailedItems(c *gc.C) {
+	s.PatchValue(httpfwd.RetryDelay, time.Duration(0))
+	s.doer.responses = []string{`{"errors":true,"items":[
+		{"index":{"status":201}},
+		{"index":{"status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}}
+	]}`, `{"errors":false,"items":[{"index":{"status":201}}]}`}
+	client := s.open(c, httpfwd.RawConfig{
+		Format: httpfwd.FormatElasticsearch,
+		URL:    "https://elastic:9200",
+	})
+
+	err := client.Send([]logfwd.Record{
+		machineRecord(1, loggo.INFO, "one"),
+		machineRecord(2, loggo.INFO, "two"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the record which failed is sent again.
+	s.stub.CheckCallNames(c, "Do", "Do")
+	lines := strings.Split(strings.TrimSuffix(string(s.doer.requests[1].body), "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	var doc map[string]interface{}
+	err = json.Unmarshal([]byte(lines[1]), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc["message"], gc.Equals, "two")
+	c.Check(client.Dropped(), gc.Equals, int64(0))
+}
+
+func (s *ClientSuite) TestSendElasticsearchGivesUpRetrying(c *gc.C) {
+	s.PatchValue(httpfwd.RetryDelay, time.Duration(0))
+	failed := `{"errors":true,"items":[
+		{"index":{"status":503,"error":{"type":"unavailable_shards_exception","reason":"primary shard is not active"}}}
+	]}`
+	s.doer.responses = []string{failed, failed, failed}
+	client := s.open(c, httpfwd.RawConfig{
+		Format: httpfwd.FormatElasticsearch,
+		URL:    "https://elastic:9200",
+	})
+
+	err := client.Send([]logfwd.Record{machineRecord(1, loggo.INFO, "one")})
+	c.Check(err, gc.ErrorMatches, `1 log records not accepted after 3 attempts`)
+	s.stub.CheckCallNames(c, "Do", "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendElasticsearchLargeResponse(c *gc.C) {
+	// Responses to large batches are parsed in full.
+	items := make([]string, 20000)
+	records := make([]logfwd.Record, len(items))
+	for i := range items {
+		items[i] = `{"index":{"_index":"juju-logs","_id":"a-document-id-long-enough-to-pad-the-response","status":201}}`
+		records[i] = machineRecord(int64(i), loggo.INFO, "msg")
+	}
+	items[len(items)-1] = `{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}`
+	s.doer.responses = []string{`{"errors":true,"items":[` + strings.Join(items, ",") + `]}`}
+	client := s.open(c, httpfwd.RawConfig{
+		Format:    httpfwd.FormatElasticsearch,
+		URL:       "https://elastic:9200",
+		BatchSize: len(records),
+	})
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.Dropped(), gc.Equals, int64(1))
+}
+
+func (s *ClientSuite) TestSendJSON(c *gc.C) {
+	client := s.open(c, httpfwd.RawConfig{
+		Format: httpfwd.FormatJSON,
+		URL:    "http://collector/ingest?token=abc",
+	})
+
+	err := client.Send([]logfwd.Record{
+		machineRecord(1, loggo.INFO, "one"),
+		unitRecord(2, "two"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := s.doer.requests[0]
+	c.Check(req.url, gc.Equals, "http://collector/ingest?token=abc")
+	c.Check(req.contentType, gc.Equals, "application/x-ndjson")
+	lines := strings.Split(strings.TrimSuffix(string(req.body), "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	var doc map[string]interface{}
+	err = json.Unmarshal([]byte(lines[1]), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc["record-id"], gc.Equals, float64(2))
+	c.Check(doc["message"], gc.Equals, "two")
+	c.Check(doc["labels"].(map[string]interface{})["application"], gc.Equals, "mysql")
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, httpfwd.RawConfig{
+		Format:    httpfwd.FormatJSON,
+		URL:       "http://collector",
+		BatchSize: 2,
+	})
+	var records []logfwd.Record
+	for i := int64(1); i <= 5; i++ {
+		records = append(records, machineRecord(i, loggo.INFO, "msg"))
+	}
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do", "Do", "Do")
+	for i, n := range []int{2, 2, 1} {
+		c.Check(bytes.Count(s.doer.requests[i].body, []byte("\n")), gc.Equals, n)
+	}
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	s.doer.status = http.StatusBadRequest
+	s.doer.responses = []string{"entry out of order\n"}
+	client := s.open(c, httpfwd.RawConfig{
+		Format: httpfwd.FormatLoki,
+		URL:    "http://loki:3100",
+	})
+
+	err := client.Send([]logfwd.Record{machineRecord(1, loggo.INFO, "one")})
+	c.Check(err, gc.ErrorMatches, `sending log records: 400 Bad Request: entry out of order`)
+}
+
+func (s *ClientSuite) TestSendRequestError(c *gc.C) {
+	s.stub.SetErrors(errors.New("connection refused"))
+	client := s.open(c, httpfwd.RawConfig{
+		Format: httpfwd.FormatLoki,
+		URL:    "http://loki:3100",
+	})
+
+	err := client.Send([]logfwd.Record{machineRecord(1, loggo.INFO, "one")})
+	c.Check(err, gc.ErrorMatches, `sending log records: connection refused`)
+}
+
+type request struct {
+	url         string
+	contentType string
+	username    string
+	password    string
+	body        []byte
+}
+
+type stubDoer struct {
+	stub *testing.Stub
+
+	status    int
+	responses []string
+	requests  []request
+}
+
+func (s *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	s.stub.AddCall("Do", req.URL.String())
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	username, password, _ := req.BasicAuth()
+	s.requests = append(s.requests, request{
+		url:         req.URL.String(),
+		contentType: req.Header.Get("Content-Type"),
+		username:    username,
+		password:    password,
+		body:        body,
+	})
+	status := s.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	var respBody string
+	if len(s.responses) > 0 {
+		respBody, s.responses = s.responses[0], s.responses[1:]
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Body:       ioutil.NopCloser(strings.NewReader(respBody)),
+	}, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpfwd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/cert"
+)
+
+// Format identifies the API understood by a log collector.
+type Format string
+
+// These are the supported log collector formats.
+const (
+	// FormatLoki is the Loki push API. Records are grouped into
+	// streams by the labels derived from their origin.
+	FormatLoki Format = "loki"
+
+	// FormatElasticsearch is the Elasticsearch (or OpenSearch)
+	// bulk API.
+	FormatElasticsearch Format = "elasticsearch"
+
+	// FormatJSON is a plain HTTP endpoint accepting one JSON
+	// document per line.
+	FormatJSON Format = "json"
+)
+
+const (
+	// DefaultBatchSize is the maximum number of records sent
+	// in a single request if no batch size is configured.
+	DefaultBatchSize = 500
+
+	// DefaultIndex is the Elasticsearch index records are
+	// written to if no index is configured.
+	DefaultIndex = "juju-logs"
+)
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP log collector.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Format is the API the collector understands.
+	Format Format
+
+	// URL is the base URL of the Loki or Elasticsearch server, or
+	// the URL records are POSTed to for the JSON format.
+	URL string
+
+	// Index is the Elasticsearch index records are written to.
+	Index string
+
+	// Username and Password are the credentials used to
+	// authenticate with the collector using basic auth.
+	Username string
+	Password string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate. If not set, the
+	// system's root CAs are used.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to
+	// present to the server, if it requires one.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) of
+	// the client certificate.
+	ClientKey string
+
+	// BatchSize is the maximum number of records sent in a
+	// single request.
+	BatchSize int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	switch cfg.Format {
+	case FormatLoki, FormatElasticsearch, FormatJSON:
+	default:
+		return errors.NotValidf("Format %q", cfg.Format)
+	}
+	if cfg.Enabled || cfg.URL != "" {
+		u, err := url.Parse(cfg.URL)
+		if err != nil {
+			return errors.Annotate(err, "parsing URL")
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NotValidf("URL %q", cfg.URL)
+		}
+	}
+	if (cfg.Username == "") != (cfg.Password == "") {
+		return errors.NotValidf("Username without Password")
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize > 0 {
+		return cfg.BatchSize
+	}
+	return DefaultBatchSize
+}
+
+func (cfg RawConfig) index() string {
+	if cfg.Index != "" {
+		return cfg.Index
+	}
+	return DefaultIndex
+}
+
+// tlsConfig returns the TLS configuration used to connect to the
+// collector, or nil if the defaults should be used.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" && cfg.ClientCert == "" && cfg.ClientKey == "" {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{}
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		tlsCfg.RootCAs.AddCert(caCert)
+	}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsCfg, nil
+}
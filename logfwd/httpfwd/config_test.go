@@ -0,0 +1,141 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Enabled:    true,
+		Format:     httpfwd.FormatElasticsearch,
+		URL:        "https://elastic.example.com:9200",
+		Index:      "logs",
+		Username:   "juju",
+		Password:   "secret",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+		BatchSize:  100,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMinimal(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Enabled: true,
+		Format:  httpfwd.FormatLoki,
+		URL:     "http://loki:3100",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateBadFormat(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Format: "splunk",
+		URL:    "http://splunk:8088",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `Format "splunk" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Enabled: true,
+		Format:  httpfwd.FormatJSON,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadURLScheme(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Enabled: true,
+		Format:  httpfwd.FormatJSON,
+		URL:     "ftp://logs.example.com",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "ftp://logs.example.com" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateUsernameWithoutPassword(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Format:   httpfwd.FormatLoki,
+		URL:      "http://loki:3100",
+		Username: "juju",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Username without Password not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeBatchSize(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Format:    httpfwd.FormatLoki,
+		URL:       "http://loki:3100",
+		BatchSize: -1,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `negative BatchSize not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Format: httpfwd.FormatLoki,
+		URL:    "https://loki:3100",
+		CACert: invalidCACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: .*`)
+}
+
+func (s *ConfigSuite) TestRawValidateMismatchedKeyPair(c *gc.C) {
+	cfg := httpfwd.RawConfig{
+		Format:     httpfwd.FormatLoki,
+		URL:        "https://loki:3100",
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.CAKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: .*`)
+}
+
+var invalidCACert = `
+-----BEGIN CERTIFICATE-----
+MIIBOgIBAAJAZabKgKInuOxj5vDWLwHHQtK3/45KB+32D15w94Nt83BmuGxo90lw
+-----END CERTIFICATE-----
+`[1:]
@@ -0,0 +1,7 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpfwd package holds the tools needed to perform log forwarding
+// from Juju to HTTP log collectors: the Loki push API, the
+// Elasticsearch bulk API and generic newline-delimited JSON endpoints.
+package httpfwd
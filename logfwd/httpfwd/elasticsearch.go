@@ -0,0 +1,88 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpfwd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type elasticsearchEncoder struct{}
+
+func (elasticsearchEncoder) url(cfg RawConfig) string {
+	return joinURL(cfg.URL, "/"+cfg.index()+"/_bulk")
+}
+
+func (elasticsearchEncoder) contentType() string {
+	return "application/x-ndjson"
+}
+
+// encode returns a bulk request indexing each record as a document
+// in the index named in the request's URL.
+func (elasticsearchEncoder) encode(records []logfwd.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		buf.WriteString(`{"index":{}}` + "\n")
+		if err := enc.Encode(newDocument(rec)); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// checkResponse returns the records whose bulk items failed with a
+// status suggesting they may succeed later: too many requests, or a
+// server error. Records failing for any other reason, such as a
+// document which can't be parsed, are rejected.
+func (elasticsearchEncoder) checkResponse(body []byte, records []logfwd.Record) ([]logfwd.Record, int, error) {
+	var resp bulkResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, 0, errors.Annotate(err, "parsing bulk response")
+	}
+	if !resp.Errors {
+		return nil, 0, nil
+	}
+	if len(resp.Items) != len(records) {
+		return nil, 0, errors.Errorf("bulk response has %d items for %d log records", len(resp.Items), len(records))
+	}
+	var retry []logfwd.Record
+	rejected := 0
+	var first string
+	for i, item := range resp.Items {
+		for _, result := range item {
+			if result.Error == nil {
+				continue
+			}
+			if result.Status == http.StatusTooManyRequests || result.Status >= 500 {
+				retry = append(retry, records[i])
+				continue
+			}
+			if rejected == 0 {
+				first = result.Error.Type + ": " + result.Error.Reason
+			}
+			rejected++
+		}
+	}
+	if rejected > 0 {
+		logger.Warningf("dropping %d of %d log records rejected by Elasticsearch: %s", rejected, len(records), first)
+	}
+	return retry, rejected, nil
+}
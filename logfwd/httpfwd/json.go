@@ -0,0 +1,39 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpfwd
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) url(cfg RawConfig) string {
+	return cfg.URL
+}
+
+func (jsonEncoder) contentType() string {
+	return "application/x-ndjson"
+}
+
+// encode returns the records as newline-delimited JSON documents.
+func (jsonEncoder) encode(records []logfwd.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		if err := enc.Encode(newDocument(rec)); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (jsonEncoder) checkResponse([]byte, []logfwd.Record) ([]logfwd.Record, int, error) {
+	return nil, 0, nil
+}
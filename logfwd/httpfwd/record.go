@@ -0,0 +1,75 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpfwd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Labels returns the labels identifying where the record originated:
+// the controller, model and host, the machine or unit agent which
+// logged it, and the software version of that agent.
+func Labels(rec logfwd.Record) map[string]string {
+	origin := rec.Origin
+	labels := map[string]string{
+		"controller_uuid": origin.ControllerUUID,
+		"model_uuid":      origin.ModelUUID,
+	}
+	if origin.Hostname != "" {
+		labels["host"] = origin.Hostname
+	}
+	switch origin.Type {
+	case logfwd.OriginTypeMachine:
+		labels["machine"] = origin.Name
+	case logfwd.OriginTypeUnit:
+		labels["unit"] = origin.Name
+		if app, err := names.UnitApplication(origin.Name); err == nil {
+			labels["application"] = app
+		}
+	}
+	if origin.Software.Name != "" {
+		labels["software"] = origin.Software.Name
+		labels["software_version"] = origin.Software.Version.String()
+	}
+	return labels
+}
+
+// document is the JSON representation of a record sent to
+// Elasticsearch and JSON collectors.
+type document struct {
+	Timestamp string            `json:"@timestamp"`
+	ID        int64             `json:"record-id"`
+	Level     string            `json:"level"`
+	Module    string            `json:"module,omitempty"`
+	Source    string            `json:"source,omitempty"`
+	Message   string            `json:"message"`
+	Labels    map[string]string `json:"labels"`
+}
+
+func newDocument(rec logfwd.Record) document {
+	return document{
+		Timestamp: rec.Timestamp.UTC().Format(time.RFC3339Nano),
+		ID:        rec.ID,
+		Level:     rec.Level.String(),
+		Module:    rec.Location.Module,
+		Source:    source(rec.Location),
+		Message:   rec.Message,
+		Labels:    Labels(rec),
+	}
+}
+
+func source(loc logfwd.SourceLocation) string {
+	if loc.Filename == "" {
+		return ""
+	}
+	if loc.Line <= 0 {
+		return loc.Filename
+	}
+	return fmt.Sprintf("%s:%d", loc.Filename, loc.Line)
+}
@@ -5,10 +5,13 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -72,6 +75,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	volumeNotFound     = "InvalidVolume.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 	incorrectState     = "IncorrectState"
 )
 
@@ -285,7 +289,11 @@ type ebsVolumeSource struct {
 	modelUUID string
 }
 
-var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var (
+	_ storage.VolumeSource      = (*ebsVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+	_ storage.VolumeResizer     = (*ebsVolumeSource)(nil)
+)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -387,6 +395,18 @@ func (v *ebsVolumeSource) createVolume(ctx context.ProviderCallContext, p storag
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	if p.SnapshotId != "" {
+		// A volume created from a snapshot must be
+		// at least as large as the snapshot.
+		snapshot, err := describeSnapshot(v.env.ec2, ctx, p.SnapshotId)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if size := int(mibToGib(snapshot.Size)); size > vol.VolumeSize {
+			vol.VolumeSize = size
+		}
+		vol.SnapshotId = p.SnapshotId
+	}
 	resp, err := v.env.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(maybeConvertCredentialError(err, ctx))
@@ -980,6 +1000,139 @@ func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId
 	}, nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createSnapshot(ctx, p)
+		if err != nil {
+			if common.IsCredentialNotValid(err) {
+				return nil, errors.Trace(err)
+			}
+			results[i].Error = err
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createSnapshot(ctx context.ProviderCallContext, p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	name := resourceName(p.Volume, v.envName)
+	resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, "snapshot of "+name)
+	if err != nil {
+		return nil, errors.Trace(maybeConvertCredentialError(err, ctx))
+	}
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = name
+	if err := tagResources(v.env.ec2, ctx, resourceTags, resp.Snapshot.Id); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	snapshot := ebsToVolumeSnapshot(resp.Snapshot)
+	return &snapshot, nil
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volIds []string) ([]storage.VolumeSnapshot, error) {
+	if len(volIds) == 0 {
+		return nil, nil
+	}
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volIds...)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, maybeConvertCredentialError(err, ctx)
+	}
+	snapshots := make([]storage.VolumeSnapshot, len(resp.Snapshots))
+	for i, snapshot := range resp.Snapshots {
+		snapshots[i] = ebsToVolumeSnapshot(snapshot)
+	}
+	return snapshots, nil
+}
+
+// DeleteVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		_, err := v.env.ec2.DeleteSnapshots([]string{snapshotId})
+		if err == nil || ec2ErrCode(err) == snapshotNotFound {
+			continue
+		}
+		err = maybeConvertCredentialError(err, ctx)
+		if common.IsCredentialNotValid(err) {
+			return nil, errors.Trace(err)
+		}
+		results[i] = err
+	}
+	return results, nil
+}
+
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeVolume(ctx, p)
+		if err != nil {
+			if common.IsCredentialNotValid(err) {
+				return nil, errors.Trace(err)
+			}
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", p.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) resizeVolume(ctx context.ProviderCallContext, p storage.VolumeResizeParams) (uint64, error) {
+	// EBS volumes are sized in GiB, so the resulting volume
+	// may be larger than requested.
+	resp, err := v.env.ec2Client.ModifyVolume(&awsec2.ModifyVolumeInput{
+		VolumeId: aws.String(p.VolumeId),
+		Size:     aws.Int64(int64(mibToGib(p.Size))),
+	})
+	if err != nil {
+		return 0, maybeConvertCredentialError(err, ctx)
+	}
+	if resp.VolumeModification == nil || resp.VolumeModification.TargetSize == nil {
+		return 0, errors.Errorf("volume modification for %s did not report a target size", p.VolumeId)
+	}
+	return gibToMib(uint64(*resp.VolumeModification.TargetSize)), nil
+}
+
+func describeSnapshot(client *ec2.EC2, ctx context.ProviderCallContext, snapshotId string) (*storage.VolumeSnapshot, error) {
+	resp, err := client.Snapshots([]string{snapshotId}, nil)
+	if err != nil {
+		if ec2ErrCode(err) == snapshotNotFound {
+			return nil, errors.NotFoundf("snapshot %q", snapshotId)
+		}
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "querying snapshot")
+	}
+	if len(resp.Snapshots) != 1 {
+		return nil, errors.Errorf("expected 1 snapshot result, got %d", len(resp.Snapshots))
+	}
+	snapshot := ebsToVolumeSnapshot(resp.Snapshots[0])
+	return &snapshot, nil
+}
+
+// ebsToVolumeSnapshot converts an EBS snapshot to a storage.VolumeSnapshot.
+// The volume size and start time are reported by EC2 as strings; if they
+// cannot be parsed, they are left zero.
+func ebsToVolumeSnapshot(snapshot ec2.Snapshot) storage.VolumeSnapshot {
+	size, _ := strconv.ParseUint(snapshot.VolumeSize, 10, 64)
+	created, _ := time.Parse(time.RFC3339, snapshot.StartTime)
+	return storage.VolumeSnapshot{
+		SnapshotId: snapshot.Id,
+		VolumeId:   snapshot.VolumeId,
+		Size:       gibToMib(size),
+		Created:    created,
+		Status:     snapshot.Status,
+	}
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
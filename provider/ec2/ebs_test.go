@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"time"
 
+	sdkec2 "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -1075,6 +1077,261 @@ func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot import volume with status "in-use"`)
 }
 
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	var modified []*sdkec2.ModifyVolumeInput
+	s.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2.EC2Client {
+		return &mockEC2Session{
+			modifyVolume: func(input *sdkec2.ModifyVolumeInput) (*sdkec2.ModifyVolumeOutput, error) {
+				modified = append(modified, input)
+				if *input.VolumeId == "vol-1" {
+					return nil, errors.New("boom")
+				}
+				return &sdkec2.ModifyVolumeOutput{
+					VolumeModification: &sdkec2.VolumeModification{
+						VolumeId:   input.VolumeId,
+						TargetSize: input.Size,
+					},
+				}, nil
+			},
+		}
+	})
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeResizer))
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     1500,
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Size, gc.Equals, uint64(2048))
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing volume vol-1: boom")
+
+	c.Assert(modified, gc.HasLen, 2)
+	c.Assert(*modified[0].VolumeId, gc.Equals, "vol-0")
+	c.Assert(*modified[0].Size, gc.Equals, int64(2))
+}
+
+func (s *ebsSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeSnapshotter))
+
+	var tagged []string
+	s.srv.proxy.ModifyResponse = makeSnapshotResponseModifier(func(action string, query url.Values) (int, interface{}) {
+		switch action {
+		case "CreateSnapshot":
+			c.Check(query.Get("VolumeId"), gc.Equals, "vol-0")
+			return http.StatusOK, &awsec2.CreateSnapshotResp{
+				Snapshot: awsec2.Snapshot{
+					Id:         "snap-0",
+					VolumeId:   "vol-0",
+					VolumeSize: "10",
+					Status:     "pending",
+					StartTime:  "2021-01-02T03:04:05.000Z",
+				},
+			}
+		case "CreateTags":
+			tagged = append(tagged, query.Get("ResourceId.1"))
+			return http.StatusOK, &awsec2.SimpleResp{Return: true}
+		}
+		return 0, nil
+	})
+
+	results, err := vs.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.cloudCallCtx, []storage.VolumeSnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		ResourceTags: map[string]string{
+			tags.JujuModel: s.modelConfig.UUID(),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		SnapshotId: "snap-0",
+		VolumeId:   "vol-0",
+		Size:       10 * 1024,
+		Created:    time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		Status:     "pending",
+	})
+	c.Assert(tagged, jc.DeepEquals, []string{"snap-0"})
+}
+
+func (s *ebsSuite) TestCreateVolumeSnapshotsCredentialError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "Blocked",
+		}}})
+	}
+	results, err := vs.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.cloudCallCtx, []storage.VolumeSnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+	}})
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *ebsSuite) TestListVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = makeSnapshotResponseModifier(func(action string, query url.Values) (int, interface{}) {
+		c.Check(action, gc.Equals, "DescribeSnapshots")
+		c.Check(query.Get("Filter.1.Name"), gc.Equals, "volume-id")
+		c.Check(query.Get("Filter.1.Value.1"), gc.Equals, "vol-0")
+		return http.StatusOK, &awsec2.SnapshotsResp{
+			Snapshots: []awsec2.Snapshot{{
+				Id:         "snap-0",
+				VolumeId:   "vol-0",
+				VolumeSize: "10",
+				Status:     "completed",
+				StartTime:  "2021-01-02T03:04:05.000Z",
+			}, {
+				Id:         "snap-1",
+				VolumeId:   "vol-0",
+				VolumeSize: "10",
+				Status:     "pending",
+			}},
+		}
+	})
+
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	snapshots, err := snapshotter.ListVolumeSnapshots(s.cloudCallCtx, []string{"vol-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "snap-0",
+		VolumeId:   "vol-0",
+		Size:       10 * 1024,
+		Created:    time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		Status:     "completed",
+	}, {
+		SnapshotId: "snap-1",
+		VolumeId:   "vol-0",
+		Size:       10 * 1024,
+		Status:     "pending",
+	}})
+
+	snapshots, err = snapshotter.ListVolumeSnapshots(s.cloudCallCtx, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+}
+
+func (s *ebsSuite) TestListVolumeSnapshotsCredentialError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "Blocked",
+		}}})
+	}
+	_, err := vs.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.cloudCallCtx, []string{"vol-0"})
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+}
+
+func (s *ebsSuite) TestDeleteVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = makeSnapshotResponseModifier(func(action string, query url.Values) (int, interface{}) {
+		c.Check(action, gc.Equals, "DeleteSnapshot")
+		switch query.Get("SnapshotId.1") {
+		case "snap-1":
+			return http.StatusBadRequest, ec2Errors{[]awsec2.Error{{
+				Code: "InvalidSnapshot.NotFound",
+			}}}
+		case "snap-2":
+			return http.StatusBadRequest, ec2Errors{[]awsec2.Error{{
+				Code:    "InvalidSnapshot.InUse",
+				Message: "snapshot in use",
+			}}}
+		}
+		return http.StatusOK, &awsec2.SimpleResp{Return: true}
+	})
+
+	results, err := vs.(storage.VolumeSnapshotter).DeleteVolumeSnapshots(s.cloudCallCtx, []string{"snap-0", "snap-1", "snap-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, "snapshot in use.*")
+}
+
+func (s *ebsSuite) TestDeleteVolumeSnapshotsCredentialError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "Blocked",
+		}}})
+	}
+	results, err := vs.(storage.VolumeSnapshotter).DeleteVolumeSnapshots(s.cloudCallCtx, []string{"snap-0"})
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *ebsSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = makeSnapshotResponseModifier(func(action string, query url.Values) (int, interface{}) {
+		if action != "DescribeSnapshots" {
+			return 0, nil
+		}
+		switch query.Get("SnapshotId.1") {
+		case "snap-0":
+			return http.StatusOK, &awsec2.SnapshotsResp{
+				Snapshots: []awsec2.Snapshot{{
+					Id:         "snap-0",
+					VolumeId:   "vol-42",
+					VolumeSize: "20",
+					Status:     "completed",
+				}},
+			}
+		}
+		return http.StatusBadRequest, ec2Errors{[]awsec2.Error{{
+			Code: "InvalidSnapshot.NotFound",
+		}}}
+	})
+
+	instanceId := s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Running, nil)[0]
+	params := []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       10 * 1024,
+		Provider:   ec2.EBS_ProviderType,
+		SnapshotId: "snap-0",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceId),
+			},
+		},
+	}, {
+		Tag:        names.NewVolumeTag("1"),
+		Size:       10 * 1024,
+		Provider:   ec2.EBS_ProviderType,
+		SnapshotId: "snap-1",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceId),
+			},
+		},
+	}}
+	results, err := vs.CreateVolumes(s.cloudCallCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	// The volume is grown to the size of the snapshot.
+	c.Assert(results[0].Volume.Size, gc.Equals, uint64(20*1024))
+	c.Assert(results[1].Error, gc.ErrorMatches, `snapshot "snap-1" not found`)
+
+	volumes, err := s.srv.client.Volumes([]string{results[0].Volume.VolumeId}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes.Volumes, gc.HasLen, 1)
+	c.Assert(volumes.Volumes[0].SnapshotId, gc.Equals, "snap-0")
+	c.Assert(volumes.Volumes[0].Size, gc.Equals, 20)
+}
+
 type blockDeviceMappingSuite struct {
 	testing.BaseSuite
 }
@@ -1219,6 +1476,25 @@ func makeDescribeVolumesResponseModifier(modify func(*awsec2.VolumesResp) error)
 	}
 }
 
+// makeSnapshotResponseModifier returns a response modifier which replaces
+// the ec2test server's responses, which don't support snapshots, with the
+// status and value returned by respond. If respond returns a nil value,
+// the server's response is left alone.
+func makeSnapshotResponseModifier(respond func(action string, query url.Values) (int, interface{})) func(*http.Response) error {
+	return func(resp *http.Response) error {
+		query := resp.Request.URL.Query()
+		status, value := respond(query.Get("Action"), query)
+		if value == nil {
+			return nil
+		}
+		resp.Body.Close()
+		resp.StatusCode = status
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		return replaceResponseBody(resp, value)
+	}
+}
+
 func replaceResponseBody(resp *http.Response, value interface{}) error {
 	var buf bytes.Buffer
 	if err := xml.NewEncoder(&buf).Encode(value); err != nil {
@@ -11,6 +11,7 @@ import (
 
 type mockEC2Session struct {
 	newInstancesClient func() *amzec2.EC2
+	modifyVolume       func(*ec2.ModifyVolumeInput) (*ec2.ModifyVolumeOutput, error)
 }
 
 func (*mockEC2Session) DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
@@ -146,3 +147,15 @@ func (*mockEC2Session) DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInp
 		SpotPriceHistory: nil,
 	}, nil
 }
+
+func (s *mockEC2Session) ModifyVolume(input *ec2.ModifyVolumeInput) (*ec2.ModifyVolumeOutput, error) {
+	if s.modifyVolume != nil {
+		return s.modifyVolume(input)
+	}
+	return &ec2.ModifyVolumeOutput{
+		VolumeModification: &ec2.VolumeModification{
+			VolumeId:   input.VolumeId,
+			TargetSize: input.Size,
+		},
+	}, nil
+}
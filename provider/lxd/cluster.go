@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var (
+	_ environs.ClusterEvacuator = (*environ)(nil)
+	_ environs.InstanceMigrator = (*environ)(nil)
+)
+
+// EvacuateClusterMember (ClusterEvacuator) moves the model's containers off
+// the named LXD cluster member. Running containers are live-migrated where
+// the cluster supports it; otherwise, and for stopped containers, they are
+// stopped, moved and started again on the target member.
+func (env *environ) EvacuateClusterMember(
+	ctx context.ProviderCallContext, member, target string,
+) ([]environs.InstanceMove, error) {
+	server := env.server()
+	if !server.IsClustered() {
+		return nil, errors.NotSupportedf("evacuating a member of an unclustered LXD server")
+	}
+
+	zones, err := env.AvailabilityZones(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var (
+		found      bool
+		candidates []string
+	)
+	for _, zone := range zones {
+		name := zone.Name()
+		if name == member {
+			found = true
+			continue
+		}
+		if zone.Available() && (target == "" || name == target) {
+			candidates = append(candidates, name)
+		}
+	}
+	if !found {
+		return nil, errors.NotFoundf("cluster member %q", member)
+	}
+	if target == member {
+		return nil, errors.NotValidf("evacuating cluster member %q to itself", member)
+	}
+	if len(candidates) == 0 {
+		if target != "" {
+			return nil, errors.Errorf("target cluster member %q is not available", target)
+		}
+		return nil, errors.Errorf("no other cluster members available to evacuate %q to", member)
+	}
+
+	insts, err := env.allInstances()
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Trace(err)
+	}
+
+	// Count the model's containers on each candidate member, so that
+	// evacuated containers can be spread over the least loaded members.
+	load := make(map[string]int)
+	var evacuees []*environInstance
+	for _, inst := range insts {
+		location := inst.container.Location
+		if location == member {
+			evacuees = append(evacuees, inst)
+			continue
+		}
+		load[location]++
+	}
+	sort.Slice(evacuees, func(i, j int) bool {
+		return evacuees[i].container.Name < evacuees[j].container.Name
+	})
+
+	moves := make([]environs.InstanceMove, len(evacuees))
+	for i, inst := range evacuees {
+		to := leastLoadedMember(candidates, load)
+		live, err := env.moveContainer(inst, to)
+		if err != nil {
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			logger.Errorf("moving container %q to cluster member %q: %v", inst.container.Name, to, err)
+		} else {
+			load[to]++
+		}
+		moves[i] = environs.InstanceMove{
+			Id:   inst.Id(),
+			Zone: to,
+			Live: live,
+			Err:  err,
+		}
+	}
+	return moves, nil
+}
+
+// MigrateInstance (InstanceMigrator) moves the container with the input
+// ID to the named LXD cluster member. The container keeps its name, and
+// so its instance ID, on the new member.
+func (env *environ) MigrateInstance(
+	ctx context.ProviderCallContext, id instance.Id, zone string,
+) (environs.InstanceMove, error) {
+	if !env.server().IsClustered() {
+		return environs.InstanceMove{}, errors.NotSupportedf("moving instances on an unclustered LXD server")
+	}
+	zones, err := env.AvailabilityZones(ctx)
+	if err != nil {
+		return environs.InstanceMove{}, errors.Trace(err)
+	}
+	var available bool
+	for _, z := range zones {
+		if z.Name() == zone {
+			if !z.Available() {
+				return environs.InstanceMove{}, errors.Errorf("cluster member %q is not available", zone)
+			}
+			available = true
+			break
+		}
+	}
+	if !available {
+		return environs.InstanceMove{}, errors.NotFoundf("cluster member %q", zone)
+	}
+
+	insts, err := env.allInstances()
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return environs.InstanceMove{}, errors.Trace(err)
+	}
+	var inst *environInstance
+	for _, candidate := range insts {
+		if candidate.Id() == id {
+			inst = candidate
+			break
+		}
+	}
+	if inst == nil {
+		return environs.InstanceMove{}, errors.NotFoundf("instance %q", id)
+	}
+	if inst.container.Location == zone {
+		return environs.InstanceMove{}, errors.AlreadyExistsf("instance %q on cluster member %q", id, zone)
+	}
+
+	live, err := env.moveContainer(inst, zone)
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return environs.InstanceMove{}, errors.Annotatef(err, "moving instance %q to cluster member %q", id, zone)
+	}
+	return environs.InstanceMove{
+		Id:   id,
+		Zone: zone,
+		Live: live,
+	}, nil
+}
+
+// moveContainer moves the input instance to the named cluster member,
+// returning true if it was moved without being stopped. A live migration
+// is attempted first for running containers; LXD refuses these if CRIU
+// is not available, in which case the container is moved cold.
+func (env *environ) moveContainer(inst *environInstance, member string) (bool, error) {
+	name := inst.container.Name
+	if inst.container.StatusCode == api.Running {
+		err := env.server().MoveContainer(name, member, true)
+		if err == nil {
+			return true, nil
+		}
+		logger.Infof("live migration of container %q failed, moving it while stopped: %v", name, err)
+	}
+	return false, errors.Trace(env.server().MoveContainer(name, member, false))
+}
+
+// leastLoadedMember returns the member with the lowest load, preferring
+// members earlier in the input slice when there is a tie.
+func leastLoadedMember(members []string, load map[string]int) string {
+	best := members[0]
+	for _, member := range members[1:] {
+		if load[member] < load[best] {
+			best = member
+		}
+	}
+	return best
+}
@@ -0,0 +1,287 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	containerlxd "github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/lxd"
+)
+
+type clusterSuite struct {
+	lxd.EnvironSuite
+
+	callCtx context.ProviderCallContext
+}
+
+var _ = gc.Suite(&clusterSuite{})
+
+func (s *clusterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *clusterSuite) clusterMembers() []api.ClusterMember {
+	return []api.ClusterMember{
+		{ServerName: "node01", Status: "ONLINE"},
+		{ServerName: "node02", Status: "ONLINE"},
+		{ServerName: "node03", Status: "ONLINE"},
+		{ServerName: "node04", Status: "OFFLINE"},
+	}
+}
+
+func clusterContainer(name, location string, code api.StatusCode) containerlxd.Container {
+	return containerlxd.Container{
+		Container: api.Container{
+			Name:       name,
+			Location:   location,
+			StatusCode: code,
+		},
+	}
+}
+
+func (s *clusterSuite) TestEvacuateClusterMember(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		clusterContainer("juju-0", "node01", api.Running),
+		clusterContainer("juju-1", "node01", api.Stopped),
+		clusterContainer("juju-2", "node02", api.Running),
+	}
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+	exp.AliveContainers(gomock.Any()).Return(containers, nil)
+	// The running container is live-migrated to the least loaded
+	// member; the stopped one is moved cold to the next.
+	exp.MoveContainer("juju-0", "node03", true).Return(nil)
+	exp.MoveContainer("juju-1", "node02", false).Return(nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.ClusterEvacuator)
+	moves, err := env.EvacuateClusterMember(s.callCtx, "node01", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(moves, jc.DeepEquals, []environs.InstanceMove{
+		{Id: "juju-0", Zone: "node03", Live: true},
+		{Id: "juju-1", Zone: "node02"},
+	})
+}
+
+func (s *clusterSuite) TestEvacuateClusterMemberLiveMigrationFails(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		clusterContainer("juju-0", "node01", api.Running),
+		clusterContainer("juju-1", "node01", api.Running),
+	}
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+	exp.AliveContainers(gomock.Any()).Return(containers, nil)
+	exp.MoveContainer("juju-0", "node02", true).Return(errors.New("CRIU not found"))
+	exp.MoveContainer("juju-0", "node02", false).Return(nil)
+	exp.MoveContainer("juju-1", "node03", true).Return(errors.New("CRIU not found"))
+	exp.MoveContainer("juju-1", "node03", false).Return(errors.New("boom"))
+
+	env := s.NewEnviron(c, svr, nil).(environs.ClusterEvacuator)
+	moves, err := env.EvacuateClusterMember(s.callCtx, "node01", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(moves, gc.HasLen, 2)
+	c.Check(moves[0], jc.DeepEquals, environs.InstanceMove{Id: "juju-0", Zone: "node02"})
+	c.Check(moves[1].Id, gc.Equals, instance.Id("juju-1"))
+	c.Check(moves[1].Err, gc.ErrorMatches, "boom")
+}
+
+func (s *clusterSuite) TestEvacuateClusterMemberToTarget(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		clusterContainer("juju-0", "node01", api.Stopped),
+		clusterContainer("juju-1", "node01", api.Stopped),
+	}
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+	exp.AliveContainers(gomock.Any()).Return(containers, nil)
+	exp.MoveContainer("juju-0", "node03", false).Return(nil)
+	exp.MoveContainer("juju-1", "node03", false).Return(nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.ClusterEvacuator)
+	moves, err := env.EvacuateClusterMember(s.callCtx, "node01", "node03")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(moves, jc.DeepEquals, []environs.InstanceMove{
+		{Id: "juju-0", Zone: "node03"},
+		{Id: "juju-1", Zone: "node03"},
+	})
+}
+
+func (s *clusterSuite) TestEvacuateClusterMemberTargetUnavailable(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.ClusterEvacuator)
+	_, err := env.EvacuateClusterMember(s.callCtx, "node01", "node04")
+	c.Assert(err, gc.ErrorMatches, `target cluster member "node04" is not available`)
+}
+
+func (s *clusterSuite) TestEvacuateClusterMemberNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.ClusterEvacuator)
+	_, err := env.EvacuateClusterMember(s.callCtx, "node05", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clusterSuite) TestEvacuateClusterMemberNotClustered(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	svr.EXPECT().IsClustered().Return(false)
+
+	env := s.NewEnviron(c, svr, nil).(environs.ClusterEvacuator)
+	_, err := env.EvacuateClusterMember(s.callCtx, "node01", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *clusterSuite) TestInstanceAvailabilityZone(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		clusterContainer("juju-0", "node02", api.Running),
+		clusterContainer("juju-1", "none", api.Running),
+	}
+	svr.EXPECT().AliveContainers(gomock.Any()).Return(containers, nil)
+
+	env := s.NewEnviron(c, svr, nil)
+	insts, err := env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Check(insts[0].(instances.ZonedInstance).AvailabilityZone(), gc.Equals, "node02")
+	c.Check(insts[1].(instances.ZonedInstance).AvailabilityZone(), gc.Equals, "")
+}
+
+func (s *clusterSuite) TestMigrateInstance(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		clusterContainer("juju-0", "node01", api.Running),
+		clusterContainer("juju-1", "node02", api.Stopped),
+	}
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+	exp.AliveContainers(gomock.Any()).Return(containers, nil)
+	exp.MoveContainer("juju-0", "node03", true).Return(nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	move, err := env.MigrateInstance(s.callCtx, "juju-0", "node03")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(move, jc.DeepEquals, environs.InstanceMove{Id: "juju-0", Zone: "node03", Live: true})
+}
+
+func (s *clusterSuite) TestMigrateInstanceCold(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		clusterContainer("juju-0", "node01", api.Running),
+	}
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+	exp.AliveContainers(gomock.Any()).Return(containers, nil)
+	exp.MoveContainer("juju-0", "node02", true).Return(errors.New("CRIU not found"))
+	exp.MoveContainer("juju-0", "node02", false).Return(nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	move, err := env.MigrateInstance(s.callCtx, "juju-0", "node02")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(move, jc.DeepEquals, environs.InstanceMove{Id: "juju-0", Zone: "node02"})
+}
+
+func (s *clusterSuite) TestMigrateInstanceAlreadyOnMember(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		clusterContainer("juju-0", "node01", api.Running),
+	}
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+	exp.AliveContainers(gomock.Any()).Return(containers, nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	_, err := env.MigrateInstance(s.callCtx, "juju-0", "node01")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *clusterSuite) TestMigrateInstanceNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil)
+	exp.AliveContainers(gomock.Any()).Return(nil, nil)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	_, err := env.MigrateInstance(s.callCtx, "juju-9", "node02")
+	c.Assert(err, gc.ErrorMatches, `instance "juju-9" not found`)
+}
+
+func (s *clusterSuite) TestMigrateInstanceMemberUnavailable(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	exp.IsClustered().Return(true).AnyTimes()
+	exp.GetClusterMembers().Return(s.clusterMembers(), nil).Times(2)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	_, err := env.MigrateInstance(s.callCtx, "juju-0", "node04")
+	c.Assert(err, gc.ErrorMatches, `cluster member "node04" is not available`)
+	_, err = env.MigrateInstance(s.callCtx, "juju-0", "node05")
+	c.Assert(err, gc.ErrorMatches, `cluster member "node05" not found`)
+}
@@ -238,8 +238,8 @@ func (env *environ) getContainerSpec(
 }
 
 // getTargetServer checks to see if a valid zone was passed as a placement
-// directive in the start-up start-up arguments. If so, a server for the
-// specific node is returned.
+// directive in the start-up start-up arguments, or chosen by the provisioner
+// for a clustered server. If so, a server for the specific node is returned.
 func (env *environ) getTargetServer(
 	ctx context.ProviderCallContext, args environs.StartInstanceParams,
 ) (Server, error) {
@@ -249,7 +249,12 @@ func (env *environ) getTargetServer(
 	}
 
 	if p.nodeName == "" {
-		return env.server(), nil
+		// Without a placement directive, target the zone chosen by the
+		// provisioner, which honours the machine's zones constraint.
+		if args.AvailabilityZone == "" || !env.server().IsClustered() {
+			return env.server(), nil
+		}
+		p.nodeName = args.AvailabilityZone
 	}
 	return env.server().UseTargetServer(p.nodeName)
 }
@@ -303,11 +308,15 @@ func (env *environ) getHardwareCharacteristics(
 	}
 	cores := uint64(container.CPUs())
 	mem := uint64(container.Mem())
-	return &instance.HardwareCharacteristics{
+	hwc := &instance.HardwareCharacteristics{
 		Arch:     &archStr,
 		CpuCores: &cores,
 		Mem:      &mem,
 	}
+	if zone := inst.AvailabilityZone(); zone != "" {
+		hwc.AvailabilityZone = &zone
+	}
+	return hwc
 }
 
 // AllInstances implements environs.InstanceBroker.
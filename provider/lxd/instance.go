@@ -20,7 +20,7 @@ type environInstance struct {
 	env       *environ
 }
 
-var _ instances.Instance = (*environInstance)(nil)
+var _ instances.ZonedInstance = (*environInstance)(nil)
 
 func newInstance(container *lxd.Container, env *environ) *environInstance {
 	return &environInstance{
@@ -60,3 +60,13 @@ func (i *environInstance) Addresses(_ context.ProviderCallContext) (network.Prov
 	addrs, err := i.env.server().ContainerAddresses(i.container.Name)
 	return addrs, errors.Trace(err)
 }
+
+// AvailabilityZone implements instances.ZonedInstance.
+// For a clustered LXD, this is the name of the cluster member that the
+// container resides on. Containers on an unclustered server report no zone.
+func (i *environInstance) AvailabilityZone() string {
+	if location := i.container.Location; location != "none" {
+		return location
+	}
+	return ""
+}
@@ -78,6 +78,7 @@ type Server interface {
 	IsClustered() bool
 	UseTargetServer(name string) (*lxd.Server, error)
 	GetClusterMembers() (members []lxdapi.ClusterMember, err error)
+	MoveContainer(name, member string, live bool) error
 	Name() string
 	GetNetworkNames() ([]string, error)
 	GetNetworkState(name string) (*lxdapi.NetworkState, error)
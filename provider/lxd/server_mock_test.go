@@ -561,6 +561,20 @@ func (mr *MockServerMockRecorder) LocalBridgeName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalBridgeName", reflect.TypeOf((*MockServer)(nil).LocalBridgeName))
 }
 
+// MoveContainer mocks base method
+func (m *MockServer) MoveContainer(arg0, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveContainer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveContainer indicates an expected call of MoveContainer
+func (mr *MockServerMockRecorder) MoveContainer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveContainer", reflect.TypeOf((*MockServer)(nil).MoveContainer), arg0, arg1, arg2)
+}
+
 // Name mocks base method
 func (m *MockServer) Name() string {
 	m.ctrl.T.Helper()
@@ -744,6 +744,11 @@ func (conn *StubClient) GetClusterMembers() (members []api.ClusterMember, err er
 	return nil, conn.NextErr()
 }
 
+func (conn *StubClient) MoveContainer(name, member string, live bool) error {
+	conn.AddCall("MoveContainer", name, member, live)
+	return conn.NextErr()
+}
+
 type MockClock struct {
 	clock.Clock
 	now time.Time
@@ -6,7 +6,9 @@ package openstack
 import (
 	"fmt"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,7 +17,9 @@ import (
 	"github.com/juju/schema"
 	"github.com/juju/utils/v2"
 	"gopkg.in/goose.v2/cinder"
+	"gopkg.in/goose.v2/client"
 	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
 	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/nova"
 
@@ -121,16 +125,19 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 
 	// TODO (stickupkid): Move this to the ClientFactory.
 	// We shouldn't have another wrapper around an existing client.
-	cinderCl := cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)}
+	handleRequest := cinder.SetAuthHeaderFn(client.Token, http.DefaultClient.Do)
 
 	cloudSpec := env.cloudUnlocked
 	if len(cloudSpec.CACertificates) > 0 {
-		cinderCl = cinderClient{cinder.BasicTLSConfig(
-			env.volumeURL,
-			client.TenantId(),
+		handleRequest = cinder.AuthHeaderTSLConfigDoRequestFn(
 			client.Token,
-			tlsConfig(cloudSpec.CACertificates)),
-		}
+			tlsConfig(cloudSpec.CACertificates),
+		)
+	}
+	cinderCl := cinderClient{
+		Client:        cinder.NewClient(client.TenantId(), env.volumeURL, handleRequest),
+		endpoint:      env.volumeURL,
+		handleRequest: handleRequest,
 	}
 
 	return &openstackStorageAdapter{
@@ -219,7 +226,11 @@ type cinderVolumeSource struct {
 	zonedEnv       common.ZonedEnviron
 }
 
-var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var (
+	_ storage.VolumeSource      = (*cinderVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
+	_ storage.VolumeResizer     = (*cinderVolumeSource)(nil)
+)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(
@@ -257,14 +268,27 @@ func (s *cinderVolumeSource) createVolume(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The Cinder documentation incorrectly states the
+	// size parameter is in GB. It is actually GiB.
+	size := int(math.Ceil(float64(arg.Size / 1024)))
+	if arg.SnapshotId != "" {
+		// A volume created from a snapshot must be
+		// at least as large as the snapshot.
+		snapshot, err := s.storageAdapter.GetSnapshot(arg.SnapshotId)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting snapshot")
+		}
+		if snapshot.Size > size {
+			size = snapshot.Size
+		}
+	}
 	cinderVolume, err := s.storageAdapter.CreateVolume(cinder.CreateVolumeVolumeParams{
-		// The Cinder documentation incorrectly states the
-		// size parameter is in GB. It is actually GiB.
-		Size:             int(math.Ceil(float64(arg.Size / 1024))),
+		Size:             size,
 		Name:             resourceName(s.namespace, s.envName, arg.Tag.String()),
 		VolumeType:       cinderConfig.volumeType,
 		AvailabilityZone: az,
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -600,6 +624,81 @@ func (s *cinderVolumeSource) ImportVolume(ctx context.ProviderCallContext, volum
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// CreateVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    arg.VolumeId,
+			Name:        resourceName(s.namespace, s.envName, arg.Volume.String()),
+			Description: "snapshot of " + arg.Volume.String(),
+			// Snapshot the volume even if it is attached.
+			Force: true,
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %q", arg.VolumeId)
+			if denied := common.MaybeHandleCredentialError(IsAuthorisationFailure, err, ctx); denied {
+				// If it is an unauthorised error, no need to continue since we will 100% fail...
+				break
+			}
+			continue
+		}
+		volumeSnapshot := cinderToJujuVolumeSnapshot(snapshot)
+		results[i].Snapshot = &volumeSnapshot
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volumeIds []string) ([]storage.VolumeSnapshot, error) {
+	// As with DescribeVolumes, get all snapshots and
+	// filter them locally.
+	snapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return nil, errors.Trace(err)
+	}
+	volumeIdSet := set.NewStrings(volumeIds...)
+	var result []storage.VolumeSnapshot
+	for i := range snapshots {
+		if volumeIdSet.Contains(snapshots[i].VolumeID) {
+			result = append(result, cinderToJujuVolumeSnapshot(&snapshots[i]))
+		}
+	}
+	return result, nil
+}
+
+// DeleteVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := s.storageAdapter.DeleteSnapshot(snapshotId); err != nil && !errors.IsNotFound(err) {
+			handleCredentialError(err, ctx)
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// ResizeVolumes is part of the storage.VolumeResizer interface.
+func (s *cinderVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		// Cinder volumes are sized in GiB, so round up.
+		size := int(math.Ceil(float64(arg.Size) / 1024))
+		if err := s.storageAdapter.ExtendVolume(arg.VolumeId, size); err != nil {
+			results[i].Error = errors.Annotatef(err, "extending volume %q", arg.VolumeId)
+			if denied := common.MaybeHandleCredentialError(IsAuthorisationFailure, err, ctx); denied {
+				// If it is an unauthorised error, no need to continue since we will 100% fail...
+				break
+			}
+			continue
+		}
+		results[i].Size = uint64(size * 1024)
+	}
+	return results, nil
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
@@ -661,6 +760,24 @@ func cinderToJujuVolumeInfo(volume *cinder.Volume) storage.VolumeInfo {
 	}
 }
 
+// cinderTimeLayout is the layout of the timestamps reported by Cinder,
+// which are in UTC but carry no time zone.
+const cinderTimeLayout = "2006-01-02T15:04:05.999999"
+
+func cinderToJujuVolumeSnapshot(snapshot *cinder.Snapshot) storage.VolumeSnapshot {
+	created, err := time.Parse(cinderTimeLayout, snapshot.CreatedAt)
+	if err != nil {
+		created, _ = time.Parse(time.RFC3339, snapshot.CreatedAt)
+	}
+	return storage.VolumeSnapshot{
+		SnapshotId: snapshot.ID,
+		VolumeId:   snapshot.VolumeID,
+		Size:       uint64(snapshot.Size * 1024),
+		Created:    created,
+		Status:     snapshot.Status,
+	}
+}
+
 func detachVolume(instanceId, volumeId string, storageAdapter OpenstackStorage) error {
 	err := storageAdapter.DetachVolume(instanceId, volumeId)
 	if err != nil && !IsNotFoundError(err) {
@@ -694,6 +811,11 @@ type OpenstackStorage interface {
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
 	ListVolumeAvailabilityZones() ([]cinder.AvailabilityZone, error)
+	GetSnapshot(snapshotId string) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -737,6 +859,40 @@ type openstackStorageAdapter struct {
 
 type cinderClient struct {
 	*cinder.Client
+
+	// endpoint and handleRequest are retained so that we can
+	// make requests which the goose Cinder client does not
+	// support, such as volume actions.
+	endpoint      *url.URL
+	handleRequest cinder.RequestHandlerFn
+}
+
+// extendVolumeParams is the body of a Cinder "os-extend" volume action.
+type extendVolumeParams struct {
+	Extend struct {
+		NewSize int `json:"new_size"`
+	} `json:"os-extend"`
+}
+
+// ExtendVolume grows the volume with the specified ID to the
+// specified size in GiB.
+func (c cinderClient) ExtendVolume(volumeId string, newSize int) error {
+	var reqValue extendVolumeParams
+	reqValue.Extend.NewSize = newSize
+	requestData := goosehttp.RequestData{
+		ReqValue:       &reqValue,
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	endpoint := *c.endpoint
+	if !strings.HasSuffix(endpoint.Path, "/") {
+		endpoint.Path += "/"
+	}
+	urlPath := url.URL{Path: fmt.Sprintf("volumes/%s/action", volumeId)}
+	httpClient := goosehttp.New()
+	httpClient.Client = http.Client{Transport: c.handleRequest}
+	return httpClient.JsonRequest(
+		client.POST, endpoint.ResolveReference(&urlPath).String(), "", &requestData, nil,
+	)
 }
 
 type novaClient struct {
@@ -789,6 +945,58 @@ func (ga *openstackStorageAdapter) DeleteVolume(volumeId string) error {
 	return nil
 }
 
+// GetSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshot(snapshotId string) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshot(snapshotId)
+	if err != nil {
+		if IsNotFoundError(err) {
+			return nil, errors.NotFoundf("snapshot %q", snapshotId)
+		}
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
+
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// DeleteSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) DeleteSnapshot(snapshotId string) error {
+	if err := ga.cinderClient.DeleteSnapshot(snapshotId); err != nil {
+		if IsNotFoundError(err) {
+			return errors.NotFoundf("snapshot %q", snapshotId)
+		}
+		return err
+	}
+	return nil
+}
+
+// ExtendVolume is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) ExtendVolume(volumeId string, newSize int) error {
+	if err := ga.cinderClient.ExtendVolume(volumeId, newSize); err != nil {
+		if IsNotFoundError(err) {
+			return errors.NotFoundf("volume %q", volumeId)
+		}
+		return err
+	}
+	return nil
+}
+
 // DetachVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) DetachVolume(serverId, attachmentId string) error {
 	if err := ga.novaClient.DetachVolume(serverId, attachmentId); err != nil {
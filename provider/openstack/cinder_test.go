@@ -244,6 +244,169 @@ func (s *cinderVolumeSourceSuite) TestCreateVolume(c *gc.C) {
 	c.Check(getVolumeCalls, gc.Equals, 2)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	mockAdapter := &mockAdapter{
+		getSnapshot: func(snapshotId string) (*cinder.Snapshot, error) {
+			c.Check(snapshotId, gc.Equals, "snap-1")
+			return &cinder.Snapshot{ID: snapshotId, Size: 5}, nil
+		},
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			// The volume is grown to the size of the snapshot.
+			c.Assert(args, jc.DeepEquals, cinder.CreateVolumeVolumeParams{
+				Size:       5,
+				Name:       "juju-testmodel-volume-123",
+				SnapshotId: "snap-1",
+			})
+			return &cinder.Volume{ID: mockVolId}, nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   5,
+				Status: "available",
+			}, nil
+		},
+	}
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	results, err := volSource.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Provider:   openstack.CinderProviderType,
+		Tag:        mockVolumeTag,
+		Size:       2 * 1024,
+		SnapshotId: "snap-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Check(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: mockVolumeTag,
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   mockVolId,
+			Size:       5 * 1024,
+			Persistent: true,
+		},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateSnapshotSnapshotParams{
+				VolumeId:    mockVolId,
+				Name:        "juju-testmodel-volume-123",
+				Description: "snapshot of volume-123",
+				Force:       true,
+			})
+			return &cinder.Snapshot{
+				ID:        "snap-1",
+				VolumeID:  mockVolId,
+				Size:      2,
+				Status:    "creating",
+				CreatedAt: "2021-05-24T03:00:00.000000",
+			}, nil
+		},
+	}
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	snapshotter, ok := volSource.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "snap-1",
+			VolumeId:   mockVolId,
+			Size:       2 * 1024,
+			Created:    time.Date(2021, 5, 24, 3, 0, 0, 0, time.UTC),
+			Status:     "creating",
+		},
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestListVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{
+				{ID: "snap-1", VolumeID: mockVolId, Size: 1, Status: "available"},
+				{ID: "snap-2", VolumeID: "other", Size: 1, Status: "available"},
+			}, nil
+		},
+	}
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	snapshots, err := volSource.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.callCtx, []string{mockVolId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "snap-1",
+		VolumeId:   mockVolId,
+		Size:       1024,
+		Status:     "available",
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestDeleteVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		deleteSnapshot: func(snapshotId string) error {
+			switch snapshotId {
+			case "snap-gone":
+				return errors.NotFoundf("snapshot %q", snapshotId)
+			case "snap-busy":
+				return errors.New("snapshot is busy")
+			}
+			return nil
+		},
+	}
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	errs, err := volSource.(storage.VolumeSnapshotter).DeleteVolumeSnapshots(
+		s.callCtx, []string{"snap-1", "snap-gone", "snap-busy"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], jc.ErrorIsNil)
+	c.Check(errs[2], gc.ErrorMatches, `deleting snapshot "snap-busy": snapshot is busy`)
+	mockAdapter.CheckCallNames(c, "DeleteSnapshot", "DeleteSnapshot", "DeleteSnapshot")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		extendVolume: func(volumeId string, newSize int) error {
+			if volumeId == "vol-busy" {
+				return errors.New("volume is busy")
+			}
+			return nil
+		},
+	}
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(
+		s.callCtx, []storage.VolumeResizeParams{{
+			Volume:   names.NewVolumeTag("0"),
+			VolumeId: "vol-0",
+			Size:     1500,
+		}, {
+			Volume:   names.NewVolumeTag("1"),
+			VolumeId: "vol-busy",
+			Size:     4096,
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0].Error, jc.ErrorIsNil)
+	c.Check(results[0].Size, gc.Equals, uint64(2048))
+	c.Check(results[1].Error, gc.ErrorMatches, `extending volume "vol-busy": volume is busy`)
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExtendVolume", []interface{}{"vol-0", 2}},
+		{"ExtendVolume", []interface{}{"vol-busy", 4}},
+	})
+}
+
 func (s *cinderVolumeSourceSuite) TestCreateVolumeNoCompatibleZones(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -852,6 +1015,11 @@ type mockAdapter struct {
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
 	listAvailabilityZones func() ([]cinder.AvailabilityZone, error)
+	getSnapshot           func(string) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -929,6 +1097,46 @@ func (ma *mockAdapter) ListVolumeAvailabilityZones() ([]cinder.AvailabilityZone,
 	return nil, gooseerrors.NewNotImplementedf(nil, nil, "ListAvailabilityZones")
 }
 
+func (ma *mockAdapter) GetSnapshot(snapshotId string) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshot", snapshotId)
+	if ma.getSnapshot != nil {
+		return ma.getSnapshot(snapshotId)
+	}
+	return nil, errors.NotFoundf("snapshot %q", snapshotId)
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshotsDetail")
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	ma.MethodCall(ma, "DeleteSnapshot", snapshotId)
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
+
+func (ma *mockAdapter) ExtendVolume(volumeId string, newSize int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, newSize)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, newSize)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
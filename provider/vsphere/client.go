@@ -33,6 +33,7 @@ type Client interface {
 	MoveVMsInto(context.Context, string, ...types.ManagedObjectReference) error
 	RemoveVirtualMachines(context.Context, string) error
 	UpdateVirtualMachineExtraConfig(context.Context, *mo.VirtualMachine, map[string]string) error
+	MigrateVirtualMachine(context.Context, *mo.VirtualMachine, types.ManagedObjectReference, *types.ManagedObjectReference) error
 	VirtualMachines(context.Context, string) ([]*mo.VirtualMachine, error)
 	UserHasRootLevelPrivilege(context.Context, string) (bool, error)
 	FindFolder(ctx context.Context, folderPath string) (vmFolder *object.Folder, err error)
@@ -0,0 +1,61 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vsphere
+
+import (
+	"github.com/juju/errors"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.InstanceMigrator = (*environ)(nil)
+
+// MigrateInstance is part of the environs.InstanceMigrator interface.
+func (env *environ) MigrateInstance(ctx context.ProviderCallContext, id instance.Id, zone string) (move environs.InstanceMove, err error) {
+	err = env.withSession(ctx, func(env *sessionEnviron) error {
+		move, err = env.MigrateInstance(ctx, id, zone)
+		return err
+	})
+	return move, err
+}
+
+// MigrateInstance is part of the environs.InstanceMigrator interface.
+// The VM is relocated into the resource pool of the target availability
+// zone; powered-on VMs are moved with vMotion.
+func (env *sessionEnviron) MigrateInstance(ctx context.ProviderCallContext, id instance.Id, zone string) (environs.InstanceMove, error) {
+	availZone, err := env.availZone(ctx, zone)
+	if err != nil {
+		return environs.InstanceMove{}, errors.Trace(err)
+	}
+	insts, err := env.Instances(ctx, []instance.Id{id})
+	if err == environs.ErrNoInstances {
+		return environs.InstanceMove{}, errors.NotFoundf("instance %q", id)
+	} else if err != nil {
+		return environs.InstanceMove{}, errors.Trace(err)
+	}
+	vm := insts[0].(*environInstance).base
+	poolRef := availZone.pool.Reference()
+	if vm.ResourcePool != nil && vm.ResourcePool.Value == poolRef.Value {
+		return environs.InstanceMove{}, errors.AlreadyExistsf("instance %q in availability zone %q", id, zone)
+	}
+
+	// A standalone host has no DRS to choose a host for the VM,
+	// so name the host explicitly.
+	var host *types.ManagedObjectReference
+	if len(availZone.r.Host) == 1 {
+		host = &availZone.r.Host[0]
+	}
+	if err := env.client.MigrateVirtualMachine(env.ctx, vm, poolRef, host); err != nil {
+		HandleCredentialError(err, env, ctx)
+		return environs.InstanceMove{}, errors.Annotatef(err, "moving instance %q to availability zone %q", id, zone)
+	}
+	return environs.InstanceMove{
+		Id:   id,
+		Zone: zone,
+		Live: vm.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn,
+	}, nil
+}
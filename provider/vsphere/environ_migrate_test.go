@@ -0,0 +1,89 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vsphere_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
+)
+
+type environMigrateSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environMigrateSuite{})
+
+func (s *environMigrateSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+
+	z1 := newComputeResource("z1")
+	z2 := newComputeResource("z2")
+	z2.Host = []types.ManagedObjectReference{{Type: "HostSystem", Value: "host-2"}}
+	s.client.folders = makeFolders("/DC/host")
+	s.client.computeResources = []vsphereclient.ComputeResource{
+		{Resource: z1, Path: "/DC/host/z1"},
+		{Resource: z2, Path: "/DC/host/z2"},
+	}
+	s.client.resourcePools = map[string][]*object.ResourcePool{
+		"/DC/host/z1/...": {makeResourcePool("rp-z1", "/DC/host/z1/Resources")},
+		"/DC/host/z2/...": {makeResourcePool("rp-z2", "/DC/host/z2/Resources")},
+	}
+	s.client.virtualMachines = []*mo.VirtualMachine{
+		buildVM("inst-0").resourcePool(z1.ResourcePool).vm(),
+		buildVM("inst-1").resourcePool(z1.ResourcePool).powerOff().vm(),
+	}
+}
+
+func (s *environMigrateSuite) TestMigrateInstance(c *gc.C) {
+	migrator := s.env.(environs.InstanceMigrator)
+	move, err := migrator.MigrateInstance(s.callCtx, "inst-0", "z2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(move, jc.DeepEquals, environs.InstanceMove{Id: "inst-0", Zone: "z2", Live: true})
+
+	call := s.client.Calls()[len(s.client.Calls())-2]
+	c.Assert(call.FuncName, gc.Equals, "MigrateVirtualMachine")
+	c.Assert(call.Args[1].(*mo.VirtualMachine).Name, gc.Equals, "inst-0")
+	c.Assert(call.Args[2], jc.DeepEquals, types.ManagedObjectReference{Type: "ResourcePool", Value: "rp-z2"})
+	c.Assert(call.Args[3], jc.DeepEquals, &types.ManagedObjectReference{Type: "HostSystem", Value: "host-2"})
+}
+
+func (s *environMigrateSuite) TestMigrateInstancePoweredOff(c *gc.C) {
+	migrator := s.env.(environs.InstanceMigrator)
+	move, err := migrator.MigrateInstance(s.callCtx, "inst-1", "z2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(move, jc.DeepEquals, environs.InstanceMove{Id: "inst-1", Zone: "z2"})
+}
+
+func (s *environMigrateSuite) TestMigrateInstanceAlreadyInZone(c *gc.C) {
+	migrator := s.env.(environs.InstanceMigrator)
+	_, err := migrator.MigrateInstance(s.callCtx, "inst-0", "z1")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *environMigrateSuite) TestMigrateInstanceNotFound(c *gc.C) {
+	migrator := s.env.(environs.InstanceMigrator)
+	_, err := migrator.MigrateInstance(s.callCtx, "inst-9", "z2")
+	c.Assert(err, gc.ErrorMatches, `instance "inst-9" not found`)
+}
+
+func (s *environMigrateSuite) TestMigrateInstanceUnknownZone(c *gc.C) {
+	migrator := s.env.(environs.InstanceMigrator)
+	_, err := migrator.MigrateInstance(s.callCtx, "inst-0", "z9")
+	c.Assert(err, gc.ErrorMatches, `availability zone "z9" not found`)
+}
+
+func (s *environMigrateSuite) TestMigrateInstanceError(c *gc.C) {
+	// Folders, ComputeResources, ResourcePools (x2), VirtualMachines.
+	s.client.SetErrors(nil, nil, nil, nil, nil, errors.New("vMotion failed"))
+	migrator := s.env.(environs.InstanceMigrator)
+	_, err := migrator.MigrateInstance(s.callCtx, "inst-0", "z2")
+	c.Assert(err, gc.ErrorMatches, `moving instance "inst-0" to availability zone "z2": vMotion failed`)
+}
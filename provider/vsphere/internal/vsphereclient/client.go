@@ -507,6 +507,33 @@ func (c *Client) UpdateVirtualMachineExtraConfig(
 	return nil
 }
 
+// MigrateVirtualMachine moves the specified virtual machine into the
+// given resource pool, and onto the given host if one is specified.
+// Virtual machines that are powered on are moved with vMotion, without
+// being stopped.
+func (c *Client) MigrateVirtualMachine(
+	ctx context.Context,
+	vmInfo *mo.VirtualMachine,
+	pool types.ManagedObjectReference,
+	host *types.ManagedObjectReference,
+) error {
+	c.logger.Tracef("MigrateVirtualMachine() vmInfo.Name=%q, pool=%v, host=%v",
+		vmInfo.Name, pool, host)
+	spec := types.VirtualMachineRelocateSpec{
+		Pool: &pool,
+		Host: host,
+	}
+	vm := object.NewVirtualMachine(c.client.Client, vmInfo.Reference())
+	task, err := vm.Relocate(ctx, spec, types.VirtualMachineMovePriorityDefaultPriority)
+	if err != nil {
+		return errors.Annotate(err, "relocating VM")
+	}
+	if _, err := task.WaitForResult(ctx, nil); err != nil {
+		return errors.Annotate(err, "relocating VM")
+	}
+	return nil
+}
+
 // DeleteDatastoreFile deletes a file or directory in the datastore.
 func (c *Client) DeleteDatastoreFile(ctx context.Context, datastorePath string) error {
 	c.logger.Tracef("DeleteDatastoreFile() path=%q", datastorePath)
@@ -833,6 +833,36 @@ func (s *clientSuite) TestUpdateVirtualMachineExtraConfig(c *gc.C) {
 	)
 }
 
+func (s *clientSuite) TestMigrateVirtualMachine(c *gc.C) {
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	var vm mo.VirtualMachine
+	vm.Self = types.ManagedObjectReference{
+		Type:  "VirtualMachine",
+		Value: "FakeVm0",
+	}
+	pool := types.ManagedObjectReference{
+		Type:  "ResourcePool",
+		Value: "FakeResourcePool2",
+	}
+	host := types.ManagedObjectReference{
+		Type:  "HostSystem",
+		Value: "FakeHost2",
+	}
+	err := client.MigrateVirtualMachine(context.Background(), &vm, pool, &host)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.roundTripper.CheckCallNames(c,
+		"RelocateVM_Task",
+		"CreatePropertyCollector",
+		"CreateFilter",
+		"WaitForUpdatesEx",
+	)
+	s.roundTripper.CheckCall(c, 0, "RelocateVM_Task", types.VirtualMachineRelocateSpec{
+		Pool: &pool,
+		Host: &host,
+	})
+}
+
 func (s *clientSuite) TestVirtualMachines(c *gc.C) {
 	client := s.newFakeClient(&s.roundTripper, "dc0")
 	result, err := client.VirtualMachines(context.Background(), "foo/bar/*")
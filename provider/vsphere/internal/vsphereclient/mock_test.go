@@ -27,6 +27,10 @@ var (
 		Type:  "Task",
 		Value: "ReconfigVMTask",
 	}
+	relocateVMTask = types.ManagedObjectReference{
+		Type:  "Task",
+		Value: "RelocateVMTask",
+	}
 	destroyTask = types.ManagedObjectReference{
 		Type:  "Task",
 		Value: "DestroyTask",
@@ -101,6 +105,10 @@ func (r *mockRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault
 		req := req.(*methods.ReconfigVM_TaskBody).Req
 		r.MethodCall(r, "ReconfigVM_Task", req.Spec)
 		res.Res = &types.ReconfigVM_TaskResponse{reconfigVMTask}
+	case *methods.RelocateVM_TaskBody:
+		req := req.(*methods.RelocateVM_TaskBody).Req
+		r.MethodCall(r, "RelocateVM_Task", req.Spec)
+		res.Res = &types.RelocateVM_TaskResponse{relocateVMTask}
 	case *methods.Destroy_TaskBody:
 		r.MethodCall(r, "Destroy_Task")
 		res.Res = &types.Destroy_TaskResponse{destroyTask}
@@ -141,6 +141,13 @@ func (c *mockClient) UpdateVirtualMachineExtraConfig(ctx context.Context, vm *mo
 	return c.NextErr()
 }
 
+func (c *mockClient) MigrateVirtualMachine(ctx context.Context, vm *mo.VirtualMachine, pool types.ManagedObjectReference, host *types.ManagedObjectReference) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "MigrateVirtualMachine", ctx, vm, pool, host)
+	return c.NextErr()
+}
+
 func (c *mockClient) UserHasRootLevelPrivilege(ctx context.Context, privilege string) (bool, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
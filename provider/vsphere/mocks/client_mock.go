@@ -169,6 +169,20 @@ func (mr *MockClientMockRecorder) Folders(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Folders", reflect.TypeOf((*MockClient)(nil).Folders), arg0)
 }
 
+// MigrateVirtualMachine mocks base method
+func (m *MockClient) MigrateVirtualMachine(arg0 context.Context, arg1 *mo.VirtualMachine, arg2 types.ManagedObjectReference, arg3 *types.ManagedObjectReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateVirtualMachine", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MigrateVirtualMachine indicates an expected call of MigrateVirtualMachine
+func (mr *MockClientMockRecorder) MigrateVirtualMachine(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateVirtualMachine", reflect.TypeOf((*MockClient)(nil).MigrateVirtualMachine), arg0, arg1, arg2, arg3)
+}
+
 // MoveVMFolderInto mocks base method
 func (m *MockClient) MoveVMFolderInto(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
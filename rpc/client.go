@@ -4,11 +4,14 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/trace"
 )
 
 var ErrShutdown = errors.New("connection is shut down")
@@ -24,6 +27,12 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// TraceParent holds the W3C trace context sent with the
+	// request, if any.
+	TraceParent string
+
+	reqId uint64
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -86,14 +95,16 @@ func (conn *Conn) send(call *Call) {
 	}
 	conn.reqId++
 	reqId := conn.reqId
+	call.reqId = reqId
 	conn.clientPending[reqId] = call
 	conn.mutex.Unlock()
 
 	// Encode and send the request.
 	hdr := &Header{
-		RequestId: reqId,
-		Request:   call.Request,
-		Version:   1,
+		RequestId:   reqId,
+		Request:     call.Request,
+		Version:     1,
+		TraceParent: call.TraceParent,
 	}
 	params := call.Params
 	if params == nil {
@@ -167,13 +178,39 @@ func (call *Call) done() {
 // The params value may be nil if no parameters are provided; the response value
 // may be nil to indicate that any result should be discarded.
 func (conn *Conn) Call(req Request, params, response interface{}) error {
+	return conn.CallContext(context.Background(), req, params, response)
+}
+
+// CallContext is like Call, but sends the context of the span held by
+// ctx, if any, with the request so that the server can record its work
+// as part of the same trace. If ctx is cancelled before the call
+// completes, CallContext returns ctx.Err() and any response is discarded.
+func (conn *Conn) CallContext(ctx context.Context, req Request, params, response interface{}) error {
 	call := &Call{
-		Request:  req,
-		Params:   params,
-		Response: response,
-		Done:     make(chan *Call, 1),
+		Request:     req,
+		Params:      params,
+		Response:    response,
+		Done:        make(chan *Call, 1),
+		TraceParent: trace.SpanContextFromContext(ctx).TraceParent(),
 	}
 	conn.send(call)
+	select {
+	case result := <-call.Done:
+		return errors.Trace(result.Error)
+	case <-ctx.Done():
+	}
+	// Only abandon the call if the response is not already being
+	// read, otherwise it could be written into response after we
+	// return.
+	conn.mutex.Lock()
+	abandoned := conn.clientPending[call.reqId] == call
+	if abandoned {
+		delete(conn.clientPending, call.reqId)
+	}
+	conn.mutex.Unlock()
+	if abandoned {
+		return errors.Trace(ctx.Err())
+	}
 	result := <-call.Done
 	return errors.Trace(result.Error)
 }
@@ -63,16 +63,17 @@ type inMsgV0 struct {
 }
 
 type inMsgV1 struct {
-	RequestId uint64                 `json:"request-id"`
-	Type      string                 `json:"type"`
-	Version   int                    `json:"version"`
-	Id        string                 `json:"id"`
-	Request   string                 `json:"request"`
-	Params    json.RawMessage        `json:"params"`
-	Error     string                 `json:"error"`
-	ErrorCode string                 `json:"error-code"`
-	ErrorInfo map[string]interface{} `json:"error-info"`
-	Response  json.RawMessage        `json:"response"`
+	RequestId   uint64                 `json:"request-id"`
+	Type        string                 `json:"type"`
+	Version     int                    `json:"version"`
+	Id          string                 `json:"id"`
+	Request     string                 `json:"request"`
+	Params      json.RawMessage        `json:"params"`
+	Error       string                 `json:"error"`
+	ErrorCode   string                 `json:"error-code"`
+	ErrorInfo   map[string]interface{} `json:"error-info"`
+	Response    json.RawMessage        `json:"response"`
+	TraceParent string                 `json:"trace-parent"`
 }
 
 // outMsg holds an outgoing message.
@@ -89,16 +90,17 @@ type outMsgV0 struct {
 }
 
 type outMsgV1 struct {
-	RequestId uint64                 `json:"request-id,omitempty"`
-	Type      string                 `json:"type,omitempty"`
-	Version   int                    `json:"version,omitempty"`
-	Id        string                 `json:"id,omitempty"`
-	Request   string                 `json:"request,omitempty"`
-	Params    interface{}            `json:"params,omitempty"`
-	Error     string                 `json:"error,omitempty"`
-	ErrorCode string                 `json:"error-code,omitempty"`
-	ErrorInfo map[string]interface{} `json:"error-info,omitempty"`
-	Response  interface{}            `json:"response,omitempty"`
+	RequestId   uint64                 `json:"request-id,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Version     int                    `json:"version,omitempty"`
+	Id          string                 `json:"id,omitempty"`
+	Request     string                 `json:"request,omitempty"`
+	Params      interface{}            `json:"params,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	ErrorCode   string                 `json:"error-code,omitempty"`
+	ErrorInfo   map[string]interface{} `json:"error-info,omitempty"`
+	Response    interface{}            `json:"response,omitempty"`
+	TraceParent string                 `json:"trace-parent,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -142,6 +144,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.ErrorInfo = c.msg.ErrorInfo
+	hdr.TraceParent = c.msg.TraceParent
 	hdr.Version = version
 	return nil
 }
@@ -268,14 +271,15 @@ func newOutMsgV0(hdr *rpc.Header, body interface{}) outMsgV0 {
 // reflect, but no.
 func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 	result := outMsgV1{
-		RequestId: hdr.RequestId,
-		Type:      hdr.Request.Type,
-		Version:   hdr.Request.Version,
-		Id:        hdr.Request.Id,
-		Request:   hdr.Request.Action,
-		Error:     hdr.Error,
-		ErrorCode: hdr.ErrorCode,
-		ErrorInfo: hdr.ErrorInfo,
+		RequestId:   hdr.RequestId,
+		Type:        hdr.Request.Type,
+		Version:     hdr.Request.Version,
+		Id:          hdr.Request.Id,
+		Request:     hdr.Request.Action,
+		Error:       hdr.Error,
+		ErrorCode:   hdr.ErrorCode,
+		ErrorInfo:   hdr.ErrorInfo,
+		TraceParent: hdr.TraceParent,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
@@ -86,6 +86,18 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 1, "type": "foo", "request": "frob", "trace-parent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}`,
+		expectHdr: rpc.Header{
+			RequestId: 1,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			Version:     1,
+		},
+		expectBody: new(map[string]interface{}),
 	}, {
 		msg: `{"request-id": 2, "error": "an error", "error-code": "a code"}`,
 		expectHdr: rpc.Header{
@@ -231,6 +243,18 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 1, "type": "foo","id":"id", "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 1,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			Version:     1,
+		},
+		body:   &value{X: "param"},
+		expect: `{"request-id": 1, "type": "foo", "request": "frob", "params": {"X": "param"}, "trace-parent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}`,
 	}, {
 		hdr: &rpc.Header{
 			RequestId: 2,
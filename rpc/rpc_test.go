@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/rpcreflect"
@@ -20,6 +21,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/testing"
@@ -1173,6 +1175,89 @@ func (*rpcSuite) TestRequestContext(c *gc.C) {
 	c.Assert(arg, gc.Equals, stringVal{"foo"})
 }
 
+func (*rpcSuite) TestRequestTraceContext(c *gc.C) {
+	root := &Root{}
+	root.contextInst = &ContextMethods{root: root}
+
+	tracer := trace.NewBufferedTracer(clock.WallClock, 10)
+	trace.SetDefaultTracer(tracer)
+	defer trace.SetDefaultTracer(nil)
+
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	remote := trace.SpanContext{
+		TraceID: trace.TraceID{15: 1},
+		SpanID:  trace.SpanID{7: 2},
+		Sampled: true,
+	}
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), remote)
+	err := client.CallContext(ctx, rpc.Request{"ContextMethods", 0, "", "Call0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spans, _ := tracer.Drain()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].Name, gc.Equals, "ContextMethods.Call0")
+	c.Assert(spans[0].SpanContext.TraceID, gc.Equals, remote.TraceID)
+	c.Assert(spans[0].ParentSpanID, gc.Equals, remote.SpanID)
+	// The method is called with the server span.
+	callSpan := trace.SpanContextFromContext(root.contextInst.callContext)
+	c.Assert(callSpan, gc.Equals, spans[0].SpanContext)
+}
+
+func (*rpcSuite) TestRequestErrorLoggedWithTraceLabels(c *gc.C) {
+	root := &Root{
+		errorInst: &ErrorMethods{errors.New("boom")},
+	}
+	tracer := trace.NewBufferedTracer(clock.WallClock, 10)
+	trace.SetDefaultTracer(tracer)
+	defer trace.SetDefaultTracer(nil)
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("rpc-trace-test", &tw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("rpc-trace-test")
+	loggo.GetLogger("juju.rpc").SetLogLevel(loggo.DEBUG)
+
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	err := client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	spans, _ := tracer.Drain()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(tw.Log(), jc.LogMatches, jc.SimpleMessages{{
+		Level: loggo.DEBUG,
+		Message: fmt.Sprintf(`\[labels trace-id=%s span-id=%s\] ErrorMethods\(0\)\.Call failed: boom`,
+			spans[0].SpanContext.TraceID, spans[0].SpanContext.SpanID),
+	}})
+}
+
+func (*rpcSuite) TestCallContextCancelled(c *gc.C) {
+	root := &Root{}
+	root.contextInst = &ContextMethods{
+		root:    root,
+		waiting: make(chan struct{}),
+	}
+
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errch := make(chan error, 1)
+	go func() {
+		errch <- client.CallContext(ctx, rpc.Request{"ContextMethods", 0, "", "Wait"}, nil, nil)
+	}()
+
+	<-root.contextInst.waiting
+	cancel()
+	select {
+	case err := <-errch:
+		c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for call to return")
+	}
+}
+
 func (*rpcSuite) TestConnectionContextCloseClient(c *gc.C) {
 	root := &Root{}
 	root.contextInst = &ContextMethods{
@@ -14,6 +14,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/rpcreflect"
+
+	"github.com/juju/juju/core/trace"
 )
 
 const codeNotImplemented = "not implemented"
@@ -70,6 +72,12 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// TraceParent holds the W3C trace context of the span which
+	// made the request, if any, so that the server's spans can be
+	// recorded as part of the same trace. It is only sent with
+	// version 1 messages.
+	TraceParent string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -568,8 +576,17 @@ func (conn *Conn) runRequest(
 	ctx, cancel := context.WithCancel(conn.context)
 	defer cancel()
 
+	ctx, span := startRequestSpan(ctx, req.hdr)
+	defer span.End()
+	// Messages about the request are labelled with its span,
+	// so that they can be found from the trace.
+	reqLogger := trace.Logger(ctx, logger)
+
 	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
+	span.RecordError(err)
 	if err != nil {
+		reqLogger.Debugf("%s(%d).%s failed: %v",
+			req.hdr.Request.Type, req.hdr.Request.Version, req.hdr.Request.Action, err)
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), recorder)
 	} else {
 		hdr := &Header{
@@ -583,7 +600,7 @@ func (conn *Conn) runRequest(
 			rvi = struct{}{}
 		}
 		if err := recorder.HandleReply(req.hdr.Request, hdr, rvi); err != nil {
-			logger.Errorf("error recording reply %+v: %T %+v", hdr, err, err)
+			reqLogger.Errorf("error recording reply %+v: %T %+v", hdr, err, err)
 		}
 		conn.sending.Lock()
 		err = conn.codec.WriteMessage(hdr, rvi)
@@ -597,9 +614,28 @@ func (conn *Conn) runRequest(
 		msg := err.Error()
 		if !strings.Contains(msg, "websocket: close sent") &&
 			!strings.Contains(msg, "write: broken pipe") {
-			logger.Errorf("error writing response: %T %+v", err, err)
+			reqLogger.Errorf("error writing response: %T %+v", err, err)
+		}
+	}
+}
+
+// startRequestSpan starts a span covering the dispatch of the request
+// to its method, as part of the caller's trace if the request has a
+// valid trace context.
+func startRequestSpan(ctx context.Context, hdr Header) (context.Context, trace.Span) {
+	if hdr.TraceParent != "" {
+		if sc, err := trace.ParseTraceParent(hdr.TraceParent); err == nil {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+		} else {
+			logger.Tracef("ignoring trace context: %v", err)
 		}
 	}
+	req := hdr.Request
+	return trace.Start(ctx, req.Type+"."+req.Action,
+		trace.StringAttr("rpc.facade", req.Type),
+		trace.IntAttr("rpc.version", req.Version),
+		trace.StringAttr("rpc.method", req.Action),
+	)
 }
 
 type serverError struct {
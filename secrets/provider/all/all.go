@@ -0,0 +1,11 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package all registers all of the secret backend providers.
+package all
+
+import (
+	// Register the secret backend providers.
+	_ "github.com/juju/juju/secrets/provider/kubernetes"
+	_ "github.com/juju/juju/secrets/provider/vault"
+)
@@ -0,0 +1,12 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package provider defines the interface implemented by external
+// secret backends, and the registry used to look them up.
+//
+// By default, secret content is stored in the controller database
+// alongside the secret metadata; this is the "internal" backend.
+// A model may instead be configured to store content in an external
+// backend, in which case the controller only records a reference to
+// each revision of content.
+package provider
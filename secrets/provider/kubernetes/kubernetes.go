@@ -0,0 +1,85 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package kubernetes implements a secret backend which stores
+// secret content as secrets in the model's Kubernetes namespace.
+package kubernetes
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+)
+
+// BackendType is the type of the Kubernetes secret backend.
+const BackendType = secrets.KubernetesBackend
+
+// SecretsBroker is implemented by CAAS brokers which
+// can store secret content in the model's namespace.
+type SecretsBroker interface {
+	SaveJujuSecret(uri *secrets.URI, value secrets.SecretValue) (string, error)
+	GetJujuSecret(name string) (secrets.SecretValue, error)
+	DeleteJujuSecret(name string) error
+}
+
+func init() {
+	provider.Register(k8sProvider{})
+}
+
+type k8sProvider struct{}
+
+// Type implements provider.SecretStoreProvider.
+func (k8sProvider) Type() string {
+	return BackendType
+}
+
+// CredentialAttributes implements provider.SecretStoreProvider.
+func (k8sProvider) CredentialAttributes() []string {
+	return nil
+}
+
+// ValidateConfig implements provider.SecretStoreProvider.
+func (k8sProvider) ValidateConfig(cfg map[string]interface{}, credential map[string]string) error {
+	if len(cfg) > 0 || len(credential) > 0 {
+		return errors.NotValidf("kubernetes secret backend config")
+	}
+	return nil
+}
+
+// NewStore implements provider.SecretStoreProvider.
+func (k8sProvider) NewStore(cfg *provider.ModelBackendConfig) (provider.SecretsStore, error) {
+	if cfg.NewBroker == nil {
+		return nil, errors.NotSupportedf("kubernetes secret backend on non kubernetes model")
+	}
+	broker, err := cfg.NewBroker()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secretsBroker, ok := broker.(SecretsBroker)
+	if !ok {
+		return nil, errors.NotSupportedf("kubernetes secret backend on this model")
+	}
+	return &k8sStore{broker: secretsBroker}, nil
+}
+
+type k8sStore struct {
+	broker SecretsBroker
+}
+
+// SaveContent implements provider.SecretsStore.
+func (s *k8sStore) SaveContent(uri *secrets.URI, value secrets.SecretValue) (string, error) {
+	name, err := s.broker.SaveJujuSecret(uri, value)
+	return name, errors.Annotatef(err, "saving content for secret %q", uri)
+}
+
+// GetContent implements provider.SecretsStore.
+func (s *k8sStore) GetContent(revisionID string) (secrets.SecretValue, error) {
+	val, err := s.broker.GetJujuSecret(revisionID)
+	return val, errors.Annotatef(err, "getting secret content %q", revisionID)
+}
+
+// DeleteContent implements provider.SecretsStore.
+func (s *k8sStore) DeleteContent(revisionID string) error {
+	return errors.Annotatef(s.broker.DeleteJujuSecret(revisionID), "deleting secret content %q", revisionID)
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/kubernetes"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type kubernetesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&kubernetesSuite{})
+
+type fakeBroker struct {
+	caas.Broker
+	testing.Stub
+
+	content map[string]secrets.SecretValue
+}
+
+func (b *fakeBroker) SaveJujuSecret(uri *secrets.URI, value secrets.SecretValue) (string, error) {
+	b.MethodCall(b, "SaveJujuSecret", uri, value)
+	name := "juju-secret-" + uri.ID
+	b.content[name] = value
+	return name, b.NextErr()
+}
+
+func (b *fakeBroker) GetJujuSecret(name string) (secrets.SecretValue, error) {
+	b.MethodCall(b, "GetJujuSecret", name)
+	val, ok := b.content[name]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", name)
+	}
+	return val, b.NextErr()
+}
+
+func (b *fakeBroker) DeleteJujuSecret(name string) error {
+	b.MethodCall(b, "DeleteJujuSecret", name)
+	delete(b.content, name)
+	return b.NextErr()
+}
+
+func (s *kubernetesSuite) TestValidateConfig(c *gc.C) {
+	p, err := provider.Provider(kubernetes.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.CredentialAttributes(), gc.HasLen, 0)
+	c.Assert(p.ValidateConfig(nil, nil), jc.ErrorIsNil)
+	err = p.ValidateConfig(map[string]interface{}{"foo": "bar"}, nil)
+	c.Assert(err, gc.ErrorMatches, "kubernetes secret backend config not valid")
+	err = p.ValidateConfig(nil, map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, "kubernetes secret backend config not valid")
+}
+
+func (s *kubernetesSuite) TestNewStoreNoBroker(c *gc.C) {
+	p, err := provider.Provider(kubernetes.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.NewStore(&provider.ModelBackendConfig{ModelUUID: coretesting.ModelTag.Id()})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *kubernetesSuite) TestSaveGetDelete(c *gc.C) {
+	broker := &fakeBroker{content: make(map[string]secrets.SecretValue)}
+	p, err := provider.Provider(kubernetes.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	store, err := p.NewStore(&provider.ModelBackendConfig{
+		ModelUUID: coretesting.ModelTag.Id(),
+		NewBroker: func() (caas.Broker, error) { return broker, nil },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	value := secrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	id, err := store.SaveContent(uri, value)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "juju-secret-"+uri.ID)
+
+	val, err := store.GetContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val, jc.DeepEquals, value)
+
+	err = store.DeleteContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = store.GetContent(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	broker.CheckCallNames(c, "SaveJujuSecret", "GetJujuSecret", "DeleteJujuSecret", "GetJujuSecret")
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/secrets"
+)
+
+// Internal is the backend type used when secret content is
+// stored in the controller database alongside its metadata.
+const Internal = secrets.InternalBackend
+
+// SecretsStore instances hold secret content on behalf of a model.
+type SecretsStore interface {
+	// SaveContent saves new content for the specified secret and
+	// returns the id used to refer to it in the store.
+	SaveContent(uri *secrets.URI, value secrets.SecretValue) (string, error)
+
+	// GetContent returns the content with the specified id.
+	GetContent(revisionID string) (secrets.SecretValue, error)
+
+	// DeleteContent removes the content with the specified id.
+	// It is not an error if the content does not exist.
+	DeleteContent(revisionID string) error
+}
+
+// ModelBackendConfig holds what is needed to create
+// a secrets store for a model.
+type ModelBackendConfig struct {
+	// ModelUUID is the UUID of the model whose secrets are stored.
+	ModelUUID string
+
+	// Config holds the backend specific attributes.
+	Config map[string]interface{}
+
+	// Credential holds the backend specific secret attributes,
+	// such as authentication tokens. They are stored apart from
+	// the model config and are never returned to clients.
+	Credential map[string]string
+
+	// NewBroker returns the model's CAAS broker, for backends
+	// which store content in the model's cloud.
+	NewBroker func() (caas.Broker, error)
+}
+
+// SecretStoreProvider instances create secrets stores
+// of a particular backend type.
+type SecretStoreProvider interface {
+	// Type returns the backend type.
+	Type() string
+
+	// CredentialAttributes returns the names of the backend
+	// specific attributes which are held in the credential
+	// rather than in the model config.
+	CredentialAttributes() []string
+
+	// ValidateConfig returns an error if the backend
+	// specific attributes and credential are not valid.
+	ValidateConfig(cfg map[string]interface{}, credential map[string]string) error
+
+	// NewStore returns a secrets store for the model.
+	NewStore(cfg *ModelBackendConfig) (SecretsStore, error)
+}
+
+var (
+	mu        sync.Mutex
+	providers = make(map[string]SecretStoreProvider)
+)
+
+// Register registers a secret store provider. It panics if
+// a provider of the same type is already registered.
+// The returned function is used by tests to unregister the provider.
+func Register(p SecretStoreProvider) (unregister func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	backendType := p.Type()
+	if backendType == Internal {
+		panic(fmt.Errorf("juju: secret backend type %q is reserved", backendType))
+	}
+	if _, ok := providers[backendType]; ok {
+		panic(fmt.Errorf("juju: duplicate secret backend type %q", backendType))
+	}
+	providers[backendType] = p
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(providers, backendType)
+	}
+}
+
+// Provider returns the secret store provider for the specified backend type.
+func Provider(backendType string) (SecretStoreProvider, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	p, ok := providers[backendType]
+	if !ok {
+		return nil, errors.NotFoundf("secret backend %q", backendType)
+	}
+	return p, nil
+}
+
+// BackendTypes returns the registered backend types,
+// including the internal backend.
+func BackendTypes() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	result := []string{Internal}
+	for t := range providers {
+		result = append(result, t)
+	}
+	sort.Strings(result)
+	return result
+}
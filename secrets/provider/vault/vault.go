@@ -0,0 +1,261 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package vault implements a secret backend which stores
+// secret content in a HashiCorp Vault KV version 2 engine.
+package vault
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/schema"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+)
+
+var logger = loggo.GetLogger("juju.secrets.provider.vault")
+
+const (
+	// BackendType is the type of the Vault secret backend.
+	BackendType = secrets.VaultBackend
+
+	// EndpointKey is the URL of the Vault server.
+	EndpointKey = "endpoint"
+
+	// TokenKey is the token used to authenticate to Vault.
+	// It is held in the backend credential, not the model config.
+	TokenKey = "token"
+
+	// MountPathKey is the path at which the KV version 2
+	// secrets engine is mounted.
+	MountPathKey = "mount-path"
+
+	// NamespaceKey is the Vault Enterprise namespace to use, if any.
+	NamespaceKey = "namespace"
+
+	// CACertKey is the PEM encoded CA certificate used to
+	// verify the Vault server's certificate.
+	CACertKey = "ca-cert"
+
+	defaultMountPath = "secret"
+)
+
+var configFields = schema.Fields{
+	EndpointKey:  schema.String(),
+	MountPathKey: schema.String(),
+	NamespaceKey: schema.String(),
+	CACertKey:    schema.String(),
+}
+
+var configDefaults = schema.Defaults{
+	MountPathKey: defaultMountPath,
+	NamespaceKey: "",
+	CACertKey:    "",
+}
+
+var configChecker = schema.FieldMap(configFields, configDefaults)
+
+func init() {
+	provider.Register(vaultProvider{})
+}
+
+type vaultProvider struct{}
+
+// Type implements provider.SecretStoreProvider.
+func (vaultProvider) Type() string {
+	return BackendType
+}
+
+// CredentialAttributes implements provider.SecretStoreProvider.
+func (vaultProvider) CredentialAttributes() []string {
+	return []string{TokenKey}
+}
+
+// ValidateConfig implements provider.SecretStoreProvider.
+func (vaultProvider) ValidateConfig(cfg map[string]interface{}, credential map[string]string) error {
+	_, err := parseConfig(cfg, credential)
+	return errors.Trace(err)
+}
+
+// NewStore implements provider.SecretStoreProvider.
+func (vaultProvider) NewStore(cfg *provider.ModelBackendConfig) (provider.SecretsStore, error) {
+	vcfg, err := parseConfig(cfg.Config, cfg.Credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newStore(cfg.ModelUUID, vcfg)
+}
+
+type vaultConfig struct {
+	endpoint  string
+	token     string
+	mountPath string
+	namespace string
+	caCert    string
+}
+
+func parseConfig(attrs map[string]interface{}, credential map[string]string) (*vaultConfig, error) {
+	if _, ok := attrs[TokenKey]; ok {
+		return nil, errors.NotValidf("vault token in model config")
+	}
+	coerced, err := configChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating vault config")
+	}
+	m := coerced.(map[string]interface{})
+	cfg := &vaultConfig{
+		endpoint:  m[EndpointKey].(string),
+		token:     credential[TokenKey],
+		mountPath: strings.Trim(m[MountPathKey].(string), "/"),
+		namespace: m[NamespaceKey].(string),
+		caCert:    m[CACertKey].(string),
+	}
+	u, err := url.Parse(cfg.endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing vault endpoint")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.NotValidf("vault endpoint %q", cfg.endpoint)
+	}
+	if cfg.token == "" {
+		return nil, errors.NotValidf("empty vault token")
+	}
+	if cfg.mountPath == "" {
+		return nil, errors.NotValidf("empty vault mount path")
+	}
+	return cfg, nil
+}
+
+type vaultStore struct {
+	modelUUID string
+	cfg       *vaultConfig
+	client    *http.Client
+}
+
+func newStore(modelUUID string, cfg *vaultConfig) (*vaultStore, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.caCert)) {
+			return nil, errors.NotValidf("vault CA certificate")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &vaultStore{
+		modelUUID: modelUUID,
+		cfg:       cfg,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		},
+	}, nil
+}
+
+// contentPath returns the path, relative to the mount point,
+// under which content with the specified id is stored.
+func (s *vaultStore) contentPath(revisionID string) string {
+	return path.Join(s.modelUUID, revisionID)
+}
+
+type kvData struct {
+	Data map[string]string `json:"data"`
+}
+
+type kvReadResponse struct {
+	Data kvData `json:"data"`
+}
+
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// SaveContent implements provider.SecretsStore.
+func (s *vaultStore) SaveContent(uri *secrets.URI, value secrets.SecretValue) (string, error) {
+	revisionID := path.Join(uri.ID, utils.MustNewUUID().String())
+	body, err := json.Marshal(kvData{Data: value.EncodedValues()})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := s.do(http.MethodPost, "data", revisionID, bytes.NewReader(body)); err != nil {
+		return "", errors.Annotatef(err, "saving content for secret %q", uri)
+	}
+	logger.Debugf("saved content for secret %q as %q", uri, revisionID)
+	return revisionID, nil
+}
+
+// GetContent implements provider.SecretsStore.
+func (s *vaultStore) GetContent(revisionID string) (secrets.SecretValue, error) {
+	body, err := s.do(http.MethodGet, "data", revisionID, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting secret content %q", revisionID)
+	}
+	var resp kvReadResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Annotatef(err, "parsing secret content %q", revisionID)
+	}
+	return secrets.NewSecretValue(resp.Data.Data), nil
+}
+
+// DeleteContent implements provider.SecretsStore.
+func (s *vaultStore) DeleteContent(revisionID string) error {
+	// Deleting the metadata removes all versions of the content.
+	_, err := s.do(http.MethodDelete, "metadata", revisionID, nil)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "deleting secret content %q", revisionID)
+}
+
+func (s *vaultStore) do(method, kind, revisionID string, body io.Reader) ([]byte, error) {
+	u := fmt.Sprintf("%s/v1/%s/%s/%s",
+		strings.TrimRight(s.cfg.endpoint, "/"), s.cfg.mountPath, kind, s.contentPath(revisionID))
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("X-Vault-Token", s.cfg.token)
+	if s.cfg.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.cfg.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.NotFoundf("secret content %q", revisionID)
+	case resp.StatusCode == http.StatusForbidden:
+		return nil, errors.Unauthorizedf("vault %s %q", strings.ToLower(method), revisionID)
+	case resp.StatusCode >= 300:
+		var errResp errorResponse
+		_ = json.Unmarshal(respBody, &errResp)
+		if len(errResp.Errors) > 0 {
+			return nil, errors.Errorf("vault error: %s", strings.Join(errResp.Errors, "; "))
+		}
+		return nil, errors.Errorf("vault error: %s", resp.Status)
+	}
+	return respBody, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vault_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/vault"
+	"github.com/juju/juju/secrets/provider/vault/vaulttest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type vaultSuite struct {
+	server *vaulttest.Server
+}
+
+var _ = gc.Suite(&vaultSuite{})
+
+func (s *vaultSuite) SetUpTest(c *gc.C) {
+	s.server = vaulttest.NewServer()
+}
+
+func (s *vaultSuite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+func (s *vaultSuite) newStore(c *gc.C, token string) provider.SecretsStore {
+	p, err := provider.Provider(vault.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	store, err := p.NewStore(&provider.ModelBackendConfig{
+		ModelUUID: coretesting.ModelTag.Id(),
+		Config: map[string]interface{}{
+			vault.EndpointKey: s.server.URL,
+		},
+		Credential: map[string]string{
+			vault.TokenKey: token,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return store
+}
+
+func (s *vaultSuite) TestValidateConfig(c *gc.C) {
+	p, err := provider.Provider(vault.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.CredentialAttributes(), jc.DeepEquals, []string{"token"})
+	token := map[string]string{"token": "foo"}
+	for i, t := range []struct {
+		cfg        map[string]interface{}
+		credential map[string]string
+		err        string
+	}{{
+		cfg:        map[string]interface{}{"endpoint": "http://vault:8200"},
+		credential: token,
+	}, {
+		credential: token,
+		err:        `validating vault config: endpoint: expected string, got nothing`,
+	}, {
+		cfg:        map[string]interface{}{"endpoint": "ftp://vault"},
+		credential: token,
+		err:        `vault endpoint "ftp://vault" not valid`,
+	}, {
+		cfg: map[string]interface{}{"endpoint": "http://vault:8200"},
+		err: `empty vault token not valid`,
+	}, {
+		cfg:        map[string]interface{}{"endpoint": "http://vault:8200", "token": "foo"},
+		credential: token,
+		err:        `vault token in model config not valid`,
+	}, {
+		cfg:        map[string]interface{}{"endpoint": "http://vault:8200", "mount-path": "/"},
+		credential: token,
+		err:        `empty vault mount path not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := p.ValidateConfig(t.cfg, t.credential)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *vaultSuite) TestSaveGetDelete(c *gc.C) {
+	store := s.newStore(c, vaulttest.RootToken)
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	id, err := store.SaveContent(uri, secrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.HasPrefix(id, uri.ID+"/"), jc.IsTrue)
+	c.Assert(s.server.Paths(), jc.SameContents, []string{coretesting.ModelTag.Id() + "/" + id})
+
+	val, err := store.GetContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	err = store.DeleteContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.Paths(), gc.HasLen, 0)
+	_, err = store.GetContent(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Deleting missing content is not an error.
+	err = store.DeleteContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *vaultSuite) TestBadToken(c *gc.C) {
+	store := s.newStore(c, "bad-token")
+	uri := secrets.NewURI(coretesting.ModelTag.Id())
+	_, err := store.SaveContent(uri, secrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
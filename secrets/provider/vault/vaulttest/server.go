@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package vaulttest provides an in-memory stand-in for a Vault
+// server running in dev mode, for use in tests.
+package vaulttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// RootToken is the token accepted by the dev server.
+const RootToken = "dev-root-token"
+
+// Server emulates the subset of the Vault HTTP API used by the
+// vault secret backend: a KV version 2 engine mounted at "secret".
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	content map[string]map[string]string
+}
+
+// NewServer starts and returns a new dev server.
+// The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{content: make(map[string]map[string]string)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Paths returns the paths, relative to the mount point,
+// of the content held by the server.
+func (s *Server) Paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []string
+	for p := range s.content {
+		result = append(result, p)
+	}
+	return result
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("X-Vault-Token") != RootToken {
+		writeErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+	parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/v1/secret/"), "/", 2)
+	if len(parts) != 2 || !strings.HasPrefix(req.URL.Path, "/v1/secret/") {
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+	kind, p := parts[0], parts[1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case kind == "data" && (req.Method == http.MethodPost || req.Method == http.MethodPut):
+		var body struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.content[p] = body.Data
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":{"version":1}}`))
+	case kind == "data" && req.Method == http.MethodGet:
+		data, ok := s.content[p]
+		if !ok {
+			writeErrors(w, http.StatusNotFound)
+			return
+		}
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     data,
+				"metadata": map[string]interface{}{"version": 1},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	case kind == "metadata" && req.Method == http.MethodDelete:
+		delete(s.content, p)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, "unsupported operation")
+	}
+}
+
+func writeErrors(w http.ResponseWriter, code int, errs ...string) {
+	if errs == nil {
+		errs = []string{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string][]string{"errors": errs})
+}
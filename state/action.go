@@ -16,6 +16,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/actions"
 	stateerrors "github.com/juju/juju/state/errors"
 )
 
@@ -129,12 +130,18 @@ type actionDoc struct {
 
 	// Logs holds the progress messages logged by the action.
 	Logs []ActionMessage `bson:"messages"`
+
+	// Output holds the output written by the action to stdout
+	// and stderr while it runs.
+	Output []ActionMessage `bson:"output,omitempty"`
 }
 
-// ActionMessage represents a progress message logged by an action.
+// ActionMessage represents a progress message logged by an action,
+// or output it wrote to stdout or stderr.
 type ActionMessage struct {
 	MessageValue   string    `bson:"message"`
 	TimestampValue time.Time `bson:"timestamp"`
+	StreamValue    string    `bson:"stream,omitempty"`
 }
 
 // Timestamp returns the message timestamp.
@@ -147,6 +154,12 @@ func (m ActionMessage) Message() string {
 	return m.MessageValue
 }
 
+// Stream returns the stream the output was written to,
+// or "" for a progress message.
+func (m ActionMessage) Stream() string {
+	return m.StreamValue
+}
+
 // action represents an instruction to do some "action" and is expected
 // to match an action definition in a charm.
 type action struct {
@@ -495,18 +508,52 @@ func (a *action) Messages() []ActionMessage {
 	return result
 }
 
+// Output returns the output written by the action while it ran.
+func (a *action) Output() []ActionMessage {
+	result := make([]ActionMessage, len(a.doc.Output))
+	for i, m := range a.doc.Output {
+		result[i] = ActionMessage{
+			MessageValue:   m.MessageValue,
+			TimestampValue: m.TimestampValue.UTC(),
+			StreamValue:    m.StreamValue,
+		}
+	}
+	return result
+}
+
 // Log adds message to the action's progress message array.
 func (a *action) Log(message string) error {
+	return a.appendMessage("messages", "message", len(a.doc.Logs), ActionMessage{
+		MessageValue: message,
+	})
+}
+
+// LogOutput adds output written by the action to stdout or stderr
+// to the action's output array.
+func (a *action) LogOutput(stream, output string) error {
+	if !actions.IsValidStream(stream) {
+		return errors.NotValidf("output stream %q", stream)
+	}
+	return a.appendMessage("output", "output", len(a.doc.Output), ActionMessage{
+		MessageValue: output,
+		StreamValue:  stream,
+	})
+}
+
+// appendMessage adds the message, timestamped now, to the named array
+// field of the action's document, which currently holds count entries.
+func (a *action) appendMessage(field, kind string, count int, message ActionMessage) error {
 	// Just to ensure we do not allow bad actions to fill up disk.
 	// 1000 messages should be enough for anyone.
-	if len(a.doc.Logs) > 1000 {
-		logger.Warningf("exceeded 1000 log messages, action may be stuck")
+	if count > 1000 {
+		logger.Warningf("exceeded 1000 log %s entries, action may be stuck", kind)
 		return nil
 	}
 	m, err := a.st.Model()
 	if err != nil {
 		return errors.Trace(err)
 	}
+	message.TimestampValue = a.st.nowToTheSecond().UTC()
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			anAction, err := m.Action(a.Id())
@@ -516,7 +563,7 @@ func (a *action) Log(message string) error {
 			a = anAction.(*action)
 		}
 		if s := a.Status(); s != ActionRunning && s != ActionAborting {
-			return nil, errors.Errorf("cannot log message to task %q with status %v", a.Id(), s)
+			return nil, errors.Errorf("cannot log %s to task %q with status %v", kind, a.Id(), s)
 		}
 		ops := []txn.Op{
 			{
@@ -527,7 +574,7 @@ func (a *action) Log(message string) error {
 					{{"status", ActionAborting}},
 				}}},
 				Update: bson.D{{"$push", bson.D{
-					{"messages", ActionMessage{MessageValue: message, TimestampValue: a.st.nowToTheSecond().UTC()}},
+					{field, message},
 				}}},
 			}}
 		return ops, nil
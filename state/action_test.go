@@ -491,6 +491,44 @@ func (s *ActionSuite) TestActionLogMessageRace(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot log message to task "2" with status completed`)
 }
 
+func (s *ActionSuite) TestActionOutput(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(anAction.Output(), gc.HasLen, 0)
+
+	// Cannot log output until action is running.
+	err = anAction.LogOutput("stdout", "hello\n")
+	c.Assert(err, gc.ErrorMatches, `cannot log output to task "2" with status pending`)
+
+	anAction, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stdout", "hello\n")
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stderr", "oops\n")
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stdin", "hello\n")
+	c.Assert(err, gc.ErrorMatches, `output stream "stdin" not valid`)
+
+	a, err := s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 0)
+	c.Assert(a.Output(), jc.DeepEquals, []state.ActionMessage{{
+		MessageValue:   "hello\n",
+		TimestampValue: clock.Now().UTC(),
+		StreamValue:    "stdout",
+	}, {
+		MessageValue:   "oops\n",
+		TimestampValue: clock.Now().UTC(),
+		StreamValue:    "stderr",
+	}})
+}
+
 // makeUnits prepares units with given Action schemas
 func makeUnits(c *gc.C, s *ActionSuite, units map[string]*state.Unit, schemas map[string]string) {
 	// A few dummy charms that haven't been used yet
@@ -1201,6 +1239,69 @@ func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
 	checkExpected(wc2, expected)
 }
 
+func (s *ActionSuite) TestWatchTaskEvents(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	now := clock.Now().UTC()
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	fa1, err := s.unit.AddAction(operationID, "snapshot", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	event := func(e actions.TaskEvent) string {
+		e.Timestamp = now
+		data, err := json.Marshal(e)
+		c.Assert(err, jc.ErrorIsNil)
+		return string(data)
+	}
+
+	w := s.State.WatchTaskEvents(fa1.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(event(actions.TaskEvent{Status: "pending"}))
+	wc.AssertNoChange()
+
+	// The status change is reported before the task's output.
+	fa1, err = fa1.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.Log("starting")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.LogOutput("stdout", "hello\n")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(
+		event(actions.TaskEvent{Status: "running"}),
+		event(actions.TaskEvent{Message: "starting"}),
+		event(actions.TaskEvent{Message: "hello\n", Stream: "stdout"}),
+	)
+	wc.AssertNoChange()
+
+	// Output is reported before the task finishes.
+	err = fa1.LogOutput("stderr", "oops\n")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = fa1.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(
+		event(actions.TaskEvent{Message: "oops\n", Stream: "stderr"}),
+		event(actions.TaskEvent{Status: "failed"}),
+	)
+	wc.AssertNoChange()
+
+	// A new watcher reports all the events so far.
+	w2 := s.State.WatchTaskEvents(fa1.Id())
+	defer statetesting.AssertStop(c, w2)
+	wc2 := statetesting.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChange(
+		event(actions.TaskEvent{Status: "running"}),
+		event(actions.TaskEvent{Message: "starting"}),
+		event(actions.TaskEvent{Message: "hello\n", Stream: "stdout"}),
+		event(actions.TaskEvent{Message: "oops\n", Stream: "stderr"}),
+		event(actions.TaskEvent{Status: "failed"}),
+	)
+	wc2.AssertNoChange()
+}
+
 func (s *ActionSuite) TestWatchActionResults(c *gc.C) {
 	w := s.Model.WatchActionResultsFilteredBy(s.unit)
 	defer statetesting.AssertStop(c, w)
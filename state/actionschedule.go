@@ -0,0 +1,362 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cron"
+)
+
+// MaxActionScheduleRuns is the number of runs recorded
+// in the history of each action schedule.
+const MaxActionScheduleRuns = 20
+
+var validActionScheduleName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// actionScheduleDoc holds a schedule on which an action is enqueued.
+type actionScheduleDoc struct {
+	DocID     string `bson:"_id"`
+	Name      string `bson:"name"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Target is the application, unit or application leader
+	// ("<application>/leader") the action is run on.
+	Target     string                 `bson:"target"`
+	Action     string                 `bson:"action"`
+	Parameters map[string]interface{} `bson:"parameters,omitempty"`
+	Cron       string                 `bson:"cron"`
+	Paused     bool                   `bson:"paused"`
+	Owner      string                 `bson:"owner"`
+	Created    time.Time              `bson:"created"`
+
+	// Runs holds the most recent runs of the schedule,
+	// oldest first.
+	Runs     []actionScheduleRunDoc `bson:"runs,omitempty"`
+	TxnRevno int64                  `bson:"txn-revno"`
+}
+
+type actionScheduleRunDoc struct {
+	Scheduled   time.Time `bson:"scheduled"`
+	Enqueued    time.Time `bson:"enqueued"`
+	OperationID string    `bson:"operation,omitempty"`
+	Error       string    `bson:"error,omitempty"`
+}
+
+// ActionSchedule is a schedule on which an action is run on an
+// application's units, one of its units, or its leader.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// ActionScheduleRun records a run of an action schedule.
+type ActionScheduleRun struct {
+	// Scheduled is the time the run was due.
+	Scheduled time.Time
+
+	// Enqueued is the time the run's operation was enqueued.
+	Enqueued time.Time
+
+	// OperationID identifies the operation holding the run's tasks,
+	// if one was enqueued.
+	OperationID string
+
+	// Error describes why the run's operation couldn't be enqueued.
+	Error string
+}
+
+// Name returns the name of the schedule, which is unique within
+// the model.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Target returns the application, unit or application leader
+// ("<application>/leader") the action is run on.
+func (s *ActionSchedule) Target() string {
+	return s.doc.Target
+}
+
+// Action returns the name of the action run by the schedule.
+func (s *ActionSchedule) Action() string {
+	return s.doc.Action
+}
+
+// Parameters returns the parameters the action is run with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Cron returns the cron expression describing when the action is run.
+func (s *ActionSchedule) Cron() string {
+	return s.doc.Cron
+}
+
+// Paused returns whether runs of the schedule are paused.
+func (s *ActionSchedule) Paused() bool {
+	return s.doc.Paused
+}
+
+// Owner returns the name of the user who added the schedule.
+func (s *ActionSchedule) Owner() string {
+	return s.doc.Owner
+}
+
+// Created returns when the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// Runs returns the most recent runs of the schedule, oldest first.
+func (s *ActionSchedule) Runs() []ActionScheduleRun {
+	runs := make([]ActionScheduleRun, len(s.doc.Runs))
+	for i, doc := range s.doc.Runs {
+		runs[i] = ActionScheduleRun{
+			Scheduled:   doc.Scheduled,
+			Enqueued:    doc.Enqueued,
+			OperationID: doc.OperationID,
+			Error:       doc.Error,
+		}
+	}
+	return runs
+}
+
+// LastRun returns the time the most recent run of the schedule
+// was due, and false if it has never been run.
+func (s *ActionSchedule) LastRun() (time.Time, bool) {
+	if len(s.doc.Runs) == 0 {
+		return time.Time{}, false
+	}
+	return s.doc.Runs[len(s.doc.Runs)-1].Scheduled, true
+}
+
+// Refresh refreshes the contents of the schedule from the
+// underlying state.
+func (s *ActionSchedule) Refresh() error {
+	doc, err := s.st.actionScheduleDoc(s.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc = *doc
+	return nil
+}
+
+// SetPaused pauses or resumes runs of the schedule.
+func (s *ActionSchedule) SetPaused(paused bool) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"paused", paused}}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(
+			onAbort(err, errors.NotFoundf("action schedule %q", s.doc.Name)),
+			"cannot update action schedule %q", s.doc.Name,
+		)
+	}
+	s.doc.Paused = paused
+	return nil
+}
+
+// RecordRun adds the run to the schedule's history, dropping the
+// oldest runs to keep no more than MaxActionScheduleRuns. Runs must
+// be recorded in the order they were due; an error satisfying
+// errors.IsAlreadyExists is returned if a run due at the same time or
+// later has already been recorded.
+func (s *ActionSchedule) RecordRun(run ActionScheduleRun) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if last, ok := s.LastRun(); ok && !run.Scheduled.After(last) {
+			return nil, errors.AlreadyExistsf("run of action schedule %q due at %s", s.doc.Name, run.Scheduled.UTC().Format(time.RFC3339))
+		}
+		runs := append(s.doc.Runs, actionScheduleRunDoc{
+			Scheduled:   run.Scheduled.UTC(),
+			Enqueued:    run.Enqueued.UTC(),
+			OperationID: run.OperationID,
+			Error:       run.Error,
+		})
+		if len(runs) > MaxActionScheduleRuns {
+			runs = runs[len(runs)-MaxActionScheduleRuns:]
+		}
+		return []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     s.doc.DocID,
+			Assert: bson.D{{"txn-revno", s.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"runs", runs}}}},
+		}}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record run of action schedule %q", s.doc.Name)
+	}
+	return errors.Trace(s.Refresh())
+}
+
+// Remove removes the schedule. Operations already enqueued by the
+// schedule are not affected.
+func (s *ActionSchedule) Remove() error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocID,
+		Remove: true,
+	}}
+	err := s.st.db().RunTransaction(ops)
+	return errors.Annotatef(err, "cannot remove action schedule %q", s.doc.Name)
+}
+
+// AddActionScheduleArgs holds the arguments to AddActionSchedule.
+type AddActionScheduleArgs struct {
+	// Name is the name of the schedule, unique within the model.
+	Name string
+
+	// Target is the application, unit or application leader
+	// ("<application>/leader") the action is run on.
+	Target string
+
+	// Action is the name of the action to run.
+	Action string
+
+	// Parameters are the parameters the action is run with.
+	Parameters map[string]interface{}
+
+	// Cron is the cron expression describing when the action is run.
+	Cron string
+
+	// Owner is the name of the user adding the schedule.
+	Owner string
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args AddActionScheduleArgs) Validate() error {
+	if !validActionScheduleName.MatchString(args.Name) {
+		return errors.NotValidf("action schedule name %q", args.Name)
+	}
+	if err := validateActionScheduleTarget(args.Target); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Action == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if _, err := cron.Parse(args.Cron); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ActionScheduleTargetApplication returns the application
+// whose units are targeted by an action schedule.
+func ActionScheduleTargetApplication(target string) string {
+	return strings.Split(target, "/")[0]
+}
+
+func validateActionScheduleTarget(target string) error {
+	if names.IsValidApplication(target) || names.IsValidUnit(target) {
+		return nil
+	}
+	if app := strings.TrimSuffix(target, "/leader"); app != target && names.IsValidApplication(app) {
+		return nil
+	}
+	return errors.NotValidf("action schedule target %q", target)
+}
+
+// AddActionSchedule adds a schedule on which an action is enqueued.
+func (m *Model) AddActionSchedule(args AddActionScheduleArgs) (*ActionSchedule, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := actionScheduleDoc{
+		DocID:      m.st.docID(args.Name),
+		Name:       args.Name,
+		ModelUUID:  m.UUID(),
+		Target:     args.Target,
+		Action:     args.Action,
+		Parameters: args.Parameters,
+		Cron:       args.Cron,
+		Owner:      args.Owner,
+		Created:    m.st.clock().Now().UTC().Round(time.Second),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkModelActive(m.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := m.st.actionScheduleDoc(args.Name); err == nil {
+			return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{
+			m.assertActiveOp(),
+			{
+				C:      actionSchedulesC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			},
+		}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot add action schedule %q", args.Name)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the named action schedule.
+func (m *Model) ActionSchedule(name string) (*ActionSchedule, error) {
+	doc, err := m.st.actionScheduleDoc(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: m.st, doc: *doc}, nil
+}
+
+// AllActionSchedules returns all the action schedules
+// in the model, ordered by name.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	schedules := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		schedules[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return schedules, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher which fires when
+// any action schedule in the model is added, changed or removed.
+func (m *Model) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, actionSchedulesC, isLocalID(m.st))
+}
+
+func (st *State) actionScheduleDoc(name string) (*actionScheduleDoc, error) {
+	coll, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &doc, nil
+}
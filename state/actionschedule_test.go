@@ -0,0 +1,220 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, name string) *state.ActionSchedule {
+	schedule, err := s.Model.AddActionSchedule(state.AddActionScheduleArgs{
+		Name:       name,
+		Target:     "mysql/leader",
+		Action:     "backup",
+		Parameters: map[string]interface{}{"outfile": "out.tar.gz"},
+		Cron:       "0 3 * * *",
+		Owner:      "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	s.addSchedule(c, "nightly-backup")
+
+	schedule, err := s.Model.ActionSchedule("nightly-backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Name(), gc.Equals, "nightly-backup")
+	c.Check(schedule.Target(), gc.Equals, "mysql/leader")
+	c.Check(schedule.Action(), gc.Equals, "backup")
+	c.Check(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.tar.gz"})
+	c.Check(schedule.Cron(), gc.Equals, "0 3 * * *")
+	c.Check(schedule.Paused(), jc.IsFalse)
+	c.Check(schedule.Owner(), gc.Equals, "admin")
+	c.Check(schedule.Created(), gc.Equals, s.clock.Now().UTC())
+	c.Check(schedule.Runs(), gc.HasLen, 0)
+	_, ok := schedule.LastRun()
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleAlreadyExists(c *gc.C) {
+	s.addSchedule(c, "nightly-backup")
+	_, err := s.Model.AddActionSchedule(state.AddActionScheduleArgs{
+		Name:   "nightly-backup",
+		Target: "mysql",
+		Action: "compact",
+		Cron:   "@daily",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add action schedule "nightly-backup": action schedule "nightly-backup" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.AddActionScheduleArgs
+		err  string
+	}{{
+		args: state.AddActionScheduleArgs{Name: "Nightly", Target: "mysql", Action: "backup", Cron: "@daily"},
+		err:  `action schedule name "Nightly" not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "nightly", Target: "mysql/x", Action: "backup", Cron: "@daily"},
+		err:  `action schedule target "mysql/x" not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "nightly", Target: "mysql/0", Cron: "@daily"},
+		err:  `empty action name not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "nightly", Target: "mysql/0", Action: "backup", Cron: "0 3 * *"},
+		err:  `cron expression "0 3 \* \*": expected 5 fields, got 4 not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.Model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAllActionSchedules(c *gc.C) {
+	s.addSchedule(c, "weekly")
+	s.addSchedule(c, "daily")
+
+	schedules, err := s.Model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Check(schedules[0].Name(), gc.Equals, "daily")
+	c.Check(schedules[1].Name(), gc.Equals, "weekly")
+}
+
+func (s *ActionScheduleSuite) TestSetPaused(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly-backup")
+
+	err := schedule.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Paused(), jc.IsTrue)
+
+	schedule, err = s.Model.ActionSchedule("nightly-backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Paused(), jc.IsTrue)
+
+	err = schedule.SetPaused(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Paused(), jc.IsFalse)
+}
+
+func (s *ActionScheduleSuite) TestRemove(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly-backup")
+
+	err := schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.Model.ActionSchedule("nightly-backup")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = schedule.SetPaused(true)
+	c.Assert(err, gc.ErrorMatches, `cannot update action schedule "nightly-backup": action schedule "nightly-backup" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRecordRun(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly-backup")
+	due := s.clock.Now().UTC()
+
+	err := schedule.RecordRun(state.ActionScheduleRun{
+		Scheduled:   due,
+		Enqueued:    due.Add(time.Second),
+		OperationID: "1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.RecordRun(state.ActionScheduleRun{
+		Scheduled: due.Add(time.Hour),
+		Enqueued:  due.Add(time.Hour),
+		Error:     `could not determine leader for "mysql"`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err = s.Model.ActionSchedule("nightly-backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Runs(), jc.DeepEquals, []state.ActionScheduleRun{{
+		Scheduled:   due,
+		Enqueued:    due.Add(time.Second),
+		OperationID: "1",
+	}, {
+		Scheduled: due.Add(time.Hour),
+		Enqueued:  due.Add(time.Hour),
+		Error:     `could not determine leader for "mysql"`,
+	}})
+	last, ok := schedule.LastRun()
+	c.Check(ok, jc.IsTrue)
+	c.Check(last, gc.Equals, due.Add(time.Hour))
+}
+
+func (s *ActionScheduleSuite) TestRecordRunAlreadyRun(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly-backup")
+	due := s.clock.Now().UTC()
+	err := schedule.RecordRun(state.ActionScheduleRun{Scheduled: due, Enqueued: due})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = schedule.RecordRun(state.ActionScheduleRun{Scheduled: due, Enqueued: due})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionScheduleSuite) TestRecordRunKeepsRecentRuns(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly-backup")
+	due := s.clock.Now().UTC()
+	for i := 0; i < state.MaxActionScheduleRuns+5; i++ {
+		err := schedule.RecordRun(state.ActionScheduleRun{
+			Scheduled:   due.Add(time.Duration(i) * time.Hour),
+			Enqueued:    due,
+			OperationID: fmt.Sprint(i),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	runs := schedule.Runs()
+	c.Assert(runs, gc.HasLen, state.MaxActionScheduleRuns)
+	c.Check(runs[0].OperationID, gc.Equals, "5")
+	c.Check(runs[len(runs)-1].OperationID, gc.Equals, fmt.Sprint(state.MaxActionScheduleRuns+4))
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.Model.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schedule := s.addSchedule(c, "nightly-backup")
+	wc.AssertOneChange()
+
+	err := schedule.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -81,6 +81,25 @@ func allCollections() CollectionSchema {
 		// controller from backup.
 		restoreInfoC: {global: true},
 
+		// This collection holds the audit log records written by
+		// every controller.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"kind", "-time"},
+			}, {
+				Key: []string{"kind", "who"},
+			}, {
+				Key: []string{"kind", "model-uuid"},
+			}, {
+				Key: []string{"conversation-id"},
+			}, {
+				// used for pruning
+				Key: []string{"time"},
+			}},
+		},
+
 		// This collection is used by the controllers to coordinate binary
 		// upgrades and schema migrations.
 		upgradeInfoC: {global: true},
@@ -350,6 +369,7 @@ func allCollections() CollectionSchema {
 			}},
 		},
 		filesystemAttachmentsC: {},
+		filesystemUsageC:       {},
 		storageInstancesC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "owner"},
@@ -411,6 +431,7 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "_id"},
 			}},
 		},
+		actionSchedulesC: {},
 
 		// -----
 
@@ -527,6 +548,38 @@ func allCollections() CollectionSchema {
 		// eg addresses.
 		cloudServicesC: {},
 
+		// secretMetadataC holds metadata about charm secrets.
+		secretMetadataC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner-tag"},
+			}},
+		},
+
+		// secretRevisionsC holds the content of each revision
+		// of a charm secret.
+		secretRevisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
+		// secretRotateC holds the rotation schedule of charm
+		// secrets, keyed on the secret owner.
+		secretRotateC: {},
+
+		// secretPermissionsC holds the access granted to
+		// charm secrets.
+		secretPermissionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
+		// secretBackendCredentialsC holds the credentials used
+		// to access external secret backends, keyed on the
+		// backend type.
+		secretBackendCredentialsC: {},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -544,11 +597,13 @@ func allCollections() CollectionSchema {
 // inspection.
 const (
 	actionNotificationsC       = "actionnotifications"
+	actionSchedulesC           = "actionschedules"
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
 	assignUnitC                = "assignUnits"
+	auditLogC                  = "auditlog"
 	bakeryStorageItemsC        = "bakeryStorageItems"
 	blockDevicesC              = "blockdevices"
 	blocksC                    = "blocks"
@@ -567,6 +622,7 @@ const (
 	dockerResourcesC           = "dockerResources"
 	filesystemAttachmentsC     = "filesystemAttachments"
 	filesystemsC               = "filesystems"
+	filesystemUsageC           = "filesystemusage"
 	globalClockC               = "globalclock"
 	globalRefcountsC           = "globalRefcounts"
 	globalSettingsC            = "globalSettings"
@@ -599,6 +655,11 @@ const (
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	secretBackendCredentialsC  = "secretBackendCredentials"
+	secretMetadataC            = "secretMetadata"
+	secretPermissionsC         = "secretPermissions"
+	secretRevisionsC           = "secretRevisions"
+	secretRotateC              = "secretRotate"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
@@ -518,7 +518,7 @@ func (op *DestroyApplicationOperation) destroyOps() ([]txn.Op, error) {
 		// application and accumulate all operational errors encountered in the operation.
 		// If the 'force' is not set and the call came across some errors,
 		// these errors will be fatal and no operations will be returned.
-		removeOps, err := op.app.removeOps(assertion, &op.ForcedOperation)
+		removeOps, err := op.app.removeOps(assertion, &op.ForcedOperation, op.DestroyStorage)
 		if err != nil {
 			if !op.Force || errors.Cause(err) == errRefresh {
 				return nil, errors.Trace(err)
@@ -619,7 +619,7 @@ func removeResourcesOps(st *State, applicationID string) ([]txn.Op, error) {
 // When 'force' is set, this call will return operations to remove this
 // application and will accumulate all operational errors encountered in the operation.
 // If the 'force' is not set, any error will be fatal and no operations will be returned.
-func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op, error) {
+func (a *Application) removeOps(asserts bson.D, op *ForcedOperation, destroyStorage bool) ([]txn.Op, error) {
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
@@ -634,6 +634,26 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove secrets owned by the application.
+	removeSecretsOps, err := removeOwnedSecretsOps(a.st, a.Tag())
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeSecretsOps...)
+
+	// Release, or destroy, the shared storage owned by the application.
+	// By the time we get here all of the units, and so all of the
+	// storage attachments, have been removed.
+	sb, err := NewStorageBackend(a.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	releaseStorageOps, err := releaseSharedStorageOps(sb, a.ApplicationTag(), destroyStorage, op.Force)
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, releaseStorageOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -1363,6 +1383,14 @@ func (a *Application) upgradeStorageOps(
 	// many instances as are specified in the storage constraints.
 	var ops []txn.Op
 	for name, cons := range allStorageCons {
+		if meta.Storage[name].Shared {
+			if _, ok := oldMeta.Storage[name]; !ok {
+				return nil, errors.NotSupportedf("adding shared storage %q on upgrade", name)
+			}
+			// Shared storage is owned by the application, and
+			// existing units are already attached to it.
+			continue
+		}
 		for _, u := range units {
 			countMin := meta.Storage[name].CountMin
 			if _, ok := oldMeta.Storage[name]; !ok {
@@ -2116,6 +2144,10 @@ type applicationAddUnitOpsArgs struct {
 	storageCons   map[string]StorageConstraints
 	attachStorage []names.StorageTag
 
+	// sharedStorage holds the application's shared storage instances,
+	// when they are created in the same transaction as the unit.
+	sharedStorage []*storageInstance
+
 	// These optional attributes are relevant to CAAS models.
 	providerId *string
 	address    *string
@@ -2338,6 +2370,22 @@ func (a *Application) addUnitStorageOps(
 		numStorageAttachments++
 		storageTags[si.StorageName()] = append(storageTags[si.StorageName()], storageTag)
 	}
+
+	// Attach the unit to the application's shared storage. Shared
+	// storage is counted against the application, not the unit.
+	sharedOps, numSharedAttachments, err := sb.attachSharedStorageOps(
+		unitTag,
+		a.doc.Series,
+		charm,
+		args.sharedStorage,
+		machineAssignable,
+	)
+	if err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	storageOps = append(storageOps, sharedOps...)
+	numStorageAttachments += numSharedAttachments
+
 	for name, tags := range storageTags {
 		count := len(tags)
 		charmStorage := charm.Meta().Storage[name]
@@ -4168,6 +4168,61 @@ func (s *ApplicationSuite) TestUnitStatusesWithUnits(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	w := app.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := app.UpdateApplicationConfig(application.ConfigAttributes{
+		"skill-level": 3,
+	}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Charm config changes are not reported.
+	err = app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"blog-title": "sauceror central"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
+func (s *ApplicationSuite) TestWatchRelationChanges(c *gc.C) {
+	w := s.mysql.WatchRelationChanges()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Relations not involving the application are not reported.
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err = s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func sampleApplicationConfigSchema() environschema.Fields {
 	schema := environschema.Fields{
 		"title":       environschema.Attr{Type: environschema.Tstring},
@@ -4493,6 +4548,41 @@ func (s *CAASApplicationSuite) TestServiceInfoEmptyProviderId(c *gc.C) {
 	}
 }
 
+func (s *CAASApplicationSuite) TestSetAutoscalerStatus(c *gc.C) {
+	status := &state.AutoscalerStatus{
+		MinReplicas:     2,
+		MaxReplicas:     5,
+		CurrentReplicas: 3,
+		DesiredReplicas: 4,
+		Message:         "scaling up",
+	}
+	// The cloud service is created if needed.
+	err := s.app.SetAutoscalerStatus(status)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Autoscaler(), jc.DeepEquals, status)
+
+	// Updating the service keeps the autoscaler status.
+	err = s.app.UpdateCloudService("id", network.NewSpaceAddresses("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ProviderId(), gc.Equals, "id")
+	c.Assert(info.Autoscaler(), jc.DeepEquals, status)
+
+	err = s.app.SetAutoscalerStatus(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Autoscaler(), gc.IsNil)
+	c.Assert(info.ProviderId(), gc.Equals, "id")
+
+	// Clearing an absent autoscaler is a no-op.
+	err = s.app.SetAutoscalerStatus(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *CAASApplicationSuite) TestRemoveApplicationDeletesServiceInfo(c *gc.C) {
 	addrs := network.NewSpaceAddresses("10.0.0.1")
 
@@ -4543,6 +4633,44 @@ func (s *CAASApplicationSuite) TestSetScale(c *gc.C) {
 	c.Assert(svcInfo.Generation(), jc.DeepEquals, int64(1))
 }
 
+func (s *CAASApplicationSuite) TestSetScaleAfterAutoscalingStaleReports(c *gc.C) {
+	// The autoscaler scales the application.
+	err := s.app.SetAutoscalerStatus(&state.AutoscalerStatus{MaxReplicas: 5, DesiredReplicas: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetScale(4, 3, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Autoscaling is turned off and the user scales the application.
+	err = s.app.SetAutoscalerStatus(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetScale(2, 0, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Stale reports from the autoscaler do not override the user's scale.
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetScale(4, 3, false)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	err = s.app.SetScale(2, 2, false)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 2)
+	svcInfo, err := s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svcInfo.DesiredScaleProtected(), jc.IsTrue)
+
+	// Once the user's scale is applied, the cluster may report it again.
+	err = s.app.SetScale(2, 4, false)
+	c.Assert(err, jc.ErrorIsNil)
+	svcInfo, err = s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svcInfo.DesiredScaleProtected(), jc.IsFalse)
+	c.Assert(svcInfo.Generation(), gc.Equals, int64(4))
+}
+
 func (s *CAASApplicationSuite) TestInvalidChangeScale(c *gc.C) {
 	newScale, err := s.app.ChangeScale(-1)
 	c.Assert(err, gc.ErrorMatches, "cannot remove more units than currently exist not valid")
@@ -0,0 +1,507 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+const (
+	auditConversationKind = "conversation"
+	auditRequestKind      = "request"
+
+	// AuditOutcomeSuccess is the outcome of a request
+	// to which the API responded without error.
+	AuditOutcomeSuccess = "success"
+
+	// AuditOutcomeFailure is the outcome of a request to which
+	// the API responded with one or more errors.
+	AuditOutcomeFailure = "failure"
+)
+
+// auditLogDoc holds a record in the audit log collection, which is
+// written to by all controllers. Conversations and the requests made
+// in them are held in the same collection, distinguished by kind; the
+// response to a request is recorded in the request's document.
+type auditLogDoc struct {
+	// DocID is the conversation ID for conversations, and the
+	// conversation ID and request ID joined by ":" for requests.
+	DocID          string `bson:"_id"`
+	Kind           string `bson:"kind"`
+	Time           int64  `bson:"time"`
+	ConversationID string `bson:"conversation-id"`
+	ConnectionID   string `bson:"connection-id"`
+
+	Who       string `bson:"who,omitempty"`
+	What      string `bson:"what,omitempty"`
+	ModelName string `bson:"model-name,omitempty"`
+	ModelUUID string `bson:"model-uuid,omitempty"`
+
+	RequestID uint64          `bson:"request-id,omitempty"`
+	Facade    string          `bson:"facade,omitempty"`
+	Method    string          `bson:"method,omitempty"`
+	Version   int             `bson:"version,omitempty"`
+	Args      string          `bson:"args,omitempty"`
+	Outcome   string          `bson:"outcome,omitempty"`
+	Errors    []auditErrorDoc `bson:"errors,omitempty"`
+}
+
+type auditErrorDoc struct {
+	Message string `bson:"message"`
+	Code    string `bson:"code,omitempty"`
+}
+
+func auditRequestDocID(conversationID string, requestID uint64) string {
+	return fmt.Sprintf("%s:%d", conversationID, requestID)
+}
+
+// auditResponse holds the outcome of a request whose
+// document has already been written to the database.
+type auditResponse struct {
+	docID   string
+	outcome string
+	errors  []auditErrorDoc
+}
+
+// DbAuditLog is an auditlog.AuditLog which writes audit records to
+// the controller database, where records from every controller can
+// be queried together.
+//
+// Records are buffered so that the API requests being audited don't
+// each wait on the database; they're written in bulk when the buffer
+// fills, when the flush interval has passed, or when the log is closed.
+type DbAuditLog struct {
+	st            *State
+	bufferSize    int
+	flushInterval time.Duration
+
+	mu         sync.Mutex
+	docs       []*auditLogDoc
+	responses  []auditResponse
+	flushTimer clock.Timer
+}
+
+var _ auditlog.AuditLog = (*DbAuditLog)(nil)
+
+// NewDbAuditLog returns a DbAuditLog which writes to the audit log
+// collection of the controller for the specified state, buffering up
+// to bufferSize records for at most flushInterval.
+func NewDbAuditLog(st *State, bufferSize int, flushInterval time.Duration) *DbAuditLog {
+	return &DbAuditLog{
+		st:            st,
+		bufferSize:    bufferSize,
+		flushInterval: flushInterval,
+	}
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (l *DbAuditLog) AddConversation(c auditlog.Conversation) error {
+	return errors.Trace(l.add(&auditLogDoc{
+		DocID:          c.ConversationID,
+		Kind:           auditConversationKind,
+		Time:           l.auditTime(c.When),
+		ConversationID: c.ConversationID,
+		ConnectionID:   c.ConnectionID,
+		Who:            c.Who,
+		What:           c.What,
+		ModelName:      c.ModelName,
+		ModelUUID:      c.ModelUUID,
+	}))
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (l *DbAuditLog) AddRequest(r auditlog.Request) error {
+	return errors.Trace(l.add(&auditLogDoc{
+		DocID:          auditRequestDocID(r.ConversationID, r.RequestID),
+		Kind:           auditRequestKind,
+		Time:           l.auditTime(r.When),
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		RequestID:      r.RequestID,
+		Facade:         r.Facade,
+		Method:         r.Method,
+		Version:        r.Version,
+		Args:           r.Args,
+	}))
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (l *DbAuditLog) AddResponse(r auditlog.ResponseErrors) error {
+	outcome := AuditOutcomeSuccess
+	var errorDocs []auditErrorDoc
+	for _, e := range r.Errors {
+		// Bulk calls report a nil error for each
+		// item which was processed successfully.
+		if e == nil {
+			continue
+		}
+		outcome = AuditOutcomeFailure
+		errorDocs = append(errorDocs, auditErrorDoc{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	docID := auditRequestDocID(r.ConversationID, r.RequestID)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// The request is most likely still buffered, in which
+	// case the response is recorded along with it.
+	for i := len(l.docs) - 1; i >= 0; i-- {
+		if doc := l.docs[i]; doc.DocID == docID && doc.Kind == auditRequestKind {
+			doc.Outcome = outcome
+			doc.Errors = errorDocs
+			return nil
+		}
+	}
+	l.responses = append(l.responses, auditResponse{
+		docID:   docID,
+		outcome: outcome,
+		errors:  errorDocs,
+	})
+	return errors.Trace(l.buffered())
+}
+
+// Flush writes any buffered records to the database.
+func (l *DbAuditLog) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return errors.Trace(l.flush())
+}
+
+// Close implements auditlog.AuditLog, writing
+// any buffered records to the database.
+func (l *DbAuditLog) Close() error {
+	return errors.Trace(l.Flush())
+}
+
+func (l *DbAuditLog) add(doc *auditLogDoc) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.docs = append(l.docs, doc)
+	return errors.Trace(l.buffered())
+}
+
+// buffered flushes the buffer if it's full, and otherwise ensures
+// that it will be flushed once the flush interval has passed. The
+// caller must be holding l.mu.
+func (l *DbAuditLog) buffered() error {
+	if len(l.docs)+len(l.responses) >= l.bufferSize {
+		return errors.Trace(l.flush())
+	}
+	if l.flushTimer == nil {
+		l.flushTimer = l.st.clock().AfterFunc(l.flushInterval, l.flushOnTimer)
+	}
+	return nil
+}
+
+func (l *DbAuditLog) flushOnTimer() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// There's no request to report the error to, so it's logged.
+	if err := l.flush(); err != nil {
+		logger.Errorf("writing audit log: %v", err)
+	}
+}
+
+// flush writes the buffered records to the database, and stops the
+// flush timer if there is one. The records are discarded even if they
+// can't be written, so that they aren't recorded twice when a write
+// partially fails. The caller must be holding l.mu.
+func (l *DbAuditLog) flush() error {
+	if l.flushTimer != nil {
+		l.flushTimer.Stop()
+		l.flushTimer = nil
+	}
+	if len(l.docs) == 0 && len(l.responses) == 0 {
+		return nil
+	}
+	docs, responses := l.docs, l.responses
+	l.docs, l.responses = nil, nil
+
+	coll, closer := l.st.db().GetRawCollection(auditLogC)
+	defer closer()
+	// Responses are only buffered separately when their requests
+	// have already been written, so the writes needn't be ordered.
+	// A response to a request which was never recorded matches
+	// nothing, as there's nothing to attach it to.
+	bulk := coll.Bulk()
+	bulk.Unordered()
+	for _, doc := range docs {
+		bulk.Insert(doc)
+	}
+	for _, r := range responses {
+		bulk.Update(bson.D{{"_id", r.docID}}, bson.D{{
+			"$set", bson.D{{"outcome", r.outcome}, {"errors", r.errors}},
+		}})
+	}
+	if _, err := bulk.Run(); err != nil {
+		return errors.Annotate(err, "writing audit log")
+	}
+	return nil
+}
+
+// auditTime converts the RFC3339 time of an audit record into the
+// unix nanoseconds stored in the database. The records are generated
+// by the controller, so a malformed time is recorded as now rather
+// than losing the record.
+func (l *DbAuditLog) auditTime(when string) int64 {
+	t, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		t = l.st.clock().Now()
+	}
+	return t.UnixNano()
+}
+
+// AuditLogFilter holds the criteria used to select audit log
+// entries. Only the criteria which are set are applied.
+type AuditLogFilter struct {
+	// Who is the user who made the requests.
+	Who string
+
+	// ModelUUID is the model the requests were made against.
+	ModelUUID string
+
+	// Facade and Method are the API method called.
+	Facade string
+	Method string
+
+	// After and Before limit the entries to requests
+	// made in a time window.
+	After  time.Time
+	Before time.Time
+
+	// Outcome is either AuditOutcomeSuccess or AuditOutcomeFailure.
+	Outcome string
+
+	// Limit is the maximum number of entries to return,
+	// most recent first.
+	Limit int
+}
+
+// Validate returns an error if the filter is not valid.
+func (f AuditLogFilter) Validate() error {
+	switch f.Outcome {
+	case "", AuditOutcomeSuccess, AuditOutcomeFailure:
+	default:
+		return errors.NotValidf("outcome %q", f.Outcome)
+	}
+	if f.Limit < 0 {
+		return errors.NotValidf("negative limit")
+	}
+	if !f.After.IsZero() && !f.Before.IsZero() && f.Before.Before(f.After) {
+		return errors.NotValidf("time window ending before it starts")
+	}
+	return nil
+}
+
+// AuditLogEntry is an API request recorded in the audit
+// log, along with the conversation it was made in.
+type AuditLogEntry struct {
+	ConversationID string
+	ConnectionID   string
+	Who            string
+	What           string
+	ModelName      string
+	ModelUUID      string
+
+	RequestID uint64
+	Time      time.Time
+	Facade    string
+	Method    string
+	Version   int
+	Args      string
+
+	// Outcome is empty if no response to the request was recorded.
+	Outcome string
+	Errors  []auditlog.Error
+}
+
+// QueryAuditLog returns the requests in the controller's audit
+// log which match the filter, most recent first.
+func (st *State) QueryAuditLog(filter AuditLogFilter) ([]AuditLogEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	coll, closer := st.db().GetRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.D{{"kind", auditRequestKind}}
+	if filter.Who != "" || filter.ModelUUID != "" {
+		// The user and model are recorded against the
+		// conversation, so find the matching conversations
+		// before the requests made in them.
+		conversationIDs, err := matchingConversations(coll, filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(conversationIDs) == 0 {
+			return nil, nil
+		}
+		query = append(query, bson.DocElem{"conversation-id", bson.D{{"$in", conversationIDs}}})
+	}
+	if filter.Facade != "" {
+		query = append(query, bson.DocElem{"facade", filter.Facade})
+	}
+	if filter.Method != "" {
+		query = append(query, bson.DocElem{"method", filter.Method})
+	}
+	if timeRange := auditTimeRange(filter); timeRange != nil {
+		query = append(query, bson.DocElem{"time", timeRange})
+	}
+	if filter.Outcome != "" {
+		query = append(query, bson.DocElem{"outcome", filter.Outcome})
+	}
+	q := coll.Find(query).Sort("-time", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var requestDocs []auditLogDoc
+	if err := q.All(&requestDocs); err != nil {
+		return nil, errors.Annotate(err, "querying audit log")
+	}
+	if len(requestDocs) == 0 {
+		return nil, nil
+	}
+
+	conversations, err := conversationsForRequests(coll, requestDocs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]AuditLogEntry, len(requestDocs))
+	for i, doc := range requestDocs {
+		entry := AuditLogEntry{
+			ConversationID: doc.ConversationID,
+			ConnectionID:   doc.ConnectionID,
+			RequestID:      doc.RequestID,
+			Time:           time.Unix(0, doc.Time).UTC(),
+			Facade:         doc.Facade,
+			Method:         doc.Method,
+			Version:        doc.Version,
+			Args:           doc.Args,
+			Outcome:        doc.Outcome,
+		}
+		// The conversation may have been pruned, in which
+		// case only the request details are available.
+		if conversation, ok := conversations[doc.ConversationID]; ok {
+			entry.Who = conversation.Who
+			entry.What = conversation.What
+			entry.ModelName = conversation.ModelName
+			entry.ModelUUID = conversation.ModelUUID
+		}
+		for _, e := range doc.Errors {
+			entry.Errors = append(entry.Errors, auditlog.Error{
+				Message: e.Message,
+				Code:    e.Code,
+			})
+		}
+		result[i] = entry
+	}
+	return result, nil
+}
+
+func auditTimeRange(filter AuditLogFilter) bson.D {
+	var timeRange bson.D
+	if !filter.After.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.After.UnixNano()})
+	}
+	if !filter.Before.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lte", filter.Before.UnixNano()})
+	}
+	return timeRange
+}
+
+func matchingConversations(coll *mgo.Collection, filter AuditLogFilter) ([]string, error) {
+	query := bson.D{{"kind", auditConversationKind}}
+	if filter.Who != "" {
+		query = append(query, bson.DocElem{"who", filter.Who})
+	}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	// A conversation always starts before the requests made
+	// in it, so only its start can be bounded.
+	if !filter.Before.IsZero() {
+		query = append(query, bson.DocElem{"time", bson.D{{"$lte", filter.Before.UnixNano()}}})
+	}
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	if err := coll.Find(query).Select(bson.M{"_id": 1}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying audit log conversations")
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.DocID
+	}
+	return ids, nil
+}
+
+func conversationsForRequests(coll *mgo.Collection, requestDocs []auditLogDoc) (map[string]auditLogDoc, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, doc := range requestDocs {
+		if !seen[doc.ConversationID] {
+			seen[doc.ConversationID] = true
+			ids = append(ids, doc.ConversationID)
+		}
+	}
+	var docs []auditLogDoc
+	if err := coll.FindId(bson.D{{"$in", ids}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading audit log conversations")
+	}
+	result := make(map[string]auditLogDoc)
+	for _, doc := range docs {
+		result[doc.DocID] = doc
+	}
+	return result, nil
+}
+
+// PruneAuditLog removes audit log records until only those newer
+// than maxAge remain, and the collection is smaller than maxSizeMB.
+func PruneAuditLog(stop <-chan struct{}, st *State, maxAge time.Duration, maxSizeMB int) error {
+	coll, closer := st.db().GetRawCollection(auditLogC)
+	defer closer()
+
+	if maxAge > 0 {
+		// The audit log holds records for every model, so the
+		// model based age pruning of collectionPruner doesn't apply.
+		cutoff := st.clock().Now().Add(-maxAge).UnixNano()
+		iter := coll.Find(bson.D{{"time", bson.D{{"$lt", cutoff}}}}).Select(bson.M{"_id": 1}).Iter()
+		defer iter.Close()
+		deleted, err := deleteInBatches(
+			stop, coll, nil, "", iter,
+			"audit log age pruning: %d rows deleted", loggo.INFO, noEarlyFinish,
+		)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if deleted > 0 {
+			logger.Infof("audit log age pruning: %d rows deleted", deleted)
+		}
+	}
+	if maxSizeMB > 0 {
+		p := collectionPruner{
+			st:       st,
+			coll:     coll,
+			maxSize:  maxSizeMB,
+			ageField: "time",
+			timeUnit: NanoSeconds,
+		}
+		if err := p.pruneBySize(stop); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	statetesting.StateSuite
+
+	log *state.DbAuditLog
+	t0  time.Time
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.log = state.NewDbAuditLog(s.State, 10, time.Second)
+	s.t0 = time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *AuditLogSuite) flush(c *gc.C) {
+	err := s.log.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) addConversation(c *gc.C, id, who, modelUUID string, at time.Time) {
+	err := s.log.AddConversation(auditlog.Conversation{
+		ConversationID: id,
+		ConnectionID:   "1A",
+		Who:            who,
+		What:           "juju deploy mysql",
+		When:           at.Format(time.RFC3339),
+		ModelName:      "admin/default",
+		ModelUUID:      modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) addRequest(c *gc.C, conversationID string, requestID uint64, method string, at time.Time, errs ...*auditlog.Error) {
+	err := s.log.AddRequest(auditlog.Request{
+		ConversationID: conversationID,
+		ConnectionID:   "1A",
+		RequestID:      requestID,
+		When:           at.Format(time.RFC3339),
+		Facade:         "Application",
+		Method:         method,
+		Version:        13,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: conversationID,
+		ConnectionID:   "1A",
+		RequestID:      requestID,
+		When:           at.Format(time.RFC3339),
+		Errors:         errs,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) TestQuery(c *gc.C) {
+	s.addConversation(c, "c1", "fred", "model-1", s.t0)
+	s.addRequest(c, "c1", 1, "Deploy", s.t0.Add(time.Second), nil)
+	s.addRequest(c, "c1", 2, "DestroyApplication", s.t0.Add(2*time.Second),
+		&auditlog.Error{Message: "boom", Code: "not found"})
+	s.flush(c)
+
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.AuditLogEntry{{
+		ConversationID: "c1",
+		ConnectionID:   "1A",
+		Who:            "fred",
+		What:           "juju deploy mysql",
+		ModelName:      "admin/default",
+		ModelUUID:      "model-1",
+		RequestID:      2,
+		Time:           s.t0.Add(2 * time.Second),
+		Facade:         "Application",
+		Method:         "DestroyApplication",
+		Version:        13,
+		Outcome:        state.AuditOutcomeFailure,
+		Errors:         []auditlog.Error{{Message: "boom", Code: "not found"}},
+	}, {
+		ConversationID: "c1",
+		ConnectionID:   "1A",
+		Who:            "fred",
+		What:           "juju deploy mysql",
+		ModelName:      "admin/default",
+		ModelUUID:      "model-1",
+		RequestID:      1,
+		Time:           s.t0.Add(time.Second),
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        13,
+		Outcome:        state.AuditOutcomeSuccess,
+	}})
+}
+
+func (s *AuditLogSuite) TestQueryFilters(c *gc.C) {
+	s.addConversation(c, "c1", "fred", "model-1", s.t0)
+	s.addRequest(c, "c1", 1, "Deploy", s.t0.Add(time.Minute))
+	s.addConversation(c, "c2", "mary", "model-2", s.t0.Add(time.Hour))
+	s.addRequest(c, "c2", 1, "DestroyApplication", s.t0.Add(time.Hour+time.Minute))
+	s.addRequest(c, "c2", 2, "DestroyApplication", s.t0.Add(time.Hour+2*time.Minute),
+		&auditlog.Error{Message: "boom"})
+	s.flush(c)
+
+	for i, test := range []struct {
+		filter   state.AuditLogFilter
+		expected []string
+	}{{
+		filter:   state.AuditLogFilter{Who: "fred"},
+		expected: []string{"c1:1"},
+	}, {
+		filter:   state.AuditLogFilter{ModelUUID: "model-2"},
+		expected: []string{"c2:2", "c2:1"},
+	}, {
+		filter:   state.AuditLogFilter{Who: "bob"},
+		expected: nil,
+	}, {
+		filter:   state.AuditLogFilter{Facade: "Application", Method: "DestroyApplication"},
+		expected: []string{"c2:2", "c2:1"},
+	}, {
+		filter:   state.AuditLogFilter{Outcome: state.AuditOutcomeFailure},
+		expected: []string{"c2:2"},
+	}, {
+		filter:   state.AuditLogFilter{After: s.t0.Add(30 * time.Minute)},
+		expected: []string{"c2:2", "c2:1"},
+	}, {
+		filter:   state.AuditLogFilter{Before: s.t0.Add(30 * time.Minute)},
+		expected: []string{"c1:1"},
+	}, {
+		filter:   state.AuditLogFilter{Limit: 1},
+		expected: []string{"c2:2"},
+	}} {
+		c.Logf("test %d: %+v", i, test.filter)
+		entries, err := s.State.QueryAuditLog(test.filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var ids []string
+		for _, e := range entries {
+			ids = append(ids, fmt.Sprintf("%s:%d", e.ConversationID, e.RequestID))
+		}
+		c.Check(ids, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *AuditLogSuite) TestQueryInvalidFilter(c *gc.C) {
+	_, err := s.State.QueryAuditLog(state.AuditLogFilter{Outcome: "meh"})
+	c.Assert(err, gc.ErrorMatches, `outcome "meh" not valid`)
+	_, err = s.State.QueryAuditLog(state.AuditLogFilter{After: s.t0, Before: s.t0.Add(-time.Hour)})
+	c.Assert(err, gc.ErrorMatches, `time window ending before it starts not valid`)
+}
+
+func (s *AuditLogSuite) TestResponseWithoutRequest(c *gc.C) {
+	err := s.log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1",
+		RequestID:      1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.flush(c)
+
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *AuditLogSuite) TestResponseToFlushedRequest(c *gc.C) {
+	s.addConversation(c, "c1", "fred", "model-1", s.t0)
+	err := s.log.AddRequest(auditlog.Request{
+		ConversationID: "c1",
+		RequestID:      1,
+		When:           s.t0.Format(time.RFC3339),
+		Facade:         "Application",
+		Method:         "Deploy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.flush(c)
+
+	err = s.log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1",
+		RequestID:      1,
+		Errors:         []*auditlog.Error{{Message: "boom"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.flush(c)
+
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Outcome, gc.Equals, state.AuditOutcomeFailure)
+	c.Assert(entries[0].Errors, jc.DeepEquals, []auditlog.Error{{Message: "boom"}})
+}
+
+func (s *AuditLogSuite) TestBuffered(c *gc.C) {
+	s.addConversation(c, "c1", "fred", "model-1", s.t0)
+	s.addRequest(c, "c1", 1, "Deploy", s.t0)
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+
+	// The buffered records are written once the flush interval passes.
+	err = s.Clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		entries, err = s.State.QueryAuditLog(state.AuditLogFilter{})
+		c.Assert(err, jc.ErrorIsNil)
+		if len(entries) > 0 {
+			break
+		}
+	}
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Outcome, gc.Equals, state.AuditOutcomeSuccess)
+}
+
+func (s *AuditLogSuite) TestBufferFull(c *gc.C) {
+	s.addConversation(c, "c1", "fred", "model-1", s.t0)
+	for i := 1; i <= 12; i++ {
+		s.addRequest(c, "c1", uint64(i), "Deploy", s.t0)
+	}
+	// The conversation and the first nine requests
+	// filled the buffer, and so have been written.
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 9)
+
+	err = s.log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	entries, err = s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 12)
+	for _, entry := range entries {
+		c.Check(entry.Outcome, gc.Equals, state.AuditOutcomeSuccess)
+	}
+}
+
+func (s *AuditLogSuite) TestPruneByAge(c *gc.C) {
+	now := s.Clock.Now()
+	s.addConversation(c, "c1", "fred", "model-1", now.Add(-48*time.Hour))
+	s.addRequest(c, "c1", 1, "Deploy", now.Add(-47*time.Hour))
+	s.addConversation(c, "c2", "fred", "model-1", now.Add(-time.Hour))
+	s.addRequest(c, "c2", 1, "Deploy", now.Add(-time.Hour))
+	s.flush(c)
+
+	var stop <-chan struct{}
+	err := state.PruneAuditLog(stop, s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].ConversationID, gc.Equals, "c2")
+	c.Assert(entries[0].Who, gc.Equals, "fred")
+}
+
+func (s *AuditLogSuite) TestPruneBySize(c *gc.C) {
+	// Write enough requests, with large enough arguments,
+	// to take the collection well over 1MB.
+	log := state.NewDbAuditLog(s.State, 1000, time.Second)
+	args := strings.Repeat("x", 500)
+	count := 10000
+	for i := 0; i < count; i++ {
+		err := log.AddRequest(auditlog.Request{
+			ConversationID: "c1",
+			RequestID:      uint64(i),
+			When:           s.t0.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+			Facade:         "Application",
+			Method:         "Deploy",
+			Args:           args,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var stop <-chan struct{}
+	err = state.PruneAuditLog(stop, s.State, 0, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(entries), jc.GreaterThan, 0)
+	c.Assert(len(entries), jc.LessThan, count/2)
+	// The oldest requests are pruned first.
+	c.Assert(entries[0].RequestID, gc.Equals, uint64(count-1))
+}
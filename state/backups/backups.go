@@ -45,6 +45,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/v2/filestorage"
+	"golang.org/x/crypto/openpgp"
 )
 
 const (
@@ -80,14 +81,18 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 		return errors.Trace(err)
 	}
 	meta.SetStored(stored.Stored())
+	if stored, ok := stored.(*Metadata); ok {
+		meta.Location = stored.Location
+	}
 	return nil
 }
 
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
+	// the provided metadata. If any keys are given, the
+	// archive is encrypted to them.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryptTo openpgp.EntityList) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -116,7 +121,9 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
+func (b *backups) Create(
+	meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryptTo openpgp.EntityList,
+) (string, error) {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
@@ -141,7 +148,7 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload, encryptTo}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
@@ -149,6 +156,9 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	defer result.archiveFile.Close()
 
 	// Finalize the metadata.
+	if len(encryptTo) > 0 {
+		meta.EncryptedTo = KeyFingerprints(encryptTo)
+	}
 	err = finishMeta(meta, result)
 	if err != nil {
 		return "", errors.Annotate(err, "while updating metadata")
@@ -61,7 +61,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 
-	_, err := s.api.Create(meta, &paths, &dbInfo, true, true)
+	_, err := s.api.Create(meta, &paths, &dbInfo, true, true, nil)
 	c.Check(err, gc.ErrorMatches, expected)
 }
 
@@ -117,7 +117,7 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	resultFilename, err := s.api.Create(meta, &paths, &dbInfo, keepCopy, noDownload)
+	resultFilename, err := s.api.Create(meta, &paths, &dbInfo, keepCopy, noDownload, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(resultFilename, gc.Equals, path.Join(backupDir, backups.TempFilename))
 
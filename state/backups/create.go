@@ -16,6 +16,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/v2/hash"
 	"github.com/juju/utils/v2/tar"
+	"golang.org/x/crypto/openpgp"
 )
 
 // TODO(ericsnow) One concern is files that get out of date by the time
@@ -33,6 +34,8 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	// encryptTo holds the keys the archive is encrypted to, if any.
+	encryptTo openpgp.EntityList
 }
 
 type createResult struct {
@@ -50,6 +53,7 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.encryptTo = args.encryptTo
 	defer func() {
 		if cerr := builder.cleanUp(args.noDownload); cerr != nil {
 			cerr.Log(logger)
@@ -105,6 +109,9 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// encryptTo holds the keys the archive file is encrypted to.
+	// The archive isn't encrypted if there are none.
+	encryptTo openpgp.EntityList
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
@@ -290,6 +297,22 @@ func (b *builder) buildDBDump() error {
 }
 
 func (b *builder) buildArchive(outFile io.Writer) error {
+	if len(b.encryptTo) == 0 {
+		return b.buildCompressedArchive(outFile)
+	}
+	logger.Infof("encrypting archive to keys %v", KeyFingerprints(b.encryptTo))
+	encrypted, err := encryptingWriter(outFile, b.encryptTo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := b.buildCompressedArchive(encrypted); err != nil {
+		_ = encrypted.Close()
+		return errors.Trace(err)
+	}
+	return errors.Annotate(encrypted.Close(), "while encrypting final archive")
+}
+
+func (b *builder) buildCompressedArchive(outFile io.Writer) error {
 	tarball := gzip.NewWriter(outFile)
 	defer tarball.Close()
 
@@ -312,8 +335,9 @@ func (b *builder) buildArchiveAndChecksum() error {
 	logger.Infof("building archive file %q", b.filename)
 
 	// Build the tarball, writing out to both the archive file and a
-	// SHA1 hash.  The hash will correspond to the gzipped file rather
-	// than to the uncompressed contents of the tarball.  This is so
+	// SHA1 hash.  The hash will correspond to the gzipped (and, if
+	// requested, encrypted) file rather than to the uncompressed
+	// contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
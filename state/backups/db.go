@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/replicaset"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/mongo"
@@ -269,6 +270,85 @@ func listDatabases(dumpDir string) (set.Strings, error) {
 	return databases, nil
 }
 
+const restoreName = "mongorestore"
+
+var getMongorestorePath = func() (string, error) {
+	return getMongoToolPath(restoreName, os.Stat, exec.LookPath)
+}
+
+type mongoRestorer struct {
+	*DBInfo
+	session *mgo.Session
+	// binPath is the path to the restore executable.
+	binPath string
+}
+
+// NewRestoreDatabase returns a RestoreDatabase which restores databases
+// to the juju state database described by info, and manages its replica
+// set with the session.
+func NewRestoreDatabase(info *DBInfo, session *mgo.Session) (RestoreDatabase, error) {
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongorestore not available")
+	}
+
+	restorer := mongoRestorer{
+		DBInfo:  info,
+		session: session,
+		binPath: mongorestorePath,
+	}
+	return &restorer, nil
+}
+
+// ReplicaSetMembers is part of the RestoreDatabase interface.
+func (md *mongoRestorer) ReplicaSetMembers() ([]replicaset.Member, error) {
+	members, err := replicaset.CurrentMembers(md.session)
+	return members, errors.Trace(err)
+}
+
+// SetReplicaSetMembers is part of the RestoreDatabase interface.
+func (md *mongoRestorer) SetReplicaSetMembers(members []replicaset.Member) error {
+	return errors.Trace(replicaset.Set(md.session, members))
+}
+
+func (md *mongoRestorer) options(dumpDir string) []string {
+	options := []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", md.Address,
+		"--username", md.Username,
+		"--password", md.Password,
+		"--drop",
+		"--oplogReplay",
+		dumpDir,
+	}
+	return options
+}
+
+// Restore is part of the RestoreDatabase interface.
+func (md *mongoRestorer) Restore(dumpDir string) error {
+	logger.Tracef("restoring Mongo database from %q", dumpDir)
+	// The juju-db.mongorestore Snap can only read from
+	// /tmp/snap.juju-db/DUMPDIR, so move the dump there.
+	if filepath.Base(md.binPath) == snapToolPrefix+restoreName {
+		snapDir := filepath.Join(snapTmpDir, dumpDir)
+		logger.Tracef("moving dump dir %q to Snap dump dir %q", dumpDir, snapDir)
+		if err := os.MkdirAll(filepath.Dir(snapDir), 0700); err != nil {
+			return errors.Trace(err)
+		}
+		if err := os.Rename(dumpDir, snapDir); err != nil {
+			return errors.Trace(err)
+		}
+		defer func() { _ = os.RemoveAll(snapDir) }()
+	}
+
+	if err := runCommandFn(md.binPath, md.options(dumpDir)...); err != nil {
+		return errors.Annotate(err, "error restoring databases")
+	}
+	return nil
+}
+
 // MongoDB represents a mgo.DB.
 type MongoDB interface {
 	UpsertUser(*mgo.User) error
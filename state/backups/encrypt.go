@@ -0,0 +1,72 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+// EncryptedFileSuffix is appended to the names of
+// backup archive files which are encrypted.
+const EncryptedFileSuffix = ".gpg"
+
+// ParseEncryptionKeys returns the OpenPGP public keys in the armored
+// key ring, which backup archives are encrypted to. Each of the keys
+// must be able to encrypt.
+func ParseEncryptionKeys(armored string) (openpgp.EntityList, error) {
+	keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return nil, errors.NewNotValid(err, "reading encryption key")
+	}
+	if len(keys) == 0 {
+		return nil, errors.NotValidf("empty encryption key ring")
+	}
+	// Encrypting an empty message checks the keys are usable.
+	plaintext, err := openpgp.Encrypt(ioutil.Discard, keys, nil, nil, nil)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "encryption key")
+	}
+	_ = plaintext.Close()
+	return keys, nil
+}
+
+// KeyFingerprints returns the fingerprints of the primary keys,
+// formatted as hex strings.
+func KeyFingerprints(keys openpgp.EntityList) []string {
+	fingerprints := make([]string, len(keys))
+	for i, key := range keys {
+		fingerprints[i] = fmt.Sprintf("%X", key.PrimaryKey.Fingerprint)
+	}
+	return fingerprints
+}
+
+// encryptingWriter returns a writer which encrypts what's written to it
+// to the keys, writing the encrypted result to w. The returned writer
+// must be closed to write the end of the encrypted message.
+func encryptingWriter(w io.Writer, keys openpgp.EntityList) (io.WriteCloser, error) {
+	hints := &openpgp.FileHints{IsBinary: true}
+	plaintext, err := openpgp.Encrypt(w, keys, nil, hints, nil)
+	return plaintext, errors.Annotate(err, "encrypting backup archive")
+}
+
+// IsEncryptedArchive reports whether the backup archive read by r is
+// encrypted. Unencrypted archives are gzip files, so anything else is
+// taken to be encrypted.
+func IsEncryptedArchive(r *bufio.Reader) (bool, error) {
+	magic, err := r.Peek(2)
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return magic[0] != 0x1f || magic[1] != 0x8b, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type encryptSuite struct {
+	LegacySuite
+}
+
+var _ = gc.Suite(&encryptSuite{})
+
+// newEncryptionKey returns a new OpenPGP key,
+// and its armored public key.
+func newEncryptionKey(c *gc.C) (*openpgp.Entity, string) {
+	// Keys made by gpg prefer SHA-256; those made by openpgp only do
+	// when asked to, and once the identity is signed again.
+	config := &packet.Config{DefaultHash: crypto.SHA256}
+	key, err := openpgp.NewEntity("backups", "", "backups@example.com", config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key.SerializePrivate(ioutil.Discard, config), jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return key, buf.String()
+}
+
+func (s *encryptSuite) TestParseEncryptionKeys(c *gc.C) {
+	key, armored := newEncryptionKey(c)
+	keys, err := backups.ParseEncryptionKeys(armored)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, gc.HasLen, 1)
+	c.Check(backups.KeyFingerprints(keys), jc.DeepEquals, []string{
+		fmt.Sprintf("%X", key.PrimaryKey.Fingerprint),
+	})
+}
+
+func (s *encryptSuite) TestParseEncryptionKeysInvalid(c *gc.C) {
+	_, err := backups.ParseEncryptionKeys("not a key")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "reading encryption key: .*")
+}
+
+func (s *encryptSuite) TestIsEncryptedArchive(c *gc.C) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		data      []byte
+		encrypted bool
+	}{
+		{compressed.Bytes(), false},
+		{nil, false},
+		{[]byte{0x85, 0x01, 0x0c}, true},
+	} {
+		c.Logf("test %d", i)
+		encrypted, err := backups.IsEncryptedArchive(bufio.NewReader(bytes.NewReader(test.data)))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(encrypted, gc.Equals, test.encrypted)
+	}
+}
+
+func (s *encryptSuite) TestCreateEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	key, _ := newEncryptionKey(c)
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, expected := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(c.MkDir(), testFiles, &TestDBDumper{}, metadataFile, true)
+	backups.SetCreateArgsEncryptTo(args, openpgp.EntityList{key})
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum, _ := backups.ExposeCreateResult(result)
+	file := archiveFile.(*os.File)
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	encrypted, err := backups.IsEncryptedArchive(bufio.NewReader(file))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsTrue)
+	_, err = file.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+
+	message, err := openpgp.ReadMessage(file, openpgp.EntityList{key}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(message.IsEncrypted, jc.IsTrue)
+	decrypted, err := ioutil.ReadAll(message.UnverifiedBody)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plain, err := ioutil.TempFile(c.MkDir(), "archive")
+	c.Assert(err, jc.ErrorIsNil)
+	defer plain.Close()
+	_, err = plain.Write(decrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = plain.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkArchive(c, plain, expected)
+}
+
+func (s *encryptSuite) TestCreateEncryptedOtherKey(c *gc.C) {
+	key, _ := newEncryptionKey(c)
+	other, _ := newEncryptionKey(c)
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, _ := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(c.MkDir(), testFiles, &TestDBDumper{}, metadataFile, true)
+	backups.SetCreateArgsEncryptTo(args, openpgp.EntityList{key})
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, _, _, _ := backups.ExposeCreateResult(result)
+	_, err = openpgp.ReadMessage(archiveFile, openpgp.EntityList{other}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "openpgp: incorrect key")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	"github.com/juju/utils/v2/filestorage"
+	"golang.org/x/crypto/openpgp"
 
 	"github.com/juju/juju/state"
 )
@@ -27,10 +28,13 @@ var (
 	StoreArchiveRef      = &storeArchive
 	GetMongodumpPath     = &getMongodumpPath
 	RunCommand           = &runCommandFn
+
+	NewS3Storage = newS3Storage
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
+var _ filestorage.RawFileStorage = (*locatingFileStorage)(nil)
 
 func getBackupDBWrapper(st *state.State) *storageDBWrapper {
 	db := st.MongoSession().DB(storageDBName)
@@ -102,6 +106,12 @@ func NewTestCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, me
 	return &args
 }
 
+// SetCreateArgsEncryptTo sets the keys the archive built
+// by a create() call is encrypted to.
+func SetCreateArgsEncryptTo(args *createArgs, keys openpgp.EntityList) {
+	args.encryptTo = keys
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.backupDir, args.filesToBackUp, args.db
@@ -83,12 +83,25 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was created by the
+	// backup scheduler rather than on request. Only scheduled
+	// backups are removed by the scheduler's retention policy.
+	Scheduled bool
+
 	// FormatVersion stores format version of these metadata.
 	FormatVersion int64
 
 	// Controller contains metadata about the controller where the backup was taken.
 	Controller ControllerMetadata
 
+	// EncryptedTo holds the fingerprints of the OpenPGP keys
+	// the archive is encrypted to, if it's encrypted.
+	EncryptedTo []string
+
+	// Location is the URL of the stored archive in remote storage,
+	// or empty if it's stored in the controller's database.
+	Location string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
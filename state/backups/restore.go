@@ -0,0 +1,370 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// jujuMachineKey is the replica set member tag holding the ID of the
+// controller machine running the member.
+const jujuMachineKey = "juju-machine-id"
+
+// ControllerNode is a controller machine whose agent is stopped while
+// a backup is restored.
+type ControllerNode interface {
+	// ID returns the machine ID of the node.
+	ID() string
+
+	// StopAgent stops the node's machine agent.
+	StopAgent() error
+
+	// StartAgent starts the node's machine agent.
+	StartAgent() error
+}
+
+// RestoreDatabase is the controller database a backup is restored into.
+type RestoreDatabase interface {
+	// ReplicaSetMembers returns the members of the
+	// controller's replica set.
+	ReplicaSetMembers() ([]replicaset.Member, error)
+
+	// SetReplicaSetMembers replaces the members of
+	// the controller's replica set.
+	SetReplicaSetMembers([]replicaset.Member) error
+
+	// Restore restores the databases dumped in dumpDir,
+	// replacing the databases of the same name.
+	Restore(dumpDir string) error
+}
+
+// RestoreConfig holds the configuration of a Restorer.
+type RestoreConfig struct {
+	// Archive is the unpacked backup archive to restore.
+	Archive *ArchiveWorkspace
+
+	// ControllerUUID is the UUID of the controller the
+	// backup is restored to.
+	ControllerUUID string
+
+	// ControllerVersion is the version of Juju the controller
+	// the backup is restored to is running.
+	ControllerVersion version.Number
+
+	// Database is the controller's database.
+	Database RestoreDatabase
+
+	// Nodes holds the controller's machines, one for each member
+	// of the replica set. Agents are stopped in the order given,
+	// and started in reverse; the node the restore is run on
+	// should be last.
+	Nodes []ControllerNode
+
+	// RootDir is the directory the files bundle in the backup
+	// is unpacked into: "/" when restoring a controller machine.
+	RootDir string
+}
+
+// Validate returns an error if the config is not valid.
+func (config RestoreConfig) Validate() error {
+	if config.Archive == nil {
+		return errors.NotValidf("nil Archive")
+	}
+	if config.ControllerUUID == "" {
+		return errors.NotValidf("empty ControllerUUID")
+	}
+	if config.ControllerVersion == version.Zero {
+		return errors.NotValidf("zero ControllerVersion")
+	}
+	if config.Database == nil {
+		return errors.NotValidf("nil Database")
+	}
+	if len(config.Nodes) == 0 {
+		return errors.NotValidf("no Nodes")
+	}
+	if config.RootDir == "" {
+		return errors.NotValidf("empty RootDir")
+	}
+	return nil
+}
+
+// RestorePlan describes what restoring a backup changes.
+type RestorePlan struct {
+	// Metadata is the metadata of the backup.
+	Metadata *Metadata
+
+	// Databases holds the names of the databases in the backup,
+	// which replace the controller's databases of the same name.
+	Databases []string
+
+	// Files holds the paths, relative to the root directory, of the
+	// files in the backup's files bundle.
+	Files []string
+
+	// Agents holds the IDs of the controller machines whose agents
+	// are stopped while the backup is restored.
+	Agents []string
+
+	// RemovedMembers holds the IDs of the controller machines which
+	// were not controllers when the backup was taken. They are removed
+	// from the replica set, and their agents are not restarted.
+	RemovedMembers []string
+}
+
+// Restorer restores a backup to a controller.
+type Restorer struct {
+	config RestoreConfig
+}
+
+// NewRestorer returns a Restorer which restores a backup
+// as described by the config.
+func NewRestorer(config RestoreConfig) (*Restorer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Restorer{config: config}, nil
+}
+
+// Plan checks the backup can be restored to the controller, and
+// returns what restoring it would change. It doesn't change anything.
+func (r *Restorer) Plan() (*RestorePlan, error) {
+	meta, err := r.config.Archive.Metadata()
+	if os.IsNotExist(errors.Cause(err)) {
+		return nil, errors.NotValidf("backup archive without metadata")
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "reading backup metadata")
+	}
+	if err := CheckRestoreVersion(meta.Origin.Version, r.config.ControllerVersion); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if meta.Controller.UUID != r.config.ControllerUUID {
+		return nil, errors.NotValidf(
+			"backup of controller %q restored to controller %q", meta.Controller.UUID, r.config.ControllerUUID)
+	}
+
+	dbNames, err := listDatabases(r.config.Archive.DBDumpDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading database dump")
+	}
+	files, err := listBundledFiles(r.config.Archive.FilesBundle)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading files bundle")
+	}
+	backupControllers, err := readDumpedControllerIDs(r.config.Archive.DBDumpDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading controller machines in backup")
+	}
+
+	members, err := r.config.Database.ReplicaSetMembers()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting replica set members")
+	}
+	memberIDs := set.NewStrings()
+	for _, member := range members {
+		memberIDs.Add(member.Tags[jujuMachineKey])
+	}
+	nodeIDs := set.NewStrings()
+	for _, node := range r.config.Nodes {
+		nodeIDs.Add(node.ID())
+	}
+	if missing := memberIDs.Difference(nodeIDs); !missing.IsEmpty() {
+		return nil, errors.Errorf("no agent control for replica set members on machines %v", missing.SortedValues())
+	}
+	if missing := backupControllers.Difference(memberIDs); !missing.IsEmpty() {
+		return nil, errors.Errorf(
+			"backup has controller machines %v which are not members of the replica set", missing.SortedValues())
+	}
+
+	agents := make([]string, len(r.config.Nodes))
+	for i, node := range r.config.Nodes {
+		agents[i] = node.ID()
+	}
+	return &RestorePlan{
+		Metadata:       meta,
+		Databases:      dbNames.SortedValues(),
+		Files:          files,
+		Agents:         agents,
+		RemovedMembers: memberIDs.Difference(backupControllers).SortedValues(),
+	}, nil
+}
+
+// Restore restores the backup to the controller. The controller's
+// agents are stopped, the databases and files in the backup restored,
+// and the controller machines which were not controllers when the
+// backup was taken removed from the replica set before the remaining
+// agents are started again. The votes of the remaining members are
+// reconciled by the peergrouper once the agents have started.
+func (r *Restorer) Restore() (*RestorePlan, error) {
+	plan, err := r.Plan()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	removed := set.NewStrings(plan.RemovedMembers...)
+
+	var stopped []ControllerNode
+	for _, node := range r.config.Nodes {
+		logger.Infof("stopping agent on controller machine %s", node.ID())
+		if err := node.StopAgent(); err != nil {
+			_ = startAgents(stopped)
+			return nil, errors.Annotatef(err, "stopping agent on controller machine %s", node.ID())
+		}
+		stopped = append(stopped, node)
+	}
+
+	var restart []ControllerNode
+	for _, node := range stopped {
+		if !removed.Contains(node.ID()) {
+			restart = append(restart, node)
+		}
+	}
+	if err := r.restore(removed); err != nil {
+		// The agents are left stopped; the controller's
+		// databases may be only partly restored.
+		return nil, errors.Trace(err)
+	}
+	if err := startAgents(restart); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return plan, nil
+}
+
+func (r *Restorer) restore(removed set.Strings) error {
+	logger.Infof("restoring databases")
+	if err := r.config.Database.Restore(r.config.Archive.DBDumpDir); err != nil {
+		return errors.Annotate(err, "restoring databases")
+	}
+	logger.Infof("restoring files")
+	if err := r.config.Archive.UnpackFilesBundle(r.config.RootDir); err != nil {
+		return errors.Annotate(err, "restoring files")
+	}
+	if removed.IsEmpty() {
+		return nil
+	}
+	logger.Infof("removing controller machines %v from the replica set", removed.SortedValues())
+	members, err := r.config.Database.ReplicaSetMembers()
+	if err != nil {
+		return errors.Annotate(err, "getting replica set members")
+	}
+	var keep []replicaset.Member
+	for _, member := range members {
+		if !removed.Contains(member.Tags[jujuMachineKey]) {
+			keep = append(keep, member)
+		}
+	}
+	return errors.Annotate(r.config.Database.SetReplicaSetMembers(keep), "updating replica set members")
+}
+
+// startAgents starts the agents of the nodes in reverse order,
+// returning the first error encountered.
+func startAgents(nodes []ControllerNode) error {
+	var firstErr error
+	for i := len(nodes) - 1; i >= 0; i-- {
+		logger.Infof("starting agent on controller machine %s", nodes[i].ID())
+		if err := nodes[i].StartAgent(); err != nil {
+			logger.Errorf("cannot start agent on controller machine %s: %v", nodes[i].ID(), err)
+			if firstErr == nil {
+				firstErr = errors.Annotatef(err, "starting agent on controller machine %s", nodes[i].ID())
+			}
+		}
+	}
+	return firstErr
+}
+
+// CheckRestoreVersion returns an error if a backup taken by a controller
+// running the backup version can't be restored to a controller running
+// the controller version. The versions must have the same major and
+// minor numbers, and the backup can't be from a later version.
+func CheckRestoreVersion(backup, controller version.Number) error {
+	if backup.Major != controller.Major || backup.Minor != controller.Minor ||
+		backup.ToPatch().Compare(controller.ToPatch()) > 0 {
+		return errors.NotSupportedf("restoring backup from juju %s to controller running juju %s", backup, controller)
+	}
+	return nil
+}
+
+// listBundledFiles returns the paths of the regular
+// files in the files bundle tar file.
+func listBundledFiles(bundle string) ([]string, error) {
+	f, err := os.Open(bundle)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var files []string
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			files = append(files, hdr.Name)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readDumpedControllerIDs returns the IDs of the controller machines
+// recorded in the juju database dumped in dumpDir.
+func readDumpedControllerIDs(dumpDir string) (set.Strings, error) {
+	f, err := os.Open(filepath.Join(dumpDir, "juju", "controllers.bson"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	for {
+		var doc struct {
+			Id            string   `bson:"_id"`
+			ControllerIds []string `bson:"controller-ids"`
+		}
+		err := readBSONDocument(f, &doc)
+		if err == io.EOF {
+			return nil, errors.NotFoundf("controller machines")
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The controller machines are recorded in the
+		// document with the model global key.
+		if doc.Id == "e" {
+			return set.NewStrings(doc.ControllerIds...), nil
+		}
+	}
+}
+
+// readBSONDocument reads the next of the documents written to r by
+// mongodump, returning io.EOF if there are no more.
+func readBSONDocument(r io.Reader, out interface{}) error {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return err
+	}
+	if size < 5 {
+		return errors.Errorf("invalid document size %d", size)
+	}
+	data := make([]byte, size)
+	binary.LittleEndian.PutUint32(data, uint32(size))
+	if _, err := io.ReadFull(r, data[4:]); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(bson.Unmarshal(data, out))
+}
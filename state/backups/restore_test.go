@@ -0,0 +1,300 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type restoreSuite struct {
+	testing.IsolationSuite
+
+	stub     testing.Stub
+	archive  *backups.ArchiveWorkspace
+	database *fakeRestoreDatabase
+	nodes    []backups.ControllerNode
+	rootDir  string
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = testing.Stub{}
+
+	dir := c.MkDir()
+	s.archive = &backups.ArchiveWorkspace{
+		ArchivePaths: backups.NewNonCanonicalArchivePaths(dir),
+		RootDir:      dir,
+	}
+	meta := backups.NewMetadata()
+	meta.Origin.Version = version.MustParse("2.9.1")
+	meta.Controller.UUID = "controller-uuid"
+	s.writeArchive(c, meta, []string{"0", "1"})
+
+	s.database = &fakeRestoreDatabase{
+		stub: &s.stub,
+		members: []replicaset.Member{
+			{Id: 1, Address: "10.0.0.1:37017", Tags: map[string]string{"juju-machine-id": "0"}},
+			{Id: 2, Address: "10.0.0.2:37017", Tags: map[string]string{"juju-machine-id": "1"}},
+		},
+	}
+	s.nodes = []backups.ControllerNode{
+		&fakeControllerNode{stub: &s.stub, id: "1"},
+		&fakeControllerNode{stub: &s.stub, id: "0"},
+	}
+	s.rootDir = c.MkDir()
+}
+
+func (s *restoreSuite) writeArchive(c *gc.C, meta *backups.Metadata, controllerIds []string) {
+	err := os.MkdirAll(filepath.Join(s.archive.DBDumpDir, "juju"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(s.archive.DBDumpDir, "oplog.bson"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var controllers []byte
+	for _, doc := range []bson.M{
+		{"_id": "controllerSettings", "settings": bson.M{}},
+		{"_id": "e", "model-uuid": "model-uuid", "controller-ids": controllerIds},
+	} {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		controllers = append(controllers, data...)
+	}
+	err = ioutil.WriteFile(filepath.Join(s.archive.DBDumpDir, "juju", "controllers.bson"), controllers, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	metaFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(metaFile)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(s.archive.MetadataFile, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	bundle, err := os.Create(s.archive.FilesBundle)
+	c.Assert(err, jc.ErrorIsNil)
+	defer bundle.Close()
+	tw := tar.NewWriter(bundle)
+	content := []byte("secret")
+	err = tw.WriteHeader(&tar.Header{
+		Name:     "var/lib/juju/shared-secret",
+		Mode:     0600,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write(content)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) newRestorer(c *gc.C) *backups.Restorer {
+	restorer, err := backups.NewRestorer(backups.RestoreConfig{
+		Archive:           s.archive,
+		ControllerUUID:    "controller-uuid",
+		ControllerVersion: version.MustParse("2.9.2"),
+		Database:          s.database,
+		Nodes:             s.nodes,
+		RootDir:           s.rootDir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return restorer
+}
+
+func (s *restoreSuite) TestValidateConfig(c *gc.C) {
+	_, err := backups.NewRestorer(backups.RestoreConfig{
+		Archive:           s.archive,
+		ControllerUUID:    "controller-uuid",
+		ControllerVersion: version.MustParse("2.9.2"),
+		Database:          s.database,
+		RootDir:           s.rootDir,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "no Nodes not valid")
+}
+
+func (s *restoreSuite) TestPlan(c *gc.C) {
+	plan, err := s.newRestorer(c).Plan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Metadata.Controller.UUID, gc.Equals, "controller-uuid")
+	c.Check(plan.Databases, jc.DeepEquals, []string{"juju"})
+	c.Check(plan.Files, jc.DeepEquals, []string{"var/lib/juju/shared-secret"})
+	c.Check(plan.Agents, jc.DeepEquals, []string{"1", "0"})
+	c.Check(plan.RemovedMembers, gc.HasLen, 0)
+	s.stub.CheckCallNames(c, "ReplicaSetMembers")
+}
+
+func (s *restoreSuite) TestPlanRemovesMembers(c *gc.C) {
+	s.database.members = append(s.database.members, replicaset.Member{
+		Id: 3, Address: "10.0.0.3:37017", Tags: map[string]string{"juju-machine-id": "2"},
+	})
+	s.nodes = append([]backups.ControllerNode{&fakeControllerNode{stub: &s.stub, id: "2"}}, s.nodes...)
+
+	plan, err := s.newRestorer(c).Plan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Agents, jc.DeepEquals, []string{"2", "1", "0"})
+	c.Check(plan.RemovedMembers, jc.DeepEquals, []string{"2"})
+}
+
+func (s *restoreSuite) TestPlanVersionMismatch(c *gc.C) {
+	meta := backups.NewMetadata()
+	meta.Origin.Version = version.MustParse("2.8.10")
+	meta.Controller.UUID = "controller-uuid"
+	s.writeArchive(c, meta, []string{"0", "1"})
+
+	_, err := s.newRestorer(c).Plan()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `restoring backup from juju 2.8.10 to controller running juju 2.9.2 not supported`)
+}
+
+func (s *restoreSuite) TestPlanOtherController(c *gc.C) {
+	meta := backups.NewMetadata()
+	meta.Origin.Version = version.MustParse("2.9.1")
+	meta.Controller.UUID = "another-uuid"
+	s.writeArchive(c, meta, []string{"0", "1"})
+
+	_, err := s.newRestorer(c).Plan()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `backup of controller "another-uuid" restored to controller "controller-uuid" not valid`)
+}
+
+func (s *restoreSuite) TestPlanMissingController(c *gc.C) {
+	meta := backups.NewMetadata()
+	meta.Origin.Version = version.MustParse("2.9.1")
+	meta.Controller.UUID = "controller-uuid"
+	s.writeArchive(c, meta, []string{"0", "1", "2"})
+
+	_, err := s.newRestorer(c).Plan()
+	c.Assert(err, gc.ErrorMatches, `backup has controller machines \[2\] which are not members of the replica set`)
+}
+
+func (s *restoreSuite) TestPlanMissingNode(c *gc.C) {
+	s.nodes = s.nodes[1:]
+
+	_, err := s.newRestorer(c).Plan()
+	c.Assert(err, gc.ErrorMatches, `no agent control for replica set members on machines \[1\]`)
+}
+
+func (s *restoreSuite) TestRestore(c *gc.C) {
+	s.database.members = append(s.database.members, replicaset.Member{
+		Id: 3, Address: "10.0.0.3:37017", Tags: map[string]string{"juju-machine-id": "2"},
+	})
+	s.nodes = append([]backups.ControllerNode{&fakeControllerNode{stub: &s.stub, id: "2"}}, s.nodes...)
+
+	plan, err := s.newRestorer(c).Restore()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.RemovedMembers, jc.DeepEquals, []string{"2"})
+
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"ReplicaSetMembers", nil},
+		{"StopAgent", []interface{}{"2"}},
+		{"StopAgent", []interface{}{"1"}},
+		{"StopAgent", []interface{}{"0"}},
+		{"Restore", []interface{}{s.archive.DBDumpDir}},
+		{"ReplicaSetMembers", nil},
+		{"SetReplicaSetMembers", []interface{}{s.database.members[:2]}},
+		{"StartAgent", []interface{}{"0"}},
+		{"StartAgent", []interface{}{"1"}},
+	})
+	data, err := ioutil.ReadFile(filepath.Join(s.rootDir, "var/lib/juju/shared-secret"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "secret")
+}
+
+func (s *restoreSuite) TestRestoreStopAgentFails(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.New("boom"))
+
+	_, err := s.newRestorer(c).Restore()
+	c.Assert(err, gc.ErrorMatches, "stopping agent on controller machine 0: boom")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"ReplicaSetMembers", nil},
+		{"StopAgent", []interface{}{"1"}},
+		{"StopAgent", []interface{}{"0"}},
+		{"StartAgent", []interface{}{"1"}},
+	})
+}
+
+func (s *restoreSuite) TestRestoreDatabaseFails(c *gc.C) {
+	s.stub.SetErrors(nil, nil, nil, errors.New("boom"))
+
+	_, err := s.newRestorer(c).Restore()
+	c.Assert(err, gc.ErrorMatches, "restoring databases: boom")
+	// The agents are left stopped.
+	s.stub.CheckCallNames(c, "ReplicaSetMembers", "StopAgent", "StopAgent", "Restore")
+}
+
+func (s *restoreSuite) TestCheckRestoreVersion(c *gc.C) {
+	for i, test := range []struct {
+		backup     string
+		controller string
+		ok         bool
+	}{
+		{"2.9.1", "2.9.1", true},
+		{"2.9.1", "2.9.3", true},
+		{"2.9.1.1", "2.9.1", true},
+		{"2.9.3", "2.9.1", false},
+		{"2.8.9", "2.9.1", false},
+		{"3.0.0", "2.9.1", false},
+	} {
+		c.Logf("test %d: %s to %s", i, test.backup, test.controller)
+		err := backups.CheckRestoreVersion(version.MustParse(test.backup), version.MustParse(test.controller))
+		if test.ok {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, errors.IsNotSupported)
+		}
+	}
+}
+
+type fakeRestoreDatabase struct {
+	stub    *testing.Stub
+	members []replicaset.Member
+}
+
+func (db *fakeRestoreDatabase) ReplicaSetMembers() ([]replicaset.Member, error) {
+	db.stub.AddCall("ReplicaSetMembers")
+	return db.members, db.stub.NextErr()
+}
+
+func (db *fakeRestoreDatabase) SetReplicaSetMembers(members []replicaset.Member) error {
+	db.stub.AddCall("SetReplicaSetMembers", members)
+	return db.stub.NextErr()
+}
+
+func (db *fakeRestoreDatabase) Restore(dumpDir string) error {
+	db.stub.AddCall("Restore", dumpDir)
+	return db.stub.NextErr()
+}
+
+type fakeControllerNode struct {
+	stub *testing.Stub
+	id   string
+}
+
+func (n *fakeControllerNode) ID() string {
+	return n.id
+}
+
+func (n *fakeControllerNode) StopAgent() error {
+	n.stub.AddCall("StopAgent", n.id)
+	return n.stub.NextErr()
+}
+
+func (n *fakeControllerNode) StartAgent() error {
+	n.stub.AddCall("StartAgent", n.id)
+	return n.stub.NextErr()
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// defaultS3Region is the region used when the backup
+// storage config doesn't specify one. S3-compatible stores
+// such as MinIO accept it regardless of their location.
+const defaultS3Region = "us-east-1"
+
+// remoteStorage stores backup archives outside the controller.
+type remoteStorage interface {
+	// Put stores the archive with the given ID,
+	// and returns the location it's stored at.
+	Put(id string, archive io.Reader) (string, error)
+
+	// Get returns the archive stored at the location.
+	Get(location string) (io.ReadCloser, error)
+
+	// Remove removes the archive stored at the location.
+	Remove(location string) error
+}
+
+// s3Storage stores backup archives in an S3-compatible object store.
+// The locations of the archives are s3://<bucket>/<key> URLs.
+type s3Storage struct {
+	client    *s3.S3
+	uploader  *s3manager.Uploader
+	bucket    string
+	keyPrefix string
+}
+
+// newS3Storage returns a remoteStorage which stores the archives of
+// the model's backups in the object store described by the config.
+func newS3Storage(config controller.BackupStorageS3Config, modelUUID string) (remoteStorage, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	region := config.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint: aws.String(config.Endpoint),
+		Region:   aws.String(region),
+		Credentials: credentials.NewStaticCredentialsFromCreds(credentials.Value{
+			AccessKeyID:     config.AccessKey,
+			SecretAccessKey: config.SecretKey,
+		}),
+		// Stores other than AWS rarely support
+		// virtual-host style bucket addressing.
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backup storage session")
+	}
+	client := s3.New(sess)
+	return &s3Storage{
+		client:    client,
+		uploader:  s3manager.NewUploaderWithClient(client),
+		bucket:    config.Bucket,
+		keyPrefix: path.Join(backupStorageRoot, modelUUID),
+	}, nil
+}
+
+// Put is part of the remoteStorage interface.
+func (s *s3Storage) Put(id string, archive io.Reader) (string, error) {
+	key := path.Join(s.keyPrefix, id)
+	_, err := s.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   archive,
+	})
+	if err != nil {
+		return "", errors.Annotatef(err, "uploading backup archive to bucket %q", s.bucket)
+	}
+	location := url.URL{Scheme: "s3", Host: s.bucket, Path: "/" + key}
+	return location.String(), nil
+}
+
+// Get is part of the remoteStorage interface.
+func (s *s3Storage) Get(location string) (io.ReadCloser, error) {
+	bucket, key, err := parseS3Location(location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("backup archive %s", location)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting backup archive %s", location)
+	}
+	return out.Body, nil
+}
+
+// Remove is part of the remoteStorage interface.
+func (s *s3Storage) Remove(location string) error {
+	bucket, key, err := parseS3Location(location)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if isS3NotFound(err) {
+		return errors.NotFoundf("backup archive %s", location)
+	}
+	return errors.Annotatef(err, "removing backup archive %s", location)
+}
+
+func parseS3Location(location string) (bucket, key string, _ error) {
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme != "s3" || u.Host == "" || len(u.Path) < 2 {
+		return "", "", errors.NotValidf("backup archive location %q", location)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+func isS3NotFound(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case s3.ErrCodeNoSuchKey, s3.ErrCodeNoSuchBucket, "NotFound":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+)
+
+type s3StorageSuite struct {
+	testing.IsolationSuite
+
+	server  *httptest.Server
+	objects *fakeObjectStore
+	config  controller.BackupStorageS3Config
+}
+
+var _ = gc.Suite(&s3StorageSuite{})
+
+func (s *s3StorageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.objects = &fakeObjectStore{
+		bucket:  "juju-backups",
+		objects: make(map[string][]byte),
+	}
+	s.server = httptest.NewServer(s.objects)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.config = controller.BackupStorageS3Config{
+		Endpoint:  s.server.URL,
+		Bucket:    "juju-backups",
+		AccessKey: "access-key",
+		SecretKey: "secret-key",
+	}
+}
+
+func (s *s3StorageSuite) TestPutGetRemove(c *gc.C) {
+	storage, err := backups.NewS3Storage(s.config, "model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+
+	location, err := storage.Put("backup-id", strings.NewReader("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(location, gc.Equals, "s3://juju-backups/backups/model-uuid/backup-id")
+	c.Check(s.objects.objects, jc.DeepEquals, map[string][]byte{
+		"backups/model-uuid/backup-id": []byte("<archive>"),
+	})
+
+	archive, err := storage.Get(location)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive.Close(), jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+
+	err = storage.Remove(location)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.objects.objects, gc.HasLen, 0)
+}
+
+func (s *s3StorageSuite) TestGetNotFound(c *gc.C) {
+	storage, err := backups.NewS3Storage(s.config, "model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = storage.Get("s3://juju-backups/backups/model-uuid/missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *s3StorageSuite) TestPutMissingBucket(c *gc.C) {
+	s.config.Bucket = "other"
+	storage, err := backups.NewS3Storage(s.config, "model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = storage.Put("backup-id", strings.NewReader("<archive>"))
+	c.Assert(err, gc.ErrorMatches, `(?s)uploading backup archive to bucket "other": NoSuchBucket.*`)
+}
+
+func (s *s3StorageSuite) TestGetInvalidLocation(c *gc.C) {
+	storage, err := backups.NewS3Storage(s.config, "model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = storage.Get("/var/lib/juju/backups/backup-id")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *s3StorageSuite) TestInvalidConfig(c *gc.C) {
+	s.config.Bucket = ""
+	_, err := backups.NewS3Storage(s.config, "model-uuid")
+	c.Assert(err, gc.ErrorMatches, "backup-storage-s3-bucket .*")
+}
+
+// fakeObjectStore is a minimal S3-compatible object store, serving
+// path-style requests for the objects in a single bucket.
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func (f *fakeObjectStore) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 2)
+	if parts[0] != f.bucket {
+		f.writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	if len(parts) < 2 || parts[1] == "" {
+		f.writeError(w, http.StatusBadRequest, "InvalidRequest")
+		return
+	}
+	key := parts[1]
+	switch req.Method {
+	case "PUT":
+		data, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			f.writeError(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		f.objects[key] = data
+	case "GET":
+		data, ok := f.objects[key]
+		if !ok {
+			f.writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		_, _ = w.Write(data)
+	case "DELETE":
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (f *fakeObjectStore) writeError(w http.ResponseWriter, status int, code string) {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	body.WriteString("<Error><Code>" + code + "</Code><Message>" + code + "</Message></Error>")
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write(body.Bytes())
+}
@@ -47,6 +47,8 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	Scheduled bool `bson:"scheduled,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -54,6 +56,11 @@ type storageMetaDoc struct {
 	Hostname string         `bson:"hostname"`
 	Version  version.Number `bson:"version"`
 	Series   string         `bson:"series"`
+
+	// archive
+
+	EncryptedTo []string `bson:"encrypted-to,omitempty"`
+	Location    string   `bson:"location,omitempty"`
 }
 
 func (doc *storageMetaDoc) isFileInfoComplete() bool {
@@ -123,6 +130,7 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Scheduled = doc.Scheduled
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -130,6 +138,9 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta.Origin.Version = doc.Version
 	meta.Origin.Series = doc.Series
 
+	meta.EncryptedTo = doc.EncryptedTo
+	meta.Location = doc.Location
+
 	meta.SetID(doc.ID)
 
 	if doc.Finished != 0 {
@@ -174,6 +185,7 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Scheduled = meta.Scheduled
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -181,6 +193,9 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 	doc.Version = meta.Origin.Version
 	doc.Series = meta.Origin.Series
 
+	// Ignore metadata.Location. It will be set by storage later.
+	doc.EncryptedTo = meta.EncryptedTo
+
 	return doc
 }
 
@@ -378,6 +393,21 @@ func setStorageStoredTime(dbWrap *storageDBWrapper, id string, stored time.Time)
 	return nil
 }
 
+// setStorageLocation updates the backup metadata associated with "id"
+// to record where its archive is stored. If "id" does not match any
+// stored records, an error satisfying juju/errors.IsNotFound() is
+// returned.
+func setStorageLocation(dbWrap *storageDBWrapper, id string, location string) error {
+	op := dbWrap.txnOpUpdate(id, bson.DocElem{"location", location})
+	if err := dbWrap.runTransaction([]txn.Op{op}); err != nil {
+		if errors.Cause(err) == txn.ErrAborted {
+			return errors.NotFoundf("backup metadata %q", id)
+		}
+		return errors.Annotate(err, "while running transaction")
+	}
+	return nil
+}
+
 //---------------------------
 // metadata storage
 
@@ -526,6 +556,110 @@ func (s *backupBlobStorage) Close() error {
 	return s.dbWrap.Close()
 }
 
+// locatingFileStorage stores archives in remote storage when it's
+// configured, and in the controller's database otherwise. Where each
+// archive is stored is recorded in its metadata, so archives stored
+// before the backup storage was configured, or changed, can still
+// be found.
+type locatingFileStorage struct {
+	dbWrap *storageDBWrapper
+	local  filestorage.RawFileStorage
+	// remote returns the configured remote storage,
+	// or nil if none is configured.
+	remote func() (remoteStorage, error)
+}
+
+func newLocatingFileStorage(
+	dbWrap *storageDBWrapper, local filestorage.RawFileStorage, remote func() (remoteStorage, error),
+) filestorage.RawFileStorage {
+	return &locatingFileStorage{
+		dbWrap: dbWrap.Copy(),
+		local:  local,
+		remote: remote,
+	}
+}
+
+// location returns where the identified archive is stored,
+// and the remote storage it's stored in if it's not local.
+func (s *locatingFileStorage) location(id string) (string, remoteStorage, error) {
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+
+	doc, err := getStorageMetadata(dbWrap, id)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if doc.Location == "" {
+		return "", nil, nil
+	}
+	remote, err := s.remote()
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if remote == nil {
+		return "", nil, errors.Errorf("backup archive stored at %s, but no backup storage is configured", doc.Location)
+	}
+	return doc.Location, remote, nil
+}
+
+// File returns the identified file from storage.
+func (s *locatingFileStorage) File(id string) (io.ReadCloser, error) {
+	location, remote, err := s.location(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if remote == nil {
+		file, err := s.local.File(id)
+		return file, errors.Trace(err)
+	}
+	file, err := remote.Get(location)
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage.
+func (s *locatingFileStorage) AddFile(id string, file io.Reader, size int64) error {
+	remote, err := s.remote()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if remote == nil {
+		return errors.Trace(s.local.AddFile(id, file, size))
+	}
+	location, err := remote.Put(id, file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+	if err := setStorageLocation(dbWrap, id, location); err != nil {
+		_ = remote.Remove(location)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *locatingFileStorage) RemoveFile(id string) error {
+	location, remote, err := s.location(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if remote == nil {
+		return errors.Trace(s.local.RemoveFile(id))
+	}
+	return errors.Trace(remote.Remove(location))
+}
+
+// Close closes the storage.
+func (s *locatingFileStorage) Close() error {
+	err := s.local.Close()
+	if cerr := s.dbWrap.Close(); err == nil {
+		err = cerr
+	}
+	return errors.Trace(err)
+}
+
 //---------------------------
 // backup storage
 
@@ -555,14 +689,27 @@ type DB interface {
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
-// archives (and metadata).
+// archives (and metadata). Archives are stored in the controller's
+// database, unless the controller config specifies an S3-compatible
+// object store to store them in.
 func NewStorage(st DB) filestorage.FileStorage {
 	modelUUID := st.ModelTag().Id()
 	db := st.MongoSession().DB(storageDBName)
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
+	remote := func() (remoteStorage, error) {
+		controllerConfig, err := st.ControllerConfig()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting backup storage config")
+		}
+		config := controllerConfig.BackupStorageS3Config()
+		if !config.Enabled() {
+			return nil, nil
+		}
+		return newS3Storage(config, modelUUID)
+	}
+	files := newLocatingFileStorage(dbWrap, newFileStorage(dbWrap, backupStorageRoot), remote)
 	docs := newMetadataStorage(dbWrap)
 	return filestorage.NewFileStorage(docs, files)
 }
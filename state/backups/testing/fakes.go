@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2/filestorage"
+	"golang.org/x/crypto/openpgp"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/instance"
@@ -52,6 +53,8 @@ type FakeBackups struct {
 	KeepCopy bool
 	// NoDownload holds the noDownload bool that was passed in.
 	NoDownload bool
+	// EncryptTo holds the encryption keys that were passed in.
+	EncryptTo openpgp.EntityList
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -63,6 +66,7 @@ func (b *FakeBackups) Create(
 	paths *backups.Paths,
 	dbInfo *backups.DBInfo,
 	keepCopy, noDownload bool,
+	encryptTo openpgp.EntityList,
 ) (string, error) {
 	b.Calls = append(b.Calls, "Create")
 
@@ -71,6 +75,7 @@ func (b *FakeBackups) Create(
 	b.MetaArg = meta
 	b.KeepCopy = keepCopy
 	b.NoDownload = noDownload
+	b.EncryptTo = encryptTo
 
 	if b.Meta != nil {
 		*meta = *b.Meta
@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const backupStatusKey = "backupStatus"
+
+// BackupStatus records the outcome of the controller's
+// scheduled backups.
+type BackupStatus struct {
+	// LastAttempt is when a scheduled backup was last started.
+	LastAttempt time.Time
+
+	// LastSuccess is when the last successful scheduled
+	// backup was started.
+	LastSuccess time.Time
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string
+
+	// LastError holds the reason the last scheduled backup
+	// failed, or "" if it succeeded.
+	LastError string
+}
+
+// Overdue reports whether backups are overdue at the given time: that
+// is, whether the last successful backup was started more than maxAge
+// ago, or backups have been attempted but none has succeeded. Backups
+// are never overdue if maxAge is zero.
+func (s BackupStatus) Overdue(now time.Time, maxAge time.Duration) bool {
+	if maxAge <= 0 {
+		return false
+	}
+	if s.LastSuccess.IsZero() {
+		return !s.LastAttempt.IsZero()
+	}
+	return now.Sub(s.LastSuccess) > maxAge
+}
+
+type backupStatusDoc struct {
+	LastAttempt  int64  `bson:"last-attempt"`
+	LastSuccess  int64  `bson:"last-success"`
+	LastBackupID string `bson:"last-backup-id"`
+	LastError    string `bson:"last-error"`
+}
+
+func unixNanoTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t).UTC()
+}
+
+func timeUnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// BackupStatus returns the outcome of the controller's scheduled
+// backups. The zero value is returned if none has been attempted.
+func (st *State) BackupStatus() (BackupStatus, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc backupStatusDoc
+	err := controllers.FindId(backupStatusKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return BackupStatus{}, nil
+	}
+	if err != nil {
+		return BackupStatus{}, errors.Annotate(err, "getting backup status")
+	}
+	return BackupStatus{
+		LastAttempt:  unixNanoTime(doc.LastAttempt),
+		LastSuccess:  unixNanoTime(doc.LastSuccess),
+		LastBackupID: doc.LastBackupID,
+		LastError:    doc.LastError,
+	}, nil
+}
+
+// SetBackupStatus records the outcome of the controller's
+// scheduled backups.
+func (st *State) SetBackupStatus(status BackupStatus) error {
+	doc := backupStatusDoc{
+		LastAttempt:  timeUnixNano(status.LastAttempt),
+		LastSuccess:  timeUnixNano(status.LastSuccess),
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		controllers, closer := st.db().GetCollection(controllersC)
+		defer closer()
+		n, err := controllers.FindId(backupStatusKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     backupStatusKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     backupStatusKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", doc}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "setting backup status")
+	}
+	return nil
+}
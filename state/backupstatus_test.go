@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type BackupStatusSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&BackupStatusSuite{})
+
+func (s *BackupStatusSuite) TestBackupStatusNotSet(c *gc.C) {
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupStatus{})
+}
+
+func (s *BackupStatusSuite) TestSetBackupStatus(c *gc.C) {
+	attempt := time.Date(2021, 5, 24, 3, 0, 0, 0, time.UTC)
+	success := attempt.Add(-24 * time.Hour)
+
+	err := s.State.SetBackupStatus(state.BackupStatus{
+		LastAttempt:  success,
+		LastSuccess:  success,
+		LastBackupID: "20210523-030000.uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := state.BackupStatus{
+		LastAttempt:  attempt,
+		LastSuccess:  success,
+		LastBackupID: "20210523-030000.uuid",
+		LastError:    "disk full",
+	}
+	err = s.State.SetBackupStatus(expected)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, expected)
+}
+
+type BackupStatusOverdueSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BackupStatusOverdueSuite{})
+
+func (s *BackupStatusOverdueSuite) TestOverdue(c *gc.C) {
+	now := time.Date(2021, 5, 24, 12, 0, 0, 0, time.UTC)
+	for i, test := range []struct {
+		about   string
+		status  state.BackupStatus
+		maxAge  time.Duration
+		overdue bool
+	}{{
+		about:  "never attempted",
+		status: state.BackupStatus{},
+		maxAge: time.Hour,
+	}, {
+		about: "attempted, never succeeded",
+		status: state.BackupStatus{
+			LastAttempt: now.Add(-time.Minute),
+			LastError:   "boom",
+		},
+		maxAge:  time.Hour,
+		overdue: true,
+	}, {
+		about: "recent success",
+		status: state.BackupStatus{
+			LastAttempt: now.Add(-30 * time.Minute),
+			LastSuccess: now.Add(-30 * time.Minute),
+		},
+		maxAge: time.Hour,
+	}, {
+		about: "old success",
+		status: state.BackupStatus{
+			LastAttempt: now.Add(-time.Minute),
+			LastSuccess: now.Add(-2 * time.Hour),
+			LastError:   "boom",
+		},
+		maxAge:  time.Hour,
+		overdue: true,
+	}, {
+		about: "warning disabled",
+		status: state.BackupStatus{
+			LastSuccess: now.Add(-2 * time.Hour),
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(test.status.Overdue(now, test.maxAge), gc.Equals, test.overdue)
+	}
+}
@@ -61,6 +61,7 @@ const (
 	cleanupStorageForDyingModel  cleanupKind = "modelStorage"
 	cleanupForceStorage          cleanupKind = "forceStorage"
 	cleanupBranchesForDyingModel cleanupKind = "branches"
+	cleanupSecretContent         cleanupKind = "secretContent"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -206,6 +207,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupForceStorage(args)
 		case cleanupBranchesForDyingModel:
 			err = st.cleanupBranchesForDyingModel(args)
+		case cleanupSecretContent:
+			err = st.cleanupSecretContent(args)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
@@ -243,6 +246,20 @@ func (st *State) cleanupResourceBlob(storagePath string) error {
 	return errors.Trace(err)
 }
 
+// cleanupSecretContent removes from their external backends the
+// content of secret revisions which have been removed from state.
+// Deleting content which no longer exists is not an error, so the
+// whole cleanup can be safely retried.
+func (st *State) cleanupSecretContent(cleanupArgs []bson.Raw) error {
+	refs := make([]valueRefDoc, len(cleanupArgs))
+	for i, arg := range cleanupArgs {
+		if err := arg.Unmarshal(&refs[i]); err != nil {
+			return errors.Annotatef(err, "unmarshalling cleanup arg %d", i)
+		}
+	}
+	return errors.Trace(st.deleteSecretContent(refs))
+}
+
 func (st *State) cleanupRelationSettings(prefix string) error {
 	change := relationSettingsCleanupChange{Prefix: st.docID(prefix)}
 	if err := Apply(st.database, change); err != nil {
@@ -864,8 +881,8 @@ func (st *State) cleanupDyingUnit(name string, cleanupArgs []bson.Raw) error {
 	}
 
 	if destroyStorage {
-		// Detach and mark storage instances as dying, allowing the
-		// unit to terminate.
+		// Detach and mark the unit's storage instances as dying,
+		// allowing the unit to terminate.
 		return st.cleanupUnitStorageInstances(unit.UnitTag(), force, maxWait)
 	} else {
 		// Mark storage attachments as dying, so that they are detached
@@ -1097,7 +1114,20 @@ func (st *State) cleanupUnitStorageInstances(unitTag names.UnitTag, force bool,
 	}
 	for _, storageAttachment := range storageAttachments {
 		storageTag := storageAttachment.StorageInstance()
-		err := sb.DestroyStorageInstance(storageTag, true, force, maxWait)
+		si, err := sb.storageInstance(storageTag)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if owner, ok := si.Owner(); ok && owner.Kind() == names.ApplicationTagKind {
+			// Shared storage belongs to the application, and is
+			// destroyed (or released) along with it; only detach
+			// it from this unit.
+			err = sb.DetachStorage(storageTag, unitTag, force, maxWait)
+		} else {
+			err = sb.DestroyStorageInstance(storageTag, true, force, maxWait)
+		}
 		if errors.IsNotFound(err) {
 			continue
 		} else if err != nil {
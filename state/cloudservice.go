@@ -4,7 +4,10 @@
 package state
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -26,6 +29,20 @@ type CloudServicer interface {
 
 	// DesiredScaleProtected indicates if current desired scale in application has been applied to the cluster.
 	DesiredScaleProtected() bool
+
+	// Autoscaler returns the observed state of the service's autoscaler,
+	// or nil if the service is not autoscaled.
+	Autoscaler() *AutoscalerStatus
+}
+
+// AutoscalerStatus represents the observed state of a cloud service's
+// autoscaler.
+type AutoscalerStatus struct {
+	MinReplicas     int
+	MaxReplicas     int
+	CurrentReplicas int
+	DesiredReplicas int
+	Message         string
 }
 
 // CloudService is an implementation of CloudService.
@@ -50,6 +67,18 @@ type cloudServiceDoc struct {
 	// It prevents the desired scale requested from CLI by user incidentally updated by
 	// k8s cluster replicas before having a chance to be applied/deployed.
 	DesiredScaleProtected bool `bson:"desired-scale-protected"`
+
+	// Autoscaler holds the observed state of the autoscaler that controls
+	// the number of replicas of the service, if there is one.
+	Autoscaler *autoscalerDoc `bson:"autoscaler,omitempty"`
+}
+
+type autoscalerDoc struct {
+	MinReplicas     int    `bson:"min-replicas"`
+	MaxReplicas     int    `bson:"max-replicas"`
+	CurrentReplicas int    `bson:"current-replicas"`
+	DesiredReplicas int    `bson:"desired-replicas"`
+	Message         string `bson:"message,omitempty"`
 }
 
 func newCloudService(st *State, doc *cloudServiceDoc) *CloudService {
@@ -85,6 +114,20 @@ func (c *CloudService) DesiredScaleProtected() bool {
 	return c.doc.DesiredScaleProtected
 }
 
+// Autoscaler implements CloudServicer.
+func (c *CloudService) Autoscaler() *AutoscalerStatus {
+	if c.doc.Autoscaler == nil {
+		return nil
+	}
+	return &AutoscalerStatus{
+		MinReplicas:     c.doc.Autoscaler.MinReplicas,
+		MaxReplicas:     c.doc.Autoscaler.MaxReplicas,
+		CurrentReplicas: c.doc.Autoscaler.CurrentReplicas,
+		DesiredReplicas: c.doc.Autoscaler.DesiredReplicas,
+		Message:         c.doc.Autoscaler.Message,
+	}
+}
+
 func (c *CloudService) cloudServiceDoc() (*cloudServiceDoc, error) {
 	coll, closer := c.st.db().GetCollection(cloudServicesC)
 	defer closer()
@@ -167,6 +210,56 @@ func buildCloudServiceOps(st *State, doc cloudServiceDoc) ([]txn.Op, error) {
 	}}, nil
 }
 
+// SetAutoscalerStatus records the observed state of the autoscaler of the
+// application's cloud service, or clears it if status is nil.
+// This is used on CAAS models.
+func (a *Application) SetAutoscalerStatus(status *AutoscalerStatus) error {
+	var autoscaler *autoscalerDoc
+	if status != nil {
+		autoscaler = &autoscalerDoc{
+			MinReplicas:     status.MinReplicas,
+			MaxReplicas:     status.MaxReplicas,
+			CurrentReplicas: status.CurrentReplicas,
+			DesiredReplicas: status.DesiredReplicas,
+			Message:         status.Message,
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		svc := newCloudService(a.st, &cloudServiceDoc{DocID: a.globalKey()})
+		existing, err := svc.cloudServiceDoc()
+		if errors.IsNotFound(err) {
+			if autoscaler == nil {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return []txn.Op{{
+				C:      cloudServicesC,
+				Id:     a.globalKey(),
+				Assert: txn.DocMissing,
+				Insert: cloudServiceDoc{DocID: a.globalKey(), Autoscaler: autoscaler},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if reflect.DeepEqual(existing.Autoscaler, autoscaler) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$set", bson.D{{"autoscaler", autoscaler}}}}
+		if autoscaler == nil {
+			update = bson.D{{"$unset", bson.D{{"autoscaler", nil}}}}
+		}
+		return []txn.Op{{
+			C:      cloudServicesC,
+			Id:     a.globalKey(),
+			Assert: txn.DocExists,
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set autoscaler status for application %q", a)
+	}
+	return nil
+}
+
 func (a *Application) removeCloudServiceOps() []txn.Op {
 	ops := []txn.Op{{
 		C:      cloudServicesC,
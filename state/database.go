@@ -4,6 +4,7 @@
 package state
 
 import (
+	"context"
 	"runtime/debug"
 	"sync"
 
@@ -17,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/mongo"
 )
@@ -263,6 +265,10 @@ type database struct {
 	// clock is used to time how long transactions take to run
 	clock clock.Clock
 
+	// ctx holds the span, if any, of which spans covering
+	// transactions run against the database are children.
+	ctx context.Context
+
 	mu           sync.RWMutex
 	queryTracker *queryTracker
 }
@@ -281,9 +287,30 @@ func (db *database) copySession(modelUUID string) (*database, SessionCloser) {
 		ownSession:             true,
 		serverSideTransactions: db.serverSideTransactions,
 		clock:                  db.clock,
+		ctx:                    db.ctx,
 	}, session.Close
 }
 
+// withContext returns a copy of the database, sharing its session,
+// whose transaction spans are children of the span held by ctx.
+func (db *database) withContext(ctx context.Context) *database {
+	db.mu.RLock()
+	tracker := db.queryTracker
+	db.mu.RUnlock()
+	return &database{
+		raw:                    db.raw,
+		schema:                 db.schema,
+		modelUUID:              db.modelUUID,
+		runner:                 db.runner,
+		ownSession:             db.ownSession,
+		serverSideTransactions: db.serverSideTransactions,
+		runTransactionObserver: db.runTransactionObserver,
+		clock:                  db.clock,
+		ctx:                    ctx,
+		queryTracker:           tracker,
+	}
+}
+
 func (db *database) setTracker(tracker *queryTracker) {
 	db.mu.Lock()
 	db.queryTracker = tracker
@@ -396,18 +423,24 @@ func (db *database) TransactionRunner() (runner jujutxn.Runner, closer SessionCl
 
 // RunTransaction is part of the Database interface.
 func (db *database) RunTransaction(ops []txn.Op) error {
+	ctx, span := db.startTxnSpan("state.RunTransaction")
+	defer span.End()
 	runner, closer := db.TransactionRunner()
 	defer closer()
-	return runner.RunTransaction(&jujutxn.Transaction{Ops: ops})
+	err := runner.RunTransaction(&jujutxn.Transaction{Ops: ops})
+	span.SetAttributes(trace.IntAttr("txn.ops", len(ops)))
+	span.RecordError(err)
+	if err != nil {
+		trace.Logger(ctx, logger).Debugf("transaction of %d ops failed: %v", len(ops), err)
+	}
+	return err
 }
 
 // RunTransactionFor is part of the Database interface.
 func (db *database) RunTransactionFor(modelUUID string, ops []txn.Op) error {
 	newDB, dbcloser := db.CopyForModel(modelUUID)
 	defer dbcloser()
-	runner, closer := newDB.TransactionRunner()
-	defer closer()
-	return runner.RunTransaction(&jujutxn.Transaction{Ops: ops})
+	return newDB.RunTransaction(ops)
 }
 
 // RunRawTransaction is part of the Database interface.
@@ -422,9 +455,39 @@ func (db *database) RunRawTransaction(ops []txn.Op) error {
 
 // Run is part of the Database interface.
 func (db *database) Run(transactions jujutxn.TransactionSource) error {
+	ctx, span := db.startTxnSpan("state.Run")
+	defer span.End()
 	runner, closer := db.TransactionRunner()
 	defer closer()
-	return runner.Run(transactions)
+	// Record how many attempts were needed, since repeated
+	// attempts are a sign of contention on the documents.
+	var attempts int
+	err := runner.Run(func(attempt int) ([]txn.Op, error) {
+		attempts = attempt + 1
+		return transactions(attempt)
+	})
+	span.SetAttributes(trace.IntAttr("txn.attempts", attempts))
+	span.RecordError(err)
+	if err != nil {
+		trace.Logger(ctx, logger).Debugf("transaction failed after %d attempts: %v", attempts, err)
+	} else if attempts > 1 {
+		trace.Logger(ctx, logger).Debugf("transaction needed %d attempts", attempts)
+	}
+	return err
+}
+
+// startTxnSpan starts a span covering a transaction run against
+// the database, as a child of the database's context span if any.
+// Messages logged with the returned context are labelled with the
+// span's IDs.
+func (db *database) startTxnSpan(name string) (context.Context, trace.Span) {
+	ctx := db.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return trace.Start(ctx, name,
+		trace.StringAttr("juju.model-uuid", db.modelUUID),
+	)
 }
 
 // Schema is part of the Database interface.
@@ -67,6 +67,11 @@ type Filesystem interface {
 	// Releasing reports whether or not the filesystem is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size in MiB that the filesystem has
+	// been requested to grow to, if a resize is pending. RequestedSize
+	// returns true if there is a pending resize, otherwise false.
+	RequestedSize() (uint64, bool)
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -121,6 +126,11 @@ type filesystemDoc struct {
 	// the filesystem as being non-detachable, and to determine
 	// which filesystems must be removed along with said machine.
 	HostId string `bson:"hostid,omitempty"`
+
+	// RequestedSize is the size in MiB that a provisioned filesystem
+	// is to be grown to. It is cleared once the filesystem's info
+	// records a size at least this large.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -153,6 +163,16 @@ type FilesystemParams struct {
 	// filesystem entity for an existing volume backed filesystem.
 	volumeInfo *VolumeInfo
 
+	// snapshot, if non-empty, is the provider ID of the snapshot
+	// from which the filesystem's backing volume is to be created.
+	// It is only used when the filesystem is volume-backed.
+	snapshot string
+
+	// attachments, if non-zero, is the number of attachments that the
+	// filesystem is created with. This is only set when creating a
+	// shared filesystem for several units at once.
+	attachments int
+
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
 }
@@ -252,6 +272,11 @@ func (f *filesystem) Releasing() bool {
 	return f.doc.Releasing
 }
 
+// RequestedSize is required to implement Filesystem.
+func (f *filesystem) RequestedSize() (uint64, bool) {
+	return f.doc.RequestedSize, f.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return getStatus(f.mb.db(), filesystemGlobalKey(f.FilesystemTag().Id()), "filesystem")
@@ -260,7 +285,7 @@ func (f *filesystem) Status() (status.StatusInfo, error) {
 // SetStatus is required to implement StatusSetter.
 func (f *filesystem) SetStatus(fsStatus status.StatusInfo) error {
 	switch fsStatus.Status {
-	case status.Attaching, status.Attached, status.Detaching, status.Detached, status.Destroying:
+	case status.Attaching, status.Attached, status.Warning, status.Detaching, status.Detached, status.Destroying:
 	case status.Error:
 		if fsStatus.Message == "" {
 			return errors.Errorf("cannot set status %q without info", fsStatus.Status)
@@ -877,6 +902,7 @@ func removeFilesystemOps(sb *storageBackend, filesystem Filesystem, release, for
 		},
 		removeModelFilesystemRefOp(sb.mb, filesystem.Tag().Id()),
 		removeStatusOp(sb.mb, filesystem.globalKey()),
+		removeFilesystemUsageOp(sb.mb, filesystem.Tag().Id()),
 	}
 	// If the filesystem is backed by a volume, the volume should
 	// be destroyed once the filesystem is removed. The volume must
@@ -1094,6 +1120,7 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 			params.volumeInfo,
 			params.Pool,
 			params.Size,
+			params.snapshot,
 		}
 		volumeOps, volumeTag, err = sb.addVolumeOps(volumeParams, hostId)
 		if err != nil {
@@ -1126,6 +1153,9 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 		// Every new filesystem is created with one attachment.
 		doc.Params = &params
 		doc.AttachmentCount = 1
+		if params.attachments > 0 {
+			doc.AttachmentCount = params.attachments
+		}
 	}
 	if !detachable {
 		doc.HostId = origHostId
@@ -1276,11 +1306,63 @@ func (sb *storageBackend) SetFilesystemInfo(tag names.FilesystemTag, info Filesy
 			}
 		}
 		ops := setFilesystemInfoOps(tag, info, unsetParams)
+		if size, ok := fs.RequestedSize(); ok && info.Size >= size {
+			// The requested resize has been carried out.
+			ops = append(ops, txn.Op{
+				C:      filesystemsC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
 }
 
+// ResizeFilesystem requests that the specified provisioned filesystem be
+// grown to the given size in MiB. The resize is carried out asynchronously
+// by the storage provisioner responsible for the filesystem. Filesystems
+// backed by a volume are resized by resizing the volume.
+func (sb *storageBackend) ResizeFilesystem(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize filesystem %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		fs, err := getFilesystemByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := fs.Volume(); err == nil {
+			return nil, errors.NotSupportedf("resizing volume-backed filesystem")
+		}
+		if fs.Life() != Alive {
+			return nil, errors.New("filesystem is not alive")
+		}
+		info, err := fs.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if requested, ok := fs.RequestedSize(); ok && requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  filesystemsC,
+			Id: tag.Id(),
+			Assert: append(bson.D{
+				{"info", bson.D{{"$exists", true}}},
+				{"info.size", info.Size},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 func validateFilesystemInfoChange(newInfo, oldInfo FilesystemInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -174,6 +174,84 @@ func (s *FilesystemStateSuite) TestSetFilesystemInfoImmutable(c *gc.C) {
 	s.assertFilesystemInfo(c, filesystemTag, filesystemInfoSet)
 }
 
+func (s *FilesystemStateSuite) TestResizeFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	hostTag := s.maybeAssignUnit(c, u)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	if _, ok := hostTag.(names.MachineTag); ok {
+		machine := unitMachine(c, s.st, u)
+		err := machine.SetProvisioned("inst-id", "", "fake_nonce", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	filesystemInfo := state.FilesystemInfo{Size: 1024, FilesystemId: "fs-id"}
+	err := s.storageBackend.SetFilesystemInfo(filesystemTag, filesystemInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 512)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": cannot resize filesystem ".*0/0": new size 512MiB must be larger than current size 1024MiB`)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.filesystem(c, filesystemTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(4096))
+
+	filesystemInfo.Pool = "rootfs"
+	filesystemInfo.Size = 4096
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, filesystemInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.filesystem(c, filesystemTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertFilesystemInfo(c, filesystemTag, filesystemInfo)
+}
+
+func (s *FilesystemIAASModelSuite) TestSetFilesystemUsage(c *gc.C) {
+	filesystem, _ := s.setupFilesystemAttachment(c, "rootfs")
+	filesystemTag := filesystem.FilesystemTag()
+
+	_, err := s.storageBackend.FilesystemUsage(filesystemTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = filesystem.SetStatus(status.StatusInfo{Status: status.Attached})
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage := state.FilesystemUsage{BytesUsed: 50, BytesFree: 50, InodesUsed: 1, InodesFree: 9}
+	err = s.storageBackend.SetFilesystemUsage(filesystemTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err := s.storageBackend.FilesystemUsage(filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.BytesUsed, gc.Equals, uint64(50))
+	c.Assert(stored.InodesFree, gc.Equals, uint64(9))
+	c.Assert(stored.Updated.IsZero(), jc.IsFalse)
+	s.assertFilesystemStatus(c, filesystemTag, status.Attached, "")
+
+	// Crossing the default threshold of 90% marks the filesystem.
+	usage.BytesUsed, usage.BytesFree = 95, 5
+	err = s.storageBackend.SetFilesystemUsage(filesystemTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemStatus(c, filesystemTag, status.Warning, "95% of capacity used")
+
+	usage.BytesUsed, usage.BytesFree = 20, 80
+	err = s.storageBackend.SetFilesystemUsage(filesystemTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemStatus(c, filesystemTag, status.Attached, "")
+}
+
+func (s *FilesystemIAASModelSuite) TestSetFilesystemUsageNotFound(c *gc.C) {
+	err := s.storageBackend.SetFilesystemUsage(names.NewFilesystemTag("42"), state.FilesystemUsage{})
+	c.Assert(err, gc.ErrorMatches, `cannot set usage of filesystem "42": filesystem "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FilesystemStateSuite) assertFilesystemStatus(c *gc.C, tag names.FilesystemTag, expect status.Status, message string) {
+	info, err := s.filesystem(c, tag).Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, expect)
+	c.Assert(info.Message, gc.Equals, message)
+}
+
 func (s *FilesystemStateSuite) maybeAssignUnit(c *gc.C, u *state.Unit) names.Tag {
 	m, err := s.st.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,191 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// FilesystemUsage describes the space and inode usage of a
+// filesystem, as last reported by the machine it is attached to.
+type FilesystemUsage struct {
+	// BytesUsed is the number of bytes used on the filesystem.
+	BytesUsed uint64
+
+	// BytesFree is the number of bytes available to
+	// unprivileged users on the filesystem.
+	BytesFree uint64
+
+	// InodesUsed is the number of inodes used on the filesystem.
+	InodesUsed uint64
+
+	// InodesFree is the number of free inodes on the filesystem.
+	InodesFree uint64
+
+	// Updated is the time at which the usage was reported.
+	Updated time.Time
+}
+
+// UsedPercent returns the percentage of the filesystem's
+// available capacity that is in use, rounded down.
+func (u FilesystemUsage) UsedPercent() int {
+	total := u.BytesUsed + u.BytesFree
+	if total == 0 {
+		return 0
+	}
+	return int(u.BytesUsed * 100 / total)
+}
+
+// filesystemUsageDoc records the usage of a filesystem. Usage is
+// kept apart from the filesystem document, so that the periodic
+// reports do not trigger the filesystem watchers.
+type filesystemUsageDoc struct {
+	DocID      string `bson:"_id"`
+	ModelUUID  string `bson:"model-uuid"`
+	BytesUsed  uint64 `bson:"bytes-used"`
+	BytesFree  uint64 `bson:"bytes-free"`
+	InodesUsed uint64 `bson:"inodes-used"`
+	InodesFree uint64 `bson:"inodes-free"`
+	Updated    int64  `bson:"updated"`
+}
+
+func (doc filesystemUsageDoc) usage() FilesystemUsage {
+	return FilesystemUsage{
+		BytesUsed:  doc.BytesUsed,
+		BytesFree:  doc.BytesFree,
+		InodesUsed: doc.InodesUsed,
+		InodesFree: doc.InodesFree,
+		Updated:    time.Unix(0, doc.Updated).UTC(),
+	}
+}
+
+// FilesystemUsage returns the last reported usage of the filesystem
+// with the specified tag. If no usage has been reported, an error
+// satisfying errors.IsNotFound will be returned.
+func (sb *storageBackend) FilesystemUsage(tag names.FilesystemTag) (FilesystemUsage, error) {
+	coll, closer := sb.mb.db().GetCollection(filesystemUsageC)
+	defer closer()
+
+	var doc filesystemUsageDoc
+	if err := coll.FindId(tag.Id()).One(&doc); err == mgo.ErrNotFound {
+		return FilesystemUsage{}, errors.NotFoundf("usage of filesystem %q", tag.Id())
+	} else if err != nil {
+		return FilesystemUsage{}, errors.Annotatef(err, "cannot get usage of filesystem %q", tag.Id())
+	}
+	return doc.usage(), nil
+}
+
+// SetFilesystemUsage records the usage of the filesystem with the
+// specified tag. If the proportion of the filesystem's capacity that
+// is used crosses the model's storage usage threshold, the status of
+// the filesystem is changed between "attached" and "warning".
+func (sb *storageBackend) SetFilesystemUsage(tag names.FilesystemTag, usage FilesystemUsage) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set usage of filesystem %q", tag.Id())
+	if usage.Updated.IsZero() {
+		usage.Updated = sb.mb.clock().Now()
+	}
+	doc := filesystemUsageDoc{
+		DocID:      sb.mb.docID(tag.Id()),
+		BytesUsed:  usage.BytesUsed,
+		BytesFree:  usage.BytesFree,
+		InodesUsed: usage.InodesUsed,
+		InodesFree: usage.InodesFree,
+		Updated:    usage.Updated.UnixNano(),
+	}
+	var fs *filesystem
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := getFilesystemByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fs = f
+		ops := []txn.Op{{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: txn.DocExists,
+		}}
+		if _, err := sb.FilesystemUsage(tag); errors.IsNotFound(err) {
+			ops = append(ops, txn.Op{
+				C:      filesystemUsageC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		} else {
+			ops = append(ops, txn.Op{
+				C:      filesystemUsageC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"bytes-used", doc.BytesUsed},
+					{"bytes-free", doc.BytesFree},
+					{"inodes-used", doc.InodesUsed},
+					{"inodes-free", doc.InodesFree},
+					{"updated", doc.Updated},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := sb.mb.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sb.updateFilesystemUsageStatus(fs, usage))
+}
+
+// updateFilesystemUsageStatus sets the status of an attached filesystem
+// to "warning" when its usage is at or above the model's threshold, and
+// back to "attached" when it drops below. Other statuses are left alone.
+func (sb *storageBackend) updateFilesystemUsageStatus(fs *filesystem, usage FilesystemUsage) error {
+	cfg, err := sb.config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	threshold := cfg.StorageUsageThreshold()
+	current, err := fs.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	used := usage.UsedPercent()
+	exceeded := threshold > 0 && used >= threshold
+	switch current.Status {
+	case status.Attached:
+		if !exceeded {
+			return nil
+		}
+	case status.Warning:
+		if !exceeded {
+			return fs.SetStatus(status.StatusInfo{Status: status.Attached})
+		}
+	default:
+		return nil
+	}
+	message := fmt.Sprintf("%d%% of capacity used", used)
+	if current.Status == status.Warning && current.Message == message {
+		return nil
+	}
+	return fs.SetStatus(status.StatusInfo{
+		Status:  status.Warning,
+		Message: message,
+	})
+}
+
+func removeFilesystemUsageOp(mb modelBackend, filesystemId string) txn.Op {
+	return txn.Op{
+		C:      filesystemUsageC,
+		Id:     mb.docID(filesystemId),
+		Remove: true,
+	}
+}
@@ -244,6 +244,13 @@ type Action interface {
 	// Messages returns the action's progress messages.
 	Messages() []ActionMessage
 
+	// LogOutput adds output written by the action to stdout or
+	// stderr to the action's output array.
+	LogOutput(stream, output string) error
+
+	// Output returns the output written by the action while it ran.
+	Output() []ActionMessage
+
 	// Cancel or Abort the action.
 	Cancel() (Action, error)
 
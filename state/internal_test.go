@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	secretsprovider "github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/storage/provider/dummy"
@@ -174,3 +175,7 @@ func (internalStatePolicy) StorageProviderRegistry() (storage.ProviderRegistry,
 func (internalStatePolicy) ProviderConfigSchemaSource(cloudName string) (config.ConfigSchemaSource, error) {
 	return nil, errors.NotImplementedf("ConfigSchemaSource")
 }
+
+func (internalStatePolicy) SecretsStore(backendType string) (secretsprovider.SecretsStore, error) {
+	return nil, errors.NotImplementedf("SecretsStore")
+}
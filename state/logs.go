@@ -321,6 +321,7 @@ type logDoc struct {
 	Location string        `bson:"l"` // "filename:lineno"
 	Level    int           `bson:"v"`
 	Message  string        `bson:"x"`
+	Labels   []string      `bson:"c,omitempty"`
 }
 
 type DbLogger struct {
@@ -368,6 +369,7 @@ func (logger *DbLogger) Log(records []LogRecord) error {
 			Location: r.Location,
 			Level:    int(r.Level),
 			Message:  r.Message,
+			Labels:   r.Labels,
 		})
 	}
 	_, err := bulk.Run()
@@ -427,6 +429,7 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+	Labels   []string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
@@ -434,6 +437,7 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -441,6 +445,9 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
+	IncludeLabel  []string
+	ExcludeLabel  []string
+	MessageRegex  string          // PCRE, as evaluated by MongoDB's $regex
 	Oplog         *mgo.Collection // For testing only
 }
 
@@ -738,8 +745,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeRange := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeRange["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeRange["$lte"] = params.EndTime.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeRange})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -760,6 +774,19 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	labels := bson.M{}
+	if len(params.IncludeLabel) > 0 {
+		labels["$in"] = params.IncludeLabel
+	}
+	if len(params.ExcludeLabel) > 0 {
+		labels["$nin"] = params.ExcludeLabel
+	}
+	if len(labels) > 0 {
+		sel = append(sel, bson.DocElem{"c", labels})
+	}
+	if params.MessageRegex != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessageRegex}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -861,6 +888,7 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+		Labels:   doc.Labels,
 	}
 	return rec, nil
 }
@@ -161,6 +161,7 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 		Location: "bar.go:42",
 		Level:    loggo.ERROR,
 		Message:  "oh noes",
+		Labels:   []string{"http"},
 	}})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -182,6 +183,9 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["c"], jc.DeepEquals, []interface{}{"http"})
+	_, ok := docs[0]["c"]
+	c.Assert(ok, jc.IsFalse)
 }
 
 type LogTailerSuite struct {
@@ -274,6 +278,40 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT, threshT.Add(5*time.Second), 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(10*time.Second), threshT.Add(15*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   threshT.Add(5 * time.Second),
+		NoTail:    true,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -627,6 +665,45 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestMessageRegexFiltering(c *gc.C) {
+	hook := logTemplate{Message: `hook "install" failed`}
+	other := logTemplate{Message: "all is well"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, hook)
+		s.writeLogs(c, s.otherUUID, 1, other)
+	}
+	params := state.LogTailerParams{
+		MessageRegex: `^hook ".*" failed$`,
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, hook)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeLabel(c *gc.C) {
+	http := logTemplate{Labels: []string{"http"}}
+	httpDebug := logTemplate{Labels: []string{"http", "debug"}}
+	db := logTemplate{Labels: []string{"db"}}
+	none := logTemplate{}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, none)
+		s.writeLogs(c, s.otherUUID, 1, http)
+		s.writeLogs(c, s.otherUUID, 1, httpDebug)
+		s.writeLogs(c, s.otherUUID, 1, db)
+	}
+	params := state.LogTailerParams{
+		IncludeLabel: []string{"http", "db"},
+		ExcludeLabel: []string{"debug"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, http)
+		s.assertTailer(c, tailer, 1, db)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -656,6 +733,7 @@ type logTemplate struct {
 	Location string
 	Level    loggo.Level
 	Message  string
+	Labels   []string
 }
 
 // emptyTag gives us an explicit way to specify an empty tag for the
@@ -744,6 +822,7 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 		lt.Location,
 		lt.Level,
 		lt.Message,
+		lt.Labels...,
 	)
 }
 
@@ -764,6 +843,7 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Labels, jc.DeepEquals, lt.Labels)
 			count++
 			if count == expectedCount {
 				return
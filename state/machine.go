@@ -1286,6 +1286,76 @@ func (m *Machine) AvailabilityZone() (string, error) {
 	return zone, nil
 }
 
+// SetAvailabilityZone records the availability zone that the machine's
+// instance is running in, for providers that can move instances between
+// zones after they have been provisioned.
+func (m *Machine) SetAvailabilityZone(zone string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := m.AvailabilityZone()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current == zone {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"availzone", zone}}}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot set availability zone for machine %v", m)
+}
+
+// SetInstanceMoved records that the provider has moved the machine's
+// instance to the given availability zone, where it is known by the
+// given instance ID.
+func (m *Machine) SetInstanceMoved(id instance.Id, zone string) error {
+	if id == "" {
+		return errors.NotValidf("empty instance id")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		instData, err := getInstanceData(m.st, m.Id())
+		if errors.IsNotFound(err) {
+			return nil, errors.NotProvisionedf("machine %v", m.Id())
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current := ""
+		if instData.AvailZone != nil {
+			current = *instData.AvailZone
+		}
+		if instData.InstanceId == id && current == zone {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"instanceid", instData.InstanceId}},
+			Update: bson.D{{"$set", bson.D{
+				{"instanceid", id},
+				{"availzone", zone},
+			}}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot record move of instance for machine %v", m)
+}
+
 // ApplicationNames returns the names of applications
 // represented by units running on the machine.
 func (m *Machine) ApplicationNames() ([]string, error) {
@@ -1331,8 +1401,8 @@ func (m *Machine) Units() (units []*Unit, err error) {
 // lost) after starting the instance, we can be sure that only a single
 // instance will be able to act for that machine.
 //
-// Once set, the instance id cannot be changed. A non-empty instance id
-// will be detected as a provisioned machine.
+// Once set, the instance id can only be changed by SetInstanceMoved.
+// A non-empty instance id will be detected as a provisioned machine.
 func (m *Machine) SetProvisioned(
 	id instance.Id,
 	displayName string,
@@ -863,6 +863,67 @@ func (s *MachineSuite) TestMachineAvailabilityZoneMissing(c *gc.C) {
 	c.Check(zone, gc.Equals, "")
 }
 
+func (s *MachineSuite) TestMachineSetAvailabilityZone(c *gc.C) {
+	zone := "a_zone"
+	hwc := &instance.HardwareCharacteristics{
+		AvailabilityZone: &zone,
+	}
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", hwc)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetAvailabilityZone("b_zone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	zone, err = s.machine.AvailabilityZone()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(zone, gc.Equals, "b_zone")
+	hw, err := s.machine.HardwareCharacteristics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*hw.AvailabilityZone, gc.Equals, "b_zone")
+}
+
+func (s *MachineSuite) TestMachineSetAvailabilityZoneNotProvisioned(c *gc.C) {
+	err := s.machine.SetAvailabilityZone("b_zone")
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *MachineSuite) TestMachineSetInstanceMoved(c *gc.C) {
+	zone := "a_zone"
+	hwc := &instance.HardwareCharacteristics{
+		AvailabilityZone: &zone,
+	}
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", hwc)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetInstanceMoved("umbrella/1", "b_zone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := m.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, instance.Id("umbrella/1"))
+	zone, err = m.AvailabilityZone()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(zone, gc.Equals, "b_zone")
+	c.Check(m.CheckProvisioned("fake_nonce"), jc.IsTrue)
+}
+
+func (s *MachineSuite) TestMachineSetInstanceMovedNotProvisioned(c *gc.C) {
+	err := s.machine.SetInstanceMoved("umbrella/1", "b_zone")
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *MachineSuite) TestMachineSetInstanceMovedDead(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetInstanceMoved("umbrella/1", "b_zone")
+	c.Assert(err, gc.ErrorMatches, `cannot record move of instance for machine 1: machine is not found or not alive`)
+}
+
 func (s *MachineSuite) TestMachineSetCheckProvisioned(c *gc.C) {
 	// Check before provisioning.
 	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsFalse)
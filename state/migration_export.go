@@ -17,6 +17,7 @@ import (
 	"github.com/juju/names/v4"
 	"github.com/juju/os/v2/series"
 	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/core/arch"
 	"github.com/juju/juju/core/charm"
@@ -2659,7 +2660,10 @@ func (e *exporter) addStorage(instance *storageInstance, attachments []names.Uni
 	if !ok {
 		owner = nil
 	}
-	cons := description.StorageInstanceConstraints(instance.doc.Constraints)
+	cons := description.StorageInstanceConstraints{
+		Pool: instance.doc.Constraints.Pool,
+		Size: instance.doc.Constraints.Size,
+	}
 	args := description.StorageArgs{
 		Tag:         instance.StorageTag(),
 		Kind:        instance.Kind().String(),
@@ -2749,3 +2753,127 @@ func (m storagePoolSettingsManager) ListSettings(keyPrefix string) (map[string]m
 	}
 	return result, nil
 }
+
+// secretsExport is the serialized form of the secrets in a model.
+// Secrets are not yet part of the model description, so they are
+// migrated alongside it.
+type secretsExport struct {
+	Version int            `yaml:"version"`
+	Secrets []secretExport `yaml:"secrets"`
+
+	// BackendCredentials holds the credentials used to access
+	// external secret backends, keyed on the backend type.
+	BackendCredentials map[string]map[string]string `yaml:"backend-credentials,omitempty"`
+}
+
+type secretExport struct {
+	ID             string                   `yaml:"id"`
+	OwnerTag       string                   `yaml:"owner-tag"`
+	Description    string                   `yaml:"description,omitempty"`
+	RotateInterval time.Duration            `yaml:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time               `yaml:"next-rotate-time,omitempty"`
+	CreateTime     time.Time                `yaml:"create-time"`
+	UpdateTime     time.Time                `yaml:"update-time"`
+	Revisions      []secretRevisionExport   `yaml:"revisions"`
+	Permissions    []secretPermissionExport `yaml:"permissions,omitempty"`
+}
+
+type secretRevisionExport struct {
+	Revision   int                   `yaml:"revision"`
+	CreateTime time.Time             `yaml:"create-time"`
+	Data       map[string]string     `yaml:"data,omitempty"`
+	ValueRef   *secretValueRefExport `yaml:"value-reference,omitempty"`
+}
+
+type secretValueRefExport struct {
+	BackendType string `yaml:"backend-type"`
+	RevisionID  string `yaml:"revision-id"`
+}
+
+type secretPermissionExport struct {
+	SubjectTag string `yaml:"subject-tag"`
+	ScopeTag   string `yaml:"scope-tag"`
+	Role       string `yaml:"role"`
+}
+
+// ExportSecrets returns the serialized secrets of the current model,
+// or nil if there are none.
+func (st *State) ExportSecrets() ([]byte, error) {
+	secretMetadataCollection, closer := st.db().GetCollection(secretMetadataC)
+	defer closer()
+	var metadataDocs []secretMetadataDoc
+	if err := secretMetadataCollection.Find(nil).Sort("_id").All(&metadataDocs); err != nil {
+		return nil, errors.Annotate(err, "reading secrets")
+	}
+
+	secretBackendCredentialsCollection, closer := st.db().GetCollection(secretBackendCredentialsC)
+	defer closer()
+	var credentialDocs []secretBackendCredentialDoc
+	if err := secretBackendCredentialsCollection.Find(nil).All(&credentialDocs); err != nil {
+		return nil, errors.Annotate(err, "reading secret backend credentials")
+	}
+	if len(metadataDocs) == 0 && len(credentialDocs) == 0 {
+		return nil, nil
+	}
+
+	secretRevisionsCollection, closer := st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	var revisionDocs []secretRevisionDoc
+	if err := secretRevisionsCollection.Find(nil).Sort("secret-id", "revision").All(&revisionDocs); err != nil {
+		return nil, errors.Annotate(err, "reading secret revisions")
+	}
+	revisions := make(map[string][]secretRevisionExport)
+	for _, doc := range revisionDocs {
+		rev := secretRevisionExport{
+			Revision:   doc.Revision,
+			CreateTime: doc.CreateTime,
+			Data:       doc.Data,
+		}
+		if doc.ValueRef != nil {
+			rev.ValueRef = &secretValueRefExport{
+				BackendType: doc.ValueRef.BackendType,
+				RevisionID:  doc.ValueRef.RevisionID,
+			}
+		}
+		revisions[doc.SecretID] = append(revisions[doc.SecretID], rev)
+	}
+
+	secretPermissionsCollection, closer := st.db().GetCollection(secretPermissionsC)
+	defer closer()
+	var permissionDocs []secretPermissionDoc
+	if err := secretPermissionsCollection.Find(nil).Sort("_id").All(&permissionDocs); err != nil {
+		return nil, errors.Annotate(err, "reading secret permissions")
+	}
+	permissions := make(map[string][]secretPermissionExport)
+	for _, doc := range permissionDocs {
+		permissions[doc.SecretID] = append(permissions[doc.SecretID], secretPermissionExport{
+			SubjectTag: doc.SubjectTag,
+			ScopeTag:   doc.ScopeTag,
+			Role:       doc.Role,
+		})
+	}
+
+	result := secretsExport{Version: 1}
+	for _, doc := range credentialDocs {
+		if result.BackendCredentials == nil {
+			result.BackendCredentials = make(map[string]map[string]string)
+		}
+		result.BackendCredentials[st.localID(doc.DocID)] = doc.Attributes
+	}
+	for _, doc := range metadataDocs {
+		id := st.localID(doc.DocID)
+		result.Secrets = append(result.Secrets, secretExport{
+			ID:             id,
+			OwnerTag:       doc.OwnerTag,
+			Description:    doc.Description,
+			RotateInterval: doc.RotateInterval,
+			NextRotateTime: doc.NextRotateTime,
+			CreateTime:     doc.CreateTime,
+			UpdateTime:     doc.UpdateTime,
+			Revisions:      revisions[id],
+			Permissions:    permissions[id],
+		})
+	}
+	data, err := yaml.Marshal(result)
+	return data, errors.Trace(err)
+}
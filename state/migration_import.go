@@ -19,6 +19,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
@@ -2345,7 +2346,10 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 
 func (i *importer) storageInstanceConstraints(storage description.Storage) storageInstanceConstraints {
 	if cons, ok := storage.Constraints(); ok {
-		return storageInstanceConstraints(cons)
+		return storageInstanceConstraints{
+			Pool: cons.Pool,
+			Size: cons.Size,
+		}
 	}
 	// Older versions of Juju did not record storage constraints on the
 	// storage instance, so we must do what we do during upgrade steps:
@@ -2675,3 +2679,103 @@ func (i *importer) storagePools() error {
 	}
 	return nil
 }
+
+// ImportSecrets adds the serialized secrets produced by ExportSecrets
+// to the current model.
+func (st *State) ImportSecrets(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var in secretsExport
+	if err := yaml.Unmarshal(data, &in); err != nil {
+		return errors.Annotate(err, "parsing secrets")
+	}
+	if in.Version != 1 {
+		return errors.NotSupportedf("secrets export version %d", in.Version)
+	}
+	var ops []txn.Op
+	for backendType, attrs := range in.BackendCredentials {
+		ops = append(ops, txn.Op{
+			C:      secretBackendCredentialsC,
+			Id:     backendType,
+			Assert: txn.DocMissing,
+			Insert: secretBackendCredentialDoc{
+				DocID:      st.docID(backendType),
+				Attributes: attrs,
+			},
+		})
+	}
+	for _, secret := range in.Secrets {
+		latestRevision := 0
+		for _, rev := range secret.Revisions {
+			if rev.Revision > latestRevision {
+				latestRevision = rev.Revision
+			}
+			key := secretRevisionKey(secret.ID, rev.Revision)
+			revisionDoc := secretRevisionDoc{
+				DocID:      st.docID(key),
+				SecretID:   secret.ID,
+				Revision:   rev.Revision,
+				CreateTime: rev.CreateTime,
+				Data:       rev.Data,
+			}
+			if rev.ValueRef != nil {
+				revisionDoc.ValueRef = &valueRefDoc{
+					BackendType: rev.ValueRef.BackendType,
+					RevisionID:  rev.ValueRef.RevisionID,
+				}
+			}
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: revisionDoc,
+			})
+		}
+		rotateKey := secretRotateKey(secret.OwnerTag, secret.ID)
+		ops = append(ops, txn.Op{
+			C:      secretMetadataC,
+			Id:     secret.ID,
+			Assert: txn.DocMissing,
+			Insert: secretMetadataDoc{
+				DocID:          st.docID(secret.ID),
+				OwnerTag:       secret.OwnerTag,
+				Description:    secret.Description,
+				RotateInterval: secret.RotateInterval,
+				NextRotateTime: secret.NextRotateTime,
+				LatestRevision: latestRevision,
+				CreateTime:     secret.CreateTime,
+				UpdateTime:     secret.UpdateTime,
+			},
+		}, txn.Op{
+			C:      secretRotateC,
+			Id:     rotateKey,
+			Assert: txn.DocMissing,
+			Insert: secretRotateDoc{
+				DocID:          st.docID(rotateKey),
+				SecretID:       secret.ID,
+				OwnerTag:       secret.OwnerTag,
+				NextRotateTime: secret.NextRotateTime,
+			},
+		})
+		for _, perm := range secret.Permissions {
+			key := secretPermissionKey(secret.ID, perm.SubjectTag)
+			ops = append(ops, txn.Op{
+				C:      secretPermissionsC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: secretPermissionDoc{
+					DocID:      st.docID(key),
+					SecretID:   secret.ID,
+					SubjectTag: perm.SubjectTag,
+					ScopeTag:   perm.ScopeTag,
+					Role:       perm.Role,
+				},
+			})
+		}
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "importing secrets")
+	}
+	return nil
+}
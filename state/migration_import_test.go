@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/payload"
@@ -2652,6 +2653,49 @@ func (s *MigrationImportSuite) TestImportingRelationApplicationSettings(c *gc.C)
 	c.Assert(newMysqlSettings, gc.DeepEquals, mysqlSettings)
 }
 
+func (s *MigrationImportSuite) TestSecrets(c *gc.C) {
+	owner := s.Factory.MakeApplication(c, nil)
+	store := state.NewSecrets(s.State)
+	uri := secrets.NewURI(s.State.ModelUUID())
+	interval := time.Hour
+	_, err := store.CreateSecret(uri, state.CreateSecretParams{
+		Owner: owner.Tag(),
+		UpdateSecretParams: state.UpdateSecretParams{
+			RotateInterval: &interval,
+			Data:           map[string]string{"foo": "YmFy"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	md, err := store.UpdateSecret(uri, state.UpdateSecretParams{
+		Data: map[string]string{"foo": "YmF6"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetSecretBackendCredential("vault", map[string]string{"token": "s.foo"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	importedModel, newSt := s.importModel(c, s.State)
+	data, err := s.State.ExportSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	err = newSt.ImportSecrets(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newStore := state.NewSecrets(newSt)
+	newURI := &secrets.URI{ModelUUID: newSt.ModelUUID(), ID: uri.ID}
+	newMD, err := newStore.GetSecret(newURI)
+	c.Assert(err, jc.ErrorIsNil)
+	md.URI = newURI
+	c.Assert(newMD, jc.DeepEquals, md)
+	val, _, err := newStore.GetSecretValue(newURI, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+	val, _, err = newStore.GetSecretValue(newURI, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmF6"})
+	credential, err := importedModel.SecretBackendCredential("vault")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, map[string]string{"token": "s.foo"})
+}
+
 // newModel replaces the uuid and name of the config attributes so we
 // can use all the other data to validate imports. An owner and name of the
 // model are unique together in a controller.
@@ -89,6 +89,13 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		relationNetworksC,
 		remoteEntitiesC,
 		externalControllersC,
+
+		// secrets
+		secretMetadataC,
+		secretRevisionsC,
+		secretRotateC,
+		secretPermissionsC,
+		secretBackendCredentialsC,
 	)
 
 	ignoredCollections := set.NewStrings(
@@ -125,6 +132,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		metricsC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
+		// The audit log is controller global, not migrated.
+		auditLogC,
+		// Action schedules aren't yet part of the model description,
+		// and need to be added again after migration.
+		actionSchedulesC,
 		// reference counts are implementation details that should be
 		// reconstructed on the other side.
 		refcountsC,
@@ -204,6 +216,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Resources are transferred separately
 		"storedResources",
 
+		// Filesystem usage is reported periodically by the machine
+		// agents, and will be reported again after migration.
+		filesystemUsageC,
+
 		// Unit state entries will be automatically created when the
 		// operator framework code mutates the state for the charm
 		// running within a unit. This is a new feature that is not
@@ -751,6 +767,8 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"Life",
 		"HostId",    // recreated from pool properties
 		"Releasing", // only when dying; can't migrate dying storage
+		// A pending resize is not migrated; it must be requested again.
+		"RequestedSize",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -764,7 +782,7 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "WWN", "Size", "Pool", "VolumeId", "Persistent"))
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool", "Snapshot"))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -794,6 +812,8 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"Life",
 		"HostId",    // recreated from pool properties
 		"Releasing", // only when dying; can't migrate dying storage
+		// A pending resize is not migrated; it must be requested again.
+		"RequestedSize",
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/storage"
 )
@@ -46,6 +47,10 @@ type Policy interface {
 
 	// StorageProviderRegistry returns a storage.ProviderRegistry or an error.
 	StorageProviderRegistry() (storage.ProviderRegistry, error)
+
+	// SecretsStore returns the store for the specified
+	// external secret backend type, or an error.
+	SecretsStore(backendType string) (provider.SecretsStore, error)
 }
 
 // precheckInstance calls the state's assigned policy, if non-nil, to obtain
@@ -0,0 +1,968 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+)
+
+// CreateSecretParams are used to create a secret.
+type CreateSecretParams struct {
+	UpdateSecretParams
+
+	// Owner is the tag of the application which owns the secret.
+	Owner names.Tag
+}
+
+// UpdateSecretParams are used to update a secret.
+type UpdateSecretParams struct {
+	// LeaderToken is used to ensure that only the owning
+	// application's leader can change the secret.
+	LeaderToken leadership.Token
+
+	// RotateInterval, if set, updates how often the secret is rotated.
+	RotateInterval *time.Duration
+
+	// Description, if set, updates the secret description.
+	Description *string
+
+	// Data, if set, is the content for a new secret revision.
+	Data secrets.SecretData
+
+	// ValueRef, if set, is a reference to the content for a new
+	// secret revision held in an external secret backend.
+	ValueRef *secrets.ValueRef
+}
+
+func (u *UpdateSecretParams) hasUpdate() bool {
+	return u.RotateInterval != nil || u.Description != nil || u.hasContent()
+}
+
+func (u *UpdateSecretParams) hasContent() bool {
+	return len(u.Data) > 0 || u.ValueRef != nil
+}
+
+// SecretsFilter holds attributes to match when listing secrets.
+type SecretsFilter struct {
+	// OwnerTag, if set, restricts the result to secrets
+	// owned by the specified entity.
+	OwnerTag *names.Tag
+}
+
+// SecretsStore instances provide access to secrets in state.
+type SecretsStore interface {
+	CreateSecret(*secrets.URI, CreateSecretParams) (*secrets.SecretMetadata, error)
+	UpdateSecret(*secrets.URI, UpdateSecretParams) (*secrets.SecretMetadata, error)
+	GetSecret(*secrets.URI) (*secrets.SecretMetadata, error)
+	GetSecretValue(*secrets.URI, int) (secrets.SecretValue, *secrets.ValueRef, error)
+	ListSecrets(SecretsFilter) ([]*secrets.SecretMetadata, error)
+	ChangeSecretBackend(*secrets.URI, int, secrets.SecretData, *secrets.ValueRef) error
+}
+
+// NewSecrets creates a new mongo backed secrets store.
+func NewSecrets(st *State) *secretsStore {
+	return &secretsStore{st: st}
+}
+
+type secretsStore struct {
+	st *State
+}
+
+type secretMetadataDoc struct {
+	DocID string `bson:"_id"`
+
+	OwnerTag       string        `bson:"owner-tag"`
+	Description    string        `bson:"description"`
+	RotateInterval time.Duration `bson:"rotate-interval"`
+	NextRotateTime *time.Time    `bson:"next-rotate-time,omitempty"`
+	LatestRevision int           `bson:"latest-revision"`
+	CreateTime     time.Time     `bson:"create-time"`
+	UpdateTime     time.Time     `bson:"update-time"`
+}
+
+type secretRevisionDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID   string            `bson:"secret-id"`
+	Revision   int               `bson:"revision"`
+	CreateTime time.Time         `bson:"create-time"`
+	Data       map[string]string `bson:"data"`
+	ValueRef   *valueRefDoc      `bson:"value-reference,omitempty"`
+}
+
+// valueRefDoc records where the content of a secret
+// revision is held in an external secret backend.
+type valueRefDoc struct {
+	BackendType string `bson:"backend-type"`
+	RevisionID  string `bson:"revision-id"`
+}
+
+func toValueRefDoc(ref *secrets.ValueRef) *valueRefDoc {
+	if ref == nil {
+		return nil
+	}
+	return &valueRefDoc{
+		BackendType: ref.BackendType,
+		RevisionID:  ref.RevisionID,
+	}
+}
+
+func (doc *valueRefDoc) toValueRef() *secrets.ValueRef {
+	if doc == nil {
+		return nil
+	}
+	return &secrets.ValueRef{
+		BackendType: doc.BackendType,
+		RevisionID:  doc.RevisionID,
+	}
+}
+
+type secretRotateDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID       string     `bson:"secret-id"`
+	OwnerTag       string     `bson:"owner-tag"`
+	NextRotateTime *time.Time `bson:"next-rotate-time,omitempty"`
+}
+
+func secretRevisionKey(id string, revision int) string {
+	return fmt.Sprintf("%s/%d", id, revision)
+}
+
+// secretRotateKey is the id of a rotation document. The owner tag
+// prefix allows watchers to filter changes on the document id.
+func secretRotateKey(ownerTag, id string) string {
+	return fmt.Sprintf("%s#%s", ownerTag, id)
+}
+
+func (s *secretsStore) secretMetadataDoc(uri *secrets.URI, p *CreateSecretParams) (*secretMetadataDoc, error) {
+	interval := time.Duration(0)
+	if p.RotateInterval != nil {
+		interval = *p.RotateInterval
+	}
+	description := ""
+	if p.Description != nil {
+		description = *p.Description
+	}
+	now := s.st.nowToTheSecond()
+	return &secretMetadataDoc{
+		DocID:          s.st.docID(uri.ID),
+		OwnerTag:       p.Owner.String(),
+		Description:    description,
+		RotateInterval: interval,
+		NextRotateTime: nextRotateTime(now, interval),
+		LatestRevision: 1,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}, nil
+}
+
+func nextRotateTime(from time.Time, interval time.Duration) *time.Time {
+	if interval <= 0 {
+		return nil
+	}
+	next := from.Add(interval)
+	return &next
+}
+
+func (s *secretsStore) secretRevisionDoc(uri *secrets.URI, revision int, p *UpdateSecretParams) *secretRevisionDoc {
+	return &secretRevisionDoc{
+		DocID:      s.st.docID(secretRevisionKey(uri.ID, revision)),
+		SecretID:   uri.ID,
+		Revision:   revision,
+		CreateTime: s.st.nowToTheSecond(),
+		Data:       copySecretData(p.Data),
+		ValueRef:   toValueRefDoc(p.ValueRef),
+	}
+}
+
+func copySecretData(data secrets.SecretData) map[string]string {
+	dataCopy := make(map[string]string, len(data))
+	for k, v := range data {
+		dataCopy[k] = v
+	}
+	return dataCopy
+}
+
+// CreateSecret creates a new secret.
+func (s *secretsStore) CreateSecret(uri *secrets.URI, p CreateSecretParams) (*secrets.SecretMetadata, error) {
+	if uri == nil {
+		return nil, errors.NotValidf("nil secret URI")
+	}
+	if uri.ModelUUID != s.st.ModelUUID() {
+		return nil, errors.NotValidf("secret URI for model %q", uri.ModelUUID)
+	}
+	if p.Owner == nil {
+		return nil, errors.NotValidf("secret with no owner")
+	}
+	cfg := secrets.SecretConfig{RotateInterval: p.RotateInterval, Description: p.Description}
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !p.hasContent() {
+		return nil, errors.NotValidf("secret with no data")
+	}
+	if len(p.Data) > 0 && p.ValueRef != nil {
+		return nil, errors.NotValidf("secret with both data and value reference")
+	}
+	metadataDoc, err := s.secretMetadataDoc(uri, &p)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	revisionDoc := s.secretRevisionDoc(uri, 1, &p.UpdateSecretParams)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := s.getSecretMetadataDoc(uri); err == nil {
+				return nil, errors.AlreadyExistsf("secret %q", uri)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{{
+			C:      secretMetadataC,
+			Id:     metadataDoc.DocID,
+			Assert: txn.DocMissing,
+			Insert: *metadataDoc,
+		}, {
+			C:      secretRevisionsC,
+			Id:     revisionDoc.DocID,
+			Assert: txn.DocMissing,
+			Insert: *revisionDoc,
+		}, {
+			C:      secretRotateC,
+			Id:     secretRotateKey(metadataDoc.OwnerTag, uri.ID),
+			Assert: txn.DocMissing,
+			Insert: secretRotateDoc{
+				DocID:          s.st.docID(secretRotateKey(metadataDoc.OwnerTag, uri.ID)),
+				SecretID:       uri.ID,
+				OwnerTag:       metadataDoc.OwnerTag,
+				NextRotateTime: metadataDoc.NextRotateTime,
+			},
+		}}
+		return ops, nil
+	}
+	if p.LeaderToken != nil {
+		buildTxn = buildTxnWithLeadership(buildTxn, p.LeaderToken)
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot create secret %q", uri)
+	}
+	return s.toSecretMetadata(metadataDoc)
+}
+
+// UpdateSecret updates an existing secret, creating a new revision
+// if the secret content is changed.
+func (s *secretsStore) UpdateSecret(uri *secrets.URI, p UpdateSecretParams) (*secrets.SecretMetadata, error) {
+	if uri == nil {
+		return nil, errors.NotValidf("nil secret URI")
+	}
+	if !p.hasUpdate() {
+		return nil, errors.New("must specify a new value or metadata to update a secret")
+	}
+	if len(p.Data) > 0 && p.ValueRef != nil {
+		return nil, errors.NotValidf("secret update with both data and value reference")
+	}
+	cfg := secrets.SecretConfig{RotateInterval: p.RotateInterval, Description: p.Description}
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var metadataDoc *secretMetadataDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		metadataDoc, err = s.getSecretMetadataDoc(uri)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		currentRevision := metadataDoc.LatestRevision
+		now := s.st.nowToTheSecond()
+		metadataDoc.UpdateTime = now
+		set := bson.D{{"update-time", now}}
+		if p.Description != nil {
+			metadataDoc.Description = *p.Description
+			set = append(set, bson.DocElem{"description", *p.Description})
+		}
+		rotateChanged := p.RotateInterval != nil && *p.RotateInterval != metadataDoc.RotateInterval
+		if rotateChanged {
+			metadataDoc.RotateInterval = *p.RotateInterval
+			metadataDoc.NextRotateTime = nextRotateTime(now, *p.RotateInterval)
+			set = append(set,
+				bson.DocElem{"rotate-interval", metadataDoc.RotateInterval},
+				bson.DocElem{"next-rotate-time", metadataDoc.NextRotateTime},
+			)
+		}
+		var ops []txn.Op
+		if p.hasContent() {
+			metadataDoc.LatestRevision++
+			set = append(set, bson.DocElem{"latest-revision", metadataDoc.LatestRevision})
+			revisionDoc := s.secretRevisionDoc(uri, metadataDoc.LatestRevision, &p)
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     revisionDoc.DocID,
+				Assert: txn.DocMissing,
+				Insert: *revisionDoc,
+			})
+		}
+		ops = append(ops, txn.Op{
+			C:  secretMetadataC,
+			Id: metadataDoc.DocID,
+			// Asserting the revision guards against concurrent updates.
+			Assert: bson.D{{"latest-revision", currentRevision}},
+			Update: bson.D{{"$set", set}},
+		})
+		if rotateChanged {
+			ops = append(ops, txn.Op{
+				C:      secretRotateC,
+				Id:     secretRotateKey(metadataDoc.OwnerTag, uri.ID),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"next-rotate-time", metadataDoc.NextRotateTime}}}},
+			})
+		}
+		return ops, nil
+	}
+	if p.LeaderToken != nil {
+		buildTxn = buildTxnWithLeadership(buildTxn, p.LeaderToken)
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot update secret %q", uri)
+	}
+	return s.toSecretMetadata(metadataDoc)
+}
+
+func (s *secretsStore) toSecretMetadata(doc *secretMetadataDoc) (*secrets.SecretMetadata, error) {
+	return &secrets.SecretMetadata{
+		URI:            &secrets.URI{ModelUUID: s.st.ModelUUID(), ID: s.st.localID(doc.DocID)},
+		OwnerTag:       doc.OwnerTag,
+		Description:    doc.Description,
+		RotateInterval: doc.RotateInterval,
+		NextRotateTime: doc.NextRotateTime,
+		LatestRevision: doc.LatestRevision,
+		CreateTime:     doc.CreateTime,
+		UpdateTime:     doc.UpdateTime,
+	}, nil
+}
+
+func (s *secretsStore) getSecretMetadataDoc(uri *secrets.URI) (*secretMetadataDoc, error) {
+	if uri.ModelUUID != s.st.ModelUUID() {
+		return nil, errors.NotFoundf("secret %q", uri)
+	}
+	secretMetadataCollection, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var doc secretMetadataDoc
+	err := secretMetadataCollection.FindId(uri.ID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", uri)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// GetSecret gets the secret metadata for the specified URI.
+func (s *secretsStore) GetSecret(uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	if uri == nil {
+		return nil, errors.NotValidf("nil secret URI")
+	}
+	doc, err := s.getSecretMetadataDoc(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.toSecretMetadata(doc)
+}
+
+// GetSecretValue gets the secret value for the specified URI and revision.
+// A revision of 0 means the latest revision. If the content is held in an
+// external secret backend, the value is empty and a reference to the
+// content is returned instead.
+func (s *secretsStore) GetSecretValue(uri *secrets.URI, revision int) (secrets.SecretValue, *secrets.ValueRef, error) {
+	if uri == nil {
+		return nil, nil, errors.NotValidf("nil secret URI")
+	}
+	if revision <= 0 {
+		doc, err := s.getSecretMetadataDoc(uri)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		revision = doc.LatestRevision
+	}
+	doc, err := s.getSecretRevisionDoc(uri, revision)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return secrets.NewSecretValue(doc.Data), doc.ValueRef.toValueRef(), nil
+}
+
+func (s *secretsStore) getSecretRevisionDoc(uri *secrets.URI, revision int) (*secretRevisionDoc, error) {
+	if uri.ModelUUID != s.st.ModelUUID() {
+		return nil, errors.NotFoundf("secret %q", uri)
+	}
+	secretRevisionCollection, closer := s.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	var doc secretRevisionDoc
+	err := secretRevisionCollection.FindId(secretRevisionKey(uri.ID, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q revision %d", uri, revision)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// ChangeSecretBackend records that the content of the specified secret
+// revision is now held in a different backend. Exactly one of data, for
+// content held in the controller database, or ref must be specified.
+func (s *secretsStore) ChangeSecretBackend(uri *secrets.URI, revision int, data secrets.SecretData, ref *secrets.ValueRef) error {
+	if uri == nil {
+		return errors.NotValidf("nil secret URI")
+	}
+	if (len(data) > 0) == (ref != nil) {
+		return errors.NotValidf("secret content must be either data or a value reference")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := s.getSecretRevisionDoc(uri, revision)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		set := bson.D{{"data", copySecretData(data)}}
+		if ref != nil {
+			set = append(set, bson.DocElem{"value-reference", toValueRefDoc(ref)})
+		}
+		update := bson.D{{"$set", set}}
+		if ref == nil {
+			update = append(update, bson.DocElem{"$unset", bson.D{{"value-reference", nil}}})
+		}
+		// Asserting the current reference guards against concurrent changes.
+		var assertRef interface{} = bson.D{{"$exists", false}}
+		if doc.ValueRef != nil {
+			assertRef = doc.ValueRef
+		}
+		return []txn.Op{{
+			C:      secretRevisionsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"value-reference", assertRef}},
+			Update: update,
+		}}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot change backend of secret %q revision %d", uri, revision)
+	}
+	return nil
+}
+
+// ListSecrets list the secrets using the specified filter.
+func (s *secretsStore) ListSecrets(filter SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	secretMetadataCollection, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var q bson.D
+	if filter.OwnerTag != nil {
+		q = bson.D{{"owner-tag", (*filter.OwnerTag).String()}}
+	}
+	var docs []secretMetadataDoc
+	if err := secretMetadataCollection.Find(q).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*secrets.SecretMetadata, len(docs))
+	for i, doc := range docs {
+		md, err := s.toSecretMetadata(&doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = md
+	}
+	return result, nil
+}
+
+// removeOwnedSecretsOps returns the operations to remove the
+// secrets owned by the specified entity. Content held in external
+// secret backends is removed by a cleanup once the secrets are gone.
+func removeOwnedSecretsOps(st *State, owner names.Tag) ([]txn.Op, error) {
+	secretMetadataCollection, closer := st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var docs []secretMetadataDoc
+	err := secretMetadataCollection.Find(bson.D{{"owner-tag", owner.String()}}).
+		Select(bson.D{{"_id", 1}, {"latest-revision", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = st.localID(doc.DocID)
+	}
+	refs, err := secretValueRefs(st, bson.D{{"secret-id", bson.D{{"$in", ids}}}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	if len(refs) > 0 {
+		args := make([]interface{}, len(refs))
+		for i, ref := range refs {
+			args[i] = ref
+		}
+		ops = append(ops, newCleanupOp(cleanupSecretContent, owner.String(), args...))
+	}
+	for _, doc := range docs {
+		id := st.localID(doc.DocID)
+		ops = append(ops, txn.Op{
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Remove: true,
+		}, txn.Op{
+			C:      secretRotateC,
+			Id:     secretRotateKey(owner.String(), id),
+			Remove: true,
+		})
+		for rev := 1; rev <= doc.LatestRevision; rev++ {
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     secretRevisionKey(id, rev),
+				Remove: true,
+			})
+		}
+		permOps, err := removeSecretPermissionsOps(st, id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, permOps...)
+	}
+	return ops, nil
+}
+
+// secretValueRefs returns the references to content held in
+// external secret backends by the secret revisions matching query.
+func secretValueRefs(st *State, query bson.D) ([]valueRefDoc, error) {
+	secretRevisionsCollection, closer := st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	query = append(query, bson.DocElem{"value-reference", bson.D{{"$exists", true}}})
+	var docs []secretRevisionDoc
+	err := secretRevisionsCollection.Find(query).
+		Select(bson.D{{"value-reference", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading secret revisions")
+	}
+	refs := make([]valueRefDoc, 0, len(docs))
+	for _, doc := range docs {
+		if doc.ValueRef != nil {
+			refs = append(refs, *doc.ValueRef)
+		}
+	}
+	return refs, nil
+}
+
+// deleteSecretContent removes the referenced content from the
+// external secret backends holding it. All the content is tried;
+// the first error encountered is returned.
+func (st *State) deleteSecretContent(refs []valueRefDoc) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	if st.policy == nil {
+		logger.Warningf("no policy, cannot delete content from external secret backends")
+		return nil
+	}
+	var firstErr error
+	stores := make(map[string]provider.SecretsStore)
+	for _, ref := range refs {
+		store, ok := stores[ref.BackendType]
+		if !ok {
+			var err error
+			store, err = st.policy.SecretsStore(ref.BackendType)
+			if errors.IsNotImplemented(err) {
+				logger.Warningf("cannot delete content from %q secret backend: %v", ref.BackendType, err)
+			} else if err != nil && firstErr == nil {
+				firstErr = errors.Annotatef(err, "getting %q secret backend", ref.BackendType)
+			}
+			stores[ref.BackendType] = store
+		}
+		if store == nil {
+			continue
+		}
+		if err := store.DeleteContent(ref.RevisionID); err != nil && firstErr == nil {
+			firstErr = errors.Trace(err)
+		}
+	}
+	return firstErr
+}
+
+// removeAllSecretContent removes from the external secret backends
+// all content still referenced by the model's secret revisions, or
+// by pending secret content cleanups. Failures are logged rather
+// than returned so that they do not prevent the model's removal.
+func (st *State) removeAllSecretContent() error {
+	refs, err := secretValueRefs(st, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cleanups, closer := st.db().GetCollection(cleanupsC)
+	defer closer()
+	var docs []cleanupDoc
+	if err := cleanups.Find(bson.D{{"kind", cleanupSecretContent}}).All(&docs); err != nil {
+		return errors.Annotate(err, "reading secret content cleanups")
+	}
+	for _, doc := range docs {
+		for _, arg := range doc.Args {
+			var ref valueRefDoc
+			if err := arg.Value.(bson.Raw).Unmarshal(&ref); err != nil {
+				return errors.Annotate(err, "unmarshalling secret content cleanup arg")
+			}
+			refs = append(refs, ref)
+		}
+	}
+	if err := st.deleteSecretContent(refs); err != nil {
+		logger.Warningf("cannot delete content from external secret backends: %v", err)
+	}
+	return nil
+}
+
+type secretBackendCredentialDoc struct {
+	DocID string `bson:"_id"`
+
+	Attributes map[string]string `bson:"attributes"`
+}
+
+// SecretBackendCredential returns the credential used to access
+// the specified external secret backend. The credential is never
+// returned to clients; it is only used to create secrets stores.
+func (m *Model) SecretBackendCredential(backendType string) (map[string]string, error) {
+	secretBackendCredentialsCollection, closer := m.st.db().GetCollection(secretBackendCredentialsC)
+	defer closer()
+
+	var doc secretBackendCredentialDoc
+	err := secretBackendCredentialsCollection.FindId(backendType).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("%q secret backend credential", backendType)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.Attributes, nil
+}
+
+// SetSecretBackendCredential replaces the credential used to access
+// the specified external secret backend. An empty credential removes
+// any existing one.
+func (m *Model) SetSecretBackendCredential(backendType string, attrs map[string]string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := m.SecretBackendCredential(backendType)
+		exists := err == nil
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		switch {
+		case len(attrs) == 0 && !exists:
+			return nil, jujutxn.ErrNoOperations
+		case len(attrs) == 0:
+			return []txn.Op{{
+				C:      secretBackendCredentialsC,
+				Id:     backendType,
+				Assert: txn.DocExists,
+				Remove: true,
+			}}, nil
+		case !exists:
+			return []txn.Op{{
+				C:      secretBackendCredentialsC,
+				Id:     backendType,
+				Assert: txn.DocMissing,
+				Insert: &secretBackendCredentialDoc{
+					DocID:      m.st.docID(backendType),
+					Attributes: attrs,
+				},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      secretBackendCredentialsC,
+			Id:     backendType,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"attributes", attrs}}}},
+		}}, nil
+	}
+	return errors.Annotatef(m.st.db().Run(buildTxn), "setting %q secret backend credential", backendType)
+}
+
+// SecretAccessParams are used to grant or revoke access to a secret.
+type SecretAccessParams struct {
+	// LeaderToken is used to ensure that only the owning
+	// application's leader can change access to the secret.
+	LeaderToken leadership.Token
+
+	// Scope is the entity, typically a relation, whose
+	// lifetime bounds the access.
+	Scope names.Tag
+
+	// Subject is the application or unit being granted access.
+	Subject names.Tag
+
+	// Role is the access being granted.
+	Role secrets.SecretRole
+}
+
+type secretPermissionDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID   string `bson:"secret-id"`
+	SubjectTag string `bson:"subject-tag"`
+	ScopeTag   string `bson:"scope-tag"`
+	Role       string `bson:"role"`
+}
+
+func secretPermissionKey(id, subject string) string {
+	return fmt.Sprintf("%s#%s", id, subject)
+}
+
+func (st *State) findSecretEntity(tag names.Tag) (entity Lifer, collName, docID string, err error) {
+	id := tag.Id()
+	switch tag.(type) {
+	case names.RelationTag:
+		entity, err = st.KeyRelation(id)
+		collName = relationsC
+		docID = id
+	case names.UnitTag:
+		entity, err = st.Unit(id)
+		collName = unitsC
+		docID = id
+	case names.ApplicationTag:
+		entity, err = st.Application(id)
+		collName = applicationsC
+		docID = id
+	default:
+		err = errors.NotValidf("secret scope or subject %q", tag)
+	}
+	return entity, collName, st.docID(docID), err
+}
+
+// GrantSecretAccess saves the secret access permission for the
+// specified subject.
+func (st *State) GrantSecretAccess(uri *secrets.URI, p SecretAccessParams) error {
+	if uri == nil {
+		return errors.NotValidf("nil secret URI")
+	}
+	if p.Subject == nil || p.Scope == nil {
+		return errors.NotValidf("secret access with no subject or scope")
+	}
+	if !p.Role.IsValid() {
+		return errors.NotValidf("secret role %q", p.Role)
+	}
+	store := NewSecrets(st)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := store.getSecretMetadataDoc(uri); err != nil {
+			return nil, errors.Trace(err)
+		}
+		scopeEntity, scopeCollName, scopeDocID, err := st.findSecretEntity(p.Scope)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid scope reference")
+		}
+		if scopeEntity.Life() != Alive {
+			return nil, errors.Errorf("cannot grant access to %q in scope of %q which is not alive", uri, p.Scope)
+		}
+		subjectEntity, subjectCollName, subjectDocID, err := st.findSecretEntity(p.Subject)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid subject reference")
+		}
+		if subjectEntity.Life() != Alive {
+			return nil, errors.Errorf("cannot grant dying %q access to %q", p.Subject, uri)
+		}
+		key := secretPermissionKey(uri.ID, p.Subject.String())
+		doc := secretPermissionDoc{
+			DocID:      st.docID(key),
+			SecretID:   uri.ID,
+			SubjectTag: p.Subject.String(),
+			ScopeTag:   p.Scope.String(),
+			Role:       string(p.Role),
+		}
+		ops := []txn.Op{{
+			C:      scopeCollName,
+			Id:     scopeDocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      subjectCollName,
+			Id:     subjectDocID,
+			Assert: isAliveDoc,
+		}}
+		existing, err := st.secretPermissionDoc(key)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			if existing.ScopeTag != doc.ScopeTag {
+				return nil, errors.Errorf("cannot change secret %q scope from %q to %q", uri, existing.ScopeTag, doc.ScopeTag)
+			}
+			if existing.Role == doc.Role {
+				return nil, jujutxn.ErrNoOperations
+			}
+			ops = append(ops, txn.Op{
+				C:      secretPermissionsC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"role", doc.Role}}}},
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      secretPermissionsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		}
+		return ops, nil
+	}
+	if p.LeaderToken != nil {
+		buildTxn = buildTxnWithLeadership(buildTxn, p.LeaderToken)
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// RevokeSecretAccess removes any secret access permission for the
+// specified subject.
+func (st *State) RevokeSecretAccess(uri *secrets.URI, p SecretAccessParams) error {
+	if uri == nil {
+		return errors.NotValidf("nil secret URI")
+	}
+	if p.Subject == nil {
+		return errors.NotValidf("secret access with no subject")
+	}
+	key := secretPermissionKey(uri.ID, p.Subject.String())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.secretPermissionDoc(key); errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      secretPermissionsC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	if p.LeaderToken != nil {
+		buildTxn = buildTxnWithLeadership(buildTxn, p.LeaderToken)
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// SecretAccess returns the secret access role for the specified subject.
+func (st *State) SecretAccess(uri *secrets.URI, subject names.Tag) (secrets.SecretRole, error) {
+	if uri == nil {
+		return secrets.RoleNone, errors.NotValidf("nil secret URI")
+	}
+	doc, err := st.secretPermissionDoc(secretPermissionKey(uri.ID, subject.String()))
+	if errors.IsNotFound(err) {
+		return secrets.RoleNone, nil
+	}
+	if err != nil {
+		return secrets.RoleNone, errors.Trace(err)
+	}
+	return secrets.SecretRole(doc.Role), nil
+}
+
+func (st *State) secretPermissionDoc(key string) (*secretPermissionDoc, error) {
+	secretPermissionsCollection, closer := st.db().GetCollection(secretPermissionsC)
+	defer closer()
+
+	var doc secretPermissionDoc
+	err := secretPermissionsCollection.FindId(key).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret permission %q", key)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+func removeSecretPermissionsOps(st *State, id string) ([]txn.Op, error) {
+	secretPermissionsCollection, closer := st.db().GetCollection(secretPermissionsC)
+	defer closer()
+
+	var docs []secretPermissionDoc
+	err := secretPermissionsCollection.Find(bson.D{{"secret-id", id}}).
+		Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretPermissionsC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// SecretRotated records when the specified secret was last rotated,
+// and hence when it is next due to be rotated.
+func (st *State) SecretRotated(uri *secrets.URI, when time.Time) error {
+	if uri == nil {
+		return errors.NotValidf("nil secret URI")
+	}
+	store := NewSecrets(st)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := store.getSecretMetadataDoc(uri)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		next := nextRotateTime(when, doc.RotateInterval)
+		if next == nil {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"rotate-interval", doc.RotateInterval}},
+			Update: bson.D{{"$set", bson.D{{"next-rotate-time", next}}}},
+		}, {
+			C:      secretRotateC,
+			Id:     secretRotateKey(doc.OwnerTag, uri.ID),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"next-rotate-time", next}}}},
+		}}, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// WatchSecretsRotationChanges returns a StringsWatcher which reports
+// the URIs of secrets owned by the specified entity whose rotation
+// schedule has changed.
+func (st *State) WatchSecretsRotationChanges(owner names.Tag) StringsWatcher {
+	prefix := owner.String() + "#"
+	modelUUID := st.ModelUUID()
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newCollectionWatcher(st, colWCfg{
+		col:    secretRotateC,
+		filter: filter,
+		idconv: func(id string) string {
+			uri := secrets.URI{ModelUUID: modelUUID, ID: strings.TrimPrefix(id, prefix)}
+			return uri.String()
+		},
+	})
+}
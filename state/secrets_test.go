@@ -0,0 +1,384 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	store state.SecretsStore
+	owner *state.Application
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.store = state.NewSecrets(s.State)
+	s.owner = s.Factory.MakeApplication(c, nil)
+}
+
+func (s *SecretsSuite) createParams(rotate time.Duration) state.CreateSecretParams {
+	description := "my secret"
+	return state.CreateSecretParams{
+		Owner: s.owner.Tag(),
+		UpdateSecretParams: state.UpdateSecretParams{
+			LeaderToken:    &fakeToken{},
+			RotateInterval: &rotate,
+			Description:    &description,
+			Data:           map[string]string{"foo": "YmFy"},
+		},
+	}
+}
+
+func (s *SecretsSuite) TestCreate(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	now := s.Clock.Now().Round(time.Second).UTC()
+	md, err := s.store.CreateSecret(uri, s.createParams(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	next := now.Add(time.Hour)
+	c.Assert(md, jc.DeepEquals, &secrets.SecretMetadata{
+		URI:            uri,
+		OwnerTag:       s.owner.Tag().String(),
+		Description:    "my secret",
+		RotateInterval: time.Hour,
+		NextRotateTime: &next,
+		LatestRevision: 1,
+		CreateTime:     now,
+		UpdateTime:     now,
+	})
+
+	_, err = s.store.CreateSecret(uri, s.createParams(time.Hour))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *SecretsSuite) TestCreateValidation(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	p := s.createParams(time.Minute)
+	_, err := s.store.CreateSecret(uri, p)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	p = s.createParams(0)
+	p.Data = nil
+	_, err = s.store.CreateSecret(uri, p)
+	c.Assert(err, gc.ErrorMatches, "secret with no data not valid")
+
+	other := secrets.NewURI("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	_, err = s.store.CreateSecret(other, s.createParams(0))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SecretsSuite) TestGetValue(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	val, _, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	_, _, err = s.store.GetSecretValue(uri, 2)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestUpdateCreatesRevision(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	md, err := s.store.UpdateSecret(uri, state.UpdateSecretParams{
+		LeaderToken: &fakeToken{},
+		Data:        map[string]string{"foo": "YmF6"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.LatestRevision, gc.Equals, 2)
+	c.Assert(md.Description, gc.Equals, "my secret")
+
+	val, _, err := s.store.GetSecretValue(uri, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmF6"})
+	val, _, err = s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+}
+
+func (s *SecretsSuite) TestValueRef(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	p := s.createParams(0)
+	p.Data = nil
+	p.ValueRef = &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-1"}
+	_, err := s.store.CreateSecret(uri, p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	md, err := s.store.UpdateSecret(uri, state.UpdateSecretParams{
+		ValueRef: &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.LatestRevision, gc.Equals, 2)
+
+	val, ref, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.IsEmpty(), jc.IsTrue)
+	c.Assert(ref, jc.DeepEquals, &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-1"})
+	_, ref, err = s.store.GetSecretValue(uri, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, jc.DeepEquals, &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-2"})
+}
+
+func (s *SecretsSuite) TestCreateDataAndValueRef(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	p := s.createParams(0)
+	p.ValueRef = &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-1"}
+	_, err := s.store.CreateSecret(uri, p)
+	c.Assert(err, gc.ErrorMatches, "secret with both data and value reference not valid")
+}
+
+func (s *SecretsSuite) TestChangeSecretBackend(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	ref := &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-1"}
+	err = s.store.ChangeSecretBackend(uri, 1, nil, ref)
+	c.Assert(err, jc.ErrorIsNil)
+	val, gotRef, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.IsEmpty(), jc.IsTrue)
+	c.Assert(gotRef, jc.DeepEquals, ref)
+
+	err = s.store.ChangeSecretBackend(uri, 1, map[string]string{"foo": "YmFy"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	val, gotRef, err = s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+	c.Assert(gotRef, gc.IsNil)
+
+	err = s.store.ChangeSecretBackend(uri, 1, map[string]string{"foo": "YmFy"}, ref)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = s.store.ChangeSecretBackend(uri, 2, nil, ref)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestUpdateMetadataOnly(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	description := "changed"
+	interval := 2 * time.Hour
+	md, err := s.store.UpdateSecret(uri, state.UpdateSecretParams{
+		Description:    &description,
+		RotateInterval: &interval,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.LatestRevision, gc.Equals, 1)
+	c.Assert(md.Description, gc.Equals, "changed")
+	c.Assert(md.RotateInterval, gc.Equals, 2*time.Hour)
+	c.Assert(md.NextRotateTime, gc.NotNil)
+}
+
+func (s *SecretsSuite) TestUpdateNothing(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.UpdateSecret(uri, state.UpdateSecretParams{})
+	c.Assert(err, gc.ErrorMatches, "must specify a new value or metadata to update a secret")
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "other"})
+	otherURI := secrets.NewURI(s.State.ModelUUID())
+	p := s.createParams(0)
+	p.Owner = other.Tag()
+	_, err = s.store.CreateSecret(otherURI, p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err := s.store.ListSecrets(state.SecretsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 2)
+
+	ownerTag := other.Tag()
+	list, err = s.store.ListSecrets(state.SecretsFilter{OwnerTag: &ownerTag})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Assert(list[0].URI, jc.DeepEquals, otherURI)
+}
+
+func (s *SecretsSuite) TestGrantRevokeAccess(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	subject := wordpress.Tag()
+
+	role, err := s.State.SecretAccess(uri, subject)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+
+	err = s.State.GrantSecretAccess(uri, state.SecretAccessParams{
+		LeaderToken: &fakeToken{},
+		Scope:       rel.Tag(),
+		Subject:     subject,
+		Role:        secrets.RoleView,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	role, err = s.State.SecretAccess(uri, subject)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleView)
+
+	err = s.State.RevokeSecretAccess(uri, state.SecretAccessParams{
+		LeaderToken: &fakeToken{},
+		Subject:     subject,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	role, err = s.State.SecretAccess(uri, subject)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+}
+
+func (s *SecretsSuite) TestGrantAccessInvalidRole(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	err := s.State.GrantSecretAccess(uri, state.SecretAccessParams{
+		Scope:   names.NewRelationTag("wordpress:db mysql:server"),
+		Subject: names.NewApplicationTag("wordpress"),
+		Role:    "admin",
+	})
+	c.Assert(err, gc.ErrorMatches, `secret role "admin" not valid`)
+}
+
+func (s *SecretsSuite) TestSecretRotated(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	when := s.Clock.Now().Add(3 * time.Hour).Round(time.Second).UTC()
+	err = s.State.SecretRotated(uri, when)
+	c.Assert(err, jc.ErrorIsNil)
+
+	md, err := s.store.GetSecret(uri)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*md.NextRotateTime, gc.Equals, when.Add(time.Hour))
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.owner.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.GetSecret(uri)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, _, err = s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationDeletesExternalContent(c *gc.C) {
+	store := &fakeSecretsStore{}
+	s.policy.GetSecretsStore = func(backendType string) (provider.SecretsStore, error) {
+		c.Check(backendType, gc.Equals, "vault")
+		return store, nil
+	}
+	uri := secrets.NewURI(s.State.ModelUUID())
+	p := s.createParams(0)
+	p.Data = nil
+	p.ValueRef = &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-1"}
+	_, err := s.store.CreateSecret(uri, p)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.UpdateSecret(uri, state.UpdateSecretParams{
+		ValueRef: &secrets.ValueRef{BackendType: "vault", RevisionID: "rev-2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.owner.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.deleted, gc.HasLen, 0)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.deleted, jc.SameContents, []string{"rev-1", "rev-2"})
+	needsCleanup, err := s.State.NeedsCleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(needsCleanup, jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSecretBackendCredential(c *gc.C) {
+	_, err := s.Model.SecretBackendCredential("vault")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.Model.SetSecretBackendCredential("vault", map[string]string{"token": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	credential, err := s.Model.SecretBackendCredential("vault")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, map[string]string{"token": "foo"})
+
+	err = s.Model.SetSecretBackendCredential("vault", map[string]string{"token": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	credential, err = s.Model.SecretBackendCredential("vault")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, map[string]string{"token": "bar"})
+
+	err = s.Model.SetSecretBackendCredential("vault", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.SecretBackendCredential("vault")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type fakeSecretsStore struct {
+	provider.SecretsStore
+	deleted []string
+}
+
+func (s *fakeSecretsStore) DeleteContent(revisionID string) error {
+	s.deleted = append(s.deleted, revisionID)
+	return nil
+}
+
+func (s *SecretsSuite) TestWatchSecretsRotationChanges(c *gc.C) {
+	w := s.State.WatchSecretsRotationChanges(s.owner.Tag())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	uri := secrets.NewURI(s.State.ModelUUID())
+	_, err := s.store.CreateSecret(uri, s.createParams(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(uri.String())
+	wc.AssertNoChange()
+
+	err = s.State.SecretRotated(uri, s.Clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(uri.String())
+	wc.AssertNoChange()
+
+	// Secrets owned by other applications are not reported.
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "other"})
+	p := s.createParams(time.Hour)
+	p.Owner = other.Tag()
+	_, err = s.store.CreateSecret(secrets.NewURI(s.State.ModelUUID()), p)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -7,6 +7,7 @@
 package state
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"regexp"
@@ -216,6 +217,13 @@ func (st *State) RemoveDyingModel() error {
 			return errors.Trace(err)
 		}
 	}
+	// Secret content held outside the controller is removed
+	// while the credentials needed to access it still exist.
+	// This is not done when removing a migrated model, whose
+	// secrets still refer to that content.
+	if err := st.removeAllSecretContent(); err != nil {
+		return errors.Trace(err)
+	}
 	err = st.removeAllModelDocs(bson.D{{"life", Dead}})
 	if errors.Cause(err) == txn.ErrAborted {
 		return errors.Wrap(err, errors.New("can't remove model: model not dead"))
@@ -472,6 +480,21 @@ func (st *State) newDB() (Database, func()) {
 	return st.database.Copy()
 }
 
+// WithContext returns a copy of the State, sharing its session and
+// workers, whose transactions are traced as children of the span held
+// by ctx; an API facade method given the context of the request can
+// use it so that its transactions are recorded in the request's trace.
+// The copy must not be closed.
+func (st *State) WithContext(ctx context.Context) *State {
+	db, ok := st.database.(*database)
+	if !ok {
+		return st
+	}
+	stCopy := *st
+	stCopy.database = db.withContext(ctx)
+	return &stCopy
+}
+
 // db returns the Database instance used by the State. It is part of
 // the modelBackend interface.
 func (st *State) db() Database {
@@ -1234,12 +1257,24 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			ops = append(ops, resOps...)
 		}
 
+		// Collect shared storage operations. Shared storage is owned
+		// by the application, and attached to each of its units.
+		sharedOps, sharedStorage, err := createSharedStorageOps(
+			sb, app.ApplicationTag(), args.Charm.Meta(), args.Storage, args.NumUnits,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, sharedOps...)
+
 		// Collect unit-adding operations.
+		unitTags := make([]names.UnitTag, 0, args.NumUnits)
 		for x := 0; x < args.NumUnits; x++ {
 			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
 				cons:          args.Constraints,
 				storageCons:   args.Storage,
 				attachStorage: args.AttachStorage,
+				sharedStorage: sharedStorage,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -1250,7 +1285,15 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 				placement = *args.Placement[x]
 			}
 			ops = append(ops, assignUnitOps(unitName, placement)...)
+			unitTags = append(unitTags, names.NewUnitTag(unitName))
+		}
+		sharedFilesystemOps, err := createSharedFilesystemOps(
+			sb, args.Charm.Meta(), args.Series, sharedStorage, unitTags,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		ops = append(ops, sharedFilesystemOps...)
 		return ops, nil
 	}
 	// At the last moment before inserting the application, prime status history.
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sort"
@@ -40,6 +41,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/mongotest"
@@ -2131,6 +2133,31 @@ func (s *StateSuite) TestInferEndpoints(c *gc.C) {
 	}
 }
 
+func (s *StateSuite) TestWithContextTracesTransactionsInContextSpan(c *gc.C) {
+	tracer := trace.NewBufferedTracer(clock.WallClock, 10)
+	trace.SetDefaultTracer(tracer)
+	defer trace.SetDefaultTracer(nil)
+
+	// The span stands in for the span of an API request.
+	ctx, facadeSpan := trace.Start(context.Background(), "ModelConfig.SetModelConstraints")
+	err := s.State.WithContext(ctx).SetModelConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	facadeSpan.End()
+
+	spans, _ := tracer.Drain()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Assert(spans[0].Name, gc.Equals, "state.RunTransaction")
+	c.Assert(spans[0].SpanContext.TraceID, gc.Equals, facadeSpan.SpanContext().TraceID)
+	c.Assert(spans[0].ParentSpanID, gc.Equals, facadeSpan.SpanContext().SpanID)
+
+	// Transactions run without a context start their own trace.
+	err = s.State.SetModelConstraints(constraints.MustParse("mem=8G"))
+	c.Assert(err, jc.ErrorIsNil)
+	spans, _ = tracer.Drain()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].ParentSpanID.IsValid(), jc.IsFalse)
+}
+
 func (s *StateSuite) TestModelConstraints(c *gc.C) {
 	// Environ constraints start out empty (for now).
 	cons, err := s.State.ModelConstraints()
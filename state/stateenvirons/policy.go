@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	secretsprovider "github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
@@ -146,6 +147,15 @@ func (p *environStatePolicy) StorageProviderRegistry() (storage.ProviderRegistry
 	return NewStorageProviderRegistryForModel(model, p.getEnviron, p.getBroker)
 }
 
+// SecretsStore implements state.Policy.
+func (p *environStatePolicy) SecretsStore(backendType string) (secretsprovider.SecretsStore, error) {
+	model, err := p.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewSecretsStoreForModel(model, backendType, p.getBroker)
+}
+
 // NewStorageProviderRegistryForModel returns a storage provider registry
 // for the specified model.
 func NewStorageProviderRegistryForModel(
@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stateenvirons
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/secrets/provider"
+	// Register the secret backend providers.
+	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/state"
+)
+
+// SecretsModel is the model used to create secrets stores.
+type SecretsModel interface {
+	Model
+	UUID() string
+	SecretBackendCredential(backendType string) (map[string]string, error)
+}
+
+// NewSecretsStoreForModel returns the store for the specified
+// external secret backend type of the model.
+func NewSecretsStoreForModel(
+	model SecretsModel,
+	backendType string,
+	newBroker NewCAASBrokerFunc,
+) (provider.SecretsStore, error) {
+	p, err := provider.Provider(backendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	credential, err := model.SecretBackendCredential(backendType)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	backendCfg := &provider.ModelBackendConfig{
+		ModelUUID:  model.UUID(),
+		Config:     cfg.SecretBackendConfig()[backendType],
+		Credential: credential,
+	}
+	if model.Type() == state.ModelTypeCAAS {
+		backendCfg.NewBroker = func() (caas.Broker, error) {
+			return newBroker(model)
+		}
+	}
+	return p.NewStore(backendCfg)
+}
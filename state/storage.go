@@ -222,8 +222,9 @@ type storageInstanceDoc struct {
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool     string `bson:"pool"`
+	Size     uint64 `bson:"size"`
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 type storageAttachment struct {
@@ -387,6 +388,38 @@ func (sb *storageBackend) ReleaseStorageInstance(tag names.StorageTag, destroyAt
 	return sb.destroyStorageInstance(tag, destroyAttachments, true, force, maxWait)
 }
 
+// ResizeStorageInstance requests that the volume or filesystem assigned
+// to the specified storage instance be grown to the given size in MiB.
+// Filesystems backed by a volume are resized by resizing the volume.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	s, err := sb.storageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.Life() != Alive {
+		return errors.New("storage is not alive")
+	}
+	switch s.Kind() {
+	case StorageKindBlock:
+		v, err := sb.storageInstanceVolume(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return sb.ResizeVolume(v.VolumeTag(), size)
+	case StorageKindFilesystem:
+		f, err := sb.storageInstanceFilesystem(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if volumeTag, err := f.Volume(); err == nil {
+			return sb.ResizeVolume(volumeTag, size)
+		}
+		return sb.ResizeFilesystem(f.FilesystemTag(), size)
+	}
+	return errors.NotSupportedf("resizing %s storage", s.Kind())
+}
+
 func (sb *storageBackend) destroyStorageInstance(
 	tag names.StorageTag,
 	destroyAttachments bool,
@@ -548,7 +581,18 @@ func removeStorageInstanceOps(si *storageInstance, assert bson.D, force bool) ([
 			ops = append(ops, decrefOp)
 		}
 	}
+	machineOps, err := removeStorageInstanceMachineStorageOps(si, force)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, machineOps...), nil
+}
 
+// removeStorageInstanceMachineStorageOps returns txn.Ops to destroy the
+// volume and/or filesystem assigned to the storage instance, which is
+// being removed.
+func removeStorageInstanceMachineStorageOps(si *storageInstance, force bool) ([]txn.Op, error) {
+	var ops []txn.Op
 	machineStorageOp := func(c string, id string) txn.Op {
 		return txn.Op{
 			C:      c,
@@ -834,8 +878,9 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:     cons.Pool,
+					Size:     cons.Size,
+					Snapshot: cons.Snapshot,
 				},
 			}
 			var hostStorageOps []txn.Op
@@ -884,16 +929,231 @@ func createStorageOps(
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the application.
-	//
-	// TODO(axw) prevent creation of shared storage after application
-	// creation, because the only sane time to add storage attachments
-	// is when units are added to said application.
-
 	return ops, storageTags, numStorageAttachments, nil
 }
 
+// createSharedStorageOps returns txn.Ops for creating the shared storage
+// instances of a new application, along with the storage instances.
+// Shared storage is only created along with the application, because the
+// only sane time to add storage attachments is when units are added.
+//
+// Each storage instance is created with numUnits attachments, accounting
+// for the units added in the same transaction as the application. The
+// caller is responsible for creating those attachments; units added
+// later are attached by attachSharedStorageOps.
+func createSharedStorageOps(
+	sb *storageBackend,
+	appTag names.ApplicationTag,
+	charmMeta *charm.Meta,
+	cons map[string]StorageConstraints,
+	numUnits int,
+) ([]txn.Op, []*storageInstance, error) {
+	// Create storage instances in order of name, to simplify testing.
+	storageNames := set.NewStrings()
+	for name := range cons {
+		storageNames.Add(name)
+	}
+
+	var ops []txn.Op
+	var instances []*storageInstance
+	for _, name := range storageNames.SortedValues() {
+		charmStorage, ok := charmMeta.Storage[name]
+		if !ok {
+			return nil, nil, errors.NotFoundf("charm storage %q", name)
+		}
+		cons := cons[name]
+		if !charmStorage.Shared || cons.Count == 0 {
+			continue
+		}
+		var kind StorageKind
+		switch charmStorage.Type {
+		case charm.StorageBlock:
+			kind = StorageKindBlock
+		case charm.StorageFilesystem:
+			kind = StorageKindFilesystem
+		default:
+			return nil, nil, errors.Errorf("unknown storage type %q", charmStorage.Type)
+		}
+		for i := uint64(0); i < cons.Count; i++ {
+			id, err := newStorageInstanceId(sb.mb, name)
+			if err != nil {
+				return nil, nil, errors.Annotate(err, "cannot generate storage instance name")
+			}
+			doc := storageInstanceDoc{
+				Id:              id,
+				Kind:            kind,
+				Owner:           appTag.String(),
+				StorageName:     name,
+				AttachmentCount: numUnits,
+				Constraints: storageInstanceConstraints{
+					Pool:     cons.Pool,
+					Size:     cons.Size,
+					Snapshot: cons.Snapshot,
+				},
+			}
+			ops = append(ops, txn.Op{
+				C:      storageInstancesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+			instances = append(instances, &storageInstance{sb, doc})
+		}
+		incRefOp, err := increfEntityStorageOp(sb.mb, appTag, name, int(cons.Count))
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ops = append(ops, incRefOp)
+	}
+	return ops, instances, nil
+}
+
+// createSharedFilesystemOps returns txn.Ops for creating the filesystems
+// of new shared storage instances in a CAAS model, attached to each of
+// the units added along with the application. There are no machines in
+// a CAAS model, so the filesystems are attached to the units directly.
+func createSharedFilesystemOps(
+	sb *storageBackend,
+	charmMeta *charm.Meta,
+	series string,
+	instances []*storageInstance,
+	units []names.UnitTag,
+) ([]txn.Op, error) {
+	if sb.modelType != ModelTypeCAAS || len(units) == 0 {
+		return nil, nil
+	}
+	var ops []txn.Op
+	for _, si := range instances {
+		storageParams, err := storageParamsForStorageInstance(
+			sb, charmMeta, units[0], series, si,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for i := range storageParams.filesystems {
+			storageParams.filesystems[i].Filesystem.attachments = len(units)
+		}
+		hostOps, _, filesystemAttachments, err := sb.hostStorageOps(units[0].Id(), storageParams)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, hostOps...)
+		for _, unitTag := range units[1:] {
+			ops = append(ops, createMachineFilesystemAttachmentsOps(
+				unitTag.Id(), filesystemAttachments,
+			)...)
+		}
+	}
+	return ops, nil
+}
+
+// attachSharedStorageOps returns txn.Ops to attach the shared storage
+// instances owned by an application to a new unit of the application,
+// along with the number of storage attachments made. The newInstances
+// are being created in the same transaction, and already account for
+// the attachment (see createSharedStorageOps).
+func (sb *storageBackend) attachSharedStorageOps(
+	unitTag names.UnitTag,
+	unitSeries string,
+	ch *Charm,
+	newInstances []*storageInstance,
+	maybeMachineAssignable machineAssignable,
+) ([]txn.Op, int, error) {
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, si := range newInstances {
+		ops = append(ops, createStorageAttachmentOp(si.StorageTag(), unitTag))
+	}
+	numStorageAttachments := len(newInstances)
+
+	instances, err := sb.storageInstances(bson.D{
+		{"owner", names.NewApplicationTag(appName).String()},
+	})
+	if err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	for _, si := range instances {
+		if si.Life() != Alive {
+			// The storage is being removed, so there
+			// is no point attaching it.
+			continue
+		}
+		attachOps, err := sb.attachStorageOps(si, unitTag, unitSeries, ch, maybeMachineAssignable)
+		if err != nil {
+			return nil, -1, errors.Trace(err)
+		}
+		ops = append(ops, attachOps...)
+		numStorageAttachments++
+
+		// For CAAS models, we attach the storage to the unit
+		// as there's no machine for the unit to be assigned to.
+		if sb.modelType == ModelTypeCAAS {
+			storageParams, err := storageParamsForStorageInstance(
+				sb, ch.Meta(), unitTag, unitSeries, si,
+			)
+			if err != nil {
+				return nil, -1, errors.Trace(err)
+			}
+			hostOps, _, _, err := sb.hostStorageOps(unitTag.Id(), storageParams)
+			if err != nil {
+				return nil, -1, errors.Trace(err)
+			}
+			ops = append(ops, hostOps...)
+		}
+	}
+	return ops, numStorageAttachments, nil
+}
+
+// releaseSharedStorageOps returns txn.Ops to disown the shared storage
+// instances of an application that is being removed. As with storage
+// detached from a unit, the storage is left in the model so that it
+// can be attached elsewhere, or removed, by the user. If destroyStorage
+// is true, the storage instances are instead removed, and their volumes
+// and filesystems destroyed.
+func releaseSharedStorageOps(sb *storageBackend, appTag names.ApplicationTag, destroyStorage, force bool) ([]txn.Op, error) {
+	instances, err := sb.storageInstances(bson.D{{"owner", appTag.String()}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, 0, len(instances)*2)
+	for _, si := range instances {
+		op := txn.Op{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: bson.D{
+				{"owner", si.doc.Owner},
+				{"attachmentcount", 0},
+			},
+		}
+		if destroyStorage {
+			op.Remove = true
+		} else {
+			op.Update = bson.D{{"$unset", bson.D{{"owner", nil}}}}
+		}
+		ops = append(ops, op)
+		decrefOp, err := decrefEntityStorageOp(sb.mb, appTag, si.StorageName())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, decrefOp)
+		if !destroyStorage {
+			continue
+		}
+		// The application is going away, so there are no charm
+		// storage requirements to validate; just destroy the
+		// underlying volume or filesystem.
+		machineOps, err := removeStorageInstanceMachineStorageOps(si, force)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, machineOps...)
+	}
+	return ops, nil
+}
+
 // unitAssignedMachineStorageOps returns ops for creating volumes, filesystems
 // and their attachments to the machine that the specified unit is assigned to,
 // corresponding to the specified storage instance.
@@ -1494,6 +1754,37 @@ func removeStorageAttachmentOps(
 	return ops, nil
 }
 
+// sharedStorageInUseOnMachine reports whether a shared storage instance
+// is attached to a unit, other than the one specified, that is assigned
+// to the specified machine.
+func (sb *storageBackend) sharedStorageInUseOnMachine(si *storageInstance, unitTag names.UnitTag, machineId string) (bool, error) {
+	owner, ok := si.Owner()
+	if !ok || owner.Kind() != names.ApplicationTagKind {
+		return false, nil
+	}
+	attachments, err := sb.StorageAttachments(si.StorageTag())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, att := range attachments {
+		if att.Unit() == unitTag || att.Life() != Alive {
+			continue
+		}
+		u, err := sb.unit(att.Unit().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if id, err := u.AssignedMachineId(); err == nil && id == machineId {
+			return true, nil
+		} else if err != nil && !errors.IsNotAssigned(err) {
+			return false, errors.Trace(err)
+		}
+	}
+	return false, nil
+}
+
 func (sb *storageBackend) detachStorageAttachmentOps(si *storageInstance, unitTag names.UnitTag, force bool) ([]txn.Op, error) {
 	unit, err := sb.unit(unitTag.Id())
 	if err != nil {
@@ -1509,6 +1800,14 @@ func (sb *storageBackend) detachStorageAttachmentOps(si *storageInstance, unitTa
 			return nil, errors.Trace(err)
 		}
 		hostTag = names.NewMachineTag(machineId)
+
+		// Shared storage remains attached to the machine while
+		// another unit on the machine is attached to it.
+		if inUse, err := sb.sharedStorageInUseOnMachine(si, unitTag, machineId); err != nil {
+			return nil, errors.Trace(err)
+		} else if inUse {
+			return nil, nil
+		}
 	}
 
 	switch si.Kind() {
@@ -1675,6 +1974,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// Snapshot, if non-empty, is the provider ID of a volume snapshot
+	// from which the storage instances' volumes are to be created.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1756,13 +2059,6 @@ func validateStorageConstraintsAgainstCharm(
 		if !ok {
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
-		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
-			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
-			)
-		}
 		if err := validateCharmStorageCount(charmStorage, cons.Count); err != nil {
 			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
 		}
@@ -1778,6 +2074,37 @@ func validateStorageConstraintsAgainstCharm(
 		if err := validateStoragePool(sb, cons.Pool, kind, nil); err != nil {
 			return err
 		}
+		if charmStorage.Shared {
+			if err := validateSharedStoragePool(sb, cons.Pool, kind); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSharedStoragePool checks that storage from the named pool can
+// be attached to more than one machine at a time, as is required for
+// storage shared by all units of an application. In CAAS models, shared
+// storage is provided by a volume claim that all pods can mount.
+func validateSharedStoragePool(sb *storageBackend, poolName string, kind storage.StorageKind) error {
+	providerType, aProvider, _, err := poolStorageProvider(sb, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if sb.modelType == ModelTypeCAAS {
+		if providerType != k8sconstants.StorageProviderType {
+			return errors.Errorf("shared storage requires a %q pool, not %q", k8sconstants.StorageProviderType, providerType)
+		}
+		return nil
+	}
+	if aProvider.Scope() != storage.ScopeEnviron {
+		return errors.Errorf("shared storage requires a model-scoped pool, %q provider is machine-scoped", providerType)
+	}
+	if !aProvider.Supports(kind) {
+		// A filesystem created on a volume is managed by the machine
+		// the volume is attached to, so it cannot be shared.
+		return errors.Errorf("%q provider does not support shared %q storage", providerType, kind)
 	}
 	return nil
 }
@@ -1931,18 +2258,7 @@ func addDefaultStorageConstraints(sb *storageBackend, allCons map[string]Storage
 	}
 
 	for name, charmStorage := range charmMeta.Storage {
-		cons, ok := allCons[name]
-		if !ok {
-			if charmStorage.Shared {
-				// TODO(axw) get the model's default shared storage
-				// pool, and create constraints here.
-				return errors.Errorf(
-					"no constraints specified for shared charm storage %q",
-					name,
-				)
-			}
-		}
-		cons, err := storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, cons)
+		cons, err := storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, allCons[name])
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -2147,6 +2463,11 @@ func (sb *storageBackend) addStorageForUnitOps(
 	if cons.Count == 0 {
 		return nil, nil, errors.NotValidf("adding storage where instance count is 0")
 	}
+	if cons.Snapshot != "" {
+		if err := validateStorageSnapshot(sb, charmStorageMeta, cons.Pool); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
 
 	tags, addUnitStorageOps, err := sb.addUnitStorageOps(charmMeta, u, storageName, cons, -1)
 	if err != nil {
@@ -2156,6 +2477,33 @@ func (sb *storageBackend) addStorageForUnitOps(
 	return tags, ops, nil
 }
 
+// validateStorageSnapshot checks that storage from the given pool can
+// be restored from a volume snapshot. This is only possible if the
+// storage is provisioned as a volume: block storage always is, but
+// filesystem storage is only volume-backed if the pool's storage
+// provider does not support filesystems natively. Snapshots are only
+// taken of model-scoped volumes, so machine-scoped providers cannot
+// restore them.
+func validateStorageSnapshot(sb *storageBackend, charmStorage charm.Storage, poolName string) error {
+	providerType, provider, _, err := poolStorageProvider(sb, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return errors.NotSupportedf(
+			"restoring storage from a snapshot with machine-scoped storage provider %q",
+			providerType,
+		)
+	}
+	if charmStorage.Type == charm.StorageFilesystem && provider.Supports(storage.StorageKindFilesystem) {
+		return errors.NotSupportedf(
+			"restoring filesystem storage from a snapshot with storage provider %q",
+			providerType,
+		)
+	}
+	return nil
+}
+
 // addUnitStorageOps returns transaction ops to create storage for the given
 // unit. If countMin is non-negative, the Count field of the constraints will
 // be ignored, and as many storage instances as necessary to make up the
@@ -2167,6 +2515,11 @@ func (sb *storageBackend) addUnitStorageOps(
 	cons StorageConstraints,
 	countMin int,
 ) ([]names.StorageTag, []txn.Op, error) {
+	if charmMeta.Storage[storageName].Shared {
+		// Shared storage is owned by the application, and is
+		// only created along with the application.
+		return nil, nil, errors.NotSupportedf("adding shared storage %q to a unit", storageName)
+	}
 	var ops []txn.Op
 
 	consTotal := cons
@@ -2308,6 +2661,15 @@ func (sb *storageBackend) hostStorageOps(
 		}
 	}
 	for tag, filesystemAttachment := range args.filesystemAttachments {
+		// Shared storage may already be attached to the host
+		// by another unit of the application.
+		if exists, err := sb.hostAttachmentExists(
+			filesystemAttachmentsC, filesystemAttachmentId(hostId, tag.Id()),
+		); err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		} else if exists {
+			continue
+		}
 		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
 			tag, names.StorageTag{}, filesystemAttachment, attachOnly,
 		})
@@ -2325,6 +2687,13 @@ func (sb *storageBackend) hostStorageOps(
 		})
 	}
 	for tag, volumeAttachment := range args.volumeAttachments {
+		if exists, err := sb.hostAttachmentExists(
+			volumeAttachmentsC, volumeAttachmentId(hostId, tag.Id()),
+		); err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		} else if exists {
+			continue
+		}
 		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
 			tag, volumeAttachment, attachOnly,
 		})
@@ -2344,6 +2713,18 @@ func (sb *storageBackend) hostStorageOps(
 	return ops, volumeAttachments, fsAttachments, nil
 }
 
+// hostAttachmentExists reports whether the volume or filesystem attachment
+// document with the specified ID exists in the given collection.
+func (sb *storageBackend) hostAttachmentExists(collection, id string) (bool, error) {
+	coll, closer := sb.mb.db().GetCollection(collection)
+	defer closer()
+	n, err := coll.FindId(id).Count()
+	if err != nil {
+		return false, errors.Annotatef(err, "cannot get attachment %q", id)
+	}
+	return n > 0, nil
+}
+
 // addMachineStorageAttachmentsOps returns txn.Ops for adding the IDs of
 // attached volumes and filesystems to an existing machine. Filesystem
 // mount points are checked against existing filesystem attachments for
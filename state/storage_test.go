@@ -1101,6 +1101,174 @@ func (s *StorageStateSuite) TestAddUnitAttachStorage(c *gc.C) {
 	c.Assert(owner, gc.Equals, u2.UnitTag())
 }
 
+func (s *StorageStateSuite) addSharedStorageApplication(c *gc.C, pool string, numUnits int) (*state.Application, error) {
+	ch := s.createStorageCharm(c, "storage-shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	return s.st.AddApplication(state.AddApplicationArgs{
+		Name:     "storage-shared",
+		Charm:    ch,
+		NumUnits: numUnits,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons(pool, 1024, 1),
+		},
+	})
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorage(c *gc.C) {
+	app, err := s.addSharedStorageApplication(c, "modelscoped", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A single storage instance is created, owned by the application.
+	all, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	owner, hasOwner := all[0].Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.ApplicationTag())
+	c.Assert(all[0].StorageTag(), gc.Equals, names.NewStorageTag("data/0"))
+
+	// Each unit is attached to the shared storage.
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	for _, u := range units {
+		attachments, err := s.storageBackend.UnitStorageAttachments(u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(attachments, gc.HasLen, 1)
+		c.Assert(attachments[0].StorageInstance(), gc.Equals, all[0].StorageTag())
+	}
+}
+
+func (s *StorageStateSuite) TestAddUnitAttachesSharedStorage(c *gc.C) {
+	app, err := s.addSharedStorageApplication(c, "modelscoped", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.storageBackend.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, names.NewStorageTag("data/0"))
+
+	all, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorageMachineScopedPool(c *gc.C) {
+	_, err := s.addSharedStorageApplication(c, "machinescoped", 1)
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-shared": charm "storage-shared" store "data": shared storage requires a model-scoped pool, "machinescoped" provider is machine-scoped`)
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorageNFSPool(c *gc.C) {
+	_, err := s.pm.Create("nfs-share", provider.NFSProviderType, map[string]interface{}{
+		"server": "nfs.example.com",
+		"export": "/srv/share",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.addSharedStorageApplication(c, "nfs-share", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	for _, u := range units {
+		err := u.AssignToNewMachine()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// A single model-scoped filesystem is attached to both machines.
+	filesystem, err := s.storageBackend.StorageInstanceFilesystem(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystem.Tag(), gc.Equals, names.NewFilesystemTag("0"))
+	attachments, err := s.storageBackend.FilesystemAttachments(filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 2)
+	hosts := make([]string, len(attachments))
+	for i, a := range attachments {
+		hosts[i] = a.Host().Id()
+	}
+	c.Assert(hosts, jc.SameContents, []string{"0", "1"})
+}
+
+func (s *StorageStateSuite) TestAddStorageForUnitSharedStorage(c *gc.C) {
+	app, err := s.addSharedStorageApplication(c, "modelscoped", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.AddStorageForUnit(units[0].UnitTag(), "data", makeStorageCons("modelscoped", 1024, 1))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageStateSuite) TestAddStorageForUnitFromSnapshotMachineScoped(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	cons := makeStorageCons("loop-pool", 1024, 1)
+	cons.Snapshot = "volume-0.1"
+	_, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", cons)
+	c.Assert(err, gc.ErrorMatches, `.*restoring storage from a snapshot with machine-scoped storage provider "loop" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageStateSuite) TestDestroyApplicationReleasesSharedStorage(c *gc.C) {
+	app, err := s.addSharedStorageApplication(c, "modelscoped", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage outlives the application, but is no longer owned.
+	storageInstance, err := s.storageBackend.StorageInstance(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+}
+
+func (s *StorageStateSuite) TestDestroyApplicationDestroyStorageDestroysSharedStorage(c *gc.C) {
+	app, err := s.addSharedStorageApplication(c, "modelscoped", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	op := app.DestroyOperation()
+	op.DestroyStorage = true
+	err = s.st.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	exists := s.storageInstanceExists(c, names.NewStorageTag("data/0"))
+	c.Assert(exists, jc.IsFalse)
+}
+
+func (s *StorageStateSuite) TestDestroyUnitDestroyStorageKeepsSharedStorage(c *gc.C) {
+	app, err := s.addSharedStorageApplication(c, "modelscoped", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+
+	op := units[0].DestroyOperation()
+	op.DestroyStorage = true
+	err = s.st.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.Cleanup(), jc.ErrorIsNil)
+
+	// The shared storage is still alive and owned by the application,
+	// and only the destroyed unit's attachment has gone.
+	storageTag := names.NewStorageTag("data/0")
+	storageInstance, err := s.storageBackend.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Life(), gc.Equals, state.Alive)
+	owner, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.ApplicationTag())
+
+	_, err = s.storageBackend.StorageAttachment(storageTag, units[0].UnitTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.storageBackend.StorageAttachment(storageTag, units[1].UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *StorageStateSuite) TestConcurrentDestroyStorageInstanceRemoveStorageAttachmentsRemovesInstance(c *gc.C) {
 	if s.series == "kubernetes" {
 		c.Skip("volumes on kubernetes not supported")
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/storage"
 )
 
@@ -22,6 +23,7 @@ type MockPolicy struct {
 	GetConstraintsValidator       func() (constraints.Validator, error)
 	GetInstanceDistributor        func() (context.Distributor, error)
 	GetStorageProviderRegistry    func() (storage.ProviderRegistry, error)
+	GetSecretsStore               func(backendType string) (provider.SecretsStore, error)
 }
 
 func (p *MockPolicy) Prechecker() (environs.InstancePrechecker, error) {
@@ -66,6 +68,13 @@ func (p *MockPolicy) ProviderConfigSchemaSource(cloudName string) (config.Config
 	return nil, errors.NotImplementedf("ProviderConfigSchemaSource")
 }
 
+func (p *MockPolicy) SecretsStore(backendType string) (provider.SecretsStore, error) {
+	if p.GetSecretsStore != nil {
+		return p.GetSecretsStore(backendType)
+	}
+	return nil, errors.NotImplementedf("SecretsStore")
+}
+
 type MockConfigSchemaSource struct {
 	CloudName string
 }
@@ -2289,9 +2289,10 @@ func storageParamsForStorageInstance(
 			}
 		} else if errors.IsNotFound(err) {
 			filesystemParams := FilesystemParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:  storage.StorageTag(),
+				snapshot: storage.doc.Constraints.Snapshot,
+				Pool:     storage.doc.Constraints.Pool,
+				Size:     storage.doc.Constraints.Size,
 			}
 			filesystems = append(filesystems, HostFilesystemParams{
 				filesystemParams, filesystemAttachmentParams,
@@ -2334,9 +2335,10 @@ func storageParamsForStorageInstance(
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		} else if errors.IsNotFound(err) {
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:  storage.StorageTag(),
+				Pool:     storage.doc.Constraints.Pool,
+				Size:     storage.doc.Constraints.Size,
+				Snapshot: storage.doc.Constraints.Snapshot,
 			}
 			volumes = append(volumes, HostVolumeParams{
 				volumeParams, volumeAttachmentParams,
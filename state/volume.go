@@ -52,6 +52,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size in MiB that the volume has been
+	// requested to grow to, if a resize is pending. RequestedSize
+	// returns true if there is a pending resize, otherwise false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -132,6 +137,11 @@ type volumeDoc struct {
 	// the volume as being non-detachable, and to determine
 	// which volumes must be removed along with said machine.
 	HostId string `bson:"hostid,omitempty"`
+
+	// RequestedSize is the size in MiB that a provisioned volume
+	// is to be grown to. It is cleared once the volume's info
+	// records a size at least this large.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -176,6 +186,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// Snapshot, if non-empty, is the provider ID of the
+	// snapshot from which the volume is to be created.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -278,6 +292,11 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return getStatus(v.mb.db(), volumeGlobalKey(v.VolumeTag().Id()), "volume")
@@ -1470,11 +1489,59 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if size, ok := v.RequestedSize(); ok && info.Size >= size {
+			// The requested resize has been carried out.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
 }
 
+// ResizeVolume requests that the specified provisioned volume be grown
+// to the given size in MiB. The resize is carried out asynchronously
+// by the storage provisioner responsible for the volume.
+func (sb *storageBackend) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if requested, ok := v.RequestedSize(); ok && requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: append(bson.D{
+				{"info", bson.D{{"$exists", true}}},
+				{"info.size", info.Size},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
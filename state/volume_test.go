@@ -241,6 +241,72 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) TestResizeVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": cannot resize volume "0/0": volume "0/0" not provisioned`)
+
+	volumeInfo := state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"}
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": cannot resize volume "0/0": new size 1024MiB must be larger than current size 1024MiB`)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	// Setting info with a smaller size leaves the resize pending.
+	volumeInfo.Pool = "loop-pool"
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+
+	volumeInfo.Size = 2048
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, volumeInfo)
+}
+
+func (s *VolumeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	w := s.storageBackend.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0/0") // initial
+	wc.AssertNoChange()
+
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	err = s.storageBackend.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	w2 := s.storageBackend.WatchMachineVolumeResizes(names.NewMachineTag("1"))
+	defer testing.AssertStop(c, w2)
+	wc2 := testing.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChangeInSingleEvent() // initial
+	wc2.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -275,6 +275,59 @@ func (sb *storageBackend) watchHostStorage(host names.Tag, collection string) St
 	return newLifecycleWatcher(mb, collection, members, filter, nil)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, so that pending resizes may be
+// identified and carried out.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	return sb.watchModelHostStorageResizes(volumesC)
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies of
+// changes to model-scoped filesystems, so that pending resizes may be
+// identified and carried out.
+func (sb *storageBackend) WatchModelFilesystemResizes() StringsWatcher {
+	return sb.watchModelHostStorageResizes(filesystemsC)
+}
+
+func (sb *storageBackend) watchModelHostStorageResizes(collection string) StringsWatcher {
+	mb := sb.mb
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newCollectionWatcher(mb, colWCfg{col: collection, filter: filter})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine, so that pending
+// resizes may be identified and carried out.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return sb.watchMachineStorageResizes(m, volumesC)
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies of
+// changes to filesystems scoped to the specified machine, so that pending
+// resizes may be identified and carried out.
+func (sb *storageBackend) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	return sb.watchMachineStorageResizes(m, filesystemsC)
+}
+
+func (sb *storageBackend) watchMachineStorageResizes(m names.MachineTag, collection string) StringsWatcher {
+	mb := sb.mb
+	matchExp := regexp.MustCompile(fmt.Sprintf("^%s/%s$", regexp.QuoteMeta(m.Id()), names.NumberSnippet))
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return matchExp.MatchString(k)
+	}
+	return newCollectionWatcher(mb, colWCfg{col: collection, filter: filter})
+}
+
 // WatchMachineAttachmentsPlans returns a StringsWatcher that notifies machine agents
 // that a volume has been attached to their instance by the environment provider.
 // This allows machine agents to do extra initialization to the volume, in cases
@@ -466,18 +519,30 @@ func (s *RemoteApplication) WatchRelations() StringsWatcher {
 	return watchApplicationRelations(s.st, s.doc.Name)
 }
 
+// WatchRelationChanges returns a NotifyWatcher that notifies when a
+// relation involving a is added, removed or changed.
+func (a *Application) WatchRelationChanges() NotifyWatcher {
+	return newNotifyCollWatcher(a.st, relationsC, applicationRelationsFilter(a.st, a.doc.Name))
+}
+
 func watchApplicationRelations(backend modelBackend, applicationName string) StringsWatcher {
+	filter := applicationRelationsFilter(backend, applicationName)
+	members := bson.D{{"endpoints.applicationname", applicationName}}
+	return newRelationLifeSuspendedWatcher(backend, members, filter, nil)
+}
+
+// applicationRelationsFilter returns a filter matching the ids of
+// relations involving the named application.
+func applicationRelationsFilter(backend modelBackend, applicationName string) func(interface{}) bool {
 	prefix := applicationName + ":"
 	infix := " " + prefix
-	filter := func(id interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := backend.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return strings.HasPrefix(k, prefix) || strings.Contains(k, infix)
 	}
-	members := bson.D{{"endpoints.applicationname", applicationName}}
-	return newRelationLifeSuspendedWatcher(backend, members, filter, nil)
 }
 
 // WatchModelMachineStartTimes watches the non-container machines in the model
@@ -2004,6 +2069,16 @@ func (sb *storageBackend) WatchFilesystemAttachment(host names.Tag, f names.File
 	return newEntityWatcher(sb.mb, filesystemAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (sb *storageBackend) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, volumesC, sb.mb.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a filesystem.
+func (sb *storageBackend) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(f.Id()))
+}
+
 // WatchCharmConfig returns a watcher for observing changes to the
 // application's charm configuration settings. The returned watcher will be
 // valid only while the application's charm URL is not changed.
@@ -2012,6 +2087,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's own configuration settings, as opposed to its charm
+// configuration settings.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -2780,6 +2862,170 @@ func (w *actionLogsWatcher) loop() error {
 	}
 }
 
+// WatchTaskEvents starts and returns a StringsWatcher that notifies
+// of the events in the life of a task as they happen: changes of its
+// status, and the messages and output it logs. The strings are json
+// encoded task events; the initial event holds all the events so far.
+func (st *State) WatchTaskEvents(actionId string) StringsWatcher {
+	return newTaskEventsWatcher(st, actionId)
+}
+
+// taskEventsWatcher reports the events in the life of a task.
+type taskEventsWatcher struct {
+	commonWatcher
+	coll func() (mongo.Collection, func())
+	out  chan []string
+
+	actionId string
+}
+
+var _ Watcher = (*taskEventsWatcher)(nil)
+
+func newTaskEventsWatcher(st *State, actionId string) StringsWatcher {
+	w := &taskEventsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		coll:          collFactory(st.db(), actionsC),
+		out:           make(chan []string),
+		actionId:      actionId,
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *taskEventsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+// taskEventsReported records the events of a task which
+// have been reported.
+type taskEventsReported struct {
+	status   ActionStatus
+	messages int
+	output   int
+}
+
+func (w *taskEventsWatcher) events(reported taskEventsReported) ([]string, taskEventsReported, error) {
+	coll, closer := w.coll()
+	defer closer()
+	var doc actionDoc
+	err := coll.FindId(w.backend.docID(w.actionId)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, reported, errors.NotFoundf("task %q", w.actionId)
+	}
+	if err != nil {
+		return nil, reported, errors.Trace(err)
+	}
+	events := taskEvents(doc, reported)
+	changes := make([]string, len(events))
+	for i, event := range events {
+		ejson, err := json.Marshal(event)
+		if err != nil {
+			return nil, reported, errors.Trace(err)
+		}
+		changes[i] = string(ejson)
+	}
+	return changes, taskEventsReported{
+		status:   doc.Status,
+		messages: len(doc.Logs),
+		output:   len(doc.Output),
+	}, nil
+}
+
+// taskEvents returns the events in the task's document which
+// haven't been reported. A task's messages and output are
+// reported after it starts running and before it finishes.
+func taskEvents(doc actionDoc, reported taskEventsReported) []actions.TaskEvent {
+	var events []actions.TaskEvent
+	for _, m := range doc.Logs[reported.messages:] {
+		events = append(events, actions.TaskEvent{
+			Timestamp: m.TimestampValue.UTC(),
+			Message:   m.MessageValue,
+		})
+	}
+	for _, m := range doc.Output[reported.output:] {
+		events = append(events, actions.TaskEvent{
+			Timestamp: m.TimestampValue.UTC(),
+			Message:   m.MessageValue,
+			Stream:    m.StreamValue,
+		})
+	}
+	// Messages and output are logged separately, and
+	// only timestamped to the second.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	if doc.Status == reported.status {
+		return events
+	}
+
+	statusEvent := func(status ActionStatus, timestamp time.Time) actions.TaskEvent {
+		return actions.TaskEvent{
+			Timestamp: timestamp.UTC(),
+			Status:    string(status),
+		}
+	}
+	switch doc.Status {
+	case ActionPending:
+		return append([]actions.TaskEvent{statusEvent(doc.Status, doc.Enqueued)}, events...)
+	case ActionRunning, ActionAborting:
+		return append([]actions.TaskEvent{statusEvent(doc.Status, doc.Started)}, events...)
+	}
+	// The task has finished; if it ran, but we didn't see it
+	// running, report that first.
+	if !doc.Started.IsZero() && (reported.status == "" || reported.status == ActionPending) {
+		events = append([]actions.TaskEvent{statusEvent(ActionRunning, doc.Started)}, events...)
+	}
+	return append(events, statusEvent(doc.Status, doc.Completed))
+}
+
+func (w *taskEventsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	filter := func(id interface{}) bool {
+		k, err := w.backend.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return k == w.actionId
+	}
+
+	w.watcher.WatchCollectionWithFilter(actionsC, in, filter)
+	defer w.watcher.UnwatchCollection(actionsC, in)
+
+	var reported taskEventsReported
+	changes, pending, err := w.events(reported)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-in:
+			// Any events not yet sent are reported again
+			// along with the new ones.
+			events, next, err := w.events(reported)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(events) > 0 {
+				changes, pending = events, next
+				out = w.out
+			}
+		case out <- changes:
+			reported = pending
+			out = nil
+		}
+	}
+}
+
 var _ StringsWatcher = (*actionStatusWatcher)(nil)
 
 // newActionStatusWatcher returns the StringsWatcher that will notify
@@ -86,6 +86,24 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// HostFilesystemAttacher is an interface that may be implemented by a
+// model-scoped Provider whose filesystems must be attached by the host
+// they are attached to, such as network filesystems that each machine
+// mounts. Such filesystems are created and destroyed by the model's
+// storage provisioner, and attached and detached by the host's.
+type HostFilesystemAttacher interface {
+	// HostAttachesFilesystems reports whether filesystems created
+	// by the provider are attached by the host's storage provisioner.
+	HostAttachesFilesystems() bool
+}
+
+// HostAttachesFilesystems reports whether the filesystems of the
+// given provider are attached by the host they are attached to.
+func HostAttachesFilesystems(p Provider) bool {
+	attacher, ok := p.(HostFilesystemAttacher)
+	return ok && attacher.HostAttachesFilesystems()
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -215,6 +233,49 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes, and for listing and deleting them. A volume
+// is restored from a snapshot by creating a new volume with the
+// snapshot's ID as the SnapshotId in its VolumeParams.
+//
+// A VolumeSource that implements VolumeSnapshotter must create
+// volumes from snapshots; other volume sources must not be asked
+// to do so.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates snapshots of the volumes with
+	// the specified parameters. Snapshots may be returned before
+	// they have completed; the snapshot status reports progress.
+	CreateVolumeSnapshots(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots returns the snapshots of the volumes with
+	// the specified provider volume IDs.
+	ListVolumeSnapshots(ctx context.ProviderCallContext, volIds []string) ([]VolumeSnapshot, error)
+
+	// DeleteVolumeSnapshots deletes the snapshots with the specified
+	// provider snapshot IDs.
+	DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error)
+}
+
+// VolumeResizer provides an interface for growing volumes that have
+// already been provisioned. Volumes are only ever grown; a request
+// to shrink a volume is an error.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters.
+	// The volumes may be attached to machines, in which case they
+	// are resized online.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems
+// that have already been provisioned. Filesystems are only ever
+// grown; a request to shrink a filesystem is an error.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters. The filesystems may be attached to machines, in
+	// which case they are resized online.
+	ResizeFilesystems(ctx context.ProviderCallContext, params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -249,6 +310,39 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId, if non-empty, is the provider ID of the snapshot
+	// from which the volume should be created. Only volume sources
+	// that implement VolumeSnapshotter support this.
+	SnapshotId string
+}
+
+// VolumeSnapshotParams is a set of parameters for creating a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Volume is the unique tag assigned by Juju for the volume
+	// that is to be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Volume is the unique tag assigned by Juju for the volume
+	// that is to be resized.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the requested new size of the volume, in MiB.
+	Size uint64
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -320,6 +414,20 @@ type FilesystemParams struct {
 	Attachment *FilesystemAttachmentParams
 }
 
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Filesystem is the unique tag assigned by Juju for the
+	// filesystem that is to be resized.
+	Filesystem names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem.
+	FilesystemId string
+
+	// Size is the requested new size of the filesystem, in MiB.
+	Size uint64
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -354,6 +462,29 @@ type DescribeVolumesResult struct {
 	Error      error
 }
 
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// Snapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the size of the volume after resizing, in MiB.
+	// This may be larger than the requested size.
+	Size uint64
+
+	// Pending is true if the resize has been requested but the
+	// volume has not yet grown, in which case Size is not set.
+	// The resize should be requested again to check on it.
+	Pending bool
+
+	Error error
+}
+
 // AttachVolumesResult contains the result of a VolumeSource.AttachVolumes call
 // for one volume. VolumeAttachment should only be used if Error is nil.
 type AttachVolumesResult struct {
@@ -368,6 +499,16 @@ type CreateFilesystemsResult struct {
 	Error      error
 }
 
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Size should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	// Size is the size of the filesystem after resizing, in MiB.
+	// This may be larger than the requested size.
+	Size  uint64
+	Error error
+}
+
 // AttachFilesystemsResult contains the result of a FilesystemSource.AttachFilesystems call
 // for one filesystem. FilesystemAttachment should only be used if Error is nil.
 type AttachFilesystemsResult struct {
@@ -27,6 +27,10 @@ type KubernetesFilesystemParams struct {
 	// Attachment identifies the mount point the filesystem should be
 	// mounted at.
 	Attachment *KubernetesFilesystemAttachmentParams
+
+	// Shared is true if the filesystem is shared by all units of
+	// the application, rather than being created for each unit.
+	Shared bool
 }
 
 // KubernetesFilesystemAttachmentParams is a set of parameters for filesystem attachment
@@ -0,0 +1,164 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	CephFSProviderType = storage.ProviderType("cephfs")
+
+	// CephFSMonitors is the pool configuration attribute holding
+	// a comma-separated list of Ceph monitor addresses.
+	CephFSMonitors = "monitors"
+
+	// CephFSPath is the pool configuration attribute holding the
+	// absolute path within the Ceph filesystem to mount.
+	CephFSPath = "path"
+
+	// CephFSUser is the pool configuration attribute holding the
+	// name of the CephX user to authenticate as.
+	CephFSUser = "user"
+
+	// CephFSSecretFile is the pool configuration attribute holding
+	// the path, on each machine, of the file containing the secret
+	// key of the CephX user.
+	CephFSSecretFile = "secret-file"
+)
+
+var cephfsConfigChecker = schema.FieldMap(
+	schema.Fields{
+		CephFSMonitors:          schema.String(),
+		CephFSPath:              schema.String(),
+		CephFSUser:              schema.String(),
+		CephFSSecretFile:        schema.String(),
+		remoteFilesystemOptions: schema.String(),
+	},
+	schema.Defaults{
+		CephFSMonitors:          "",
+		CephFSPath:              "/",
+		CephFSUser:              "",
+		CephFSSecretFile:        "",
+		remoteFilesystemOptions: "",
+	},
+)
+
+type cephfsConfig struct {
+	monitors []string
+	path     string
+	options  []string
+}
+
+func newCephFSConfig(attrs map[string]interface{}) (*cephfsConfig, error) {
+	out, err := cephfsConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating CephFS storage config")
+	}
+	coerced := out.(map[string]interface{})
+	cfg := &cephfsConfig{
+		monitors: splitOptions(coerced[CephFSMonitors].(string)),
+		path:     coerced[CephFSPath].(string),
+	}
+	if len(cfg.monitors) == 0 {
+		return nil, errors.NotValidf("CephFS storage config without %q", CephFSMonitors)
+	}
+	if !path.IsAbs(cfg.path) {
+		return nil, errors.NotValidf("CephFS path %q, must be an absolute path", cfg.path)
+	}
+	if user := coerced[CephFSUser].(string); user != "" {
+		cfg.options = append(cfg.options, "name="+user)
+	}
+	if secretFile := coerced[CephFSSecretFile].(string); secretFile != "" {
+		if !path.IsAbs(secretFile) {
+			return nil, errors.NotValidf("CephFS secret file %q, must be an absolute path", secretFile)
+		}
+		cfg.options = append(cfg.options, "secretfile="+secretFile)
+	}
+	cfg.options = append(cfg.options, splitOptions(coerced[remoteFilesystemOptions].(string))...)
+	return cfg, nil
+}
+
+// cephfsProvider creates filesystem sources which mount
+// a path within a Ceph filesystem using the kernel client.
+type cephfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider               = (*cephfsProvider)(nil)
+	_ storage.HostFilesystemAttacher = (*cephfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *cephfsProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newCephFSConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *cephfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *cephfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	// The pool configuration is passed to CreateFilesystems
+	// in the filesystem parameters, not in the source config.
+	return &remoteFilesystemSource{
+		dirFuncs:    &osDirFuncs{p.run},
+		run:         p.run,
+		fsType:      "ceph",
+		mountConfig: cephfsMountConfig,
+	}, nil
+}
+
+// cephfsMountConfig returns the remote location to mount, and the
+// mount options, given CephFS pool configuration attributes.
+func cephfsMountConfig(attrs map[string]interface{}) (string, []string, error) {
+	cfg, err := newCephFSConfig(attrs)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return strings.Join(cfg.monitors, ",") + ":" + cfg.path, cfg.options, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*cephfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*cephfsProvider) Scope() storage.Scope {
+	// A single filesystem is shared by all of the machines it is
+	// attached to; each machine mounts it, see HostAttachesFilesystems.
+	return storage.ScopeEnviron
+}
+
+// HostAttachesFilesystems is defined on the HostFilesystemAttacher interface.
+func (*cephfsProvider) HostAttachesFilesystems() bool {
+	return true
+}
+
+// Dynamic is defined on the Provider interface.
+func (*cephfsProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*cephfsProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*cephfsProvider) DefaultPools() []*storage.Config {
+	return nil
+}
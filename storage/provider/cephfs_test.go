@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&cephfsSuite{})
+
+type cephfsSuite struct {
+	testing.BaseSuite
+	commands   *mockRunCommand
+	fakeEtcDir string
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *cephfsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.fakeEtcDir = c.MkDir()
+	s.commands = &mockRunCommand{c: c}
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *cephfsSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *cephfsSuite) cephfsConfig(c *gc.C, attrs map[string]interface{}) *storage.Config {
+	cfg, err := storage.NewConfig("shared", provider.CephFSProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *cephfsSuite) TestValidateConfig(c *gc.C) {
+	p := provider.CephFSProvider(s.commands.run)
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"monitors": "10.0.0.1:6789"},
+	}, {
+		attrs: map[string]interface{}{
+			"monitors":    "10.0.0.1,10.0.0.2",
+			"path":        "/volumes/web",
+			"user":        "web",
+			"secret-file": "/etc/ceph/web.secret",
+		},
+	}, {
+		attrs: map[string]interface{}{},
+		err:   `CephFS storage config without "monitors" not valid`,
+	}, {
+		attrs: map[string]interface{}{"monitors": " , "},
+		err:   `CephFS storage config without "monitors" not valid`,
+	}, {
+		attrs: map[string]interface{}{"monitors": "10.0.0.1", "path": "volumes"},
+		err:   `CephFS path "volumes", must be an absolute path not valid`,
+	}, {
+		attrs: map[string]interface{}{"monitors": "10.0.0.1", "secret-file": "web.secret"},
+		err:   `CephFS secret file "web.secret", must be an absolute path not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		err := p.ValidateConfig(s.cephfsConfig(c, test.attrs))
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *cephfsSuite) TestSupports(c *gc.C) {
+	p := provider.CephFSProvider(s.commands.run)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(storage.HostAttachesFilesystems(p), jc.IsTrue)
+}
+
+func (s *cephfsSuite) TestCreateAndAttachFilesystems(c *gc.C) {
+	p := provider.CephFSProvider(s.commands.run)
+	source, _, err := provider.RemoteFilesystemSource(p, s.cephfsConfig(c, nil), s.fakeEtcDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+		Attributes: map[string]interface{}{
+			"monitors":    "10.0.0.1, 10.0.0.2",
+			"path":        "/volumes/web",
+			"user":        "web",
+			"secret-file": "/etc/ceph/web.secret",
+			"options":     "noatime",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	filesystemId := results[0].Filesystem.FilesystemId
+	c.Assert(filesystemId, gc.Equals, "10.0.0.1,10.0.0.2:/volumes/web -o name=web,secretfile=/etc/ceph/web.secret,noatime")
+
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/web")
+	cmd.respond("headers\n/dev/sda1", nil)
+	s.commands.expect(
+		"mount", "-t", "ceph", "10.0.0.1,10.0.0.2:/volumes/web", "/srv/web",
+		"-o", "name=web,secretfile=/etc/ceph/web.secret,noatime",
+	)
+	attachResults, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: filesystemId,
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: "/srv/web",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachResults, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0"),
+			Machine:    names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/srv/web",
+			},
+		},
+	}})
+}
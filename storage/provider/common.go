@@ -16,6 +16,8 @@ var (
 		LoopProviderType:   &loopProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		NFSProviderType:    &nfsProvider{logAndExec},
+		CephFSProviderType: &cephfsProvider{logAndExec},
 	}
 )
 
@@ -36,6 +36,8 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 		provider.LoopProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
+		provider.NFSProviderType,
+		provider.CephFSProviderType,
 	})
 }
 
@@ -139,3 +139,28 @@ func TmpfsFilesystemSource(etcDir, storageDir string, run func(string, ...string
 func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider {
 	return &tmpfsProvider{run}
 }
+
+func NFSProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &nfsProvider{run}
+}
+
+func CephFSProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &cephfsProvider{run}
+}
+
+// RemoteFilesystemSource returns the filesystem source of the given
+// NFS or CephFS provider, with directory operations stubbed out.
+func RemoteFilesystemSource(p storage.Provider, cfg *storage.Config, etcDir string) (storage.FilesystemSource, *MockDirFuncs, error) {
+	source, err := p.FilesystemSource(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	remote := source.(*remoteFilesystemSource)
+	d := &MockDirFuncs{
+		osDirFuncs{remote.run},
+		etcDir,
+		set.NewStrings(),
+	}
+	remote.dirFuncs = d
+	return remote, d, nil
+}
@@ -103,7 +103,10 @@ type loopVolumeSource struct {
 	storageDir string
 }
 
-var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var (
+	_ storage.VolumeSource  = (*loopVolumeSource)(nil)
+	_ storage.VolumeResizer = (*loopVolumeSource)(nil)
+)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -248,6 +251,46 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Volume.Id())
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	info, err := os.Stat(loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "reading loop backing file")
+	}
+	if size := uint64(info.Size()) / (1024 * 1024); arg.Size < size {
+		return errors.NotSupportedf("shrinking volume from %dMiB to %dMiB", size, arg.Size)
+	}
+	// fallocate grows the existing backing file in place.
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return errors.Trace(err)
+	}
+	// Any loop devices attached to the backing file must be told
+	// to re-read its size before the new capacity is visible.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if _, err := lvs.run("losetup", "-c", path.Join("/dev", deviceName)); err != nil {
+			return errors.Annotatef(err, "updating capacity of loop device %q", deviceName)
+		}
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
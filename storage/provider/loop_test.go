@@ -277,3 +277,56 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	_, err = os.Stat(fileName)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+// createSparseFile creates a file of the specified
+// size in MiB, without writing its contents.
+func createSparseFile(c *gc.C, path string, sizeInMiB int64) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = f.Truncate(sizeInMiB * 1024 * 1024)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	volumePath := filepath.Join(s.storageDir, "volume-0")
+	createSparseFile(c, volumePath, 2)
+	s.commands.expect("fallocate", "-l", "4MiB", volumePath)
+	cmd := s.commands.expect("losetup", "-j", volumePath)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Size, gc.Equals, uint64(4))
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing volume 1: reading loop backing file: .*")
+}
+
+func (s *loopSuite) TestResizeVolumesShrink(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	createSparseFile(c, filepath.Join(s.storageDir, "volume-0"), 2)
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing volume 0: shrinking volume from 2MiB to 1MiB not supported")
+}
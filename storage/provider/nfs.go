@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	NFSProviderType = storage.ProviderType("nfs")
+
+	// NFSServer is the pool configuration attribute holding
+	// the host name or address of the NFS server.
+	NFSServer = "server"
+
+	// NFSExport is the pool configuration attribute holding
+	// the absolute path of the directory exported by the server.
+	NFSExport = "export"
+)
+
+var nfsConfigChecker = schema.FieldMap(
+	schema.Fields{
+		NFSServer:               schema.String(),
+		NFSExport:               schema.String(),
+		remoteFilesystemOptions: schema.String(),
+	},
+	schema.Defaults{
+		NFSServer:               "",
+		NFSExport:               "",
+		remoteFilesystemOptions: "",
+	},
+)
+
+type nfsConfig struct {
+	server  string
+	export  string
+	options []string
+}
+
+func newNFSConfig(attrs map[string]interface{}) (*nfsConfig, error) {
+	out, err := nfsConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating NFS storage config")
+	}
+	coerced := out.(map[string]interface{})
+	cfg := &nfsConfig{
+		server:  coerced[NFSServer].(string),
+		export:  coerced[NFSExport].(string),
+		options: splitOptions(coerced[remoteFilesystemOptions].(string)),
+	}
+	if cfg.server == "" {
+		return nil, errors.NotValidf("NFS storage config without %q", NFSServer)
+	}
+	if !path.IsAbs(cfg.export) {
+		return nil, errors.NotValidf("NFS export %q, must be an absolute path", cfg.export)
+	}
+	return cfg, nil
+}
+
+// nfsProvider creates filesystem sources which mount a directory
+// exported by an NFS server.
+type nfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider               = (*nfsProvider)(nil)
+	_ storage.HostFilesystemAttacher = (*nfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newNFSConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *nfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *nfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	// The pool configuration is passed to CreateFilesystems
+	// in the filesystem parameters, not in the source config.
+	return &remoteFilesystemSource{
+		dirFuncs:    &osDirFuncs{p.run},
+		run:         p.run,
+		fsType:      "nfs",
+		mountConfig: nfsMountConfig,
+	}, nil
+}
+
+// nfsMountConfig returns the remote location to mount, and the
+// mount options, given NFS pool configuration attributes.
+func nfsMountConfig(attrs map[string]interface{}) (string, []string, error) {
+	cfg, err := newNFSConfig(attrs)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return cfg.server + ":" + cfg.export, cfg.options, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	// A single filesystem is shared by all of the machines it is
+	// attached to; each machine mounts it, see HostAttachesFilesystems.
+	return storage.ScopeEnviron
+}
+
+// HostAttachesFilesystems is defined on the HostFilesystemAttacher interface.
+func (*nfsProvider) HostAttachesFilesystems() bool {
+	return true
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*nfsProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*nfsProvider) DefaultPools() []*storage.Config {
+	return nil
+}
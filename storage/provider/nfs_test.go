@@ -0,0 +1,304 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	commands   *mockRunCommand
+	fakeEtcDir string
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.fakeEtcDir = c.MkDir()
+	s.commands = &mockRunCommand{c: c}
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsConfig(c *gc.C, attrs map[string]interface{}) *storage.Config {
+	cfg, err := storage.NewConfig("shared", provider.NFSProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+// nfsFilesystemSource returns a filesystem source as the storage
+// provisioner creates it, without the pool configuration.
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) storage.FilesystemSource {
+	p := provider.NFSProvider(s.commands.run)
+	source, _, err := provider.RemoteFilesystemSource(p, s.nfsConfig(c, nil), s.fakeEtcDir)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := provider.NFSProvider(s.commands.run)
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"server": "nfs.example.com", "export": "/srv/share"},
+	}, {
+		attrs: map[string]interface{}{"server": "nfs.example.com", "export": "/srv/share", "options": "vers=4.1"},
+	}, {
+		attrs: map[string]interface{}{"export": "/srv/share"},
+		err:   `NFS storage config without "server" not valid`,
+	}, {
+		attrs: map[string]interface{}{"server": "nfs.example.com"},
+		err:   `NFS export "", must be an absolute path not valid`,
+	}, {
+		attrs: map[string]interface{}{"server": "nfs.example.com", "export": "srv/share"},
+		err:   `NFS export "srv/share", must be an absolute path not valid`,
+	}, {
+		attrs: map[string]interface{}{"server": 123, "export": "/srv/share"},
+		err:   `validating NFS storage config: server: expected string, got int\(123\)`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		err := p.ValidateConfig(s.nfsConfig(c, test.attrs))
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *nfsSuite) TestValidateFilesystemParams(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	err := source.ValidateFilesystemParams(storage.FilesystemParams{
+		Tag:        names.NewFilesystemTag("0"),
+		Attributes: map[string]interface{}{"server": "nfs.example.com", "export": "/srv/share"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = source.ValidateFilesystemParams(storage.FilesystemParams{
+		Tag:        names.NewFilesystemTag("0"),
+		Attributes: map[string]interface{}{"export": "/srv/share"},
+	})
+	c.Assert(err, gc.ErrorMatches, `NFS storage config without "server" not valid`)
+}
+
+func (s *nfsSuite) TestCreateFilesystemsInvalidConfig(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `NFS storage config without "server" not valid`)
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := provider.NFSProvider(s.commands.run)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := provider.NFSProvider(s.commands.run)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(storage.HostAttachesFilesystems(p), jc.IsTrue)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+	c.Assert(p.Releasable(), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("0"),
+		Size:       1024,
+		Attributes: map[string]interface{}{"server": "nfs.example.com", "export": "/srv/share"},
+	}, {
+		Tag:  names.NewFilesystemTag("1"),
+		Size: 2048,
+		Attributes: map[string]interface{}{
+			"server": "nfs.example.com", "export": "/srv/share", "options": "vers=4.1, hard",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	// Both filesystems refer to the same export; the
+	// IDs hold everything needed to mount them.
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "nfs.example.com:/srv/share",
+				Size:         1024,
+			},
+		},
+	}, {
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("1"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "nfs.example.com:/srv/share -o vers=4.1,hard",
+				Size:         2048,
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	errs, err := source.DestroyFilesystems(s.callCtx, []string{"nfs.example.com:/srv/share"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *nfsSuite) TestAttachFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("headers\n/dev/sda1", nil)
+	s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/share", "/srv/data", "-o", "ro,vers=4.1,hard")
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/share -o vers=4.1,hard",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+		Path: "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0"),
+			Machine:    names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     "/srv/data",
+				ReadOnly: true,
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestAttachFilesystemsFstab(c *gc.C) {
+	nonRelatedFstabEntry := "/dev/foo /mount/point stuff\n"
+	err := ioutil.WriteFile(filepath.Join(s.fakeEtcDir, "fstab"), []byte(nonRelatedFstabEntry), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	mtabEntry := "nfs.example.com:/srv/share /srv/data nfs4 rw,relatime,vers=4.1 0 0"
+	err = ioutil.WriteFile(filepath.Join(s.fakeEtcDir, "mtab"), []byte(mtabEntry), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("headers\n/dev/sda1", nil)
+	s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/share", "/srv/data")
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/share",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(filepath.Join(s.fakeEtcDir, "fstab"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, nonRelatedFstabEntry+
+		"nfs.example.com:/srv/share /srv/data nfs4 nofail,relatime,rw,vers=4.1 0 0\n")
+}
+
+func (s *nfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("headers\nnfs.example.com:/srv/share", nil)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/share",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].FilesystemAttachment.Path, gc.Equals, "/srv/data")
+}
+
+func (s *nfsSuite) TestAttachFilesystemsMountedElsewhere(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("headers\nother.example.com:/srv/share", nil)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/share",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `"/srv/data" is already mounted from "other.example.com:/srv/share"`)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/share", "/srv/data")
+	cmd.respond("", errors.New("access denied by server"))
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/share",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "cannot mount nfs filesystem: access denied by server")
+}
+
+func (s *nfsSuite) TestAttachFilesystemsNoPath(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/share",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem mount point not specified")
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	nonRelatedFstabEntry := "/dev/foo /mount/point stuff\n"
+	fstabEntry := fmt.Sprintf("nfs.example.com:/srv/share %s nfs4 nofail,rw 0 0", testMountPoint)
+	err := ioutil.WriteFile(filepath.Join(s.fakeEtcDir, "fstab"), []byte(nonRelatedFstabEntry+fstabEntry), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	source := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, true, s.fakeEtcDir, nonRelatedFstabEntry)
+}
+
+func (s *nfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, false, s.fakeEtcDir, "")
+}
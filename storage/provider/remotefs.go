@@ -0,0 +1,210 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// remoteFilesystemOptions is the pool configuration attribute
+	// holding additional options to pass to "mount".
+	remoteFilesystemOptions = "options"
+)
+
+// remoteFilesystemSource is a storage.FilesystemSource that mounts
+// a filesystem exported by a remote server, such as an NFS or CephFS
+// server, on the machine that the storage is attached to.
+//
+// The remote filesystem is managed outside of Juju. Every filesystem
+// created from a pool refers to the same remote location, so it may
+// be attached to many machines, and shared by many units, at once.
+// Filesystems are created by the model's storage provisioner, which
+// has the pool configuration; the ID of the filesystem holds all that
+// the machines need to mount it.
+type remoteFilesystemSource struct {
+	dirFuncs dirFuncs
+	run      runCommandFunc
+
+	// fsType is the filesystem type passed to "mount -t".
+	fsType string
+
+	// mountConfig returns the remote location to mount, e.g.
+	// "server:/export", and the mount options, excluding "ro",
+	// given the pool configuration attributes.
+	mountConfig func(attrs map[string]interface{}) (string, []string, error)
+}
+
+var _ storage.FilesystemSource = (*remoteFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *remoteFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// The remote filesystem already exists; its capacity
+	// is managed by the server, not by Juju.
+	_, _, err := s.mountConfig(params.Attributes)
+	return errors.Trace(err)
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *remoteFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		// There is nothing to create; the filesystem refers to the
+		// remote location, and is mounted by AttachFilesystems.
+		source, options, err := s.mountConfig(arg.Attributes)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].Filesystem = &storage.Filesystem{
+			Tag:    arg.Tag,
+			Volume: arg.Volume,
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: remoteFilesystemId(source, options),
+				Size:         arg.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *remoteFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	// The remote filesystem is not managed by Juju, and may be
+	// in use by other filesystems; its contents are left intact.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *remoteFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *remoteFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *remoteFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	path := arg.Path
+	if path == "" {
+		return nil, errNoMountPoint
+	}
+	if arg.FilesystemId == "" {
+		return nil, errors.NotValidf("attaching filesystem without ID")
+	}
+	source, options := parseRemoteFilesystemId(arg.FilesystemId)
+	if err := ensureDir(s.dirFuncs, path); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check if the mount already exists.
+	mounted, mountedSource, err := isMounted(s.dirFuncs, path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mounted && mountedSource != source {
+		return nil, errors.Errorf("%q is already mounted from %q", path, mountedSource)
+	}
+	if !mounted {
+		if err := ensureEmptyDir(s.dirFuncs, path); err != nil {
+			return nil, err
+		}
+		if arg.ReadOnly {
+			options = append([]string{"ro"}, options...)
+		}
+		mountArgs := []string{"-t", s.fsType, source, path}
+		if len(options) > 0 {
+			mountArgs = append(mountArgs, "-o", strings.Join(options, ","))
+		}
+		if _, err := s.run("mount", mountArgs...); err != nil {
+			os.Remove(path)
+			return nil, errors.Annotatef(err, "cannot mount %s filesystem", s.fsType)
+		}
+	}
+
+	// Copy the mtab entry to fstab so the filesystem is mounted
+	// again after a reboot; DetachFilesystems removes it.
+	etcDir := s.dirFuncs.etcDir()
+	mtabEntry, err := extractMtabEntry(etcDir, source, path)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing /etc/mtab")
+	}
+	if mtabEntry != "" {
+		if err := ensureFstabEntry(etcDir, source, "", path, mtabEntry); err != nil {
+			return nil, errors.Annotate(err, "updating /etc/fstab failed")
+		}
+	}
+
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     path,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *remoteFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
+
+// remoteFilesystemOptionsSeparator separates the remote location from
+// the mount options in the ID of a remote filesystem.
+const remoteFilesystemOptionsSeparator = " -o "
+
+// remoteFilesystemId returns the ID of a filesystem that mounts the
+// given remote location with the given options, as understood by
+// parseRemoteFilesystemId.
+func remoteFilesystemId(source string, options []string) string {
+	if len(options) == 0 {
+		return source
+	}
+	return source + remoteFilesystemOptionsSeparator + strings.Join(options, ",")
+}
+
+// parseRemoteFilesystemId returns the remote location and the mount
+// options held in the ID of a remote filesystem.
+func parseRemoteFilesystemId(id string) (string, []string) {
+	i := strings.LastIndex(id, remoteFilesystemOptionsSeparator)
+	if i == -1 {
+		return id, nil
+	}
+	return id[:i], splitOptions(id[i+len(remoteFilesystemOptionsSeparator):])
+}
+
+// splitOptions splits a comma-separated list of mount options,
+// discarding empty options.
+func splitOptions(options string) []string {
+	var result []string
+	for _, option := range strings.Split(options, ",") {
+		if option = strings.TrimSpace(option); option != "" {
+			result = append(result, option)
+		}
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&remoteFilesystemSuite{})
+
+// remoteFilesystemSuite exercises the NFS provider against a userspace
+// stand-in for the kernel's mount table, to check that a single export
+// may be attached to many machines at once.
+type remoteFilesystemSuite struct {
+	testing.BaseSuite
+	callCtx context.ProviderCallContext
+}
+
+func (s *remoteFilesystemSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.callCtx = context.NewCloudCallContext()
+}
+
+// fakeMountTable stands in for the mount table of a machine, responding
+// to the "df", "mount" and "umount" commands run by the providers.
+type fakeMountTable struct {
+	mounts map[string]string
+}
+
+func newFakeMountTable() *fakeMountTable {
+	return &fakeMountTable{mounts: make(map[string]string)}
+}
+
+func (t *fakeMountTable) run(cmd string, args ...string) (string, error) {
+	switch cmd {
+	case "df":
+		path := args[len(args)-1]
+		source := "/dev/root"
+		for p := path; ; p = filepath.Dir(p) {
+			if mounted, ok := t.mounts[p]; ok {
+				source = mounted
+				break
+			}
+			if p == "/" {
+				break
+			}
+		}
+		return "Filesystem\n" + source, nil
+	case "mount":
+		if len(args) < 4 || args[0] != "-t" {
+			return "", fmt.Errorf("unexpected mount args %q", args)
+		}
+		source, target := args[2], args[3]
+		if _, ok := t.mounts[target]; ok {
+			return "", fmt.Errorf("%s already mounted", target)
+		}
+		t.mounts[target] = source
+		return "", nil
+	case "umount":
+		if _, ok := t.mounts[args[0]]; !ok {
+			return "", fmt.Errorf("%s: not mounted", args[0])
+		}
+		delete(t.mounts, args[0])
+		return "", nil
+	}
+	return "", fmt.Errorf("unexpected command %s %s", cmd, strings.Join(args, " "))
+}
+
+func (s *remoteFilesystemSuite) machineSource(c *gc.C, table *fakeMountTable) storage.FilesystemSource {
+	cfg, err := storage.NewConfig("nfs", provider.NFSProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	source, _, err := provider.RemoteFilesystemSource(provider.NFSProvider(table.run), cfg, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *remoteFilesystemSuite) attach(
+	c *gc.C, source storage.FilesystemSource, machine, filesystem, path string,
+) {
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag(filesystem),
+		FilesystemId: "nfs.example.com:/srv/share",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag(machine),
+		},
+		Path: path,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *remoteFilesystemSuite) TestMultiAttach(c *gc.C) {
+	machine0, machine1 := newFakeMountTable(), newFakeMountTable()
+	source0 := s.machineSource(c, machine0)
+	source1 := s.machineSource(c, machine1)
+
+	// Filesystem 0 is shared by units on machines 0 and 1; filesystem
+	// 1, from the same pool, is attached to machine 0 only.
+	s.attach(c, source0, "0", "0", "/srv/web/0")
+	s.attach(c, source0, "0", "1", "/srv/web/1")
+	s.attach(c, source1, "1", "0", "/srv/web/0")
+	c.Assert(machine0.mounts, jc.DeepEquals, map[string]string{
+		"/srv/web/0": "nfs.example.com:/srv/share",
+		"/srv/web/1": "nfs.example.com:/srv/share",
+	})
+	c.Assert(machine1.mounts, jc.DeepEquals, map[string]string{
+		"/srv/web/0": "nfs.example.com:/srv/share",
+	})
+
+	// Reattaching, e.g. when the agent restarts, is a no-op.
+	s.attach(c, source1, "1", "0", "/srv/web/0")
+	c.Assert(machine1.mounts, gc.HasLen, 1)
+
+	// Detaching from one machine leaves the others mounted.
+	errs, err := source0.DetachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+		Path:       "/srv/web/0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	c.Assert(machine0.mounts, jc.DeepEquals, map[string]string{
+		"/srv/web/1": "nfs.example.com:/srv/share",
+	})
+	c.Assert(machine1.mounts, gc.HasLen, 1)
+}
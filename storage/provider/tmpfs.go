@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -100,7 +101,10 @@ type tmpfsFilesystemSource struct {
 	storageDir string
 }
 
-var _ storage.FilesystemSource = (*tmpfsFilesystemSource)(nil)
+var (
+	_ storage.FilesystemSource  = (*tmpfsFilesystemSource)(nil)
+	_ storage.FilesystemResizer = (*tmpfsFilesystemSource)(nil)
+)
 
 // ValidateFilesystemParams is defined on the FilesystemSource interface.
 func (s *tmpfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
@@ -130,17 +134,9 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 	if err := s.ValidateFilesystemParams(params); err != nil {
 		return nil, errors.Trace(err)
 	}
-	// Align size to the page size in MiB.
-	sizeInMiB := params.Size
-	pageSizeInMiB := uint64(getpagesize()) / (1024 * 1024)
-	if pageSizeInMiB > 0 {
-		x := (sizeInMiB + pageSizeInMiB - 1)
-		sizeInMiB = x - x%pageSizeInMiB
-	}
-
 	info := storage.FilesystemInfo{
 		FilesystemId: params.Tag.String(),
-		Size:         sizeInMiB,
+		Size:         alignToPageSize(params.Size),
 	}
 
 	// Creating the mount is the responsibility of AttachFilesystems.
@@ -154,6 +150,17 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 	return &storage.Filesystem{params.Tag, params.Volume, info}, nil
 }
 
+// alignToPageSize rounds the given size in MiB
+// up to a multiple of the page size.
+func alignToPageSize(sizeInMiB uint64) uint64 {
+	pageSizeInMiB := uint64(getpagesize()) / (1024 * 1024)
+	if pageSizeInMiB > 0 {
+		x := (sizeInMiB + pageSizeInMiB - 1)
+		sizeInMiB = x - x%pageSizeInMiB
+	}
+	return sizeInMiB
+}
+
 // DestroyFilesystems is defined on the FilesystemSource interface.
 func (s *tmpfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
 	// DestroyFilesystems is a no-op; there is nothing to destroy,
@@ -236,6 +243,67 @@ func (s *tmpfsFilesystemSource) DetachFilesystems(ctx context.ProviderCallContex
 	return results, nil
 }
 
+// ResizeFilesystems is defined on the FilesystemResizer interface.
+func (s *tmpfsFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing filesystem %s", arg.Filesystem.Id())
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *tmpfsFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (uint64, error) {
+	info, err := s.readFilesystemInfo(arg.Filesystem)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if arg.Size < info.Size {
+		return 0, errors.NotSupportedf("shrinking filesystem from %dMiB to %dMiB", info.Size, arg.Size)
+	}
+	size := alignToPageSize(arg.Size)
+
+	// Remount any existing mounts with the new size before
+	// recording it, so that subsequent attachments use it too.
+	mountPoints, err := tmpfsMountPoints(s.run, arg.Filesystem.String())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	for _, mountPoint := range mountPoints {
+		if _, err := s.run(
+			"mount", "-o", fmt.Sprintf("remount,size=%dm", size), mountPoint,
+		); err != nil {
+			return 0, errors.Annotatef(err, "remounting tmpfs at %q", mountPoint)
+		}
+	}
+	err = utils.WriteYaml(s.filesystemInfoFile(arg.Filesystem), filesystemInfo{&size})
+	if err != nil {
+		return 0, errors.Annotate(err, "writing filesystem info to disk")
+	}
+	return size, nil
+}
+
+// tmpfsMountPoints returns the mount points of the
+// tmpfs mounts with the specified source.
+func tmpfsMountPoints(run runCommandFunc, source string) ([]string, error) {
+	output, err := run("findmnt", "--noheadings", "--list", "--output", "SOURCE,TARGET", "--types", "tmpfs")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing tmpfs mounts")
+	}
+	var mountPoints []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == source {
+			mountPoints = append(mountPoints, fields[1])
+		}
+	}
+	return mountPoints, nil
+}
+
 func (s *tmpfsFilesystemSource) writeFilesystemInfo(tag names.FilesystemTag, info storage.FilesystemInfo) error {
 	filename := s.filesystemInfoFile(tag)
 	if _, err := os.Stat(filename); err == nil {
@@ -287,3 +287,61 @@ func (s *tmpfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
 	testDetachFilesystems(c, s.commands, source, s.callCtx, false, s.fakeEtcDir, "")
 }
+
+func (s *tmpfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	_, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmd := s.commands.expect("findmnt", "--noheadings", "--list", "--output", "SOURCE,TARGET", "--types", "tmpfs")
+	cmd.respond("tmpfs /run\nfilesystem-6 /srv/data\nfilesystem-60 /srv/other\n", nil)
+	s.commands.expect("mount", "-o", "remount,size=4m", "/srv/data")
+
+	resizer := source.(storage.FilesystemResizer)
+	results, err := resizer.ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "filesystem-6",
+		Size:         4,
+	}, {
+		Filesystem:   names.NewFilesystemTag("7"),
+		FilesystemId: "filesystem-7",
+		Size:         4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Size, gc.Equals, uint64(4))
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing filesystem 7: reading filesystem info from disk: .*")
+
+	// Subsequent attachments are mounted with the new size.
+	cmd = s.commands.expect("df", "--output=source", "/srv/data2")
+	cmd.respond("headers\nnone", nil)
+	s.commands.expect("mount", "-t", "tmpfs", "filesystem-6", "/srv/data2", "-o", "size=4m")
+	attachResults, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("6"),
+		Path:       "/srv/data2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachResults[0].Error, jc.ErrorIsNil)
+}
+
+func (s *tmpfsSuite) TestResizeFilesystemsShrink(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	_, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resizer := source.(storage.FilesystemResizer)
+	results, err := resizer.ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "filesystem-6",
+		Size:         1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing filesystem 6: shrinking filesystem from 2MiB to 1MiB not supported")
+}
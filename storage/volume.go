@@ -3,7 +3,11 @@
 
 package storage
 
-import "github.com/juju/names/v4"
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+)
 
 type DeviceType string
 
@@ -43,6 +47,26 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider ID of the volume that the
+	// snapshot was taken from.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64
+
+	// Created is the time at which the snapshot was started.
+	Created time.Time
+
+	// Status is the provider-specific status of the snapshot,
+	// e.g. "pending" or "completed".
+	Status string
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
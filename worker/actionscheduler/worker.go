@@ -0,0 +1,221 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker which runs actions on
+// the schedules defined in a model.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/core/watcher"
+)
+
+// missedRunGrace is how late a run of a schedule may be enqueued,
+// when the worker wasn't running at the time it was due. Runs due
+// longer ago than this are skipped, so that a controller outage or
+// a paused schedule doesn't cause a burst of runs.
+const missedRunGrace = time.Hour
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+	ActionSchedules() ([]params.ActionSchedule, error)
+	RunActionSchedules([]params.RunActionScheduleArg) ([]params.ActionScheduleRunResult, error)
+}
+
+// Config holds the configuration for an action scheduler worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate returns an error if the config can't be used
+// to start a worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker enqueues the actions of the model's action schedules
+// as they become due.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// attempted holds the time of the most recent run of each
+	// schedule the worker has tried to enqueue, so that a run
+	// which can't be recorded isn't retried.
+	attempted map[string]time.Time
+}
+
+// NewWorker returns a worker which enqueues the actions of the
+// model's action schedules as they become due.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:    config,
+		attempted: make(map[string]time.Time),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher, err := w.config.Facade.WatchActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		schedules []params.ActionSchedule
+		timeout   <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("action schedule watcher closed")
+			}
+			if schedules, err = w.config.Facade.ActionSchedules(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-timeout:
+		}
+
+		next, err := w.runDue(schedules)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		timeout = nil
+		if !next.IsZero() {
+			delay := next.Sub(w.config.Clock.Now())
+			w.config.Logger.Debugf("next action schedule run due in %v", delay)
+			timeout = w.config.Clock.After(delay)
+		}
+	}
+}
+
+// runDue enqueues the runs of the schedules which are due, and
+// returns the time the next run of any schedule is due, or the
+// zero time if none are.
+func (w *Worker) runDue(schedules []params.ActionSchedule) (time.Time, error) {
+	now := w.config.Clock.Now().UTC()
+	var (
+		runs []params.RunActionScheduleArg
+		next time.Time
+	)
+	for _, schedule := range schedules {
+		if schedule.Paused {
+			continue
+		}
+		due, upcoming, err := w.dueRun(schedule, now)
+		if err != nil {
+			w.config.Logger.Errorf("cannot schedule %q: %v", schedule.Name, err)
+			continue
+		}
+		if !due.IsZero() {
+			runs = append(runs, params.RunActionScheduleArg{
+				Name:      schedule.Name,
+				Scheduled: due,
+			})
+			w.attempted[schedule.Name] = due
+		}
+		if !upcoming.IsZero() && (next.IsZero() || upcoming.Before(next)) {
+			next = upcoming
+		}
+	}
+	if len(runs) == 0 {
+		return next, nil
+	}
+
+	results, err := w.config.Facade.RunActionSchedules(runs)
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "running action schedules")
+	}
+	for i, result := range results {
+		name := runs[i].Name
+		switch {
+		case result.Error != nil && params.IsCodeAlreadyExists(result.Error):
+			w.config.Logger.Debugf("run of %q already recorded", name)
+		case result.Error != nil:
+			w.config.Logger.Errorf("cannot run %q: %v", name, result.Error)
+		case result.Run.Error != "":
+			w.config.Logger.Errorf("run of %q failed: %v", name, result.Run.Error)
+		default:
+			w.config.Logger.Infof("ran %q as %v", name, result.Run.OperationTag)
+		}
+	}
+	return next, nil
+}
+
+// dueRun returns the time of the schedule's latest run which is
+// due now but hasn't been run, if any, and the time of its next
+// run after now.
+func (w *Worker) dueRun(schedule params.ActionSchedule, now time.Time) (due, upcoming time.Time, _ error) {
+	spec, err := cron.Parse(schedule.Cron)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Trace(err)
+	}
+	from := schedule.Created.UTC()
+	if n := len(schedule.Runs); n > 0 {
+		from = schedule.Runs[n-1].Scheduled.UTC()
+	}
+	if attempted, ok := w.attempted[schedule.Name]; ok && attempted.After(from) {
+		from = attempted
+	}
+	if earliest := now.Add(-missedRunGrace); from.Before(earliest) {
+		from = earliest
+	}
+	for t := spec.Next(from); !t.IsZero(); t = spec.Next(t) {
+		if t.After(now) {
+			return due, t, nil
+		}
+		due = t
+	}
+	return due, time.Time{}, nil
+}
@@ -0,0 +1,291 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *fakeFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var created = time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = newFakeFacade()
+	s.clock = testclock.NewClock(time.Date(2021, 6, 2, 2, 59, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *actionscheduler.Worker {
+	w, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) assertRun(c *gc.C, expect ...params.RunActionScheduleArg) {
+	select {
+	case runs := <-s.facade.runs:
+		c.Assert(runs, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for runs")
+	}
+}
+
+func (s *WorkerSuite) assertNoRun(c *gc.C) {
+	select {
+	case runs := <-s.facade.runs:
+		c.Fatalf("unexpected runs %v", runs)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	}
+	c.Check(config.Validate(), jc.ErrorIsNil)
+
+	noFacade := config
+	noFacade.Facade = nil
+	c.Check(noFacade.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	noClock := config
+	noClock.Clock = nil
+	c.Check(noClock.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	noLogger := config
+	noLogger.Logger = nil
+	c.Check(noLogger.Validate(), gc.ErrorMatches, "nil Logger not valid")
+}
+
+func (s *WorkerSuite) TestRunsWhenDue(c *gc.C) {
+	s.facade.setSchedules(params.ActionSchedule{
+		Name:    "nightly-backup",
+		Cron:    "0 3 * * *",
+		Created: created,
+	})
+	s.startWorker(c)
+	s.assertNoRun(c)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRun(c, params.RunActionScheduleArg{
+		Name:      "nightly-backup",
+		Scheduled: time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *WorkerSuite) TestRunsMissedWithinGrace(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2021, 6, 2, 3, 30, 0, 0, time.UTC))
+	s.facade.setSchedules(params.ActionSchedule{
+		Name:    "nightly-backup",
+		Cron:    "0 3 * * *",
+		Created: created,
+	})
+	s.startWorker(c)
+	s.assertRun(c, params.RunActionScheduleArg{
+		Name:      "nightly-backup",
+		Scheduled: time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *WorkerSuite) TestSkipsMissedBeyondGrace(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2021, 6, 2, 5, 0, 0, 0, time.UTC))
+	s.facade.setSchedules(params.ActionSchedule{
+		Name:    "nightly-backup",
+		Cron:    "0 3 * * *",
+		Created: created,
+	})
+	s.startWorker(c)
+	s.assertNoRun(c)
+
+	err := s.clock.WaitAdvance(22*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRun(c, params.RunActionScheduleArg{
+		Name:      "nightly-backup",
+		Scheduled: time.Date(2021, 6, 3, 3, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *WorkerSuite) TestCatchesUpWithLatestRunOnly(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2021, 6, 2, 2, 10, 30, 0, time.UTC))
+	s.facade.setSchedules(params.ActionSchedule{
+		Name:    "health-check",
+		Cron:    "* * * * *",
+		Created: created,
+		Runs: []params.ActionScheduleRun{{
+			Scheduled: time.Date(2021, 6, 2, 2, 0, 0, 0, time.UTC),
+		}},
+	})
+	s.startWorker(c)
+	s.assertRun(c, params.RunActionScheduleArg{
+		Name:      "health-check",
+		Scheduled: time.Date(2021, 6, 2, 2, 10, 0, 0, time.UTC),
+	})
+}
+
+func (s *WorkerSuite) TestPausedNotRun(c *gc.C) {
+	s.facade.setSchedules(params.ActionSchedule{
+		Name:    "nightly-backup",
+		Cron:    "0 3 * * *",
+		Created: created,
+		Paused:  true,
+	}, params.ActionSchedule{
+		Name:    "hourly",
+		Cron:    "@hourly",
+		Created: created,
+		Runs: []params.ActionScheduleRun{{
+			Scheduled: time.Date(2021, 6, 2, 2, 0, 0, 0, time.UTC),
+		}},
+	})
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRun(c, params.RunActionScheduleArg{
+		Name:      "hourly",
+		Scheduled: time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *WorkerSuite) TestFailedRunNotRetried(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2021, 6, 2, 3, 30, 0, 0, time.UTC))
+	s.facade.setSchedules(params.ActionSchedule{
+		Name:    "nightly-backup",
+		Cron:    "0 3 * * *",
+		Created: created,
+	})
+	s.facade.setRunError(&params.Error{Message: "boom"})
+	s.startWorker(c)
+	s.assertRun(c, params.RunActionScheduleArg{
+		Name:      "nightly-backup",
+		Scheduled: time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC),
+	})
+
+	s.facade.changes <- struct{}{}
+	s.assertNoRun(c)
+}
+
+func (s *WorkerSuite) TestScheduleChangesReloaded(c *gc.C) {
+	s.startWorker(c)
+	s.assertNoRun(c)
+
+	s.facade.setSchedules(params.ActionSchedule{
+		Name:    "nightly-backup",
+		Cron:    "59 2 * * *",
+		Created: created,
+	})
+	s.facade.changes <- struct{}{}
+	s.assertRun(c, params.RunActionScheduleArg{
+		Name:      "nightly-backup",
+		Scheduled: time.Date(2021, 6, 2, 2, 59, 0, 0, time.UTC),
+	})
+}
+
+func (s *WorkerSuite) TestActionSchedulesError(c *gc.C) {
+	s.facade.setSchedulesError(errors.New("boom"))
+	w, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeFacade struct {
+	mu           sync.Mutex
+	schedules    []params.ActionSchedule
+	schedulesErr error
+	runErr       *params.Error
+
+	changes chan struct{}
+	runs    chan []params.RunActionScheduleArg
+}
+
+func newFakeFacade() *fakeFacade {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	return &fakeFacade{
+		changes: changes,
+		runs:    make(chan []params.RunActionScheduleArg, 10),
+	}
+}
+
+func (f *fakeFacade) setSchedules(schedules ...params.ActionSchedule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedules = schedules
+}
+
+func (f *fakeFacade) setSchedulesError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedulesErr = err
+}
+
+func (f *fakeFacade) setRunError(err *params.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runErr = err
+}
+
+func (f *fakeFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) ActionSchedules() ([]params.ActionSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.schedules, f.schedulesErr
+}
+
+func (f *fakeFacade) RunActionSchedules(runs []params.RunActionScheduleArg) ([]params.ActionScheduleRunResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs <- runs
+	results := make([]params.ActionScheduleRunResult, len(runs))
+	for i, run := range runs {
+		if f.runErr != nil {
+			results[i].Error = f.runErr
+			continue
+		}
+		results[i].Run = &params.ActionScheduleRun{
+			Scheduled:    run.Scheduled,
+			Enqueued:     run.Scheduled,
+			OperationTag: "operation-1",
+		}
+	}
+	return results, nil
+}
@@ -4,22 +4,58 @@
 package auditconfigupdater
 
 import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	jujuagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
 
+const (
+	// syslogQueueSize is the number of audit records held while
+	// the syslog server is unreachable.
+	syslogQueueSize = 10000
+
+	// webhookMaxSpooled is the number of undelivered audit
+	// records held in the webhook spool.
+	webhookMaxSpooled = 100000
+
+	// webhookTimeout bounds each delivery to the webhook, so that
+	// an unresponsive webhook doesn't stall delivery indefinitely.
+	webhookTimeout = 30 * time.Second
+
+	// dbBufferSize and dbFlushInterval bound the audit records
+	// held before they're written to the database together.
+	dbBufferSize    = 1024
+	dbFlushInterval = 2 * time.Second
+)
+
+// Logger represents the methods used by the manifold to log details.
+type Logger interface {
+	Errorf(string, ...interface{})
+}
+
 // ManifoldConfig holds the information needed to run an
 // auditconfigupdater in a dependency.Engine.
 type ManifoldConfig struct {
-	AgentName string
-	StateName string
-	NewWorker func(ConfigSource, auditlog.Config, AuditLogFactory) (worker.Worker, error)
+	AgentName            string
+	StateName            string
+	Clock                clock.Clock
+	Logger               Logger
+	PrometheusRegisterer prometheus.Registerer
+	NewWorker            func(ConfigSource, auditlog.Config, AuditLogFactory) (worker.Worker, error)
 }
 
 // Validate validates the manifold configuration.
@@ -30,6 +66,15 @@ func (config ManifoldConfig) Validate() error {
 	if config.StateName == "" {
 		return errors.NotValidf("empty StateName")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
@@ -73,12 +118,56 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		}
 	}()
 
-	logDir := agent.CurrentConfig().LogDir()
+	agentConfig := agent.CurrentConfig()
+	logDir := agentConfig.LogDir()
+	spoolDir := filepath.Join(agentConfig.DataDir(), "audit-webhook-spool")
 
 	st := statePool.SystemState()
 
+	// The webhook's undelivered records are counted across
+	// sinks, which are replaced when the config changes.
+	webhookClient := &http.Client{Timeout: webhookTimeout}
+	webhookDropped := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "juju_audit",
+		Name:      "webhook_dropped_records_total",
+		Help:      "Audit records discarded without being delivered to the webhook",
+	}, []string{"reason"})
+
+	// Records are written to the database as well as the local file
+	// so that the records from every controller can be queried together.
+	// They're also forwarded to any configured syslog server and
+	// webhook; a sink which can't be created is logged and skipped
+	// rather than preventing the records being kept locally.
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		logs := []auditlog.AuditLog{
+			auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups),
+			state.NewDbAuditLog(st, dbBufferSize, dbFlushInterval),
+		}
+		if cfg.Syslog.Enabled {
+			sink, err := newSyslogSink(cfg.Syslog, config.Clock)
+			if err != nil {
+				config.Logger.Errorf("cannot forward audit records to syslog: %v", err)
+			} else {
+				logs = append(logs, sink)
+			}
+		}
+		if cfg.WebhookURL != "" {
+			sink, err := auditlog.NewWebhookSink(auditlog.WebhookConfig{
+				URL:        cfg.WebhookURL,
+				Secret:     cfg.WebhookSecret,
+				SpoolDir:   spoolDir,
+				MaxSpooled: webhookMaxSpooled,
+				Client:     webhookClient,
+				Clock:      config.Clock,
+				Dropped:    webhookDropped,
+			})
+			if err != nil {
+				config.Logger.Errorf("cannot forward audit records to webhook: %v", err)
+			} else {
+				logs = append(logs, sink)
+			}
+		}
+		return auditlog.NewMultiLog(logs...)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -92,7 +181,42 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+	_ = config.PrometheusRegisterer.Register(webhookDropped)
+	return common.NewCleanupWorker(w, func() {
+		// Write out any records still buffered for the
+		// database before the state is released.
+		if u, ok := w.(withCurrentConfig); ok && u.CurrentConfig().Target != nil {
+			if err := u.CurrentConfig().Target.Close(); err != nil {
+				config.Logger.Errorf("closing audit log: %v", err)
+			}
+		}
+		config.PrometheusRegisterer.Unregister(webhookDropped)
+		stTracker.Done()
+	}), nil
+}
+
+// newSyslogSink returns an audit log sink which forwards
+// records to the syslog server described by cfg.
+func newSyslogSink(cfg syslog.RawConfig, clock clock.Clock) (auditlog.AuditLog, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting hostname")
+	}
+	return auditlog.NewSyslogSink(auditlog.SyslogConfig{
+		Open: func() (auditlog.SyslogSender, error) {
+			client, err := syslog.Open(cfg)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return client.Sender, nil
+		},
+		Hostname:  hostname,
+		QueueSize: syslogQueueSize,
+		Clock:     clock,
+	})
 }
 
 type withCurrentConfig interface {
@@ -126,6 +250,9 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Syslog:         cfg.AuditLogSyslogConfig(),
+		WebhookURL:     cfg.AuditLogWebhookURL(),
+		WebhookSecret:  cfg.AuditLogWebhookSecret(),
 	}
 	return result, nil
 }
@@ -4,14 +4,17 @@
 package auditconfigupdater_test
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
 	dt "github.com/juju/worker/v2/dependency/testing"
 	"github.com/juju/worker/v2/workertest"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
@@ -46,6 +49,7 @@ func (s *manifoldSuite) SetUpTest(c *gc.C) {
 
 	s.agent = &mockAgent{}
 	s.agent.conf.logDir = c.MkDir()
+	s.agent.conf.dataDir = c.MkDir()
 
 	s.stateTracker = stubStateTracker{
 		pool: s.StatePool,
@@ -55,9 +59,12 @@ func (s *manifoldSuite) SetUpTest(c *gc.C) {
 	s.context = s.newContext(nil)
 
 	s.manifold = auditconfigupdater.Manifold(auditconfigupdater.ManifoldConfig{
-		AgentName: "agent",
-		StateName: "state",
-		NewWorker: s.newWorker,
+		AgentName:            "agent",
+		StateName:            "state",
+		Clock:                clock.WallClock,
+		Logger:               loggo.GetLogger("test"),
+		PrometheusRegisterer: prometheus.NewRegistry(),
+		NewWorker:            s.newWorker,
 	})
 }
 
@@ -201,13 +208,18 @@ func (ma *mockAgent) CurrentConfig() agent.Config {
 
 type mockAgentConfig struct {
 	agent.Config
-	logDir string
+	logDir  string
+	dataDir string
 }
 
 func (c *mockAgentConfig) LogDir() string {
 	return c.logDir
 }
 
+func (c *mockAgentConfig) DataDir() string {
+	return c.dataDir
+}
+
 type stubStateTracker struct {
 	testing.Stub
 	pool *state.StatePool
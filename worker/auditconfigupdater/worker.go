@@ -95,14 +95,32 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Syslog:         cfg.AuditLogSyslogConfig(),
+		WebhookURL:     cfg.AuditLogWebhookURL(),
+		WebhookSecret:  cfg.AuditLogWebhookSecret(),
 	}
 	if result.Enabled && u.current.Target == nil {
 		result.Target = u.logFactory(result)
+	} else if result.Enabled && !result.SameForwarding(u.current) {
+		// The records need to go somewhere different, so replace
+		// the target. The old one is closed first so that only one
+		// sink is ever delivering from the webhook spool.
+		if err := u.current.Target.Close(); err != nil {
+			return auditlog.Config{}, errors.Annotate(err, "closing audit log")
+		}
+		result.Target = u.logFactory(result)
 	} else {
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
-		// because enabled is false.
+		// because enabled is false. The forwarding details are those
+		// of the target, so that changes made while auditing is
+		// disabled are picked up when it's enabled again.
 		result.Target = u.current.Target
+		if result.Target != nil {
+			result.Syslog = u.current.Syslog
+			result.WebhookURL = u.current.WebhookURL
+			result.WebhookSecret = u.current.WebhookSecret
+		}
 	}
 	return result, nil
 }
@@ -188,6 +188,44 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingForwardingReplacesTarget(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-webhook-url"] = "https://siem.example.com/audit"
+	cfg["audit-log-webhook-secret"] = "sekrit"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Target == auditlog.AuditLog(newTarget)
+	})
+	c.Assert(newConfig.WebhookURL, gc.Equals, "https://siem.example.com/audit")
+	c.Assert(newConfig.WebhookSecret, gc.Equals, "sekrit")
+	c.Assert(calls, gc.HasLen, 1)
+	oldTarget.CheckCallNames(c, "Close")
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
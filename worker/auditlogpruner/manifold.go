@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogpruner
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
+	"github.com/juju/juju/worker/pruner"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run an audit
+// log pruner worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName string
+	StateName string
+
+	PruneInterval time.Duration
+	Logger        pruner.Logger
+	NewWorker     func(pruner.Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.PruneInterval <= 0 {
+		return errors.NotValidf("non-positive PruneInterval")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run an
+// audit log pruner worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker, err error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			stTracker.Done()
+		}
+	}()
+
+	w, err := config.NewWorker(pruner.Config{
+		ControllerFacade: stateBackend{st: statePool.SystemState()},
+		PruneInterval:    config.PruneInterval,
+		Clock:            clock,
+		Logger:           config.Logger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+}
+
+type stateBackend struct {
+	st *state.State
+}
+
+// ControllerConfig is part of the pruner.ControllerFacade interface.
+func (b stateBackend) ControllerConfig() (controller.Config, error) {
+	return b.st.ControllerConfig()
+}
+
+// WatchControllerConfig is part of the pruner.ControllerFacade interface.
+func (b stateBackend) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	return notifyWatcher{b.st.WatchControllerConfig()}, nil
+}
+
+// Prune is part of the pruner.ControllerFacade interface.
+func (b stateBackend) Prune(stop <-chan struct{}, maxAge time.Duration, maxSizeMB int) error {
+	err := state.PruneAuditLog(stop, b.st, maxAge, maxSizeMB)
+	return errors.Annotate(err, "pruning audit log")
+}
+
+// notifyWatcher adapts a state.NotifyWatcher to watcher.NotifyWatcher.
+type notifyWatcher struct {
+	state.NotifyWatcher
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w notifyWatcher) Changes() watcher.NotifyChannel {
+	return w.NotifyWatcher.Changes()
+}
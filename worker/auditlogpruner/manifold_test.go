@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/auditlogpruner"
+	"github.com/juju/juju/worker/pruner"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config auditlogpruner.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = auditlogpruner.ManifoldConfig{
+		ClockName:     "clock",
+		StateName:     "state",
+		PruneInterval: time.Minute,
+		Logger:        loggo.GetLogger("test"),
+		NewWorker:     func(pruner.Config) (worker.Worker, error) { return nil, nil },
+	}
+}
+
+func (s *ManifoldSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := auditlogpruner.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.SameContents, []string{"clock", "state"})
+}
+
+func (s *ManifoldSuite) TestInvalid(c *gc.C) {
+	tests := []struct {
+		mutate func(*auditlogpruner.ManifoldConfig)
+		err    string
+	}{
+		{func(cfg *auditlogpruner.ManifoldConfig) { cfg.ClockName = "" }, "empty ClockName not valid"},
+		{func(cfg *auditlogpruner.ManifoldConfig) { cfg.StateName = "" }, "empty StateName not valid"},
+		{func(cfg *auditlogpruner.ManifoldConfig) { cfg.PruneInterval = 0 }, "non-positive PruneInterval not valid"},
+		{func(cfg *auditlogpruner.ManifoldConfig) { cfg.Logger = nil }, "nil Logger not valid"},
+		{func(cfg *auditlogpruner.ManifoldConfig) { cfg.NewWorker = nil }, "nil NewWorker not valid"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.err)
+		config := s.config
+		test.mutate(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlogpruner provides a worker which keeps the
+// controller's audit log collection within the age and size
+// limits set in the controller config.
+package auditlogpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/worker/pruner"
+)
+
+// DefaultPruneInterval is how often the audit log is pruned.
+const DefaultPruneInterval = 5 * time.Minute
+
+// Worker prunes audit log records at regular intervals.
+type Worker struct {
+	pruner.PrunerWorker
+}
+
+func (w *Worker) loop() error {
+	return w.WorkWithControllerConfig(func(config controller.Config) (time.Duration, uint) {
+		return config.AuditLogMaxAge(), uint(config.AuditLogMaxDBSizeMB())
+	})
+}
+
+// New creates a new audit log pruner worker.
+func New(conf pruner.Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if conf.ControllerFacade == nil {
+		return nil, errors.NotValidf("nil ControllerFacade")
+	}
+
+	w := &Worker{
+		pruner.New(conf),
+	}
+
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: w.Catacomb(),
+		Work: w.loop,
+	})
+
+	return w, errors.Trace(err)
+}
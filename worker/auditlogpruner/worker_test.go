@@ -0,0 +1,165 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/auditlogpruner"
+	"github.com/juju/juju/worker/pruner"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	backend *stubBackend
+	config  pruner.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.backend = &stubBackend{
+		cfg: controller.Config{
+			controller.ControllerUUIDKey: coretesting.ControllerTag.Id(),
+			controller.AuditLogMaxAge:    "24h",
+			controller.AuditLogMaxDBSize: "2G",
+		},
+		changes:    make(chan struct{}, 1),
+		configRead: make(chan struct{}, 1),
+		pruned:     make(chan struct{}, 1),
+	}
+	s.config = pruner.Config{
+		ControllerFacade: s.backend,
+		PruneInterval:    time.Minute,
+		Clock:            s.clock,
+		Logger:           loggo.GetLogger("test"),
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.config.ControllerFacade = nil
+	_, err := auditlogpruner.New(s.config)
+	c.Check(err, gc.ErrorMatches, "missing Facade")
+}
+
+func (s *workerSuite) TestPrunes(c *gc.C) {
+	s.backend.changes <- struct{}{}
+	w, err := auditlogpruner.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 2; i++ {
+		c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+		s.waitPruned(c)
+	}
+	s.backend.CheckCallNames(c, "WatchControllerConfig", "ControllerConfig", "Prune", "Prune")
+	s.backend.CheckCall(c, 2, "Prune", 24*time.Hour, 2048)
+}
+
+func (s *workerSuite) TestControllerConfigChange(c *gc.C) {
+	s.backend.changes <- struct{}{}
+	w, err := auditlogpruner.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitConfigRead(c)
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitPruned(c)
+
+	s.backend.setConfig(controller.AuditLogMaxDBSize, "1G")
+	s.backend.changes <- struct{}{}
+	s.waitConfigRead(c)
+	// The timer is not reset by config changes, so the worker
+	// is still waiting on the timer from the first prune.
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitPruned(c)
+
+	s.backend.CheckCallNames(c,
+		"WatchControllerConfig", "ControllerConfig", "Prune", "ControllerConfig", "Prune",
+	)
+	s.backend.CheckCall(c, 4, "Prune", 24*time.Hour, 1024)
+}
+
+func (s *workerSuite) TestPruneError(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	s.backend.changes <- struct{}{}
+	w, err := auditlogpruner.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *workerSuite) waitConfigRead(c *gc.C) {
+	select {
+	case <-s.backend.configRead:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for controller config to be read")
+	}
+}
+
+func (s *workerSuite) waitPruned(c *gc.C) {
+	select {
+	case <-s.backend.pruned:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for audit log to be pruned")
+	}
+}
+
+type stubBackend struct {
+	testing.Stub
+	cfg        controller.Config
+	changes    chan struct{}
+	configRead chan struct{}
+	pruned     chan struct{}
+}
+
+func (b *stubBackend) setConfig(key string, value interface{}) {
+	cfg := make(controller.Config)
+	for k, v := range b.cfg {
+		cfg[k] = v
+	}
+	cfg[key] = value
+	b.cfg = cfg
+}
+
+func (b *stubBackend) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	b.MethodCall(b, "WatchControllerConfig")
+	return watchertest.NewMockNotifyWatcher(b.changes), b.NextErr()
+}
+
+func (b *stubBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	select {
+	case b.configRead <- struct{}{}:
+	default:
+	}
+	return b.cfg, b.NextErr()
+}
+
+func (b *stubBackend) Prune(stop <-chan struct{}, maxAge time.Duration, maxSizeMB int) error {
+	b.MethodCall(b, "Prune", maxAge, maxSizeMB)
+	err := b.NextErr()
+	if err == nil {
+		b.pruned <- struct{}{}
+	}
+	return err
+}
@@ -0,0 +1,258 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/replicaset"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	"golang.org/x/crypto/openpgp"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker/common"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a backup
+// scheduler worker in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName string
+	ClockName string
+	StateName string
+
+	Logger    Logger
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a
+// backup scheduler worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker, err error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var a agent.Agent
+	if err := context.Get(config.AgentName, &a); err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentConfig := a.CurrentConfig()
+	machineTag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected a machine agent, got %q", agentConfig.Tag())
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			stTracker.Done()
+		}
+	}()
+
+	w, err := config.NewWorker(Config{
+		Backend: stateBackend{
+			st:          statePool.SystemState(),
+			agentConfig: agentConfig,
+			machineID:   machineTag.Id(),
+		},
+		ConfigPollInterval: DefaultConfigPollInterval,
+		Clock:              clock,
+		Logger:             config.Logger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+}
+
+type stateBackend struct {
+	st          *state.State
+	agentConfig agent.Config
+	machineID   string
+}
+
+// ControllerConfig is part of the Backend interface.
+func (b stateBackend) ControllerConfig() (controller.Config, error) {
+	return b.st.ControllerConfig()
+}
+
+// BackupStatus is part of the Backend interface.
+func (b stateBackend) BackupStatus() (state.BackupStatus, error) {
+	return b.st.BackupStatus()
+}
+
+// SetBackupStatus is part of the Backend interface.
+func (b stateBackend) SetBackupStatus(status state.BackupStatus) error {
+	return b.st.SetBackupStatus(status)
+}
+
+// db returns the controller model's database, as used
+// by the backups machinery.
+func (b stateBackend) db() (*backupsDB, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &backupsDB{b.st, model}, nil
+}
+
+// CreateBackup is part of the Backend interface. It creates
+// the backup as the Backups facade does for create-backup,
+// keeping the archive in the controller's backup storage.
+func (b stateBackend) CreateBackup(notes string, encryptTo openpgp.EntityList) (string, error) {
+	db, err := b.db()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return "", errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return "", errors.New("no mongo info found in agent config")
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	m, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(db, b.machineID, m.Series())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Notes = notes
+	meta.Scheduled = true
+	meta.Controller.MachineID = b.machineID
+	instanceID, err := m.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Controller.MachineInstanceID = string(instanceID)
+	nodes, err := b.st.ControllerNodes()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Controller.HANodes = int64(len(nodes))
+
+	modelConfig, err := db.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+
+	stor := backups.NewStorage(db)
+	defer stor.Close()
+	if _, err := backups.NewBackups(stor).Create(meta, &paths, dbInfo, true, true, encryptTo); err != nil {
+		return "", errors.Trace(err)
+	}
+	return meta.ID(), nil
+}
+
+// ListBackups is part of the Backend interface.
+func (b stateBackend) ListBackups() ([]Backup, error) {
+	db, err := b.db()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor := backups.NewStorage(db)
+	defer stor.Close()
+	metaList, err := backups.NewBackups(stor).List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Backup, len(metaList))
+	for i, meta := range metaList {
+		result[i] = Backup{
+			ID:        meta.ID(),
+			Started:   meta.Started,
+			Scheduled: meta.Scheduled,
+		}
+	}
+	return result, nil
+}
+
+// RemoveBackup is part of the Backend interface.
+func (b stateBackend) RemoveBackup(id string) error {
+	db, err := b.db()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stor := backups.NewStorage(db)
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
+
+// backupsDB gives the backups machinery access to the
+// controller model's config as well as its state.
+type backupsDB struct {
+	*state.State
+	*state.Model
+}
+
+// ModelTag disambiguates the ModelTag method of the
+// embedded state and model.
+func (db *backupsDB) ModelTag() names.ModelTag {
+	return db.Model.ModelTag()
+}
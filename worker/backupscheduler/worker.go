@@ -0,0 +1,267 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker which creates controller
+// backups on the schedule set in the controller config, and removes
+// old scheduled backups according to its retention policy.
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+	"golang.org/x/crypto/openpgp"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// ScheduledNotes is recorded as the notes of the backups
+// created by the worker.
+const ScheduledNotes = "scheduled backup"
+
+// DefaultConfigPollInterval is how often the worker reads the
+// controller config to pick up changes to the backup schedule.
+const DefaultConfigPollInterval = 5 * time.Minute
+
+// Logger defines the methods used by the worker for logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Backup describes a stored backup. Only scheduled
+// backups are ever removed by the worker.
+type Backup struct {
+	ID        string
+	Started   time.Time
+	Scheduled bool
+}
+
+// Backend provides the controller config, the status of scheduled
+// backups, and the means to create and remove backups.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	BackupStatus() (state.BackupStatus, error)
+	SetBackupStatus(state.BackupStatus) error
+
+	// CreateBackup creates and stores a backup of the controller
+	// with the given notes, marked as scheduled, returning its ID.
+	// The archive is encrypted to the keys, if there are any.
+	CreateBackup(notes string, encryptTo openpgp.EntityList) (string, error)
+	ListBackups() ([]Backup, error)
+	RemoveBackup(id string) error
+}
+
+// Config holds all necessary attributes to start a backup
+// scheduler worker.
+type Config struct {
+	Backend            Backend
+	ConfigPollInterval time.Duration
+	Clock              clock.Clock
+	Logger             Logger
+}
+
+// Validate returns an error if the config cannot be used
+// to start a backup scheduler worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.ConfigPollInterval <= 0 {
+		return errors.NotValidf("non-positive ConfigPollInterval")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// New returns a worker which creates controller backups on
+// the schedule set in the controller config.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduler{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type scheduler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *scheduler) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *scheduler) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *scheduler) loop() error {
+	// If no scheduled backup has ever been attempted, the first
+	// one is due at the first scheduled time after the worker
+	// starts, rather than immediately.
+	started := w.config.Clock.Now().UTC()
+	for {
+		delay, err := w.backupIfDue(started)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+		}
+	}
+}
+
+// backupIfDue creates a backup if one is due according to the
+// schedule in the controller config, and returns how long to wait
+// before checking again.
+func (w *scheduler) backupIfDue(started time.Time) (time.Duration, error) {
+	poll := w.config.ConfigPollInterval
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return 0, errors.Annotate(err, "getting controller config")
+	}
+	spec := cfg.BackupSchedule()
+	if spec == "" {
+		return poll, nil
+	}
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing backup schedule")
+	}
+	status, err := w.config.Backend.BackupStatus()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	from := started
+	if !status.LastAttempt.IsZero() {
+		from = status.LastAttempt.UTC()
+	}
+	now := w.config.Clock.Now().UTC()
+	next := schedule.Next(from)
+	if next.IsZero() {
+		return poll, nil
+	}
+	if next.After(now) {
+		if delay := next.Sub(now); delay < poll {
+			return delay, nil
+		}
+		return poll, nil
+	}
+
+	w.config.Logger.Infof("creating scheduled backup")
+	status.LastAttempt = now
+	id, err := w.createBackup(cfg)
+	if err != nil {
+		w.config.Logger.Errorf("scheduled backup failed: %v", err)
+		status.LastError = err.Error()
+	} else {
+		w.config.Logger.Infof("created scheduled backup %q", id)
+		status.LastSuccess = now
+		status.LastBackupID = id
+		status.LastError = ""
+	}
+	if err := w.config.Backend.SetBackupStatus(status); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if status.LastError == "" {
+		if err := w.prune(cfg); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	return 0, nil
+}
+
+// createBackup creates a scheduled backup, encrypted to the
+// keys in the controller config if there are any.
+func (w *scheduler) createBackup(cfg controller.Config) (string, error) {
+	var encryptTo openpgp.EntityList
+	if armored := cfg.BackupEncryptionKeys(); armored != "" {
+		keys, err := backups.ParseEncryptionKeys(armored)
+		if err != nil {
+			return "", errors.Annotatef(err, "parsing %s", controller.BackupEncryptionKeys)
+		}
+		encryptTo = keys
+	}
+	return w.config.Backend.CreateBackup(ScheduledNotes, encryptTo)
+}
+
+// prune removes the scheduled backups which the retention
+// policy in the controller config no longer keeps.
+func (w *scheduler) prune(cfg controller.Config) error {
+	stored, err := w.config.Backend.ListBackups()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+	for _, id := range backupsToPrune(stored, cfg.BackupRetentionDaily(), cfg.BackupRetentionWeekly()) {
+		w.config.Logger.Debugf("removing scheduled backup %q", id)
+		if err := w.config.Backend.RemoveBackup(id); err != nil {
+			return errors.Annotatef(err, "removing backup %q", id)
+		}
+	}
+	return nil
+}
+
+// backupsToPrune returns the IDs of the scheduled backups which
+// aren't kept by a retention policy of the newest backup of each
+// of the most recent daily days, and the newest backup of each of
+// the most recent weekly weeks. Other backups are always kept.
+func backupsToPrune(stored []Backup, daily, weekly int) []string {
+	var scheduled []Backup
+	for _, b := range stored {
+		if b.Scheduled {
+			scheduled = append(scheduled, b)
+		}
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+
+	type week struct{ year, week int }
+	days := make(map[string]bool)
+	weeks := make(map[week]bool)
+	var remove []string
+	for _, b := range scheduled {
+		started := b.Started.UTC()
+		keep := false
+		if day := started.Format("2006-01-02"); !days[day] && len(days) < daily {
+			days[day] = true
+			keep = true
+		}
+		year, n := started.ISOWeek()
+		if w := (week{year, n}); !weeks[w] && len(weeks) < weekly {
+			weeks[w] = true
+			keep = true
+		}
+		if !keep {
+			remove = append(remove, b.ID)
+		}
+	}
+	return remove
+}
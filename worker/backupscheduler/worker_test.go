@@ -0,0 +1,322 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	now     time.Time
+	clock   *testclock.Clock
+	backend *stubBackend
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.now = time.Date(2021, 5, 24, 2, 59, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(s.now)
+	s.backend = &stubBackend{
+		cfg: controller.Config{
+			controller.BackupSchedule:        "0 3 * * *",
+			controller.BackupRetentionDaily:  1,
+			controller.BackupRetentionWeekly: 0,
+		},
+		statusSet: make(chan state.BackupStatus, 1),
+	}
+	s.config = backupscheduler.Config{
+		Backend:            s.backend,
+		ConfigPollInterval: 5 * time.Minute,
+		Clock:              s.clock,
+		Logger:             loggo.GetLogger("test"),
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*backupscheduler.Config)
+		err    string
+	}{
+		{func(cfg *backupscheduler.Config) { cfg.Backend = nil }, "nil Backend not valid"},
+		{func(cfg *backupscheduler.Config) { cfg.ConfigPollInterval = 0 }, "non-positive ConfigPollInterval not valid"},
+		{func(cfg *backupscheduler.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *backupscheduler.Config) { cfg.Logger = nil }, "nil Logger not valid"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.err)
+		config := s.config
+		test.mutate(&config)
+		_, err := backupscheduler.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) TestNoSchedule(c *gc.C) {
+	delete(s.backend.cfg, controller.BackupSchedule)
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 2; i++ {
+		c.Assert(s.clock.WaitAdvance(5*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	}
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerConfig", "ControllerConfig", "ControllerConfig")
+}
+
+func (s *workerSuite) TestBackupWhenDue(c *gc.C) {
+	s.backend.backups = []backupscheduler.Backup{{
+		ID:      "manual",
+		Started: s.now.Add(-48 * time.Hour),
+	}, {
+		ID:        "yesterday",
+		Started:   s.now.Add(-24 * time.Hour),
+		Scheduled: true,
+	}, {
+		ID:        "today",
+		Started:   s.now.Add(time.Minute),
+		Scheduled: true,
+	}}
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The first backup is due at 03:00.
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	status := s.waitStatusSet(c)
+	due := s.now.Add(time.Minute)
+	c.Check(status, jc.DeepEquals, state.BackupStatus{
+		LastAttempt:  due,
+		LastSuccess:  due,
+		LastBackupID: "today",
+	})
+
+	// Wait for the worker to go back to sleep before
+	// checking the backups were pruned.
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c,
+		"ControllerConfig", "BackupStatus",
+		"ControllerConfig", "BackupStatus", "CreateBackup", "SetBackupStatus",
+		"ListBackups", "RemoveBackup",
+		"ControllerConfig", "BackupStatus",
+	)
+	s.backend.CheckCall(c, 4, "CreateBackup", backupscheduler.ScheduledNotes, openpgp.EntityList(nil))
+	s.backend.CheckCall(c, 7, "RemoveBackup", "yesterday")
+}
+
+func (s *workerSuite) TestBackupNotYetDue(c *gc.C) {
+	s.backend.cfg[controller.BackupSchedule] = "30 3 * * *"
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The backup isn't due until 03:30, so
+	// the worker polls the config until then.
+	c.Assert(s.clock.WaitAdvance(5*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerConfig", "BackupStatus", "ControllerConfig", "BackupStatus")
+}
+
+func (s *workerSuite) TestBackupError(c *gc.C) {
+	previous := s.now.Add(time.Minute).AddDate(0, 0, -1)
+	s.backend.status = state.BackupStatus{
+		LastAttempt:  previous,
+		LastSuccess:  previous,
+		LastBackupID: "yesterday",
+	}
+	s.backend.SetErrors(nil, nil, nil, nil, errors.New("disk full"))
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	status := s.waitStatusSet(c)
+	c.Check(status, jc.DeepEquals, state.BackupStatus{
+		LastAttempt:  s.now.Add(time.Minute),
+		LastSuccess:  previous,
+		LastBackupID: "yesterday",
+		LastError:    "disk full",
+	})
+
+	// A failed backup isn't retried until its next scheduled time,
+	// and no backups are pruned.
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c,
+		"ControllerConfig", "BackupStatus",
+		"ControllerConfig", "BackupStatus", "CreateBackup", "SetBackupStatus",
+		"ControllerConfig", "BackupStatus",
+	)
+}
+
+func (s *workerSuite) TestBackupEncrypted(c *gc.C) {
+	key, armored := newEncryptionKey(c)
+	s.backend.cfg[controller.BackupEncryptionKeys] = armored
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	status := s.waitStatusSet(c)
+	c.Check(status.LastError, gc.Equals, "")
+
+	// The archive is encrypted to the key in the controller config.
+	calls := s.backend.Calls()
+	c.Assert(calls[4].FuncName, gc.Equals, "CreateBackup")
+	encryptTo, ok := calls[4].Args[1].(openpgp.EntityList)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(backups.KeyFingerprints(encryptTo), jc.DeepEquals, []string{
+		fmt.Sprintf("%X", key.PrimaryKey.Fingerprint),
+	})
+}
+
+func (s *workerSuite) waitStatusSet(c *gc.C) state.BackupStatus {
+	select {
+	case status := <-s.backend.statusSet:
+		return status
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup status to be set")
+	}
+	panic("unreachable")
+}
+
+type pruneSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&pruneSuite{})
+
+func (s *pruneSuite) TestBackupsToPrune(c *gc.C) {
+	// Monday 24th May 2021.
+	monday := time.Date(2021, 5, 24, 3, 0, 0, 0, time.UTC)
+	scheduled := func(id string, started time.Time) backupscheduler.Backup {
+		return backupscheduler.Backup{
+			ID:        id,
+			Started:   started,
+			Scheduled: true,
+		}
+	}
+	stored := []backupscheduler.Backup{
+		scheduled("mon-late", monday.Add(12*time.Hour)),
+		scheduled("mon", monday),
+		scheduled("sun", monday.AddDate(0, 0, -1)),
+		scheduled("sat", monday.AddDate(0, 0, -2)),
+		scheduled("prev-sun", monday.AddDate(0, 0, -8)),
+		scheduled("prev-mon", monday.AddDate(0, 0, -7)),
+		scheduled("old", monday.AddDate(0, 0, -15)),
+		{ID: "manual", Started: monday.AddDate(0, 0, -30)},
+	}
+	for i, test := range []struct {
+		daily, weekly int
+		remove        []string
+	}{{
+		daily:  0,
+		weekly: 0,
+		remove: []string{"mon-late", "mon", "sun", "sat", "prev-mon", "prev-sun", "old"},
+	}, {
+		daily:  2,
+		weekly: 0,
+		remove: []string{"mon", "sat", "prev-mon", "prev-sun", "old"},
+	}, {
+		daily:  1,
+		weekly: 2,
+		remove: []string{"mon", "sat", "prev-mon", "prev-sun", "old"},
+	}, {
+		daily:  0,
+		weekly: 3,
+		remove: []string{"mon", "sat", "prev-mon", "old"},
+	}, {
+		daily:  10,
+		weekly: 10,
+		remove: []string{"mon"},
+	}} {
+		c.Logf("test %d: daily %d, weekly %d", i, test.daily, test.weekly)
+		c.Check(backupscheduler.BackupsToPrune(stored, test.daily, test.weekly), jc.SameContents, test.remove)
+	}
+}
+
+// newEncryptionKey returns a new OpenPGP key,
+// and its armored public key.
+func newEncryptionKey(c *gc.C) (*openpgp.Entity, string) {
+	config := &packet.Config{DefaultHash: crypto.SHA256}
+	key, err := openpgp.NewEntity("backups", "", "backups@example.com", config)
+	c.Assert(err, jc.ErrorIsNil)
+	// Signing the identity again records the preferred hash.
+	c.Assert(key.SerializePrivate(&bytes.Buffer{}, config), jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return key, buf.String()
+}
+
+type stubBackend struct {
+	testing.Stub
+
+	cfg       controller.Config
+	status    state.BackupStatus
+	backups   []backupscheduler.Backup
+	statusSet chan state.BackupStatus
+}
+
+func (b *stubBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	return b.cfg, b.NextErr()
+}
+
+func (b *stubBackend) BackupStatus() (state.BackupStatus, error) {
+	b.MethodCall(b, "BackupStatus")
+	return b.status, b.NextErr()
+}
+
+func (b *stubBackend) SetBackupStatus(status state.BackupStatus) error {
+	b.MethodCall(b, "SetBackupStatus", status)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	b.status = status
+	b.statusSet <- status
+	return nil
+}
+
+func (b *stubBackend) CreateBackup(notes string, encryptTo openpgp.EntityList) (string, error) {
+	b.MethodCall(b, "CreateBackup", notes, encryptTo)
+	return "today", b.NextErr()
+}
+
+func (b *stubBackend) ListBackups() ([]backupscheduler.Backup, error) {
+	b.MethodCall(b, "ListBackups")
+	return b.backups, b.NextErr()
+}
+
+func (b *stubBackend) RemoveBackup(id string) error {
+	b.MethodCall(b, "RemoveBackup", id)
+	return b.NextErr()
+}
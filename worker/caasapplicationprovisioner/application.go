@@ -44,6 +44,16 @@ type appWorker struct {
 	changes     chan struct{}
 	password    string
 	lastApplied caas.ApplicationConfig
+
+	// autoscalerApplied records whether lastAutoscaling has been
+	// applied to the application since the worker started.
+	autoscalerApplied bool
+	lastAutoscaling   *caas.AutoscalingPolicy
+
+	// networkPolicyApplied records whether lastNetworkPolicy has been
+	// applied to the application since the worker started.
+	networkPolicyApplied bool
+	lastNetworkPolicy    *caas.NetworkPolicy
 }
 
 type AppWorkerConfig struct {
@@ -125,6 +135,8 @@ func (a *appWorker) loop() error {
 	var appChanges watcher.NotifyChannel
 	var replicaChanges watcher.NotifyChannel
 	var appStateChanges watcher.NotifyChannel
+	var appConfigChanges watcher.NotifyChannel
+	var networkPolicyChanges watcher.NotifyChannel
 	var lastReportedStatus map[string]status.StatusInfo
 
 	done := false
@@ -148,6 +160,36 @@ func (a *appWorker) loop() error {
 				}
 				appStateChanges = appStateWatcher.Changes()
 			}
+			if appConfigChanges == nil {
+				appConfigWatcher, err := a.facade.WatchApplicationConfig(a.name)
+				if errors.IsNotSupported(err) {
+					// The controller does not support autoscaling; leave
+					// appConfigChanges nil so it is never selected.
+					a.logger.Debugf("not watching config of application %q: %v", a.name, err)
+				} else if err != nil {
+					return errors.Annotatef(err, "failed to watch for config changes to application %q", a.name)
+				} else {
+					if err := a.catacomb.Add(appConfigWatcher); err != nil {
+						return errors.Trace(err)
+					}
+					appConfigChanges = appConfigWatcher.Changes()
+				}
+			}
+			if networkPolicyChanges == nil {
+				networkPolicyWatcher, err := a.facade.WatchNetworkPolicy(a.name)
+				if errors.IsNotSupported(err) {
+					// The controller does not support network policies; leave
+					// networkPolicyChanges nil so it is never selected.
+					a.logger.Debugf("not watching network policy of application %q: %v", a.name, err)
+				} else if err != nil {
+					return errors.Annotatef(err, "failed to watch for network policy changes to application %q", a.name)
+				} else {
+					if err := a.catacomb.Add(networkPolicyWatcher); err != nil {
+						return errors.Trace(err)
+					}
+					networkPolicyChanges = networkPolicyWatcher.Changes()
+				}
+			}
 			err = a.alive(app)
 			if err != nil {
 				return errors.Trace(err)
@@ -198,6 +240,18 @@ func (a *appWorker) loop() error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+		case <-appConfigChanges:
+			// Respond to changes to the autoscaling policy.
+			err = handleChange()
+			if err != nil {
+				return errors.Trace(err)
+			}
+		case <-networkPolicyChanges:
+			// Respond to changes to relations and model config.
+			err = handleChange()
+			if err != nil {
+				return errors.Trace(err)
+			}
 		case <-a.changes:
 			// Respond to life changes.
 			err = handleChange()
@@ -258,6 +312,24 @@ func (a *appWorker) updateState(app caas.Application, force bool, lastReportedSt
 		ApplicationTag: names.NewApplicationTag(a.name).String(),
 		Status:         params.EntityStatus{},
 	}
+	// While the application is autoscaled, the autoscaler decides the
+	// scale and we report it back to the controller.
+	autoscaler, err := app.AutoscalerStatus()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	} else if err == nil {
+		scale := autoscaler.DesiredReplicas
+		generation := autoscaler.Generation
+		args.Scale = &scale
+		args.Generation = &generation
+		args.Autoscaler = &params.AutoscalerStatus{
+			MinReplicas:     autoscaler.MinReplicas,
+			MaxReplicas:     autoscaler.MaxReplicas,
+			CurrentReplicas: autoscaler.CurrentReplicas,
+			DesiredReplicas: autoscaler.DesiredReplicas,
+			Message:         autoscaler.Message,
+		}
+	}
 	for _, u := range units {
 		// For pods managed by the substrate, any marked as dying
 		// are treated as non-existing.
@@ -452,6 +524,13 @@ func (a *appWorker) alive(app caas.Application) error {
 		}
 	}
 
+	if err := a.ensureAutoscaler(app, provisionInfo.Autoscaling, provisionInfo.Scale); err != nil {
+		return errors.Trace(err)
+	}
+	if err := a.ensureNetworkPolicy(app, provisionInfo.NetworkPolicy); err != nil {
+		return errors.Trace(err)
+	}
+
 	err = a.facade.SetOperatorStatus(a.name, status.Active, reason, nil)
 	if err != nil {
 		return errors.Trace(err)
@@ -460,6 +539,43 @@ func (a *appWorker) alive(app caas.Application) error {
 	return nil
 }
 
+// ensureAutoscaler applies the autoscaling policy to the application if it
+// has changed. When autoscaling is disabled, the application is returned
+// to the scale recorded in the controller.
+func (a *appWorker) ensureAutoscaler(app caas.Application, policy *caas.AutoscalingPolicy, scale int) error {
+	if a.autoscalerApplied && reflect.DeepEqual(policy, a.lastAutoscaling) {
+		return nil
+	}
+	if err := app.EnsureAutoscaler(policy); err != nil {
+		return errors.Annotate(err, "ensuring autoscaler")
+	}
+	if policy == nil && a.lastAutoscaling != nil {
+		if err := app.Scale(scale); err != nil {
+			return errors.Annotatef(err, "scaling application to %d", scale)
+		}
+	}
+	a.autoscalerApplied = true
+	a.lastAutoscaling = policy
+	return nil
+}
+
+// ensureNetworkPolicy applies the network policy to the application if it
+// has changed.
+func (a *appWorker) ensureNetworkPolicy(app caas.Application, policy *caas.NetworkPolicy) error {
+	if a.networkPolicyApplied && reflect.DeepEqual(policy, a.lastNetworkPolicy) {
+		return nil
+	}
+	err := app.EnsureNetworkPolicy(policy)
+	if errors.IsNotSupported(err) {
+		a.logger.Warningf("not restricting ingress to application %q: %v", a.name, err)
+	} else if err != nil {
+		return errors.Annotate(err, "ensuring network policy")
+	}
+	a.networkPolicyApplied = true
+	a.lastNetworkPolicy = policy
+	return nil
+}
+
 func (a *appWorker) dying(app caas.Application) error {
 	a.logger.Debugf("application %q dying", a.name)
 	err := app.Delete()
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/charm/v9"
 	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	"github.com/juju/systems"
@@ -88,6 +89,14 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 		Series:   "focal",
 		CharmURL: appCharmURL,
 	}
+	autoscaling := &caas.AutoscalingPolicy{
+		MinReplicas:          1,
+		MaxReplicas:          3,
+		TargetCPUUtilization: 70,
+	}
+	networkPolicy := &caas.NetworkPolicy{
+		Applications: []string{"postgresql"},
+	}
 	ociResources := map[string]resources.DockerImageDetails{
 		"test-oci": {
 			RegistryPath: "some/test:img",
@@ -99,6 +108,12 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 	appStateChan := make(chan struct{}, 1)
 	appStateWatcher := watchertest.NewMockNotifyWatcher(appStateChan)
 
+	appConfigChan := make(chan struct{}, 1)
+	appConfigWatcher := watchertest.NewMockNotifyWatcher(appConfigChan)
+
+	networkPolicyChan := make(chan struct{}, 1)
+	networkPolicyWatcher := watchertest.NewMockNotifyWatcher(networkPolicyChan)
+
 	appChan := make(chan struct{}, 1)
 	appWatcher := watchertest.NewMockNotifyWatcher(appChan)
 
@@ -130,6 +145,8 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 			return life.Alive, nil
 		}),
 		facade.EXPECT().WatchApplication("test").Return(appStateWatcher, nil),
+		facade.EXPECT().WatchApplicationConfig("test").Return(appConfigWatcher, nil),
+		facade.EXPECT().WatchNetworkPolicy("test").Return(networkPolicyWatcher, nil),
 		facade.EXPECT().ProvisioningInfo("test").DoAndReturn(func(string) (api.ProvisioningInfo, error) {
 			return appProvisioningInfo, nil
 		}),
@@ -161,6 +178,8 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 			})
 			return nil
 		}),
+		brokerApp.EXPECT().EnsureAutoscaler(nil).Return(nil),
+		brokerApp.EXPECT().EnsureNetworkPolicy(nil).Return(nil),
 		facade.EXPECT().SetOperatorStatus("test", status.Active, "deployed", nil).Return(nil),
 		brokerApp.EXPECT().Watch().Return(appWatcher, nil),
 		brokerApp.EXPECT().WatchReplicas().DoAndReturn(func() (watcher.NotifyWatcher, error) {
@@ -211,6 +230,7 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 				},
 			}},
 		}}, nil),
+		brokerApp.EXPECT().AutoscalerStatus().Return(caas.AutoscalerStatus{}, errors.NotFoundf("autoscaler")),
 		facade.EXPECT().UpdateUnits(params.UpdateApplicationUnits{
 			ApplicationTag: "application-test",
 			Status:         params.EntityStatus{},
@@ -240,12 +260,16 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 			return nil, nil
 		}),
 
-		// Second run - Ensure() for the application.
+		// Second run - Ensure() for the application, now autoscaled and
+		// related to postgresql.
 		facade.EXPECT().Life("test").DoAndReturn(func(string) (life.Value, error) {
 			return life.Alive, nil
 		}),
 		facade.EXPECT().ProvisioningInfo("test").DoAndReturn(func(string) (api.ProvisioningInfo, error) {
-			return appProvisioningInfo, nil
+			info := appProvisioningInfo
+			info.Autoscaling = autoscaling
+			info.NetworkPolicy = networkPolicy
+			return info, nil
 		}),
 		facade.EXPECT().CharmInfo("cs:test").DoAndReturn(func(string) (*charmscommon.CharmInfo, error) {
 			return appCharmInfo, nil
@@ -258,6 +282,8 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 			return ociResources, nil
 		}),
 		// Second run should not Ensure since unchanged.
+		brokerApp.EXPECT().EnsureAutoscaler(autoscaling).Return(nil),
+		brokerApp.EXPECT().EnsureNetworkPolicy(networkPolicy).Return(nil),
 		facade.EXPECT().SetOperatorStatus("test", status.Active, "unchanged", nil).Return(nil),
 
 		// Got appChanges -> updateState().
@@ -284,9 +310,24 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 				Status: status.Terminated,
 			},
 		}}, nil),
+		brokerApp.EXPECT().AutoscalerStatus().Return(caas.AutoscalerStatus{
+			MinReplicas:     1,
+			MaxReplicas:     3,
+			CurrentReplicas: 1,
+			DesiredReplicas: 2,
+			Generation:      3,
+		}, nil),
 		facade.EXPECT().UpdateUnits(params.UpdateApplicationUnits{
 			ApplicationTag: "application-test",
+			Scale:          intPtr(2),
+			Generation:     int64Ptr(3),
 			Status:         params.EntityStatus{},
+			Autoscaler: &params.AutoscalerStatus{
+				MinReplicas:     1,
+				MaxReplicas:     3,
+				CurrentReplicas: 1,
+				DesiredReplicas: 2,
+			},
 		}).Return(nil, nil),
 
 		// 1st Notify() - dying.
@@ -366,3 +407,11 @@ type appNotifyWorker interface {
 	worker.Worker
 	Notify()
 }
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
@@ -203,6 +203,21 @@ func (mr *MockCAASProvisionerFacadeMockRecorder) WatchApplication(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplication", reflect.TypeOf((*MockCAASProvisionerFacade)(nil).WatchApplication), arg0)
 }
 
+// WatchApplicationConfig mocks base method
+func (m *MockCAASProvisionerFacade) WatchApplicationConfig(arg0 string) (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchApplicationConfig", arg0)
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchApplicationConfig indicates an expected call of WatchApplicationConfig
+func (mr *MockCAASProvisionerFacadeMockRecorder) WatchApplicationConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplicationConfig", reflect.TypeOf((*MockCAASProvisionerFacade)(nil).WatchApplicationConfig), arg0)
+}
+
 // WatchApplications mocks base method
 func (m *MockCAASProvisionerFacade) WatchApplications() (watcher.StringsWatcher, error) {
 	m.ctrl.T.Helper()
@@ -217,3 +232,18 @@ func (mr *MockCAASProvisionerFacadeMockRecorder) WatchApplications() *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplications", reflect.TypeOf((*MockCAASProvisionerFacade)(nil).WatchApplications))
 }
+
+// WatchNetworkPolicy mocks base method
+func (m *MockCAASProvisionerFacade) WatchNetworkPolicy(arg0 string) (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchNetworkPolicy", arg0)
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchNetworkPolicy indicates an expected call of WatchNetworkPolicy
+func (mr *MockCAASProvisionerFacadeMockRecorder) WatchNetworkPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchNetworkPolicy", reflect.TypeOf((*MockCAASProvisionerFacade)(nil).WatchNetworkPolicy), arg0)
+}
@@ -24,8 +24,9 @@ type applicationWorker struct {
 
 	lifeGetter LifeGetter
 
-	initial           bool
-	previouslyExposed bool
+	initial                    bool
+	previouslyExposed          bool
+	previouslyExposedEndpoints []string
 
 	logger Logger
 }
@@ -122,12 +123,25 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !w.initial && exposed == w.previouslyExposed {
+	var exposedEndpoints []string
+	if exposed {
+		exposedEndpoints, err = w.applicationGetter.ExposedEndpoints(w.application)
+		if errors.IsNotSupported(err) {
+			// Older controllers can only expose all endpoints.
+			exposedEndpoints, err = nil, nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !w.initial && exposed == w.previouslyExposed &&
+		strings.Join(exposedEndpoints, ",") == strings.Join(w.previouslyExposedEndpoints, ",") {
 		return nil
 	}
 
 	w.initial = false
 	w.previouslyExposed = exposed
+	w.previouslyExposedEndpoints = exposedEndpoints
 	if exposed {
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
@@ -137,7 +151,7 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 			names.NewModelTag(w.modelUUID),
 			names.NewControllerTag(w.controllerUUID),
 		)
-		if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
+		if err := w.serviceExposer.ExposeService(w.application, resourceTags, exposedEndpoints, appConfig); err != nil {
 			return errors.Trace(err)
 		}
 		return nil
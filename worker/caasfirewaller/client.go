@@ -25,6 +25,7 @@ type ApplicationGetter interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	WatchApplication(string) (watcher.NotifyWatcher, error)
 	IsExposed(string) (bool, error)
+	ExposedEndpoints(string) ([]string, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
 }
 
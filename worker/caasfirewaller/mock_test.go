@@ -33,8 +33,8 @@ type mockServiceExposer struct {
 	unexposed chan<- struct{}
 }
 
-func (m *mockServiceExposer) ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error {
-	m.MethodCall(m, "ExposeService", appName, resourceTags, config)
+func (m *mockServiceExposer) ExposeService(appName string, resourceTags map[string]string, exposedEndpoints []string, config application.ConfigAttributes) error {
+	m.MethodCall(m, "ExposeService", appName, resourceTags, exposedEndpoints, config)
 	m.exposed <- struct{}{}
 	return m.NextErr()
 }
@@ -50,6 +50,7 @@ type mockApplicationGetter struct {
 	allWatcher *watchertest.MockStringsWatcher
 	appWatcher *watchertest.MockNotifyWatcher
 	exposed    bool
+	endpoints  []string
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -76,6 +77,14 @@ func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
 	return m.exposed, nil
 }
 
+func (m *mockApplicationGetter) ExposedEndpoints(appName string) ([]string, error) {
+	m.MethodCall(m, "ExposedEndpoints", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.endpoints, nil
+}
+
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
 	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
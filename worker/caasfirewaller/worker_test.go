@@ -156,9 +156,55 @@ func (s *WorkerSuite) TestExposedChange(c *gc.C) {
 		map[string]string{
 			"juju-controller-uuid": coretesting.ControllerTag.Id(),
 			"juju-model-uuid":      coretesting.ModelTag.Id()},
+		[]string(nil),
 		application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestExposedEndpointsChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.applicationGetter.endpoints = []string{"website"}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	// Nothing changed, so the service isn't exposed again.
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.applicationGetter.endpoints = []string{"api", "website"}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	s.serviceExposer.CheckCallNames(c, "ExposeService", "ExposeService")
+	tags := map[string]string{
+		"juju-controller-uuid": coretesting.ControllerTag.Id(),
+		"juju-model-uuid":      coretesting.ModelTag.Id(),
+	}
+	config := application.ConfigAttributes{"juju-external-hostname": "exthost"}
+	s.serviceExposer.CheckCall(c, 0, "ExposeService", "gitlab", tags, []string{"website"}, config)
+	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab", tags, []string{"api", "website"}, config)
+}
+
 func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
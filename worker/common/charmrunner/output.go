@@ -0,0 +1,143 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+const (
+	// OutputForwardInterval is how long output is gathered
+	// before it is forwarded.
+	OutputForwardInterval = time.Second
+
+	// MaxForwardedOutput is the most output forwarded from
+	// a single stream; anything more is dropped.
+	MaxForwardedOutput = 1024 * 1024
+
+	outputTruncatedMessage = "[output truncated]\n"
+)
+
+// OutputForwarder is a MessageReceiver which forwards the output of
+// a running hook or action as it is written. Output is gathered for
+// up to OutputForwardInterval so it isn't forwarded line by line.
+type OutputForwarder struct {
+	forward func(string) error
+	clock   clock.Clock
+
+	mu        sync.Mutex
+	pending   bytes.Buffer
+	gathered  int
+	truncated bool
+
+	ready    chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOutputForwarder returns an OutputForwarder which calls forward
+// with the output it receives. If forward returns an error, no more
+// output is forwarded.
+func NewOutputForwarder(forward func(output string) error, clock clock.Clock) *OutputForwarder {
+	f := &OutputForwarder{
+		forward: forward,
+		clock:   clock,
+		ready:   make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go f.loop()
+	return f
+}
+
+// Messagef implements MessageReceiver.
+func (f *OutputForwarder) Messagef(isPrefix bool, message string, args ...interface{}) {
+	formattedMessage := message
+	if len(args) > 0 {
+		formattedMessage = fmt.Sprintf(message, args...)
+	}
+	if !isPrefix {
+		formattedMessage += "\n"
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.truncated {
+		return
+	}
+	if f.gathered+len(formattedMessage) > MaxForwardedOutput {
+		f.truncated = true
+		formattedMessage = outputTruncatedMessage
+	}
+	f.gathered += len(formattedMessage)
+	f.pending.WriteString(formattedMessage)
+	select {
+	case f.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Stop forwards any output not yet forwarded, and stops the forwarder.
+func (f *OutputForwarder) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stop)
+	})
+	<-f.done
+}
+
+func (f *OutputForwarder) loop() {
+	defer close(f.done)
+	for {
+		select {
+		case <-f.stop:
+			f.forwardPending()
+			return
+		case <-f.ready:
+		}
+		select {
+		case <-f.stop:
+			f.forwardPending()
+			return
+		case <-f.clock.After(OutputForwardInterval):
+		}
+		if !f.forwardPending() {
+			f.discard()
+			return
+		}
+	}
+}
+
+// forwardPending forwards the output gathered since it last ran,
+// and reports whether it succeeded.
+func (f *OutputForwarder) forwardPending() bool {
+	f.mu.Lock()
+	output := f.pending.String()
+	f.pending.Reset()
+	f.mu.Unlock()
+	if output == "" {
+		return true
+	}
+	err := f.forward(output)
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("not forwarding output: %v", err)
+	} else if err != nil {
+		logger.Warningf("cannot forward output: %v", err)
+	}
+	return err == nil
+}
+
+// discard drops any output received after forwarding fails.
+func (f *OutputForwarder) discard() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.truncated = true
+	f.pending.Reset()
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/common/charmrunner"
+)
+
+type OutputForwarderSuite struct {
+	coretesting.BaseSuite
+
+	clock     *testclock.Clock
+	forwarded chan string
+	err       error
+}
+
+var _ = gc.Suite(&OutputForwarderSuite{})
+
+func (s *OutputForwarderSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.forwarded = make(chan string, 10)
+	s.err = nil
+}
+
+func (s *OutputForwarderSuite) forward(output string) error {
+	s.forwarded <- output
+	return s.err
+}
+
+func (s *OutputForwarderSuite) assertForwarded(c *gc.C, expect string) {
+	select {
+	case output := <-s.forwarded:
+		c.Assert(output, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("output not forwarded")
+	}
+}
+
+func (s *OutputForwarderSuite) assertNotForwarded(c *gc.C) {
+	select {
+	case output := <-s.forwarded:
+		c.Fatalf("unexpected output forwarded: %q", output)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *OutputForwarderSuite) TestForwardsGatheredOutput(c *gc.C) {
+	f := charmrunner.NewOutputForwarder(s.forward, s.clock)
+	defer f.Stop()
+
+	f.Messagef(false, "%s", "one")
+	f.Messagef(true, "%s", "tw")
+	f.Messagef(false, "%s", "o")
+	err := s.clock.WaitAdvance(charmrunner.OutputForwardInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertForwarded(c, "one\ntwo\n")
+
+	f.Messagef(false, "%s", "three")
+	err = s.clock.WaitAdvance(charmrunner.OutputForwardInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertForwarded(c, "three\n")
+}
+
+func (s *OutputForwarderSuite) TestStopForwardsPendingOutput(c *gc.C) {
+	f := charmrunner.NewOutputForwarder(s.forward, s.clock)
+	f.Messagef(false, "%s", "one")
+	f.Stop()
+	s.assertForwarded(c, "one\n")
+	s.assertNotForwarded(c)
+
+	// Stop is idempotent.
+	f.Stop()
+}
+
+func (s *OutputForwarderSuite) TestTruncatesOutput(c *gc.C) {
+	f := charmrunner.NewOutputForwarder(s.forward, s.clock)
+	line := strings.Repeat("x", 1023)
+	for i := 0; i < 1025; i++ {
+		f.Messagef(false, "%s", line)
+	}
+	f.Stop()
+	expect := strings.Repeat(line+"\n", 1024) + "[output truncated]\n"
+	s.assertForwarded(c, expect)
+}
+
+func (s *OutputForwarderSuite) TestStopsForwardingAfterError(c *gc.C) {
+	s.err = errors.NotImplementedf("forwarding")
+	f := charmrunner.NewOutputForwarder(s.forward, s.clock)
+	f.Messagef(false, "%s", "one")
+	err := s.clock.WaitAdvance(charmrunner.OutputForwardInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertForwarded(c, "one\n")
+
+	f.Messagef(false, "%s", "two")
+	f.Stop()
+	s.assertNotForwarded(c)
+}
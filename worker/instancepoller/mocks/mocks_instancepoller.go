@@ -5,8 +5,6 @@
 package mocks
 
 import (
-	reflect "reflect"
-
 	gomock "github.com/golang/mock/gomock"
 	params "github.com/juju/juju/apiserver/params"
 	instance "github.com/juju/juju/core/instance"
@@ -15,6 +13,7 @@ import (
 	status "github.com/juju/juju/core/status"
 	context "github.com/juju/juju/environs/context"
 	instances "github.com/juju/juju/environs/instances"
+	reflect "reflect"
 )
 
 // MockEnviron is a mock of Environ interface
@@ -42,6 +41,7 @@ func (m *MockEnviron) EXPECT() *MockEnvironMockRecorder {
 
 // Instances mocks base method
 func (m *MockEnviron) Instances(arg0 context.ProviderCallContext, arg1 []instance.Id) ([]instances.Instance, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Instances", arg0, arg1)
 	ret0, _ := ret[0].([]instances.Instance)
 	ret1, _ := ret[1].(error)
@@ -50,11 +50,13 @@ func (m *MockEnviron) Instances(arg0 context.ProviderCallContext, arg1 []instanc
 
 // Instances indicates an expected call of Instances
 func (mr *MockEnvironMockRecorder) Instances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instances", reflect.TypeOf((*MockEnviron)(nil).Instances), arg0, arg1)
 }
 
 // NetworkInterfaces mocks base method
 func (m *MockEnviron) NetworkInterfaces(arg0 context.ProviderCallContext, arg1 []instance.Id) ([]network.InterfaceInfos, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "NetworkInterfaces", arg0, arg1)
 	ret0, _ := ret[0].([]network.InterfaceInfos)
 	ret1, _ := ret[1].(error)
@@ -63,6 +65,7 @@ func (m *MockEnviron) NetworkInterfaces(arg0 context.ProviderCallContext, arg1 [
 
 // NetworkInterfaces indicates an expected call of NetworkInterfaces
 func (mr *MockEnvironMockRecorder) NetworkInterfaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaces", reflect.TypeOf((*MockEnviron)(nil).NetworkInterfaces), arg0, arg1)
 }
 
@@ -91,6 +94,7 @@ func (m *MockMachine) EXPECT() *MockMachineMockRecorder {
 
 // Id mocks base method
 func (m *MockMachine) Id() string {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Id")
 	ret0, _ := ret[0].(string)
 	return ret0
@@ -98,11 +102,13 @@ func (m *MockMachine) Id() string {
 
 // Id indicates an expected call of Id
 func (mr *MockMachineMockRecorder) Id() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Id", reflect.TypeOf((*MockMachine)(nil).Id))
 }
 
 // InstanceId mocks base method
 func (m *MockMachine) InstanceId() (instance.Id, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InstanceId")
 	ret0, _ := ret[0].(instance.Id)
 	ret1, _ := ret[1].(error)
@@ -111,11 +117,13 @@ func (m *MockMachine) InstanceId() (instance.Id, error) {
 
 // InstanceId indicates an expected call of InstanceId
 func (mr *MockMachineMockRecorder) InstanceId() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceId", reflect.TypeOf((*MockMachine)(nil).InstanceId))
 }
 
 // InstanceStatus mocks base method
 func (m *MockMachine) InstanceStatus() (params.StatusResult, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InstanceStatus")
 	ret0, _ := ret[0].(params.StatusResult)
 	ret1, _ := ret[1].(error)
@@ -124,11 +132,13 @@ func (m *MockMachine) InstanceStatus() (params.StatusResult, error) {
 
 // InstanceStatus indicates an expected call of InstanceStatus
 func (mr *MockMachineMockRecorder) InstanceStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceStatus", reflect.TypeOf((*MockMachine)(nil).InstanceStatus))
 }
 
 // IsManual mocks base method
 func (m *MockMachine) IsManual() (bool, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IsManual")
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
@@ -137,11 +147,13 @@ func (m *MockMachine) IsManual() (bool, error) {
 
 // IsManual indicates an expected call of IsManual
 func (mr *MockMachineMockRecorder) IsManual() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManual", reflect.TypeOf((*MockMachine)(nil).IsManual))
 }
 
 // Life mocks base method
 func (m *MockMachine) Life() life.Value {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Life")
 	ret0, _ := ret[0].(life.Value)
 	return ret0
@@ -149,11 +161,13 @@ func (m *MockMachine) Life() life.Value {
 
 // Life indicates an expected call of Life
 func (mr *MockMachineMockRecorder) Life() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Life", reflect.TypeOf((*MockMachine)(nil).Life))
 }
 
 // Refresh mocks base method
 func (m *MockMachine) Refresh() error {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Refresh")
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -161,11 +175,27 @@ func (m *MockMachine) Refresh() error {
 
 // Refresh indicates an expected call of Refresh
 func (mr *MockMachineMockRecorder) Refresh() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockMachine)(nil).Refresh))
 }
 
+// SetAvailabilityZone mocks base method
+func (m *MockMachine) SetAvailabilityZone(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvailabilityZone", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvailabilityZone indicates an expected call of SetAvailabilityZone
+func (mr *MockMachineMockRecorder) SetAvailabilityZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailabilityZone", reflect.TypeOf((*MockMachine)(nil).SetAvailabilityZone), arg0)
+}
+
 // SetInstanceStatus mocks base method
 func (m *MockMachine) SetInstanceStatus(arg0 status.Status, arg1 string, arg2 map[string]interface{}) error {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetInstanceStatus", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -173,11 +203,13 @@ func (m *MockMachine) SetInstanceStatus(arg0 status.Status, arg1 string, arg2 ma
 
 // SetInstanceStatus indicates an expected call of SetInstanceStatus
 func (mr *MockMachineMockRecorder) SetInstanceStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInstanceStatus", reflect.TypeOf((*MockMachine)(nil).SetInstanceStatus), arg0, arg1, arg2)
 }
 
 // SetProviderNetworkConfig mocks base method
 func (m *MockMachine) SetProviderNetworkConfig(arg0 network.InterfaceInfos) (network.ProviderAddresses, bool, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetProviderNetworkConfig", arg0)
 	ret0, _ := ret[0].(network.ProviderAddresses)
 	ret1, _ := ret[1].(bool)
@@ -187,11 +219,13 @@ func (m *MockMachine) SetProviderNetworkConfig(arg0 network.InterfaceInfos) (net
 
 // SetProviderNetworkConfig indicates an expected call of SetProviderNetworkConfig
 func (mr *MockMachineMockRecorder) SetProviderNetworkConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderNetworkConfig", reflect.TypeOf((*MockMachine)(nil).SetProviderNetworkConfig), arg0)
 }
 
 // Status mocks base method
 func (m *MockMachine) Status() (params.StatusResult, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Status")
 	ret0, _ := ret[0].(params.StatusResult)
 	ret1, _ := ret[1].(error)
@@ -200,11 +234,13 @@ func (m *MockMachine) Status() (params.StatusResult, error) {
 
 // Status indicates an expected call of Status
 func (mr *MockMachineMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockMachine)(nil).Status))
 }
 
 // String mocks base method
 func (m *MockMachine) String() string {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "String")
 	ret0, _ := ret[0].(string)
 	return ret0
@@ -212,5 +248,6 @@ func (m *MockMachine) String() string {
 
 // String indicates an expected call of String
 func (mr *MockMachineMockRecorder) String() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockMachine)(nil).String))
 }
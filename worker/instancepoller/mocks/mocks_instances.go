@@ -1,16 +1,15 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/environs/instances (interfaces: Instance)
+// Source: github.com/juju/juju/environs/instances (interfaces: Instance,ZonedInstance)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
-	reflect "reflect"
-
 	gomock "github.com/golang/mock/gomock"
 	instance "github.com/juju/juju/core/instance"
 	network "github.com/juju/juju/core/network"
 	context "github.com/juju/juju/environs/context"
+	reflect "reflect"
 )
 
 // MockInstance is a mock of Instance interface
@@ -38,6 +37,7 @@ func (m *MockInstance) EXPECT() *MockInstanceMockRecorder {
 
 // Addresses mocks base method
 func (m *MockInstance) Addresses(arg0 context.ProviderCallContext) (network.ProviderAddresses, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Addresses", arg0)
 	ret0, _ := ret[0].(network.ProviderAddresses)
 	ret1, _ := ret[1].(error)
@@ -46,11 +46,13 @@ func (m *MockInstance) Addresses(arg0 context.ProviderCallContext) (network.Prov
 
 // Addresses indicates an expected call of Addresses
 func (mr *MockInstanceMockRecorder) Addresses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Addresses", reflect.TypeOf((*MockInstance)(nil).Addresses), arg0)
 }
 
 // Id mocks base method
 func (m *MockInstance) Id() instance.Id {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Id")
 	ret0, _ := ret[0].(instance.Id)
 	return ret0
@@ -58,11 +60,13 @@ func (m *MockInstance) Id() instance.Id {
 
 // Id indicates an expected call of Id
 func (mr *MockInstanceMockRecorder) Id() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Id", reflect.TypeOf((*MockInstance)(nil).Id))
 }
 
 // Status mocks base method
 func (m *MockInstance) Status(arg0 context.ProviderCallContext) instance.Status {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Status", arg0)
 	ret0, _ := ret[0].(instance.Status)
 	return ret0
@@ -70,5 +74,86 @@ func (m *MockInstance) Status(arg0 context.ProviderCallContext) instance.Status
 
 // Status indicates an expected call of Status
 func (mr *MockInstanceMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockInstance)(nil).Status), arg0)
 }
+
+// MockZonedInstance is a mock of ZonedInstance interface
+type MockZonedInstance struct {
+	ctrl     *gomock.Controller
+	recorder *MockZonedInstanceMockRecorder
+}
+
+// MockZonedInstanceMockRecorder is the mock recorder for MockZonedInstance
+type MockZonedInstanceMockRecorder struct {
+	mock *MockZonedInstance
+}
+
+// NewMockZonedInstance creates a new mock instance
+func NewMockZonedInstance(ctrl *gomock.Controller) *MockZonedInstance {
+	mock := &MockZonedInstance{ctrl: ctrl}
+	mock.recorder = &MockZonedInstanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockZonedInstance) EXPECT() *MockZonedInstanceMockRecorder {
+	return m.recorder
+}
+
+// Addresses mocks base method
+func (m *MockZonedInstance) Addresses(arg0 context.ProviderCallContext) (network.ProviderAddresses, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Addresses", arg0)
+	ret0, _ := ret[0].(network.ProviderAddresses)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Addresses indicates an expected call of Addresses
+func (mr *MockZonedInstanceMockRecorder) Addresses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Addresses", reflect.TypeOf((*MockZonedInstance)(nil).Addresses), arg0)
+}
+
+// AvailabilityZone mocks base method
+func (m *MockZonedInstance) AvailabilityZone() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilityZone")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AvailabilityZone indicates an expected call of AvailabilityZone
+func (mr *MockZonedInstanceMockRecorder) AvailabilityZone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilityZone", reflect.TypeOf((*MockZonedInstance)(nil).AvailabilityZone))
+}
+
+// Id mocks base method
+func (m *MockZonedInstance) Id() instance.Id {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Id")
+	ret0, _ := ret[0].(instance.Id)
+	return ret0
+}
+
+// Id indicates an expected call of Id
+func (mr *MockZonedInstanceMockRecorder) Id() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Id", reflect.TypeOf((*MockZonedInstance)(nil).Id))
+}
+
+// Status mocks base method
+func (m *MockZonedInstance) Status(arg0 context.ProviderCallContext) instance.Status {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(instance.Status)
+	return ret0
+}
+
+// Status indicates an expected call of Status
+func (mr *MockZonedInstanceMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockZonedInstance)(nil).Status), arg0)
+}
@@ -25,7 +25,7 @@ import (
 )
 
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/mocks_watcher.go github.com/juju/juju/core/watcher StringsWatcher
-//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/mocks_instances.go github.com/juju/juju/environs/instances Instance
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/mocks_instances.go github.com/juju/juju/environs/instances Instance,ZonedInstance
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/mocks_cred_api.go github.com/juju/juju/worker/common CredentialAPI
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/mocks_instancepoller.go github.com/juju/juju/worker/instancepoller Environ,Machine
 
@@ -64,6 +64,7 @@ type Machine interface {
 	Status() (params.StatusResult, error)
 	Life() life.Value
 	IsManual() (bool, error)
+	SetAvailabilityZone(string) error
 }
 
 // FacadeAPI specifies the api-server methods needed by the instance
@@ -117,6 +118,10 @@ type pollGroupEntry struct {
 	tag        names.MachineTag
 	instanceID instance.Id
 
+	// zone is the availability zone last recorded for the
+	// machine's instance, if the provider reports one.
+	zone string
+
 	shortPollInterval time.Duration
 	shortPollAt       time.Time
 }
@@ -453,6 +458,11 @@ func (u *updaterWorker) processProviderInfo(entry *pollGroupEntry, info instance
 		return status.Unknown, -1, nil
 	}
 
+	// Check whether the instance has moved to another availability zone.
+	if err := u.syncAvailabilityZone(entry, info); err != nil {
+		return status.Unknown, -1, err
+	}
+
 	// Check whether the provider addresses for this machine need to be
 	// updated.
 	addrCount, err := u.syncProviderAddresses(entry, info, providerIfaceList)
@@ -463,6 +473,28 @@ func (u *updaterWorker) processProviderInfo(entry *pollGroupEntry, info instance
 	return providerStatus.Status, addrCount, nil
 }
 
+// syncAvailabilityZone records the availability zone of this entry's machine
+// for providers whose instances can move between zones, such as containers
+// in a clustered LXD. Instances of other providers are left alone.
+func (u *updaterWorker) syncAvailabilityZone(entry *pollGroupEntry, instInfo instances.Instance) error {
+	zoned, ok := instInfo.(instances.ZonedInstance)
+	if !ok {
+		return nil
+	}
+	zone := zoned.AvailabilityZone()
+	if zone == "" || zone == entry.zone {
+		return nil
+	}
+	if err := entry.m.SetAvailabilityZone(zone); err != nil {
+		return errors.Trace(err)
+	}
+	if entry.zone != "" {
+		u.config.Logger.Infof("machine %q (instance ID %q) moved from availability zone %q to %q", entry.m.Id(), entry.instanceID, entry.zone, zone)
+	}
+	entry.zone = zone
+	return nil
+}
+
 // syncProviderAddresses updates the provider addresses for this entry's machine
 // using either the provider interface list or falling back to the collected
 // instance information.
@@ -225,6 +225,49 @@ func (s *workerSuite) TestUpdateOfStatusAndAddressDetails(c *gc.C) {
 	c.Assert(addrCount, gc.Equals, len(testAddrs))
 }
 
+func (s *workerSuite) TestUpdateOfAvailabilityZone(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, _ := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	entry := &pollGroupEntry{
+		tag:        machineTag,
+		m:          machine,
+		instanceID: "b4dc0ffee",
+	}
+
+	machine.EXPECT().Id().Return("0").AnyTimes()
+	machine.EXPECT().Life().Return(life.Alive).AnyTimes()
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil).AnyTimes()
+	machine.EXPECT().SetProviderNetworkConfig(testNetIfs).Return(testAddrs, false, nil).AnyTimes()
+
+	// The provider reports the zone of the instance, which has moved
+	// from node01 to node02 between polls.
+	instInfo := mocks.NewMockZonedInstance(ctrl)
+	instInfo.EXPECT().Status(gomock.Any()).Return(instance.Status{Status: status.Running}).AnyTimes()
+	gomock.InOrder(
+		instInfo.EXPECT().AvailabilityZone().Return("node01").Times(2),
+		instInfo.EXPECT().AvailabilityZone().Return("node02"),
+	)
+
+	// The zone is recorded on the first poll and whenever it changes.
+	gomock.InOrder(
+		machine.EXPECT().SetAvailabilityZone("node01").Return(nil),
+		machine.EXPECT().SetAvailabilityZone("node02").Return(nil),
+	)
+
+	for i := 0; i < 3; i++ {
+		_, _, err := updWorker.processProviderInfo(entry, instInfo, testNetIfs)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(entry.zone, gc.Equals, "node02")
+}
+
 func (s *workerSuite) TestStartedMachineWithNetAddressesMovesToLongPollGroup(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -85,8 +85,8 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.Syslog.Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -249,13 +249,15 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
+func (c *mockLogForwardConfig) LogForwardConfig() (*logforwarder.SinkConfig, bool, error) {
+	return &logforwarder.SinkConfig{
+		Syslog: &syslog.RawConfig{
+			Enabled:    c.enabled,
+			Host:       c.host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
 	}, true, nil
 }
 
@@ -12,6 +12,8 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/logstream"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 // Logger represents the methods used by the worker to log details.
@@ -76,7 +78,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 			orchestrator, err := newOrchestratorForController(OrchestratorArgs{
 				ControllerUUID:   controllerCfg.ControllerUUID(),
-				LogForwardConfig: agentFacade,
+				LogForwardConfig: modelLogForwardConfig{agentFacade},
 				Caller:           apiCaller,
 				Sinks:            config.Sinks,
 				OpenLogStream:    openLogStream,
@@ -87,3 +89,35 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		},
 	}
 }
+
+// ModelConfigAPI provides access to the model config.
+type ModelConfigAPI interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+}
+
+// modelLogForwardConfig reads the log forwarding config
+// from the model config.
+type modelLogForwardConfig struct {
+	api ModelConfigAPI
+}
+
+// WatchForLogForwardConfigChanges is part of the LogForwardConfig interface.
+func (c modelLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
+	return c.api.WatchForModelConfigChanges()
+}
+
+// LogForwardConfig is part of the LogForwardConfig interface.
+func (c modelLogForwardConfig) LogForwardConfig() (*SinkConfig, bool, error) {
+	modelConfig, err := c.api.ModelConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if cfg, ok := modelConfig.LogFwdHTTP(); ok {
+		return &SinkConfig{HTTP: cfg}, true, nil
+	}
+	if cfg, ok := modelConfig.LogFwdSyslog(); ok {
+		return &SinkConfig{Syslog: cfg}, true, nil
+	}
+	return nil, false, nil
+}
@@ -4,7 +4,10 @@
 package logforwarder
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/logfwd/httpfwd"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -15,7 +18,42 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	LogForwardConfig() (*SinkConfig, bool, error)
+}
+
+// SinkConfig holds the configuration of the server logs are forwarded
+// to. Exactly one of Syslog and HTTP is set.
+type SinkConfig struct {
+	// Syslog is set if logs are forwarded to a syslog server.
+	Syslog *syslog.RawConfig
+
+	// HTTP is set if logs are forwarded to a Loki, Elasticsearch
+	// or JSON log collector.
+	HTTP *httpfwd.RawConfig
+}
+
+// Enabled returns whether log forwarding is enabled.
+func (cfg SinkConfig) Enabled() bool {
+	switch {
+	case cfg.Syslog != nil:
+		return cfg.Syslog.Enabled
+	case cfg.HTTP != nil:
+		return cfg.HTTP.Enabled
+	}
+	return false
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg SinkConfig) Validate() error {
+	switch {
+	case cfg.Syslog != nil && cfg.HTTP != nil:
+		return errors.NotValidf("both syslog and HTTP config")
+	case cfg.Syslog != nil:
+		return errors.Trace(cfg.Syslog.Validate())
+	case cfg.HTTP != nil:
+		return errors.Trace(cfg.HTTP.Validate())
+	}
+	return errors.NotValidf("missing sink config")
 }
 
 type LogSinkSpec struct {
@@ -27,7 +65,7 @@ type LogSinkSpec struct {
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg *SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
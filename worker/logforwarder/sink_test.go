@@ -0,0 +1,70 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpfwd"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type SinkConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinkConfigSuite{})
+
+func (s *SinkConfigSuite) TestSyslog(c *gc.C) {
+	cfg := logforwarder.SinkConfig{
+		Syslog: &syslog.RawConfig{
+			Enabled:    true,
+			Host:       "10.0.0.1:10514",
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+	}
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+	c.Check(cfg.Enabled(), jc.IsTrue)
+}
+
+func (s *SinkConfigSuite) TestHTTP(c *gc.C) {
+	cfg := logforwarder.SinkConfig{
+		HTTP: &httpfwd.RawConfig{
+			Format: httpfwd.FormatLoki,
+			URL:    "http://loki:3100",
+		},
+	}
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+	c.Check(cfg.Enabled(), jc.IsFalse)
+}
+
+func (s *SinkConfigSuite) TestHTTPInvalid(c *gc.C) {
+	cfg := logforwarder.SinkConfig{
+		HTTP: &httpfwd.RawConfig{
+			Enabled: true,
+			Format:  httpfwd.FormatLoki,
+		},
+	}
+	c.Check(cfg.Validate(), gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *SinkConfigSuite) TestBoth(c *gc.C) {
+	cfg := logforwarder.SinkConfig{
+		Syslog: &syslog.RawConfig{},
+		HTTP:   &httpfwd.RawConfig{Format: httpfwd.FormatJSON},
+	}
+	c.Check(cfg.Validate(), gc.ErrorMatches, `both syslog and HTTP config not valid`)
+}
+
+func (s *SinkConfigSuite) TestEmpty(c *gc.C) {
+	var cfg logforwarder.SinkConfig
+	c.Check(cfg.Validate(), gc.ErrorMatches, `missing sink config not valid`)
+	c.Check(cfg.Enabled(), jc.IsFalse)
+}
@@ -0,0 +1,26 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpfwd"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink used to forward log messages to a Loki,
+// Elasticsearch or JSON log collector.
+func OpenHTTP(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	if cfg.HTTP == nil || !cfg.HTTP.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpfwd.Open(*cfg.HTTP)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns a sink which forwards log messages to the syslog
+// server or HTTP log collector described by the config.
+func Open(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	switch {
+	case cfg.HTTP != nil:
+		return OpenHTTP(cfg)
+	case cfg.Syslog != nil:
+		return OpenSyslog(cfg)
+	}
+	return nil, errors.NotValidf("missing sink config")
+}
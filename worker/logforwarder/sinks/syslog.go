@@ -12,11 +12,11 @@ import (
 )
 
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-	if !cfg.Enabled {
+func OpenSyslog(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	if cfg.Syslog == nil || !cfg.Syslog.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
-	client, err := syslog.Open(*cfg)
+	client, err := syslog.Open(*cfg.Syslog)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
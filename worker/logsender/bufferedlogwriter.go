@@ -12,6 +12,8 @@ import (
 	"github.com/juju/collections/deque"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/logger"
 )
 
 // LogRecord represents a log message in an agent which is to be
@@ -22,6 +24,7 @@ type LogRecord struct {
 	Location string // e.g. "foo.go:42"
 	Level    loggo.Level
 	Message  string
+	Labels   []string
 
 	// Number of messages dropped after this one due to buffer limit.
 	DroppedAfter int
@@ -146,13 +149,16 @@ func (w *BufferedLogWriter) loop() {
 }
 
 // Write sends a new log message to the writer. This implements the loggo.Writer interface.
+// Any labels prefixing the message are moved to the record's labels.
 func (w *BufferedLogWriter) Write(entry loggo.Entry) {
+	labels, message := logger.ParseLabels(entry.Message)
 	w.in <- &LogRecord{
 		Time:     entry.Timestamp,
 		Module:   entry.Module,
 		Location: fmt.Sprintf("%s:%d", filepath.Base(entry.Filename), entry.Line),
 		Level:    entry.Level,
-		Message:  entry.Message,
+		Message:  message,
+		Labels:   labels,
 	}
 }
 
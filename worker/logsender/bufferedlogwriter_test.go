@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/logger"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendertest"
@@ -77,6 +78,28 @@ func (s *bufferedLogWriterSuite) TestBuffering(c *gc.C) {
 	}
 }
 
+func (s *bufferedLogWriterSuite) TestLabels(c *gc.C) {
+	now := time.Now()
+	s.writer.Write(
+		loggo.Entry{
+			Level:     loggo.INFO,
+			Module:    "module",
+			Filename:  "filename",
+			Line:      42,
+			Timestamp: now,
+			Message:   logger.FormatLabels("trace-id=1", "span-id=2") + "message",
+		})
+
+	c.Assert(*s.receiveOne(c), gc.DeepEquals, logsender.LogRecord{
+		Time:     now,
+		Module:   "module",
+		Location: "filename:42",
+		Level:    loggo.INFO,
+		Message:  "message",
+		Labels:   []string{"trace-id=1", "span-id=2"},
+	})
+}
+
 func (s *bufferedLogWriterSuite) TestLimiting(c *gc.C) {
 	write := func(msgNum int) {
 		s.writer.Write(
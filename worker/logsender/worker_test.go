@@ -88,6 +88,7 @@ func (s *workerSuite) TestLogSending(c *gc.C) {
 			Location: location,
 			Level:    loggo.INFO,
 			Message:  message,
+			Labels:   []string{"label"},
 		}
 
 		expectedDocs = append(expectedDocs, bson.M{
@@ -98,6 +99,7 @@ func (s *workerSuite) TestLogSending(c *gc.C) {
 			"l": location,
 			"v": int(loggo.INFO),
 			"x": message,
+			"c": []interface{}{"label"},
 		})
 	}
 
@@ -97,6 +97,11 @@ func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// LogActionOutput implements runner.Context.
+func (ctx *limitedContext) LogActionOutput(stream, output string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // Flush implements runner.Context.
 func (ctx *limitedContext) Flush(_ string, err error) error {
 	return err
@@ -111,6 +111,11 @@ func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// LogActionOutput implements runner.Context.
+func (ctx *hookContext) LogActionOutput(stream, output string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 
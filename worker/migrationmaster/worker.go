@@ -410,7 +410,7 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
+	err = targetClient.Import(serialized)
 	if err != nil {
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
@@ -611,6 +611,7 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 				Location: msg.Location,
 				Level:    msg.Severity,
 				Message:  msg.Message,
+				Labels:   msg.Labels,
 			})
 			if err != nil {
 				return errors.Trace(err)
@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	stdcontext "context"
 	"fmt"
 	"sort"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -1046,12 +1048,35 @@ func (task *provisionerTask) populateExcludedMachines(machineId string, startIns
 	return nil
 }
 
+// startInstance asks the broker to start an instance,
+// recording the provider call as part of the trace in ctx.
+func (task *provisionerTask) startInstance(
+	ctx stdcontext.Context, args environs.StartInstanceParams,
+) (*environs.StartInstanceResult, error) {
+	_, span := trace.Start(ctx, "provider.StartInstance",
+		trace.StringAttr("juju.availability-zone", args.AvailabilityZone),
+	)
+	defer span.End()
+	result, err := task.broker.StartInstance(task.cloudCallCtx, args)
+	span.RecordError(err)
+	return result, err
+}
+
 func (task *provisionerTask) startMachine(
 	machine apiprovisioner.MachineProvisioner,
 	distributionGroupMachineIds []string,
-) error {
+) (err error) {
+	ctx, span := trace.Start(stdcontext.Background(), "provisioner.StartMachine",
+		trace.StringAttr("juju.machine", machine.Id()),
+	)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+	logger := trace.WrapLogger(ctx, task.logger)
+
 	if err := machine.SetInstanceStatus(status.Provisioning, "starting", nil); err != nil {
-		task.logger.Errorf("%v", err)
+		logger.Errorf("%v", err)
 	}
 
 	v, err := machine.ModelAgentVersion()
@@ -1088,11 +1113,11 @@ func (task *provisionerTask) startMachine(
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
 		}
 		if startInstanceParams.AvailabilityZone != "" {
-			task.logger.Infof("trying machine %s StartInstance in availability zone %s",
+			logger.Infof("trying machine %s StartInstance in availability zone %s",
 				machine, startInstanceParams.AvailabilityZone)
 		}
 
-		attemptResult, err := task.broker.StartInstance(task.cloudCallCtx, startInstanceParams)
+		attemptResult, err := task.startInstance(ctx, startInstanceParams)
 		if err == nil {
 			result = attemptResult
 			break
@@ -1103,10 +1128,10 @@ func (task *provisionerTask) startMachine(
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
 		} else {
 			if startInstanceParams.AvailabilityZone != "" {
-				task.logger.Warningf("machine %s failed to start in availability zone %s: %v",
+				logger.Warningf("machine %s failed to start in availability zone %s: %v",
 					machine, startInstanceParams.AvailabilityZone, err)
 			} else {
-				task.logger.Warningf("machine %s failed to start: %v",
+				logger.Warningf("machine %s failed to start: %v",
 					machine, err)
 			}
 		}
@@ -1120,7 +1145,7 @@ func (task *provisionerTask) startMachine(
 				startInstanceParams.AvailabilityZone, startInstanceParams.Constraints)
 			if err2 != nil {
 				if err = task.setErrorStatus("cannot start instance: %v", machine, err2); err != nil {
-					task.logger.Errorf("setting error status: %s", err)
+					logger.Errorf("setting error status: %s", err)
 				}
 				return err2
 			}
@@ -1130,7 +1155,7 @@ func (task *provisionerTask) startMachine(
 					machine, startInstanceParams.AvailabilityZone,
 					task.retryStartInstanceStrategy.retryDelay, err,
 				)
-				task.logger.Debugf("%s", retryMsg)
+				logger.Debugf("%s", retryMsg)
 				// There's still more zones to try, so don't decrement "attemptsLeft" yet.
 				retrying = false
 			} else {
@@ -1145,12 +1170,12 @@ func (task *provisionerTask) startMachine(
 				"failed to start machine %s (%s), retrying in %v (%d more attempts)",
 				machine, err.Error(), task.retryStartInstanceStrategy.retryDelay, attemptsLeft,
 			)
-			task.logger.Warningf("%s", retryMsg)
+			logger.Warningf("%s", retryMsg)
 			attemptsLeft--
 		}
 
 		if err3 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err3 != nil {
-			task.logger.Warningf("failed to set instance status: %v", err3)
+			logger.Warningf("failed to set instance status: %v", err3)
 		}
 
 		select {
@@ -1185,15 +1210,15 @@ func (task *provisionerTask) startMachine(
 	); err != nil {
 		// We need to stop the instance right away here, set error status and go on.
 		if err2 := task.setErrorStatus("cannot register instance for machine %v: %v", machine, err); err2 != nil {
-			task.logger.Errorf("%v", errors.Annotate(err2, "cannot set machine's status"))
+			logger.Errorf("%v", errors.Annotate(err2, "cannot set machine's status"))
 		}
 		if err2 := task.broker.StopInstances(task.cloudCallCtx, instanceID); err2 != nil {
-			task.logger.Errorf("%v", errors.Annotate(err2, "after failing to set instance info"))
+			logger.Errorf("%v", errors.Annotate(err2, "after failing to set instance info"))
 		}
 		return errors.Annotate(err, "cannot set instance info")
 	}
 
-	task.logger.Infof(
+	logger.Infof(
 		"started machine %s as instance %s with hardware %q, network config %+v, "+
 			"volumes %v, volume attachments %v, subnets to zones %v, lxd profiles %v",
 		machine,
@@ -16,19 +16,26 @@ type Logger interface {
 }
 
 // Config holds all necessary attributes to start a pruner worker.
+// Exactly one of Facade and ControllerFacade must be set, according
+// to whether the pruned records' limits are held in the model config
+// or in the controller config.
 type Config struct {
-	Facade        Facade
-	PruneInterval time.Duration
-	Clock         clock.Clock
-	Logger        Logger
+	Facade           Facade
+	ControllerFacade ControllerFacade
+	PruneInterval    time.Duration
+	Clock            clock.Clock
+	Logger           Logger
 }
 
 // Validate will err unless basic requirements for a valid
 // config are met.
 func (c *Config) Validate() error {
-	if c.Facade == nil {
+	if c.Facade == nil && c.ControllerFacade == nil {
 		return errors.New("missing Facade")
 	}
+	if c.Facade != nil && c.ControllerFacade != nil {
+		return errors.New("only one of Facade and ControllerFacade may be set")
+	}
 	if c.Clock == nil {
 		return errors.New("missing Clock")
 	}
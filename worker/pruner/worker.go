@@ -4,12 +4,14 @@
 package pruner
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2/catacomb"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
 )
@@ -27,7 +29,17 @@ type Facade interface {
 	ModelConfig() (*config.Config, error)
 }
 
-// PrunerWorker prunes status history or action records at regular intervals.
+// ControllerFacade represents an API that implements pruning of
+// controller-wide records, such as the audit log, whose limits
+// are held in the controller config.
+type ControllerFacade interface {
+	Prune(stop <-chan struct{}, maxAge time.Duration, maxCollectionMB int) error
+	WatchControllerConfig() (watcher.NotifyWatcher, error)
+	ControllerConfig() (controller.Config, error)
+}
+
+// PrunerWorker prunes status history, action or audit log records at
+// regular intervals.
 type PrunerWorker struct {
 	catacomb catacomb.Catacomb
 	config   Config
@@ -53,23 +65,73 @@ func (w *PrunerWorker) Config() *Config {
 	return &w.config
 }
 
-// Work is the main body of generic pruner loop.
+// Work is the main body of generic pruner loop, for records whose
+// limits are held in the model config.
 func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration, uint)) error {
-	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	facade := w.config.Facade
+	return w.work(facade.WatchForModelConfigChanges, func() (limits, error) {
+		modelConfig, err := facade.ModelConfig()
+		if err != nil {
+			return limits{}, errors.Annotate(err, "cannot load model configuration")
+		}
+		maxAge, maxCollectionMB := getPrunerConfig(modelConfig)
+		return limits{
+			maxAge:          maxAge,
+			maxCollectionMB: maxCollectionMB,
+			owner:           fmt.Sprintf("%s (%s)", modelConfig.Name(), modelConfig.UUID()),
+		}, nil
+	}, func(maxAge time.Duration, maxCollectionMB uint) error {
+		return facade.Prune(maxAge, int(maxCollectionMB))
+	})
+}
+
+// WorkWithControllerConfig is the main body of a pruner loop for
+// controller-wide records, whose limits are held in the controller config.
+func (w *PrunerWorker) WorkWithControllerConfig(getPrunerConfig func(controller.Config) (time.Duration, uint)) error {
+	facade := w.config.ControllerFacade
+	return w.work(facade.WatchControllerConfig, func() (limits, error) {
+		controllerConfig, err := facade.ControllerConfig()
+		if err != nil {
+			return limits{}, errors.Annotate(err, "cannot load controller configuration")
+		}
+		maxAge, maxCollectionMB := getPrunerConfig(controllerConfig)
+		return limits{
+			maxAge:          maxAge,
+			maxCollectionMB: maxCollectionMB,
+			owner:           fmt.Sprintf("controller %s", controllerConfig.ControllerUUID()),
+		}, nil
+	}, func(maxAge time.Duration, maxCollectionMB uint) error {
+		return facade.Prune(w.catacomb.Dying(), maxAge, int(maxCollectionMB))
+	})
+}
+
+// limits holds the limits that records are pruned to, and
+// a description of the model or controller they apply to.
+type limits struct {
+	maxAge          time.Duration
+	maxCollectionMB uint
+	owner           string
+}
+
+func (w *PrunerWorker) work(
+	watch func() (watcher.NotifyWatcher, error),
+	getLimits func() (limits, error),
+	prune func(time.Duration, uint) error,
+) error {
+	configWatcher, err := watch()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = w.catacomb.Add(modelConfigWatcher)
+	err = w.catacomb.Add(configWatcher)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
 	var (
-		maxAge             time.Duration
-		maxCollectionMB    uint
-		modelConfigChanges = modelConfigWatcher.Changes()
+		current limits
 		// We will also get an initial event, but need to ensure that event is
 		// received before doing any pruning.
+		configChanges = configWatcher.Changes()
 	)
 
 	var timer clock.Timer
@@ -79,30 +141,26 @@ func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration,
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
 
-		case _, ok := <-modelConfigChanges:
+		case _, ok := <-configChanges:
 			if !ok {
-				return errors.New("model configuration watcher closed")
+				return errors.New("configuration watcher closed")
 			}
-			modelConfig, err := w.config.Facade.ModelConfig()
+			newLimits, err := getLimits()
 			if err != nil {
-				return errors.Annotate(err, "cannot load model configuration")
+				return errors.Trace(err)
 			}
-
-			newMaxAge, newMaxCollectionMB := getPrunerConfig(modelConfig)
-
-			if newMaxAge != maxAge || newMaxCollectionMB != maxCollectionMB {
-				w.config.Logger.Infof("pruner config: max age: %v, max collection size %dM for %s (%s)",
-					newMaxAge, newMaxCollectionMB, modelConfig.Name(), modelConfig.UUID())
-				maxAge = newMaxAge
-				maxCollectionMB = newMaxCollectionMB
+			if newLimits.maxAge != current.maxAge || newLimits.maxCollectionMB != current.maxCollectionMB {
+				w.config.Logger.Infof("pruner config: max age: %v, max collection size %dM for %s",
+					newLimits.maxAge, newLimits.maxCollectionMB, newLimits.owner)
 			}
+			current = newLimits
 			if timer == nil {
 				timer = w.config.Clock.NewTimer(w.config.PruneInterval)
 				timerCh = timer.Chan()
 			}
 
 		case <-timerCh:
-			err := w.config.Facade.Prune(maxAge, int(maxCollectionMB))
+			err := prune(current.maxAge, current.maxCollectionMB)
 			if err != nil {
 				return errors.Trace(err)
 			}
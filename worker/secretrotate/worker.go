@@ -0,0 +1,224 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretrotate
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead use the one passed as manifold config.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+// SecretManagerFacade instances provide a watcher for secret rotation changes.
+type SecretManagerFacade interface {
+	WatchSecretsRotationChanges(ownerTag names.ApplicationTag) (watcher.StringsWatcher, error)
+	GetSecretMetadata() ([]secrets.SecretMetadata, error)
+}
+
+// Config defines the operation of the Worker.
+type Config struct {
+	SecretManagerFacade SecretManagerFacade
+	Logger              Logger
+	Clock               clock.Clock
+
+	SecretOwner   names.ApplicationTag
+	RotateSecrets chan<- []string
+}
+
+// Validate returns an error if config cannot drive the Worker.
+func (config Config) Validate() error {
+	if config.SecretManagerFacade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.SecretOwner.Id() == "" {
+		return errors.NotValidf("empty SecretOwner")
+	}
+	if config.RotateSecrets == nil {
+		return errors.NotValidf("nil RotateSecretsChannel")
+	}
+	return nil
+}
+
+// New returns a Secret Rotation Worker backed by config, or an error.
+// The worker tracks the next rotation time of each secret owned by
+// the configured application, and sends the URIs of those secrets
+// which are due to be rotated on the RotateSecrets channel.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		config:  config,
+		secrets: make(map[string]time.Time),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker fires events when secrets should be rotated.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	secrets map[string]time.Time
+
+	timer       clock.Timer
+	nextTrigger time.Time
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() (err error) {
+	changes, err := w.config.SecretManagerFacade.WatchSecretsRotationChanges(w.config.SecretOwner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(changes); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+	}()
+	for {
+		var timeout <-chan time.Time
+		if w.timer != nil {
+			timeout = w.timer.Chan()
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case uris, ok := <-changes.Changes():
+			if !ok {
+				return errors.New("secret rotation change channel closed")
+			}
+			if err := w.handleSecretRotateChanges(uris); err != nil {
+				return errors.Trace(err)
+			}
+		case now := <-timeout:
+			if err := w.rotate(now); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *Worker) rotate(now time.Time) error {
+	var toRotate []string
+	for uri, next := range w.secrets {
+		if next.After(now) {
+			continue
+		}
+		toRotate = append(toRotate, uri)
+		// Once the secret is rotated, its next rotate time will
+		// be updated and reported by the watcher.
+		delete(w.secrets, uri)
+	}
+	sort.Strings(toRotate)
+	w.config.Logger.Debugf("secrets to rotate: %v", toRotate)
+	if len(toRotate) > 0 {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case w.config.RotateSecrets <- toRotate:
+		}
+	}
+	w.computeNextRotateTime()
+	return nil
+}
+
+func (w *Worker) handleSecretRotateChanges(uris []string) error {
+	w.config.Logger.Debugf("got rotate secret changes: %v", uris)
+	if len(uris) == 0 {
+		return nil
+	}
+	md, err := w.config.SecretManagerFacade.GetSecretMetadata()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current := make(map[string]secrets.SecretMetadata, len(md))
+	for _, m := range md {
+		current[m.URI.String()] = m
+	}
+	for _, uri := range uris {
+		m, ok := current[uri]
+		if !ok || m.RotateInterval <= 0 || m.NextRotateTime == nil {
+			w.config.Logger.Debugf("secret %q no longer rotated", uri)
+			delete(w.secrets, uri)
+			continue
+		}
+		w.secrets[uri] = *m.NextRotateTime
+	}
+	w.computeNextRotateTime()
+	return nil
+}
+
+func (w *Worker) computeNextRotateTime() {
+	if len(w.secrets) == 0 {
+		if w.timer != nil {
+			w.timer.Stop()
+			w.timer = nil
+		}
+		w.nextTrigger = time.Time{}
+		return
+	}
+
+	var soonest time.Time
+	for _, next := range w.secrets {
+		if soonest.IsZero() || next.Before(soonest) {
+			soonest = next
+		}
+	}
+	if soonest.Equal(w.nextTrigger) && w.timer != nil {
+		return
+	}
+	w.nextTrigger = soonest
+
+	now := w.config.Clock.Now()
+	delay := soonest.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	w.config.Logger.Debugf("next secret rotation in %v at %s", delay, soonest)
+	if w.timer == nil {
+		w.timer = w.config.Clock.NewTimer(delay)
+	} else {
+		w.timer.Reset(delay)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretrotate_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/secretrotate"
+)
+
+type workerSuite struct {
+	jujutesting.IsolationSuite
+
+	clock         *testclock.Clock
+	config        secretrotate.Config
+	facade        *mockFacade
+	changes       chan []string
+	rotateSecrets chan []string
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.clock = testclock.NewClock(time.Now())
+	s.changes = make(chan []string, 1)
+	s.rotateSecrets = make(chan []string, 5)
+	s.facade = &mockFacade{
+		watcher: watchertest.NewMockStringsWatcher(s.changes),
+	}
+	s.config = secretrotate.Config{
+		SecretManagerFacade: s.facade,
+		Clock:               s.clock,
+		Logger:              loggo.GetLogger("test"),
+		SecretOwner:         names.NewApplicationTag("mariadb"),
+		RotateSecrets:       s.rotateSecrets,
+	}
+}
+
+func (s *workerSuite) TestValidateConfig(c *gc.C) {
+	s.testValidateConfig(c, func(config *secretrotate.Config) {
+		config.SecretManagerFacade = nil
+	}, `nil Facade not valid`)
+
+	s.testValidateConfig(c, func(config *secretrotate.Config) {
+		config.SecretOwner = names.ApplicationTag{}
+	}, `empty SecretOwner not valid`)
+
+	s.testValidateConfig(c, func(config *secretrotate.Config) {
+		config.RotateSecrets = nil
+	}, `nil RotateSecretsChannel not valid`)
+
+	s.testValidateConfig(c, func(config *secretrotate.Config) {
+		config.Logger = nil
+	}, `nil Logger not valid`)
+
+	s.testValidateConfig(c, func(config *secretrotate.Config) {
+		config.Clock = nil
+	}, `nil Clock not valid`)
+}
+
+func (s *workerSuite) testValidateConfig(c *gc.C, f func(*secretrotate.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := secretrotate.New(config)
+	if err == nil {
+		workertest.DirtyKill(c, w)
+	}
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *workerSuite) expectNoRotates(c *gc.C) {
+	select {
+	case uris := <-s.rotateSecrets:
+		c.Fatalf("got unexpected secret rotation %q", uris)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) expectRotated(c *gc.C, expected ...string) {
+	select {
+	case uris, ok := <-s.rotateSecrets:
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(uris, jc.SameContents, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for secrets to be rotated")
+	}
+}
+
+func (s *workerSuite) secret(c *gc.C, id string, next time.Duration) secrets.SecretMetadata {
+	uri, err := secrets.ParseURI("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/" + id)
+	c.Assert(err, jc.ErrorIsNil)
+	nextTime := s.clock.Now().Add(next)
+	return secrets.SecretMetadata{
+		URI:            uri,
+		RotateInterval: time.Hour,
+		NextRotateTime: &nextTime,
+	}
+}
+
+func (s *workerSuite) TestStartStop(c *gc.C) {
+	w, err := secretrotate.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+	s.facade.CheckCallNames(c, "WatchSecretsRotationChanges")
+	s.facade.CheckCall(c, 0, "WatchSecretsRotationChanges", names.NewApplicationTag("mariadb"))
+}
+
+func (s *workerSuite) TestFirstSecret(c *gc.C) {
+	md := s.secret(c, "a", time.Hour)
+	s.facade.metadata = []secrets.SecretMetadata{md}
+
+	w, err := secretrotate.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{md.URI.String()}
+	c.Assert(s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.expectNoRotates(c)
+	c.Assert(s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.expectRotated(c, md.URI.String())
+}
+
+func (s *workerSuite) TestSecretsDueTogether(c *gc.C) {
+	md1 := s.secret(c, "a", time.Hour)
+	md2 := s.secret(c, "b", 30*time.Minute)
+	md3 := s.secret(c, "c", 2*time.Hour)
+	s.facade.metadata = []secrets.SecretMetadata{md1, md2, md3}
+
+	w, err := secretrotate.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{md1.URI.String(), md2.URI.String(), md3.URI.String()}
+	c.Assert(s.clock.WaitAdvance(90*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.expectRotated(c, md1.URI.String(), md2.URI.String())
+	s.expectNoRotates(c)
+}
+
+func (s *workerSuite) TestSecretNoLongerRotated(c *gc.C) {
+	md := s.secret(c, "a", time.Hour)
+	s.facade.metadata = []secrets.SecretMetadata{md}
+
+	w, err := secretrotate.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{md.URI.String()}
+	c.Assert(s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	s.facade.metadata = nil
+	s.changes <- []string{md.URI.String()}
+	// Wait for the change to be processed.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.facade.Calls()) == 3 {
+			break
+		}
+	}
+	s.clock.Advance(time.Hour)
+	s.expectNoRotates(c)
+}
+
+func (s *workerSuite) TestWatcherError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := secretrotate.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockFacade struct {
+	jujutesting.Stub
+	watcher  watcher.StringsWatcher
+	metadata []secrets.SecretMetadata
+}
+
+func (f *mockFacade) WatchSecretsRotationChanges(ownerTag names.ApplicationTag) (watcher.StringsWatcher, error) {
+	f.MethodCall(f, "WatchSecretsRotationChanges", ownerTag)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.watcher, nil
+}
+
+func (f *mockFacade) GetSecretMetadata() ([]secrets.SecretMetadata, error) {
+	f.MethodCall(f, "GetSecretMetadata")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.metadata, nil
+}
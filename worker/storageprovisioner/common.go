@@ -180,6 +180,14 @@ func filesystemSource(
 	return source, nil
 }
 
+// hostAttachesFilesystems reports whether filesystems of the given
+// provider type are attached by the host's storage provisioner,
+// though they are managed by the model's.
+func hostAttachesFilesystems(registry storage.ProviderRegistry, providerType storage.ProviderType) bool {
+	provider, err := registry.StorageProvider(providerType)
+	return err == nil && storage.HostAttachesFilesystems(provider)
+}
+
 func sourceParams(
 	baseStorageDir string,
 	sourceName string,
@@ -7,6 +7,7 @@ import "github.com/juju/worker/v2"
 
 var (
 	NewManagedFilesystemSource = &newManagedFilesystemSource
+	FilesystemUsage            = &filesystemUsage
 )
 
 func StorageWorker(parent worker.Worker, appName string) (worker.Worker, bool) {
@@ -160,7 +160,10 @@ func updatePendingFilesystemAttachment(
 	var incomplete bool
 	filesystem, ok := ctx.filesystems[params.Filesystem]
 	if !ok {
-		incomplete = true
+		// Filesystems that are attached by the host, but managed
+		// by the model, are not known to the host's provisioner;
+		// the filesystem ID comes with the attachment parameters.
+		incomplete = !hostAttachesFilesystems(ctx.config.Registry, params.Provider)
 	} else {
 		params.FilesystemId = filesystem.FilesystemId
 		if filesystem.Volume != (names.VolumeTag{}) {
@@ -451,6 +451,12 @@ func filesystemAttachmentParamsBySource(
 			continue
 		}
 		filesystem, ok := filesystems[params.Filesystem]
+		if !ok {
+			// Filesystems that are attached by the host, but
+			// managed by the model, are not known to the host's
+			// provisioner; they are attached by their provider.
+			ok = hostAttachesFilesystems(registry, params.Provider)
+		}
 		if !ok || filesystem.Volume != (names.VolumeTag{}) {
 			filesystemSources[sourceName] = managedFilesystemSource
 			continue
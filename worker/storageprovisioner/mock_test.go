@@ -26,6 +26,10 @@ import (
 const needsInstanceVolumeId = "23"
 const noAttachmentVolumeId = "66"
 
+// snapshotVolumeId is the ID of a volume which
+// is to be created from a snapshot.
+const snapshotVolumeId = "77"
+
 var (
 	releasingVolumeId     = "2"
 	releasingFilesystemId = "2"
@@ -137,6 +141,7 @@ func (w *mockAttachmentPlansWatcher) Changes() watcher.MachineStorageIdsChannel
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	resizesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	attachmentPlansWatcher *mockAttachmentPlansWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
@@ -144,6 +149,7 @@ type mockVolumeAccessor struct {
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	pendingResizes         map[string]uint64
 
 	setVolumeInfo               func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo     func([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -165,6 +171,10 @@ func (w *mockVolumeAccessor) WatchVolumes(names.Tag) (watcher.StringsWatcher, er
 	return w.volumesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchVolumeAttachments(names.Tag) (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -233,6 +243,9 @@ func (v *mockVolumeAccessor) VolumeParams(volumes []names.VolumeTag) ([]params.V
 				"very": "fancy",
 			},
 		}
+		if tag.Id() == snapshotVolumeId {
+			volumeParams.SnapshotId = "snap-1"
+		}
 		if tag.Id() != noAttachmentVolumeId {
 			volumeParams.Attachment = &params.VolumeAttachmentParams{
 				VolumeTag:  tag.String(),
@@ -267,6 +280,26 @@ func (v *mockVolumeAccessor) RemoveVolumeParams(volumes []names.VolumeTag) ([]pa
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) ResizeVolumeParams(volumes []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	var result []params.ResizeVolumeParamsResult
+	for _, tag := range volumes {
+		size, ok := v.pendingResizes[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeVolumeParamsResult{
+				Error: &params.Error{Code: params.CodeNotFound},
+			})
+			continue
+		}
+		result = append(result, params.ResizeVolumeParamsResult{Result: params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			Provider:  "dummy",
+			VolumeId:  v.provisionedVolumes[tag.String()].Info.VolumeId,
+			Size:      size,
+		}})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
 	var result []params.VolumeAttachmentParamsResult
 	for _, id := range ids {
@@ -320,6 +353,7 @@ func (v *mockVolumeAccessor) VolumeAttachmentPlans([]params.MachineStorageId) ([
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		attachmentPlansWatcher: newMockAttachmentPlansWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
@@ -327,19 +361,27 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		pendingResizes:         make(map[string]uint64),
 	}
 }
 
 type mockFilesystemAccessor struct {
 	testing.Stub
 	filesystemsWatcher     *mockStringsWatcher
+	resizesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
+	pendingResizes         map[string]uint64
+
+	// attachmentFilesystemIds, if true, causes FilesystemAttachmentParams
+	// to report the IDs of provisioned filesystems.
+	attachmentFilesystemIds bool
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+	setFilesystemUsage          func([]params.FilesystemUsageArg) ([]params.ErrorResult, error)
 }
 
 func (m *mockFilesystemAccessor) provisionFilesystem(tag names.FilesystemTag) params.Filesystem {
@@ -358,6 +400,10 @@ func (w *mockFilesystemAccessor) WatchFilesystems(tag names.Tag) (watcher.String
 	return w.filesystemsWatcher, nil
 }
 
+func (w *mockFilesystemAccessor) WatchFilesystemResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (w *mockFilesystemAccessor) WatchFilesystemAttachments(names.Tag) (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -431,15 +477,40 @@ func (v *mockFilesystemAccessor) RemoveFilesystemParams(filesystems []names.File
 	return results, nil
 }
 
+func (v *mockFilesystemAccessor) ResizeFilesystemParams(filesystems []names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error) {
+	var result []params.ResizeFilesystemParamsResult
+	for _, tag := range filesystems {
+		size, ok := v.pendingResizes[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeFilesystemParamsResult{
+				Error: &params.Error{Code: params.CodeNotFound},
+			})
+			continue
+		}
+		result = append(result, params.ResizeFilesystemParamsResult{Result: params.ResizeFilesystemParams{
+			FilesystemTag: tag.String(),
+			Provider:      "dummy",
+			FilesystemId:  v.provisionedFilesystems[tag.String()].Info.FilesystemId,
+			Size:          size,
+		}})
+	}
+	return result, nil
+}
+
 func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error) {
 	var result []params.FilesystemAttachmentParamsResult
 	for _, id := range ids {
 		// Parameters are returned regardless of whether the attachment
 		// exists; this is to support reattachment.
 		instanceId := f.provisionedMachines[id.MachineTag]
+		var filesystemId string
+		if f.attachmentFilesystemIds {
+			filesystemId = f.provisionedFilesystems[id.AttachmentTag].Info.FilesystemId
+		}
 		result = append(result, params.FilesystemAttachmentParamsResult{Result: params.FilesystemAttachmentParams{
 			MachineTag:    id.MachineTag,
 			FilesystemTag: id.AttachmentTag,
+			FilesystemId:  filesystemId,
 			InstanceId:    string(instanceId),
 			Provider:      "dummy",
 			ReadOnly:      true,
@@ -462,13 +533,22 @@ func (f *mockFilesystemAccessor) SetFilesystemAttachmentInfo(filesystemAttachmen
 	return make([]params.ErrorResult, len(filesystemAttachments)), nil
 }
 
+func (f *mockFilesystemAccessor) SetFilesystemUsage(usage []params.FilesystemUsageArg) ([]params.ErrorResult, error) {
+	if f.setFilesystemUsage != nil {
+		return f.setFilesystemUsage(usage)
+	}
+	return make([]params.ErrorResult, len(usage)), nil
+}
+
 func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		pendingResizes:         make(map[string]uint64),
 	}
 }
 
@@ -557,6 +637,17 @@ type dummyProvider struct {
 	releaseFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+}
+
+// hostAttachingProvider is a dummyProvider whose
+// filesystems are attached by the host.
+type hostAttachingProvider struct {
+	*dummyProvider
+}
+
+func (hostAttachingProvider) HostAttachesFilesystems() bool {
+	return true
 }
 
 type dummyVolumeSource struct {
@@ -664,6 +755,18 @@ func (s *dummyVolumeSource) AttachVolumes(ctx context.ProviderCallContext, param
 	return results, nil
 }
 
+// ResizeVolumes grows volumes to the requested size.
+func (s *dummyVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider != nil && s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
 // DetachVolumes detaches volumes from machines.
 func (s *dummyVolumeSource) DetachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]error, error) {
 	if s.provider.detachVolumesFunc != nil {
@@ -857,6 +960,7 @@ type mockClock struct {
 	onNow       func() time.Time
 	onAfter     func(time.Duration) <-chan time.Time
 	onAfterFunc func(time.Duration, func()) clock.Timer
+	onNewTimer  func(time.Duration) clock.Timer
 }
 
 func (c *mockClock) Now() time.Time {
@@ -881,6 +985,9 @@ func (c *mockClock) After(d time.Duration) <-chan time.Time {
 }
 
 func (c *mockClock) NewTimer(d time.Duration) clock.Timer {
+	if c.onNewTimer != nil {
+		return c.onNewTimer(d)
+	}
 	return mockTimer{time.NewTimer(0)}
 }
 
@@ -903,6 +1010,24 @@ func (t mockTimer) Chan() <-chan time.Time {
 	return t.C
 }
 
+// manualTimer is a clock.Timer that fires only when
+// a value is sent on its channel.
+type manualTimer struct {
+	ch chan time.Time
+}
+
+func (t *manualTimer) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *manualTimer) Reset(time.Duration) bool {
+	return true
+}
+
+func (t *manualTimer) Stop() bool {
+	return true
+}
+
 type mockStatusSetter struct {
 	args      []params.EntityStatusArgs
 	setStatus func([]params.EntityStatusArgs) error
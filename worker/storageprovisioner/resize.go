@@ -0,0 +1,277 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/storage"
+)
+
+// resizeCheckInterval is how often volumes whose resizes were
+// still in progress are checked to see whether they've grown.
+const resizeCheckInterval = 30 * time.Second
+
+// checkPendingVolumeResizes checks whether the volumes whose resizes
+// were still in progress have grown, recording their new sizes if so.
+func checkPendingVolumeResizes(ctx *context) error {
+	if len(ctx.pendingVolumeResizes) == 0 {
+		return nil
+	}
+	changes := make([]string, 0, len(ctx.pendingVolumeResizes))
+	for _, tag := range ctx.pendingVolumeResizes.SortedValues() {
+		changes = append(changes, tag.Id())
+	}
+	ctx.pendingVolumeResizes = names.NewSet()
+	return volumeResizesChanged(ctx, changes)
+}
+
+// volumeResizesChanged is called when volumes with the provided IDs
+// have been seen to have changed, and may have pending resizes.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	bySource := make(map[storage.ProviderType][]storage.VolumeResizeParams)
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// No resize is pending for the volume.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		providerType := storage.ProviderType(result.Result.Provider)
+		bySource[providerType] = append(bySource[providerType], storage.VolumeResizeParams{
+			Volume:   tags[i],
+			VolumeId: result.Result.VolumeId,
+			Size:     result.Result.Size,
+		})
+	}
+	if len(bySource) == 0 {
+		return nil
+	}
+
+	var resized []names.VolumeTag
+	sizes := make(map[names.VolumeTag]uint64)
+	var statuses []params.EntityStatusArgs
+	setError := func(tag names.VolumeTag, err error) {
+		ctx.config.Logger.Errorf("failed to resize %s: %v", names.ReadableString(tag), err)
+		statuses = append(statuses, params.EntityStatusArgs{
+			Tag:    tag.String(),
+			Status: status.Error.String(),
+			Info:   err.Error(),
+		})
+	}
+	for providerType, resizeParams := range bySource {
+		sourceName := string(providerType)
+		source, err := volumeSource(
+			ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			// Non-dynamic volumes are handled by the
+			// machine storage provisioner.
+			continue
+		} else if err != nil {
+			return errors.Annotate(err, "getting volume source")
+		}
+		resizer, ok := source.(storage.VolumeResizer)
+		if !ok {
+			for _, p := range resizeParams {
+				setError(p.Volume, errors.NotSupportedf("resizing %q volumes", providerType))
+			}
+			continue
+		}
+		ctx.config.Logger.Debugf("resizing volumes: %v", resizeParams)
+		resizeResults, err := resizer.ResizeVolumes(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range resizeResults {
+			tag := resizeParams[i].Volume
+			ctx.pendingVolumeResizes.Remove(tag)
+			if result.Error != nil {
+				setError(tag, result.Error)
+				continue
+			}
+			if result.Pending {
+				// The volume hasn't grown yet, so check it again
+				// later rather than record a size it doesn't have.
+				ctx.pendingVolumeResizes.Add(tag)
+				continue
+			}
+			resized = append(resized, tag)
+			sizes[tag] = result.Size
+		}
+	}
+	setStatus(ctx, statuses)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes, which clears the pending resizes.
+	volumeResults, err := ctx.config.Volumes.Volumes(resized)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]params.Volume, 0, len(resized))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting information for %s",
+				names.ReadableString(resized[i]),
+			)
+		}
+		volume := result.Result
+		volume.Info.Size = sizes[resized[i]]
+		volumes = append(volumes, volume)
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing size of %s to state",
+				names.ReadableString(resized[i]),
+			)
+		}
+		if info, ok := ctx.volumes[resized[i]]; ok {
+			info.Size = sizes[resized[i]]
+			ctx.volumes[resized[i]] = info
+		}
+	}
+	return nil
+}
+
+// filesystemResizesChanged is called when filesystems with the provided
+// IDs have been seen to have changed, and may have pending resizes.
+func filesystemResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.FilesystemTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewFilesystemTag(change)
+	}
+	results, err := ctx.config.Filesystems.ResizeFilesystemParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize params")
+	}
+	bySource := make(map[storage.ProviderType][]storage.FilesystemResizeParams)
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// No resize is pending for the filesystem.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		providerType := storage.ProviderType(result.Result.Provider)
+		bySource[providerType] = append(bySource[providerType], storage.FilesystemResizeParams{
+			Filesystem:   tags[i],
+			FilesystemId: result.Result.FilesystemId,
+			Size:         result.Result.Size,
+		})
+	}
+	if len(bySource) == 0 {
+		return nil
+	}
+
+	var resized []names.FilesystemTag
+	sizes := make(map[names.FilesystemTag]uint64)
+	var statuses []params.EntityStatusArgs
+	setError := func(tag names.FilesystemTag, err error) {
+		ctx.config.Logger.Errorf("failed to resize %s: %v", names.ReadableString(tag), err)
+		statuses = append(statuses, params.EntityStatusArgs{
+			Tag:    tag.String(),
+			Status: status.Error.String(),
+			Info:   err.Error(),
+		})
+	}
+	for providerType, resizeParams := range bySource {
+		sourceName := string(providerType)
+		source, err := filesystemSource(
+			ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry,
+		)
+		if errors.Cause(err) == errNonDynamic || errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotate(err, "getting filesystem source")
+		}
+		resizer, ok := source.(storage.FilesystemResizer)
+		if !ok {
+			for _, p := range resizeParams {
+				setError(p.Filesystem, errors.NotSupportedf("resizing %q filesystems", providerType))
+			}
+			continue
+		}
+		ctx.config.Logger.Debugf("resizing filesystems: %v", resizeParams)
+		resizeResults, err := resizer.ResizeFilesystems(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing filesystems from source %q", sourceName)
+		}
+		for i, result := range resizeResults {
+			if result.Error != nil {
+				setError(resizeParams[i].Filesystem, result.Error)
+				continue
+			}
+			resized = append(resized, resizeParams[i].Filesystem)
+			sizes[resizeParams[i].Filesystem] = result.Size
+		}
+	}
+	setStatus(ctx, statuses)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes, which clears the pending resizes.
+	filesystemResults, err := ctx.config.Filesystems.Filesystems(resized)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem information")
+	}
+	filesystems := make([]params.Filesystem, 0, len(resized))
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting information for %s",
+				names.ReadableString(resized[i]),
+			)
+		}
+		filesystem := result.Result
+		filesystem.Info.Size = sizes[resized[i]]
+		filesystems = append(filesystems, filesystem)
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystems)
+	if err != nil {
+		return errors.Annotate(err, "publishing resized filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing size of %s to state",
+				names.ReadableString(resized[i]),
+			)
+		}
+		if info, ok := ctx.filesystems[resized[i]]; ok {
+			info.Size = sizes[resized[i]]
+			ctx.filesystems[resized[i]] = info
+		}
+	}
+	return nil
+}
@@ -27,6 +27,9 @@
 package storageprovisioner
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -56,6 +59,11 @@ type VolumeAccessor interface {
 	// provisioner is responsible for.
 	WatchVolumes(scope names.Tag) (watcher.StringsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, so that pending resizes
+	// may be identified.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// WatchVolumeAttachments watches for changes to volume attachments
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
@@ -86,6 +94,10 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// ResizeVolumeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	ResizeVolumeParams([]names.VolumeTag) ([]params.ResizeVolumeParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -109,6 +121,11 @@ type FilesystemAccessor interface {
 	// storage provisioner is responsible for.
 	WatchFilesystems(scope names.Tag) (watcher.StringsWatcher, error)
 
+	// WatchFilesystemResizes watches for changes to filesystems that
+	// this storage provisioner is responsible for, so that pending
+	// resizes may be identified.
+	WatchFilesystemResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// WatchFilesystemAttachments watches for changes to filesystem attachments
 	// that this storage provisioner is responsible for.
 	WatchFilesystemAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
@@ -128,6 +145,10 @@ type FilesystemAccessor interface {
 	// releasing the filesystems with the specified tags.
 	RemoveFilesystemParams([]names.FilesystemTag) ([]params.RemoveFilesystemParamsResult, error)
 
+	// ResizeFilesystemParams returns the parameters for resizing the
+	// filesystems with the specified tags.
+	ResizeFilesystemParams([]names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error)
+
 	// FilesystemAttachmentParams returns the parameters for creating the
 	// filesystem attachments with the specified tags.
 	FilesystemAttachmentParams([]params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error)
@@ -138,6 +159,10 @@ type FilesystemAccessor interface {
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// SetFilesystemUsage records the space and inode usage of filesystems,
+	// as observed on the machines that they are attached to.
+	SetFilesystemUsage([]params.FilesystemUsageArg) ([]params.ErrorResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -219,10 +244,14 @@ func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		volumeAttachmentPlansChanges watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
+		usageReports                 <-chan time.Time
+		resizeChecks                 <-chan time.Time
 	)
 	machineChanges := make(chan names.MachineTag)
 
@@ -249,6 +278,15 @@ func (w *storageProvisioner) loop() error {
 		volumeAttachmentPlansChanges = volumeAttachmentPlansWatcher.Changes()
 	}
 
+	// Machine-scoped provisioners periodically report the usage of
+	// the filesystems mounted on the machine.
+	var usageTimer clock.Timer
+	if _, ok := w.config.Scope.(names.MachineTag); ok {
+		usageTimer = w.config.Clock.NewTimer(usageReportInterval)
+		defer usageTimer.Stop()
+		usageReports = usageTimer.Chan()
+	}
+
 	ctx := context{
 		kill:                                 w.catacomb.Kill,
 		addWorker:                            w.catacomb.Add,
@@ -266,6 +304,7 @@ func (w *storageProvisioner) loop() error {
 		incompleteFilesystemParams:           make(map[names.FilesystemTag]storage.FilesystemParams),
 		incompleteFilesystemAttachmentParams: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
 		pendingVolumeBlockDevices:            names.NewSet(),
+		pendingVolumeResizes:                 names.NewSet(),
 	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
 		ctx.volumeBlockDevices, ctx.filesystems,
@@ -297,6 +336,30 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemsChanges = filesystemsWatcher.Changes()
 
+	// Resizing is not supported for application-scoped storage, nor by
+	// controllers that predate it; in either case, we don't watch.
+	if !ctx.isApplicationKind() {
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if err != nil && !errors.IsNotSupported(err) {
+			return errors.Annotate(err, "watching volume resizes")
+		} else if err == nil {
+			if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
+
+		filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes(w.config.Scope)
+		if err != nil && !errors.IsNotSupported(err) {
+			return errors.Annotate(err, "watching filesystem resizes")
+		} else if err == nil {
+			if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			filesystemResizesChanges = filesystemResizesWatcher.Changes()
+		}
+	}
+
 	volumeAttachmentsWatcher, err := w.config.Volumes.WatchVolumeAttachments(w.config.Scope)
 	if err != nil {
 		return errors.Annotate(err, "watching volume attachments")
@@ -322,6 +385,10 @@ func (w *storageProvisioner) loop() error {
 			return errors.Annotate(err, "processing pending block devices")
 		}
 
+		if resizeChecks == nil && len(ctx.pendingVolumeResizes) > 0 {
+			resizeChecks = w.config.Clock.After(resizeCheckInterval)
+		}
+
 		select {
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
@@ -353,6 +420,25 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case <-resizeChecks:
+			resizeChecks = nil
+			if err := checkPendingVolumeResizes(&ctx); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-filesystemAttachmentsChanges:
 			if !ok {
 				return errors.New("filesystem attachments watcher closed")
@@ -371,6 +457,15 @@ func (w *storageProvisioner) loop() error {
 			if err := refreshMachine(&ctx, machineTag); err != nil {
 				return errors.Trace(err)
 			}
+		case <-usageReports:
+			if err := reportFilesystemUsage(&ctx); errors.IsNotSupported(err) {
+				w.config.Logger.Debugf("not reporting filesystem usage: %v", err)
+				usageReports = nil
+			} else if err != nil {
+				return errors.Trace(err)
+			} else {
+				usageTimer.Reset(usageReportInterval)
+			}
 		case <-ctx.schedule.Next():
 			// Ready to pick something(s) off the pending queue.
 			if err := processSchedule(&ctx); err != nil {
@@ -527,6 +622,10 @@ type context struct {
 	// block devices we wish to enquire.
 	pendingVolumeBlockDevices names.Set
 
+	// pendingVolumeResizes contains the tags of volumes whose resizes
+	// have been requested but which have not yet grown.
+	pendingVolumeResizes names.Set
+
 	// managedFilesystemSource is a storage.FilesystemSource that
 	// manages filesystems backed by volumes attached to the host
 	// machine.
@@ -610,6 +610,45 @@ func (s *storageProvisionerSuite) TestValidateVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestCreateVolumeFromSnapshotNotSupported(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+
+	// The dummy volume source is not a storage.VolumeSnapshotter.
+	createdVolumes := make(chan interface{}, 1)
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		createdVolumes <- args
+		return nil, errors.New("unexpected call to CreateVolumes")
+	}
+
+	statusSet := make(chan interface{}, 1)
+	args := &workerArgs{
+		volumes:  volumeAccessor,
+		registry: s.registry,
+		statusSetter: &mockStatusSetter{
+			setStatus: func(args []params.EntityStatusArgs) error {
+				statusSet <- args
+				return nil
+			},
+		},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-" + snapshotVolumeId,
+	}}
+	volumeAccessor.volumesWatcher.changes <- []string{snapshotVolumeId}
+	statuses := waitChannel(c, statusSet, "waiting for volume status").([]params.EntityStatusArgs)
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-" + snapshotVolumeId,
+		Status: "error",
+		Info:   `creating volume from snapshot with storage provider "dummy" not supported`,
+	}})
+	assertNoEvent(c, createdVolumes, "volume created")
+}
+
 func (s *storageProvisionerSuite) TestValidateFilesystemParams(c *gc.C) {
 	filesystemAccessor := newMockFilesystemAccessor()
 	filesystemAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
@@ -801,6 +840,168 @@ func (s *storageProvisionerSuite) TestVolumeNonDynamic(c *gc.C) {
 	assertNoEvent(c, volumeInfoSet, "volume info set")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolume(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+	volumeAccessor.provisionedVolumes["volume-1"] = params.Volume{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-123",
+			Size:     1024,
+		},
+	}
+	volumeAccessor.provisionedVolumes["volume-2"] = params.Volume{
+		VolumeTag: "volume-2",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-456",
+			Size:     1024,
+		},
+	}
+	// Only volume-1 has a pending resize.
+	volumeAccessor.pendingResizes["volume-1"] = 2048
+
+	resizeArgs := make(chan []storage.VolumeResizeParams, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeArgs <- args
+		return []storage.ResizeVolumesResult{{Size: 2048}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer worker.Wait()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	select {
+	case args := <-resizeArgs:
+		c.Assert(args, jc.DeepEquals, []storage.VolumeResizeParams{{
+			Volume:   names.NewVolumeTag("1"),
+			VolumeId: "vol-123",
+			Size:     2048,
+		}})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for volumes to be resized")
+	}
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-123",
+			Size:     2048,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumePending(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+	volumeAccessor.provisionedVolumes["volume-1"] = params.Volume{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-123",
+			Size:     1024,
+		},
+	}
+	volumeAccessor.pendingResizes["volume-1"] = 2048
+
+	// The first request is still in progress; the volume
+	// has grown by the time it is checked again.
+	resizeCalls := make(chan struct{}, 2)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeCalls <- struct{}{}
+		if len(resizeCalls) == 1 {
+			return []storage.ResizeVolumesResult{{Pending: true}}, nil
+		}
+		return []storage.ResizeVolumesResult{{Size: 2048}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer worker.Wait()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-123",
+			Size:     2048,
+		},
+	}})
+	c.Assert(resizeCalls, gc.HasLen, 2)
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeErrorSetsStatus(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.pendingResizes["volume-1"] = 2048
+
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		return []storage.ResizeVolumesResult{{Error: errors.New("quota exceeded")}}, nil
+	}
+
+	statusSet := make(chan interface{})
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			statusSet <- args
+			return nil
+		},
+	}
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry, statusSetter: statusSetter}
+	worker := newStorageProvisioner(c, args)
+	defer worker.Wait()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	statuses := waitChannel(c, statusSet, "waiting for status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "error",
+		Info:   "quota exceeded",
+	}})
+	assertNoEvent(c, volumeInfoSet, "volume info set")
+}
+
+func (s *storageProvisionerSuite) TestResizeFilesystemNotSupported(c *gc.C) {
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionFilesystem(names.NewFilesystemTag("1"))
+	filesystemAccessor.pendingResizes["filesystem-1"] = 2048
+
+	statusSet := make(chan interface{})
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			statusSet <- args
+			return nil
+		},
+	}
+	args := &workerArgs{filesystems: filesystemAccessor, registry: s.registry, statusSetter: statusSetter}
+	worker := newStorageProvisioner(c, args)
+	defer worker.Wait()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"1"}
+	statuses := waitChannel(c, statusSet, "waiting for status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "filesystem-1",
+		Status: "error",
+		Info:   `resizing "dummy" filesystems not supported`,
+	}})
+}
+
 func (s *storageProvisionerSuite) TestVolumeAttachmentAdded(c *gc.C) {
 	// We should get two volume attachments:
 	//   - volume-1 to machine-1, because the volume and
@@ -1005,6 +1206,55 @@ func (s *storageProvisionerSuite) TestFilesystemAttachmentAdded(c *gc.C) {
 	assertNoEvent(c, filesystemAttachmentInfoSet, "filesystem attachment info set")
 }
 
+func (s *storageProvisionerSuite) TestHostAttachedFilesystemAttachmentAdded(c *gc.C) {
+	// Model-scoped filesystems attached by the host are not known to the
+	// machine's storage provisioner; it attaches them using the ID from
+	// the attachment parameters.
+	filesystemAttachmentInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(filesystemAttachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		filesystemAttachmentInfoSet <- filesystemAttachments
+		return make([]params.ErrorResult, len(filesystemAttachments)), nil
+	}
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = params.Filesystem{
+		FilesystemTag: "filesystem-1",
+		Info: params.FilesystemInfo{
+			FilesystemId: "fs-123",
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+	filesystemAccessor.attachmentFilesystemIds = true
+
+	registry := storage.StaticProviderRegistry{
+		map[storage.ProviderType]storage.Provider{
+			"dummy": hostAttachingProvider{s.provider},
+		},
+	}
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-0", AttachmentTag: "filesystem-1",
+	}}
+	filesystemAttachments := waitChannel(
+		c, filesystemAttachmentInfoSet,
+		"waiting for filesystem attachments to be set",
+	).([]params.FilesystemAttachment)
+	c.Assert(filesystemAttachments, jc.DeepEquals, []params.FilesystemAttachment{{
+		FilesystemTag: "filesystem-1",
+		MachineTag:    "machine-0",
+		Info: params.FilesystemAttachmentInfo{
+			MountPoint: "/srv/fs-123",
+		},
+	}})
+}
+
 func (s *storageProvisionerSuite) TestCreateVolumeBackedFilesystem(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -1148,6 +1398,83 @@ func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 
 }
 
+func (s *storageProvisionerSuite) TestReportFilesystemUsage(c *gc.C) {
+	s.PatchValue(storageprovisioner.FilesystemUsage, func(path string) (params.FilesystemUsage, error) {
+		c.Check(path, gc.Equals, "/mnt/xvdf1")
+		return params.FilesystemUsage{
+			BytesUsed:  768,
+			BytesFree:  256,
+			InodesUsed: 10,
+			InodesFree: 90,
+		}, nil
+	})
+
+	attachmentInfoSet := make(chan interface{})
+	usageSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(attachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		attachmentInfoSet <- attachments
+		return make([]params.ErrorResult, len(attachments)), nil
+	}
+	filesystemAccessor.setFilesystemUsage = func(usage []params.FilesystemUsageArg) ([]params.ErrorResult, error) {
+		usageSet <- usage
+		return make([]params.ErrorResult, len(usage)), nil
+	}
+
+	timer := &manualTimer{ch: make(chan time.Time)}
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+		clock: &mockClock{
+			onNewTimer: func(d time.Duration) clock.Timer {
+				c.Check(d, gc.Equals, 5*time.Minute)
+				return timer
+			},
+		},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         123,
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       123,
+	}
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag:    "machine-0",
+		AttachmentTag: "filesystem-0-0",
+	}}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+	waitChannel(c, attachmentInfoSet, "waiting for filesystem attachment info to be set")
+	assertNoEvent(c, usageSet, "filesystem usage set")
+
+	timer.ch <- time.Time{}
+	usage := waitChannel(c, usageSet, "waiting for filesystem usage to be set")
+	c.Assert(usage, jc.DeepEquals, []params.FilesystemUsageArg{{
+		FilesystemTag: "filesystem-0-0",
+		MachineTag:    "machine-0",
+		Usage: params.FilesystemUsage{
+			BytesUsed:  768,
+			BytesFree:  256,
+			InodesUsed: 10,
+			InodesFree: 90,
+		},
+	}})
+}
+
 func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
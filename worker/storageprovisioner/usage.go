@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// usageReportInterval is the interval at which a machine-scoped storage
+// provisioner reports the usage of the filesystems mounted on its machine.
+const usageReportInterval = 5 * time.Minute
+
+// filesystemUsage returns the space and inode usage of the filesystem
+// mounted at the specified path. It is a variable so it may be replaced
+// in tests.
+var filesystemUsage = statFilesystemUsage
+
+// reportFilesystemUsage reports the usage of the filesystems that are
+// attached and mounted to the machine that the storage provisioner is
+// scoped to. If usage cannot be observed on this platform, or the
+// controller does not support recording it, an error satisfying
+// errors.IsNotSupported is returned.
+func reportFilesystemUsage(ctx *context) error {
+	machineTag, ok := ctx.config.Scope.(names.MachineTag)
+	if !ok {
+		return errors.NotSupportedf("reporting usage of %s filesystems", names.ReadableString(ctx.config.Scope))
+	}
+	var args []params.FilesystemUsageArg
+	for id, attachment := range ctx.filesystemAttachments {
+		if id.MachineTag != machineTag.String() || attachment.Path == "" {
+			continue
+		}
+		usage, err := filesystemUsage(attachment.Path)
+		if errors.IsNotSupported(err) {
+			return errors.Trace(err)
+		} else if err != nil {
+			ctx.config.Logger.Warningf(
+				"cannot get usage of %s mounted at %q: %v",
+				names.ReadableString(attachment.Filesystem), attachment.Path, err,
+			)
+			continue
+		}
+		args = append(args, params.FilesystemUsageArg{
+			FilesystemTag: attachment.Filesystem.String(),
+			MachineTag:    machineTag.String(),
+			Usage:         usage,
+		})
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	results, err := ctx.config.Filesystems.SetFilesystemUsage(args)
+	if err != nil {
+		return errors.Annotate(err, "reporting filesystem usage")
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.config.Logger.Warningf(
+				"cannot report usage of %s: %v",
+				args[i].FilesystemTag, result.Error,
+			)
+		}
+	}
+	return nil
+}
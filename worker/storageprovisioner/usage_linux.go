@@ -0,0 +1,29 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package storageprovisioner
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func statFilesystemUsage(path string) (params.FilesystemUsage, error) {
+	// Note: do not use golang.org/x/sys/unix for this; see lp:1632541.
+	var statfs syscall.Statfs_t
+	if err := syscall.Statfs(path, &statfs); err != nil {
+		return params.FilesystemUsage{}, errors.Trace(err)
+	}
+	blockSize := uint64(statfs.Bsize)
+	return params.FilesystemUsage{
+		BytesUsed:  (statfs.Blocks - statfs.Bfree) * blockSize,
+		BytesFree:  statfs.Bavail * blockSize,
+		InodesUsed: statfs.Files - statfs.Ffree,
+		InodesFree: statfs.Ffree,
+	}, nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package storageprovisioner
+
+import (
+	"runtime"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func statFilesystemUsage(path string) (params.FilesystemUsage, error) {
+	return params.FilesystemUsage{}, errors.NotSupportedf("filesystem usage on %s", runtime.GOOS)
+}
@@ -468,8 +468,14 @@ func validateVolumeParams(
 ) ([]storage.VolumeParams, []error) {
 	valid := make([]storage.VolumeParams, 0, len(volumeParams))
 	results := make([]error, len(volumeParams))
+	_, canRestore := volumeSource.(storage.VolumeSnapshotter)
 	for i, params := range volumeParams {
-		err := volumeSource.ValidateVolumeParams(params)
+		var err error
+		if params.SnapshotId != "" && !canRestore {
+			err = errors.NotSupportedf("creating volume from snapshot with storage provider %q", params.Provider)
+		} else {
+			err = volumeSource.ValidateVolumeParams(params)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
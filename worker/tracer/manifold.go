@@ -0,0 +1,102 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracer
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/agent"
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/trace"
+	"github.com/juju/juju/core/trace/otlp"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a
+// tracer worker depends, along with its other dependencies.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Clock         clock.Clock
+	Logger        Logger
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency manifold that runs a tracer
+// worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var a agent.Agent
+	if err := context.Get(config.AgentName, &a); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := apiagent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		ConfigGetter:     facade,
+		ServiceName:      a.CurrentConfig().Tag().String(),
+		Clock:            config.Clock,
+		Logger:           config.Logger,
+		FlushInterval:    DefaultFlushInterval,
+		RefreshInterval:  DefaultRefreshInterval,
+		MaxSpans:         DefaultMaxSpans,
+		NewExporter:      NewExporter,
+		SetDefaultTracer: trace.SetDefaultTracer,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// NewExporter returns an OTLP exporter for the specified config.
+func NewExporter(config otlp.Config) (trace.Exporter, error) {
+	exporter, err := otlp.NewExporter(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return exporter, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracer_test
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/worker/tracer"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	manifold dependency.Manifold
+	config   tracer.Config
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.manifold = tracer.Manifold(tracer.ManifoldConfig{
+		AgentName:     "agent",
+		APICallerName: "api-caller",
+		Clock:         clock.WallClock,
+		Logger:        loggo.GetLogger("test"),
+		NewWorker: func(config tracer.Config) (worker.Worker, error) {
+			s.config = config
+			return worker.NewRunner(worker.RunnerParams{}), nil
+		},
+	})
+}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	c.Assert(s.manifold.Inputs, jc.SameContents, []string{
+		"agent",
+		"api-caller",
+	})
+}
+
+func (s *manifoldSuite) TestStartAgentMissing(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent": dependency.ErrMissing,
+	})
+	w, err := s.manifold.Start(context)
+	c.Check(w, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *manifoldSuite) TestStartAPICallerMissing(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &mockAgent{tag: names.NewMachineTag("0")},
+		"api-caller": dependency.ErrMissing,
+	})
+	w, err := s.manifold.Start(context)
+	c.Check(w, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *manifoldSuite) TestStart(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &mockAgent{tag: names.NewMachineTag("0")},
+		"api-caller": basetesting.APICallerFunc(nil),
+	})
+	w, err := s.manifold.Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	defer w.Kill()
+
+	c.Check(s.config.ServiceName, gc.Equals, "machine-0")
+	c.Check(s.config.ConfigGetter, gc.NotNil)
+	c.Check(s.config.FlushInterval, gc.Equals, tracer.DefaultFlushInterval)
+	c.Check(s.config.RefreshInterval, gc.Equals, tracer.DefaultRefreshInterval)
+	c.Check(s.config.MaxSpans, gc.Equals, tracer.DefaultMaxSpans)
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracer_test
+
+import (
+	"sync"
+
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/trace"
+	"github.com/juju/juju/core/trace/otlp"
+)
+
+type stubConfigGetter struct {
+	mu  sync.Mutex
+	cfg controller.Config
+	err error
+}
+
+func (s *stubConfigGetter) ControllerConfig() (controller.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg, s.err
+}
+
+func (s *stubConfigGetter) setConfig(cfg controller.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+}
+
+type fakeExporter struct {
+	config otlp.Config
+	spans  chan []trace.SpanData
+}
+
+func (e *fakeExporter) ExportSpans(spans []trace.SpanData) error {
+	e.spans <- spans
+	return nil
+}
+
+type mockAgent struct {
+	agent.Agent
+	tag names.Tag
+}
+
+func (a *mockAgent) CurrentConfig() agent.Config {
+	return &mockConfig{tag: a.tag}
+}
+
+type mockConfig struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (c *mockConfig) Tag() names.Tag {
+	return c.tag
+}
@@ -0,0 +1,221 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracer provides a worker which records traces of the work
+// done by the agent and exports them to the OpenTelemetry collector
+// named in the controller config.
+package tracer
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/trace"
+	"github.com/juju/juju/core/trace/otlp"
+)
+
+const (
+	// DefaultFlushInterval is how often recorded spans are exported.
+	DefaultFlushInterval = 5 * time.Second
+
+	// DefaultRefreshInterval is how often the controller
+	// config is checked for changes to the tracing settings.
+	DefaultRefreshInterval = 5 * time.Minute
+
+	// DefaultMaxSpans is the number of spans buffered between
+	// exports; spans recorded once the buffer is full are dropped.
+	DefaultMaxSpans = 2048
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// ConfigGetter provides the controller config.
+type ConfigGetter interface {
+	ControllerConfig() (controller.Config, error)
+}
+
+// Config defines the parameters of the tracer worker.
+type Config struct {
+	ConfigGetter     ConfigGetter
+	ServiceName      string
+	Clock            clock.Clock
+	Logger           Logger
+	FlushInterval    time.Duration
+	RefreshInterval  time.Duration
+	MaxSpans         int
+	NewExporter      func(otlp.Config) (trace.Exporter, error)
+	SetDefaultTracer func(trace.Tracer)
+}
+
+// Validate returns an error if config cannot drive a tracer worker.
+func (config Config) Validate() error {
+	if config.ConfigGetter == nil {
+		return errors.NotValidf("nil ConfigGetter")
+	}
+	if config.ServiceName == "" {
+		return errors.NotValidf("empty ServiceName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	if config.RefreshInterval <= 0 {
+		return errors.NotValidf("non-positive RefreshInterval")
+	}
+	if config.MaxSpans <= 0 {
+		return errors.NotValidf("non-positive MaxSpans")
+	}
+	if config.NewExporter == nil {
+		return errors.NotValidf("nil NewExporter")
+	}
+	if config.SetDefaultTracer == nil {
+		return errors.NotValidf("nil SetDefaultTracer")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which, while tracing is enabled in the
+// controller config, installs a tracer as the process default and
+// periodically exports the spans it records.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &tracerWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type tracerWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	endpoint string
+	tracer   *trace.BufferedTracer
+	exporter trace.Exporter
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *tracerWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *tracerWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *tracerWorker) loop() error {
+	defer w.disable()
+	if err := w.refresh(); err != nil {
+		return errors.Trace(err)
+	}
+	flush := w.config.Clock.After(w.config.FlushInterval)
+	refresh := w.config.Clock.After(w.config.RefreshInterval)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-flush:
+			w.flush()
+			flush = w.config.Clock.After(w.config.FlushInterval)
+		case <-refresh:
+			if err := w.refresh(); err != nil {
+				return errors.Trace(err)
+			}
+			refresh = w.config.Clock.After(w.config.RefreshInterval)
+		}
+	}
+}
+
+// refresh reads the controller config and starts, stops
+// or redirects tracing accordingly.
+func (w *tracerWorker) refresh() error {
+	cfg, err := w.config.ConfigGetter.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	endpoint := cfg.OpenTelemetryEndpoint()
+	if !cfg.OpenTelemetryEnabled() || endpoint == "" {
+		if w.tracer != nil {
+			w.config.Logger.Infof("tracing disabled")
+		}
+		w.disable()
+		return nil
+	}
+	if w.tracer != nil && endpoint == w.endpoint {
+		return nil
+	}
+	// Export anything recorded so far to the old
+	// collector before switching to the new one.
+	w.flush()
+	exporter, err := w.config.NewExporter(otlp.Config{
+		Endpoint:    endpoint,
+		ServiceName: w.config.ServiceName,
+	})
+	if err != nil {
+		return errors.Annotate(err, "creating trace exporter")
+	}
+	if w.tracer == nil {
+		w.tracer = trace.NewBufferedTracer(w.config.Clock, w.config.MaxSpans)
+		w.config.SetDefaultTracer(w.tracer)
+	}
+	w.exporter = exporter
+	w.endpoint = endpoint
+	w.config.Logger.Infof("exporting traces to %s", endpoint)
+	return nil
+}
+
+// flush exports the spans recorded since the last flush.
+// Export failures are logged rather than killing the worker;
+// tracing is never allowed to interfere with the agent's work.
+func (w *tracerWorker) flush() {
+	if w.tracer == nil {
+		return
+	}
+	spans, dropped := w.tracer.Drain()
+	if dropped > 0 {
+		w.config.Logger.Warningf("dropped %d spans: buffer full", dropped)
+	}
+	if len(spans) == 0 {
+		return
+	}
+	if err := w.exporter.ExportSpans(spans); err != nil {
+		w.config.Logger.Warningf("exporting %d spans: %v", len(spans), err)
+		return
+	}
+	w.config.Logger.Debugf("exported %d spans", len(spans))
+}
+
+// disable exports any outstanding spans and stops tracing.
+func (w *tracerWorker) disable() {
+	if w.tracer == nil {
+		return
+	}
+	w.config.SetDefaultTracer(nil)
+	w.flush()
+	w.tracer = nil
+	w.exporter = nil
+	w.endpoint = ""
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracer_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/trace"
+	"github.com/juju/juju/core/trace/otlp"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/tracer"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock    *testclock.Clock
+	getter   *stubConfigGetter
+	exporter *fakeExporter
+	tracers  chan trace.Tracer
+	config   tracer.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.getter = &stubConfigGetter{cfg: controller.Config{
+		controller.OpenTelemetryEnabled:  true,
+		controller.OpenTelemetryEndpoint: "http://collector:4318",
+	}}
+	s.exporter = &fakeExporter{spans: make(chan []trace.SpanData, 10)}
+	s.tracers = make(chan trace.Tracer, 10)
+	s.config = tracer.Config{
+		ConfigGetter:    s.getter,
+		ServiceName:     "machine-0",
+		Clock:           s.clock,
+		Logger:          loggo.GetLogger("test"),
+		FlushInterval:   time.Second,
+		RefreshInterval: time.Minute,
+		MaxSpans:        10,
+		NewExporter: func(cfg otlp.Config) (trace.Exporter, error) {
+			s.exporter.config = cfg
+			return s.exporter, nil
+		},
+		SetDefaultTracer: func(t trace.Tracer) {
+			s.tracers <- t
+		},
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*tracer.Config)
+		err    string
+	}{
+		{func(cfg *tracer.Config) { cfg.ConfigGetter = nil }, "nil ConfigGetter not valid"},
+		{func(cfg *tracer.Config) { cfg.ServiceName = "" }, "empty ServiceName not valid"},
+		{func(cfg *tracer.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *tracer.Config) { cfg.Logger = nil }, "nil Logger not valid"},
+		{func(cfg *tracer.Config) { cfg.FlushInterval = 0 }, "non-positive FlushInterval not valid"},
+		{func(cfg *tracer.Config) { cfg.RefreshInterval = 0 }, "non-positive RefreshInterval not valid"},
+		{func(cfg *tracer.Config) { cfg.MaxSpans = 0 }, "non-positive MaxSpans not valid"},
+		{func(cfg *tracer.Config) { cfg.NewExporter = nil }, "nil NewExporter not valid"},
+		{func(cfg *tracer.Config) { cfg.SetDefaultTracer = nil }, "nil SetDefaultTracer not valid"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.err)
+		config := s.config
+		test.mutate(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) TestDisabled(c *gc.C) {
+	s.getter.cfg = controller.Config{}
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+
+	select {
+	case <-s.tracers:
+		c.Fatalf("unexpected tracer installed")
+	default:
+	}
+}
+
+func (s *workerSuite) TestConfigError(c *gc.C) {
+	s.getter.err = errors.New("boom")
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting controller config: boom")
+}
+
+func (s *workerSuite) TestExportsSpans(c *gc.C) {
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	t := s.nextTracer(c)
+	c.Assert(t, gc.NotNil)
+	c.Assert(s.exporter.config, jc.DeepEquals, otlp.Config{
+		Endpoint:    "http://collector:4318",
+		ServiceName: "machine-0",
+	})
+
+	_, span := t.Start(context.Background(), "work")
+	span.End()
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 2), jc.ErrorIsNil)
+
+	spans := s.nextSpans(c)
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].Name, gc.Equals, "work")
+}
+
+func (s *workerSuite) TestKillFlushesAndUninstalls(c *gc.C) {
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	t := s.nextTracer(c)
+	_, span := t.Start(context.Background(), "work")
+	span.End()
+	workertest.CleanKill(c, w)
+
+	c.Assert(s.nextTracer(c), gc.IsNil)
+	spans := s.nextSpans(c)
+	c.Assert(spans, gc.HasLen, 1)
+}
+
+func (s *workerSuite) TestRefreshDisables(c *gc.C) {
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.nextTracer(c), gc.NotNil)
+	s.getter.setConfig(controller.Config{
+		controller.OpenTelemetryEnabled: false,
+	})
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 2), jc.ErrorIsNil)
+	c.Assert(s.nextTracer(c), gc.IsNil)
+}
+
+func (s *workerSuite) nextTracer(c *gc.C) trace.Tracer {
+	select {
+	case t := <-s.tracers:
+		return t
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for tracer to be set")
+	}
+	return nil
+}
+
+func (s *workerSuite) nextSpans(c *gc.C) []trace.SpanData {
+	select {
+	case spans := <-s.exporter.spans:
+		return spans
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for spans to be exported")
+	}
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/juju/charm/v9/hooks"
 	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/secrets"
 )
 
 // TODO(fwereade): move these definitions to juju/charm/hooks.
@@ -16,8 +18,21 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// SecretRotate is run on the leader unit of an application
+	// when a secret it owns is due to be rotated.
+	SecretRotate hooks.Kind = "secret-rotate"
+
+	// StorageResized is run when the volume or filesystem backing
+	// a storage attachment has grown.
+	StorageResized hooks.Kind = "storage-resized"
 )
 
+// IsStorage returns whether the specified hook kind is a storage hook.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -42,9 +57,18 @@ type Info struct {
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
 
+	// StorageSize is the size, in MiB, of the storage reported to
+	// the charm. It is only set when Kind indicates a storage-attached
+	// or storage-resized hook, and the size is known.
+	StorageSize uint64 `yaml:"storage-size,omitempty"`
+
 	// DepartingUnit is the name of the unit that goes away. It is only set
 	// when Kind indicates a relation-departed hook.
 	DepartingUnit string `yaml:"departee,omitempty"`
+
+	// SecretURI is the URI of the secret relevant to the hook. It is only
+	// set when Kind indicates a secret-rotate hook.
+	SecretURI string `yaml:"secret-uri,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -72,7 +96,7 @@ func (hi Info) Validate() error {
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
@@ -80,6 +104,11 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case SecretRotate:
+		if _, err := secrets.ParseURI(hi.SecretURI); err != nil {
+			return fmt.Errorf("invalid secret URI %q", hi.SecretURI)
+		}
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
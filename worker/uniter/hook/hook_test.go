@@ -61,6 +61,10 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.SecretRotate}, `invalid secret URI ""`},
+	{hook.Info{Kind: hook.SecretRotate, SecretURI: "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/a"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
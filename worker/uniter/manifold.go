@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/secretsmanager"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/common/reboot"
 	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/secretrotate"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/resolver"
@@ -126,6 +128,20 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Errorf("expected a unit tag, got %v", tag)
 			}
 			uniterFacade := uniter.NewState(apiConn, unitTag)
+			secretsClient := secretsmanager.NewClient(apiConn)
+			secretRotateWatcherFunc := func(unitTag names.UnitTag, rotateSecrets chan []string) (worker.Worker, error) {
+				appName, err := names.UnitApplication(unitTag.Id())
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				return secretrotate.New(secretrotate.Config{
+					SecretManagerFacade: secretsClient,
+					Clock:               manifoldConfig.Clock,
+					Logger:              config.Logger.Child("secretsrotate"),
+					SecretOwner:         names.NewApplicationTag(appName),
+					RotateSecrets:       rotateSecrets,
+				})
+			}
 			uniter, err := NewUniter(&UniterParams{
 				UniterFacade:                 uniterFacade,
 				UnitTag:                      unitTag,
@@ -143,6 +159,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				TranslateResolverErr:         config.TranslateResolverErr,
 				Clock:                        manifoldConfig.Clock,
 				RebootQuerier:                reboot.NewMonitor(agentConfig.TransientDataDir()),
+				SecretRotateWatcherFunc:      secretRotateWatcherFunc,
+				SecretsClient:                secretsClient,
 				Logger:                       config.Logger,
 				Embedded:                     config.Embedded,
 				EnforcedCharmModifiedVersion: config.EnforcedCharmModifiedVersion,
@@ -4,6 +4,7 @@
 package operation
 
 import (
+	stdcontext "context"
 	"fmt"
 
 	"github.com/juju/charm/v9/hooks"
@@ -12,6 +13,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -47,8 +49,10 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; unit: %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case rh.info.Kind == hook.SecretRotate:
+		suffix = fmt.Sprintf(" (%s)", rh.info.SecretURI)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
@@ -100,7 +104,17 @@ func RunningHookMessage(hookName string) string {
 
 // Execute runs the hook.
 // Execute is part of the Operation interface.
-func (rh *runHook) Execute(state State) (*State, error) {
+func (rh *runHook) Execute(state State) (_ *State, err error) {
+	ctx, span := trace.Start(stdcontext.Background(), "uniter.RunHook",
+		trace.StringAttr("juju.hook", rh.name),
+		trace.StringAttr("juju.unit", rh.runner.Context().UnitName()),
+	)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+	logger := trace.WrapLogger(ctx, rh.logger)
+
 	message := RunningHookMessage(rh.name)
 	if err := rh.beforeHook(state); err != nil {
 		return nil, err
@@ -133,16 +147,16 @@ func (rh *runHook) Execute(state State) (*State, error) {
 		err = ErrNeedsReboot
 	case err == nil:
 	default:
-		rh.logger.Errorf("hook %q (via %s) failed: %v", rh.name, handlerType, err)
+		logger.Errorf("hook %q (via %s) failed: %v", rh.name, handlerType, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
 
 	if rh.hookFound {
-		rh.logger.Infof("ran %q hook (via %s)", rh.name, handlerType)
+		logger.Infof("ran %q hook (via %s)", rh.name, handlerType)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
-		rh.logger.Infof("skipped %q hook (missing)", rh.name)
+		logger.Infof("skipped %q hook (missing)", rh.name)
 	}
 
 	var hasRunStatusSet bool
@@ -4,13 +4,18 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/charm/v9/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -279,6 +284,24 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteLogsTraceLabels(c *gc.C) {
+	trace.SetDefaultTracer(trace.NewBufferedTracer(testclock.NewClock(time.Time{}), 10))
+	defer trace.SetDefaultTracer(nil)
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("runhook-test", &tw), jc.ErrorIsNil)
+
+	op, _, _ := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, nil)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(tw.Log(), jc.LogMatches, jc.SimpleMessages{{
+		Level:   loggo.INFO,
+		Message: `\[labels trace-id=[0-9a-f]{32} span-id=[0-9a-f]{16}\] ran "some-hook-name" hook .*`,
+	}})
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
@@ -103,6 +103,9 @@ type Snapshot struct {
 
 	// CharmProfileRequired is true if the charm has a lxdprofile.yaml.
 	CharmProfileRequired bool
+
+	// SecretRotations is a list of secret URIs that need to be rotated.
+	SecretRotations []string
 }
 
 // RelationSnapshot tracks the state of a relationship from the viewpoint of the local unit.
@@ -128,4 +131,5 @@ type StorageSnapshot struct {
 	Life     life.Value
 	Attached bool
 	Location string
+	Size     uint64
 }
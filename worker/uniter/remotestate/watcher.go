@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -56,6 +57,10 @@ type RemoteStateWatcher struct {
 	containerRunningStatusFunc    ContainerRunningStatusFunc
 	canApplyCharmProfile          bool
 
+	secretRotateWatcherFunc SecretRotateWatcherFunc
+	secretRotateWatcher     worker.Worker
+	rotateSecretsChanges    chan []string
+
 	catacomb catacomb.Catacomb
 
 	out     chan struct{}
@@ -75,6 +80,10 @@ type ContainerRunningStatus struct {
 // model to determine if the unit is running and ready to execute actions.
 type ContainerRunningStatusFunc func(providerID string) (*ContainerRunningStatus, error)
 
+// SecretRotateWatcherFunc returns a watcher that triggers when secrets
+// owned by a unit's application should be rotated.
+type SecretRotateWatcherFunc func(names.UnitTag, chan []string) (worker.Worker, error)
+
 // WatcherConfig holds configuration parameters for the
 // remote state watcher.
 type WatcherConfig struct {
@@ -86,6 +95,7 @@ type WatcherConfig struct {
 	ApplicationChannel            watcher.NotifyChannel
 	ContainerRunningStatusChannel watcher.NotifyChannel
 	ContainerRunningStatusFunc    ContainerRunningStatusFunc
+	SecretRotateWatcherFunc       SecretRotateWatcherFunc
 	UnitTag                       names.UnitTag
 	ModelType                     model.ModelType
 	Embedded                      bool
@@ -133,6 +143,8 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		applicationChannel:            config.ApplicationChannel,
 		containerRunningStatusChannel: config.ContainerRunningStatusChannel,
 		containerRunningStatusFunc:    config.ContainerRunningStatusFunc,
+		secretRotateWatcherFunc:       config.SecretRotateWatcherFunc,
+		rotateSecretsChanges:          make(chan []string),
 		modelType:                     config.ModelType,
 		logger:                        config.Logger,
 		canApplyCharmProfile:          config.CanApplyCharmProfile,
@@ -204,6 +216,8 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.ActionsPending, w.current.ActionsPending)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	snapshot.SecretRotations = make([]string, len(w.current.SecretRotations))
+	copy(snapshot.SecretRotations, w.current.SecretRotations)
 	snapshot.ActionChanged = make(map[string]int)
 	for k, v := range w.current.ActionChanged {
 		snapshot.ActionChanged[k] = v
@@ -451,7 +465,9 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		return w.catacomb.ErrDying()
 	case <-claimLeader.Ready():
 		isLeader := claimLeader.Wait()
-		w.leadershipChanged(isLeader)
+		if err := w.leadershipChanged(isLeader); err != nil {
+			return errors.Trace(err)
+		}
 		if isLeader {
 			waitMinion = w.leadershipTracker.WaitMinion().Ready()
 		} else {
@@ -626,13 +642,17 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 
 		case <-waitMinion:
 			w.logger.Debugf("got leadership change for %v: minion", unitTag.Id())
-			w.leadershipChanged(false)
+			if err := w.leadershipChanged(false); err != nil {
+				return errors.Trace(err)
+			}
 			waitMinion = nil
 			waitLeader = w.leadershipTracker.WaitLeader().Ready()
 
 		case <-waitLeader:
 			w.logger.Debugf("got leadership change for %v: leader", unitTag.Id())
-			w.leadershipChanged(true)
+			if err := w.leadershipChanged(true); err != nil {
+				return errors.Trace(err)
+			}
 			waitLeader = nil
 			waitMinion = w.leadershipTracker.WaitMinion().Ready()
 
@@ -646,6 +666,13 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return errors.Trace(err)
 			}
 
+		case uris, ok := <-w.rotateSecretsChanges:
+			if !ok {
+				return errors.New("secret rotation watcher closed")
+			}
+			w.logger.Debugf("secrets to rotate for %s: %v", w.unit.Tag().Id(), uris)
+			w.rotateSecretsChanged(uris)
+
 		case <-updateStatusTimer:
 			w.logger.Debugf("update status timer triggered for %s", w.unit.Tag().Id())
 			w.updateStatusChanged()
@@ -814,10 +841,65 @@ func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	return nil
 }
 
-func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) {
+func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.current.Leader = isLeader
-	w.mu.Unlock()
+
+	if w.secretRotateWatcherFunc == nil {
+		return nil
+	}
+	// Only the leader unit rotates the application's secrets.
+	if !isLeader {
+		if w.secretRotateWatcher != nil {
+			w.secretRotateWatcher.Kill()
+			w.secretRotateWatcher = nil
+		}
+		w.current.SecretRotations = nil
+		return nil
+	}
+	if w.secretRotateWatcher != nil {
+		return nil
+	}
+	rotateWatcher, err := w.secretRotateWatcherFunc(w.unit.Tag(), w.rotateSecretsChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(rotateWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	w.secretRotateWatcher = rotateWatcher
+	return nil
+}
+
+// rotateSecretsChanged is called when there are secrets to be rotated.
+func (w *RemoteStateWatcher) rotateSecretsChanged(uris []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	pending := set.NewStrings(w.current.SecretRotations...)
+	for _, uri := range uris {
+		if !pending.Contains(uri) {
+			pending.Add(uri)
+			w.current.SecretRotations = append(w.current.SecretRotations, uri)
+		}
+	}
+}
+
+// RotateSecretCompleted is called when a secret rotation hook has
+// been successfully run.
+func (w *RemoteStateWatcher) RotateSecretCompleted(rotatedURI string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, uri := range w.current.SecretRotations {
+		if uri != rotatedURI {
+			continue
+		}
+		w.current.SecretRotations = append(
+			w.current.SecretRotations[:i],
+			w.current.SecretRotations[i+1:]...,
+		)
+		break
+	}
 }
 
 // relationsChanged responds to application relation changes.
@@ -11,6 +11,8 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
@@ -35,6 +37,9 @@ type WatcherSuite struct {
 	applicationWatcher   *mockNotifyWatcher
 	runningStatusWatcher *mockNotifyWatcher
 	running              *remotestate.ContainerRunningStatus
+
+	rotateSecretWatcherEvent chan string
+	rotateSecretsChanges     chan []string
 }
 
 type WatcherSuiteIAAS struct {
@@ -118,6 +123,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.clock = testclock.NewClock(time.Now())
+	s.rotateSecretWatcherEvent = make(chan string, 1)
 }
 
 func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
@@ -178,6 +184,11 @@ func (s *WatcherSuite) setupWatcherConfig() remotestate.WatcherConfig {
 		UnitTag:                      s.st.unit.tag,
 		UpdateStatusChannel:          statusTicker,
 		CanApplyCharmProfile:         s.modelType == model.IAAS,
+		SecretRotateWatcherFunc: func(u names.UnitTag, secretsChanged chan []string) (worker.Worker, error) {
+			s.rotateSecretsChanges = secretsChanged
+			s.rotateSecretWatcherEvent <- u.Id()
+			return workertest.NewErrorWorker(nil), nil
+		},
 	}
 }
 
@@ -543,6 +554,40 @@ func (s *WatcherSuite) TestLeadershipLeaderUnchanged(c *gc.C) {
 	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
 }
 
+func (s *WatcherSuite) TestRotateSecretsSignal(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	select {
+	case u := <-s.rotateSecretWatcherEvent:
+		c.Assert(u, gc.Equals, "mysql/0")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for secret rotation watcher to start")
+	}
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+
+	s.rotateSecretsChanges <- []string{"secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/password", "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/user"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{
+		"secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/password", "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/user",
+	})
+
+	s.watcher.RotateSecretCompleted("secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/password")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{
+		"secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/user",
+	})
+}
+
+func (s *WatcherSuite) TestRotateSecretsNotLeader(c *gc.C) {
+	s.leadership.claimTicket.result = false
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	select {
+	case <-s.rotateSecretWatcherEvent:
+		c.Fatalf("secret rotation watcher started for minion")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 	s.signalAll()
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -37,6 +37,7 @@ type ResolverConfig struct {
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
 	Container           resolver.Resolver
+	Secrets             resolver.Resolver
 	Logger              Logger
 }
 
@@ -121,6 +122,13 @@ func (s *uniterResolver) NextOp(
 		}
 	}
 
+	if s.config.Secrets != nil {
+		op, err = s.config.Secrets.NextOp(localState, remoteState, opFactory)
+		if errors.Cause(err) != resolver.ErrNoOperation {
+			return op, err
+		}
+	}
+
 	op, err = s.config.Actions.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
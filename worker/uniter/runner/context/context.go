@@ -127,6 +127,7 @@ type HookUnit interface {
 	ApplicationName() string
 	ConfigSettings() (charm.Settings, error)
 	LogActionMessage(names.ActionTag, string) error
+	LogActionOutput(names.ActionTag, string, string) error
 	Name() string
 	NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error)
 	RequestReboot() error
@@ -251,6 +252,12 @@ type HookContext struct {
 	// storage provides access to the information about storage attached to the unit.
 	storage StorageContextAccessor
 
+	// secretsClient allows the context to access the secrets backend.
+	secretsClient SecretsAccessor
+
+	// secretURI is the reference to the secret relevant to the hook.
+	secretURI string
+
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
@@ -824,6 +831,17 @@ func (ctx *HookContext) LogActionMessage(message string) error {
 	return ctx.unit.LogActionMessage(ctx.actionData.Tag, message)
 }
 
+// LogActionOutput logs output written by the Action to stdout or stderr.
+// Implements runner.Context.
+func (ctx *HookContext) LogActionOutput(stream, output string) error {
+	ctx.actionDataMu.Lock()
+	defer ctx.actionDataMu.Unlock()
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.unit.LogActionOutput(ctx.actionData.Tag, stream, output)
+}
+
 // SetActionMessage sets a message for the Action, usually an error message.
 // Implements jujuc.ActionHookContext.actionHookContext, part of runner.Context.
 func (ctx *HookContext) SetActionMessage(message string) error {
@@ -999,6 +1017,11 @@ func (ctx *HookContext) HookVars(paths Paths, remote bool, getEnv GetEnvFunc) ([
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if ctx.secretURI != "" {
+		vars = append(vars,
+			"JUJU_SECRET_URI="+ctx.secretURI,
+		)
+	}
 	if ctx.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+ctx.actionData.Name,
@@ -401,6 +401,27 @@ func (s *InterfaceSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Message(), gc.Equals, "hello world")
 }
 
+// TestLogActionOutput ensures LogActionOutput works properly.
+func (s *InterfaceSuite) TestLogActionOutput(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.unit.AddAction(operationID, "fakeaction", nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	hctx := s.getHookContext(c, s.State.ModelUUID(), -1, "")
+	context.WithActionContext(hctx, nil, nil)
+	err = hctx.LogActionOutput("stderr", "hello world\n")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.Model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	output := a.Output()
+	c.Assert(output, gc.HasLen, 1)
+	c.Assert(output[0].Stream(), gc.Equals, "stderr")
+	c.Assert(output[0].Message(), gc.Equals, "hello world\n")
+}
+
 func (s *InterfaceSuite) TestRequestRebootAfterHook(c *gc.C) {
 	var killed bool
 	p := &mockProcess{func() error {
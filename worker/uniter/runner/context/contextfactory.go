@@ -78,6 +78,7 @@ type contextFactory struct {
 	modelType  model.ModelType
 	machineTag names.MachineTag
 	storage    StorageContextAccessor
+	secrets    SecretsAccessor
 	clock      Clock
 	zone       string
 	principal  string
@@ -98,6 +99,7 @@ type FactoryConfig struct {
 	Tracker          leadership.Tracker
 	GetRelationInfos RelationsFunc
 	Storage          StorageContextAccessor
+	SecretsClient    SecretsAccessor
 	Paths            Paths
 	Clock            Clock
 	Logger           loggo.Logger
@@ -144,6 +146,7 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		getRelationInfos: config.GetRelationInfos,
 		relationCaches:   map[int]*RelationCache{},
 		storage:          config.Storage,
+		secrets:          config.SecretsClient,
 		rand:             rand.New(rand.NewSource(time.Now().Unix())),
 		clock:            config.Clock,
 		zone:             zone,
@@ -178,6 +181,7 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		relations:          f.getContextRelations(),
 		relationId:         -1,
 		storage:            f.storage,
+		secretsClient:      f.secrets,
 		clock:              f.clock,
 		logger:             f.logger,
 		componentDir:       f.paths.ComponentDir,
@@ -231,7 +235,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
 			return nil, errors.Annotatef(err, "could not retrieve storage for id: %v", hookInfo.StorageId)
@@ -242,6 +246,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.SecretRotate {
+		ctx.secretURI = hookInfo.SecretURI
+	}
 	ctx.id = f.newId(hookName)
 	ctx.hookName = hookName
 	return ctx, nil
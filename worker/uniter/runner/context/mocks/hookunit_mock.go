@@ -110,6 +110,20 @@ func (mr *MockHookUnitMockRecorder) LogActionMessage(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActionMessage", reflect.TypeOf((*MockHookUnit)(nil).LogActionMessage), arg0, arg1)
 }
 
+// LogActionOutput mocks base method
+func (m *MockHookUnit) LogActionOutput(arg0 names.ActionTag, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogActionOutput", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogActionOutput indicates an expected call of LogActionOutput
+func (mr *MockHookUnitMockRecorder) LogActionOutput(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActionOutput", reflect.TypeOf((*MockHookUnit)(nil).LogActionOutput), arg0, arg1, arg2)
+}
+
 // Name mocks base method
 func (m *MockHookUnit) Name() string {
 	m.ctrl.T.Helper()
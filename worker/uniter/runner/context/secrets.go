@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/secretsmanager"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// SecretsAccessor is used by the hook context to access the secrets backend.
+type SecretsAccessor interface {
+	Create(ownerTag names.ApplicationTag, cfg *secrets.SecretConfig, value secrets.SecretValue) (string, error)
+	Update(uri string, cfg *secrets.SecretConfig, value secrets.SecretValue) error
+	GetValue(uri string, revision int) (secrets.SecretValue, error)
+	Grant(uri string, p *secretsmanager.SecretRevokeGrantArgs) error
+	Revoke(uri string, p *secretsmanager.SecretRevokeGrantArgs) error
+}
+
+// GetSecret returns the value of the specified secret revision.
+// Implements jujuc.ContextSecrets.
+func (ctx *HookContext) GetSecret(uri string, revision int) (secrets.SecretValue, error) {
+	if ctx.secretsClient == nil {
+		return nil, errors.NotSupportedf("secrets")
+	}
+	return ctx.secretsClient.GetValue(uri, revision)
+}
+
+// CreateSecret creates a secret owned by the unit's application.
+// Implements jujuc.ContextSecrets.
+func (ctx *HookContext) CreateSecret(args *jujuc.SecretUpsertArgs) (string, error) {
+	if ctx.secretsClient == nil {
+		return "", errors.NotSupportedf("secrets")
+	}
+	if err := ctx.ensureLeader(); err != nil {
+		return "", errors.Trace(err)
+	}
+	ownerTag := names.NewApplicationTag(ctx.unit.ApplicationName())
+	return ctx.secretsClient.Create(ownerTag, secretConfig(args), args.Value)
+}
+
+// UpdateSecret creates a new revision of a secret and/or updates its metadata.
+// Implements jujuc.ContextSecrets.
+func (ctx *HookContext) UpdateSecret(uri string, args *jujuc.SecretUpsertArgs) error {
+	if ctx.secretsClient == nil {
+		return errors.NotSupportedf("secrets")
+	}
+	if err := ctx.ensureLeader(); err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.secretsClient.Update(uri, secretConfig(args), args.Value)
+}
+
+// GrantSecret grants access to the specified secret.
+// Implements jujuc.ContextSecrets.
+func (ctx *HookContext) GrantSecret(uri string, args *jujuc.SecretGrantRevokeArgs) error {
+	if ctx.secretsClient == nil {
+		return errors.NotSupportedf("secrets")
+	}
+	grantArgs, err := ctx.secretGrantRevokeArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.secretsClient.Grant(uri, grantArgs)
+}
+
+// RevokeSecret revokes access to the specified secret.
+// Implements jujuc.ContextSecrets.
+func (ctx *HookContext) RevokeSecret(uri string, args *jujuc.SecretGrantRevokeArgs) error {
+	if ctx.secretsClient == nil {
+		return errors.NotSupportedf("secrets")
+	}
+	revokeArgs, err := ctx.secretGrantRevokeArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.secretsClient.Revoke(uri, revokeArgs)
+}
+
+func (ctx *HookContext) ensureLeader() error {
+	isLeader, err := ctx.IsLeader()
+	if err != nil {
+		return errors.Annotatef(err, "cannot determine leadership")
+	}
+	if !isLeader {
+		return ErrIsNotLeader
+	}
+	return nil
+}
+
+func (ctx *HookContext) secretGrantRevokeArgs(args *jujuc.SecretGrantRevokeArgs) (*secretsmanager.SecretRevokeGrantArgs, error) {
+	if args.RelationId == nil {
+		return nil, errors.NotValidf("missing relation id")
+	}
+	rel, found := ctx.relations[*args.RelationId]
+	if !found {
+		return nil, errors.NotFoundf("relation %d", *args.RelationId)
+	}
+	appName := rel.RemoteApplicationName()
+	result := &secretsmanager.SecretRevokeGrantArgs{
+		ScopeTag:        rel.RelationTag(),
+		ApplicationName: &appName,
+		Role:            secrets.RoleView,
+	}
+	if args.UnitName != nil {
+		if unitApp, _ := names.UnitApplication(*args.UnitName); unitApp != appName {
+			return nil, errors.NotValidf("unit %q not in relation %d", *args.UnitName, *args.RelationId)
+		}
+		result.ApplicationName = nil
+		result.UnitName = args.UnitName
+	}
+	if args.Role != nil {
+		result.Role = *args.Role
+	}
+	return result, nil
+}
+
+func secretConfig(args *jujuc.SecretUpsertArgs) *secrets.SecretConfig {
+	if args.RotateInterval == nil && args.Description == nil {
+		return nil
+	}
+	return &secrets.SecretConfig{
+		RotateInterval: args.RotateInterval,
+		Description:    args.Description,
+	}
+}
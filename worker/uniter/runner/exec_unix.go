@@ -0,0 +1,91 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	utilexec "github.com/juju/utils/v2/exec"
+)
+
+// killWaitTime is how long to wait for commands
+// to exit once they have been killed.
+const killWaitTime = 30 * time.Second
+
+// streamOnMachine executes commands on the current machine as
+// execOnMachine does, also copying their output to params.Stdout
+// and params.Stderr as it is written.
+func streamOnMachine(params ExecParams) (*utilexec.ExecResponse, error) {
+	tempDir, err := ioutil.TempDir("", "juju-exec")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	script := filepath.Join(tempDir, "script.sh")
+	if err := ioutil.WriteFile(script, []byte(params.Commands[0]), 0644); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	ps := exec.Command("/bin/bash", script)
+	ps.Env = append(os.Environ(), params.Env...)
+	ps.Dir = params.WorkingDir
+	ps.Stdout = io.MultiWriter(&stdout, params.Stdout)
+	ps.Stderr = io.MultiWriter(&stderr, params.Stderr)
+	// Run the commands in their own process group,
+	// so they can all be killed if cancelled.
+	ps.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	params.ProcessSetter(hookProcess{ps.Process})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	response := func(err error) (*utilexec.ExecResponse, error) {
+		resp := &utilexec.ExecResponse{
+			Stdout: stdout.Bytes(),
+			Stderr: stderr.Bytes(),
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.Exited() {
+			// A non-zero return code isn't considered an error here.
+			resp.Code = exitErr.ExitCode()
+			err = nil
+		}
+		return resp, errors.Trace(err)
+	}
+
+	select {
+	case err := <-done:
+		return response(err)
+	case <-params.Cancel:
+	}
+	// As with execOnMachine, we wait for the commands to exit
+	// whether or not they could be killed.
+	_ = utilexec.KillProcess(ps.Process)
+	waitClock := params.Clock
+	if waitClock == nil {
+		waitClock = clock.WallClock
+	}
+	select {
+	case err := <-done:
+		resp, _ := response(err)
+		return resp, utilexec.ErrCancelled
+	case <-waitClock.After(killWaitTime):
+		return nil, errors.Errorf("tried to kill process %v, but timed out", ps.Process.Pid)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build windows
+
+package runner
+
+import (
+	utilexec "github.com/juju/utils/v2/exec"
+)
+
+// streamOnMachine executes commands on the current machine. Output
+// isn't streamed on Windows; it is only available once the commands
+// have finished.
+func streamOnMachine(params ExecParams) (*utilexec.ExecResponse, error) {
+	return execOnMachine(params)
+}
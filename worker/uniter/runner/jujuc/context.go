@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/storage"
 )
 
@@ -44,6 +45,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -143,6 +145,52 @@ type ContextUnit interface {
 	CloudSpec() (*params.CloudSpec, error)
 }
 
+// SecretUpsertArgs specifies args used to create or update a secret.
+// Nil values are not included in the update.
+type SecretUpsertArgs struct {
+	// Value is the new secret value or nil to not update.
+	Value secrets.SecretValue
+
+	// RotateInterval is the new rotate interval or nil to not update.
+	RotateInterval *time.Duration
+
+	// Description describes the secret or nil to not update.
+	Description *string
+}
+
+// SecretGrantRevokeArgs specify the args used to grant or revoke access to a secret.
+type SecretGrantRevokeArgs struct {
+	// RelationId is the relation whose remote application
+	// is granted or loses access.
+	RelationId *int
+
+	// UnitName, if set, restricts the access change to a single remote unit.
+	UnitName *string
+
+	// Role is the access role being granted.
+	Role *secrets.SecretRole
+}
+
+// ContextSecrets is the part of a hook context related to secrets.
+type ContextSecrets interface {
+	// GetSecret returns the value of the specified secret revision.
+	// A revision of zero means the latest revision.
+	GetSecret(uri string, revision int) (secrets.SecretValue, error)
+
+	// CreateSecret creates a secret with the specified data.
+	CreateSecret(args *SecretUpsertArgs) (string, error)
+
+	// UpdateSecret creates a new revision of a secret and/or updates
+	// its metadata.
+	UpdateSecret(uri string, args *SecretUpsertArgs) error
+
+	// GrantSecret grants access to the specified secret.
+	GrantSecret(uri string, args *SecretGrantRevokeArgs) error
+
+	// RevokeSecret revokes access to the specified secret.
+	RevokeSecret(uri string, args *SecretGrantRevokeArgs) error
+}
+
 // ContextStatus is the part of a hook context related to the unit's status.
 type ContextStatus interface {
 	// UnitStatus returns the executing unit's current status.
@@ -0,0 +1,58 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	SecretValue secrets.SecretValue
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(uri string, revision int) (secrets.SecretValue, error) {
+	c.stub.AddCall("GetSecret", uri, revision)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.SecretValue, nil
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(args *jujuc.SecretUpsertArgs) (string, error) {
+	c.stub.AddCall("CreateSecret", args)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return "secret://model-uuid/id", nil
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) UpdateSecret(uri string, args *jujuc.SecretUpsertArgs) error {
+	c.stub.AddCall("UpdateSecret", uri, args)
+	return c.stub.NextErr()
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(uri string, args *jujuc.SecretGrantRevokeArgs) error {
+	c.stub.AddCall("GrantSecret", uri, args)
+	return c.stub.NextErr()
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(uri string, args *jujuc.SecretGrantRevokeArgs) error {
+	c.stub.AddCall("RevokeSecret", uri, args)
+	return c.stub.NextErr()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/secrets"
 )
 
 // ErrRestrictedContext indicates a method is not implemented in the given context.
@@ -215,3 +216,28 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// GetSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) GetSecret(string, int) (secrets.SecretValue, error) {
+	return nil, ErrRestrictedContext
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) CreateSecret(*SecretUpsertArgs) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) UpdateSecret(string, *SecretUpsertArgs) error {
+	return ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) GrantSecret(string, *SecretGrantRevokeArgs) error {
+	return ErrRestrictedContext
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) RevokeSecret(string, *SecretGrantRevokeArgs) error {
+	return ErrRestrictedContext
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretUpsertCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	rotateInterval time.Duration
+	description    string
+	data           map[string]string
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretUpsertCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.DurationVar(&c.rotateInterval, "rotate", 0, "how often the secret should be rotated")
+	f.StringVar(&c.description, "description", "", "the secret description")
+}
+
+// Init implements cmd.Command.
+func (c *secretUpsertCommand) Init(args []string) error {
+	if c.rotateInterval < 0 {
+		return errors.NotValidf("rotate interval %q", c.rotateInterval)
+	}
+	if c.rotateInterval > 0 && c.rotateInterval < secrets.MinRotateInterval {
+		return errors.NotValidf("rotate interval %q less than %v", c.rotateInterval, secrets.MinRotateInterval)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	var err error
+	c.data, err = secrets.CreateSecretData(args)
+	return errors.Trace(err)
+}
+
+func (c *secretUpsertCommand) marshallArg() *SecretUpsertArgs {
+	arg := &SecretUpsertArgs{}
+	if len(c.data) > 0 {
+		arg.Value = secrets.NewSecretValue(c.data)
+	}
+	if c.rotateInterval > 0 {
+		arg.RotateInterval = &c.rotateInterval
+	}
+	if c.description != "" {
+		arg.Description = &c.description
+	}
+	return arg
+}
+
+type secretAddCommand struct {
+	secretUpsertCommand
+}
+
+// NewSecretAddCommand returns a command to add a secret.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{
+		secretUpsertCommand{ctx: ctx},
+	}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+Add a secret with a list of key values.
+
+If a key has the '#base64' suffix, the value is already in base64 format and no
+encoding will be performed, otherwise the value will be base64 encoded
+prior to being stored.
+
+The secret is owned by the application of the calling unit, and only the
+leader unit may create it. The secret URI is printed on success.
+
+Examples:
+    secret-add token=34ae35facd4
+    secret-add key#base64=AA==
+    secret-add --rotate 24h --description "my database password" password=s3cret
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-add",
+		Args:    "[key[#base64]=value...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *secretAddCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret value")
+	}
+	return c.secretUpsertCommand.Init(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	uri, err := c.ctx.CreateSecret(c.marshallArg())
+	if err != nil {
+		return err
+	}
+	_, _ = ctx.Stdout.Write([]byte(uri + "\n"))
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+
+	uri      string
+	key      string
+	revision int
+}
+
+// NewSecretGetCommand returns a command to get a secret value.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+Get the value of a secret with a given secret URI.
+
+By default, the latest revision of the secret is returned. Use --revision
+to get a specific revision.
+
+If a key is specified, only the value for that key is printed. If the key
+has the '#base64' suffix, the value is printed in base64 encoded form.
+
+Examples:
+    secret-get secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a
+    secret-get secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a token
+    secret-get secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a --revision 2
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-get",
+		Args:    "<uri> [key[#base64]]",
+		Purpose: "get the value of a secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.IntVar(&c.revision, "revision", 0, "the secret revision to get (defaults to the latest)")
+}
+
+// Init implements cmd.Command.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if _, err := secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.uri = args[0]
+	if c.revision < 0 {
+		return errors.NotValidf("revision %d", c.revision)
+	}
+	args = args[1:]
+	if len(args) > 0 {
+		c.key = args[0]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.GetSecret(c.uri, c.revision)
+	if err != nil {
+		return err
+	}
+	if c.key == "" {
+		values, err := value.Values()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, values)
+	}
+	if strings.HasSuffix(c.key, "#base64") {
+		key := strings.TrimSuffix(c.key, "#base64")
+		val, ok := value.EncodedValues()[key]
+		if !ok {
+			return errors.NotFoundf("secret key %q", key)
+		}
+		return c.out.Write(ctx, val)
+	}
+	val, err := value.KeyValue(c.key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, val)
+}
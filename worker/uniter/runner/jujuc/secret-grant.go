@@ -0,0 +1,168 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretGrantRevokeCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	uri  string
+	unit string
+	role string
+
+	relationId      int
+	relationIdProxy gnuflag.Value
+}
+
+func newSecretGrantRevokeCommand(ctx Context) (*secretGrantRevokeCommand, error) {
+	cmd := &secretGrantRevokeCommand{ctx: ctx}
+	rV, err := NewRelationIdValue(ctx, &cmd.relationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cmd.relationIdProxy = rV
+	return cmd, nil
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretGrantRevokeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "the relation whose remote application is affected")
+	f.Var(c.relationIdProxy, "relation", "")
+	f.StringVar(&c.unit, "unit", "", "restrict the change to a specific remote unit")
+}
+
+// Init implements cmd.Command.
+func (c *secretGrantRevokeCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if _, err := secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.uri = args[0]
+	if c.relationId == -1 {
+		return errors.Errorf("no relation id specified")
+	}
+	if c.unit != "" && !names.IsValidUnit(c.unit) {
+		return errors.NotValidf("unit %q", c.unit)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *secretGrantRevokeCommand) marshallArg() *SecretGrantRevokeArgs {
+	args := &SecretGrantRevokeArgs{
+		RelationId: &c.relationId,
+	}
+	if c.unit != "" {
+		args.UnitName = &c.unit
+	}
+	if c.role != "" {
+		role := secrets.SecretRole(c.role)
+		args.Role = &role
+	}
+	return args
+}
+
+type secretGrantCommand struct {
+	*secretGrantRevokeCommand
+}
+
+// NewSecretGrantCommand returns a command to grant access to a secret.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	base, err := newSecretGrantRevokeCommand(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &secretGrantCommand{base}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+Grant access to a secret to the remote application on the specified
+relation, or to just one of its units if --unit is given.
+
+The access is bound to the lifetime of the relation; when the relation
+is removed, the access is revoked.
+
+Examples:
+    secret-grant secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a -r 0
+    secret-grant secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a -r db:2 --unit mediawiki/6
+    secret-grant secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a -r 0 --role manage
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<uri>",
+		Purpose: "grant access to a secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretGrantRevokeCommand.SetFlags(f)
+	f.StringVar(&c.role, "role", string(secrets.RoleView), "the access role to grant (view or manage)")
+}
+
+// Init implements cmd.Command.
+func (c *secretGrantCommand) Init(args []string) error {
+	if err := c.secretGrantRevokeCommand.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	if role := secrets.SecretRole(c.role); !role.IsValid() || role == secrets.RoleNone {
+		return errors.NotValidf("secret role %q", c.role)
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	return c.ctx.GrantSecret(c.uri, c.marshallArg())
+}
+
+type secretRevokeCommand struct {
+	*secretGrantRevokeCommand
+}
+
+// NewSecretRevokeCommand returns a command to revoke access to a secret.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	base, err := newSecretGrantRevokeCommand(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &secretRevokeCommand{base}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretRevokeCommand) Info() *cmd.Info {
+	doc := `
+Revoke access to a secret from the remote application on the specified
+relation, or from just one of its units if --unit is given.
+
+Examples:
+    secret-revoke secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a -r 0
+    secret-revoke secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a -r db:2 --unit mediawiki/6
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-revoke",
+		Args:    "<uri>",
+		Purpose: "revoke access to a secret",
+		Doc:     doc,
+	})
+}
+
+// Run implements cmd.Command.
+func (c *secretRevokeCommand) Run(_ *cmd.Context) error {
+	return c.ctx.RevokeSecret(c.uri, c.marshallArg())
+}
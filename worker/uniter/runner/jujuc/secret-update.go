@@ -0,0 +1,70 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretUpdateCommand struct {
+	secretUpsertCommand
+
+	uri string
+}
+
+// NewSecretUpdateCommand returns a command to update a secret.
+func NewSecretUpdateCommand(ctx Context) (cmd.Command, error) {
+	return &secretUpdateCommand{
+		secretUpsertCommand: secretUpsertCommand{ctx: ctx},
+	}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretUpdateCommand) Info() *cmd.Info {
+	doc := `
+Update a secret with a list of key values, and/or update its metadata.
+
+If any key values are specified, a new revision of the secret is created.
+If a key has the '#base64' suffix, the value is already in base64 format and
+no encoding will be performed, otherwise the value will be base64 encoded
+prior to being stored.
+
+Examples:
+    secret-update secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a token=34ae35facd4
+    secret-update secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a --rotate 48h
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-update",
+		Args:    "<uri> [key[#base64]=value...]",
+		Purpose: "update an existing secret",
+		Doc:     doc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *secretUpdateCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if _, err := secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.uri = args[0]
+	if err := c.secretUpsertCommand.Init(args[1:]); err != nil {
+		return errors.Trace(err)
+	}
+	if len(c.data) == 0 && c.rotateInterval == 0 && c.description == "" {
+		return errors.New("must specify a new value or metadata to update a secret")
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *secretUpdateCommand) Run(_ *cmd.Context) error {
+	return c.ctx.UpdateSecret(c.uri, c.marshallArg())
+}
@@ -0,0 +1,261 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+const secretURI = "secret://5c4d08a6-7a74-4ec4-8a4c-5e6a38c3f8a1/c5ftls1a"
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) TestAddSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret value",
+		}, {
+			args: []string{"--rotate", "1m", "foo=bar"},
+			err:  `ERROR rotate interval "1m0s" less than 1h0m0s not valid`,
+		}, {
+			args: []string{"foo=bar", "foo=baz"},
+			err:  `ERROR duplicate key "foo"`,
+		}, {
+			args: []string{"foo"},
+			err:  `ERROR key value "foo" not valid`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+}
+
+func (s *SecretAddSuite) TestAddSecret(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"--rotate", "24h", "--description", "sssshhhh",
+		"data=secret", "token#base64=MTIz",
+	})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "secret://model-uuid/id\n")
+
+	interval := 24 * time.Hour
+	description := "sssshhhh"
+	s.Stub.CheckCallNames(c, "CreateSecret")
+	s.Stub.CheckCall(c, 0, "CreateSecret", &jujuc.SecretUpsertArgs{
+		Value: secrets.NewSecretValue(map[string]string{
+			"data":  "c2VjcmV0",
+			"token": "MTIz",
+		}),
+		RotateInterval: &interval,
+		Description:    &description,
+	})
+}
+
+type SecretUpdateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretUpdateSuite{})
+
+func (s *SecretUpdateSuite) TestUpdateSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret URI",
+		}, {
+			args: []string{"foo"},
+			err:  `ERROR secret URI scheme "" not valid`,
+		}, {
+			args: []string{secretURI},
+			err:  "ERROR must specify a new value or metadata to update a secret",
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-update"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+}
+
+func (s *SecretUpdateSuite) TestUpdateSecret(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-update"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		secretURI, "--description", "changed", "data=secret",
+	})
+	c.Assert(code, gc.Equals, 0)
+
+	description := "changed"
+	s.Stub.CheckCallNames(c, "UpdateSecret")
+	s.Stub.CheckCall(c, 0, "UpdateSecret", secretURI, &jujuc.SecretUpsertArgs{
+		Value:       secrets.NewSecretValue(map[string]string{"data": "c2VjcmV0"}),
+		Description: &description,
+	})
+}
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) TestGetSecret(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValue = secrets.NewSecretValue(map[string]string{
+		"cert": "Y2VydA==",
+		"key":  "a2V5",
+	})
+
+	for _, t := range []struct {
+		args []string
+		out  string
+		rev  int
+	}{
+		{
+			args: []string{secretURI},
+			out:  "cert: cert\nkey: key\n",
+		}, {
+			args: []string{secretURI, "--revision", "2", "key"},
+			out:  "key\n",
+			rev:  2,
+		}, {
+			args: []string{secretURI, "key#base64"},
+			out:  "a2V5\n",
+		}, {
+			args: []string{secretURI, "--format", "json"},
+			out:  `{"cert":"cert","key":"key"}` + "\n",
+		},
+	} {
+		s.Stub.ResetCalls()
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+		s.Stub.CheckCall(c, 0, "GetSecret", secretURI, t.rev)
+	}
+}
+
+func (s *SecretGetSuite) TestGetSecretMissingKey(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValue = secrets.NewSecretValue(map[string]string{"key": "a2V5"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{secretURI, "cert"})
+	c.Assert(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR secret key \"cert\" not found\n")
+}
+
+type SecretGrantRevokeSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantRevokeSuite{})
+
+func (s *SecretGrantRevokeSuite) TestGrantSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "", "")
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret URI",
+		}, {
+			args: []string{secretURI},
+			err:  "ERROR no relation id specified",
+		}, {
+			args: []string{secretURI, "-r", "0", "--unit", "foo"},
+			err:  `ERROR unit "foo" not valid`,
+		}, {
+			args: []string{secretURI, "-r", "0", "--role", "admin"},
+			err:  `ERROR secret role "admin" not valid`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+}
+
+func (s *SecretGrantRevokeSuite) TestGrantSecret(c *gc.C) {
+	hctx, _ := s.newHookContext(1, "", "")
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		secretURI, "-r", "1", "--unit", "mediawiki/6", "--role", "manage",
+	})
+	c.Assert(code, gc.Equals, 0)
+
+	relId := 1
+	unit := "mediawiki/6"
+	role := secrets.RoleManage
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "GrantSecret", secretURI, &jujuc.SecretGrantRevokeArgs{
+		RelationId: &relId,
+		UnitName:   &unit,
+		Role:       &role,
+	})
+}
+
+func (s *SecretGrantRevokeSuite) TestRevokeSecret(c *gc.C) {
+	hctx, _ := s.newHookContext(1, "", "")
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{secretURI})
+	c.Assert(code, gc.Equals, 0)
+
+	relId := 1
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "RevokeSecret", secretURI, &jujuc.SecretGrantRevokeArgs{
+		RelationId: &relId,
+	})
+}
@@ -82,6 +82,12 @@ var baseCommands = map[string]creator{
 	"state-get" + cmdSuffix:    NewStateGetCommand,
 	"state-delete" + cmdSuffix: NewStateDeleteCommand,
 	"state-set" + cmdSuffix:    NewStateSetCommand,
+
+	"secret-add" + cmdSuffix:    NewSecretAddCommand,
+	"secret-get" + cmdSuffix:    NewSecretGetCommand,
+	"secret-update" + cmdSuffix: NewSecretUpdateCommand,
+	"secret-grant" + cmdSuffix:  NewSecretGrantCommand,
+	"secret-revoke" + cmdSuffix: NewSecretRevokeCommand,
 }
 
 type functionCmdCreator func(Context, string) (cmd.Command, error)
@@ -110,6 +110,7 @@ type Context interface {
 	Id() string
 	HookVars(paths context.Paths, remote bool, getEnvFunc context.GetEnvFunc) ([]string, error)
 	ActionData() (*context.ActionData, error)
+	LogActionOutput(stream, output string) error
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
@@ -216,13 +217,16 @@ func (runner *runner) RunCommands(commands string, runLocation RunLocation) (*ut
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock, rMode, nil)
+	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock, rMode, nil, false)
 	return result, runner.context.Flush("run commands", err)
 }
 
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
-// juju-exec as an action
-func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock, rMode runMode, abort <-chan struct{}) (*utilexec.ExecResponse, error) {
+// juju-exec as an action. If forwardOutput is true, the output of the commands is
+// forwarded to the running action as it is written.
+func (runner *runner) runCommandsWithTimeout(
+	commands string, timeout time.Duration, clock clock.Clock, rMode runMode, abort <-chan struct{}, forwardOutput bool,
+) (*utilexec.ExecResponse, error) {
 	var err error
 	token := ""
 	if rMode == runOnRemote {
@@ -270,7 +274,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 		return nil, errors.Trace(err)
 	}
 	var stdout, stderr bytes.Buffer
-	return executor(ExecParams{
+	params := ExecParams{
 		Commands:      []string{commands},
 		Env:           env,
 		WorkingDir:    runner.paths.GetCharmDir(),
@@ -279,7 +283,76 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 		Cancel:        cancel,
 		Stdout:        &stdout,
 		Stderr:        &stderr,
-	})
+	}
+	if forwardOutput {
+		return runner.execForwardingOutput(executor, rMode, params)
+	}
+	return executor(params)
+}
+
+// execForwardingOutput runs the commands in params, forwarding their
+// output to the running action as it is written.
+func (runner *runner) execForwardingOutput(executor ExecFunc, rMode runMode, params ExecParams) (*utilexec.ExecResponse, error) {
+	outForwarder := runner.newActionOutputForwarder(actions.StdoutStream)
+	defer outForwarder.Stop()
+	errForwarder := runner.newActionOutputForwarder(actions.StderrStream)
+	defer errForwarder.Stop()
+
+	outReader, outWriter, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Errorf("cannot make stdout forwarding pipe: %v", err)
+	}
+	defer func() { _ = outWriter.Close() }()
+	errReader, errWriter, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Errorf("cannot make stderr forwarding pipe: %v", err)
+	}
+	defer func() { _ = errWriter.Close() }()
+
+	if rMode == runOnRemote {
+		// The remote executor reads the output back from the
+		// writers it is given, once it has stopped the loggers.
+		actionOut := &bufferAdaptor{ReadWriter: outWriter}
+		hookOutLogger := charmrunner.NewHookLogger(outReader, actionOut, outForwarder)
+		defer hookOutLogger.Stop()
+		go hookOutLogger.Run()
+
+		actionErr := &bufferAdaptor{ReadWriter: errWriter}
+		hookErrLogger := charmrunner.NewHookLogger(errReader, actionErr, errForwarder)
+		defer hookErrLogger.Stop()
+		go hookErrLogger.Run()
+
+		params.Stdout, params.StdoutLogger = actionOut, hookOutLogger
+		params.Stderr, params.StderrLogger = actionErr, hookErrLogger
+		return executor(params)
+	}
+
+	hookOutLogger := charmrunner.NewHookLogger(outReader, outForwarder)
+	defer hookOutLogger.Stop()
+	go hookOutLogger.Run()
+	hookErrLogger := charmrunner.NewHookLogger(errReader, errForwarder)
+	defer hookErrLogger.Stop()
+	go hookErrLogger.Run()
+
+	params.Stdout, params.Stderr = outWriter, errWriter
+	resp, err := streamOnMachine(params)
+
+	// Close the pipes so the loggers see all the output
+	// before they are stopped.
+	_ = outWriter.Close()
+	_ = errWriter.Close()
+	hookOutLogger.Stop()
+	hookErrLogger.Stop()
+	return resp, err
+}
+
+// newActionOutputForwarder returns a receiver which forwards the
+// output it receives to the running action's output on the given
+// stream.
+func (runner *runner) newActionOutputForwarder(stream string) *charmrunner.OutputForwarder {
+	return charmrunner.NewOutputForwarder(func(output string) error {
+		return runner.context.LogActionOutput(stream, output)
+	}, clock.WallClock)
 }
 
 // runJujuExecAction is the function that executes when a juju-exec action is ran.
@@ -312,7 +385,7 @@ func (runner *runner) runJujuExecAction() (err error) {
 		return errors.Trace(err)
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock, rMode, data.Cancel)
+	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock, rMode, data.Cancel, true)
 	if results != nil {
 		if err := runner.updateActionResults(results); err != nil {
 			return runner.context.Flush("juju-exec", err)
@@ -558,6 +631,14 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 		)
 		defer hookErrLogger.Stop()
 		go hookErrLogger.Run()
+
+		// Forward the action's output as it is written.
+		outForwarder := runner.newActionOutputForwarder(actions.StdoutStream)
+		defer outForwarder.Stop()
+		hookOutLogger.AddReceiver(outForwarder)
+		errForwarder := runner.newActionOutputForwarder(actions.StderrStream)
+		defer errForwarder.Stop()
+		hookErrLogger.AddReceiver(errForwarder)
 	}
 
 	executor, err := runner.getExecutor(runOnRemote)
@@ -622,6 +703,7 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	var cancel <-chan struct{}
 	var actionOut *bufferAdaptor
 	var actionErr *bufferAdaptor
+	var outForwarder, errForwarder *charmrunner.OutputForwarder
 	actionData, err := runner.context.ActionData()
 	runningAction := err == nil && actionData != nil
 	if runningAction {
@@ -630,6 +712,14 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		actionErr = &bufferAdaptor{ReadWriter: errWriter}
 		hookErrLogger.AddReceiver(actionErr)
 		cancel = actionData.Cancel
+
+		// Forward the action's output as it is written.
+		outForwarder = runner.newActionOutputForwarder(actions.StdoutStream)
+		defer outForwarder.Stop()
+		hookOutLogger.AddReceiver(outForwarder)
+		errForwarder = runner.newActionOutputForwarder(actions.StderrStream)
+		defer errForwarder.Stop()
+		hookErrLogger.AddReceiver(errForwarder)
 	}
 
 	err = ps.Start()
@@ -659,8 +749,11 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	hookOutLogger.Stop()
 	hookErrLogger.Stop()
 
-	// If we are running an action, record stdout and stderr.
+	// If we are running an action, record stdout and stderr, once
+	// any output not yet forwarded has been.
 	if runningAction {
+		outForwarder.Stop()
+		errForwarder.Stop()
 		resp := &utilexec.ExecResponse{
 			Code:   ps.ProcessState.ExitCode(),
 			Stdout: actionOut.Bytes(),
@@ -224,6 +224,7 @@ type MockContext struct {
 	actionParams    map[string]interface{}
 	actionParamsErr error
 	actionResults   map[string]interface{}
+	actionOutput    []string
 	expectPid       int
 	flushBadge      string
 	flushFailure    error
@@ -258,6 +259,11 @@ func (ctx *MockContext) ActionData() (*context.ActionData, error) {
 	return ctx.actionData, ctx.actionDataErr
 }
 
+func (ctx *MockContext) LogActionOutput(stream, output string) error {
+	ctx.actionOutput = append(ctx.actionOutput, stream+": "+output)
+	return nil
+}
+
 func (ctx *MockContext) SetProcess(process context.HookProcess) {
 	ctx.expectPid = process.Pid()
 }
@@ -510,6 +516,26 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunActionForwardsOutput(c *gc.C) {
+	params := map[string]interface{}{
+		"command": "echo 1\necho 2 >&2",
+		"timeout": 0,
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{
+			Params: params,
+		},
+		actionParams:  params,
+		actionResults: map[string]interface{}{},
+	}
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunAction("juju-exec")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["stdout"], gc.Equals, "1\n")
+	c.Assert(ctx.actionResults["stderr"], gc.Equals, "2\n")
+	c.Assert(ctx.actionOutput, jc.SameContents, []string{"stdout: 1\n", "stderr: 2\n"})
+}
+
 func (s *RunMockContextSuite) TestRunActionError(c *gc.C) {
 	params := map[string]interface{}{
 		"command": "echo 1\nexit 3",
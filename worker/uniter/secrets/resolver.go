@@ -0,0 +1,86 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// Logger is here to stop the desire of creating a package level Logger.
+// Don't do this, instead use the one passed into the NewResolver as needed.
+var logger interface{}
+
+// Logger represents the logging methods used by the secrets resolver.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+type secretsResolver struct {
+	logger        Logger
+	secretRotated func(string) error
+}
+
+// NewSecretsResolver returns a new Resolver that runs the secret-rotate
+// hook for any secrets due to be rotated. The secretRotated callback is
+// invoked once the hook for a secret has been committed.
+func NewSecretsResolver(logger Logger, secretRotated func(string) error) resolver.Resolver {
+	return &secretsResolver{
+		logger:        logger,
+		secretRotated: secretRotated,
+	}
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (s *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	// Nothing to do if not yet installed or if the unit is dying.
+	if !localState.Installed || remoteState.Life != life.Alive {
+		return nil, resolver.ErrNoOperation
+	}
+	// Only the leader rotates secrets.
+	if !remoteState.Leader || localState.Kind != operation.Continue {
+		return nil, resolver.ErrNoOperation
+	}
+	if len(remoteState.SecretRotations) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	uri := remoteState.SecretRotations[0]
+	s.logger.Debugf("%s: rotate secret", uri)
+	op, err := opFactory.NewRunHook(hook.Info{
+		Kind:      hook.SecretRotate,
+		SecretURI: uri,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &rotateSecretCompleter{
+		Operation: op,
+		rotated: func() error {
+			return s.secretRotated(uri)
+		},
+	}, nil
+}
+
+type rotateSecretCompleter struct {
+	operation.Operation
+	rotated func() error
+}
+
+// Commit is part of the operation.Operation interface.
+func (c *rotateSecretCompleter) Commit(st operation.State) (*operation.State, error) {
+	result, err := c.Operation.Commit(st)
+	if err == nil {
+		err = c.rotated()
+	}
+	return result, errors.Trace(err)
+}
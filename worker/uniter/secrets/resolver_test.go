@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+const secretURI = "secret://deadbeef-0bad-400d-8000-4b1d0d06f00d/a"
+
+type resolverSuite struct {
+	jujutesting.IsolationSuite
+
+	rotated     []string
+	rotateErr   error
+	opFactory   *mockOpFactory
+	resolver    resolver.Resolver
+	localState  resolver.LocalState
+	remoteState remotestate.Snapshot
+}
+
+var _ = gc.Suite(&resolverSuite{})
+
+func (s *resolverSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.reset()
+}
+
+func (s *resolverSuite) reset() {
+	s.rotated = nil
+	s.rotateErr = nil
+	s.opFactory = &mockOpFactory{}
+	s.resolver = secrets.NewSecretsResolver(loggo.GetLogger("test"), func(uri string) error {
+		s.rotated = append(s.rotated, uri)
+		return s.rotateErr
+	})
+	s.localState = resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+		},
+	}
+	s.remoteState = remotestate.Snapshot{
+		Life:            life.Alive,
+		Leader:          true,
+		SecretRotations: []string{secretURI},
+	}
+}
+
+func (s *resolverSuite) TestRotateHook(c *gc.C) {
+	op, err := s.resolver.NextOp(s.localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	s.opFactory.CheckCall(c, 0, "NewRunHook", hook.Info{
+		Kind:      hook.SecretRotate,
+		SecretURI: secretURI,
+	})
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rotated, jc.DeepEquals, []string{secretURI})
+}
+
+func (s *resolverSuite) TestRotateCallbackError(c *gc.C) {
+	s.rotateErr = errors.New("boom")
+	op, err := s.resolver.NextOp(s.localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *resolverSuite) TestRotateCommitErrorNoCallback(c *gc.C) {
+	s.opFactory.commitErr = errors.New("commit failed")
+	op, err := s.resolver.NextOp(s.localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, gc.ErrorMatches, "commit failed")
+	c.Assert(s.rotated, gc.HasLen, 0)
+}
+
+func (s *resolverSuite) TestNoOperation(c *gc.C) {
+	for i, mutate := range []func(){
+		func() { s.remoteState.SecretRotations = nil },
+		func() { s.remoteState.Leader = false },
+		func() { s.remoteState.Life = life.Dying },
+		func() { s.localState.Installed = false },
+		func() { s.localState.Kind = operation.RunHook },
+	} {
+		c.Logf("test %d", i)
+		s.reset()
+		mutate()
+		_, err := s.resolver.NextOp(s.localState, s.remoteState, s.opFactory)
+		c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	}
+}
+
+type mockOpFactory struct {
+	jujutesting.Stub
+	operation.Factory
+
+	commitErr error
+}
+
+func (f *mockOpFactory) NewRunHook(info hook.Info) (operation.Operation, error) {
+	f.MethodCall(f, "NewRunHook", info)
+	return &mockOp{commitErr: f.commitErr}, f.NextErr()
+}
+
+type mockOp struct {
+	operation.Operation
+	commitErr error
+}
+
+func (op *mockOp) Commit(st operation.State) (*operation.State, error) {
+	return &st, op.commitErr
+}
@@ -132,6 +132,13 @@ func (a *Attachments) init() error {
 				location: attachment.Location,
 			}
 		newStateStorage.Attach(storageTag.Id())
+		// Storage attached before sizes were recorded is
+		// taken to have been reported at its current size.
+		size, ok := existingStorageState.Size(storageTag.Id())
+		if !ok {
+			size = attachment.Size
+		}
+		newStateStorage.SetSize(storageTag.Id(), size)
 	}
 	a.storageState = newStateStorage
 	if a.storageState.Empty() {
@@ -194,7 +201,7 @@ func (a *Attachments) ValidateHook(hi hook.Info) error {
 // CommitHook persists the State change encoded in the supplied storage
 // hook, or returns an error if the hook is invalid given current State.
 func (a *Attachments) CommitHook(hi hook.Info) error {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return errors.Errorf("not a storage hook: %#v", hi)
 	}
 	if hi.Kind == hooks.StorageDetaching {
@@ -204,6 +211,9 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 		}
 	} else {
 		a.storageState.Attach(hi.StorageId)
+		if hi.StorageSize > 0 {
+			a.storageState.SetSize(hi.StorageId, hi.StorageSize)
+		}
 	}
 	if err := a.stateOps.Write(a.storageState); err != nil {
 		return err
@@ -101,6 +101,7 @@ func (s *attachmentsSuite) assertNewAttachments(c *gc.C, storageTag names.Storag
 		Life:       life.Alive,
 		Kind:       params.StorageKindBlock,
 		Location:   "/dev/sdb",
+		Size:       1024,
 	}
 
 	storSt := &mockStorageAccessor{
@@ -139,6 +140,7 @@ func (s *attachmentsSuite) TestNewAttachmentsInit(c *gc.C) {
 	defer s.mockStateOpsSuite.setupMocks(c).Finish()
 	storageTag := names.NewStorageTag("data/0")
 	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetSize(storageTag.Id(), 1024)
 	s.expectSetState(c, "")
 	// Setup a storage tag which should be ignored by init.
 	s.storSt.Attach("data/3")
@@ -162,6 +164,100 @@ func (s *attachmentsSuite) TestNewAttachmentsInit(c *gc.C) {
 	assertStorageTags(c, att, storageTag)
 }
 
+func resizedSnapshot(storageTag names.StorageTag, size uint64) remotestate.Snapshot {
+	return remotestate.Snapshot{
+		Life: life.Alive,
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: {
+				Kind:     params.StorageKindBlock,
+				Life:     life.Alive,
+				Location: "/dev/sdb",
+				Attached: true,
+				Size:     size,
+			},
+		},
+	}
+}
+
+var startedLocalState = resolver.LocalState{State: operation.State{
+	Kind:      operation.Continue,
+	Installed: true,
+	Started:   true,
+}}
+
+func (s *attachmentsSuite) TestAttachmentsStorageResized(c *gc.C) {
+	defer s.mockStateOpsSuite.setupMocks(c).Finish()
+	storageTag := names.NewStorageTag("data/0")
+	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetSize(storageTag.Id(), 1024)
+	s.expectSetState(c, "")
+	s.expectState(c)
+
+	att := s.assertNewAttachments(c, storageTag)
+	r := storage.NewResolver(loggo.GetLogger("test"), att, s.modelType)
+
+	// No change in size, so no hook.
+	_, err := r.NextOp(startedLocalState, resizedSnapshot(storageTag, 1024), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	op, err := r.NextOp(startedLocalState, resizedSnapshot(storageTag, 2048), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+	hi := op.(*mockOperation).hookInfo
+	c.Assert(hi, jc.DeepEquals, hook.Info{
+		Kind:        hook.StorageResized,
+		StorageId:   storageTag.Id(),
+		StorageSize: 2048,
+	})
+	c.Assert(att.ValidateHook(hi), jc.ErrorIsNil)
+
+	// Until the hook is committed, it is offered again.
+	op, err = r.NextOp(startedLocalState, resizedSnapshot(storageTag, 2048), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+
+	// Committing the hook records the reported size,
+	// so it is not reported again.
+	s.storSt.SetSize(storageTag.Id(), 2048)
+	s.expectSetState(c, "")
+	c.Assert(att.CommitHook(hi), jc.ErrorIsNil)
+	_, err = r.NextOp(startedLocalState, resizedSnapshot(storageTag, 2048), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *attachmentsSuite) TestAttachmentsStorageResizedWhileStopped(c *gc.C) {
+	defer s.mockStateOpsSuite.setupMocks(c).Finish()
+	storageTag := names.NewStorageTag("data/0")
+	// The charm was last told the storage was 512MiB,
+	// but it is now 1024MiB.
+	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetSize(storageTag.Id(), 512)
+	s.expectSetState(c, "")
+	s.expectState(c)
+
+	att := s.assertNewAttachments(c, storageTag)
+	r := storage.NewResolver(loggo.GetLogger("test"), att, s.modelType)
+	op, err := r.NextOp(startedLocalState, resizedSnapshot(storageTag, 1024), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+}
+
+func (s *attachmentsSuite) TestAttachmentsCommitStorageAttachedRecordsSize(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	storageTag := names.NewStorageTag("data/0")
+	att := s.assertNewAttachments(c, storageTag)
+
+	hi := hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   storageTag.Id(),
+		StorageSize: 1024,
+	}
+	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetSize(storageTag.Id(), 1024)
+	s.expectSetState(c, "")
+	c.Assert(att.CommitHook(hi), jc.ErrorIsNil)
+}
+
 func (s *attachmentsSuite) TestAttachmentsUpdateShortCircuitDeath(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
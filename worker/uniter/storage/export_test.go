@@ -6,3 +6,7 @@ package storage
 func Storage(st *State) map[string]bool {
 	return st.storage
 }
+
+func MarshalState(st *State) (string, error) {
+	return st.marshal()
+}
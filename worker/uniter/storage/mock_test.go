@@ -41,15 +41,16 @@ type mockOperations struct {
 }
 
 func (m *mockOperations) NewUpdateStorage(tags []names.StorageTag) (operation.Operation, error) {
-	return &mockOperation{"update storage"}, nil
+	return &mockOperation{name: "update storage"}, nil
 }
 
 func (m *mockOperations) NewRunHook(hookInfo hook.Info) (operation.Operation, error) {
-	return &mockOperation{fmt.Sprintf("run hook %v", hookInfo.Kind)}, nil
+	return &mockOperation{name: fmt.Sprintf("run hook %v", hookInfo.Kind), hookInfo: hookInfo}, nil
 }
 
 type mockOperation struct {
-	name string
+	name     string
+	hookInfo hook.Info
 }
 
 func (m *mockOperation) String() string {
@@ -183,8 +183,8 @@ func (s *storageResolver) nextHookOp(
 		attached, ok := s.storage.storageState.Attached(tag.Id())
 		if ok && attached {
 			// Once the storage is attached, we only care about
-			// lifecycle State changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle State changes, and the storage growing.
+			return s.maybeResized(tag, snap, opFactory)
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -198,6 +198,7 @@ func (s *storageResolver) nextHookOp(
 		// The storage is alive, but we haven't previously run the
 		// "storage-attached" hook. Do so now.
 		hookInfo.Kind = hooks.StorageAttached
+		hookInfo.StorageSize = snap.Size
 	case life.Dying:
 		attached, ok := s.storage.storageState.Attached(tag.Id())
 		if !ok || !attached {
@@ -221,3 +222,23 @@ func (s *storageResolver) nextHookOp(
 
 	return opFactory.NewRunHook(hookInfo)
 }
+
+// maybeResized returns an operation to run the "storage-resized" hook
+// if the attached storage has grown since its size was last reported
+// to the charm. The reported size is only recorded when the hook is
+// committed, so a hook which fails or is interrupted is run again.
+func (s *storageResolver) maybeResized(
+	tag names.StorageTag,
+	snap remotestate.StorageSnapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	reported, ok := s.storage.storageState.Size(tag.Id())
+	if !ok || snap.Size <= reported {
+		return nil, resolver.ErrNoOperation
+	}
+	return opFactory.NewRunHook(hook.Info{
+		Kind:        hook.StorageResized,
+		StorageId:   tag.Id(),
+		StorageSize: snap.Size,
+	})
+}
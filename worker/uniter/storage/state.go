@@ -18,6 +18,11 @@ type State struct {
 	// key is the storage tag id, the value is attached
 	// or not.
 	storage map[string]bool
+
+	// sizes holds the size, in MiB, of attached storage
+	// last reported to the charm by a storage-attached or
+	// storage-resized hook, keyed on the storage tag id.
+	sizes map[string]uint64
 }
 
 func (s *State) Detach(storageID string) error {
@@ -25,6 +30,7 @@ func (s *State) Detach(storageID string) error {
 		return errors.NotFoundf("storage %q", storageID)
 	}
 	s.storage[storageID] = false
+	delete(s.sizes, storageID)
 	return nil
 }
 
@@ -37,12 +43,66 @@ func (s *State) Attached(storageID string) (bool, bool) {
 	return attached, ok
 }
 
+// Size returns the size of the storage last reported to the charm,
+// and whether it is known.
+func (s *State) Size(storageID string) (uint64, bool) {
+	size, ok := s.sizes[storageID]
+	return size, ok
+}
+
+// SetSize records the size of the storage reported to the charm.
+func (s *State) SetSize(storageID string, size uint64) {
+	s.sizes[storageID] = size
+}
+
 func (s *State) Empty() bool {
 	return len(s.storage) == 0
 }
 
 func NewState() *State {
-	return &State{storage: make(map[string]bool)}
+	return &State{
+		storage: make(map[string]bool),
+		sizes:   make(map[string]uint64),
+	}
+}
+
+// stateDoc is the serialised form of State. Storage State written
+// before sizes were recorded is a bare map of attachments.
+type stateDoc struct {
+	Attached map[string]bool   `yaml:"attached"`
+	Sizes    map[string]uint64 `yaml:"sizes,omitempty"`
+}
+
+// marshal returns the serialised form of the State, or
+// the empty string if there are no storage attachments.
+func (s *State) marshal() (string, error) {
+	if len(s.storage) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(stateDoc{Attached: s.storage, Sizes: s.sizes})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// unmarshalState returns the State serialised in data, which may
+// be in either the current or the original format.
+func unmarshalState(data string) (*State, error) {
+	var doc stateDoc
+	if err := yaml.Unmarshal([]byte(data), &doc); err == nil && doc.Attached != nil {
+		st := NewState()
+		st.storage = doc.Attached
+		for id, size := range doc.Sizes {
+			st.sizes[id] = size
+		}
+		return st, nil
+	}
+	st := NewState()
+	if err := yaml.Unmarshal([]byte(data), &st.storage); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st, nil
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -58,7 +118,7 @@ func (s *State) ValidateHook(hi hook.Info) (err error) {
 		if attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized:
 		if !attached {
 			return errors.New("storage not attached")
 		}
@@ -86,7 +146,6 @@ func NewStateOps(rw UnitStateReadWriter) *stateOps {
 // Read reads a storage State from the controller. If the saved State
 // does not exist it returns NotFound and a new state.
 func (f *stateOps) Read() (*State, error) {
-	var stor map[string]bool
 	unitState, err := f.unitStateRW.State()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -94,10 +153,11 @@ func (f *stateOps) Read() (*State, error) {
 	if unitState.StorageState == "" {
 		return NewState(), errors.NotFoundf("storage State")
 	}
-	if err = yaml.Unmarshal([]byte(unitState.StorageState), &stor); err != nil {
+	st, err := unmarshalState(unitState.StorageState)
+	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &State{storage: stor}, nil
+	return st, nil
 }
 
 // Write stores the supplied State storage map on the controller.  If
@@ -106,13 +166,9 @@ func (f *stateOps) Write(st *State) error {
 	if st == nil {
 		return errors.Trace(errors.BadRequestf("arg is nil"))
 	}
-	var str string
-	if len(st.storage) > 0 {
-		data, err := yaml.Marshal(st.storage)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		str = string(data)
+	str, err := st.marshal()
+	if err != nil {
+		return errors.Trace(err)
 	}
 	return f.unitStateRW.SetState(params.SetUnitStateArg{StorageState: &str})
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	corecharm "github.com/juju/charm/v9"
 	"github.com/juju/clock"
@@ -43,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 	"github.com/juju/juju/worker/uniter/upgradeseries"
 	"github.com/juju/juju/worker/uniter/verifycharmprofile"
@@ -136,6 +138,13 @@ type Uniter struct {
 	// containerRunningStatusFunc is used to determine the unit's running status.
 	containerRunningStatusFunc remotestate.ContainerRunningStatusFunc
 
+	// secretRotateWatcherFunc is used to start a watcher which signals
+	// when secrets owned by the unit's application need to be rotated.
+	secretRotateWatcherFunc remotestate.SecretRotateWatcherFunc
+
+	// secretsClient provides access to the secrets backend.
+	secretsClient SecretsClient
+
 	// remoteInitFunc is used to init remote charm state.
 	remoteInitFunc RemoteInitFunc
 
@@ -155,6 +164,12 @@ type Uniter struct {
 	logger        Logger
 }
 
+// SecretsClient provides access to the secrets manager facade.
+type SecretsClient interface {
+	context.SecretsAccessor
+	SecretRotated(uri string, when time.Time) error
+}
+
 // UniterParams hold all the necessary parameters for a new Uniter.
 type UniterParams struct {
 	UniterFacade                  *uniter.State
@@ -178,6 +193,8 @@ type UniterParams struct {
 	ApplicationChannel            watcher.NotifyChannel
 	ContainerRunningStatusChannel watcher.NotifyChannel
 	ContainerRunningStatusFunc    remotestate.ContainerRunningStatusFunc
+	SecretRotateWatcherFunc       remotestate.SecretRotateWatcherFunc
+	SecretsClient                 SecretsClient
 	IsRemoteUnit                  bool
 	SocketConfig                  *SocketConfig
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
@@ -247,6 +264,8 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 			applicationChannel:            uniterParams.ApplicationChannel,
 			containerRunningStatusChannel: uniterParams.ContainerRunningStatusChannel,
 			containerRunningStatusFunc:    uniterParams.ContainerRunningStatusFunc,
+			secretRotateWatcherFunc:       uniterParams.SecretRotateWatcherFunc,
+			secretsClient:                 uniterParams.SecretsClient,
 			isRemoteUnit:                  uniterParams.IsRemoteUnit,
 			runListener:                   uniterParams.RunListener,
 			rebootQuerier:                 uniterParams.RebootQuerier,
@@ -367,6 +386,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				ApplicationChannel:            u.applicationChannel,
 				ContainerRunningStatusChannel: u.containerRunningStatusChannel,
 				ContainerRunningStatusFunc:    u.containerRunningStatusFunc,
+				SecretRotateWatcherFunc:       u.secretRotateWatcherFunc,
 				ModelType:                     u.modelType,
 				Logger:                        u.logger.Child("remotestate"),
 				CanApplyCharmProfile:          canApplyCharmProfile,
@@ -459,6 +479,18 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		if u.modelType == model.CAAS && u.isRemoteUnit {
 			cfg.Container = container.NewResolver()
 		}
+		if u.secretsClient != nil {
+			cfg.Secrets = secrets.NewSecretsResolver(
+				u.logger.Child("secrets"),
+				func(uri string) error {
+					if err := u.secretsClient.SecretRotated(uri, u.clock.Now()); err != nil {
+						return errors.Annotatef(err, "recording rotation of secret %q", uri)
+					}
+					watcher.RotateSecretCompleted(uri)
+					return nil
+				},
+			)
+		}
 		uniterResolver := NewUniterResolver(cfg)
 
 		// We should not do anything until there has been a change
@@ -780,6 +812,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Tracker:          u.leadershipTracker,
 		GetRelationInfos: u.relationStateTracker.GetInfo,
 		Storage:          u.storage,
+		SecretsClient:    u.secretsClient,
 		Paths:            u.paths,
 		Clock:            u.clock,
 		Logger:           u.logger.Child("context"),